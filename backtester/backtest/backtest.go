@@ -192,6 +192,7 @@ func NewFromConfig(cfg *config.Config, templatePath, output string, bot *engine.
 		StrategyGoal:                cfg.Goal,
 		ExchangeAssetPairStatistics: make(map[string]map[asset.Item]map[currency.Pair]*currencystatistics.CurrencyStatistic),
 		RiskFreeRate:                cfg.StatisticSettings.RiskFreeRate,
+		ReportingCurrency:           currency.NewCode(cfg.StatisticSettings.ReportingCurrency),
 	}
 	bt.Statistic = stats
 	reports.Statistics = stats
@@ -54,7 +54,8 @@ type StrategySettings struct {
 // StatisticSettings holds configurable varialbes to adjust ratios where
 // proper data is currently lacking
 type StatisticSettings struct {
-	RiskFreeRate float64 `json:"risk-free-rate"`
+	RiskFreeRate      float64 `json:"risk-free-rate"`
+	ReportingCurrency string  `json:"reporting-currency,omitempty"`
 }
 
 // PortfolioSettings act as a global protector for strategies
@@ -9,6 +9,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 )
 
 // CurrencyStats defines what is expected in order to
@@ -52,9 +53,21 @@ type CurrencyStatistic struct {
 	TotalOrders              int64                 `json:"total-orders"`
 	FinalHoldings            holdings.Holding      `json:"final-holdings"`
 	FinalOrders              compliance.Snapshot   `json:"final-orders"`
+	ReportingValue           *ReportingValue       `json:"reporting-value,omitempty"`
 	ShowMissingDataWarning   bool                  `json:"-"`
 }
 
+// ReportingValue is the total value of the final holdings converted into the
+// reporting currency, along with the path and age of the rates used
+type ReportingValue struct {
+	Currency   currency.Code `json:"currency"`
+	Value      float64       `json:"value"`
+	Rate       float64       `json:"rate"`
+	Path       string        `json:"path"`
+	Confidence float64       `json:"confidence"`
+	Timestamp  time.Time     `json:"timestamp"`
+}
+
 // Ratios stores all the ratios used for statistics
 type Ratios struct {
 	SharpeRatio      float64 `json:"sharpe-ratio"`
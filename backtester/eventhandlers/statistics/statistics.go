@@ -3,6 +3,8 @@ package statistics
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/compliance"
@@ -13,7 +15,9 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/currency/valuation"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -148,6 +152,7 @@ func (s *Statistic) CalculateAllResults() error {
 	s.PrintAllEvents()
 	currCount := 0
 	var finalResults []FinalResultsHolder
+	var allStats []*currencystatistics.CurrencyStatistic
 	for exchangeName, exchangeMap := range s.ExchangeAssetPairStatistics {
 		for assetItem, assetMap := range exchangeMap {
 			for pair, stats := range assetMap {
@@ -160,7 +165,7 @@ func (s *Statistic) CalculateAllResults() error {
 				last := stats.Events[len(stats.Events)-1]
 				stats.FinalHoldings = last.Holdings
 				stats.FinalOrders = last.Transactions
-				s.AllStats = append(s.AllStats, *stats)
+				allStats = append(allStats, stats)
 
 				finalResults = append(finalResults, FinalResultsHolder{
 					Exchange:         exchangeName,
@@ -178,6 +183,10 @@ func (s *Statistic) CalculateAllResults() error {
 			}
 		}
 	}
+	s.valueFinalHoldings(allStats)
+	for i := range allStats {
+		s.AllStats = append(s.AllStats, *allStats[i])
+	}
 	s.TotalOrders = s.TotalBuyOrders + s.TotalSellOrders
 	if currCount > 1 {
 		s.BiggestDrawdown = s.GetTheBiggestDrawdownAcrossCurrencies(finalResults)
@@ -189,6 +198,100 @@ func (s *Statistic) CalculateAllResults() error {
 	return nil
 }
 
+// forexRate returns the rate to convert one unit of a fiat currency into
+// another on the supplied date
+var forexRate = func(from, to currency.Code, at time.Time) (float64, error) {
+	return currency.ConvertCurrencyAt(1, from, to, at)
+}
+
+// valueFinalHoldings values the final holdings of each exchange asset pair in
+// the reporting currency. Rates are taken from the closing prices of every
+// pair at the end of the backtest, linked to the reporting currency through
+// pegged stablecoins and forex rates
+func (s *Statistic) valueFinalHoldings(stats []*currencystatistics.CurrencyStatistic) {
+	if s.ReportingCurrency.IsEmpty() {
+		s.ReportingCurrency = currency.USD
+	}
+	s.TotalReportingValue = 0
+	var oldest, end time.Time
+	fiat := make(map[*currency.Item]currency.Code)
+	if s.ReportingCurrency.IsFiatCurrency() {
+		fiat[s.ReportingCurrency.Item] = s.ReportingCurrency
+	}
+	var tickers []*ticker.Price
+	for i := range stats {
+		last := stats[i].Events[len(stats[i].Events)-1].DataEvent
+		if last == nil {
+			continue
+		}
+		tickers = append(tickers, &ticker.Price{
+			ExchangeName: last.GetExchange(),
+			Pair:         last.Pair(),
+			AssetType:    last.GetAssetType(),
+			Last:         last.ClosePrice(),
+			LastUpdated:  last.GetTime(),
+		})
+		if oldest.IsZero() || last.GetTime().Before(oldest) {
+			oldest = last.GetTime()
+		}
+		if last.GetTime().After(end) {
+			end = last.GetTime()
+		}
+		if last.Pair().Quote.IsFiatCurrency() {
+			fiat[last.Pair().Quote.Item] = last.Pair().Quote
+		}
+	}
+
+	// closing prices are only stale relative to the end of the backtest
+	g := valuation.NewGraph(time.Since(oldest) + time.Minute)
+	for i := range tickers {
+		if tickers[i].Pair.Base.Item == tickers[i].Pair.Quote.Item {
+			continue
+		}
+		if err := g.AddTicker(tickers[i]); err != nil {
+			log.Warnf(log.BackTester, "unable to use %v %v %v closing price for valuation: %v", tickers[i].ExchangeName, tickers[i].AssetType, tickers[i].Pair, err)
+		}
+	}
+	if err := g.AddPegs(end); err != nil {
+		log.Warnf(log.BackTester, "unable to link stablecoins for valuation: %v", err)
+	}
+	for _, c := range fiat {
+		if c.Item == currency.USD.Item {
+			continue
+		}
+		rate, err := forexRate(currency.USD, c, end)
+		if err == nil {
+			err = g.AddForexRate(currency.USD, c, rate, end)
+		}
+		if err != nil {
+			log.Warnf(log.BackTester, "unable to link %v to %v for valuation: %v", c, currency.USD, err)
+		}
+	}
+
+	for i := range stats {
+		h := stats[i].FinalHoldings
+		v, err := g.Value(h.TotalValue, h.Pair.Quote, s.ReportingCurrency)
+		if err != nil {
+			log.Warnf(log.BackTester, "unable to value %v %v %v holdings in %v: %v", h.Exchange, h.Asset, h.Pair, s.ReportingCurrency, err)
+			continue
+		}
+		path := make([]string, 0, len(v.Path)+1)
+		path = append(path, h.Pair.Quote.String())
+		for j := range v.Path {
+			path = append(path, v.Path[j].To.String())
+		}
+		stats[i].ReportingValue = &currencystatistics.ReportingValue{
+			Currency:   s.ReportingCurrency,
+			Value:      v.Value,
+			Rate:       v.Rate,
+			Path:       strings.Join(path, " > "),
+			Confidence: v.Confidence,
+			Timestamp:  v.Timestamp,
+		}
+		s.TotalReportingValue += v.Value
+	}
+}
+
 // PrintTotalResults outputs all results to the CMD
 func (s *Statistic) PrintTotalResults() {
 	log.Info(log.BackTester, "------------------Strategy-----------------------------------")
@@ -199,7 +302,8 @@ func (s *Statistic) PrintTotalResults() {
 	log.Info(log.BackTester, "------------------Orders----------------------------------")
 	log.Infof(log.BackTester, "Total buy orders: %v", s.TotalBuyOrders)
 	log.Infof(log.BackTester, "Total sell orders: %v", s.TotalSellOrders)
-	log.Infof(log.BackTester, "Total orders: %v\n\n", s.TotalOrders)
+	log.Infof(log.BackTester, "Total orders: %v", s.TotalOrders)
+	log.Infof(log.BackTester, "Total value: %.2f %v\n\n", s.TotalReportingValue, s.ReportingCurrency)
 
 	if s.BiggestDrawdown != nil {
 		log.Info(log.BackTester, "------------------Biggest Drawdown------------------------")
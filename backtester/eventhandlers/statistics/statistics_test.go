@@ -719,3 +719,68 @@ func TestCalculateTheResults(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestValueFinalHoldings(t *testing.T) {
+	rate := forexRate
+	forexRate = func(from, to currency.Code, _ time.Time) (float64, error) {
+		if from.Item != currency.USD.Item || to.Item != currency.EUR.Item {
+			return 0, errors.New("unexpected forex conversion")
+		}
+		return 0.5, nil
+	}
+	defer func() { forexRate = rate }()
+
+	end := time.Now().Add(-time.Hour * 24 * 30)
+	finalStats := func(p currency.Pair, closePrice, totalValue float64) *currencystatistics.CurrencyStatistic {
+		return &currencystatistics.CurrencyStatistic{
+			Events: []currencystatistics.EventStore{{
+				DataEvent: &kline.Kline{
+					Base: event.Base{
+						Exchange:     testExchange,
+						Time:         end,
+						Interval:     gctkline.OneDay,
+						CurrencyPair: p,
+						AssetType:    asset.Spot,
+					},
+					Close: closePrice,
+				},
+			}},
+			FinalHoldings: holdings.Holding{
+				Exchange:   testExchange,
+				Asset:      asset.Spot,
+				Pair:       p,
+				TotalValue: totalValue,
+			},
+		}
+	}
+	btc := finalStats(currency.NewPair(currency.BTC, currency.USDT), 10000, 20000)
+	xrp := finalStats(currency.NewPair(currency.XRP, currency.BTC), 0.00002, 1)
+	doge := finalStats(currency.NewPair(currency.DOGE, currency.LTC), 0.001, 5)
+
+	s := Statistic{ReportingCurrency: currency.EUR}
+	s.valueFinalHoldings([]*currencystatistics.CurrencyStatistic{btc, xrp, doge})
+	if btc.ReportingValue == nil || btc.ReportingValue.Value != 10000 {
+		t.Fatalf("expected BTC-USDT holdings to be valued at 10000 EUR, received %+v", btc.ReportingValue)
+	}
+	if btc.ReportingValue.Path != "USDT > USD > EUR" {
+		t.Errorf("received '%v' expected '%v'", btc.ReportingValue.Path, "USDT > USD > EUR")
+	}
+	if !btc.ReportingValue.Timestamp.Equal(end) {
+		t.Errorf("received '%v' expected '%v'", btc.ReportingValue.Timestamp, end)
+	}
+	if xrp.ReportingValue == nil || xrp.ReportingValue.Value != 5000 {
+		t.Fatalf("expected XRP-BTC holdings to be valued at 5000 EUR, received %+v", xrp.ReportingValue)
+	}
+	if doge.ReportingValue != nil {
+		t.Errorf("expected holdings without a conversion path to be left unvalued, received %+v", doge.ReportingValue)
+	}
+	if s.TotalReportingValue != 15000 {
+		t.Errorf("received '%v' expected '%v'", s.TotalReportingValue, 15000)
+	}
+
+	s = Statistic{}
+	s.valueFinalHoldings([]*currencystatistics.CurrencyStatistic{btc})
+	if s.ReportingCurrency != currency.USD || s.TotalReportingValue != 20000 {
+		t.Errorf("expected holdings to be valued in USD by default, received %v %v", s.TotalReportingValue, s.ReportingCurrency)
+	}
+}
@@ -36,6 +36,8 @@ type Statistic struct {
 	BestMarketMovement          *FinalResultsHolder                                                               `json:"best-market-movement,omitempty"`
 	AllStats                    []currencystatistics.CurrencyStatistic                                            `json:"results"` // as ExchangeAssetPairStatistics cannot be rendered via json.Marshall, we append all result to this slice instead
 	WasAnyDataMissing           bool                                                                              `json:"was-any-data-missing"`
+	ReportingCurrency           currency.Code                                                                     `json:"reporting-currency"`
+	TotalReportingValue         float64                                                                           `json:"total-reporting-value"`
 }
 
 // FinalResultsHolder holds important stats about a currency's performance
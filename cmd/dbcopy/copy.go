@@ -0,0 +1,357 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	defaultStateFile = "dbcopy_state.json"
+	// progressTable is created in the destination to record the progress of
+	// generated ID tables in the same transaction as their rows
+	progressTable = "dbcopy_progress"
+)
+
+var copyCommand = &cli.Command{
+	Name:  "copy",
+	Usage: "copy every table from one database to another, resuming a previous copy if interrupted",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "source",
+			Usage:    "config file containing the database to copy from",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "destination",
+			Usage:    "config file containing the database to copy to, migrations must already be applied",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "tables",
+			Usage: "comma separated list of tables to copy, defaults to all tables",
+		},
+		&cli.IntFlag{
+			Name:  "batchsize",
+			Usage: "number of rows copied per transaction",
+			Value: defaultBatchSize,
+		},
+		&cli.StringFlag{
+			Name:  "state",
+			Usage: "file used to record progress so an interrupted copy can be resumed",
+			Value: defaultStateFile,
+		},
+		&cli.BoolFlag{
+			Name:  "restart",
+			Usage: "discard any previous progress and copy from the beginning",
+		},
+	},
+	Action: copyDatabases,
+}
+
+// copyState records the progress of a copy so it can be resumed
+type copyState struct {
+	Source      string                 `json:"source"`
+	Destination string                 `json:"destination"`
+	Tables      map[string]*tableState `json:"tables"`
+}
+
+// tableState records the id of the last copied row of a table
+type tableState struct {
+	Cursor   string `json:"cursor"`
+	Copied   int64  `json:"copied"`
+	Skipped  int64  `json:"skipped"`
+	Complete bool   `json:"complete"`
+}
+
+func copyDatabases(c *cli.Context) error {
+	selected, err := selectTables(c.String("tables"))
+	if err != nil {
+		return err
+	}
+
+	src, err := openEndpoint(c.String("source"))
+	if err != nil {
+		return err
+	}
+	defer src.db.Close()
+
+	dst, err := openEndpoint(c.String("destination"))
+	if err != nil {
+		return err
+	}
+	defer dst.db.Close()
+
+	statePath := c.String("state")
+	if c.Bool("restart") {
+		err = os.Remove(statePath)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	state, err := loadState(statePath, src, dst)
+	if err != nil {
+		return err
+	}
+
+	return copyTables(src, dst, selected, c.Int("batchsize"), state, statePath)
+}
+
+// loadState loads the progress of a previous copy between src and dst, or
+// returns a new state if there is none
+func loadState(path string, src, dst *endpoint) (*copyState, error) {
+	state := &copyState{
+		Source:      src.name,
+		Destination: dst.name,
+		Tables:      make(map[string]*tableState),
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+	var previous copyState
+	err = json.Unmarshal(data, &previous)
+	if err != nil {
+		return nil, err
+	}
+	if previous.Source != src.name || previous.Destination != dst.name {
+		return nil, errStateMismatch
+	}
+	if previous.Tables != nil {
+		state.Tables = previous.Tables
+	}
+	return state, nil
+}
+
+func (s *copyState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(path, data)
+}
+
+// copyTables copies the supplied tables from src to dst in batches. Progress
+// is saved to statePath after each batch is committed. A batch interrupted
+// before its progress is saved is copied again, rows that already exist are
+// ignored. Tables with generated IDs resume from the progress committed to
+// the destination instead
+func copyTables(src, dst *endpoint, selected []*table, batchSize int, state *copyState, statePath string) error {
+	if batchSize <= 0 {
+		return errInvalidBatchSize
+	}
+	if src.name == dst.name {
+		return errSameDatabase
+	}
+
+	err := dst.createProgressTable()
+	if err != nil {
+		return err
+	}
+	exchanges, err := mapExchanges(src, dst)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range selected {
+		if t.name == "exchange" {
+			// exchanges are always mapped above
+			continue
+		}
+		ts, ok := state.Tables[t.name]
+		if !ok {
+			ts = &tableState{}
+			state.Tables[t.name] = ts
+		}
+		if ts.Complete {
+			fmt.Printf("%s: already copied, skipping\n", t.name)
+			continue
+		}
+		if t.generatedID {
+			var cursor string
+			cursor, err = dst.committedCursor(src.name, t)
+			if err != nil {
+				return fmt.Errorf("%s: %w", t.name, err)
+			}
+			if cursor != "" {
+				ts.Cursor = cursor
+			}
+		}
+		err = copyTable(src, dst, t, exchanges, batchSize, ts, func() error {
+			return state.save(statePath)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", t.name, err)
+		}
+		fmt.Printf("%s: %d rows copied, %d existing rows skipped\n", t.name, ts.Copied, ts.Skipped)
+	}
+	return nil
+}
+
+func copyTable(src, dst *endpoint, t *table, exchanges map[string]string, batchSize int, ts *tableState, checkpoint func() error) error {
+	query := dst.insertStatement(t)
+	for {
+		batch, err := src.selectBatch(t, ts.Cursor, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			ts.Complete = true
+			return checkpoint()
+		}
+
+		copied, err := insertBatch(src, dst, t, query, batch, exchanges)
+		if err != nil {
+			return err
+		}
+
+		ts.Cursor = batch[len(batch)-1][0].(string)
+		ts.Copied += copied
+		ts.Skipped += int64(len(batch)) - copied
+		if err = checkpoint(); err != nil {
+			return err
+		}
+		if verbose {
+			fmt.Printf("%s: copied up to id %s\n", t.name, ts.Cursor)
+		}
+	}
+}
+
+// insertBatch inserts a batch of rows into dst in a single transaction and
+// returns the number of rows inserted. The last source ID of a table with
+// generated IDs is committed in the same transaction so that the batch is
+// never copied twice
+func insertBatch(src, dst *endpoint, t *table, query string, batch [][]interface{}, exchanges map[string]string) (copied int64, err error) {
+	tx, err := dst.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			if errRB := tx.Rollback(); errRB != nil {
+				err = fmt.Errorf("%w rollback failed: %v", err, errRB)
+			}
+		}
+	}()
+
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	for i := range batch {
+		var args []interface{}
+		if !t.generatedID {
+			args = append(args, batch[i][0])
+		}
+		for j := range t.columns {
+			v := batch[i][j+1]
+			if t.columns[j].kind == kindExchange && v != nil {
+				id, ok := exchanges[v.(string)]
+				if !ok {
+					return 0, fmt.Errorf("%w %v", errExchangeNotMapped, v)
+				}
+				v = id
+			}
+			args = append(args, dst.value(v))
+		}
+		var result sql.Result
+		result, err = stmt.Exec(args...)
+		if err != nil {
+			return 0, fmt.Errorf("source id %v: %w", batch[i][0], err)
+		}
+		var affected int64
+		affected, err = result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		copied += affected
+	}
+	if t.generatedID {
+		_, err = tx.Exec(dst.progressStatement(), src.name, t.name, batch[len(batch)-1][0])
+		if err != nil {
+			return 0, err
+		}
+	}
+	err = tx.Commit()
+	return copied, err
+}
+
+// mapExchanges matches every source exchange to the destination exchange of
+// the same name, inserting any that are missing. It returns a map of source
+// exchange IDs to destination exchange IDs
+func mapExchanges(src, dst *endpoint) (map[string]string, error) {
+	srcExchanges, err := readExchanges(src)
+	if err != nil {
+		return nil, err
+	}
+	dstExchanges, err := readExchanges(dst)
+	if err != nil {
+		return nil, err
+	}
+	dstIDs := make(map[string]string, len(dstExchanges))
+	for id, name := range dstExchanges {
+		dstIDs[name] = id
+	}
+
+	insert := "INSERT INTO " + quote("exchange") + " (" + quote("id") + ", " +
+		quote("name") + ") VALUES (" + dst.placeholder(1) + ", " + dst.placeholder(2) + ")"
+	mapped := make(map[string]string, len(srcExchanges))
+	for srcID, name := range srcExchanges {
+		if id, ok := dstIDs[name]; ok {
+			mapped[srcID] = id
+			continue
+		}
+		id, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+		_, err = dst.db.Exec(insert, id.String(), name)
+		if err != nil {
+			return nil, err
+		}
+		mapped[srcID] = id.String()
+		if verbose {
+			fmt.Printf("exchange: added %s\n", name)
+		}
+	}
+	return mapped, nil
+}
+
+// readExchanges returns every exchange name keyed by its ID
+func readExchanges(e *endpoint) (map[string]string, error) {
+	rows, err := e.db.Query("SELECT " + quote("id") + ", " + quote("name") + " FROM " + quote("exchange"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	exchanges := make(map[string]string)
+	idCol := column{name: "id"}
+	for rows.Next() {
+		var id interface{}
+		var name string
+		if err = rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		v, err := idCol.normalise(id)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, errNoExchangeID
+		}
+		exchanges[s] = name
+	}
+	return exchanges, rows.Err()
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	dbPSQL "github.com/thrasher-corp/gocryptotrader/database/drivers/postgres"
+	dbsqlite3 "github.com/thrasher-corp/gocryptotrader/database/drivers/sqlite3"
+)
+
+var (
+	errUnknownTable      = errors.New("unknown table")
+	errUnsupportedValue  = errors.New("unsupported value")
+	errUnsupportedDriver = errors.New("unsupported database driver")
+	errSameDatabase      = errors.New("source and destination are the same database")
+	errInvalidBatchSize  = errors.New("batch size must be greater than zero")
+	errStateMismatch     = errors.New("state file was created for a different source or destination, use --restart to discard it")
+	errExchangeNotMapped = errors.New("no destination exchange for source exchange id")
+	errNoExchangeID      = errors.New("exchange has no id")
+)
+
+// endpoint is a database connection used as the source or destination of a
+// copy. Both connections are held at once, so the global database instance is
+// not used
+type endpoint struct {
+	driver string
+	// name identifies the database, it is stored in the state file to make
+	// sure a copy is resumed against the same databases
+	name string
+	db   *sql.DB
+}
+
+// openEndpoint loads the database configuration from the supplied
+// GoCryptoTrader config file and connects to it
+func openEndpoint(configPath string) (*endpoint, error) {
+	var conf config.Config
+	err := conf.LoadConfig(configPath, true)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.Database.Enabled {
+		return nil, fmt.Errorf("%s %w", configPath, database.ErrDatabaseSupportDisabled)
+	}
+	return connect(&conf.Database, conf.GetDataPath("database"))
+}
+
+// connect opens a connection to the database described by cfg, sqlite
+// databases are located in dataPath
+func connect(cfg *database.Config, dataPath string) (*endpoint, error) {
+	switch {
+	case isSQLite(cfg.Driver):
+		if cfg.Database == "" {
+			return nil, database.ErrNoDatabaseProvided
+		}
+		path, err := filepath.Abs(filepath.Join(dataPath, cfg.Database))
+		if err != nil {
+			return nil, err
+		}
+		db, err := dbsqlite3.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		return &endpoint{driver: database.DBSQLite3, name: database.DBSQLite3 + ":" + path, db: db}, nil
+	case cfg.Driver == database.DBPostgreSQL:
+		db, err := dbPSQL.Open(&cfg.ConnectionDetails)
+		if err != nil {
+			return nil, err
+		}
+		return &endpoint{
+			driver: database.DBPostgreSQL,
+			name: database.DBPostgreSQL + ":" + cfg.Host + ":" +
+				strconv.FormatUint(uint64(cfg.Port), 10) + "/" + cfg.Database,
+			db: db,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w %s", errUnsupportedDriver, cfg.Driver)
+}
+
+func isSQLite(driver string) bool {
+	return driver == database.DBSQLite || driver == database.DBSQLite3
+}
+
+// placeholder returns the bind parameter for the nth (1 based) argument
+func (e *endpoint) placeholder(n int) string {
+	if e.driver == database.DBPostgreSQL {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// value converts a normalised value into the form stored by the endpoint's
+// driver. sqlite timestamps are stored as RFC3339 strings to match the
+// repositories
+func (e *endpoint) value(v interface{}) interface{} {
+	if t, ok := v.(time.Time); ok && isSQLite(e.driver) {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return v
+}
+
+// selectBatch returns the next batch of up to limit rows of t ordered by id,
+// starting after cursor. The id is returned as the first value of each row
+func (e *endpoint) selectBatch(t *table, cursor string, limit int) ([][]interface{}, error) {
+	cols := make([]string, 0, len(t.columns)+1)
+	cols = append(cols, quote("id"))
+	for i := range t.columns {
+		cols = append(cols, quote(t.columns[i].columnName(e.driver)))
+	}
+	query := "SELECT " + strings.Join(cols, ", ") + " FROM " + quote(t.name)
+	var args []interface{}
+	if cursor != "" {
+		query += " WHERE " + quote("id") + " > " + e.placeholder(1)
+		args = append(args, cursor)
+	}
+	query += " ORDER BY " + quote("id") + " LIMIT " + strconv.Itoa(limit)
+
+	rows, err := e.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batch [][]interface{}
+	for rows.Next() {
+		raw := make([]interface{}, len(cols))
+		dest := make([]interface{}, len(cols))
+		for i := range raw {
+			dest[i] = &raw[i]
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		row := make([]interface{}, len(cols))
+		idCol := column{name: "id"}
+		if row[0], err = idCol.normalise(raw[0]); err != nil {
+			return nil, err
+		}
+		for i := range t.columns {
+			if row[i+1], err = t.columns[i].normalise(raw[i+1]); err != nil {
+				return nil, err
+			}
+		}
+		batch = append(batch, row)
+	}
+	return batch, rows.Err()
+}
+
+// insertStatement returns an insert statement for t which ignores rows that
+// conflict with existing rows, allowing interrupted batches to be copied again.
+// Tables with generated IDs are never copied again as their progress is
+// committed with each batch, so any conflict is an error
+func (e *endpoint) insertStatement(t *table) string {
+	var cols, params []string
+	if !t.generatedID {
+		cols = append(cols, quote("id"))
+	}
+	for i := range t.columns {
+		cols = append(cols, quote(t.columns[i].columnName(e.driver)))
+	}
+	for i := range cols {
+		params = append(params, e.placeholder(i+1))
+	}
+	if t.generatedID {
+		return "INSERT INTO " + quote(t.name) + " (" + strings.Join(cols, ", ") +
+			") VALUES (" + strings.Join(params, ", ") + ")"
+	}
+	if e.driver == database.DBPostgreSQL {
+		return "INSERT INTO " + quote(t.name) + " (" + strings.Join(cols, ", ") +
+			") VALUES (" + strings.Join(params, ", ") + ") ON CONFLICT DO NOTHING"
+	}
+	return "INSERT OR IGNORE INTO " + quote(t.name) + " (" + strings.Join(cols, ", ") +
+		") VALUES (" + strings.Join(params, ", ") + ")"
+}
+
+// createProgressTable creates the table the destination of a copy records
+// the last copied source ID of generated ID tables in
+func (e *endpoint) createProgressTable() error {
+	_, err := e.db.Exec("CREATE TABLE IF NOT EXISTS " + quote(progressTable) + " (" +
+		quote("source") + " TEXT NOT NULL, " +
+		quote("table_name") + " TEXT NOT NULL, " +
+		quote("cursor") + " TEXT NOT NULL, " +
+		"PRIMARY KEY (" + quote("source") + ", " + quote("table_name") + "))")
+	return err
+}
+
+// committedCursor returns the last source ID of t committed to the
+// destination by a copy from source, or an empty string if there is none
+func (e *endpoint) committedCursor(source string, t *table) (string, error) {
+	var cursor string
+	err := e.db.QueryRow("SELECT "+quote("cursor")+" FROM "+quote(progressTable)+
+		" WHERE "+quote("source")+" = "+e.placeholder(1)+" AND "+quote("table_name")+" = "+e.placeholder(2),
+		source, t.name).Scan(&cursor)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return cursor, err
+}
+
+// progressStatement returns a statement recording the last copied source ID
+// of a table
+func (e *endpoint) progressStatement() string {
+	return "INSERT INTO " + quote(progressTable) + " (" + quote("source") + ", " +
+		quote("table_name") + ", " + quote("cursor") + ") VALUES (" +
+		e.placeholder(1) + ", " + e.placeholder(2) + ", " + e.placeholder(3) + ") ON CONFLICT (" +
+		quote("source") + ", " + quote("table_name") + ") DO UPDATE SET " +
+		quote("cursor") + " = excluded." + quote("cursor")
+}
+
+func quote(identifier string) string {
+	return `"` + identifier + `"`
+}
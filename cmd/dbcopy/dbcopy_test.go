@@ -0,0 +1,395 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/goose"
+)
+
+var migrationDir = filepath.Join("..", "..", "database", "migrations")
+
+func newTestEndpoint(t *testing.T, dir, name string) *endpoint {
+	t.Helper()
+	e, err := connect(&database.Config{
+		Driver:            database.DBSQLite3,
+		ConnectionDetails: drivers.ConnectionDetails{Database: name},
+	}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = goose.Run("up", e.db, database.DBSQLite3, migrationDir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func execAll(t *testing.T, e *endpoint, queries ...string) {
+	t.Helper()
+	for i := range queries {
+		if _, err := e.db.Exec(queries[i]); err != nil {
+			t.Fatalf("%s: %v", queries[i], err)
+		}
+	}
+}
+
+func count(t *testing.T, e *endpoint, query string, args ...interface{}) int {
+	t.Helper()
+	var n int
+	if err := e.db.QueryRow(query, args...).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func setupDatabases(t *testing.T) (src, dst *endpoint, dir string) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "gct-dbcopy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src = newTestEndpoint(t, dir, "source.db")
+	dst = newTestEndpoint(t, dir, "destination.db")
+
+	ts := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	execAll(t, src,
+		`INSERT INTO exchange (id, name) VALUES ('src-binance', 'binance'), ('src-bitstamp', 'bitstamp')`,
+		`INSERT INTO audit_event (type, identifier, message) VALUES ('test', 'one', 'first'), ('test', 'two', 'second')`,
+		`INSERT INTO withdrawal_history (id, exchange_name_id, exchange_id, status, currency, amount, description, withdraw_type)
+			VALUES ('w1', 'src-binance', '123', 'success', 'BTC', 1.5, NULL, 1)`,
+		`INSERT INTO withdrawal_crypto (address, address_tag, fee, withdrawal_history_id) VALUES ('addr', NULL, 0.1, 'w1')`,
+	)
+	for i := 0; i < 5; i++ {
+		_, err = src.db.Exec(`INSERT INTO candle (id, exchange_name_id, base, quote, interval, timestamp, open, high, low, close, volume, asset)
+			VALUES (?, 'src-binance', 'BTC', 'USDT', '3600', ?, 1, 2, 0.5, 1.5, 100, 'spot')`,
+			"c"+string(rune('a'+i)), ts.Add(time.Hour*time.Duration(i)).Format(time.RFC3339))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = src.db.Exec(`INSERT INTO trade (id, exchange_name_id, tid, base, quote, asset, price, amount, side, timestamp)
+			VALUES (?, 'src-bitstamp', ?, 'BTC', 'USD', 'spot', 100, 1, NULL, ?)`,
+			"t"+string(rune('a'+i)), string(rune('a'+i)), ts.Add(time.Minute*time.Duration(i)).Format(time.RFC3339))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the destination already knows binance under a different ID
+	execAll(t, dst, `INSERT INTO exchange (id, name) VALUES ('dst-binance', 'binance')`)
+	return src, dst, dir
+}
+
+func TestCopyTables(t *testing.T) {
+	src, dst, dir := setupDatabases(t)
+	defer os.RemoveAll(dir)
+	defer src.db.Close()
+	defer dst.db.Close()
+
+	statePath := filepath.Join(dir, defaultStateFile)
+	state, err := loadState(statePath, src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = copyTables(src, dst, tables, 2, state, statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := count(t, dst, `SELECT count(*) FROM candle WHERE exchange_name_id = 'dst-binance'`); n != 5 {
+		t.Errorf("received '%v' expected '%v'", n, 5)
+	}
+	if n := count(t, dst, `SELECT count(*) FROM trade t JOIN exchange e ON t.exchange_name_id = e.id WHERE e.name = 'bitstamp' AND t.side IS NULL`); n != 5 {
+		t.Errorf("received '%v' expected '%v'", n, 5)
+	}
+	if n := count(t, dst, `SELECT count(*) FROM exchange`); n != 2 {
+		t.Errorf("received '%v' expected '%v'", n, 2)
+	}
+	if n := count(t, dst, `SELECT count(*) FROM audit_event`); n != 2 {
+		t.Errorf("received '%v' expected '%v'", n, 2)
+	}
+	if n := count(t, dst, `SELECT count(*) FROM withdrawal_crypto c JOIN withdrawal_history h ON c.withdrawal_history_id = h.id WHERE h.exchange_name_id = 'dst-binance'`); n != 1 {
+		t.Errorf("received '%v' expected '%v'", n, 1)
+	}
+	var ts string
+	if err = dst.db.QueryRow(`SELECT timestamp FROM candle WHERE id = 'cb'`).Scan(&ts); err != nil {
+		t.Fatal(err)
+	}
+	if ts != "2020-01-01T01:00:00Z" {
+		t.Errorf("received '%v' expected '%v'", ts, "2020-01-01T01:00:00Z")
+	}
+	if !state.Tables["candle"].Complete || state.Tables["candle"].Copied != 5 {
+		t.Errorf("unexpected candle state %+v", state.Tables["candle"])
+	}
+
+	// a completed copy is not repeated
+	state, err = loadState(statePath, src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = copyTables(src, dst, tables, 2, state, statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := count(t, dst, `SELECT count(*) FROM audit_event`); n != 2 {
+		t.Errorf("received '%v' expected '%v'", n, 2)
+	}
+
+	_, err = loadState(statePath, dst, src)
+	if !errors.Is(err, errStateMismatch) {
+		t.Errorf("received '%v' expected '%v'", err, errStateMismatch)
+	}
+	err = copyTables(src, src, tables, 2, state, statePath)
+	if !errors.Is(err, errSameDatabase) {
+		t.Errorf("received '%v' expected '%v'", err, errSameDatabase)
+	}
+	err = copyTables(src, dst, tables, 0, state, statePath)
+	if !errors.Is(err, errInvalidBatchSize) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidBatchSize)
+	}
+}
+
+func TestCopyTablesResume(t *testing.T) {
+	src, dst, dir := setupDatabases(t)
+	defer os.RemoveAll(dir)
+	defer src.db.Close()
+	defer dst.db.Close()
+
+	selected, err := selectTables("trade")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// simulate a copy interrupted after the first two trades, one of which
+	// was committed before its progress was saved
+	statePath := filepath.Join(dir, defaultStateFile)
+	state := &copyState{
+		Source:      src.name,
+		Destination: dst.name,
+		Tables:      map[string]*tableState{"trade": {Cursor: "tb", Copied: 2}},
+	}
+	if err = state.save(statePath); err != nil {
+		t.Fatal(err)
+	}
+	state, err = loadState(statePath, src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = copyTables(src, dst, selected, 2, state, statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := count(t, dst, `SELECT count(*) FROM trade`); n != 3 {
+		t.Errorf("received '%v' expected '%v'", n, 3)
+	}
+	if n := count(t, dst, `SELECT count(*) FROM trade WHERE id IN ('ta', 'tb')`); n != 0 {
+		t.Errorf("received '%v' expected '%v'", n, 0)
+	}
+	if state.Tables["trade"].Copied != 5 {
+		t.Errorf("received '%v' expected '%v'", state.Tables["trade"].Copied, 5)
+	}
+
+	// copying again from the beginning skips the existing rows
+	state.Tables["trade"] = &tableState{}
+	err = copyTables(src, dst, selected, 2, state, statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Tables["trade"].Copied != 2 || state.Tables["trade"].Skipped != 3 {
+		t.Errorf("unexpected trade state %+v", state.Tables["trade"])
+	}
+}
+
+func TestCopyTablesGeneratedIDResume(t *testing.T) {
+	src, dst, dir := setupDatabases(t)
+	defer os.RemoveAll(dir)
+	defer src.db.Close()
+	defer dst.db.Close()
+
+	// rows with identical content are still separate rows and are all copied
+	execAll(t, src,
+		`INSERT INTO audit_event (type, identifier, message) VALUES ('test', 'two', 'second')`,
+		`INSERT INTO script (id, script_id, script_name, script_path, script_data, last_executed_at, created_at)
+			VALUES ('s1', 's1', 'test', 'test.gct', NULL, '2020-01-01T00:00:00Z', '2020-01-01T00:00:00Z')`,
+		`INSERT INTO script_execution (script_id, execution_type, execution_status, execution_time)
+			VALUES ('s1', 'run', 'ok', '2020-01-01T00:00:00Z'), ('s1', 'run', 'ok', '2020-01-01T00:00:00Z')`,
+	)
+	selected, err := selectTables("audit_event,script,script_execution,withdrawal_history,withdrawal_crypto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	statePath := filepath.Join(dir, defaultStateFile)
+	state, err := loadState(statePath, src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = copyTables(src, dst, selected, 1, state, statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate every batch being committed without its progress being saved
+	state.Tables = make(map[string]*tableState)
+	err = copyTables(src, dst, selected, 1, state, statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the copy resumes from the progress committed to the destination
+	for table, expected := range map[string]int{"audit_event": 3, "script_execution": 2, "withdrawal_crypto": 1} {
+		if n := count(t, dst, `SELECT count(*) FROM `+table); n != expected {
+			t.Errorf("%s received '%v' expected '%v'", table, n, expected)
+		}
+		if state.Tables[table].Copied != 0 || !state.Tables[table].Complete {
+			t.Errorf("unexpected %s state %+v", table, state.Tables[table])
+		}
+	}
+}
+
+func TestCopyTablesAuditConflict(t *testing.T) {
+	src, dst, dir := setupDatabases(t)
+	defer os.RemoveAll(dir)
+	defer src.db.Close()
+	defer dst.db.Close()
+
+	execAll(t, src, `INSERT INTO audit_event (type, identifier, message, sequence, hash) VALUES ('test', 'three', 'third', 1, 'abc')`)
+	execAll(t, dst, `INSERT INTO audit_event (type, identifier, message, sequence, hash) VALUES ('test', 'other', 'other', 1, 'def')`)
+	selected, err := selectTables("audit_event")
+	if err != nil {
+		t.Fatal(err)
+	}
+	statePath := filepath.Join(dir, defaultStateFile)
+	state, err := loadState(statePath, src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = copyTables(src, dst, selected, 10, state, statePath)
+	if err == nil {
+		t.Fatal("expected an error copying an audit event with a conflicting sequence")
+	}
+	if n := count(t, dst, `SELECT count(*) FROM audit_event`); n != 1 {
+		t.Errorf("received '%v' expected '%v'", n, 1)
+	}
+}
+
+func TestSelectTables(t *testing.T) {
+	t.Parallel()
+	selected, err := selectTables("trade, CANDLE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// tables are returned in copy order
+	if len(selected) != 2 || selected[0].name != "candle" || selected[1].name != "trade" {
+		t.Errorf("unexpected tables %v", selected)
+	}
+	_, err = selectTables("trade,orders")
+	if !errors.Is(err, errUnknownTable) {
+		t.Errorf("received '%v' expected '%v'", err, errUnknownTable)
+	}
+}
+
+func TestNormalise(t *testing.T) {
+	t.Parallel()
+	c := column{name: "timestamp", kind: kindTime}
+	v, err := c.normalise("2020-01-01 01:02:03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.(time.Time).Equal(time.Date(2020, 1, 1, 1, 2, 3, 0, time.UTC)) {
+		t.Errorf("unexpected time %v", v)
+	}
+	c = column{name: "interval", kind: kindInt}
+	v, err = c.normalise([]byte("3600"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.(int64) != 3600 {
+		t.Errorf("received '%v' expected '%v'", v, 3600)
+	}
+	if v, err = c.normalise(nil); v != nil || err != nil {
+		t.Errorf("expected nil value, received %v %v", v, err)
+	}
+	_, err = c.normalise(true)
+	if !errors.Is(err, errUnsupportedValue) {
+		t.Errorf("received '%v' expected '%v'", err, errUnsupportedValue)
+	}
+}
+
+func TestExportTables(t *testing.T) {
+	src, dst, dir := setupDatabases(t)
+	defer os.RemoveAll(dir)
+	defer src.db.Close()
+	defer dst.db.Close()
+
+	selected, err := selectTables("candle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "export")
+	err = exportTables(src, selected, "parquet", out, 2)
+	if !errors.Is(err, errUnsupportedFormat) {
+		t.Errorf("received '%v' expected '%v'", err, errUnsupportedFormat)
+	}
+
+	err = exportTables(src, selected, formatCSV, out, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(filepath.Join(out, "candle.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(f).ReadAll()
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 6 {
+		t.Fatalf("received '%v' expected '%v'", len(records), 6)
+	}
+	if records[0][1] != "exchange" || records[1][1] != "binance" || records[1][4] != "3600" || records[1][5] != "2020-01-01T00:00:00Z" {
+		t.Errorf("unexpected records %v %v", records[0], records[1])
+	}
+
+	err = exportTables(src, selected, formatColumnar, out, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err = os.Open(filepath.Join(out, "candle.columnar.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec := json.NewDecoder(gz)
+	var header columnarHeader
+	if err = dec.Decode(&header); err != nil {
+		t.Fatal(err)
+	}
+	if header.Table != "candle" || len(header.Columns) != 12 || header.Columns[4].Type != "int" {
+		t.Errorf("unexpected header %+v", header)
+	}
+	var rows int
+	for dec.More() {
+		var group columnarRowGroup
+		if err = dec.Decode(&group); err != nil {
+			t.Fatal(err)
+		}
+		if len(group.Columns) != 12 || len(group.Columns[0]) != group.Rows {
+			t.Errorf("unexpected row group %+v", group)
+		}
+		rows += group.Rows
+	}
+	if rows != 5 {
+		t.Errorf("received '%v' expected '%v'", rows, 5)
+	}
+}
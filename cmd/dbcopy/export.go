@@ -0,0 +1,280 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	formatCSV      = "csv"
+	formatColumnar = "columnar"
+)
+
+var errUnsupportedFormat = errors.New("unsupported export format")
+
+var exportCommand = &cli.Command{
+	Name:  "export",
+	Usage: "export tables to csv or columnar files",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "source",
+			Usage:    "config file containing the database to export from",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "tables",
+			Usage: "comma separated list of tables to export, defaults to all tables",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "export format csv|columnar",
+			Value: formatCSV,
+		},
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "directory to write exported files to",
+			Value: "export",
+		},
+		&cli.IntFlag{
+			Name:  "batchsize",
+			Usage: "number of rows read per query and stored per columnar row group",
+			Value: defaultBatchSize,
+		},
+	},
+	Action: exportDatabase,
+}
+
+func exportDatabase(c *cli.Context) error {
+	selected, err := selectTables(c.String("tables"))
+	if err != nil {
+		return err
+	}
+
+	src, err := openEndpoint(c.String("source"))
+	if err != nil {
+		return err
+	}
+	defer src.db.Close()
+
+	return exportTables(src, selected, c.String("format"), c.String("output"), c.Int("batchsize"))
+}
+
+// exportTables writes each of the selected tables to its own file in dir.
+// References to the exchange table are written as the exchange name
+func exportTables(src *endpoint, selected []*table, format, dir string, batchSize int) error {
+	if batchSize <= 0 {
+		return errInvalidBatchSize
+	}
+	var ext string
+	switch format {
+	case formatCSV:
+		ext = ".csv"
+	case formatColumnar:
+		ext = ".columnar.gz"
+	default:
+		return fmt.Errorf("%w %s", errUnsupportedFormat, format)
+	}
+
+	err := common.CreateDir(dir)
+	if err != nil {
+		return err
+	}
+
+	exchanges, err := readExchanges(src)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range selected {
+		path := filepath.Join(dir, t.name+ext)
+		var rows int64
+		rows, err = exportTable(src, t, format, path, exchanges, batchSize)
+		if err != nil {
+			return fmt.Errorf("%s: %w", t.name, err)
+		}
+		fmt.Printf("%s: %d rows exported to %s\n", t.name, rows, path)
+	}
+	return nil
+}
+
+func exportTable(src *endpoint, t *table, format, path string, exchanges map[string]string, batchSize int) (rows int64, err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if errClose := f.Close(); err == nil {
+			err = errClose
+		}
+	}()
+
+	var w batchWriter
+	if format == formatColumnar {
+		w, err = newColumnarWriter(f, t)
+	} else {
+		w, err = newCSVWriter(f, t)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var cursor string
+	for {
+		var batch [][]interface{}
+		batch, err = src.selectBatch(t, cursor, batchSize)
+		if err != nil {
+			return rows, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for i := range batch {
+			for j := range t.columns {
+				if t.columns[j].kind == kindExchange && batch[i][j+1] != nil {
+					batch[i][j+1] = exchanges[batch[i][j+1].(string)]
+				}
+			}
+		}
+		if err = w.write(batch); err != nil {
+			return rows, err
+		}
+		rows += int64(len(batch))
+		cursor = batch[len(batch)-1][0].(string)
+	}
+	return rows, w.close()
+}
+
+// batchWriter writes batches of normalised rows to an export file
+type batchWriter interface {
+	write(batch [][]interface{}) error
+	close() error
+}
+
+// exportColumns returns the exported column names of t, exchange references
+// are exported as the exchange name
+func exportColumns(t *table) []string {
+	names := make([]string, 0, len(t.columns)+1)
+	names = append(names, "id")
+	for i := range t.columns {
+		if t.columns[i].kind == kindExchange {
+			names = append(names, "exchange")
+			continue
+		}
+		names = append(names, t.columns[i].name)
+	}
+	return names
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, t *table) (*csvWriter, error) {
+	c := &csvWriter{w: csv.NewWriter(w)}
+	return c, c.w.Write(exportColumns(t))
+}
+
+func (c *csvWriter) write(batch [][]interface{}) error {
+	record := make([]string, len(batch[0]))
+	for i := range batch {
+		for j := range batch[i] {
+			record[j] = formatValue(batch[i][j])
+		}
+		if err := c.w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *csvWriter) close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(val)
+	}
+	return fmt.Sprint(v)
+}
+
+// columnarHeader is the first document of a columnar export and describes
+// the columns stored in each row group
+type columnarHeader struct {
+	Table   string           `json:"table"`
+	Columns []columnarColumn `json:"columns"`
+}
+
+type columnarColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// columnarRowGroup holds a batch of rows stored column by column, so each
+// column can be read without decoding the others
+type columnarRowGroup struct {
+	Rows    int             `json:"rows"`
+	Columns [][]interface{} `json:"columns"`
+}
+
+// columnarWriter writes a gzip compressed stream of JSON documents, a
+// columnarHeader followed by one columnarRowGroup per batch. Timestamps are
+// RFC3339 strings and binary values are base64 encoded
+type columnarWriter struct {
+	gz  *gzip.Writer
+	enc *json.Encoder
+}
+
+func newColumnarWriter(w io.Writer, t *table) (*columnarWriter, error) {
+	gz := gzip.NewWriter(w)
+	c := &columnarWriter{gz: gz, enc: json.NewEncoder(gz)}
+	header := columnarHeader{Table: t.name}
+	names := exportColumns(t)
+	header.Columns = append(header.Columns, columnarColumn{Name: names[0], Type: kindText.String()})
+	for i := range t.columns {
+		header.Columns = append(header.Columns, columnarColumn{Name: names[i+1], Type: t.columns[i].kind.String()})
+	}
+	return c, c.enc.Encode(header)
+}
+
+func (c *columnarWriter) write(batch [][]interface{}) error {
+	group := columnarRowGroup{
+		Rows:    len(batch),
+		Columns: make([][]interface{}, len(batch[0])),
+	}
+	for j := range group.Columns {
+		group.Columns[j] = make([]interface{}, len(batch))
+		for i := range batch {
+			group.Columns[j][i] = batch[i][j]
+		}
+	}
+	return c.enc.Encode(group)
+}
+
+func (c *columnarWriter) close() error {
+	return c.gz.Close()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/thrasher-corp/gocryptotrader/core"
+	"github.com/urfave/cli/v2"
+)
+
+const defaultBatchSize = 1000
+
+var (
+	app = &cli.App{
+		Name:                 "dbcopy",
+		Version:              core.Version(false),
+		EnableBashCompletion: true,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "verbose",
+				Usage:       "toggle verbose output",
+				Destination: &verbose,
+			},
+		},
+		Commands: []*cli.Command{
+			copyCommand,
+			exportCommand,
+		},
+	}
+	verbose bool
+)
+
+func main() {
+	fmt.Println("GoCryptoTrader database copy tool")
+	fmt.Println(core.Copyright)
+	fmt.Println()
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// columnKind describes how a column value is normalised when moving between
+// drivers
+type columnKind uint8
+
+const (
+	kindText columnKind = iota
+	kindFloat
+	kindInt
+	kindTime
+	kindBytes
+	// kindExchange is a reference to the exchange table which is remapped to
+	// the matching destination exchange ID when copying
+	kindExchange
+)
+
+// String returns the type name of the column kind used in columnar exports
+func (k columnKind) String() string {
+	switch k {
+	case kindFloat:
+		return "float"
+	case kindInt:
+		return "int"
+	case kindTime:
+		return "time"
+	case kindBytes:
+		return "bytes"
+	}
+	return "text"
+}
+
+// column describes a single table column. Columns are named by their postgres
+// name, sqliteName is only set where the sqlite schema differs
+type column struct {
+	name       string
+	sqliteName string
+	kind       columnKind
+}
+
+// table describes a table that can be copied or exported. The id column is
+// implied and used to batch through the table in order. Tables with
+// generatedID set have IDs which differ in type between drivers, so the
+// destination assigns its own. Their rows can't be matched to rows already in
+// the destination, so the last copied source ID is committed to the
+// destination along with each batch instead
+type table struct {
+	name        string
+	generatedID bool
+	columns     []column
+}
+
+// tables lists every supported table, ordered so that referenced tables are
+// copied before the tables which reference them
+var tables = []*table{
+	{
+		name:    "exchange",
+		columns: []column{{name: "name"}},
+	},
+	{
+		name:        "audit_event",
+		generatedID: true,
+		columns: []column{
+			{name: "type"},
+			{name: "identifier"},
+			{name: "message"},
+			{name: "created_at", kind: kindTime},
+			{name: "actor_type"},
+			{name: "actor"},
+			{name: "data"},
+			{name: "sequence", kind: kindInt},
+			{name: "previous_hash"},
+			{name: "hash"},
+		},
+	},
+	{
+		name: "script",
+		columns: []column{
+			{name: "script_id"},
+			{name: "script_name"},
+			{name: "script_path"},
+			{name: "script_data", kind: kindBytes},
+			{name: "last_executed_at", kind: kindTime},
+			{name: "created_at", kind: kindTime},
+		},
+	},
+	{
+		name:        "script_execution",
+		generatedID: true,
+		columns: []column{
+			{name: "script_id"},
+			{name: "execution_type"},
+			{name: "execution_status"},
+			{name: "execution_time", kind: kindTime},
+		},
+	},
+	{
+		name: "withdrawal_history",
+		columns: []column{
+			{name: "exchange_name_id", kind: kindExchange},
+			{name: "exchange_id"},
+			{name: "status"},
+			{name: "currency"},
+			{name: "amount", kind: kindFloat},
+			{name: "description"},
+			{name: "withdraw_type", kind: kindInt},
+			{name: "created_at", kind: kindTime},
+			{name: "updated_at", kind: kindTime},
+		},
+	},
+	{
+		name:        "withdrawal_fiat",
+		generatedID: true,
+		columns: []column{
+			{name: "withdrawal_fiat_id", sqliteName: "withdrawal_history_id"},
+			{name: "bank_name"},
+			{name: "bank_address"},
+			{name: "bank_account_name"},
+			{name: "bank_account_number"},
+			{name: "bsb"},
+			{name: "swift_code"},
+			{name: "iban"},
+			{name: "bank_code", kind: kindFloat},
+		},
+	},
+	{
+		name:        "withdrawal_crypto",
+		generatedID: true,
+		columns: []column{
+			{name: "withdrawal_crypto_id", sqliteName: "withdrawal_history_id"},
+			{name: "address"},
+			{name: "address_tag"},
+			{name: "fee", kind: kindFloat},
+		},
+	},
+	{
+		name: "candle",
+		columns: []column{
+			{name: "exchange_name_id", kind: kindExchange},
+			{name: "base"},
+			{name: "quote"},
+			{name: "interval", kind: kindInt},
+			{name: "timestamp", kind: kindTime},
+			{name: "open", kind: kindFloat},
+			{name: "high", kind: kindFloat},
+			{name: "low", kind: kindFloat},
+			{name: "close", kind: kindFloat},
+			{name: "volume", kind: kindFloat},
+			{name: "asset"},
+		},
+	},
+	{
+		name: "trade",
+		columns: []column{
+			{name: "exchange_name_id", kind: kindExchange},
+			{name: "tid"},
+			{name: "base"},
+			{name: "quote"},
+			{name: "asset"},
+			{name: "price", kind: kindFloat},
+			{name: "amount", kind: kindFloat},
+			{name: "side"},
+			{name: "timestamp", kind: kindTime},
+		},
+	},
+	{
+		name: "datahistoryjob",
+		columns: []column{
+			{name: "nickname"},
+			{name: "exchange_name_id", kind: kindExchange},
+			{name: "asset"},
+			{name: "base"},
+			{name: "quote"},
+			{name: "start_time", kind: kindTime},
+			{name: "end_time", kind: kindTime},
+			{name: "interval", kind: kindInt},
+			{name: "data_type", kind: kindInt},
+			{name: "request_size", kind: kindInt},
+			{name: "max_retries", kind: kindInt},
+			{name: "batch_count", kind: kindInt},
+			{name: "status", kind: kindInt},
+			{name: "created", kind: kindTime},
+		},
+	},
+	{
+		name: "datahistoryjobresult",
+		columns: []column{
+			{name: "job_id"},
+			{name: "result"},
+			{name: "status", kind: kindInt},
+			{name: "interval_start_time", kind: kindTime},
+			{name: "interval_end_time", kind: kindTime},
+			{name: "run_time", kind: kindTime},
+		},
+	},
+	{
+		name: "balance_snapshot",
+		columns: []column{
+			{name: "exchange_name_id", kind: kindExchange},
+			{name: "account_id"},
+			{name: "asset"},
+			{name: "currency"},
+			{name: "total", kind: kindFloat},
+			{name: "hold", kind: kindFloat},
+			{name: "fiat_currency"},
+			{name: "fiat_value", kind: kindFloat},
+			{name: "timestamp", kind: kindTime},
+		},
+	},
+	{
+		name: "fx_rate",
+		columns: []column{
+			{name: "base_currency"},
+			{name: "quote_currency"},
+			{name: "rate", kind: kindFloat},
+			{name: "provider"},
+			{name: "date", kind: kindTime},
+		},
+	},
+	{
+		name: "script_state",
+		columns: []column{
+			{name: "script_name"},
+			{name: "key"},
+			{name: "value"},
+			{name: "updated_at", kind: kindTime},
+		},
+	},
+}
+
+// selectTables returns the tables matching the supplied comma separated
+// names, or every table when names is empty
+func selectTables(names string) ([]*table, error) {
+	if names == "" {
+		return tables, nil
+	}
+	wanted := make(map[string]bool)
+	for _, n := range strings.Split(names, ",") {
+		n = strings.ToLower(strings.TrimSpace(n))
+		if n == "" {
+			continue
+		}
+		if getTable(n) == nil {
+			return nil, fmt.Errorf("%w %s", errUnknownTable, n)
+		}
+		wanted[n] = true
+	}
+	var selected []*table
+	for i := range tables {
+		if wanted[tables[i].name] {
+			selected = append(selected, tables[i])
+		}
+	}
+	return selected, nil
+}
+
+func getTable(name string) *table {
+	for i := range tables {
+		if tables[i].name == name {
+			return tables[i]
+		}
+	}
+	return nil
+}
+
+// columnName returns the name of the column for the supplied driver
+func (c *column) columnName(driver string) string {
+	if c.sqliteName != "" && isSQLite(driver) {
+		return c.sqliteName
+	}
+	return c.name
+}
+
+// normalise converts a value scanned from either driver into a driver
+// independent type: string, int64, float64, time.Time, []byte or nil
+func (c *column) normalise(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch c.kind {
+	case kindText, kindExchange:
+		switch val := v.(type) {
+		case string:
+			return val, nil
+		case []byte:
+			return string(val), nil
+		case int64:
+			return strconv.FormatInt(val, 10), nil
+		}
+	case kindFloat:
+		switch val := v.(type) {
+		case float64:
+			return val, nil
+		case int64:
+			return float64(val), nil
+		case string:
+			return strconv.ParseFloat(val, 64)
+		case []byte:
+			return strconv.ParseFloat(string(val), 64)
+		}
+	case kindInt:
+		switch val := v.(type) {
+		case int64:
+			return val, nil
+		case float64:
+			return int64(val), nil
+		case string:
+			return strconv.ParseInt(val, 10, 64)
+		case []byte:
+			return strconv.ParseInt(string(val), 10, 64)
+		}
+	case kindTime:
+		switch val := v.(type) {
+		case time.Time:
+			return val.UTC(), nil
+		case string:
+			return parseTime(val)
+		case []byte:
+			return parseTime(string(val))
+		}
+	case kindBytes:
+		switch val := v.(type) {
+		case []byte:
+			return val, nil
+		case string:
+			return []byte(val), nil
+		}
+	}
+	return nil, fmt.Errorf("%w %T for column %s", errUnsupportedValue, v, c.name)
+}
+
+// timeFormats are the layouts sqlite timestamps are stored with, either
+// written by the repositories or by a CURRENT_TIMESTAMP default
+var timeFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func parseTime(s string) (time.Time, error) {
+	for i := range timeFormats {
+		t, err := time.Parse(timeFormats[i], s)
+		if err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w %s", errUnsupportedValue, s)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli"
+)
+
+var apiTokenCommand = cli.Command{
+	Name:      "apitoken",
+	Usage:     "manage scoped remote control API tokens",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []cli.Command{
+		{
+			Name:      "create",
+			Usage:     "creates an API token, the token is only displayed once",
+			ArgsUsage: "<name> <scopes>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "name",
+					Usage: "a description of what the token is used for",
+				},
+				cli.StringFlag{
+					Name:  "scopes",
+					Usage: "comma separated scopes to grant: read, trade, withdraw, admin",
+				},
+				cli.StringFlag{
+					Name:  "expiry",
+					Usage: "how long the token is valid for e.g. 720h, never expires if unset",
+				},
+			},
+			Action: createAPIToken,
+		},
+		{
+			Name:      "revoke",
+			Usage:     "revokes an API token",
+			ArgsUsage: "<id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "id",
+					Usage: "the ID of the token to revoke",
+				},
+			},
+			Action: revokeAPIToken,
+		},
+		{
+			Name:   "list",
+			Usage:  "lists API tokens",
+			Action: listAPITokens,
+		},
+	},
+}
+
+func createAPIToken(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var name string
+	if c.IsSet("name") {
+		name = c.String("name")
+	} else {
+		name = c.Args().First()
+	}
+
+	var scopes string
+	if c.IsSet("scopes") {
+		scopes = c.String("scopes")
+	} else {
+		scopes = c.Args().Get(1)
+	}
+	if scopes == "" {
+		return errors.New("at least one scope is required")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.CreateAPIToken(context.Background(),
+		&gctrpc.CreateAPITokenRequest{
+			Name:   name,
+			Scopes: strings.Split(scopes, ","),
+			Expiry: c.String("expiry"),
+		})
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+func revokeAPIToken(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var id string
+	if c.IsSet("id") {
+		id = c.String("id")
+	} else {
+		id = c.Args().First()
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.RevokeAPIToken(context.Background(),
+		&gctrpc.RevokeAPITokenRequest{Id: id})
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
+
+func listAPITokens(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.ListAPITokens(context.Background(),
+		&gctrpc.ListAPITokensRequest{})
+	if err != nil {
+		return err
+	}
+	jsonOutput(result)
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli"
+)
+
+var balanceSnapshotFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "exchange, e",
+		Usage: "limits the results to a single exchange, leave empty for all exchanges",
+	},
+	cli.StringFlag{
+		Name:        "start",
+		Usage:       "<start>",
+		Value:       time.Now().AddDate(0, 0, -30).Truncate(time.Hour).Format(common.SimpleTimeFormat),
+		Destination: &startTime,
+	},
+	cli.StringFlag{
+		Name:        "end",
+		Usage:       "<end>",
+		Value:       time.Now().Format(common.SimpleTimeFormat),
+		Destination: &endTime,
+	},
+}
+
+var balanceSnapshotCommand = cli.Command{
+	Name:      "balancesnapshot",
+	Usage:     "view equity and profit and loss from stored account balance snapshots",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []cli.Command{
+		{
+			Name:      "getequitycurve",
+			Usage:     "gets the total value of all balances at each snapshot",
+			ArgsUsage: "<exchange> <start> <end>",
+			Action:    getEquityCurve,
+			Flags:     balanceSnapshotFlags,
+		},
+		{
+			Name:      "getdailypnl",
+			Usage:     "gets the day over day profit and loss of all balances",
+			ArgsUsage: "<exchange> <start> <end>",
+			Action:    getDailyPnL,
+			Flags:     balanceSnapshotFlags,
+		},
+	},
+}
+
+func getEquityCurve(c *cli.Context) error {
+	req, err := parseBalanceSnapshotRequest(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetEquityCurve(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getDailyPnL(c *cli.Context) error {
+	req, err := parseBalanceSnapshotRequest(c)
+	if err != nil {
+		return err
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetDailyPnL(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func parseBalanceSnapshotRequest(c *cli.Context) (*gctrpc.GetBalanceSnapshotRequest, error) {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	if exchangeName != "" && !validExchange(exchangeName) {
+		return nil, errInvalidExchange
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(1) != "" {
+			startTime = c.Args().Get(1)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(2) != "" {
+			endTime = c.Args().Get(2)
+		}
+	}
+
+	s, err := time.Parse(common.SimpleTimeFormat, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time format for start: %v", err)
+	}
+	e, err := time.Parse(common.SimpleTimeFormat, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return nil, errors.New("start cannot be after end")
+	}
+
+	return &gctrpc.GetBalanceSnapshotRequest{
+		Exchange:  exchangeName,
+		StartDate: negateLocalOffset(s),
+		EndDate:   negateLocalOffset(e),
+	}, nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -15,6 +16,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/scenario"
 	"github.com/urfave/cli"
 	"google.golang.org/grpc"
 )
@@ -491,6 +494,52 @@ func getExchangeInfo(c *cli.Context) error {
 	return nil
 }
 
+var getExchangeHealthCommand = cli.Command{
+	Name:      "getexchangehealth",
+	Usage:     "gets the connectivity health of an exchange, or all exchanges if none is specified",
+	ArgsUsage: "<exchange>",
+	Action:    getExchangeHealth,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the health for",
+		},
+	},
+}
+
+func getExchangeHealth(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if exchangeName != "" && !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetExchangeHealth(context.Background(),
+		&gctrpc.GetExchangeHealthRequest{
+			Exchange: exchangeName,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
 var getTickerCommand = cli.Command{
 	Name:      "getticker",
 	Usage:     "gets the ticker for a specific currency pair and exchange",
@@ -950,6 +999,29 @@ func getConfig(_ *cli.Context) error {
 	return nil
 }
 
+var reloadConfigCommand = cli.Command{
+	Name:   "reloadconfig",
+	Usage:  "reloads the config file, applying pairs, logging levels, communications and order manager changes and reporting changes which require a restart",
+	Action: reloadConfig,
+}
+
+func reloadConfig(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.ReloadConfig(context.Background(), &gctrpc.ReloadConfigRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
 var getPortfolioCommand = cli.Command{
 	Name:   "getportfolio",
 	Usage:  "gets the portfolio",
@@ -3621,6 +3693,46 @@ func getAuditEvent(c *cli.Context) error {
 	return nil
 }
 
+var verifyAuditLogCommand = cli.Command{
+	Name:      "verifyauditlog",
+	Usage:     "verifies the audit log hash chain, reporting modified or removed events",
+	ArgsUsage: "<expectedheadhash>",
+	Action:    verifyAuditLog,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "expectedheadhash, head",
+			Usage: "the head hash reported by a previous verification, kept outside of the database; required to detect a rewritten log or events removed from the end of it, as the hash chain is unkeyed",
+		},
+	},
+}
+
+func verifyAuditLog(c *cli.Context) error {
+	var expectedHead string
+	if c.IsSet("expectedheadhash") {
+		expectedHead = c.String("expectedheadhash")
+	} else {
+		expectedHead = c.Args().First()
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.VerifyAuditLog(context.Background(),
+		&gctrpc.VerifyAuditLogRequest{
+			ExpectedHeadHash: expectedHead,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
 var uuid, filename, path string
 var gctScriptCommand = cli.Command{
 	Name:      "script",
@@ -3713,6 +3825,10 @@ var gctScriptCommand = cli.Command{
 					Name:  "archived",
 					Usage: "<true/false>",
 				},
+				cli.StringFlag{
+					Name:  "permissions",
+					Usage: "<path> to a JSON permission manifest restricting what the script may do",
+				},
 			},
 			Action: gctScriptUpload,
 		},
@@ -3731,9 +3847,125 @@ var gctScriptCommand = cli.Command{
 			},
 			Action: gctScriptAutoload,
 		},
+		{
+			Name:      "state",
+			Usage:     "display the persisted state of a script",
+			ArgsUsage: "<script>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "script",
+					Usage: "<script name>",
+				},
+			},
+			Action: gctScriptGetState,
+		},
+		{
+			Name:      "clearstate",
+			Usage:     "remove a key or all persisted state of a script",
+			ArgsUsage: "<script> <key>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "script",
+					Usage: "<script name>",
+				},
+				cli.StringFlag{
+					Name:  "key",
+					Usage: "the state key to remove, all keys are removed if unset",
+				},
+			},
+			Action: gctScriptClearState,
+		},
+		{
+			Name:      "test",
+			Usage:     "run a script offline against a scenario of mocked responses and expected calls",
+			ArgsUsage: "<script> <scenario>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "script",
+					Usage: "<path> to the script",
+				},
+				cli.StringFlag{
+					Name:  "scenario",
+					Usage: "<path> to the JSON scenario file",
+				},
+			},
+			Action: gctScriptTest,
+		},
 	},
 }
 
+func gctScriptGetState(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		_ = cli.ShowSubcommandHelp(c)
+		return nil
+	}
+
+	var script string
+	if c.IsSet("script") {
+		script = c.String("script")
+	} else {
+		script = c.Args().First()
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+
+	result, err := client.GCTScriptGetState(context.Background(),
+		&gctrpc.GCTScriptGetStateRequest{
+			Script: script,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func gctScriptClearState(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		_ = cli.ShowSubcommandHelp(c)
+		return nil
+	}
+
+	var script string
+	if c.IsSet("script") {
+		script = c.String("script")
+	} else {
+		script = c.Args().First()
+	}
+
+	var key string
+	if c.IsSet("key") {
+		key = c.String("key")
+	} else {
+		key = c.Args().Get(1)
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+
+	result, err := client.GCTScriptClearState(context.Background(),
+		&gctrpc.GCTScriptClearStateRequest{
+			Script: script,
+			Key:    key,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
 func gctScriptAutoload(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
 		_ = cli.ShowSubcommandHelp(c)
@@ -3985,6 +4217,48 @@ func gctScriptQuery(c *cli.Context) error {
 	return nil
 }
 
+func gctScriptTest(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		_ = cli.ShowSubcommandHelp(c)
+		return nil
+	}
+
+	var script string
+	if c.IsSet("script") {
+		script = c.String("script")
+	} else {
+		script = c.Args().First()
+	}
+
+	var scenarioPath string
+	if c.IsSet("scenario") {
+		scenarioPath = c.String("scenario")
+	} else {
+		scenarioPath = c.Args().Get(1)
+	}
+
+	if script == "" || scenarioPath == "" {
+		return errors.New("script and scenario must be set")
+	}
+
+	s, err := scenario.Load(scenarioPath)
+	if err != nil {
+		return err
+	}
+
+	result, err := scenario.Run(script, s)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	if !result.Passed {
+		fmt.Println()
+		return fmt.Errorf("scenario failed for script %s", script)
+	}
+	return nil
+}
+
 func gctScriptUpload(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
 		_ = cli.ShowSubcommandHelp(c)
@@ -4021,6 +4295,27 @@ func gctScriptUpload(c *cli.Context) error {
 		return errors.New("file type must be gct or zip")
 	}
 
+	var permissions *gctrpc.GCTScriptPermissions
+	if c.IsSet("permissions") {
+		manifestData, err := ioutil.ReadFile(c.String("permissions"))
+		if err != nil {
+			return err
+		}
+		var manifest permission.Manifest
+		err = json.Unmarshal(manifestData, &manifest)
+		if err != nil {
+			return err
+		}
+		permissions = &gctrpc.GCTScriptPermissions{
+			Modules:            manifest.Modules,
+			Exchanges:          manifest.Exchanges,
+			Pairs:              manifest.Pairs,
+			MaxOrderNotional:   manifest.MaxOrderNotional,
+			MaxOrdersPerMinute: int64(manifest.MaxOrdersPerMinute),
+			AllowWithdrawals:   manifest.AllowWithdrawals,
+		}
+	}
+
 	file, err := os.Open(filename)
 	if err != nil {
 		return err
@@ -4040,10 +4335,11 @@ func gctScriptUpload(c *cli.Context) error {
 
 	uploadCommand, err := client.GCTScriptUpload(context.Background(),
 		&gctrpc.GCTScriptUploadRequest{
-			ScriptName: filepath.Base(file.Name()),
-			Data:       data,
-			Archived:   archived,
-			Overwrite:  overwrite,
+			ScriptName:  filepath.Base(file.Name()),
+			Data:        data,
+			Archived:    archived,
+			Overwrite:   overwrite,
+			Permissions: permissions,
 		})
 
 	if err != nil {
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli"
+)
+
+var (
+	errNicknameOrIDRequired = errors.New("either a job nickname or id is required")
+	dataHistoryJobType      int64
+)
+
+var dataHistoryCommand = cli.Command{
+	Name:      "datahistory",
+	Usage:     "manage data history jobs to retrieve historic trade or candle data over time",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []cli.Command{
+		{
+			Name:   "getactivejobs",
+			Usage:  "returns all jobs that are currently being processed",
+			Action: getActiveDataHistoryJobs,
+		},
+		{
+			Name:      "getjobdetails",
+			Usage:     "returns a job's details via its nickname or id",
+			ArgsUsage: "<id> or <nickname>",
+			Action:    getDataHistoryJob,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "id",
+					Usage: "the id of the job",
+				},
+				cli.StringFlag{
+					Name:  "nickname, n",
+					Usage: "the nickname of the job",
+				},
+				cli.BoolFlag{
+					Name:  "fulldetails",
+					Usage: "includes the result of every request made by the job",
+				},
+			},
+		},
+		{
+			Name:      "getjobsbetween",
+			Usage:     "returns all jobs created between the start and end dates",
+			ArgsUsage: "<start> <end>",
+			Action:    getDataHistoryJobsBetween,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:        "start",
+					Usage:       "<start>",
+					Value:       time.Now().AddDate(0, -1, 0).Truncate(time.Hour).Format(common.SimpleTimeFormat),
+					Destination: &startTime,
+				},
+				cli.StringFlag{
+					Name:        "end",
+					Usage:       "<end>",
+					Value:       time.Now().Format(common.SimpleTimeFormat),
+					Destination: &endTime,
+				},
+			},
+		},
+		{
+			Name:      "upsertjob",
+			Usage:     "adds a new job, or updates an existing job with the same nickname",
+			ArgsUsage: "<nickname> <exchange> <asset> <pair> <start> <end> <interval> <datatype>",
+			Action:    upsertDataHistoryJob,
+			Flags:     dataHistoryJobFlags,
+		},
+		{
+			Name:      "addnewjob",
+			Usage:     "adds a new job, failing if the nickname is already in use",
+			ArgsUsage: "<nickname> <exchange> <asset> <pair> <start> <end> <interval> <datatype>",
+			Action:    upsertDataHistoryJob,
+			Flags:     dataHistoryJobFlags,
+		},
+		{
+			Name:      "deletejob",
+			Usage:     "sets a job's status to removed so it is no longer processed",
+			ArgsUsage: "<id> or <nickname>",
+			Action:    deleteDataHistoryJob,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "id",
+					Usage: "the id of the job",
+				},
+				cli.StringFlag{
+					Name:  "nickname, n",
+					Usage: "the nickname of the job",
+				},
+			},
+		},
+	},
+}
+
+var dataHistoryJobFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "nickname, n",
+		Usage: "a unique name for the job, used to update or query it",
+	},
+	cli.StringFlag{
+		Name:  "exchange, e",
+		Usage: "the exchange to retrieve data from",
+	},
+	cli.StringFlag{
+		Name:  "asset, a",
+		Usage: "the asset type of the currency pair",
+	},
+	cli.StringFlag{
+		Name:  "pair, p",
+		Usage: "the currency pair",
+	},
+	cli.StringFlag{
+		Name:        "start",
+		Usage:       "<start>",
+		Value:       time.Now().AddDate(0, -1, 0).Truncate(time.Hour).Format(common.SimpleTimeFormat),
+		Destination: &startTime,
+	},
+	cli.StringFlag{
+		Name:        "end",
+		Usage:       "<end>",
+		Value:       time.Now().Truncate(time.Hour).Format(common.SimpleTimeFormat),
+		Destination: &endTime,
+	},
+	cli.Int64Flag{
+		Name:        "interval, i",
+		Usage:       fmt.Sprintf(klineMessage, "interval"),
+		Value:       86400,
+		Destination: &candleGranularity,
+	},
+	cli.Int64Flag{
+		Name:        "datatype",
+		Usage:       "0 for candles, 1 for trades",
+		Destination: &dataHistoryJobType,
+	},
+	cli.Int64Flag{
+		Name:  "request_size_limit",
+		Usage: "the number of candles or trade intervals to request at a time",
+		Value: 500,
+	},
+	cli.Int64Flag{
+		Name:  "max_retry_attempts",
+		Usage: "the number of times a failed interval is retried before the job is failed",
+		Value: 3,
+	},
+	cli.Int64Flag{
+		Name:  "batch_size",
+		Usage: "the number of requests made for the job per cycle",
+		Value: 3,
+	},
+}
+
+func getActiveDataHistoryJobs(_ *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetActiveDataHistoryJobs(context.Background(),
+		&gctrpc.GetInfoRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getDataHistoryJob(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, c.Command.Name)
+	}
+
+	id, nickname := dataHistoryJobIdentifier(c)
+	if id == "" && nickname == "" {
+		return errNicknameOrIDRequired
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetDataHistoryJobDetails(context.Background(),
+		&gctrpc.GetDataHistoryJobDetailsRequest{
+			Id:          id,
+			Nickname:    nickname,
+			FullDetails: c.Bool("fulldetails"),
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getDataHistoryJobsBetween(c *cli.Context) error {
+	if !c.IsSet("start") {
+		if c.Args().Get(0) != "" {
+			startTime = c.Args().Get(0)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(1) != "" {
+			endTime = c.Args().Get(1)
+		}
+	}
+
+	s, err := time.Parse(common.SimpleTimeFormat, startTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+	e, err := time.Parse(common.SimpleTimeFormat, endTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.GetDataHistoryJobsBetween(context.Background(),
+		&gctrpc.GetDataHistoryJobsBetweenRequest{
+			StartDate: negateLocalOffset(s),
+			EndDate:   negateLocalOffset(e),
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func upsertDataHistoryJob(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, c.Command.Name)
+	}
+
+	var nickname string
+	if c.IsSet("nickname") {
+		nickname = c.String("nickname")
+	} else {
+		nickname = c.Args().First()
+	}
+	if nickname == "" {
+		return errNicknameOrIDRequired
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().Get(1)
+	}
+	if !validExchange(exchangeName) {
+		return errInvalidExchange
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(3)
+	}
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p, err := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	if err != nil {
+		return err
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(4) != "" {
+			startTime = c.Args().Get(4)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(5) != "" {
+			endTime = c.Args().Get(5)
+		}
+	}
+
+	if !c.IsSet("interval") && c.Args().Get(6) != "" {
+		candleGranularity, err = strconv.ParseInt(c.Args().Get(6), 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !c.IsSet("datatype") && c.Args().Get(7) != "" {
+		dataHistoryJobType, err = strconv.ParseInt(c.Args().Get(7), 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	s, err := time.Parse(common.SimpleTimeFormat, startTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+	e, err := time.Parse(common.SimpleTimeFormat, endTime)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.UpsertDataHistoryJob(context.Background(),
+		&gctrpc.UpsertDataHistoryJobRequest{
+			Nickname: nickname,
+			Exchange: exchangeName,
+			Asset:    assetType,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			StartDate:        negateLocalOffset(s),
+			EndDate:          negateLocalOffset(e),
+			Interval:         int64(time.Duration(candleGranularity) * time.Second),
+			DataType:         dataHistoryJobType,
+			RequestSizeLimit: c.Int64("request_size_limit"),
+			MaxRetryAttempts: c.Int64("max_retry_attempts"),
+			BatchSize:        c.Int64("batch_size"),
+			InsertOnly:       c.Command.Name == "addnewjob",
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func deleteDataHistoryJob(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowCommandHelp(c, c.Command.Name)
+	}
+
+	id, nickname := dataHistoryJobIdentifier(c)
+	if id == "" && nickname == "" {
+		return errNicknameOrIDRequired
+	}
+
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.DeleteDataHistoryJob(context.Background(),
+		&gctrpc.DeleteDataHistoryJobRequest{
+			Id:       id,
+			Nickname: nickname,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+// dataHistoryJobIdentifier returns the id or nickname supplied by flag, or
+// treats a lone argument as a nickname
+func dataHistoryJobIdentifier(c *cli.Context) (id, nickname string) {
+	if c.IsSet("id") {
+		return c.String("id"), ""
+	}
+	if c.IsSet("nickname") {
+		return "", c.String("nickname")
+	}
+	return "", c.Args().First()
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli"
+)
+
+var runDatabaseRetentionCommand = cli.Command{
+	Name:      "rundatabaseretention",
+	Usage:     "applies the configured database retention policies to old trade and candle data",
+	ArgsUsage: "<dryrun>",
+	Action:    runDatabaseRetention,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "dryrun",
+			Usage: "reports what would be downsampled, archived or deleted without changing any data",
+		},
+	},
+}
+
+func runDatabaseRetention(c *cli.Context) error {
+	conn, err := setupClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = conn.Close()
+		if err != nil {
+			fmt.Print(err)
+		}
+	}()
+
+	client := gctrpc.NewGoCryptoTraderClient(conn)
+	result, err := client.RunDatabaseRetention(context.Background(),
+		&gctrpc.RunDatabaseRetentionRequest{
+			DryRun: c.Bool("dryrun"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
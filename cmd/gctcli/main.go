@@ -20,6 +20,7 @@ var (
 	host          string
 	username      string
 	password      string
+	apiToken      string
 	pairDelimiter string
 	certPath      string
 )
@@ -38,11 +39,15 @@ func setupClient() (*grpc.ClientConn, error) {
 		return nil, err
 	}
 
+	var perRPC credentials.PerRPCCredentials = auth.BasicAuth{
+		Username: username,
+		Password: password,
+	}
+	if apiToken != "" {
+		perRPC = auth.TokenAuth{Token: apiToken}
+	}
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds),
-		grpc.WithPerRPCCredentials(auth.BasicAuth{
-			Username: username,
-			Password: password,
-		}),
+		grpc.WithPerRPCCredentials(perRPC),
 	}
 	conn, err := grpc.Dial(host, opts...)
 	if err != nil {
@@ -77,6 +82,12 @@ func main() {
 			Usage:       "the gRPC password",
 			Destination: &password,
 		},
+		cli.StringFlag{
+			Name:        "rpctoken",
+			Usage:       "a gRPC API token, used instead of the username and password when set",
+			EnvVar:      "GCT_RPC_TOKEN",
+			Destination: &apiToken,
+		},
 		cli.StringFlag{
 			Name:        "delimiter",
 			Value:       "-",
@@ -103,6 +114,10 @@ func main() {
 		getExchangeOTPCommand,
 		getExchangeOTPsCommand,
 		getExchangeInfoCommand,
+		getExchangeHealthCommand,
+		dataHistoryCommand,
+		balanceSnapshotCommand,
+		runDatabaseRetentionCommand,
 		getTickerCommand,
 		getTickersCommand,
 		getOrderbookCommand,
@@ -111,6 +126,7 @@ func main() {
 		getAccountInfoStreamCommand,
 		updateAccountInfoCommand,
 		getConfigCommand,
+		reloadConfigCommand,
 		getPortfolioCommand,
 		getPortfolioSummaryCommand,
 		addPortfolioAddressCommand,
@@ -141,12 +157,14 @@ func main() {
 		getTickerStreamCommand,
 		getExchangeTickerStreamCommand,
 		getAuditEventCommand,
+		verifyAuditLogCommand,
 		getHistoricCandlesCommand,
 		getHistoricCandlesExtendedCommand,
 		findMissingSavedCandleIntervalsCommand,
 		gctScriptCommand,
 		websocketManagerCommand,
 		tradeCommand,
+		apiTokenCommand,
 	}
 
 	err := app.Run(os.Args)
@@ -1,7 +1,26 @@
 package base
 
 import (
+	"sync"
 	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// Event types pushed by the engine
+const (
+	OrderEvent          = "order"
+	ExchangeHealthEvent = "exchange_health"
+	TriggeredEvent      = "event"
+	DigestEvent         = "digest"
+)
+
+// Event severities
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
 )
 
 // global vars contain staged update data that will be sent to the communication
@@ -16,12 +35,33 @@ type Base struct {
 	Enabled   bool
 	Verbose   bool
 	Connected bool
+
+	commands   *CommandRegistry
+	commandMtx sync.RWMutex
 }
 
-// Event is a generalise event type
+// Event is a generalise event type. Relayers that template their messages
+// can use the structured fields, others send the type and message
 type Event struct {
-	Type    string
-	Message string
+	Type      string        `json:"type"`
+	Message   string        `json:"message"`
+	Severity  string        `json:"severity,omitempty"`
+	Exchange  string        `json:"exchange,omitempty"`
+	Pair      currency.Pair `json:"pair,omitempty"`
+	Asset     asset.Item    `json:"asset,omitempty"`
+	Order     *OrderDetails `json:"order,omitempty"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// OrderDetails are the order fields of an order event
+type OrderDetails struct {
+	ID             string  `json:"id"`
+	Side           string  `json:"side,omitempty"`
+	Type           string  `json:"type,omitempty"`
+	Status         string  `json:"status,omitempty"`
+	Price          float64 `json:"price,omitempty"`
+	Amount         float64 `json:"amount,omitempty"`
+	ExecutedAmount float64 `json:"executedAmount,omitempty"`
 }
 
 // CommsStatus stores the status of a comms relayer
@@ -46,6 +86,38 @@ func (b *Base) GetName() string {
 	return b.Name
 }
 
+// SetCommandRegistry sets the chat commands the relayer routes inbound
+// messages to
+func (b *Base) SetCommandRegistry(r *CommandRegistry) {
+	b.commandMtx.Lock()
+	b.commands = r
+	b.commandMtx.Unlock()
+}
+
+// HandleCommand runs a chat command for a user through the command registry.
+// It returns false when no registry has been set
+func (b *Base) HandleCommand(userID, text string) (string, bool) {
+	b.commandMtx.RLock()
+	r := b.commands
+	b.commandMtx.RUnlock()
+	if r == nil {
+		return "", false
+	}
+	return r.Handle(b.Name, userID, text), true
+}
+
+// CommandHelp returns the chat commands a user is authorised to run. It
+// returns an empty string when no registry has been set
+func (b *Base) CommandHelp(userID string) string {
+	b.commandMtx.RLock()
+	r := b.commands
+	b.commandMtx.RUnlock()
+	if r == nil {
+		return ""
+	}
+	return r.Help(b.Name, userID)
+}
+
 // GetStatus returns status data
 func (b *Base) GetStatus() string {
 	return `
@@ -19,6 +19,7 @@ type ICommunicate interface {
 	IsEnabled() bool
 	IsConnected() bool
 	GetName() string
+	SetCommandRegistry(*CommandRegistry)
 }
 
 // Setup sets up communication variables and intiates a connection to the
@@ -50,6 +51,14 @@ func (c IComm) PushEvent(event Event) {
 	}
 }
 
+// SetCommandRegistry sets the chat commands that all relayers route inbound
+// messages to
+func (c IComm) SetCommandRegistry(r *CommandRegistry) {
+	for i := range c {
+		c[i].SetCommandRegistry(r)
+	}
+}
+
 // GetStatus returns the status of the comms relayers
 func (c IComm) GetStatus() map[string]CommsStatus {
 	result := make(map[string]CommsStatus)
@@ -0,0 +1,203 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+// Built in chat commands handled by the registry itself
+const (
+	CommandHelp    = "help"
+	CommandConfirm = "confirm"
+
+	allCommands                = "*"
+	defaultConfirmationTimeout = time.Minute
+)
+
+var (
+	errCommandNameUnset    = errors.New("command name unset")
+	errCommandHandlerUnset = errors.New("command handler unset")
+	errCommandExists       = errors.New("command already registered")
+)
+
+// CommandUser identifies the relayer user a chat command is run for
+type CommandUser struct {
+	Relayer string
+	ID      string
+}
+
+// CommandHandler runs a chat command for a user with the arguments supplied
+// after its name and returns the reply to send back to the user
+type CommandHandler func(user CommandUser, args []string) (string, error)
+
+// Command is a chat command that authorised relayer users can run
+type Command struct {
+	Name        string
+	Usage       string
+	Description string
+	// Destructive commands are only run once the user sends a confirmation
+	Destructive bool
+	Handler     CommandHandler
+}
+
+// CommandRegistry holds the chat commands shared by all relayers and the
+// users authorised to run them
+type CommandRegistry struct {
+	commands       map[string]*Command
+	authorised     map[string]map[string]bool
+	confirmTimeout time.Duration
+	pending        map[string]*pendingCommand
+	m              sync.Mutex
+}
+
+// pendingCommand is a destructive command awaiting confirmation
+type pendingCommand struct {
+	command *Command
+	args    []string
+	expires time.Time
+}
+
+// NewCommandRegistry returns a command registry that only allows the users
+// in the config to run commands
+func NewCommandRegistry(cfg *config.ChatCommandsConfig) *CommandRegistry {
+	r := &CommandRegistry{
+		commands:       make(map[string]*Command),
+		authorised:     make(map[string]map[string]bool),
+		confirmTimeout: cfg.ConfirmationTimeout,
+		pending:        make(map[string]*pendingCommand),
+	}
+	if r.confirmTimeout <= 0 {
+		r.confirmTimeout = defaultConfirmationTimeout
+	}
+	for i := range cfg.AuthorisedUsers {
+		key := userKey(cfg.AuthorisedUsers[i].Relayer, cfg.AuthorisedUsers[i].ID)
+		if r.authorised[key] == nil {
+			r.authorised[key] = make(map[string]bool)
+		}
+		for j := range cfg.AuthorisedUsers[i].Commands {
+			r.authorised[key][strings.ToLower(cfg.AuthorisedUsers[i].Commands[j])] = true
+		}
+	}
+	return r
+}
+
+// Register adds commands to the registry
+func (r *CommandRegistry) Register(cmds ...*Command) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range cmds {
+		if cmds[i].Name == "" {
+			return errCommandNameUnset
+		}
+		if cmds[i].Handler == nil {
+			return fmt.Errorf("%s %w", cmds[i].Name, errCommandHandlerUnset)
+		}
+		name := strings.ToLower(cmds[i].Name)
+		if _, ok := r.commands[name]; ok || name == CommandHelp || name == CommandConfirm {
+			return fmt.Errorf("%s %w", name, errCommandExists)
+		}
+		r.commands[name] = cmds[i]
+	}
+	return nil
+}
+
+// Handle runs the command in an inbound message for a relayer user and
+// returns the reply. A leading "/" or "!" and a trailing "@botname" on the
+// command are ignored
+func (r *CommandRegistry) Handle(relayer, userID, text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "No command supplied"
+	}
+	name := strings.ToLower(strings.TrimLeft(fields[0], "/!"))
+	if i := strings.Index(name, "@"); i > 0 {
+		name = name[:i]
+	}
+	args := fields[1:]
+	key := userKey(relayer, userID)
+	user := CommandUser{Relayer: relayer, ID: userID}
+
+	r.m.Lock()
+	switch name {
+	case CommandHelp:
+		r.m.Unlock()
+		return r.Help(relayer, userID)
+	case CommandConfirm:
+		p, ok := r.pending[key]
+		delete(r.pending, key)
+		r.m.Unlock()
+		if !ok || time.Now().After(p.expires) {
+			return "No command awaiting confirmation"
+		}
+		return run(p.command, user, p.args)
+	}
+	cmd, ok := r.commands[name]
+	if !ok {
+		r.m.Unlock()
+		return fmt.Sprintf("Command %s not recognised, send help for a list of commands", name)
+	}
+	if !r.isAuthorised(key, name) {
+		r.m.Unlock()
+		return fmt.Sprintf("You are not authorised to run %s", name)
+	}
+	if cmd.Destructive {
+		r.pending[key] = &pendingCommand{
+			command: cmd,
+			args:    args,
+			expires: time.Now().Add(r.confirmTimeout),
+		}
+		r.m.Unlock()
+		return fmt.Sprintf("Send %s within %s to run: %s",
+			CommandConfirm,
+			r.confirmTimeout,
+			strings.Join(append([]string{name}, args...), " "))
+	}
+	r.m.Unlock()
+	return run(cmd, user, args)
+}
+
+// Help returns the commands a relayer user is authorised to run
+func (r *CommandRegistry) Help(relayer, userID string) string {
+	r.m.Lock()
+	defer r.m.Unlock()
+	key := userKey(relayer, userID)
+	var lines []string
+	for name, cmd := range r.commands {
+		if !r.isAuthorised(key, name) {
+			continue
+		}
+		line := name
+		if cmd.Usage != "" {
+			line += " " + cmd.Usage
+		}
+		lines = append(lines, line+" - "+cmd.Description)
+	}
+	if len(lines) == 0 {
+		return "You are not authorised to run any commands"
+	}
+	sort.Strings(lines)
+	return "Available commands:\n" + strings.Join(lines, "\n")
+}
+
+// isAuthorised must be called with the lock held
+func (r *CommandRegistry) isAuthorised(key, name string) bool {
+	return r.authorised[key][name] || r.authorised[key][allCommands]
+}
+
+func run(cmd *Command, user CommandUser, args []string) string {
+	reply, err := cmd.Handler(user, args)
+	if err != nil {
+		return fmt.Sprintf("%s failed: %v", cmd.Name, err)
+	}
+	return reply
+}
+
+func userKey(relayer, userID string) string {
+	return strings.ToLower(relayer) + ":" + userID
+}
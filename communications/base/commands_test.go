@@ -0,0 +1,137 @@
+package base
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+func testRegistry(t *testing.T) (*CommandRegistry, *int) {
+	t.Helper()
+	r := NewCommandRegistry(&config.ChatCommandsConfig{
+		ConfirmationTimeout: time.Minute,
+		AuthorisedUsers: []config.ChatCommandUser{
+			{Relayer: "Telegram", ID: "1", Commands: []string{"*"}},
+			{Relayer: "Slack", ID: "U2", Commands: []string{"Balances"}},
+		},
+	})
+	var cancelled int
+	err := r.Register(&Command{
+		Name:        "balances",
+		Usage:       "<exchange>",
+		Description: "shows balances",
+		Handler: func(_ CommandUser, args []string) (string, error) {
+			if len(args) == 0 {
+				return "", errors.New("exchange required")
+			}
+			return "balances for " + args[0], nil
+		},
+	}, &Command{
+		Name:        "cancelall",
+		Description: "cancels all orders",
+		Destructive: true,
+		Handler: func(_ CommandUser, args []string) (string, error) {
+			cancelled++
+			return "cancelled", nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, &cancelled
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+	r, _ := testRegistry(t)
+	if err := r.Register(&Command{}); !errors.Is(err, errCommandNameUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errCommandNameUnset)
+	}
+	if err := r.Register(&Command{Name: "test"}); !errors.Is(err, errCommandHandlerUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errCommandHandlerUnset)
+	}
+	handler := func(CommandUser, []string) (string, error) { return "", nil }
+	if err := r.Register(&Command{Name: "Balances", Handler: handler}); !errors.Is(err, errCommandExists) {
+		t.Errorf("received '%v' expected '%v'", err, errCommandExists)
+	}
+	if err := r.Register(&Command{Name: CommandConfirm, Handler: handler}); !errors.Is(err, errCommandExists) {
+		t.Errorf("received '%v' expected '%v'", err, errCommandExists)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	t.Parallel()
+	r, cancelled := testRegistry(t)
+	if reply := r.Handle("telegram", "1", "  "); reply != "No command supplied" {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if reply := r.Handle("Telegram", "1", "/balances@gctbot binance"); reply != "balances for binance" {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if reply := r.Handle("Telegram", "1", "/balances"); !strings.Contains(reply, "exchange required") {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if reply := r.Handle("Telegram", "1", "/notacommand"); !strings.Contains(reply, "not recognised") {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if reply := r.Handle("Telegram", "2", "/balances binance"); !strings.Contains(reply, "not authorised") {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if reply := r.Handle("Slack", "U2", "!cancelall"); !strings.Contains(reply, "not authorised") {
+		t.Errorf("unexpected reply %q", reply)
+	}
+
+	if reply := r.Handle("Telegram", "1", "/cancelall"); !strings.Contains(reply, CommandConfirm) {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if *cancelled != 0 {
+		t.Fatal("destructive command ran before confirmation")
+	}
+	// a confirmation from another user must not run the pending command
+	if reply := r.Handle("Slack", "U2", "!confirm"); reply != "No command awaiting confirmation" {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if reply := r.Handle("Telegram", "1", "/confirm"); reply != "cancelled" || *cancelled != 1 {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if reply := r.Handle("Telegram", "1", "/confirm"); reply != "No command awaiting confirmation" {
+		t.Errorf("unexpected reply %q", reply)
+	}
+
+	r.confirmTimeout = -time.Second
+	r.Handle("Telegram", "1", "/cancelall")
+	if reply := r.Handle("Telegram", "1", "/confirm"); reply != "No command awaiting confirmation" || *cancelled != 1 {
+		t.Errorf("expired confirmation should not run, received %q", reply)
+	}
+}
+
+func TestHelp(t *testing.T) {
+	t.Parallel()
+	r, _ := testRegistry(t)
+	help := r.Handle("Slack", "U2", "!help")
+	if !strings.Contains(help, "balances <exchange> - shows balances") || strings.Contains(help, "cancelall") {
+		t.Errorf("unexpected help %q", help)
+	}
+	if help = r.Help("Slack", "nobody"); help != "You are not authorised to run any commands" {
+		t.Errorf("unexpected help %q", help)
+	}
+
+	var b Base
+	b.Name = "Telegram"
+	if _, ok := b.HandleCommand("1", "/balances binance"); ok {
+		t.Error("expected command to be unhandled without a registry")
+	}
+	if b.CommandHelp("1") != "" {
+		t.Error("expected no help without a registry")
+	}
+	b.SetCommandRegistry(r)
+	if reply, ok := b.HandleCommand("1", "/balances binance"); !ok || reply != "balances for binance" {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if !strings.Contains(b.CommandHelp("1"), "cancelall") {
+		t.Error("expected help to include destructive commands for authorised user")
+	}
+}
@@ -4,16 +4,19 @@ import (
 	"errors"
 
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/communications/discord"
 	"github.com/thrasher-corp/gocryptotrader/communications/slack"
 	"github.com/thrasher-corp/gocryptotrader/communications/smsglobal"
 	"github.com/thrasher-corp/gocryptotrader/communications/smtpservice"
 	"github.com/thrasher-corp/gocryptotrader/communications/telegram"
+	"github.com/thrasher-corp/gocryptotrader/communications/webhook"
 	"github.com/thrasher-corp/gocryptotrader/config"
 )
 
 // Communications is the overarching type across the communications packages
 type Communications struct {
 	base.IComm
+	router *Router
 }
 
 // NewComm sets up and returns a pointer to a Communications object
@@ -47,6 +50,48 @@ func NewComm(cfg *config.CommunicationsConfig) (*Communications, error) {
 		comm.IComm = append(comm.IComm, Slack)
 	}
 
+	if cfg.WebhookConfig.Enabled {
+		Webhook := new(webhook.Webhook)
+		Webhook.Setup(cfg)
+		comm.IComm = append(comm.IComm, Webhook)
+	}
+
+	for i := range cfg.Webhooks {
+		if !cfg.Webhooks[i].Enabled {
+			continue
+		}
+		Webhook := new(webhook.Webhook)
+		Webhook.SetupWebhook(&cfg.Webhooks[i])
+		comm.IComm = append(comm.IComm, Webhook)
+	}
+
+	if cfg.DiscordConfig.Enabled {
+		Discord := new(discord.Discord)
+		Discord.Setup(cfg)
+		comm.IComm = append(comm.IComm, Discord)
+	}
+
 	comm.Setup()
+	comm.router = NewRouter(&cfg.Routing, comm.IComm)
 	return &comm, nil
 }
+
+// PushEvent sends an event to the relayers selected by the routing rules
+func (c *Communications) PushEvent(e base.Event) {
+	c.router.Push(e)
+}
+
+// Process flushes due event digests and retries failed deliveries
+func (c *Communications) Process() {
+	c.router.Process()
+}
+
+// Stop stops the relayer delivery workers once queued events have been sent
+func (c *Communications) Stop() {
+	c.router.Stop()
+}
+
+// RetryQueueLength returns the number of events awaiting redelivery
+func (c *Communications) RetryQueueLength() int {
+	return c.router.RetryQueueLength()
+}
@@ -17,13 +17,28 @@ func TestNewComm(t *testing.T) {
 	cfg.SMSGlobalConfig.Enabled = true
 	cfg.SMTPConfig.Enabled = true
 	cfg.SlackConfig.Enabled = true
+	cfg.WebhookConfig.Enabled = true
+	cfg.DiscordConfig.Enabled = true
 	communications, err := NewComm(&cfg)
 	if err != nil {
 		t.Error("Unexpected result")
 	}
 
-	if len(communications.IComm) != 4 {
-		t.Errorf("communications NewComm, expected len 4, got len %d",
+	if len(communications.IComm) != 6 {
+		t.Errorf("communications NewComm, expected len 6, got len %d",
 			len(communications.IComm))
 	}
+	cfg = config.CommunicationsConfig{
+		Webhooks: []config.WebhookConfig{
+			{Name: "Alerts", Enabled: true, URL: "http://localhost"},
+			{Name: "Orders", URL: "http://localhost"},
+		},
+	}
+	communications, err = NewComm(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(communications.IComm) != 1 || communications.IComm[0].GetName() != "Alerts" {
+		t.Errorf("expected only the enabled webhook to be added, received %d relayers", len(communications.IComm))
+	}
 }
@@ -0,0 +1,130 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/communications/webhook"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Setup takes in a Discord configuration and sets the webhook URL and message
+// template
+func (d *Discord) Setup(cfg *config.CommunicationsConfig) {
+	d.Name = cfg.DiscordConfig.Name
+	d.Enabled = cfg.DiscordConfig.Enabled
+	d.Verbose = cfg.DiscordConfig.Verbose
+	d.WebhookURL = cfg.DiscordConfig.WebhookURL
+	d.Username = cfg.DiscordConfig.Username
+	d.MessageTemplate = cfg.DiscordConfig.MessageTemplate
+	if d.MessageTemplate == "" {
+		d.MessageTemplate = defaultMessageTemplate
+	}
+	d.EventTemplates = make(map[string]string, len(cfg.DiscordConfig.EventTemplates))
+	for k, v := range cfg.DiscordConfig.EventTemplates {
+		d.EventTemplates[k] = v
+	}
+	d.client = &http.Client{Timeout: time.Second * 10}
+}
+
+// Connect compiles the message templates
+func (d *Discord) Connect() error {
+	if d.WebhookURL == "" {
+		return errWebhookURLUnset
+	}
+	tmpl, err := webhook.NewTemplates(d.Name, d.MessageTemplate, d.EventTemplates)
+	if err != nil {
+		return err
+	}
+	if d.client == nil {
+		d.client = &http.Client{Timeout: time.Second * 10}
+	}
+	d.tmpl = tmpl
+	d.Connected = true
+	return nil
+}
+
+// PushEvent sends an event to the Discord channel as a message with an embed
+// holding the event details
+func (d *Discord) PushEvent(e base.Event) error {
+	if d.tmpl == nil {
+		return errNotConnected
+	}
+	var content bytes.Buffer
+	if err := d.tmpl.Execute(&content, e); err != nil {
+		return fmt.Errorf("%s unable to execute template for event %s: %v", d.Name, e.Type, err)
+	}
+	msg := Message{
+		Content:  truncate(content.String(), maxContentLength),
+		Username: d.Username,
+		Embeds:   []Embed{buildEmbed(&e)},
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if d.Verbose {
+		log.Debugf(log.CommunicationMgr, "%s: Sending %s\n", d.Name, payload)
+	}
+	return webhook.Send(d.client, http.MethodPost, d.WebhookURL, nil, bytes.NewReader(payload))
+}
+
+// buildEmbed returns an embed coloured by the event severity with a field for
+// each structured value set on the event
+func buildEmbed(e *base.Event) Embed {
+	embed := Embed{
+		Title:       e.Type,
+		Description: truncate(e.Message, maxDescriptionLength),
+		Colour:      colourInfo,
+	}
+	switch e.Severity {
+	case base.SeverityWarning:
+		embed.Colour = colourWarning
+	case base.SeverityCritical:
+		embed.Colour = colourCritical
+	}
+	if !e.Timestamp.IsZero() {
+		embed.Timestamp = e.Timestamp.UTC().Format(time.RFC3339)
+	}
+	addField := func(name, value string) {
+		if value != "" {
+			embed.Fields = append(embed.Fields, EmbedField{Name: name, Value: value, Inline: true})
+		}
+	}
+	addField("Severity", e.Severity)
+	addField("Exchange", e.Exchange)
+	if !e.Pair.IsEmpty() {
+		addField("Pair", e.Pair.String())
+	}
+	addField("Asset", e.Asset.String())
+	if e.Order != nil {
+		addField("Order ID", e.Order.ID)
+		addField("Side", e.Order.Side)
+		addField("Type", e.Order.Type)
+		addField("Status", e.Order.Status)
+		if e.Order.Price != 0 {
+			addField("Price", strconv.FormatFloat(e.Order.Price, 'f', -1, 64))
+		}
+		if e.Order.Amount != 0 {
+			addField("Amount", strconv.FormatFloat(e.Order.Amount, 'f', -1, 64))
+		}
+		if e.Order.ExecutedAmount != 0 {
+			addField("Executed", strconv.FormatFloat(e.Order.ExecutedAmount, 'f', -1, 64))
+		}
+	}
+	return embed
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-3]) + "..."
+}
@@ -0,0 +1,146 @@
+package discord
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestSetup(t *testing.T) {
+	t.Parallel()
+	var d Discord
+	d.Setup(&config.CommunicationsConfig{
+		DiscordConfig: config.DiscordConfig{
+			Name:       "Discord",
+			Enabled:    true,
+			WebhookURL: "http://localhost",
+		},
+	})
+	if d.MessageTemplate != defaultMessageTemplate {
+		t.Errorf("received '%v' expected '%v'", d.MessageTemplate, defaultMessageTemplate)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	t.Parallel()
+	var d Discord
+	if err := d.Connect(); !errors.Is(err, errWebhookURLUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errWebhookURLUnset)
+	}
+	d.WebhookURL = "http://localhost"
+	d.MessageTemplate = defaultMessageTemplate
+	if err := d.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if !d.IsConnected() {
+		t.Error("expected Discord to be connected")
+	}
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	ch := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		ch <- b
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var d Discord
+	d.Setup(&config.CommunicationsConfig{
+		DiscordConfig: config.DiscordConfig{
+			Name:       "Discord",
+			Enabled:    true,
+			WebhookURL: srv.URL,
+			Username:   "gct",
+			EventTemplates: map[string]string{
+				base.OrderEvent: "order {{.Order.ID}} {{.Order.Status}}",
+			},
+		},
+	})
+	if err := d.PushEvent(base.Event{}); !errors.Is(err, errNotConnected) {
+		t.Errorf("received '%v' expected '%v'", err, errNotConnected)
+	}
+	if err := d.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	err := d.PushEvent(base.Event{
+		Type:      base.ExchangeHealthEvent,
+		Message:   "Bitstamp is down",
+		Severity:  base.SeverityCritical,
+		Exchange:  "Bitstamp",
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		Asset:     asset.Spot,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg Message
+	if err = json.Unmarshal(<-ch, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Content != "exchange_health: Bitstamp is down" || msg.Username != "gct" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+	if len(msg.Embeds) != 1 || msg.Embeds[0].Colour != colourCritical {
+		t.Fatalf("unexpected embeds %+v", msg.Embeds)
+	}
+	if len(msg.Embeds[0].Fields) != 4 {
+		t.Errorf("received '%v' expected '%v'", len(msg.Embeds[0].Fields), 4)
+	}
+
+	err = d.PushEvent(base.Event{
+		Type:  base.OrderEvent,
+		Order: &base.OrderDetails{ID: "1337", Status: "FILLED"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(<-ch, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Content != "order 1337 FILLED" {
+		t.Errorf("received '%v' expected '%v'", msg.Content, "order 1337 FILLED")
+	}
+}
+
+func TestBuildEmbed(t *testing.T) {
+	t.Parallel()
+	e := buildEmbed(&base.Event{
+		Type:     base.OrderEvent,
+		Severity: base.SeverityWarning,
+		Order:    &base.OrderDetails{ID: "1", Side: "BUY", Price: 1.5, Amount: 2},
+	})
+	if e.Colour != colourWarning {
+		t.Errorf("received '%v' expected '%v'", e.Colour, colourWarning)
+	}
+	// severity, order ID, side, price and amount
+	if len(e.Fields) != 5 {
+		t.Errorf("received '%v' expected '%v'", len(e.Fields), 5)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+	if s := truncate(strings.Repeat("a", maxContentLength+1), maxContentLength); len(s) != maxContentLength {
+		t.Errorf("received '%v' expected '%v'", len(s), maxContentLength)
+	}
+	if s := truncate("abc", maxContentLength); s != "abc" {
+		t.Errorf("received '%v' expected '%v'", s, "abc")
+	}
+}
@@ -0,0 +1,59 @@
+package discord
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/communications/webhook"
+)
+
+const (
+	defaultMessageTemplate = "{{.Type}}: {{.Message}}"
+	maxContentLength       = 2000
+	maxDescriptionLength   = 4096
+
+	colourInfo     = 0x3498db
+	colourWarning  = 0xf1c40f
+	colourCritical = 0xe74c3c
+)
+
+var (
+	errWebhookURLUnset = errors.New("discord webhook URL unset")
+	errNotConnected    = errors.New("discord template not compiled, connect first")
+)
+
+// Discord sends events to a Discord channel through an incoming webhook
+type Discord struct {
+	base.Base
+	WebhookURL      string
+	Username        string
+	MessageTemplate string
+	EventTemplates  map[string]string
+
+	client *http.Client
+	tmpl   *webhook.Templates
+}
+
+// Message is a Discord webhook execution request
+type Message struct {
+	Content  string  `json:"content"`
+	Username string  `json:"username,omitempty"`
+	Embeds   []Embed `json:"embeds,omitempty"`
+}
+
+// Embed is a Discord rich embed
+type Embed struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Colour      int          `json:"color"`
+	Timestamp   string       `json:"timestamp,omitempty"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+}
+
+// EmbedField is a name and value shown in an embed
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
@@ -0,0 +1,333 @@
+package communications
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// NewRouter returns a router for the supplied relayers
+func NewRouter(cfg *config.CommsRoutingConfig, relayers base.IComm) *Router {
+	r := &Router{
+		relayers:   relayers,
+		maxRetries: cfg.MaxRetries,
+		retryDelay: cfg.RetryDelay,
+		now:        time.Now,
+	}
+	for i := range cfg.Rules {
+		rt := &route{
+			CommsRouteRule: cfg.Rules[i],
+			eventTypes:     make(map[string]bool),
+			severities:     make(map[string]bool),
+			lastSeen:       make(map[string]time.Time),
+		}
+		for j := range cfg.Rules[i].EventTypes {
+			rt.eventTypes[strings.ToLower(cfg.Rules[i].EventTypes[j])] = true
+		}
+		for j := range cfg.Rules[i].Severities {
+			rt.severities[strings.ToLower(cfg.Rules[i].Severities[j])] = true
+		}
+		r.rules = append(r.rules, rt)
+	}
+	return r
+}
+
+// Push routes an event to relayers. Without rules the event is sent to every
+// enabled relayer, otherwise the first matching rule decides whether and
+// where it is sent. An event matching no rule is logged and dropped, a rule
+// without event types or severities can be added last to catch them
+func (r *Router) Push(e base.Event) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	now := r.now()
+	if len(r.rules) == 0 {
+		r.deliver(r.relayers, e)
+		return
+	}
+	for i := range r.rules {
+		if !r.rules[i].matches(&e) {
+			continue
+		}
+		r.route(r.rules[i], e, now)
+		return
+	}
+	log.Debugf(log.CommunicationMgr, "Communications: no routing rule matches %s event, dropping it\n", e.Type)
+}
+
+// Process flushes digests that are due and redelivers queued events. It is
+// called periodically by the communications manager
+func (r *Router) Process() {
+	r.m.Lock()
+	defer r.m.Unlock()
+	now := r.now()
+	for i := range r.rules {
+		rt := r.rules[i]
+		if rt.DedupWindow > 0 {
+			for k, seen := range rt.lastSeen {
+				if now.Sub(seen) >= rt.DedupWindow {
+					delete(rt.lastSeen, k)
+				}
+			}
+		}
+		if rt.DigestInterval > 0 && now.Sub(rt.lastDigest) >= rt.DigestInterval {
+			r.flushDigest(rt, now)
+		}
+	}
+
+	// retries beyond the per tick cap stay queued for the next tick so that a
+	// backlog of failures is not resent all at once
+	var pending []*retry
+	var sent int
+	for i := range r.retries {
+		if sent >= maxRetriesPerProcess || now.Before(r.retries[i].next) {
+			pending = append(pending, r.retries[i])
+			continue
+		}
+		sent++
+		r.dispatch(&delivery{
+			relayer:  r.retries[i].relayer,
+			event:    r.retries[i].event,
+			attempts: r.retries[i].attempts,
+			retry:    true,
+		})
+	}
+	r.retries = pending
+}
+
+// Stop sends any batched digests and waits for the relayer workers to send
+// their queued deliveries before stopping them. Events pushed afterwards and
+// deliveries that fail while stopping are dropped
+func (r *Router) Stop() {
+	r.m.Lock()
+	if r.stopped {
+		r.m.Unlock()
+		return
+	}
+	now := r.now()
+	for i := range r.rules {
+		if r.rules[i].DigestInterval > 0 {
+			r.flushDigest(r.rules[i], now)
+		}
+	}
+	r.stopped = true
+	for _, w := range r.workers {
+		close(w)
+	}
+	r.workers = nil
+	r.m.Unlock()
+	// the workers take the lock when a delivery fails so it cannot be held
+	// while waiting on them
+	r.inflight.Wait()
+}
+
+// RetryQueueLength returns the number of events awaiting redelivery
+func (r *Router) RetryQueueLength() int {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return len(r.retries)
+}
+
+// route applies a rule's throttling to an event. Must be called with the lock
+// held
+func (r *Router) route(rt *route, e base.Event, now time.Time) {
+	if rt.DedupWindow > 0 {
+		key := e.Type + "|" + e.Exchange + "|" + e.Message
+		if seen, ok := rt.lastSeen[key]; ok && now.Sub(seen) < rt.DedupWindow {
+			return
+		}
+		rt.lastSeen[key] = now
+	}
+
+	if rt.RateLimit > 0 {
+		if now.Sub(rt.windowStart) >= rt.RateLimitInterval {
+			if rt.suppressed > 0 {
+				log.Warnf(log.CommunicationMgr, "Communications: routing %s suppressed %d events over its rate limit\n",
+					rt.Name, rt.suppressed)
+			}
+			rt.windowStart = now
+			rt.windowCount = 0
+			rt.suppressed = 0
+		}
+		if rt.windowCount >= rt.RateLimit {
+			rt.suppressed++
+			return
+		}
+		rt.windowCount++
+	}
+
+	if rt.DigestInterval > 0 {
+		if rt.lastDigest.IsZero() {
+			rt.lastDigest = now
+		}
+		rt.digest = append(rt.digest, e)
+		return
+	}
+	r.deliver(r.targets(rt), e)
+}
+
+// flushDigest sends a rule's batched events as a single event. Must be called
+// with the lock held
+func (r *Router) flushDigest(rt *route, now time.Time) {
+	rt.lastDigest = now
+	if len(rt.digest) == 0 {
+		return
+	}
+	if len(rt.digest) == 1 {
+		r.deliver(r.targets(rt), rt.digest[0])
+		rt.digest = nil
+		return
+	}
+	digest := base.Event{
+		Type:      base.DigestEvent,
+		Severity:  base.SeverityInfo,
+		Timestamp: now,
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d events since %s:", len(rt.digest), rt.digest[0].Timestamp.Format(time.RFC3339))
+	for i := range rt.digest {
+		if severityRank(rt.digest[i].Severity) > severityRank(digest.Severity) {
+			digest.Severity = rt.digest[i].Severity
+		}
+		if i >= maxDigestEvents {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n[%s] %s: %s", rt.digest[i].Severity, rt.digest[i].Type, rt.digest[i].Message)
+	}
+	if len(rt.digest) > maxDigestEvents {
+		fmt.Fprintf(&sb, "\n...and %d more", len(rt.digest)-maxDigestEvents)
+	}
+	digest.Message = sb.String()
+	rt.digest = nil
+	r.deliver(r.targets(rt), digest)
+}
+
+// deliver queues an event for each enabled and connected relayer. Must be
+// called with the lock held
+func (r *Router) deliver(relayers base.IComm, e base.Event) {
+	for i := range relayers {
+		if !relayers[i].IsEnabled() || !relayers[i].IsConnected() {
+			continue
+		}
+		r.dispatch(&delivery{relayer: relayers[i], event: e})
+	}
+}
+
+// dispatch hands a delivery to its relayer's worker, starting the worker on
+// first use. Deliveries are never sent with the lock held so that a slow
+// relayer cannot hold up routing or the other relayers. Must be called with
+// the lock held
+func (r *Router) dispatch(d *delivery) {
+	if r.stopped {
+		return
+	}
+	w, ok := r.workers[d.relayer]
+	if !ok {
+		if r.workers == nil {
+			r.workers = make(map[base.ICommunicate]chan *delivery)
+		}
+		w = make(chan *delivery, relayerQueueSize)
+		r.workers[d.relayer] = w
+		go r.work(w)
+	}
+	r.inflight.Add(1)
+	select {
+	case w <- d:
+	default:
+		r.inflight.Done()
+		r.failed(d, errRelayerQueueFull)
+	}
+}
+
+// work sends the deliveries queued for a single relayer in order
+func (r *Router) work(queue <-chan *delivery) {
+	for d := range queue {
+		if err := d.relayer.PushEvent(d.event); err != nil {
+			r.m.Lock()
+			r.failed(d, err)
+			r.m.Unlock()
+		}
+		r.inflight.Done()
+	}
+}
+
+// failed queues a failed delivery for retry with an exponential backoff until
+// the max retries are reached. Must be called with the lock held
+func (r *Router) failed(d *delivery, err error) {
+	now := r.now()
+	next := now.Add(r.retryDelay)
+	if d.retry {
+		d.attempts++
+		if d.attempts >= r.maxRetries {
+			log.Errorf(log.CommunicationMgr, "Communications: %s giving up on event %s after %d attempts. Err: %s\n",
+				d.relayer.GetName(), d.event.Type, d.attempts, err)
+			return
+		}
+		next = now.Add(r.retryDelay << uint(d.attempts))
+	} else {
+		log.Errorf(log.CommunicationMgr, "Communications error - PushEvent() in package %s with %v. Err %s\n",
+			d.relayer.GetName(), d.event, err)
+	}
+	if r.maxRetries <= 0 || r.stopped {
+		return
+	}
+	if len(r.retries) >= maxRetryQueue {
+		log.Warnf(log.CommunicationMgr, "Communications: retry queue full, dropping %s event for %s\n",
+			r.retries[0].event.Type, r.retries[0].relayer.GetName())
+		r.retries = r.retries[1:]
+	}
+	r.retries = append(r.retries, &retry{
+		relayer:  d.relayer,
+		event:    d.event,
+		attempts: d.attempts,
+		next:     next,
+	})
+}
+
+// targets returns the relayers a rule sends to. Must be called with the lock
+// held
+func (r *Router) targets(rt *route) base.IComm {
+	if len(rt.Relayers) == 0 {
+		return r.relayers
+	}
+	var targets base.IComm
+	for i := range r.relayers {
+		for j := range rt.Relayers {
+			if strings.EqualFold(r.relayers[i].GetName(), rt.Relayers[j]) {
+				targets = append(targets, r.relayers[i])
+				break
+			}
+		}
+	}
+	return targets
+}
+
+// matches returns whether an event is handled by a rule
+func (rt *route) matches(e *base.Event) bool {
+	if len(rt.eventTypes) > 0 && !rt.eventTypes[strings.ToLower(e.Type)] {
+		return false
+	}
+	if len(rt.severities) > 0 {
+		severity := e.Severity
+		if severity == "" {
+			severity = base.SeverityInfo
+		}
+		if !rt.severities[strings.ToLower(severity)] {
+			return false
+		}
+	}
+	return true
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case base.SeverityWarning:
+		return 1
+	case base.SeverityCritical:
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,324 @@
+package communications
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+var errDeliveryFailed = errors.New("delivery failed")
+
+type testRelayer struct {
+	base.Base
+	events []base.Event
+	fail   int
+}
+
+func newTestRelayer(name string) *testRelayer {
+	r := &testRelayer{}
+	r.Name = name
+	r.Enabled = true
+	r.Connected = true
+	return r
+}
+
+func (r *testRelayer) Setup(*config.CommunicationsConfig) {}
+
+func (r *testRelayer) Connect() error { return nil }
+
+func (r *testRelayer) PushEvent(e base.Event) error {
+	if r.fail > 0 {
+		r.fail--
+		return errDeliveryFailed
+	}
+	r.events = append(r.events, e)
+	return nil
+}
+
+type testClock struct {
+	t time.Time
+}
+
+func (c *testClock) now() time.Time { return c.t }
+
+func testRouter(cfg *config.CommsRoutingConfig) (*Router, *testRelayer, *testRelayer, *testClock) {
+	telegram := newTestRelayer("Telegram")
+	slack := newTestRelayer("Slack")
+	r := NewRouter(cfg, base.IComm{telegram, slack})
+	clock := &testClock{t: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r.now = clock.now
+	return r, telegram, slack, clock
+}
+
+// push routes an event and waits for the relayer workers to send it
+func push(r *Router, e base.Event) {
+	r.Push(e)
+	r.inflight.Wait()
+}
+
+// process runs a router tick and waits for the relayer workers to send any
+// flushed digests and retries
+func process(r *Router) {
+	r.Process()
+	r.inflight.Wait()
+}
+
+func TestRouterBroadcast(t *testing.T) {
+	t.Parallel()
+	r, telegram, slack, _ := testRouter(&config.CommsRoutingConfig{})
+	push(r, base.Event{Type: base.OrderEvent, Message: "test"})
+	if len(telegram.events) != 1 || len(slack.events) != 1 {
+		t.Errorf("expected event to be broadcast, received %d %d", len(telegram.events), len(slack.events))
+	}
+}
+
+func TestRouterRules(t *testing.T) {
+	t.Parallel()
+	r, telegram, slack, _ := testRouter(&config.CommsRoutingConfig{
+		Rules: []config.CommsRouteRule{
+			{Name: "critical", Severities: []string{base.SeverityCritical}, Relayers: []string{"telegram"}},
+			{Name: "health", EventTypes: []string{base.ExchangeHealthEvent}},
+		},
+	})
+	push(r, base.Event{Type: base.OrderEvent, Severity: base.SeverityCritical})
+	push(r, base.Event{Type: base.ExchangeHealthEvent, Severity: base.SeverityWarning})
+	push(r, base.Event{Type: base.OrderEvent})
+	if len(telegram.events) != 2 {
+		t.Errorf("received '%v' expected '%v'", len(telegram.events), 2)
+	}
+	if len(slack.events) != 1 || slack.events[0].Type != base.ExchangeHealthEvent {
+		t.Errorf("unexpected slack events %+v", slack.events)
+	}
+}
+
+func TestRouterDedup(t *testing.T) {
+	t.Parallel()
+	r, telegram, _, clock := testRouter(&config.CommsRoutingConfig{
+		Rules: []config.CommsRouteRule{{DedupWindow: time.Minute}},
+	})
+	e := base.Event{Type: base.ExchangeHealthEvent, Exchange: "Bitstamp", Message: "down"}
+	push(r, e)
+	push(r, e)
+	push(r, base.Event{Type: base.ExchangeHealthEvent, Exchange: "Bitstamp", Message: "up"})
+	if len(telegram.events) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(telegram.events), 2)
+	}
+	clock.t = clock.t.Add(time.Minute)
+	process(r)
+	if len(r.rules[0].lastSeen) != 0 {
+		t.Error("expected expired dedup entries to be removed")
+	}
+	push(r, e)
+	if len(telegram.events) != 3 {
+		t.Errorf("received '%v' expected '%v'", len(telegram.events), 3)
+	}
+}
+
+func TestRouterRateLimit(t *testing.T) {
+	t.Parallel()
+	r, telegram, _, clock := testRouter(&config.CommsRoutingConfig{
+		Rules: []config.CommsRouteRule{{RateLimit: 2, RateLimitInterval: time.Minute}},
+	})
+	for i := 0; i < 5; i++ {
+		push(r, base.Event{Type: base.OrderEvent})
+	}
+	if len(telegram.events) != 2 || r.rules[0].suppressed != 3 {
+		t.Fatalf("received '%v' suppressed '%v'", len(telegram.events), r.rules[0].suppressed)
+	}
+	clock.t = clock.t.Add(time.Minute)
+	push(r, base.Event{Type: base.OrderEvent})
+	if len(telegram.events) != 3 || r.rules[0].suppressed != 0 {
+		t.Errorf("received '%v' suppressed '%v'", len(telegram.events), r.rules[0].suppressed)
+	}
+}
+
+func TestRouterDigest(t *testing.T) {
+	t.Parallel()
+	r, telegram, _, clock := testRouter(&config.CommsRoutingConfig{
+		Rules: []config.CommsRouteRule{{DigestInterval: time.Minute}},
+	})
+	push(r, base.Event{Type: base.OrderEvent, Message: "added order 1", Timestamp: clock.t})
+	push(r, base.Event{Type: base.OrderEvent, Message: "added order 2", Severity: base.SeverityWarning})
+	process(r)
+	if len(telegram.events) != 0 {
+		t.Fatal("digest sent before its interval")
+	}
+	clock.t = clock.t.Add(time.Minute)
+	process(r)
+	if len(telegram.events) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(telegram.events), 1)
+	}
+	d := telegram.events[0]
+	if d.Type != base.DigestEvent || d.Severity != base.SeverityWarning ||
+		!strings.Contains(d.Message, "added order 1") || !strings.Contains(d.Message, "added order 2") {
+		t.Errorf("unexpected digest %+v", d)
+	}
+
+	for i := 0; i < maxDigestEvents+5; i++ {
+		push(r, base.Event{Type: base.OrderEvent})
+	}
+	clock.t = clock.t.Add(time.Minute)
+	process(r)
+	if len(telegram.events) != 2 || !strings.HasSuffix(telegram.events[1].Message, "...and 5 more") {
+		t.Errorf("unexpected digest %+v", telegram.events[1])
+	}
+
+	push(r, base.Event{Type: base.OrderEvent, Message: "single"})
+	clock.t = clock.t.Add(time.Minute)
+	process(r)
+	if len(telegram.events) != 3 || telegram.events[2].Message != "single" {
+		t.Error("expected a single batched event to be sent unchanged")
+	}
+}
+
+func TestRouterRetry(t *testing.T) {
+	t.Parallel()
+	r, telegram, slack, clock := testRouter(&config.CommsRoutingConfig{
+		MaxRetries: 2,
+		RetryDelay: time.Second,
+	})
+	telegram.fail = 2
+	push(r, base.Event{Type: base.OrderEvent})
+	if len(slack.events) != 1 || r.RetryQueueLength() != 1 {
+		t.Fatalf("expected failed delivery to be queued, queue length %d", r.RetryQueueLength())
+	}
+	process(r)
+	if telegram.fail != 1 {
+		t.Fatal("retry attempted before its delay")
+	}
+	clock.t = clock.t.Add(time.Second)
+	process(r)
+	if telegram.fail != 0 || r.RetryQueueLength() != 1 {
+		t.Fatalf("expected retry to fail and be requeued, queue length %d", r.RetryQueueLength())
+	}
+	// the delay doubles after each failed attempt
+	clock.t = clock.t.Add(time.Second)
+	process(r)
+	if len(telegram.events) != 0 {
+		t.Fatal("retry attempted before its backoff")
+	}
+	clock.t = clock.t.Add(time.Second)
+	process(r)
+	if len(telegram.events) != 1 || r.RetryQueueLength() != 0 {
+		t.Errorf("expected retry to be delivered, queue length %d", r.RetryQueueLength())
+	}
+
+	telegram.fail = 3
+	push(r, base.Event{Type: base.OrderEvent})
+	for i := 0; i < 3; i++ {
+		clock.t = clock.t.Add(time.Minute)
+		process(r)
+	}
+	if r.RetryQueueLength() != 0 || len(telegram.events) != 1 {
+		t.Errorf("expected event to be dropped after max retries, queue length %d", r.RetryQueueLength())
+	}
+
+	r.maxRetries = 0
+	telegram.fail = 1
+	push(r, base.Event{Type: base.OrderEvent})
+	if r.RetryQueueLength() != 0 {
+		t.Error("expected no retries when disabled")
+	}
+}
+
+type blockingRelayer struct {
+	base.Base
+	release chan struct{}
+	sent    chan base.Event
+}
+
+func (r *blockingRelayer) Setup(*config.CommunicationsConfig) {}
+
+func (r *blockingRelayer) Connect() error { return nil }
+
+func (r *blockingRelayer) PushEvent(e base.Event) error {
+	<-r.release
+	r.sent <- e
+	return nil
+}
+
+func TestRouterSlowRelayer(t *testing.T) {
+	t.Parallel()
+	slow := &blockingRelayer{release: make(chan struct{}), sent: make(chan base.Event, 2)}
+	slow.Name = "Webhook"
+	slow.Enabled = true
+	slow.Connected = true
+	telegram := newTestRelayer("Telegram")
+	r := NewRouter(&config.CommsRoutingConfig{}, base.IComm{slow, telegram})
+	defer r.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		r.Push(base.Event{Type: base.OrderEvent, Message: "1"})
+		r.Push(base.Event{Type: base.OrderEvent, Message: "2"})
+		r.Process()
+		r.RetryQueueLength()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("expected routing not to wait on a slow relayer")
+	}
+
+	close(slow.release)
+	r.inflight.Wait()
+	if len(telegram.events) != 2 {
+		t.Errorf("received '%v' expected '%v'", len(telegram.events), 2)
+	}
+	if e := <-slow.sent; e.Message != "1" {
+		t.Errorf("received '%v' expected '%v'", e.Message, "1")
+	}
+	if e := <-slow.sent; e.Message != "2" {
+		t.Errorf("received '%v' expected '%v'", e.Message, "2")
+	}
+}
+
+func TestRouterRetryCap(t *testing.T) {
+	t.Parallel()
+	r, telegram, _, clock := testRouter(&config.CommsRoutingConfig{
+		MaxRetries: 5,
+		RetryDelay: time.Second,
+	})
+	defer r.Stop()
+	r.relayers = base.IComm{telegram}
+	telegram.fail = maxRetriesPerProcess * 10
+	for i := 0; i < maxRetriesPerProcess+10; i++ {
+		push(r, base.Event{Type: base.OrderEvent})
+	}
+	if r.RetryQueueLength() != maxRetriesPerProcess+10 {
+		t.Fatalf("received '%v' expected '%v'", r.RetryQueueLength(), maxRetriesPerProcess+10)
+	}
+	clock.t = clock.t.Add(time.Second)
+	failing := telegram.fail
+	process(r)
+	if attempted := failing - telegram.fail; attempted != maxRetriesPerProcess {
+		t.Errorf("received '%v' retries expected '%v'", attempted, maxRetriesPerProcess)
+	}
+}
+
+func TestRouterStop(t *testing.T) {
+	t.Parallel()
+	r, telegram, _, _ := testRouter(&config.CommsRoutingConfig{})
+	push(r, base.Event{Type: base.OrderEvent})
+	r.Stop()
+	r.Stop()
+	push(r, base.Event{Type: base.OrderEvent})
+	if len(telegram.events) != 1 {
+		t.Errorf("expected events pushed after stopping to be dropped, received %v", len(telegram.events))
+	}
+
+	r, telegram, _, _ = testRouter(&config.CommsRoutingConfig{
+		Rules: []config.CommsRouteRule{{DigestInterval: time.Hour}},
+	})
+	r.Push(base.Event{Type: base.OrderEvent, Message: "batched"})
+	// stopping sends the pending digest and waits for it to be delivered
+	r.Stop()
+	if len(telegram.events) != 1 || telegram.events[0].Message != "batched" {
+		t.Errorf("expected the pending digest to be sent on stop, received %+v", telegram.events)
+	}
+}
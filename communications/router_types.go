@@ -0,0 +1,66 @@
+package communications
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+const (
+	maxRetryQueue        = 1000
+	maxRetriesPerProcess = 100
+	maxDigestEvents      = 50
+	relayerQueueSize     = 100
+)
+
+var errRelayerQueueFull = errors.New("relayer delivery queue full")
+
+// Router sends events to relayers according to the configured routing rules,
+// throttling and batching them per rule and retrying failed deliveries. Each
+// relayer has its own worker so that a slow relayer only delays its own
+// deliveries
+type Router struct {
+	relayers   base.IComm
+	rules      []*route
+	maxRetries int
+	retryDelay time.Duration
+	retries    []*retry
+	now        func() time.Time
+	workers    map[base.ICommunicate]chan *delivery
+	inflight   sync.WaitGroup
+	stopped    bool
+	m          sync.Mutex
+}
+
+// route is a routing rule and its throttling state
+type route struct {
+	config.CommsRouteRule
+	eventTypes map[string]bool
+	severities map[string]bool
+
+	windowStart time.Time
+	windowCount int
+	suppressed  int
+	lastSeen    map[string]time.Time
+	digest      []base.Event
+	lastDigest  time.Time
+}
+
+// delivery is an event queued to be sent by a relayer's worker
+type delivery struct {
+	relayer  base.ICommunicate
+	event    base.Event
+	attempts int
+	retry    bool
+}
+
+// retry is an event awaiting redelivery to a relayer
+type retry struct {
+	relayer  base.ICommunicate
+	event    base.Event
+	attempts int
+	next     time.Time
+}
@@ -375,15 +375,24 @@ func (s *Slack) HandleMessage(msg *Message) error {
 		return errors.New("slack msg is nil")
 	}
 
+	// command arguments such as script names are case sensitive
+	text := msg.Text
 	msg.Text = strings.ToLower(msg.Text)
 	switch {
 	case strings.Contains(msg.Text, cmdStatus):
 		return s.WebsocketSend("message", s.GetStatus())
 
 	case strings.Contains(msg.Text, cmdHelp):
-		return s.WebsocketSend("message", getHelp)
+		reply := getHelp
+		if commands := s.CommandHelp(msg.User); commands != "" {
+			reply += "\n" + commands
+		}
+		return s.WebsocketSend("message", reply)
 
 	default:
+		if reply, ok := s.HandleCommand(msg.User, text); ok {
+			return s.WebsocketSend("message", reply)
+		}
 		return s.WebsocketSend("message", "GoCryptoTrader SlackBot - Command Unknown!")
 	}
 }
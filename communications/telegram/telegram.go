@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -172,7 +173,11 @@ func (t *Telegram) HandleMessages(text string, chatID int64) error {
 
 	switch {
 	case strings.Contains(text, cmdHelp):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, cmdHelpReply), chatID)
+		reply := cmdHelpReply
+		if commands := t.CommandHelp(strconv.FormatInt(chatID, 10)); commands != "" {
+			reply += "\n" + commands
+		}
+		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, reply), chatID)
 
 	case strings.Contains(text, cmdStart):
 		return t.SendMessage(fmt.Sprintf("%s: START COMMANDS HERE", talkRoot), chatID)
@@ -181,6 +186,9 @@ func (t *Telegram) HandleMessages(text string, chatID int64) error {
 		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetStatus()), chatID)
 
 	default:
+		if reply, ok := t.HandleCommand(strconv.FormatInt(chatID, 10), text); ok {
+			return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, reply), chatID)
+		}
 		return t.SendMessage(fmt.Sprintf("Command %s not recognized", text), chatID)
 	}
 }
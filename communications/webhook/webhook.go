@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Setup takes in a webhook configuration and sets the endpoint and body
+// template
+func (w *Webhook) Setup(cfg *config.CommunicationsConfig) {
+	w.SetupWebhook(&cfg.WebhookConfig)
+}
+
+// SetupWebhook sets the endpoint and body templates from a single webhook
+// config, allowing more than one webhook to be configured
+func (w *Webhook) SetupWebhook(cfg *config.WebhookConfig) {
+	w.Name = cfg.Name
+	w.Enabled = cfg.Enabled
+	w.Verbose = cfg.Verbose
+	w.URL = cfg.URL
+	w.Method = strings.ToUpper(cfg.Method)
+	if w.Method == "" {
+		w.Method = http.MethodPost
+	}
+	w.Headers = make(map[string]string, len(cfg.Headers))
+	for k, v := range cfg.Headers {
+		w.Headers[k] = v
+	}
+	w.BodyTemplate = cfg.BodyTemplate
+	if w.BodyTemplate == "" {
+		w.BodyTemplate = defaultBodyTemplate
+	}
+	w.EventTemplates = make(map[string]string, len(cfg.EventTemplates))
+	for k, v := range cfg.EventTemplates {
+		w.EventTemplates[k] = v
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	w.client = &http.Client{Timeout: timeout}
+}
+
+// Connect compiles the body templates so that template errors are reported
+// when the relayer starts rather than when the first event is sent
+func (w *Webhook) Connect() error {
+	if w.URL == "" {
+		return errURLUnset
+	}
+	tmpl, err := NewTemplates(w.Name, w.BodyTemplate, w.EventTemplates)
+	if err != nil {
+		return err
+	}
+	if w.client == nil {
+		w.client = &http.Client{Timeout: defaultTimeout}
+	}
+	w.tmpl = tmpl
+	w.Connected = true
+	return nil
+}
+
+// PushEvent renders the body template for an event and sends it to the
+// configured URL
+func (w *Webhook) PushEvent(e base.Event) error {
+	if w.tmpl == nil {
+		return errNotConnected
+	}
+	var body bytes.Buffer
+	if err := w.tmpl.Execute(&body, e); err != nil {
+		return fmt.Errorf("%w %s: %v", errTemplateExecute, e.Type, err)
+	}
+	if w.Verbose {
+		log.Debugf(log.CommunicationMgr, "%s: Sending %s %s\n", w.Name, w.Method, body.String())
+	}
+	return Send(w.client, w.Method, w.URL, w.Headers, &body)
+}
+
+// Templates holds a default template and the templates overriding it for
+// specific event types
+type Templates struct {
+	fallback *template.Template
+	events   map[string]*template.Template
+}
+
+// NewTemplates parses the default template and the per event type templates,
+// which are matched against the event type case insensitively
+func NewTemplates(name, fallback string, events map[string]string) (*Templates, error) {
+	tmpl, err := NewTemplate(name, fallback)
+	if err != nil {
+		return nil, err
+	}
+	t := &Templates{fallback: tmpl, events: make(map[string]*template.Template, len(events))}
+	for eventType, text := range events {
+		tmpl, err = NewTemplate(name+" "+eventType, text)
+		if err != nil {
+			return nil, fmt.Errorf("%s event template: %w", eventType, err)
+		}
+		t.events[strings.ToLower(eventType)] = tmpl
+	}
+	return t, nil
+}
+
+// Execute renders the template for the event type, falling back to the
+// default template
+func (t *Templates) Execute(wr io.Writer, e base.Event) error {
+	if tmpl, ok := t.events[strings.ToLower(e.Type)]; ok {
+		return tmpl.Execute(wr, e)
+	}
+	return t.fallback.Execute(wr, e)
+}
+
+// NewTemplate parses a Go text/template with the functions available to
+// event templates. "json" encodes a value as JSON
+func NewTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}).Parse(text)
+}
+
+// Send sends a request and returns an error when the endpoint responds with a
+// non 2xx status code so that failed deliveries can be detected
+func Send(client *http.Client, method, url string, headers map[string]string, body io.Reader) error {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", defaultContentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		contents, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %w %d: %s", url, errUnexpectedStatus, resp.StatusCode, contents)
+	}
+	return nil
+}
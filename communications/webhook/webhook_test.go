@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+type received struct {
+	method string
+	header http.Header
+	body   []byte
+}
+
+func testServer(t *testing.T, status int) (*httptest.Server, chan received) {
+	t.Helper()
+	ch := make(chan received, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		ch <- received{method: r.Method, header: r.Header, body: b}
+		w.WriteHeader(status)
+	}))
+	return srv, ch
+}
+
+func TestSetup(t *testing.T) {
+	t.Parallel()
+	var w Webhook
+	w.Setup(&config.CommunicationsConfig{
+		WebhookConfig: config.WebhookConfig{
+			Name:    "Webhook",
+			Enabled: true,
+			URL:     "http://localhost",
+			Method:  "put",
+		},
+	})
+	if w.Method != http.MethodPut {
+		t.Errorf("received '%v' expected '%v'", w.Method, http.MethodPut)
+	}
+	if w.BodyTemplate != defaultBodyTemplate {
+		t.Errorf("received '%v' expected '%v'", w.BodyTemplate, defaultBodyTemplate)
+	}
+	if w.client == nil || w.client.Timeout != defaultTimeout {
+		t.Error("expected client with default timeout")
+	}
+}
+
+func TestConnect(t *testing.T) {
+	t.Parallel()
+	var w Webhook
+	if err := w.Connect(); !errors.Is(err, errURLUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errURLUnset)
+	}
+	w.URL = "http://localhost"
+	w.BodyTemplate = "{{.Type"
+	if err := w.Connect(); err == nil {
+		t.Error("expected template parse error")
+	}
+	w.BodyTemplate = defaultBodyTemplate
+	if err := w.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if !w.IsConnected() {
+		t.Error("expected webhook to be connected")
+	}
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	srv, ch := testServer(t, http.StatusOK)
+	defer srv.Close()
+
+	var w Webhook
+	w.Setup(&config.CommunicationsConfig{
+		WebhookConfig: config.WebhookConfig{
+			Name:    "Webhook",
+			Enabled: true,
+			URL:     srv.URL,
+			Headers: map[string]string{"Authorization": "Bearer test"},
+		},
+	})
+	if err := w.PushEvent(base.Event{}); !errors.Is(err, errNotConnected) {
+		t.Errorf("received '%v' expected '%v'", err, errNotConnected)
+	}
+	if err := w.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	evt := base.Event{
+		Type:     base.OrderEvent,
+		Message:  "order filled",
+		Severity: base.SeverityInfo,
+		Exchange: "Bitstamp",
+		Pair:     currency.NewPair(currency.BTC, currency.USD),
+		Asset:    asset.Spot,
+		Order:    &base.OrderDetails{ID: "1337", Amount: 1},
+	}
+	if err := w.PushEvent(evt); err != nil {
+		t.Fatal(err)
+	}
+	r := <-ch
+	if r.method != http.MethodPost {
+		t.Errorf("received '%v' expected '%v'", r.method, http.MethodPost)
+	}
+	if r.header.Get("Authorization") != "Bearer test" ||
+		r.header.Get("Content-Type") != defaultContentType {
+		t.Errorf("unexpected headers %v", r.header)
+	}
+	var got base.Event
+	if err := json.Unmarshal(r.body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Exchange != evt.Exchange || got.Order == nil || got.Order.ID != "1337" {
+		t.Errorf("unexpected body %s", r.body)
+	}
+
+	w.tmpl, _ = NewTemplates("test", `{"text":"{{upper .Exchange}} {{.Order.ID}}"}`, nil)
+	if err := w.PushEvent(evt); err != nil {
+		t.Fatal(err)
+	}
+	if r = <-ch; string(r.body) != `{"text":"BITSTAMP 1337"}` {
+		t.Errorf("unexpected body %s", r.body)
+	}
+
+	w.tmpl, _ = NewTemplates("test", "{{.Order.ID}}", nil)
+	if err := w.PushEvent(base.Event{}); !errors.Is(err, errTemplateExecute) {
+		t.Errorf("received '%v' expected '%v'", err, errTemplateExecute)
+	}
+}
+
+func TestEventTemplates(t *testing.T) {
+	t.Parallel()
+	if _, err := NewTemplates("test", "{{.Type}}", map[string]string{"order": "{{.Order"}); err == nil {
+		t.Error("expected event template parse error")
+	}
+	srv, ch := testServer(t, http.StatusOK)
+	defer srv.Close()
+
+	var w Webhook
+	w.SetupWebhook(&config.WebhookConfig{
+		Name:         "Orders",
+		Enabled:      true,
+		URL:          srv.URL,
+		BodyTemplate: "{{.Type}}: {{.Message}}",
+		EventTemplates: map[string]string{
+			strings.ToUpper(base.OrderEvent): "order {{.Order.ID}}",
+		},
+	})
+	if err := w.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PushEvent(base.Event{Type: base.OrderEvent, Order: &base.OrderDetails{ID: "1337"}}); err != nil {
+		t.Fatal(err)
+	}
+	if r := <-ch; string(r.body) != "order 1337" {
+		t.Errorf("received '%s' expected '%v'", r.body, "order 1337")
+	}
+	if err := w.PushEvent(base.Event{Type: "other", Message: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	if r := <-ch; string(r.body) != "other: hello" {
+		t.Errorf("received '%s' expected '%v'", r.body, "other: hello")
+	}
+}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+	srv, ch := testServer(t, http.StatusInternalServerError)
+	defer srv.Close()
+	err := Send(http.DefaultClient, http.MethodPost, srv.URL, nil, nil)
+	if !errors.Is(err, errUnexpectedStatus) {
+		t.Errorf("received '%v' expected '%v'", err, errUnexpectedStatus)
+	}
+	<-ch
+}
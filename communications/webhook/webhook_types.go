@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+const (
+	defaultBodyTemplate = "{{json .}}"
+	defaultContentType  = "application/json"
+	defaultTimeout      = time.Second * 10
+)
+
+var (
+	errURLUnset         = errors.New("webhook URL unset")
+	errNotConnected     = errors.New("webhook template not compiled, connect first")
+	errUnexpectedStatus = errors.New("unexpected response status")
+	errTemplateExecute  = errors.New("unable to execute template for event")
+)
+
+// Webhook sends events to an arbitrary HTTP endpoint with a templated body
+type Webhook struct {
+	base.Base
+	URL            string
+	Method         string
+	Headers        map[string]string
+	BodyTemplate   string
+	EventTemplates map[string]string
+
+	client *http.Client
+	tmpl   *Templates
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
@@ -296,10 +298,23 @@ func (c *Config) CheckCommunicationsConfig() {
 		}
 	}
 
+	if c.Communications.WebhookConfig.Name == "" {
+		c.Communications.WebhookConfig.Name = "Webhook"
+	}
+	if c.Communications.WebhookConfig.Timeout <= 0 {
+		c.Communications.WebhookConfig.Timeout = defaultWebhookTimeout
+	}
+
+	if c.Communications.DiscordConfig.Name == "" {
+		c.Communications.DiscordConfig.Name = "Discord"
+	}
+
 	if c.Communications.SlackConfig.Name != "Slack" ||
 		c.Communications.SMSGlobalConfig.Name != "SMSGlobal" ||
 		c.Communications.SMTPConfig.Name != "SMTP" ||
-		c.Communications.TelegramConfig.Name != "Telegram" {
+		c.Communications.TelegramConfig.Name != "Telegram" ||
+		c.Communications.WebhookConfig.Name != "Webhook" ||
+		c.Communications.DiscordConfig.Name != "Discord" {
 		log.Warnln(log.ConfigMgr, "Communications config name/s not set correctly")
 	}
 	if c.Communications.SlackConfig.Enabled {
@@ -333,6 +348,95 @@ func (c *Config) CheckCommunicationsConfig() {
 			log.Warnln(log.ConfigMgr, "Telegram enabled in config but variable data not set, disabling.")
 		}
 	}
+	if c.Communications.WebhookConfig.Enabled && c.Communications.WebhookConfig.URL == "" {
+		c.Communications.WebhookConfig.Enabled = false
+		log.Warnln(log.ConfigMgr, "Webhook enabled in config but variable data not set, disabling.")
+	}
+	if c.Communications.DiscordConfig.Enabled && c.Communications.DiscordConfig.WebhookURL == "" {
+		c.Communications.DiscordConfig.Enabled = false
+		log.Warnln(log.ConfigMgr, "Discord enabled in config but variable data not set, disabling.")
+	}
+	for i := range c.Communications.Webhooks {
+		w := &c.Communications.Webhooks[i]
+		if w.Name == "" {
+			w.Name = "Webhook " + strconv.Itoa(i+1)
+		}
+		if w.Timeout <= 0 {
+			w.Timeout = defaultWebhookTimeout
+		}
+		if w.Enabled && w.URL == "" {
+			w.Enabled = false
+			log.Warnf(log.ConfigMgr, "Webhook %s enabled in config but variable data not set, disabling.\n", w.Name)
+		}
+	}
+	if c.Communications.ChatCommands.ConfirmationTimeout <= 0 {
+		c.Communications.ChatCommands.ConfirmationTimeout = defaultChatCommandConfirmTimeout
+	}
+	for i := range c.Communications.ChatCommands.AuthorisedUsers {
+		for j := range c.Communications.ChatCommands.AuthorisedUsers[i].Commands {
+			c.Communications.ChatCommands.AuthorisedUsers[i].Commands[j] = strings.ToLower(
+				c.Communications.ChatCommands.AuthorisedUsers[i].Commands[j])
+		}
+	}
+	if c.Communications.ChatCommands.Enabled && len(c.Communications.ChatCommands.AuthorisedUsers) == 0 {
+		log.Warnln(log.ConfigMgr, "Chat commands enabled in config but no users are authorised to use them.")
+	}
+
+	routing := &c.Communications.Routing
+	// retries default on when the routing config has not been populated, once
+	// saved a max retries of zero disables them
+	if routing.MaxRetries < 0 {
+		routing.MaxRetries = 0
+	} else if routing.MaxRetries == 0 && routing.RetryDelay == 0 {
+		routing.MaxRetries = defaultCommsMaxRetries
+	}
+	if routing.RetryDelay <= 0 {
+		routing.RetryDelay = defaultCommsRetryDelay
+	}
+	relayers := map[string]bool{
+		strings.ToLower(c.Communications.SlackConfig.Name):     true,
+		strings.ToLower(c.Communications.SMSGlobalConfig.Name): true,
+		strings.ToLower(c.Communications.SMTPConfig.Name):      true,
+		strings.ToLower(c.Communications.TelegramConfig.Name):  true,
+		strings.ToLower(c.Communications.WebhookConfig.Name):   true,
+		strings.ToLower(c.Communications.DiscordConfig.Name):   true,
+	}
+	for i := range c.Communications.Webhooks {
+		name := strings.ToLower(c.Communications.Webhooks[i].Name)
+		if relayers[name] {
+			log.Warnf(log.ConfigMgr, "Communications webhook name %s is already in use, routing rules will not be able to tell them apart.\n", c.Communications.Webhooks[i].Name)
+		}
+		relayers[name] = true
+	}
+	for i := range routing.Rules {
+		rule := &routing.Rules[i]
+		if rule.Name == "" {
+			rule.Name = "rule " + strconv.Itoa(i+1)
+		}
+		for j := range rule.EventTypes {
+			rule.EventTypes[j] = strings.ToLower(rule.EventTypes[j])
+		}
+		for j := range rule.Severities {
+			rule.Severities[j] = strings.ToLower(rule.Severities[j])
+		}
+		for j := range rule.Relayers {
+			if !relayers[strings.ToLower(rule.Relayers[j])] {
+				log.Warnf(log.ConfigMgr, "Communications routing %s relayer %s not found.\n", rule.Name, rule.Relayers[j])
+			}
+		}
+		if rule.RateLimit < 0 {
+			rule.RateLimit = 0
+		}
+		if rule.RateLimit > 0 && rule.RateLimitInterval <= 0 {
+			rule.RateLimitInterval = defaultCommsRateLimitInterval
+		}
+		if rule.DedupWindow < 0 {
+			rule.DedupWindow = 0
+		}
+		if rule.DigestInterval < 0 {
+			rule.DigestInterval = 0
+		}
+	}
 }
 
 // GetExchangeAssetTypes returns the exchanges supported asset types
@@ -1051,7 +1155,14 @@ func (c *Config) CheckExchangeConfigValues() error {
 }
 
 // CheckBankAccountConfig checks all bank accounts to see if they are valid
+// and sets them as the accounts used by the banking package
 func (c *Config) CheckBankAccountConfig() {
+	c.checkBankAccountValues()
+	banking.Accounts = c.BankAccounts
+}
+
+// checkBankAccountValues disables any invalid bank accounts
+func (c *Config) checkBankAccountValues() {
 	for x := range c.BankAccounts {
 		if c.BankAccounts[x].Enabled {
 			err := c.BankAccounts[x].Validate()
@@ -1061,7 +1172,6 @@ func (c *Config) CheckBankAccountConfig() {
 			}
 		}
 	}
-	banking.Accounts = c.BankAccounts
 }
 
 // CheckCurrencyConfigValues checks to see if the currency config values are correct or not
@@ -1086,6 +1196,18 @@ func (c *Config) CheckCurrencyConfigValues() error {
 	count := 0
 	for i := range c.Currency.ForexProviders {
 		if c.Currency.ForexProviders[i].Enabled {
+			if c.Currency.ForexProviders[i].Name == DefaultForexProviderOffline {
+				// the offline provider reads a local rates file and needs no
+				// API key
+				if c.Currency.ForexProviders[i].FilePath == "" {
+					log.Warnf(log.Global, "%s enabled forex provider file path not set. Please set this in your config.json file\n", c.Currency.ForexProviders[i].Name)
+					c.Currency.ForexProviders[i].Enabled = false
+					c.Currency.ForexProviders[i].PrimaryProvider = false
+					continue
+				}
+				count++
+				continue
+			}
 			if (c.Currency.ForexProviders[i].Name == "CurrencyConverter" || c.Currency.ForexProviders[i].Name == "ExchangeRates") &&
 				c.Currency.ForexProviders[i].PrimaryProvider &&
 				(c.Currency.ForexProviders[i].APIKey == "" ||
@@ -1254,6 +1376,26 @@ func (c *Config) CheckLoggerConfig() error {
 	m.Lock()
 	defer m.Unlock()
 
+	c.checkLoggerValues()
+	if c.Logging.LoggerFileConfig != nil {
+		log.FileLoggingConfiguredCorrectly = true
+	}
+	log.RWM.Lock()
+	log.GlobalLogConfig = &c.Logging
+	log.RWM.Unlock()
+
+	logPath := c.GetDataPath("logs")
+	err := common.CreateDir(logPath)
+	if err != nil {
+		return err
+	}
+	log.LogPath = logPath
+
+	return nil
+}
+
+// checkLoggerValues sets defaults for missing logger values
+func (c *Config) checkLoggerValues() {
 	if c.Logging.Enabled == nil || c.Logging.Output == "" {
 		c.Logging = log.GenDefaultSettings()
 	}
@@ -1273,36 +1415,20 @@ func (c *Config) CheckLoggerConfig() error {
 			log.Warnf(log.Global, "Logger rotation size invalid, defaulting to %v", log.DefaultMaxFileSize)
 			c.Logging.LoggerFileConfig.MaxSize = log.DefaultMaxFileSize
 		}
-		log.FileLoggingConfiguredCorrectly = true
-	}
-	log.RWM.Lock()
-	log.GlobalLogConfig = &c.Logging
-	log.RWM.Unlock()
-
-	logPath := c.GetDataPath("logs")
-	err := common.CreateDir(logPath)
-	if err != nil {
-		return err
 	}
-	log.LogPath = logPath
-
-	return nil
 }
 
 func (c *Config) checkGCTScriptConfig() error {
 	m.Lock()
 	defer m.Unlock()
 
-	if c.GCTScript.ScriptTimeout <= 0 {
-		c.GCTScript.ScriptTimeout = gctscript.DefaultTimeoutValue
-	}
-
-	if c.GCTScript.MaxVirtualMachines == 0 {
-		c.GCTScript.MaxVirtualMachines = gctscript.DefaultMaxVirtualMachines
+	err := c.checkGCTScriptValues()
+	if err != nil {
+		return err
 	}
 
 	scriptPath := c.GetDataPath("scripts")
-	err := common.CreateDir(scriptPath)
+	err = common.CreateDir(scriptPath)
 	if err != nil {
 		return err
 	}
@@ -1318,27 +1444,49 @@ func (c *Config) checkGCTScriptConfig() error {
 	return nil
 }
 
-func (c *Config) checkDatabaseConfig() error {
-	m.Lock()
-	defer m.Unlock()
+// checkGCTScriptValues sets defaults for missing gctscript values and
+// validates the script permissions
+func (c *Config) checkGCTScriptValues() error {
+	if c.GCTScript.ScriptTimeout <= 0 {
+		c.GCTScript.ScriptTimeout = gctscript.DefaultTimeoutValue
+	}
 
-	if (c.Database == database.Config{}) {
-		c.Database.Driver = database.DBSQLite3
-		c.Database.Database = database.DefaultSQLiteDatabase
+	if c.GCTScript.MaxVirtualMachines == 0 {
+		c.GCTScript.MaxVirtualMachines = gctscript.DefaultMaxVirtualMachines
 	}
 
-	if !c.Database.Enabled {
-		return nil
+	if c.GCTScript.EventQueueSize <= 0 {
+		c.GCTScript.EventQueueSize = gctscript.DefaultEventQueueSize
 	}
 
-	if !common.StringDataCompare(database.SupportedDrivers, c.Database.Driver) {
-		c.Database.Enabled = false
-		return fmt.Errorf("unsupported database driver %v, database disabled", c.Database.Driver)
+	if len(c.GCTScript.Permissions) > 0 {
+		permissions := make(map[string]*permission.Manifest, len(c.GCTScript.Permissions))
+		for name, manifest := range c.GCTScript.Permissions {
+			if filepath.Ext(name) != common.GctExt {
+				name += common.GctExt
+			}
+			if err := manifest.Validate(); err != nil {
+				return fmt.Errorf("gctscript permissions for %s: %w", name, err)
+			}
+			permissions[name] = manifest
+		}
+		c.GCTScript.Permissions = permissions
+	}
+	return nil
+}
+
+func (c *Config) checkDatabaseConfig() error {
+	m.Lock()
+	defer m.Unlock()
+
+	err := c.checkDatabaseValues()
+	if err != nil || !c.Database.Enabled {
+		return err
 	}
 
 	if c.Database.Driver == database.DBSQLite || c.Database.Driver == database.DBSQLite3 {
 		databaseDir := c.GetDataPath("database")
-		err := common.CreateDir(databaseDir)
+		err = common.CreateDir(databaseDir)
 		if err != nil {
 			return err
 		}
@@ -1350,6 +1498,25 @@ func (c *Config) checkDatabaseConfig() error {
 	return nil
 }
 
+// checkDatabaseValues sets the default database when none is configured and
+// disables the database when its driver is unsupported
+func (c *Config) checkDatabaseValues() error {
+	if (c.Database == database.Config{}) {
+		c.Database.Driver = database.DBSQLite3
+		c.Database.Database = database.DefaultSQLiteDatabase
+	}
+
+	if !c.Database.Enabled {
+		return nil
+	}
+
+	if !common.StringDataCompare(database.SupportedDrivers, c.Database.Driver) {
+		c.Database.Enabled = false
+		return fmt.Errorf("unsupported database driver %v, database disabled", c.Database.Driver)
+	}
+	return nil
+}
+
 // CheckNTPConfig checks for missing or incorrectly configured NTPClient and recreates with known safe defaults
 func (c *Config) CheckNTPConfig() {
 	m.Lock()
@@ -1428,6 +1595,172 @@ func (c *Config) CheckConnectionMonitorConfig() {
 	}
 }
 
+// CheckExchangeHealthConfig checks and if zero value assigns default values
+func (c *Config) CheckExchangeHealthConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.ExchangeHealth.CheckInterval <= 0 {
+		c.ExchangeHealth.CheckInterval = defaultHealthCheckInterval
+	}
+
+	if c.ExchangeHealth.TrafficAlertWindow <= 0 {
+		c.ExchangeHealth.TrafficAlertWindow = defaultHealthTrafficAlertWindow
+	}
+
+	if c.ExchangeHealth.DownErrorRate <= 0 || c.ExchangeHealth.DownErrorRate > 1 {
+		c.ExchangeHealth.DownErrorRate = defaultHealthDownErrorRate
+	}
+
+	if c.ExchangeHealth.DegradedErrorRate <= 0 ||
+		c.ExchangeHealth.DegradedErrorRate > c.ExchangeHealth.DownErrorRate {
+		c.ExchangeHealth.DegradedErrorRate = defaultHealthDegradedErrorRate
+		if c.ExchangeHealth.DegradedErrorRate > c.ExchangeHealth.DownErrorRate {
+			c.ExchangeHealth.DegradedErrorRate = c.ExchangeHealth.DownErrorRate
+		}
+	}
+
+	if c.ExchangeHealth.MinimumSamples == 0 {
+		c.ExchangeHealth.MinimumSamples = defaultHealthMinimumSamples
+	}
+
+	if c.ExchangeHealth.SampleExpiry <= 0 {
+		c.ExchangeHealth.SampleExpiry = defaultHealthSampleExpiry
+	}
+
+	switch c.ExchangeHealth.UnhealthyOrderPolicy {
+	case UnhealthyOrderPolicyAllow, UnhealthyOrderPolicyBlock, UnhealthyOrderPolicyQueue:
+	default:
+		if c.ExchangeHealth.UnhealthyOrderPolicy != "" {
+			log.Warnf(log.ConfigMgr,
+				"Exchange health unhealthy order policy %q invalid, defaulting to %q.\n",
+				c.ExchangeHealth.UnhealthyOrderPolicy,
+				UnhealthyOrderPolicyAllow)
+		}
+		c.ExchangeHealth.UnhealthyOrderPolicy = UnhealthyOrderPolicyAllow
+	}
+
+	if c.ExchangeHealth.OrderQueueTimeout <= 0 {
+		c.ExchangeHealth.OrderQueueTimeout = defaultHealthOrderQueueTimeout
+	}
+}
+
+// CheckDataHistoryManagerConfig checks and if zero value assigns default
+// values
+func (c *Config) CheckDataHistoryManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.DataHistoryManager.CheckInterval <= 0 {
+		c.DataHistoryManager.CheckInterval = defaultDataHistoryCheckInterval
+	}
+
+	if c.DataHistoryManager.MaxJobsPerCycle <= 0 {
+		c.DataHistoryManager.MaxJobsPerCycle = defaultDataHistoryMaxJobsPerCycle
+	}
+}
+
+// CheckBalanceSnapshotConfig checks and if zero value assigns default values
+func (c *Config) CheckBalanceSnapshotConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.BalanceSnapshot.Interval <= 0 {
+		c.BalanceSnapshot.Interval = defaultBalanceSnapshotInterval
+	}
+
+	if c.BalanceSnapshot.FiatCurrency == "" {
+		c.BalanceSnapshot.FiatCurrency = defaultBalanceSnapshotFiatCurrency
+	}
+	c.BalanceSnapshot.FiatCurrency = strings.ToUpper(c.BalanceSnapshot.FiatCurrency)
+}
+
+// CheckDatabaseRetentionConfig checks and if zero value assigns default
+// values. Invalid policies are removed
+func (c *Config) CheckDatabaseRetentionConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.DatabaseRetention.CheckInterval <= 0 {
+		c.DatabaseRetention.CheckInterval = defaultRetentionCheckInterval
+	}
+
+	if c.DatabaseRetention.ArchivePath == "" {
+		c.DatabaseRetention.ArchivePath = filepath.Join(common.GetDefaultDataDir(runtime.GOOS), "archive")
+	}
+
+	policies := c.DatabaseRetention.Policies[:0]
+	for i := range c.DatabaseRetention.Policies {
+		p := c.DatabaseRetention.Policies[i]
+		p.Exchange = strings.ToLower(p.Exchange)
+		p.Asset = strings.ToLower(p.Asset)
+		if p.Exchange == "" || !asset.Item(p.Asset).IsValid() {
+			log.Warnf(log.ConfigMgr,
+				"Database retention policy %d exchange %q asset %q invalid, removing policy.\n",
+				i, p.Exchange, p.Asset)
+			continue
+		}
+		if p.TradeRetention < 0 {
+			p.TradeRetention = 0
+		}
+		if p.CandleRetention < 0 {
+			p.CandleRetention = 0
+		}
+		if p.CandleInterval <= 0 || (time.Hour*24)%p.CandleInterval != 0 {
+			if p.CandleInterval != 0 {
+				log.Warnf(log.ConfigMgr,
+					"Database retention policy %s %s candle interval %v must divide a day evenly, defaulting to %v.\n",
+					p.Exchange, p.Asset, p.CandleInterval, defaultRetentionCandleInterval)
+			}
+			p.CandleInterval = defaultRetentionCandleInterval
+		}
+		if p.TradeRetention > 0 && p.CandleRetention > 0 && p.CandleRetention <= p.TradeRetention {
+			// candles downsampled from old trades would be removed straight
+			// away
+			log.Warnf(log.ConfigMgr,
+				"Database retention policy %s %s candle retention %v must be longer than trade retention %v, disabling candle retention.\n",
+				p.Exchange, p.Asset, p.CandleRetention, p.TradeRetention)
+			p.CandleRetention = 0
+		}
+		p.Action = strings.ToLower(p.Action)
+		switch p.Action {
+		case RetentionActionArchive, RetentionActionDelete:
+		default:
+			if p.Action != "" {
+				log.Warnf(log.ConfigMgr,
+					"Database retention policy %s %s action %q invalid, defaulting to %q.\n",
+					p.Exchange, p.Asset, p.Action, RetentionActionArchive)
+			}
+			p.Action = RetentionActionArchive
+		}
+		policies = append(policies, p)
+	}
+	c.DatabaseRetention.Policies = policies
+}
+
+// CheckOrderManagerConfig removes invalid order manager limits
+func (c *Config) CheckOrderManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+
+	if c.OrderManager.LimitAmount < 0 {
+		log.Warnf(log.ConfigMgr,
+			"Order manager limit amount %v invalid, disabling limit.\n",
+			c.OrderManager.LimitAmount)
+		c.OrderManager.LimitAmount = 0
+	}
+
+	exchanges := c.OrderManager.AllowedExchanges[:0]
+	for i := range c.OrderManager.AllowedExchanges {
+		name := strings.ToLower(strings.TrimSpace(c.OrderManager.AllowedExchanges[i]))
+		if name == "" {
+			continue
+		}
+		exchanges = append(exchanges, name)
+	}
+	c.OrderManager.AllowedExchanges = exchanges
+}
+
 // DefaultFilePath returns the default config file path
 // MacOS/Linux: $HOME/.gocryptotrader/config.json or config.dat
 // Windows: %APPDATA%\GoCryptoTrader\config.json or config.dat
@@ -1727,16 +2060,113 @@ func (c *Config) CheckRemoteControlConfig() {
 	}
 }
 
-// CheckConfig checks all config settings
+// checkRemoteControlAccess validates remote control users and API tokens and
+// replaces any plaintext user passwords with their hashes
+func (c *Config) checkRemoteControlAccess() error {
+	m.Lock()
+	defer m.Unlock()
+	hashed, err := auth.CheckUsers(c.RemoteControl.Users)
+	if err != nil {
+		return fmt.Errorf("remote control users: %w", err)
+	}
+	if hashed {
+		log.Warnln(log.ConfigMgr,
+			"Remote control user passwords have been hashed, they will be removed from the config on next save.")
+	}
+	err = auth.CheckTokens(c.RemoteControl.Tokens)
+	if err != nil {
+		return fmt.Errorf("remote control tokens: %w", err)
+	}
+	return nil
+}
+
+// GetRemoteControlCredentials returns a copy of the credentials used to
+// authenticate remote control clients
+func (c *Config) GetRemoteControlCredentials() auth.Credentials {
+	m.Lock()
+	defer m.Unlock()
+	return auth.Credentials{
+		Username: c.RemoteControl.Username,
+		Password: c.RemoteControl.Password,
+		Users:    append([]auth.User(nil), c.RemoteControl.Users...),
+		Tokens:   append([]auth.Token(nil), c.RemoteControl.Tokens...),
+	}
+}
+
+// GetAPITokens returns a copy of the configured remote control API tokens
+func (c *Config) GetAPITokens() []auth.Token {
+	m.Lock()
+	defer m.Unlock()
+	return append([]auth.Token(nil), c.RemoteControl.Tokens...)
+}
+
+// AddAPIToken stores a hashed remote control API token
+func (c *Config) AddAPIToken(t *auth.Token) error {
+	if t == nil {
+		return errAPITokenNil
+	}
+	m.Lock()
+	defer m.Unlock()
+	tokens := append(append([]auth.Token(nil), c.RemoteControl.Tokens...), *t)
+	if err := auth.CheckTokens(tokens); err != nil {
+		return err
+	}
+	c.RemoteControl.Tokens = tokens
+	return nil
+}
+
+// RemoveAPIToken removes a remote control API token by its ID
+func (c *Config) RemoveAPIToken(id string) error {
+	m.Lock()
+	defer m.Unlock()
+	for i := range c.RemoteControl.Tokens {
+		if c.RemoteControl.Tokens[i].ID == id {
+			c.RemoteControl.Tokens = append(c.RemoteControl.Tokens[:i:i], c.RemoteControl.Tokens[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", auth.ErrTokenNotFound, id)
+}
+
+// CheckConfig checks all config settings. Besides setting defaults on the
+// config it configures the process for it: the global logger settings and log
+// path, the package level database instance, the gctscript path and the
+// banking accounts are pointed at this config and the log, database and script
+// directories are created. Use ValidateConfig to check a config without these
+// side effects
 func (c *Config) CheckConfig() error {
-	err := c.CheckLoggerConfig()
+	return c.checkConfig(true)
+}
+
+// ValidateConfig checks all config settings and sets their defaults like
+// CheckConfig, but leaves package level state and the data directories
+// untouched so a config can be checked while another is in use
+func (c *Config) ValidateConfig() error {
+	return c.checkConfig(false)
+}
+
+func (c *Config) checkConfig(configureProcess bool) error {
+	var err error
+	if configureProcess {
+		err = c.CheckLoggerConfig()
+	} else {
+		m.Lock()
+		c.checkLoggerValues()
+		m.Unlock()
+	}
 	if err != nil {
 		log.Errorf(log.ConfigMgr,
 			"Failed to configure logger, some logging features unavailable: %s\n",
 			err)
 	}
 
-	err = c.checkDatabaseConfig()
+	if configureProcess {
+		err = c.checkDatabaseConfig()
+	} else {
+		m.Lock()
+		err = c.checkDatabaseValues()
+		m.Unlock()
+	}
 	if err != nil {
 		log.Errorf(log.DatabaseMgr,
 			"Failed to configure database: %v",
@@ -1748,7 +2178,13 @@ func (c *Config) CheckConfig() error {
 		return fmt.Errorf(ErrCheckingConfigValues, err)
 	}
 
-	err = c.checkGCTScriptConfig()
+	if configureProcess {
+		err = c.checkGCTScriptConfig()
+	} else {
+		m.Lock()
+		err = c.checkGCTScriptValues()
+		m.Unlock()
+	}
 	if err != nil {
 		log.Errorf(log.Global,
 			"Failed to configure gctscript, feature has been disabled: %s\n",
@@ -1756,11 +2192,25 @@ func (c *Config) CheckConfig() error {
 	}
 
 	c.CheckConnectionMonitorConfig()
+	c.CheckExchangeHealthConfig()
+	c.CheckDataHistoryManagerConfig()
+	c.CheckBalanceSnapshotConfig()
+	c.CheckDatabaseRetentionConfig()
+	c.CheckOrderManagerConfig()
 	c.CheckCommunicationsConfig()
 	c.CheckClientBankAccounts()
-	c.CheckBankAccountConfig()
+	if configureProcess {
+		c.CheckBankAccountConfig()
+	} else {
+		c.checkBankAccountValues()
+	}
 	c.CheckRemoteControlConfig()
 
+	err = c.checkRemoteControlAccess()
+	if err != nil {
+		return err
+	}
+
 	err = c.CheckCurrencyConfigValues()
 	if err != nil {
 		return err
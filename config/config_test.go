@@ -1,12 +1,14 @@
 package config
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
@@ -15,6 +17,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/ntpclient"
@@ -420,6 +424,84 @@ func TestCheckCommunicationsConfig(t *testing.T) {
 	if cfg.Communications.TelegramConfig.Enabled {
 		t.Error("CheckCommunicationsConfig TelegramConfig is enabled when it shouldn't be.")
 	}
+
+	cfg.Communications.WebhookConfig.Enabled = true
+	cfg.Communications.DiscordConfig.Enabled = true
+	cfg.CheckCommunicationsConfig()
+	if cfg.Communications.WebhookConfig.Enabled {
+		t.Error("CheckCommunicationsConfig WebhookConfig is enabled when it shouldn't be.")
+	}
+	if cfg.Communications.DiscordConfig.Enabled {
+		t.Error("CheckCommunicationsConfig DiscordConfig is enabled when it shouldn't be.")
+	}
+	if cfg.Communications.WebhookConfig.Name != "Webhook" ||
+		cfg.Communications.DiscordConfig.Name != "Discord" {
+		t.Error("CheckCommunicationsConfig webhook and Discord names should be set")
+	}
+	if cfg.Communications.WebhookConfig.Timeout != defaultWebhookTimeout {
+		t.Errorf("received '%v' expected '%v'",
+			cfg.Communications.WebhookConfig.Timeout,
+			defaultWebhookTimeout)
+	}
+
+	cfg.Communications.Webhooks = []WebhookConfig{
+		{Enabled: true, URL: "http://localhost"},
+		{Name: "Orders", Enabled: true},
+	}
+	cfg.CheckCommunicationsConfig()
+	if w := cfg.Communications.Webhooks[0]; w.Name != "Webhook 1" || w.Timeout != defaultWebhookTimeout || !w.Enabled {
+		t.Errorf("unexpected webhook defaults %+v", w)
+	}
+	if cfg.Communications.Webhooks[1].Enabled {
+		t.Error("CheckCommunicationsConfig webhook without a URL is enabled when it shouldn't be.")
+	}
+
+	cfg.Communications.ChatCommands = ChatCommandsConfig{
+		Enabled: true,
+		AuthorisedUsers: []ChatCommandUser{
+			{Relayer: "Telegram", ID: "1337", Commands: []string{"Balances"}},
+		},
+	}
+	cfg.CheckCommunicationsConfig()
+	if cfg.Communications.ChatCommands.ConfirmationTimeout != defaultChatCommandConfirmTimeout {
+		t.Errorf("received '%v' expected '%v'",
+			cfg.Communications.ChatCommands.ConfirmationTimeout,
+			defaultChatCommandConfirmTimeout)
+	}
+	if cfg.Communications.ChatCommands.AuthorisedUsers[0].Commands[0] != "balances" {
+		t.Error("CheckCommunicationsConfig chat commands should be lowercase")
+	}
+	if cfg.Communications.Routing.MaxRetries != defaultCommsMaxRetries ||
+		cfg.Communications.Routing.RetryDelay != defaultCommsRetryDelay {
+		t.Errorf("unexpected routing retry defaults %+v", cfg.Communications.Routing)
+	}
+
+	cfg.Communications.Routing = CommsRoutingConfig{
+		RetryDelay: time.Second,
+		Rules: []CommsRouteRule{
+			{
+				EventTypes:  []string{"Order"},
+				Severities:  []string{"Critical"},
+				Relayers:    []string{"telegram", "pigeon"},
+				RateLimit:   5,
+				DedupWindow: -time.Second,
+			},
+		},
+	}
+	cfg.CheckCommunicationsConfig()
+	rule := cfg.Communications.Routing.Rules[0]
+	if cfg.Communications.Routing.MaxRetries != 0 {
+		t.Error("CheckCommunicationsConfig should not enable retries once routing is configured")
+	}
+	if rule.Name != "rule 1" || rule.EventTypes[0] != "order" || rule.Severities[0] != "critical" {
+		t.Errorf("unexpected routing rule %+v", rule)
+	}
+	if rule.RateLimitInterval != defaultCommsRateLimitInterval {
+		t.Errorf("received '%v' expected '%v'", rule.RateLimitInterval, defaultCommsRateLimitInterval)
+	}
+	if rule.DedupWindow != 0 {
+		t.Errorf("received '%v' expected '%v'", rule.DedupWindow, 0)
+	}
 }
 
 func TestGetExchangeAssetTypes(t *testing.T) {
@@ -1209,7 +1291,7 @@ func TestGetForexProviders(t *testing.T) {
 		t.Error(err)
 	}
 
-	if r := cfg.GetForexProviders(); len(r) != 6 {
+	if r := cfg.GetForexProviders(); len(r) != 7 {
 		t.Error("unexpected length of forex providers")
 	}
 }
@@ -1715,6 +1797,146 @@ func TestCheckConnectionMonitorConfig(t *testing.T) {
 	}
 }
 
+func TestCheckExchangeHealthConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckExchangeHealthConfig()
+	if c.ExchangeHealth.CheckInterval != defaultHealthCheckInterval ||
+		c.ExchangeHealth.TrafficAlertWindow != defaultHealthTrafficAlertWindow ||
+		c.ExchangeHealth.DegradedErrorRate != defaultHealthDegradedErrorRate ||
+		c.ExchangeHealth.DownErrorRate != defaultHealthDownErrorRate ||
+		c.ExchangeHealth.MinimumSamples != defaultHealthMinimumSamples ||
+		c.ExchangeHealth.SampleExpiry != defaultHealthSampleExpiry ||
+		c.ExchangeHealth.UnhealthyOrderPolicy != UnhealthyOrderPolicyAllow ||
+		c.ExchangeHealth.OrderQueueTimeout != defaultHealthOrderQueueTimeout {
+		t.Error("unexpected values")
+	}
+
+	c.ExchangeHealth.DownErrorRate = 0.1
+	c.ExchangeHealth.DegradedErrorRate = 0.5
+	c.ExchangeHealth.UnhealthyOrderPolicy = "meow"
+	c.CheckExchangeHealthConfig()
+	if c.ExchangeHealth.DegradedErrorRate != 0.1 {
+		t.Errorf("received '%v' expected '%v'", c.ExchangeHealth.DegradedErrorRate, 0.1)
+	}
+	if c.ExchangeHealth.UnhealthyOrderPolicy != UnhealthyOrderPolicyAllow {
+		t.Error("unexpected values")
+	}
+
+	c.ExchangeHealth.UnhealthyOrderPolicy = UnhealthyOrderPolicyQueue
+	c.CheckExchangeHealthConfig()
+	if c.ExchangeHealth.UnhealthyOrderPolicy != UnhealthyOrderPolicyQueue {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckDataHistoryManagerConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckDataHistoryManagerConfig()
+	if c.DataHistoryManager.CheckInterval != defaultDataHistoryCheckInterval ||
+		c.DataHistoryManager.MaxJobsPerCycle != defaultDataHistoryMaxJobsPerCycle {
+		t.Error("unexpected values")
+	}
+
+	c.DataHistoryManager.CheckInterval = time.Second
+	c.DataHistoryManager.MaxJobsPerCycle = 1
+	c.CheckDataHistoryManagerConfig()
+	if c.DataHistoryManager.CheckInterval != time.Second ||
+		c.DataHistoryManager.MaxJobsPerCycle != 1 {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckBalanceSnapshotConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckBalanceSnapshotConfig()
+	if c.BalanceSnapshot.Interval != defaultBalanceSnapshotInterval ||
+		c.BalanceSnapshot.FiatCurrency != defaultBalanceSnapshotFiatCurrency {
+		t.Error("unexpected values")
+	}
+
+	c.BalanceSnapshot.Interval = time.Minute
+	c.BalanceSnapshot.FiatCurrency = "eur"
+	c.CheckBalanceSnapshotConfig()
+	if c.BalanceSnapshot.Interval != time.Minute ||
+		c.BalanceSnapshot.FiatCurrency != "EUR" {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckOrderManagerConfig(t *testing.T) {
+	t.Parallel()
+
+	c := Config{OrderManager: OrderManagerConfig{
+		LimitAmount:      -1,
+		AllowedExchanges: []string{" Bitstamp", "", "binance"},
+	}}
+	c.CheckOrderManagerConfig()
+	if c.OrderManager.LimitAmount != 0 {
+		t.Errorf("received %v expected 0", c.OrderManager.LimitAmount)
+	}
+	if len(c.OrderManager.AllowedExchanges) != 2 ||
+		c.OrderManager.AllowedExchanges[0] != "bitstamp" ||
+		c.OrderManager.AllowedExchanges[1] != "binance" {
+		t.Errorf("unexpected allowed exchanges %v", c.OrderManager.AllowedExchanges)
+	}
+}
+
+func TestCheckDatabaseRetentionConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckDatabaseRetentionConfig()
+	if c.DatabaseRetention.CheckInterval != defaultRetentionCheckInterval ||
+		c.DatabaseRetention.ArchivePath == "" {
+		t.Error("unexpected values")
+	}
+
+	c.DatabaseRetention.Policies = []RetentionPolicy{
+		{Exchange: "", Asset: "spot"},
+		{Exchange: "Bitstamp", Asset: "meow"},
+		{
+			Exchange:        "Bitstamp",
+			Asset:           "SPOT",
+			TradeRetention:  time.Hour * 24 * 7,
+			CandleInterval:  time.Hour * 7,
+			CandleRetention: time.Hour * 24,
+			Action:          "meow",
+		},
+		{
+			Exchange:        "binance",
+			Asset:           "spot",
+			TradeRetention:  time.Hour * 24,
+			CandleInterval:  time.Hour,
+			CandleRetention: time.Hour * 24 * 365,
+			Action:          "DELETE",
+		},
+	}
+	c.CheckDatabaseRetentionConfig()
+	if len(c.DatabaseRetention.Policies) != 2 {
+		t.Fatalf("received %v expected %v", len(c.DatabaseRetention.Policies), 2)
+	}
+	p := c.DatabaseRetention.Policies[0]
+	if p.Exchange != "bitstamp" ||
+		p.Asset != "spot" ||
+		p.CandleInterval != defaultRetentionCandleInterval ||
+		p.CandleRetention != 0 ||
+		p.Action != RetentionActionArchive {
+		t.Errorf("unexpected policy %+v", p)
+	}
+	p = c.DatabaseRetention.Policies[1]
+	if p.CandleInterval != time.Hour ||
+		p.CandleRetention != time.Hour*24*365 ||
+		p.Action != RetentionActionDelete {
+		t.Errorf("unexpected policy %+v", p)
+	}
+}
+
 func TestDefaultFilePath(t *testing.T) {
 	// This is tricky to test because we're dealing with a config file stored
 	// in a persons default directory and to properly test it, it would
@@ -1791,6 +2013,60 @@ func TestCheckRemoteControlConfig(t *testing.T) {
 	}
 }
 
+func TestCheckRemoteControlAccess(t *testing.T) {
+	t.Parallel()
+	var c Config
+	c.RemoteControl.Users = []auth.User{{Username: "reader", Password: "pass", Scopes: []string{"meow"}}}
+	if err := c.checkRemoteControlAccess(); err == nil {
+		t.Error("expected invalid scope error")
+	}
+	c.RemoteControl.Users[0].Scopes = []string{auth.ScopeRead}
+	if err := c.checkRemoteControlAccess(); err != nil {
+		t.Fatal(err)
+	}
+	if c.RemoteControl.Users[0].Password != "" || c.RemoteControl.Users[0].PasswordHash == "" {
+		t.Error("expected user password to be hashed")
+	}
+	c.RemoteControl.Tokens = []auth.Token{{ID: "1"}}
+	if err := c.checkRemoteControlAccess(); err == nil {
+		t.Error("expected invalid token error")
+	}
+}
+
+func TestAPITokens(t *testing.T) {
+	t.Parallel()
+	c := Config{RemoteControl: RemoteControlConfig{Username: "admin", Password: "pass"}}
+	if err := c.AddAPIToken(nil); !errors.Is(err, errAPITokenNil) {
+		t.Errorf("received: %v but expected: %v", err, errAPITokenNil)
+	}
+	value, token, err := auth.NewToken("test", "admin", []string{auth.ScopeRead}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.AddAPIToken(&token); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.AddAPIToken(&token); err == nil {
+		t.Error("expected duplicate token error")
+	}
+	if tokens := c.GetAPITokens(); len(tokens) != 1 || tokens[0].ID != token.ID {
+		t.Errorf("unexpected tokens %+v", tokens)
+	}
+	creds := c.GetRemoteControlCredentials()
+	if _, err = creds.AuthenticateToken(value); err != nil {
+		t.Error(err)
+	}
+	if err = c.RemoveAPIToken("meow"); !errors.Is(err, auth.ErrTokenNotFound) {
+		t.Errorf("received: %v but expected: %v", err, auth.ErrTokenNotFound)
+	}
+	if err = c.RemoveAPIToken(token.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.GetAPITokens()) != 0 || len(creds.Tokens) != 1 {
+		t.Error("expected token to be removed without altering previous copies")
+	}
+}
+
 func TestCheckConfig(t *testing.T) {
 	var c Config
 	err := c.LoadConfig(TestFile, true)
@@ -1804,6 +2080,52 @@ func TestCheckConfig(t *testing.T) {
 	}
 }
 
+func TestValidateConfig(t *testing.T) {
+	var c Config
+	err := c.ReadConfigFromFile(TestFile, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "gct-validate-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	c.DataDirectory = filepath.Join(dir, "data")
+	c.Database.Enabled = true
+	c.Database.Driver = database.DBSQLite3
+	c.BankAccounts = append(c.BankAccounts, banking.Account{Enabled: true})
+
+	log.RWM.RLock()
+	logConfig := log.GlobalLogConfig
+	log.RWM.RUnlock()
+	dbConfig, scriptPath, accounts := database.DB.Config, gctscript.ScriptPath, len(banking.Accounts)
+
+	if err = c.ValidateConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if c.BankAccounts[len(c.BankAccounts)-1].Enabled {
+		t.Error("expected invalid bank account to be disabled")
+	}
+	log.RWM.RLock()
+	if log.GlobalLogConfig != logConfig {
+		t.Error("expected global logger config to be left untouched")
+	}
+	log.RWM.RUnlock()
+	if database.DB.Config != dbConfig {
+		t.Error("expected database instance config to be left untouched")
+	}
+	if gctscript.ScriptPath != scriptPath {
+		t.Error("expected script path to be left untouched")
+	}
+	if len(banking.Accounts) != accounts {
+		t.Error("expected banking accounts to be left untouched")
+	}
+	if file.Exists(c.DataDirectory) {
+		t.Error("expected data directories not to be created")
+	}
+}
+
 func TestUpdateConfig(t *testing.T) {
 	var c Config
 	err := c.LoadConfig(TestFile, true)
@@ -1922,6 +2244,25 @@ func TestCheckGCTScriptConfig(t *testing.T) {
 	if c.GCTScript.MaxVirtualMachines != gctscript.DefaultMaxVirtualMachines {
 		t.Fatal("unexpected value return")
 	}
+
+	if c.GCTScript.EventQueueSize != gctscript.DefaultEventQueueSize {
+		t.Fatal("unexpected value return")
+	}
+
+	c.GCTScript.Permissions = map[string]*permission.Manifest{
+		"trader": {Exchanges: []string{"binance"}},
+	}
+	if err := c.checkGCTScriptConfig(); err != nil {
+		t.Error(err)
+	}
+	if _, ok := c.GCTScript.Permissions["trader.gct"]; !ok {
+		t.Error("expected permissions to be keyed by script file name")
+	}
+
+	c.GCTScript.Permissions["trader.gct"].MaxOrderNotional = -1
+	if err := c.checkGCTScriptConfig(); err == nil {
+		t.Error("expected invalid permissions error")
+	}
 }
 
 func TestCheckDatabaseConfig(t *testing.T) {
@@ -2038,6 +2379,57 @@ func TestCheckCurrencyConfigValues(t *testing.T) {
 	}
 }
 
+func TestCheckCurrencyConfigValuesOffline(t *testing.T) {
+	t.Parallel()
+	c := &Config{
+		Currency: CurrencyConfig{
+			ForexProviders: []currency.FXSettings{
+				{Name: DefaultForexProviderOffline, Enabled: true, PrimaryProvider: true},
+			},
+		},
+	}
+	err := c.CheckCurrencyConfigValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fx, err := c.GetForexProvider(DefaultForexProviderOffline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fx.Enabled || fx.PrimaryProvider {
+		t.Error("offline forex provider without a file path should be disabled")
+	}
+
+	c.Currency.ForexProviders = nil
+	err = c.CheckCurrencyConfigValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range c.Currency.ForexProviders {
+		c.Currency.ForexProviders[i].Enabled = false
+		c.Currency.ForexProviders[i].PrimaryProvider = false
+		if c.Currency.ForexProviders[i].Name == DefaultForexProviderOffline {
+			c.Currency.ForexProviders[i].Enabled = true
+			c.Currency.ForexProviders[i].PrimaryProvider = true
+			c.Currency.ForexProviders[i].FilePath = "rates.csv"
+		}
+	}
+	err = c.CheckCurrencyConfigValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fx, err = c.GetForexProvider(DefaultForexProviderOffline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fx.Enabled || !fx.PrimaryProvider {
+		t.Error("offline forex provider with a file path should not require an API key")
+	}
+	if c.GetPrimaryForexProvider() != DefaultForexProviderOffline {
+		t.Errorf("received %v expected %v", c.GetPrimaryForexProvider(), DefaultForexProviderOffline)
+	}
+}
+
 func TestPreengineConfigUpgrade(t *testing.T) {
 	var c Config
 	if err := c.LoadConfig("../testdata/preengine_config.json", false); err != nil {
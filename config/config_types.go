@@ -1,12 +1,14 @@
 package config
 
 import (
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
@@ -31,6 +33,24 @@ const (
 	maxAuthFailures                      = 3
 	defaultNTPAllowedDifference          = 50000000
 	defaultNTPAllowedNegativeDifference  = 50000000
+	defaultHealthCheckInterval           = time.Second * 10
+	defaultHealthTrafficAlertWindow      = time.Minute
+	defaultHealthDegradedErrorRate       = 0.2
+	defaultHealthDownErrorRate           = 0.8
+	defaultHealthMinimumSamples          = 5
+	defaultHealthSampleExpiry            = time.Minute * 5
+	defaultHealthOrderQueueTimeout       = time.Second * 30
+	defaultDataHistoryCheckInterval      = time.Minute
+	defaultDataHistoryMaxJobsPerCycle    = 5
+	defaultBalanceSnapshotInterval       = time.Hour
+	defaultBalanceSnapshotFiatCurrency   = "USD"
+	defaultRetentionCheckInterval        = time.Hour * 6
+	defaultRetentionCandleInterval       = time.Minute
+	defaultChatCommandConfirmTimeout     = time.Minute
+	defaultWebhookTimeout                = time.Second * 10
+	defaultCommsRateLimitInterval        = time.Minute
+	defaultCommsMaxRetries               = 3
+	defaultCommsRetryDelay               = time.Second * 30
 	DefaultAPIKey                        = "Key"
 	DefaultAPISecret                     = "Secret"
 	DefaultAPIClientID                   = "ClientID"
@@ -63,34 +83,57 @@ const (
 	DefaultUnsetAPISecret                = "Secret"
 	DefaultUnsetAccountPlan              = "accountPlan"
 	DefaultForexProviderExchangeRatesAPI = "ExchangeRateHost"
+	DefaultForexProviderOffline          = "Offline"
+)
+
+// Constants here define how the order manager handles submissions to an
+// exchange that is down
+const (
+	UnhealthyOrderPolicyAllow = "allow"
+	UnhealthyOrderPolicyBlock = "block"
+	UnhealthyOrderPolicyQueue = "queue"
+)
+
+// Constants here define what happens to data once it is older than a
+// database retention policy allows
+const (
+	RetentionActionArchive = "archive"
+	RetentionActionDelete  = "delete"
 )
 
 // Variables here are used for configuration
 var (
 	Cfg Config
 	m   sync.Mutex
+
+	errAPITokenNil = errors.New("api token cannot be nil")
 )
 
 // Config is the overarching object that holds all the information for
 // prestart management of Portfolio, Communications, Webserver and Enabled
 // Exchanges
 type Config struct {
-	Name              string                  `json:"name"`
-	DataDirectory     string                  `json:"dataDirectory"`
-	EncryptConfig     int                     `json:"encryptConfig"`
-	GlobalHTTPTimeout time.Duration           `json:"globalHTTPTimeout"`
-	Database          database.Config         `json:"database"`
-	Logging           log.Config              `json:"logging"`
-	ConnectionMonitor ConnectionMonitorConfig `json:"connectionMonitor"`
-	Profiler          Profiler                `json:"profiler"`
-	NTPClient         NTPClientConfig         `json:"ntpclient"`
-	GCTScript         gctscript.Config        `json:"gctscript"`
-	Currency          CurrencyConfig          `json:"currencyConfig"`
-	Communications    CommunicationsConfig    `json:"communications"`
-	RemoteControl     RemoteControlConfig     `json:"remoteControl"`
-	Portfolio         portfolio.Base          `json:"portfolioAddresses"`
-	Exchanges         []ExchangeConfig        `json:"exchanges"`
-	BankAccounts      []banking.Account       `json:"bankAccounts"`
+	Name               string                   `json:"name"`
+	DataDirectory      string                   `json:"dataDirectory"`
+	EncryptConfig      int                      `json:"encryptConfig"`
+	GlobalHTTPTimeout  time.Duration            `json:"globalHTTPTimeout"`
+	Database           database.Config          `json:"database"`
+	Logging            log.Config               `json:"logging"`
+	ConnectionMonitor  ConnectionMonitorConfig  `json:"connectionMonitor"`
+	Profiler           Profiler                 `json:"profiler"`
+	NTPClient          NTPClientConfig          `json:"ntpclient"`
+	ExchangeHealth     ExchangeHealthConfig     `json:"exchangeHealth"`
+	DataHistoryManager DataHistoryManagerConfig `json:"dataHistoryManager"`
+	BalanceSnapshot    BalanceSnapshotConfig    `json:"balanceSnapshot"`
+	DatabaseRetention  DatabaseRetentionConfig  `json:"databaseRetention"`
+	OrderManager       OrderManagerConfig       `json:"orderManager"`
+	GCTScript          gctscript.Config         `json:"gctscript"`
+	Currency           CurrencyConfig           `json:"currencyConfig"`
+	Communications     CommunicationsConfig     `json:"communications"`
+	RemoteControl      RemoteControlConfig      `json:"remoteControl"`
+	Portfolio          portfolio.Base           `json:"portfolioAddresses"`
+	Exchanges          []ExchangeConfig         `json:"exchanges"`
+	BankAccounts       []banking.Account        `json:"bankAccounts"`
 
 	// Deprecated config settings, will be removed at a future date
 	Webserver           *WebserverConfig          `json:"webserver,omitempty"`
@@ -111,6 +154,71 @@ type ConnectionMonitorConfig struct {
 	CheckInterval    time.Duration `json:"checkInterval"`
 }
 
+// ExchangeHealthConfig defines the thresholds used to determine exchange
+// connectivity health and how order submissions to unhealthy exchanges are
+// handled
+type ExchangeHealthConfig struct {
+	CheckInterval        time.Duration `json:"checkInterval"`
+	TrafficAlertWindow   time.Duration `json:"trafficAlertWindow"`
+	DegradedErrorRate    float64       `json:"degradedErrorRate"`
+	DownErrorRate        float64       `json:"downErrorRate"`
+	MinimumSamples       uint32        `json:"minimumSamples"`
+	SampleExpiry         time.Duration `json:"sampleExpiry"`
+	UnhealthyOrderPolicy string        `json:"unhealthyOrderPolicy"`
+	OrderQueueTimeout    time.Duration `json:"orderQueueTimeout"`
+}
+
+// DataHistoryManagerConfig defines how often the data history manager
+// processes jobs and how many jobs are processed each time
+type DataHistoryManagerConfig struct {
+	CheckInterval   time.Duration `json:"checkInterval"`
+	MaxJobsPerCycle int64         `json:"maxJobsPerCycle"`
+	Verbose         bool          `json:"verbose"`
+}
+
+// BalanceSnapshotConfig defines how often account balances are snapshotted
+// to the database and the fiat currency they are valued in
+type BalanceSnapshotConfig struct {
+	Interval     time.Duration `json:"interval"`
+	FiatCurrency string        `json:"fiatCurrency"`
+	Verbose      bool          `json:"verbose"`
+}
+
+// DatabaseRetentionConfig defines how often retention policies are applied to
+// stored trade and candle data and where archived data is written
+type DatabaseRetentionConfig struct {
+	CheckInterval time.Duration     `json:"checkInterval"`
+	DryRun        bool              `json:"dryRun"`
+	ArchivePath   string            `json:"archivePath"`
+	Verbose       bool              `json:"verbose"`
+	Policies      []RetentionPolicy `json:"policies"`
+}
+
+// OrderManagerConfig defines the limits the order manager enforces on
+// submitted orders, they are only applied when EnforceLimitConfig is set
+type OrderManagerConfig struct {
+	EnforceLimitConfig     bool           `json:"enforceLimitConfig"`
+	AllowMarketOrders      bool           `json:"allowMarketOrders"`
+	CancelOrdersOnShutdown bool           `json:"cancelOrdersOnShutdown"`
+	LimitAmount            float64        `json:"limitAmount"`
+	AllowedExchanges       []string       `json:"allowedExchanges,omitempty"`
+	AllowedPairs           currency.Pairs `json:"allowedPairs,omitempty"`
+}
+
+// RetentionPolicy defines how long trades and candles are kept for an
+// exchange asset. Trades older than TradeRetention are downsampled into
+// candles of CandleInterval before being archived or deleted. Candles older
+// than CandleRetention are archived or deleted. A zero retention keeps data
+// forever
+type RetentionPolicy struct {
+	Exchange        string        `json:"exchange"`
+	Asset           string        `json:"asset"`
+	TradeRetention  time.Duration `json:"tradeRetention"`
+	CandleInterval  time.Duration `json:"candleInterval"`
+	CandleRetention time.Duration `json:"candleRetention"`
+	Action          string        `json:"action"`
+}
+
 // ExchangeConfig holds all the information needed for each enabled Exchange.
 type ExchangeConfig struct {
 	Name                          string                 `json:"name"`
@@ -129,6 +237,7 @@ type ExchangeConfig struct {
 	API                           APIConfig              `json:"api"`
 	Features                      *FeaturesConfig        `json:"features"`
 	BankAccounts                  []banking.Account      `json:"bankAccounts,omitempty"`
+	GenericSpec                   string                 `json:"genericSpec,omitempty"`
 	OrderbookConfig               `json:"orderbook"`
 
 	// Deprecated settings which will be removed in a future update
@@ -192,8 +301,10 @@ type WebsocketRPCConfig struct {
 
 // RemoteControlConfig stores the RPC services config
 type RemoteControlConfig struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string       `json:"username"`
+	Password string       `json:"password"`
+	Users    []auth.User  `json:"users,omitempty"`
+	Tokens   []auth.Token `json:"tokens,omitempty"`
 
 	GRPC          GRPCConfig           `json:"gRPC"`
 	DeprecatedRPC DepcrecatedRPCConfig `json:"deprecatedRPC"`
@@ -254,10 +365,15 @@ type CryptocurrencyProvider struct {
 // CommunicationsConfig holds all the information needed for each
 // enabled communication package
 type CommunicationsConfig struct {
-	SlackConfig     SlackConfig     `json:"slack"`
-	SMSGlobalConfig SMSGlobalConfig `json:"smsGlobal"`
-	SMTPConfig      SMTPConfig      `json:"smtp"`
-	TelegramConfig  TelegramConfig  `json:"telegram"`
+	SlackConfig     SlackConfig        `json:"slack"`
+	SMSGlobalConfig SMSGlobalConfig    `json:"smsGlobal"`
+	SMTPConfig      SMTPConfig         `json:"smtp"`
+	TelegramConfig  TelegramConfig     `json:"telegram"`
+	WebhookConfig   WebhookConfig      `json:"webhook"`
+	Webhooks        []WebhookConfig    `json:"webhooks,omitempty"`
+	DiscordConfig   DiscordConfig      `json:"discord"`
+	ChatCommands    ChatCommandsConfig `json:"chatCommands"`
+	Routing         CommsRoutingConfig `json:"routing"`
 }
 
 // IsAnyEnabled returns whether or any any comms relayers
@@ -266,9 +382,16 @@ func (c *CommunicationsConfig) IsAnyEnabled() bool {
 	if c.SMSGlobalConfig.Enabled ||
 		c.SMTPConfig.Enabled ||
 		c.SlackConfig.Enabled ||
-		c.TelegramConfig.Enabled {
+		c.TelegramConfig.Enabled ||
+		c.WebhookConfig.Enabled ||
+		c.DiscordConfig.Enabled {
 		return true
 	}
+	for i := range c.Webhooks {
+		if c.Webhooks[i].Enabled {
+			return true
+		}
+	}
 	return false
 }
 
@@ -321,6 +444,73 @@ type TelegramConfig struct {
 	VerificationToken string `json:"verificationToken"`
 }
 
+// WebhookConfig holds all variables to start and run the generic HTTP webhook
+// package. BodyTemplate is a Go text/template executed against each event,
+// EventTemplates overrides it for specific event types
+type WebhookConfig struct {
+	Name           string            `json:"name"`
+	Enabled        bool              `json:"enabled"`
+	Verbose        bool              `json:"verbose"`
+	URL            string            `json:"url"`
+	Method         string            `json:"method"`
+	Headers        map[string]string `json:"headers"`
+	BodyTemplate   string            `json:"bodyTemplate"`
+	EventTemplates map[string]string `json:"eventTemplates,omitempty"`
+	Timeout        time.Duration     `json:"timeout"`
+}
+
+// DiscordConfig holds all variables to start and run the Discord package.
+// MessageTemplate is a Go text/template executed against each event,
+// EventTemplates overrides it for specific event types
+type DiscordConfig struct {
+	Name            string            `json:"name"`
+	Enabled         bool              `json:"enabled"`
+	Verbose         bool              `json:"verbose"`
+	WebhookURL      string            `json:"webhookURL"`
+	Username        string            `json:"username"`
+	MessageTemplate string            `json:"messageTemplate"`
+	EventTemplates  map[string]string `json:"eventTemplates,omitempty"`
+}
+
+// CommsRoutingConfig holds the rules deciding which relayers receive an event
+// and how failed deliveries are retried. With no rules every event is sent to
+// every enabled relayer
+type CommsRoutingConfig struct {
+	Rules      []CommsRouteRule `json:"rules"`
+	MaxRetries int              `json:"maxRetries"`
+	RetryDelay time.Duration    `json:"retryDelay"`
+}
+
+// CommsRouteRule matches events by type and severity and sends them to a set
+// of relayers. Rules are checked in order and the first match is used, events
+// matching no rule are not sent. Empty lists match everything
+type CommsRouteRule struct {
+	Name              string        `json:"name"`
+	EventTypes        []string      `json:"eventTypes"`
+	Severities        []string      `json:"severities"`
+	Relayers          []string      `json:"relayers"`
+	RateLimit         int           `json:"rateLimit"`
+	RateLimitInterval time.Duration `json:"rateLimitInterval"`
+	DedupWindow       time.Duration `json:"dedupWindow"`
+	DigestInterval    time.Duration `json:"digestInterval"`
+}
+
+// ChatCommandsConfig stores which communication relayer users are allowed to
+// control the bot with chat commands
+type ChatCommandsConfig struct {
+	Enabled             bool              `json:"enabled"`
+	ConfirmationTimeout time.Duration     `json:"confirmationTimeout"`
+	AuthorisedUsers     []ChatCommandUser `json:"authorisedUsers"`
+}
+
+// ChatCommandUser is a relayer user ID and the chat commands it may run. A
+// command of "*" allows every command
+type ChatCommandUser struct {
+	Relayer  string   `json:"relayer"`
+	ID       string   `json:"id"`
+	Commands []string `json:"commands"`
+}
+
 // FeaturesSupportedConfig stores the exchanges supported features
 type FeaturesSupportedConfig struct {
 	REST                  bool              `json:"restAPI"`
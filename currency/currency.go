@@ -1,5 +1,9 @@
 package currency
 
+import (
+	"time"
+)
+
 // GetDefaultExchangeRates returns the currency exchange rates based off the
 // default fiat values
 func GetDefaultExchangeRates() (Conversions, error) {
@@ -61,6 +65,21 @@ func ConvertCurrency(amount float64, from, to Code) (float64, error) {
 	return storage.ConvertCurrency(amount, from, to)
 }
 
+// ConvertCurrencyAt converts an amount from one fiat currency to another
+// using the rate on the supplied date
+func ConvertCurrencyAt(amount float64, from, to Code, date time.Time) (float64, error) {
+	r, err := storage.GetHistoricalRate(from, to, date)
+	if err != nil {
+		return 0, err
+	}
+	return r * amount, nil
+}
+
+// GetHistoricalRate returns the fiat conversion rate on the supplied date
+func GetHistoricalRate(from, to Code, date time.Time) (float64, error) {
+	return storage.GetHistoricalRate(from, to, date)
+}
+
 // SeedForeignExchangeData seeds FX data with the currencies supplied
 func SeedForeignExchangeData(c Currencies) error {
 	return storage.SeedForeignExchangeRatesByCurrencies(c)
@@ -71,6 +90,11 @@ func GetTotalMarketCryptocurrencies() ([]Code, error) {
 	return storage.GetTotalMarketCryptocurrencies()
 }
 
+// SetRateCache sets the cache historical rates are stored in
+func SetRateCache(c RateCache) {
+	storage.SetRateCache(c)
+}
+
 // RunStorageUpdater runs a new foreign exchange updater instance
 func RunStorageUpdater(o BotOverrides, m *MainConfiguration, filepath string) error {
 	return storage.RunUpdater(o, m, filepath)
@@ -49,6 +49,7 @@ type FXSettings struct {
 	APIKey           string        `json:"apiKey"`
 	APIKeyLvl        int           `json:"apiKeyLvl"`
 	PrimaryProvider  bool          `json:"primaryProvider"`
+	FilePath         string        `json:"filePath,omitempty"`
 }
 
 // File defines a full currency file generated by the currency storage
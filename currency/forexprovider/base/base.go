@@ -31,6 +31,7 @@ type Settings struct {
 	APIKey           string        `json:"apiKey"`
 	APIKeyLvl        int           `json:"apiKeyLvl"`
 	PrimaryProvider  bool          `json:"primaryProvider"`
+	FilePath         string        `json:"filePath,omitempty"`
 }
 
 // Base enforces standard variables across the provider packages
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 )
@@ -14,12 +15,32 @@ import (
 type IFXProvider interface {
 	Setup(config Settings) error
 	GetRates(baseCurrency, symbols string) (map[string]float64, error)
+	GetHistoricalRates(date time.Time, baseCurrency, symbols string) (map[string]float64, error)
 	GetName() string
 	IsEnabled() bool
 	IsPrimaryProvider() bool
 	GetSupportedCurrencies() ([]string, error)
 }
 
+// ErrHistoricalRatesUnsupported is returned by providers that cannot fetch
+// rates for past dates
+var ErrHistoricalRatesUnsupported = errors.New("historical rates not supported")
+
+// DatedHistoricalRatesProvider is implemented by providers which may answer a
+// historical rates request with the rates of an earlier day, returning the day
+// the rates are for
+type DatedHistoricalRatesProvider interface {
+	GetDatedHistoricalRates(date time.Time, baseCurrency, symbols string) (map[string]float64, time.Time, error)
+}
+
+// HistoricalRate is a rate for a past date, the provider that supplied it and
+// the day the provider's rate is for
+type HistoricalRate struct {
+	Rate     float64
+	Provider string
+	Date     time.Time
+}
+
 // FXHandler defines a full suite of FX data providers with failure backup with
 // unsupported currency shunt procedure
 type FXHandler struct {
@@ -154,3 +175,103 @@ func (f *FXHandler) backupGetRate(base string, currencies []string) (map[string]
 
 	return nil, fmt.Errorf("currencies %s not supported", shunt)
 }
+
+// GetHistoricalCurrencyData returns the rates for a past date from enabled FX
+// providers. Providers are tried in order, primary first, until every
+// currency has a rate
+func (f *FXHandler) GetHistoricalCurrencyData(date time.Time, baseCurrency string, currencies []string) (map[string]float64, error) {
+	rates, err := f.historicalRates(date, baseCurrency, currencies)
+	if err != nil {
+		return nil, err
+	}
+	resp := make(map[string]float64, len(rates))
+	for k, v := range rates {
+		resp[k] = v.Rate
+	}
+	return resp, nil
+}
+
+// GetHistoricalRate returns a single rate for a past date along with the name
+// of the provider that supplied it and the day the rate is for, which can be
+// earlier than the requested date when a provider falls back to older rates
+func (f *FXHandler) GetHistoricalRate(date time.Time, baseCurrency, quoteCurrency string) (HistoricalRate, error) {
+	rates, err := f.historicalRates(date, baseCurrency, []string{quoteCurrency})
+	if err != nil {
+		return HistoricalRate{}, err
+	}
+	return rates[baseCurrency+quoteCurrency], nil
+}
+
+func (f *FXHandler) historicalRates(date time.Time, baseCurrency string, currencies []string) (map[string]HistoricalRate, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.Primary.Provider == nil {
+		return nil, errors.New("primary foreign exchange provider details not set")
+	}
+
+	day := date.UTC()
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	rates := make(map[string]HistoricalRate)
+	remaining := currencies
+	var errs []string
+	for _, p := range append([]Provider{f.Primary}, f.Support...) {
+		if !p.Provider.IsEnabled() {
+			continue
+		}
+		// currencies the provider doesn't support are left for the next
+		shunt := p.CheckCurrencies(remaining)
+		if len(shunt) == len(remaining) {
+			continue
+		}
+		request := supported(remaining, shunt)
+		var newRates map[string]float64
+		ratesDay := day
+		var err error
+		if dated, ok := p.Provider.(DatedHistoricalRatesProvider); ok {
+			newRates, ratesDay, err = dated.GetDatedHistoricalRates(date, baseCurrency, strings.Join(request, ","))
+		} else {
+			newRates, err = p.Provider.GetHistoricalRates(date, baseCurrency, strings.Join(request, ","))
+		}
+		if err != nil {
+			errs = append(errs, p.Provider.GetName()+": "+err.Error())
+			continue
+		}
+		var missing []string
+		for i := range remaining {
+			rate, ok := newRates[baseCurrency+remaining[i]]
+			if !ok {
+				missing = append(missing, remaining[i])
+				continue
+			}
+			rates[baseCurrency+remaining[i]] = HistoricalRate{
+				Rate:     rate,
+				Provider: p.Provider.GetName(),
+				Date:     ratesDay,
+			}
+		}
+		remaining = missing
+		if len(remaining) == 0 {
+			return rates, nil
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("unable to fetch %s historical rates for %v on %s: %s",
+			baseCurrency,
+			remaining,
+			date.UTC().Format("2006-01-02"),
+			strings.Join(errs, ", "))
+	}
+	return nil, fmt.Errorf("historical rates for currencies %s not supported", remaining)
+}
+
+// supported returns the currencies not in the unsupported list
+func supported(currencies, unsupported []string) []string {
+	var out []string
+	for i := range currencies {
+		if !common.StringDataCompareInsensitive(unsupported, currencies[i]) {
+			out = append(out, currencies[i])
+		}
+	}
+	return out
+}
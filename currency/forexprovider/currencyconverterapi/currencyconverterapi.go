@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
@@ -118,6 +119,11 @@ func (c *CurrencyConverter) Convert(from, to string) (map[string]float64, error)
 	return result, nil
 }
 
+// GetHistoricalRates is not supported by the CurrencyConverter API
+func (c *CurrencyConverter) GetHistoricalRates(_ time.Time, _, _ string) (map[string]float64, error) {
+	return nil, fmt.Errorf("%s %w", c.Name, base.ErrHistoricalRatesUnsupported)
+}
+
 // GetSupportedCurrencies returns a list of the supported currencies
 func (c *CurrencyConverter) GetSupportedCurrencies() ([]string, error) {
 	var result Currencies
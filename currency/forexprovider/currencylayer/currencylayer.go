@@ -20,6 +20,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
@@ -55,6 +56,11 @@ func (c *CurrencyLayer) GetRates(baseCurrency, symbols string) (map[string]float
 	return c.GetliveData(symbols, baseCurrency)
 }
 
+// GetHistoricalRates is a wrapper function to return rates for a past date
+func (c *CurrencyLayer) GetHistoricalRates(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	return c.GetHistoricalData(date.UTC().Format(timeLayout), strings.Split(symbols, ","), baseCurrency)
+}
+
 // GetSupportedCurrencies returns supported currencies
 func (c *CurrencyLayer) GetSupportedCurrencies() ([]string, error) {
 	var resp SupportedCurrencies
@@ -23,6 +23,7 @@ const (
 
 	authRate   = 0
 	unAuthRate = 0
+	timeLayout = "2006-01-02"
 )
 
 // CurrencyLayer is a foreign exchange rate provider at
@@ -102,8 +102,8 @@ func (e *ExchangeRateHost) ConvertCurrency(from, to, baseCurrency, symbols, sour
 	return &c, e.SendHTTPRequest("convert", v, &c)
 }
 
-// GetHistoricalRates returns a list of historical rates based on the supplied params
-func (e *ExchangeRateHost) GetHistoricalRates(date time.Time, baseCurrency, symbols string, amount float64, places int64, source string) (*HistoricRates, error) {
+// GetHistoricalData returns a list of historical rates based on the supplied params
+func (e *ExchangeRateHost) GetHistoricalData(date time.Time, baseCurrency, symbols string, amount float64, places int64, source string) (*HistoricRates, error) {
 	v := url.Values{}
 	if date.IsZero() {
 		date = time.Now()
@@ -250,6 +250,21 @@ func (e *ExchangeRateHost) GetRates(baseCurrency, symbols string) (map[string]fl
 	return rates, nil
 }
 
+// GetHistoricalRates returns the forex rates for a past date based on the
+// supplied base currency and symbols
+func (e *ExchangeRateHost) GetHistoricalRates(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	h, err := e.GetHistoricalData(date, baseCurrency, symbols, 0, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]float64)
+	for k, v := range h.Rates {
+		rates[baseCurrency+k] = v
+	}
+	return rates, nil
+}
+
 // SendHTTPRequest sends a typical get request
 func (e *ExchangeRateHost) SendHTTPRequest(endpoint string, v url.Values, result interface{}) error {
 	path := common.EncodeURLValues(exchangeRateHostURL+"/"+endpoint, v)
@@ -35,7 +35,7 @@ func TestConvertCurrency(t *testing.T) {
 }
 
 func TestGetHistoricRates(t *testing.T) {
-	_, err := e.GetHistoricalRates(time.Time{}, "AUD", testCurrencies, 1200, 2, "")
+	_, err := e.GetHistoricalData(time.Time{}, "AUD", testCurrencies, 1200, 2, "")
 	if err != nil {
 		t.Error(err)
 	}
@@ -103,13 +103,13 @@ func (e *ExchangeRates) GetLatestRates(baseCurrency, symbols string) (*Rates, er
 	return &result, e.SendHTTPRequest(exchangeRatesLatest, vals, &result)
 }
 
-// GetHistoricalRates returns historical exchange rate data for all available or
+// GetHistoricalData returns historical exchange rate data for all available or
 // a specific set of currencies.
 // date - YYYY-MM-DD	[required] A date in the past
 // baseCurrency - USD 			[optional] The base currency to use for forex rates, defaults to EUR
 // symbols - AUD,USD	[optional] The symbols to query the forex rates for, default is
 // all supported currencies
-func (e *ExchangeRates) GetHistoricalRates(date time.Time, baseCurrency string, symbols []string) (*HistoricalRates, error) {
+func (e *ExchangeRates) GetHistoricalData(date time.Time, baseCurrency string, symbols []string) (*HistoricalRates, error) {
 	if date.IsZero() {
 		return nil, errors.New("a date must be specified")
 	}
@@ -235,6 +235,26 @@ func (e *ExchangeRates) GetRates(baseCurrency, symbols string) (map[string]float
 	return standardisedRates, nil
 }
 
+// GetHistoricalRates is a wrapper function to return forex rates for a past
+// date
+func (e *ExchangeRates) GetHistoricalRates(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	var s []string
+	if symbols != "" {
+		s = strings.Split(symbols, ",")
+	}
+	result, err := e.GetHistoricalData(date, baseCurrency, s)
+	if err != nil {
+		return nil, err
+	}
+
+	standardisedRates := make(map[string]float64)
+	for k, v := range result.Rates.Rates {
+		standardisedRates[baseCurrency+k] = v
+	}
+
+	return standardisedRates, nil
+}
+
 // GetSupportedCurrencies returns the supported currency list
 func (e *ExchangeRates) GetSupportedCurrencies() ([]string, error) {
 	symbols, err := e.GetSymbols()
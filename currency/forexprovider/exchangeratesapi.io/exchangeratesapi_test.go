@@ -86,24 +86,24 @@ func TestGetLatestRates(t *testing.T) {
 	}
 }
 
-func TestGetHistoricalRates(t *testing.T) {
+func TestGetHistoricalData(t *testing.T) {
 	if !isAPIKeySet() {
 		t.Skip("API key not set, skipping test")
 	}
 
-	_, err := e.GetHistoricalRates(time.Time{}, "EUR", []string{"AUD"})
+	_, err := e.GetHistoricalData(time.Time{}, "EUR", []string{"AUD"})
 	if err == nil {
 		t.Fatalf("invalid date should throw an error")
 	}
 
 	if e.APIKeyLvl <= apiKeyFree {
-		_, err = e.GetHistoricalRates(time.Now(), "USD", []string{"AUD"})
+		_, err = e.GetHistoricalData(time.Now(), "USD", []string{"AUD"})
 		if !errors.Is(err, errCannotSetBaseCurrencyOnFreePlan) {
 			t.Errorf("expected: %s, got %s", errCannotSetBaseCurrencyOnFreePlan, err)
 		}
 	}
 
-	_, err = e.GetHistoricalRates(time.Now(), "EUR", []string{"AUD,USD"})
+	_, err = e.GetHistoricalData(time.Now(), "EUR", []string{"AUD,USD"})
 	if err != nil {
 		t.Error(err)
 	}
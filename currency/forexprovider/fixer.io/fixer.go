@@ -11,10 +11,12 @@ package fixer
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
@@ -107,12 +109,38 @@ func (f *Fixer) GetLatestRates(baseCurrency, symbols string) (map[string]float64
 	return resp.Rates, nil
 }
 
-// GetHistoricalRates returns historical exchange rate data for all available or
+// GetHistoricalRates is a wrapper function to return rates for a past date
+// Free tier rates are always based on EUR, so requests for any other base
+// currency return an error for the next provider to be tried
+func (f *Fixer) GetHistoricalRates(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	requestBase := baseCurrency
+	if f.APIKeyLvl == fixerAPIFree {
+		if !strings.EqualFold(baseCurrency, fixerFreeBaseCurrency) {
+			return nil, fmt.Errorf("%s %w, %s requested", f.Name, errBaseCurrencyRestricted, baseCurrency)
+		}
+		// the free tier rejects the base parameter
+		requestBase = ""
+	}
+
+	rates, err := f.GetHistoricalData(date.UTC().Format(timeLayout), requestBase, strings.Split(symbols, ","))
+	if err != nil {
+		return nil, err
+	}
+
+	standardisedRates := make(map[string]float64)
+	for k, v := range rates {
+		standardisedRates[baseCurrency+k] = v
+	}
+
+	return standardisedRates, nil
+}
+
+// GetHistoricalData returns historical exchange rate data for all available or
 // a specific set of currencies.
 // date - YYYY-MM-DD	[required] A date in the past
 // base - USD 			[optional]
 // symbols - the desired symbols
-func (f *Fixer) GetHistoricalRates(date, baseCurrency string, symbols []string) (map[string]float64, error) {
+func (f *Fixer) GetHistoricalData(date, baseCurrency string, symbols []string) (map[string]float64, error) {
 	var resp Rates
 
 	v := url.Values{}
@@ -1,7 +1,9 @@
 package fixer
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
 )
@@ -44,11 +46,20 @@ func TestGetLatestRates(t *testing.T) {
 	}
 }
 
-func TestGetHistoricalRates(t *testing.T) {
+func TestGetHistoricalData(t *testing.T) {
 	setup(t)
-	_, err := f.GetHistoricalRates("2013-12-24", "EUR", []string{"AUD,KRW"})
+	_, err := f.GetHistoricalData("2013-12-24", "EUR", []string{"AUD,KRW"})
 	if err == nil {
-		t.Error("fixer GetHistoricalRates() Expected error")
+		t.Error("fixer GetHistoricalData() Expected error")
+	}
+}
+
+func TestGetHistoricalRates(t *testing.T) {
+	free := Fixer{}
+	free.APIKeyLvl = fixerAPIFree
+	_, err := free.GetHistoricalRates(time.Now(), "USD", "AUD")
+	if !errors.Is(err, errBaseCurrencyRestricted) {
+		t.Errorf("received %v expected %v", err, errBaseCurrencyRestricted)
 	}
 }
 
@@ -1,6 +1,8 @@
 package fixer
 
 import (
+	"errors"
+
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 )
@@ -19,8 +21,12 @@ const (
 	fixerAPITimeSeries       = "timeseries"
 	fixerAPIFluctuation      = "fluctuation"
 	fixerSupportedCurrencies = "symbols"
+	timeLayout               = "2006-01-02"
+	fixerFreeBaseCurrency    = "EUR"
 )
 
+var errBaseCurrencyRestricted = errors.New("free tier rates are only available in EUR")
+
 // Fixer is a foreign exchange rate provider at https://fixer.io/
 // NOTE DEFAULT BASE CURRENCY IS EUR upgrade to basic to change
 type Fixer struct {
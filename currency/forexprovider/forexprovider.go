@@ -11,6 +11,7 @@ import (
 	exchangeratehost "github.com/thrasher-corp/gocryptotrader/currency/forexprovider/exchangerate.host"
 	exchangerates "github.com/thrasher-corp/gocryptotrader/currency/forexprovider/exchangeratesapi.io"
 	fixer "github.com/thrasher-corp/gocryptotrader/currency/forexprovider/fixer.io"
+	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/offline"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/openexchangerates"
 )
 
@@ -23,6 +24,7 @@ func GetSupportedForexProviders() []string {
 		"Fixer",
 		"OpenExchangeRates",
 		"ExchangeRateHost",
+		"Offline",
 	}
 }
 
@@ -124,6 +126,15 @@ func StartFXService(fxProviders []base.Settings) (*ForexProviders, error) {
 				return nil, err
 			}
 
+			handler.SetProvider(provider)
+
+		case fxProviders[i].Name == "Offline" && fxProviders[i].Enabled:
+			provider := new(offline.Offline)
+			err := provider.Setup(fxProviders[i])
+			if err != nil {
+				return nil, err
+			}
+
 			handler.SetProvider(provider)
 		}
 	}
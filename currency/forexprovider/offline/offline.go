@@ -0,0 +1,245 @@
+package offline
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
+)
+
+const (
+	timeLayout = "2006-01-02"
+	// MaxStaleness is how far back a historical lookup will search for the
+	// closest earlier day when the requested day is missing from the file,
+	// which covers weekends and bank holidays
+	MaxStaleness = time.Hour * 24 * 7
+)
+
+var (
+	errFilePathUnset       = errors.New("file path not set")
+	errUnsupportedFileType = errors.New("unsupported rates file type, must be .csv or .json")
+	errNoRates             = errors.New("no rates loaded")
+	errNoRatesForDate      = errors.New("no rates found for date")
+	errInvalidRecord       = errors.New("invalid rate record")
+)
+
+// Setup sets up the offline provider and loads its rates file
+func (o *Offline) Setup(config base.Settings) error {
+	o.Name = config.Name
+	o.Enabled = config.Enabled
+	o.RESTPollingDelay = config.RESTPollingDelay
+	o.Verbose = config.Verbose
+	o.PrimaryProvider = config.PrimaryProvider
+	o.FilePath = config.FilePath
+	return o.Load(config.FilePath)
+}
+
+// Load reads rates from a CSV or JSON file, replacing any rates already loaded
+func (o *Offline) Load(path string) error {
+	if path == "" {
+		return errFilePathUnset
+	}
+	var records []Record
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		records, err = readCSV(path)
+	case ".json":
+		records, err = readJSON(path)
+	default:
+		return fmt.Errorf("%s %w", path, errUnsupportedFileType)
+	}
+	if err != nil {
+		return err
+	}
+	return o.loadRecords(records)
+}
+
+func readCSV(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 4
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	records := make([]Record, 0, len(rows))
+	for i := range rows {
+		rate, err := strconv.ParseFloat(rows[i][3], 64)
+		if err != nil {
+			if i == 0 {
+				// header row
+				continue
+			}
+			return nil, fmt.Errorf("%s line %d %w: %v", path, i+1, errInvalidRecord, err)
+		}
+		records = append(records, Record{
+			Date:  rows[i][0],
+			Base:  rows[i][1],
+			Quote: rows[i][2],
+			Rate:  rate,
+		})
+	}
+	return records, nil
+}
+
+func readJSON(path string) ([]Record, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var records []Record
+	return records, json.Unmarshal(data, &records)
+}
+
+func (o *Offline) loadRecords(records []Record) error {
+	rates := make(map[time.Time]map[string]float64)
+	codes := make(map[string]struct{})
+	for i := range records {
+		date, err := time.Parse(timeLayout, strings.TrimSpace(records[i].Date))
+		if err != nil {
+			return fmt.Errorf("%w %+v: %v", errInvalidRecord, records[i], err)
+		}
+		b := strings.ToUpper(strings.TrimSpace(records[i].Base))
+		q := strings.ToUpper(strings.TrimSpace(records[i].Quote))
+		if b == "" || q == "" || records[i].Rate <= 0 {
+			return fmt.Errorf("%w %+v", errInvalidRecord, records[i])
+		}
+		if rates[date] == nil {
+			rates[date] = make(map[string]float64)
+		}
+		rates[date][b+q] = records[i].Rate
+		codes[b] = struct{}{}
+		codes[q] = struct{}{}
+	}
+	if len(rates) == 0 {
+		return errNoRates
+	}
+
+	dates := make([]time.Time, 0, len(rates))
+	for d := range rates {
+		dates = append(dates, d)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	currencies := make([]string, 0, len(codes))
+	for c := range codes {
+		currencies = append(currencies, c)
+	}
+	sort.Strings(currencies)
+
+	o.rates = rates
+	o.dates = dates
+	o.currencies = currencies
+	return nil
+}
+
+// GetSupportedCurrencies returns every currency code found in the rates file
+func (o *Offline) GetSupportedCurrencies() ([]string, error) {
+	if len(o.dates) == 0 {
+		return nil, errNoRates
+	}
+	return o.currencies, nil
+}
+
+// GetRates returns the most recent rates in the file for the supplied base
+// currency and comma separated symbols
+func (o *Offline) GetRates(baseCurrency, symbols string) (map[string]float64, error) {
+	if len(o.dates) == 0 {
+		return nil, errNoRates
+	}
+	return o.ratesForDay(o.dates[len(o.dates)-1], baseCurrency, symbols), nil
+}
+
+// GetHistoricalRates returns the rates for the supplied date. When the day is
+// missing the closest earlier day within MaxStaleness is used
+func (o *Offline) GetHistoricalRates(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	rates, _, err := o.GetDatedHistoricalRates(date, baseCurrency, symbols)
+	return rates, err
+}
+
+// GetDatedHistoricalRates returns the rates for the supplied date along with
+// the day the rates are for, which is earlier than the supplied date when the
+// day is missing from the file
+func (o *Offline) GetDatedHistoricalRates(date time.Time, baseCurrency, symbols string) (map[string]float64, time.Time, error) {
+	if len(o.dates) == 0 {
+		return nil, time.Time{}, errNoRates
+	}
+	date = date.UTC()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	i := sort.Search(len(o.dates), func(i int) bool { return o.dates[i].After(day) }) - 1
+	if i < 0 || day.Sub(o.dates[i]) > MaxStaleness {
+		return nil, time.Time{}, fmt.Errorf("%s %w %s", o.Name, errNoRatesForDate, day.Format(timeLayout))
+	}
+	return o.ratesForDay(o.dates[i], baseCurrency, symbols), o.dates[i], nil
+}
+
+// ratesForDay returns the rates keyed by base+quote. Symbols that cannot be
+// derived from the day's rates are left out for the caller to handle
+func (o *Offline) ratesForDay(day time.Time, baseCurrency, symbols string) map[string]float64 {
+	baseCurrency = strings.ToUpper(baseCurrency)
+	var quotes []string
+	if symbols == "" {
+		quotes = o.currencies
+	} else {
+		quotes = strings.Split(strings.ToUpper(symbols), ",")
+	}
+	resp := make(map[string]float64)
+	for i := range quotes {
+		if quotes[i] == baseCurrency {
+			continue
+		}
+		rate, ok := o.crossRate(o.rates[day], baseCurrency, quotes[i])
+		if ok {
+			resp[baseCurrency+quotes[i]] = rate
+		}
+	}
+	return resp
+}
+
+// crossRate derives a rate from a direct quote, its inverse or through a
+// single pivot currency
+func (o *Offline) crossRate(rates map[string]float64, from, to string) (float64, bool) {
+	if rate, ok := pairRate(rates, from, to); ok {
+		return rate, true
+	}
+	for i := range o.currencies {
+		pivot := o.currencies[i]
+		if pivot == from || pivot == to {
+			continue
+		}
+		first, ok := pairRate(rates, from, pivot)
+		if !ok {
+			continue
+		}
+		second, ok := pairRate(rates, pivot, to)
+		if ok {
+			return first * second, true
+		}
+	}
+	return 0, false
+}
+
+func pairRate(rates map[string]float64, from, to string) (float64, bool) {
+	if rate, ok := rates[from+to]; ok {
+		return rate, true
+	}
+	if rate, ok := rates[to+from]; ok {
+		return 1 / rate, true
+	}
+	return 0, false
+}
@@ -0,0 +1,167 @@
+package offline
+
+import (
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
+)
+
+const testCSV = `date,base,quote,rate
+2020-01-01,USD,AUD,1.4
+2020-01-01,USD,EUR,0.9
+2020-01-03,USD,AUD,1.5
+2020-01-03,USD,EUR,0.8
+`
+
+const testJSON = `[
+	{"date":"2020-01-01","base":"USD","quote":"AUD","rate":1.4},
+	{"date":"2020-01-01","base":"USD","quote":"EUR","rate":0.9}
+]`
+
+func writeTestFile(t *testing.T, name, data string) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "gct-offline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	err = ioutil.WriteFile(path, []byte(data), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func setupTestProvider(t *testing.T) (*Offline, func()) {
+	t.Helper()
+	path, cleanup := writeTestFile(t, "rates.csv", testCSV)
+	var o Offline
+	err := o.Setup(base.Settings{Name: "Offline", Enabled: true, FilePath: path})
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	return &o, cleanup
+}
+
+func TestSetup(t *testing.T) {
+	var o Offline
+	err := o.Setup(base.Settings{Name: "Offline"})
+	if !errors.Is(err, errFilePathUnset) {
+		t.Errorf("received %v expected %v", err, errFilePathUnset)
+	}
+	err = o.Setup(base.Settings{Name: "Offline", FilePath: "rates.txt"})
+	if !errors.Is(err, errUnsupportedFileType) {
+		t.Errorf("received %v expected %v", err, errUnsupportedFileType)
+	}
+
+	path, cleanup := writeTestFile(t, "rates.json", testJSON)
+	defer cleanup()
+	err = o.Setup(base.Settings{Name: "Offline", FilePath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := o.GetSupportedCurrencies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c) != 3 || c[0] != "AUD" || c[1] != "EUR" || c[2] != "USD" {
+		t.Errorf("unexpected currencies %v", c)
+	}
+
+	bad, cleanupBad := writeTestFile(t, "bad.csv", "2020-01-01,USD,AUD,1.4\n2020-01-02,USD,AUD,bad\n")
+	defer cleanupBad()
+	err = o.Load(bad)
+	if !errors.Is(err, errInvalidRecord) {
+		t.Errorf("received %v expected %v", err, errInvalidRecord)
+	}
+
+	empty, cleanupEmpty := writeTestFile(t, "empty.json", "[]")
+	defer cleanupEmpty()
+	err = o.Load(empty)
+	if !errors.Is(err, errNoRates) {
+		t.Errorf("received %v expected %v", err, errNoRates)
+	}
+}
+
+func TestGetRates(t *testing.T) {
+	o, cleanup := setupTestProvider(t)
+	defer cleanup()
+	r, err := o.GetRates("USD", "AUD,EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r["USDAUD"] != 1.5 || r["USDEUR"] != 0.8 {
+		t.Errorf("unexpected rates %v", r)
+	}
+}
+
+func TestGetHistoricalRates(t *testing.T) {
+	o, cleanup := setupTestProvider(t)
+	defer cleanup()
+	r, err := o.GetHistoricalRates(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), "USD", "AUD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r["USDAUD"] != 1.4 {
+		t.Errorf("received %v expected %v", r["USDAUD"], 1.4)
+	}
+
+	// a missing day falls back to the closest earlier day
+	r, err = o.GetHistoricalRates(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), "USD", "AUD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r["USDAUD"] != 1.4 {
+		t.Errorf("received %v expected %v", r["USDAUD"], 1.4)
+	}
+
+	_, err = o.GetHistoricalRates(time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC), "USD", "AUD")
+	if !errors.Is(err, errNoRatesForDate) {
+		t.Errorf("received %v expected %v", err, errNoRatesForDate)
+	}
+	_, err = o.GetHistoricalRates(time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), "USD", "AUD")
+	if !errors.Is(err, errNoRatesForDate) {
+		t.Errorf("received %v expected %v", err, errNoRatesForDate)
+	}
+}
+
+func TestGetDatedHistoricalRates(t *testing.T) {
+	o, cleanup := setupTestProvider(t)
+	defer cleanup()
+	r, date, err := o.GetDatedHistoricalRates(time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC), "USD", "AUD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r["USDAUD"] != 1.4 {
+		t.Errorf("received %v expected %v", r["USDAUD"], 1.4)
+	}
+	expected := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !date.Equal(expected) {
+		t.Errorf("received %v expected %v", date, expected)
+	}
+}
+
+func TestCrossRates(t *testing.T) {
+	o, cleanup := setupTestProvider(t)
+	defer cleanup()
+	r, err := o.GetHistoricalRates(time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC), "AUD", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r) != 2 {
+		t.Fatalf("received %v expected %v", len(r), 2)
+	}
+	if math.Abs(r["AUDUSD"]-1/1.5) > 1e-9 {
+		t.Errorf("received %v expected %v", r["AUDUSD"], 1/1.5)
+	}
+	if math.Abs(r["AUDEUR"]-0.8/1.5) > 1e-9 {
+		t.Errorf("received %v expected %v", r["AUDEUR"], 0.8/1.5)
+	}
+}
@@ -0,0 +1,26 @@
+package offline
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
+)
+
+// Offline serves forex rates from a local CSV or JSON file so conversions can
+// be done without network access
+type Offline struct {
+	base.Base
+	// rates are keyed by UTC day then by base+quote
+	rates      map[time.Time]map[string]float64
+	dates      []time.Time
+	currencies []string
+}
+
+// Record defines a single daily rate as stored in a JSON rates file. CSV files
+// hold the same fields in the column order date,base,quote,rate
+type Record struct {
+	Date  string  `json:"date"`
+	Base  string  `json:"base"`
+	Quote string  `json:"quote"`
+	Rate  float64 `json:"rate"`
+}
@@ -16,6 +16,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
@@ -80,9 +81,24 @@ func (o *OXR) GetLatest(baseCurrency, symbols string, prettyPrint, showAlternati
 	return resp.Rates, nil
 }
 
-// GetHistoricalRates returns historical exchange rates for any date available
+// GetHistoricalRates is a wrapper function to return rates for a past date
+func (o *OXR) GetHistoricalRates(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	rates, err := o.GetHistoricalData(date.UTC().Format(timeLayout), baseCurrency, strings.Split(symbols, ","), false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	standardisedRates := make(map[string]float64)
+	for k, v := range rates {
+		standardisedRates[baseCurrency+k] = v
+	}
+
+	return standardisedRates, nil
+}
+
+// GetHistoricalData returns historical exchange rates for any date available
 // from the Open Exchange Rates API.
-func (o *OXR) GetHistoricalRates(date, baseCurrency string, symbols []string, prettyPrint, showAlternative bool) (map[string]float64, error) {
+func (o *OXR) GetHistoricalData(date, baseCurrency string, symbols []string, prettyPrint, showAlternative bool) (map[string]float64, error) {
 	var resp Latest
 
 	v := url.Values{}
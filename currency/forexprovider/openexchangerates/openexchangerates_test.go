@@ -45,11 +45,11 @@ func TestGetLatest(t *testing.T) {
 	}
 }
 
-func TestGetHistoricalRates(t *testing.T) {
+func TestGetHistoricalData(t *testing.T) {
 	if !initialSetup {
 		setup()
 	}
-	_, err := o.GetHistoricalRates("2017-12-01", "USD", []string{"CNH", "AUD", "ANG"}, false, false)
+	_, err := o.GetHistoricalData("2017-12-01", "USD", []string{"CNH", "AUD", "ANG"}, false, false)
 	if err == nil {
 		t.Error("GetRates() Expected error")
 	}
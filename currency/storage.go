@@ -146,6 +146,12 @@ func (s *Storage) RunUpdater(overrides BotOverrides, settings *MainConfiguration
 				fxSettings = append(fxSettings,
 					base.Settings(settings.ForexProviders[i]))
 			}
+
+		case "Offline":
+			if settings.ForexProviders[i].Enabled {
+				fxSettings = append(fxSettings,
+					base.Settings(settings.ForexProviders[i]))
+			}
 		}
 	}
 
@@ -732,6 +738,71 @@ func (s *Storage) GetStorageRate(from, to Code) (float64, error) {
 	return s.fxRates.GetRate(from, to)
 }
 
+// SetRateCache sets the cache historical rates are stored in, a nil cache
+// disables caching
+func (s *Storage) SetRateCache(c RateCache) {
+	s.mtx.Lock()
+	s.rateCache = c
+	s.mtx.Unlock()
+}
+
+// GetHistoricalRate returns the rate between two fiat currencies on a past
+// date. Cached rates are used first, otherwise the forex providers are
+// queried and the result is cached for next time
+func (s *Storage) GetHistoricalRate(from, to Code, date time.Time) (float64, error) {
+	if date.IsZero() {
+		return 0, errors.New("historical rate date not set")
+	}
+	if from.Match(to) {
+		return 1, nil
+	}
+	base := from.Upper().String()
+	quote := to.Upper().String()
+
+	s.mtx.Lock()
+	cache := s.rateCache
+	markets := s.fiatExchangeMarkets
+	s.mtx.Unlock()
+
+	if cache != nil {
+		cached, ok, err := cache.GetRate(base, quote, date)
+		switch {
+		case err != nil:
+			log.Warnf(log.Global, "Currency Storage: unable to read cached %s%s rate: %v", base, quote, err)
+		case ok:
+			return cached, nil
+		}
+	}
+
+	if markets == nil {
+		return 0, errors.New("no foreign exchange providers set")
+	}
+
+	rate, err := markets.GetHistoricalRate(date, base, quote)
+	if err != nil {
+		return 0, err
+	}
+
+	if cache == nil || !isCacheableRateDate(rate.Date, time.Now()) {
+		return rate.Rate, nil
+	}
+	// the rate is stored under the day the provider's rate is for so that a
+	// fallback to an earlier day is not cached as the requested day
+	err = cache.StoreRate(base, quote, rate.Provider, rate.Rate, rate.Date)
+	if err != nil {
+		log.Warnf(log.Global, "Currency Storage: unable to cache %s%s rate: %v", base, quote, err)
+	}
+	return rate.Rate, nil
+}
+
+// isCacheableRateDate returns whether a historical rate for the date is final.
+// Rates for the current UTC day, or later, are still changing
+func isCacheableRateDate(date, now time.Time) bool {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return !date.IsZero() && date.Before(today)
+}
+
 // NewConversion returns a new conversion object that has a pointer to a related
 // rate with its inversion.
 func (s *Storage) NewConversion(from, to Code) (Conversion, error) {
@@ -1,6 +1,14 @@
 package currency
 
-import "testing"
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
+)
 
 func TestRunUpdater(t *testing.T) {
 	var newStorage Storage
@@ -26,3 +34,105 @@ func TestRunUpdater(t *testing.T) {
 		t.Fatal("storage RunUpdater() error", err)
 	}
 }
+
+type testRateCache struct {
+	rates map[string]float64
+}
+
+func (c *testRateCache) GetRate(base, quote string, date time.Time) (float64, bool, error) {
+	r, ok := c.rates[base+quote+date.Format("2006-01-02")]
+	return r, ok, nil
+}
+
+func (c *testRateCache) StoreRate(base, quote, _ string, rate float64, date time.Time) error {
+	c.rates[base+quote+date.Format("2006-01-02")] = rate
+	return nil
+}
+
+func TestGetHistoricalRate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gct-currency")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "rates.csv")
+	err = ioutil.WriteFile(path, []byte("2020-01-01,USD,AUD,1.4\n2020-01-02,USD,AUD,1.5\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s Storage
+	_, err = s.GetHistoricalRate(USD, AUD, time.Time{})
+	if err == nil {
+		t.Error("expected error for unset date")
+	}
+	_, err = s.GetHistoricalRate(USD, AUD, time.Now())
+	if err == nil {
+		t.Error("expected error for unset forex providers")
+	}
+
+	err = s.SetupForexProviders(base.Settings{
+		Name:            "Offline",
+		Enabled:         true,
+		PrimaryProvider: true,
+		FilePath:        path,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := s.GetHistoricalRate(USD, AUD, time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != 1.4 {
+		t.Errorf("received %v expected %v", r, 1.4)
+	}
+	r, err = s.GetHistoricalRate(AUD, USD, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != 1/1.5 {
+		t.Errorf("received %v expected %v", r, 1/1.5)
+	}
+	r, err = s.GetHistoricalRate(USD, USD, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != 1 {
+		t.Errorf("received %v expected %v", r, 1)
+	}
+
+	cache := &testRateCache{rates: make(map[string]float64)}
+	s.SetRateCache(cache)
+	_, err = s.GetHistoricalRate(USD, AUD, time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cache.rates["USDAUD2020-01-01"] != 1.4 {
+		t.Errorf("expected the provider rate to be cached, received %v", cache.rates)
+	}
+	cache.rates["USDAUD2020-01-01"] = 1.3
+	r, err = s.GetHistoricalRate(USD, AUD, time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != 1.3 {
+		t.Errorf("received %v expected the cached rate %v", r, 1.3)
+	}
+}
+
+func TestIsCacheableRateDate(t *testing.T) {
+	now := time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC)
+	if isCacheableRateDate(time.Time{}, now) {
+		t.Error("unset date should not be cacheable")
+	}
+	if isCacheableRateDate(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), now) {
+		t.Error("current day should not be cacheable")
+	}
+	if isCacheableRateDate(time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC), now) {
+		t.Error("future day should not be cacheable")
+	}
+	if !isCacheableRateDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), now) {
+		t.Error("previous day should be cacheable")
+	}
+}
@@ -16,6 +16,16 @@ const (
 	DefaultStorageFile          = "currency.json"
 )
 
+// RateCache stores historical fiat rates so that a rate for a past day only
+// has to be requested from the forex providers once
+type RateCache interface {
+	// GetRate returns the cached rate for the day of the date, ok is false
+	// when no rate is cached
+	GetRate(base, quote string, date time.Time) (rate float64, ok bool, err error)
+	// StoreRate caches the rate a provider returned for the day of the date
+	StoreRate(base, quote, provider string, rate float64, date time.Time) error
+}
+
 // storage is an overarching type that keeps track of and updates currency,
 // currency exchange rates and pairs
 var storage Storage
@@ -54,9 +64,11 @@ type Storage struct {
 	// Update delay variables
 	currencyFileUpdateDelay    time.Duration
 	foreignExchangeUpdateDelay time.Duration
-	mtx                        sync.Mutex
-	wg                         sync.WaitGroup
-	shutdown                   chan struct{}
-	updaterRunning             bool
-	Verbose                    bool
+	// rateCache caches historical rates, rates are not cached when unset
+	rateCache      RateCache
+	mtx            sync.Mutex
+	wg             sync.WaitGroup
+	shutdown       chan struct{}
+	updaterRunning bool
+	Verbose        bool
 }
@@ -0,0 +1,309 @@
+package valuation
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// NewGraph returns an empty valuation graph
+func NewGraph(staleAfter time.Duration) *Graph {
+	if staleAfter <= 0 {
+		staleAfter = DefaultStaleAfter
+	}
+	return &Graph{
+		edges:      make(map[*currency.Item]map[*currency.Item]Hop),
+		staleAfter: staleAfter,
+	}
+}
+
+// Build creates a graph from the stored spot tickers of the supplied
+// exchanges, pegged stablecoins and forex rates linking every fiat currency
+// seen, including the supplied reporting currencies, to USD
+func Build(exchanges []string, reporting ...currency.Code) (*Graph, error) {
+	return BuildFromService(ticker.GetService(), exchanges, reporting...)
+}
+
+// BuildFromService creates a graph in the same way as Build, reading the
+// tickers from the supplied ticker service
+func BuildFromService(s *ticker.Service, exchanges []string, reporting ...currency.Code) (*Graph, error) {
+	if s == nil {
+		return nil, errTickerServiceUnset
+	}
+	g := NewGraph(DefaultStaleAfter)
+	fiat := make(map[*currency.Item]currency.Code)
+	for i := range reporting {
+		if !reporting[i].IsEmpty() && reporting[i].IsFiatCurrency() {
+			fiat[reporting[i].Item] = reporting[i]
+		}
+	}
+	for i := range exchanges {
+		tickers, err := s.GetExchangeTickers(exchanges[i])
+		if err != nil {
+			// exchanges that have not fetched tickers yet have nothing to add
+			continue
+		}
+		for j := range tickers {
+			if tickers[j].AssetType != asset.Spot {
+				continue
+			}
+			if err = g.AddTicker(&tickers[j]); err != nil {
+				continue
+			}
+			for _, c := range []currency.Code{tickers[j].Pair.Base, tickers[j].Pair.Quote} {
+				if c.IsFiatCurrency() {
+					fiat[c.Item] = c
+				}
+			}
+		}
+	}
+
+	now := time.Now()
+	if err := g.AddPegs(now); err != nil {
+		return nil, err
+	}
+	for _, c := range fiat {
+		if c.Match(currency.USD) {
+			continue
+		}
+		rate, err := currency.ConvertCurrency(1, currency.USD, c)
+		if err != nil {
+			log.Warnf(log.Global, "Valuation graph unable to link %s to %s: %v\n", c, currency.USD, err)
+			continue
+		}
+		if err = g.AddForexRate(currency.USD, c, rate, now); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// AddTicker adds both directions of a ticker's last price to the graph. When
+// a rate between two currencies is already known the more liquid one is kept
+func (g *Graph) AddTicker(t *ticker.Price) error {
+	if t == nil {
+		return errTickerUnset
+	}
+	if t.Pair.Base.IsEmpty() || t.Pair.Quote.IsEmpty() {
+		return errCurrencyUnset
+	}
+	if t.Last <= 0 {
+		return fmt.Errorf("%s %s %w", t.ExchangeName, t.Pair, errInvalidRate)
+	}
+	// liquidity is stored in the currency being converted from so that it
+	// can be carried through the rest of the path
+	baseVolume := t.Volume
+	if baseVolume == 0 && t.QuoteVolume > 0 {
+		baseVolume = t.QuoteVolume / t.Last
+	}
+	quoteVolume := t.QuoteVolume
+	if quoteVolume == 0 {
+		quoteVolume = t.Volume * t.Last
+	}
+	confidence := 1.0
+	if time.Since(t.LastUpdated) > g.staleAfter {
+		confidence *= staleConfidence
+	}
+	if baseVolume == 0 {
+		confidence *= noVolumeConfidence
+	}
+
+	g.m.Lock()
+	defer g.m.Unlock()
+	g.setEdge(&Hop{
+		From:        t.Pair.Base,
+		To:          t.Pair.Quote,
+		Rate:        t.Last,
+		Exchange:    t.ExchangeName,
+		Pair:        t.Pair,
+		Asset:       t.AssetType,
+		Source:      TickerSource,
+		LastUpdated: t.LastUpdated,
+		Liquidity:   baseVolume,
+		Confidence:  confidence,
+	})
+	g.setEdge(&Hop{
+		From:        t.Pair.Quote,
+		To:          t.Pair.Base,
+		Rate:        1 / t.Last,
+		Exchange:    t.ExchangeName,
+		Pair:        t.Pair,
+		Asset:       t.AssetType,
+		Source:      TickerSource,
+		LastUpdated: t.LastUpdated,
+		Liquidity:   quoteVolume,
+		Confidence:  confidence,
+	})
+	return nil
+}
+
+// AddForexRate adds a fiat conversion rate and its inverse to the graph
+func (g *Graph) AddForexRate(from, to currency.Code, rate float64, updated time.Time) error {
+	return g.addRate(from, to, rate, ForexSource, updated)
+}
+
+// AddPegs links the pegged stablecoins to USD at a rate of one
+func (g *Graph) AddPegs(updated time.Time) error {
+	for i := range pegged {
+		if err := g.addRate(pegged[i], currency.USD, 1, PegSource, updated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Graph) addRate(from, to currency.Code, rate float64, source string, updated time.Time) error {
+	if from.IsEmpty() || to.IsEmpty() {
+		return errCurrencyUnset
+	}
+	if rate <= 0 {
+		return fmt.Errorf("%s %s %w", from, to, errInvalidRate)
+	}
+	confidence := forexConfidence
+	if source == PegSource {
+		confidence = pegConfidence
+	}
+	g.m.Lock()
+	defer g.m.Unlock()
+	g.setEdge(&Hop{
+		From:        from,
+		To:          to,
+		Rate:        rate,
+		Source:      source,
+		LastUpdated: updated,
+		Liquidity:   math.Inf(1),
+		Confidence:  confidence,
+	})
+	g.setEdge(&Hop{
+		From:        to,
+		To:          from,
+		Rate:        1 / rate,
+		Source:      source,
+		LastUpdated: updated,
+		Liquidity:   math.Inf(1),
+		Confidence:  confidence,
+	})
+	return nil
+}
+
+// setEdge stores a hop unless a more liquid or fresher one already exists.
+// Must be called with the lock held
+func (g *Graph) setEdge(h *Hop) {
+	to, ok := g.edges[h.From.Item]
+	if !ok {
+		to = make(map[*currency.Item]Hop)
+		g.edges[h.From.Item] = to
+	}
+	if existing, ok := to[h.To.Item]; ok {
+		if existing.Liquidity > h.Liquidity ||
+			(existing.Liquidity == h.Liquidity && existing.LastUpdated.After(h.LastUpdated)) {
+			return
+		}
+	}
+	to[h.To.Item] = *h
+}
+
+// Value converts an amount of one currency into another using the path with
+// the greatest liquidity, preferring fewer hops when liquidity is equal
+func (g *Graph) Value(amount float64, from, to currency.Code) (*Valuation, error) {
+	if from.IsEmpty() || to.IsEmpty() {
+		return nil, errCurrencyUnset
+	}
+	if from.Item == to.Item {
+		return &Valuation{
+			Amount:     amount,
+			From:       from,
+			To:         to,
+			Rate:       1,
+			Value:      amount,
+			Liquidity:  math.Inf(1),
+			Confidence: 1,
+			Timestamp:  time.Now(),
+		}, nil
+	}
+
+	g.m.RLock()
+	defer g.m.RUnlock()
+	// the search runs outward from the target so that each label holds the
+	// rate and liquidity of the remainder of the path in target terms
+	incoming := make(map[*currency.Item][]Hop)
+	for _, edges := range g.edges {
+		for _, h := range edges {
+			incoming[h.To.Item] = append(incoming[h.To.Item], h)
+		}
+	}
+	labels := map[*currency.Item]*label{
+		to.Item: {rate: 1, liquidity: math.Inf(1), confidence: 1},
+	}
+	for {
+		var current *currency.Item
+		for item, l := range labels {
+			if !l.done && (current == nil || l.betterThan(labels[current])) {
+				current = item
+			}
+		}
+		if current == nil || current == from.Item {
+			break
+		}
+		l := labels[current]
+		l.done = true
+		if l.hops >= maxHops {
+			continue
+		}
+		for i := range incoming[current] {
+			h := incoming[current][i]
+			if existing, ok := labels[h.From.Item]; ok && existing.done {
+				continue
+			}
+			rate := h.Rate * l.rate
+			candidate := &label{
+				rate:       rate,
+				liquidity:  math.Min(h.Liquidity*rate, l.liquidity),
+				confidence: h.Confidence * l.confidence,
+				hops:       l.hops + 1,
+				next:       current,
+				hop:        h,
+			}
+			if existing, ok := labels[h.From.Item]; !ok || candidate.betterThan(existing) {
+				labels[h.From.Item] = candidate
+			}
+		}
+	}
+
+	start, ok := labels[from.Item]
+	if !ok {
+		return nil, fmt.Errorf("%w from %s to %s", ErrNoConversionPath, from, to)
+	}
+	v := &Valuation{
+		Amount:     amount,
+		From:       from,
+		To:         to,
+		Rate:       start.rate,
+		Value:      amount * start.rate,
+		Liquidity:  start.liquidity,
+		Confidence: start.confidence,
+	}
+	for l := start; l.next != nil; l = labels[l.next] {
+		v.Path = append(v.Path, l.hop)
+		if v.Timestamp.IsZero() || l.hop.LastUpdated.Before(v.Timestamp) {
+			v.Timestamp = l.hop.LastUpdated
+		}
+	}
+	return v, nil
+}
+
+// betterThan ranks paths by liquidity, then length, then confidence
+func (l *label) betterThan(o *label) bool {
+	if l.liquidity != o.liquidity {
+		return l.liquidity > o.liquidity
+	}
+	if l.hops != o.hops {
+		return l.hops < o.hops
+	}
+	return l.confidence > o.confidence
+}
@@ -0,0 +1,196 @@
+package valuation
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+func testTicker(exch string, base, quote currency.Code, last, volume float64) *ticker.Price {
+	return &ticker.Price{
+		ExchangeName: exch,
+		Pair:         currency.NewPair(base, quote),
+		AssetType:    asset.Spot,
+		Last:         last,
+		Volume:       volume,
+		LastUpdated:  time.Now(),
+	}
+}
+
+func TestAddTicker(t *testing.T) {
+	t.Parallel()
+	g := NewGraph(0)
+	if g.staleAfter != DefaultStaleAfter {
+		t.Errorf("received '%v' expected '%v'", g.staleAfter, DefaultStaleAfter)
+	}
+	if err := g.AddTicker(nil); !errors.Is(err, errTickerUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errTickerUnset)
+	}
+	if err := g.AddTicker(&ticker.Price{}); !errors.Is(err, errCurrencyUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errCurrencyUnset)
+	}
+	if err := g.AddTicker(testTicker("a", currency.BTC, currency.USD, 0, 1)); !errors.Is(err, errInvalidRate) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidRate)
+	}
+
+	if err := g.AddTicker(testTicker("a", currency.BTC, currency.USD, 10000, 5)); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddTicker(testTicker("b", currency.BTC, currency.USD, 10100, 1)); err != nil {
+		t.Fatal(err)
+	}
+	h := g.edges[currency.BTC.Item][currency.USD.Item]
+	if h.Exchange != "a" || h.Liquidity != 5 {
+		t.Errorf("expected the most liquid ticker to be kept, received %+v", h)
+	}
+	h = g.edges[currency.USD.Item][currency.BTC.Item]
+	if h.Rate != 0.0001 || h.Liquidity != 50000 {
+		t.Errorf("unexpected inverse hop %+v", h)
+	}
+
+	stale := testTicker("c", currency.ETH, currency.BTC, 0.03, 0)
+	stale.LastUpdated = time.Now().Add(-time.Hour)
+	if err := g.AddTicker(stale); err != nil {
+		t.Fatal(err)
+	}
+	h = g.edges[currency.ETH.Item][currency.BTC.Item]
+	if h.Confidence != staleConfidence*noVolumeConfidence {
+		t.Errorf("received '%v' expected '%v'", h.Confidence, staleConfidence*noVolumeConfidence)
+	}
+}
+
+func TestValue(t *testing.T) {
+	t.Parallel()
+	g := NewGraph(time.Minute)
+	if _, err := g.Value(1, currency.Code{}, currency.USD); !errors.Is(err, errCurrencyUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errCurrencyUnset)
+	}
+	v, err := g.Value(5, currency.USD, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Value != 5 || v.Confidence != 1 || len(v.Path) != 0 {
+		t.Errorf("unexpected valuation %+v", v)
+	}
+
+	for _, tick := range []*ticker.Price{
+		testTicker("a", currency.XRP, currency.BTC, 0.00002, 1000000),
+		testTicker("a", currency.BTC, currency.USDT, 10000, 100),
+		// a direct but illiquid market should lose to the path through BTC
+		testTicker("b", currency.XRP, currency.USDT, 0.3, 10),
+	} {
+		if err = g.AddTicker(tick); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = g.addRate(currency.USDT, currency.USD, 1, PegSource, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err = g.AddForexRate(currency.USD, currency.EUR, 0.8, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err = g.Value(100, currency.XRP, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.Path) != 3 ||
+		!v.Path[0].To.Match(currency.BTC) ||
+		!v.Path[1].To.Match(currency.USDT) ||
+		!v.Path[2].To.Match(currency.USD) {
+		t.Fatalf("unexpected path %+v", v.Path)
+	}
+	if math.Abs(v.Value-20) > 1e-9 {
+		t.Errorf("received '%v' expected '%v'", v.Value, 20)
+	}
+	if v.Confidence != pegConfidence {
+		t.Errorf("received '%v' expected '%v'", v.Confidence, pegConfidence)
+	}
+
+	v, err = g.Value(100, currency.XRP, currency.EUR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(v.Value-16) > 1e-9 || len(v.Path) != 4 {
+		t.Errorf("unexpected valuation %+v", v)
+	}
+
+	v, err = g.Value(1, currency.EUR, currency.BTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(v.Value-0.000125) > 1e-12 {
+		t.Errorf("received '%v' expected '%v'", v.Value, 0.000125)
+	}
+
+	if _, err = g.Value(1, currency.LTC, currency.USD); !errors.Is(err, ErrNoConversionPath) {
+		t.Errorf("received '%v' expected '%v'", err, ErrNoConversionPath)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+	for _, tick := range []*ticker.Price{
+		testTicker("valuationbuild", currency.DOGE, currency.BTC, 0.0000003, 1000),
+		testTicker("valuationbuild", currency.BTC, currency.USDT, 10000, 10),
+		{
+			ExchangeName: "valuationbuild",
+			Pair:         currency.NewPair(currency.BTC, currency.USD),
+			AssetType:    asset.Futures,
+			Last:         1,
+		},
+	} {
+		if err := ticker.ProcessTicker(tick); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g, err := Build([]string{"valuationbuild", "valuationnotickers"}, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := g.Value(1000, currency.DOGE, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(v.Value-3) > 1e-9 {
+		t.Errorf("received '%v' expected '%v'", v.Value, 3)
+	}
+	if v.Path[len(v.Path)-1].Source != PegSource {
+		t.Errorf("expected futures ticker to be ignored, received path %+v", v.Path)
+	}
+}
+
+func TestBuildFromService(t *testing.T) {
+	t.Parallel()
+	if _, err := BuildFromService(nil, nil); !errors.Is(err, errTickerServiceUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errTickerServiceUnset)
+	}
+	s := ticker.NewService()
+	err := s.ProcessTicker(testTicker("valuationservice", currency.LTC, currency.USD, 100, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := BuildFromService(s, []string{"valuationservice"}, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := g.Value(2, currency.LTC, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Value != 200 {
+		t.Errorf("received '%v' expected '%v'", v.Value, 200)
+	}
+	g, err = Build([]string{"valuationservice"}, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = g.Value(2, currency.LTC, currency.USD); !errors.Is(err, ErrNoConversionPath) {
+		t.Errorf("received '%v' expected '%v'", err, ErrNoConversionPath)
+	}
+}
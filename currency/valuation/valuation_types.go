@@ -0,0 +1,99 @@
+package valuation
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// Sources of a conversion rate
+const (
+	TickerSource = "ticker"
+	ForexSource  = "forex"
+	PegSource    = "peg"
+)
+
+const (
+	// DefaultStaleAfter is how old a ticker can be before its rate is
+	// considered stale and the confidence of any valuation using it drops
+	DefaultStaleAfter = time.Minute * 5
+	// maxHops limits the length of a conversion path
+	maxHops = 4
+
+	staleConfidence    = 0.5
+	noVolumeConfidence = 0.8
+	forexConfidence    = 0.95
+	pegConfidence      = 0.9
+)
+
+var (
+	// ErrNoConversionPath is returned when no chain of rates links two
+	// currencies
+	ErrNoConversionPath = errors.New("no conversion path found")
+
+	errInvalidRate   = errors.New("conversion rate must be greater than zero")
+	errCurrencyUnset = errors.New("currency unset")
+	errTickerUnset   = errors.New("ticker unset")
+
+	errTickerServiceUnset = errors.New("ticker service unset")
+
+	// pegged stablecoins are treated as USD, matching the forex conversion
+	// rates which already substitute USDT for USD
+	pegged = []currency.Code{currency.USDT, currency.USDC, currency.TUSD}
+)
+
+// Graph holds the best known rate between each directed pair of currencies,
+// sourced from exchange tickers and forex rates
+type Graph struct {
+	edges      map[*currency.Item]map[*currency.Item]Hop
+	staleAfter time.Duration
+	m          sync.RWMutex
+}
+
+// Hop is a single conversion step within a valuation path
+type Hop struct {
+	From        currency.Code
+	To          currency.Code
+	Rate        float64
+	Exchange    string
+	Pair        currency.Pair
+	Asset       asset.Item
+	Source      string
+	LastUpdated time.Time
+	// Liquidity is the traded volume available for the hop denominated in
+	// the From currency. Forex and pegged hops have unlimited liquidity
+	Liquidity  float64
+	Confidence float64
+}
+
+// Valuation is the value of an amount of one currency in another
+type Valuation struct {
+	Amount float64
+	From   currency.Code
+	To     currency.Code
+	Rate   float64
+	Value  float64
+	Path   []Hop
+	// Liquidity is the smallest hop liquidity along the path denominated in
+	// the To currency
+	Liquidity float64
+	// Confidence ranges from zero to one and is reduced by stale tickers,
+	// missing volume and non-exchange rates along the path
+	Confidence float64
+	// Timestamp is the time of the oldest rate used in the path
+	Timestamp time.Time
+}
+
+// label tracks the best known path from a currency to the valuation target
+type label struct {
+	rate       float64
+	liquidity  float64
+	confidence float64
+	hops       int
+	next       *currency.Item
+	hop        Hop
+	done       bool
+}
@@ -8,21 +8,45 @@ import (
 	// import go libpq driver package
 	_ "github.com/lib/pq"
 	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
 )
 
 // Connect opens a connection to Postgres database and returns a pointer to database.DB
 func Connect() (*database.Instance, error) {
-	if database.DB.Config.SSLMode == "" {
-		database.DB.Config.SSLMode = "disable"
+	return ConnectInstance(database.DB)
+}
+
+// ConnectInstance opens a connection to the Postgres database described by the
+// supplied instance's config and stores it on the instance
+func ConnectInstance(inst *database.Instance) (*database.Instance, error) {
+	if inst == nil || inst.Config == nil {
+		return nil, database.ErrNoDatabaseProvided
+	}
+
+	db, err := Open(&inst.Config.ConnectionDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	inst.SQL = db
+
+	return inst, nil
+}
+
+// Open opens a connection to the Postgres database described by the supplied
+// connection details without altering the global database instance
+func Open(details *drivers.ConnectionDetails) (*sql.DB, error) {
+	if details.SSLMode == "" {
+		details.SSLMode = "disable"
 	}
 
 	configDSN := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		database.DB.Config.Username,
-		database.DB.Config.Password,
-		database.DB.Config.Host,
-		database.DB.Config.Port,
-		database.DB.Config.Database,
-		database.DB.Config.SSLMode)
+		details.Username,
+		details.Password,
+		details.Host,
+		details.Port,
+		details.Database,
+		details.SSLMode)
 
 	db, err := sql.Open(database.DBPostgreSQL, configDSN)
 	if err != nil {
@@ -34,10 +58,9 @@ func Connect() (*database.Instance, error) {
 		return nil, err
 	}
 
-	database.DB.SQL = db
-	database.DB.SQL.SetMaxOpenConns(2)
-	database.DB.SQL.SetMaxIdleConns(1)
-	database.DB.SQL.SetConnMaxLifetime(time.Hour)
+	db.SetMaxOpenConns(2)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(time.Hour)
 
-	return database.DB, nil
+	return db, nil
 }
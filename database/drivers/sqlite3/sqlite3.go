@@ -11,19 +11,36 @@ import (
 
 // Connect opens a connection to sqlite database and returns a pointer to database.DB
 func Connect() (*database.Instance, error) {
-	if database.DB.Config.Database == "" {
+	return ConnectInstance(database.DB)
+}
+
+// ConnectInstance opens a connection to the sqlite database described by the
+// supplied instance's config and stores it on the instance
+func ConnectInstance(inst *database.Instance) (*database.Instance, error) {
+	if inst == nil {
+		return nil, database.ErrNoDatabaseProvided
+	}
+	if inst.Config == nil || inst.Config.Database == "" {
 		return nil, database.ErrNoDatabaseProvided
 	}
 
-	databaseFullLocation := filepath.Join(database.DB.DataPath, database.DB.Config.Database)
-
-	dbConn, err := sql.Open("sqlite3", databaseFullLocation)
+	dbConn, err := Open(filepath.Join(inst.DataPath, inst.Config.Database))
 	if err != nil {
 		return nil, err
 	}
 
-	database.DB.SQL = dbConn
-	database.DB.SQL.SetMaxOpenConns(1)
+	inst.SQL = dbConn
 
-	return database.DB, nil
+	return inst, nil
+}
+
+// Open opens a connection to the sqlite database file at the supplied path
+// without altering the global database instance
+func Open(path string) (*sql.DB, error) {
+	dbConn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	dbConn.SetMaxOpenConns(1)
+	return dbConn, nil
 }
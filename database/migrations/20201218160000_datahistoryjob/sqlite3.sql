@@ -0,0 +1,35 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS datahistoryjob
+(
+    id text not null primary key,
+    nickname text NOT NULL,
+    exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    asset text NOT NULL,
+    base text NOT NULL,
+    quote text NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    interval INTEGER NOT NULL,
+    data_type INTEGER NOT NULL,
+    request_size INTEGER NOT NULL,
+    max_retries INTEGER NOT NULL,
+    batch_count INTEGER NOT NULL,
+    status INTEGER NOT NULL,
+    created TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uniquenickname
+        unique(nickname)
+);
+
+CREATE TABLE IF NOT EXISTS datahistoryjobresult
+(
+    id text not null primary key,
+    job_id text REFERENCES datahistoryjob(id) ON DELETE CASCADE NOT NULL,
+    result TEXT NOT NULL,
+    status INTEGER NOT NULL,
+    interval_start_time TIMESTAMP NOT NULL,
+    interval_end_time TIMESTAMP NOT NULL,
+    run_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose Down
+DROP TABLE datahistoryjobresult;
+DROP TABLE datahistoryjob;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS balance_snapshot
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    account_id varchar NOT NULL,
+    asset varchar NOT NULL,
+    currency varchar(30) NOT NULL,
+    total DOUBLE PRECISION NOT NULL,
+    hold DOUBLE PRECISION NOT NULL,
+    fiat_currency varchar(30) NOT NULL,
+    fiat_value DOUBLE PRECISION,
+    timestamp TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uniquebalancesnapshot
+        unique(exchange_name_id, account_id, asset, currency, timestamp)
+);
+CREATE INDEX IF NOT EXISTS balance_snapshot_timestamp ON balance_snapshot(timestamp);
+-- +goose Down
+DROP TABLE balance_snapshot;
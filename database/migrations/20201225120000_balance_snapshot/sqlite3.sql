@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS balance_snapshot
+(
+    id text not null primary key,
+    exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    account_id text NOT NULL,
+    asset text NOT NULL,
+    currency text NOT NULL,
+    total REAL NOT NULL,
+    hold REAL NOT NULL,
+    fiat_currency text NOT NULL,
+    fiat_value REAL,
+    timestamp TIMESTAMP NOT NULL,
+    CONSTRAINT uniquebalancesnapshot
+        unique(exchange_name_id, account_id, asset, currency, timestamp)
+);
+CREATE INDEX IF NOT EXISTS balance_snapshot_timestamp ON balance_snapshot(timestamp);
+-- +goose Down
+DROP TABLE balance_snapshot;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS fx_rate
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    base_currency varchar(30) NOT NULL,
+    quote_currency varchar(30) NOT NULL,
+    rate DOUBLE PRECISION NOT NULL,
+    provider varchar NOT NULL,
+    date TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uniquefxrate
+        unique(base_currency, quote_currency, date)
+);
+CREATE INDEX IF NOT EXISTS fx_rate_date ON fx_rate(date);
+-- +goose Down
+DROP TABLE fx_rate;
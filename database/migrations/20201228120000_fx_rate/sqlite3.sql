@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS fx_rate
+(
+    id text not null primary key,
+    base_currency text NOT NULL,
+    quote_currency text NOT NULL,
+    rate REAL NOT NULL,
+    provider text NOT NULL,
+    date TIMESTAMP NOT NULL,
+    CONSTRAINT uniquefxrate
+        unique(base_currency, quote_currency, date)
+);
+CREATE INDEX IF NOT EXISTS fx_rate_date ON fx_rate(date);
+-- +goose Down
+DROP TABLE fx_rate;
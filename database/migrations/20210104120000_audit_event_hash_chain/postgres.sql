@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE audit_event ADD COLUMN actor_type varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE audit_event ADD COLUMN actor varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE audit_event ADD COLUMN data text NOT NULL DEFAULT '';
+ALTER TABLE audit_event ADD COLUMN sequence bigint NOT NULL DEFAULT 0;
+ALTER TABLE audit_event ADD COLUMN previous_hash varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE audit_event ADD COLUMN hash varchar(64) NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX IF NOT EXISTS audit_event_sequence ON audit_event(sequence) WHERE sequence > 0;
+-- +goose Down
+DROP INDEX IF EXISTS audit_event_sequence;
+ALTER TABLE audit_event DROP COLUMN hash;
+ALTER TABLE audit_event DROP COLUMN previous_hash;
+ALTER TABLE audit_event DROP COLUMN sequence;
+ALTER TABLE audit_event DROP COLUMN data;
+ALTER TABLE audit_event DROP COLUMN actor;
+ALTER TABLE audit_event DROP COLUMN actor_type;
@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE audit_event ADD COLUMN actor_type text NOT NULL DEFAULT '';
+ALTER TABLE audit_event ADD COLUMN actor text NOT NULL DEFAULT '';
+ALTER TABLE audit_event ADD COLUMN data text NOT NULL DEFAULT '';
+ALTER TABLE audit_event ADD COLUMN sequence integer NOT NULL DEFAULT 0;
+ALTER TABLE audit_event ADD COLUMN previous_hash text NOT NULL DEFAULT '';
+ALTER TABLE audit_event ADD COLUMN hash text NOT NULL DEFAULT '';
+CREATE UNIQUE INDEX IF NOT EXISTS audit_event_sequence ON audit_event(sequence) WHERE sequence > 0;
+-- +goose Down
+DROP INDEX IF EXISTS audit_event_sequence;
+CREATE TABLE "audit_event_new" (
+    id	        integer not null primary key,
+    type    	text not null,
+    identifier	text not null,
+    message	    text not null,
+    created_at  timestamp not null default CURRENT_TIMESTAMP
+);
+INSERT INTO audit_event_new SELECT id, type, identifier, message, created_at FROM audit_event;
+DROP TABLE audit_event;
+ALTER TABLE audit_event_new RENAME TO audit_event;
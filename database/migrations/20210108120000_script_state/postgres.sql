@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS script_state
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    script_name varchar NOT NULL,
+    key varchar NOT NULL,
+    value text NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uniquescriptstate
+        unique(script_name, key)
+);
+-- +goose Down
+DROP TABLE script_state;
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS script_state
+(
+    id text not null primary key,
+    script_name text NOT NULL,
+    key text NOT NULL,
+    value text NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uniquescriptstate
+        unique(script_name, key)
+);
+-- +goose Down
+DROP TABLE script_state;
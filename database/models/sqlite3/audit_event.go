@@ -22,28 +22,46 @@ import (
 
 // AuditEvent is an object representing the database table.
 type AuditEvent struct {
-	ID         int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Type       string `boil:"type" json:"type" toml:"type" yaml:"type"`
-	Identifier string `boil:"identifier" json:"identifier" toml:"identifier" yaml:"identifier"`
-	Message    string `boil:"message" json:"message" toml:"message" yaml:"message"`
-	CreatedAt  string `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ID           int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Type         string `boil:"type" json:"type" toml:"type" yaml:"type"`
+	Identifier   string `boil:"identifier" json:"identifier" toml:"identifier" yaml:"identifier"`
+	Message      string `boil:"message" json:"message" toml:"message" yaml:"message"`
+	CreatedAt    string `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ActorType    string `boil:"actor_type" json:"actor_type" toml:"actor_type" yaml:"actor_type"`
+	Actor        string `boil:"actor" json:"actor" toml:"actor" yaml:"actor"`
+	Data         string `boil:"data" json:"data" toml:"data" yaml:"data"`
+	Sequence     int64  `boil:"sequence" json:"sequence" toml:"sequence" yaml:"sequence"`
+	PreviousHash string `boil:"previous_hash" json:"previous_hash" toml:"previous_hash" yaml:"previous_hash"`
+	Hash         string `boil:"hash" json:"hash" toml:"hash" yaml:"hash"`
 
 	R *auditEventR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L auditEventL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var AuditEventColumns = struct {
-	ID         string
-	Type       string
-	Identifier string
-	Message    string
-	CreatedAt  string
+	ID           string
+	Type         string
+	Identifier   string
+	Message      string
+	CreatedAt    string
+	ActorType    string
+	Actor        string
+	Data         string
+	Sequence     string
+	PreviousHash string
+	Hash         string
 }{
-	ID:         "id",
-	Type:       "type",
-	Identifier: "identifier",
-	Message:    "message",
-	CreatedAt:  "created_at",
+	ID:           "id",
+	Type:         "type",
+	Identifier:   "identifier",
+	Message:      "message",
+	CreatedAt:    "created_at",
+	ActorType:    "actor_type",
+	Actor:        "actor",
+	Data:         "data",
+	Sequence:     "sequence",
+	PreviousHash: "previous_hash",
+	Hash:         "hash",
 }
 
 // Generated where
@@ -74,17 +92,29 @@ func (w whereHelperstring) IN(slice []string) qm.QueryMod {
 }
 
 var AuditEventWhere = struct {
-	ID         whereHelperint64
-	Type       whereHelperstring
-	Identifier whereHelperstring
-	Message    whereHelperstring
-	CreatedAt  whereHelperstring
+	ID           whereHelperint64
+	Type         whereHelperstring
+	Identifier   whereHelperstring
+	Message      whereHelperstring
+	CreatedAt    whereHelperstring
+	ActorType    whereHelperstring
+	Actor        whereHelperstring
+	Data         whereHelperstring
+	Sequence     whereHelperint64
+	PreviousHash whereHelperstring
+	Hash         whereHelperstring
 }{
-	ID:         whereHelperint64{field: "\"audit_event\".\"id\""},
-	Type:       whereHelperstring{field: "\"audit_event\".\"type\""},
-	Identifier: whereHelperstring{field: "\"audit_event\".\"identifier\""},
-	Message:    whereHelperstring{field: "\"audit_event\".\"message\""},
-	CreatedAt:  whereHelperstring{field: "\"audit_event\".\"created_at\""},
+	ID:           whereHelperint64{field: "\"audit_event\".\"id\""},
+	Type:         whereHelperstring{field: "\"audit_event\".\"type\""},
+	Identifier:   whereHelperstring{field: "\"audit_event\".\"identifier\""},
+	Message:      whereHelperstring{field: "\"audit_event\".\"message\""},
+	CreatedAt:    whereHelperstring{field: "\"audit_event\".\"created_at\""},
+	ActorType:    whereHelperstring{field: "\"audit_event\".\"actor_type\""},
+	Actor:        whereHelperstring{field: "\"audit_event\".\"actor\""},
+	Data:         whereHelperstring{field: "\"audit_event\".\"data\""},
+	Sequence:     whereHelperint64{field: "\"audit_event\".\"sequence\""},
+	PreviousHash: whereHelperstring{field: "\"audit_event\".\"previous_hash\""},
+	Hash:         whereHelperstring{field: "\"audit_event\".\"hash\""},
 }
 
 // AuditEventRels is where relationship names are stored.
@@ -104,9 +134,9 @@ func (*auditEventR) NewStruct() *auditEventR {
 type auditEventL struct{}
 
 var (
-	auditEventAllColumns            = []string{"id", "type", "identifier", "message", "created_at"}
+	auditEventAllColumns            = []string{"id", "type", "identifier", "message", "created_at", "actor_type", "actor", "data", "sequence", "previous_hash", "hash"}
 	auditEventColumnsWithoutDefault = []string{"type", "identifier", "message"}
-	auditEventColumnsWithDefault    = []string{"id", "created_at"}
+	auditEventColumnsWithDefault    = []string{"id", "created_at", "actor_type", "actor", "data", "sequence", "previous_hash", "hash"}
 	auditEventPrimaryKeyColumns     = []string{"id"}
 )
 
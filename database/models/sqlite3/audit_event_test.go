@@ -568,7 +568,7 @@ func testAuditEventsSelect(t *testing.T) {
 }
 
 var (
-	auditEventDBTypes = map[string]string{`ID`: `INTEGER`, `Type`: `TEXT`, `Identifier`: `TEXT`, `Message`: `TEXT`, `CreatedAt`: `TIMESTAMP`}
+	auditEventDBTypes = map[string]string{`ID`: `INTEGER`, `Type`: `TEXT`, `Identifier`: `TEXT`, `Message`: `TEXT`, `CreatedAt`: `TIMESTAMP`, `ActorType`: `TEXT`, `Actor`: `TEXT`, `Data`: `TEXT`, `Sequence`: `INTEGER`, `PreviousHash`: `TEXT`, `Hash`: `TEXT`}
 	_                 = bytes.MinRead
 )
 
@@ -4,25 +4,35 @@
 package sqlite3
 
 var TableNames = struct {
-	AuditEvent        string
-	Candle            string
-	Exchange          string
-	GooseDBVersion    string
-	Script            string
-	ScriptExecution   string
-	Trade             string
-	WithdrawalCrypto  string
-	WithdrawalFiat    string
-	WithdrawalHistory string
+	AuditEvent           string
+	BalanceSnapshot      string
+	Candle               string
+	Datahistoryjob       string
+	Datahistoryjobresult string
+	Exchange             string
+	FxRate               string
+	GooseDBVersion       string
+	Script               string
+	ScriptExecution      string
+	ScriptState          string
+	Trade                string
+	WithdrawalCrypto     string
+	WithdrawalFiat       string
+	WithdrawalHistory    string
 }{
-	AuditEvent:        "audit_event",
-	Candle:            "candle",
-	Exchange:          "exchange",
-	GooseDBVersion:    "goose_db_version",
-	Script:            "script",
-	ScriptExecution:   "script_execution",
-	Trade:             "trade",
-	WithdrawalCrypto:  "withdrawal_crypto",
-	WithdrawalFiat:    "withdrawal_fiat",
-	WithdrawalHistory: "withdrawal_history",
+	AuditEvent:           "audit_event",
+	BalanceSnapshot:      "balance_snapshot",
+	Candle:               "candle",
+	Datahistoryjob:       "datahistoryjob",
+	Datahistoryjobresult: "datahistoryjobresult",
+	Exchange:             "exchange",
+	FxRate:               "fx_rate",
+	GooseDBVersion:       "goose_db_version",
+	Script:               "script",
+	ScriptExecution:      "script_execution",
+	ScriptState:          "script_state",
+	Trade:                "trade",
+	WithdrawalCrypto:     "withdrawal_crypto",
+	WithdrawalFiat:       "withdrawal_fiat",
+	WithdrawalHistory:    "withdrawal_history",
 }
@@ -2,6 +2,12 @@ package audit
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/database"
@@ -13,56 +19,381 @@ import (
 	"github.com/thrasher-corp/sqlboiler/queries/qm"
 )
 
-// Event inserts a new audit event to database
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
+const sqliteTimeFormat = "2006-01-02 15:04:05"
+
+// chainMtx serialises writes so each record is chained to the one before it
+var chainMtx sync.Mutex
+
+// WithActor returns a copy of ctx carrying the actor responsible for any
+// audited actions performed with it
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFromContext returns the actor stored in ctx, actions without an actor
+// are attributed to the system
+func ActorFromContext(ctx context.Context) Actor {
+	if ctx != nil {
+		if a, ok := ctx.Value(actorKey{}).(Actor); ok && a.Type != "" {
+			return a
+		}
+	}
+	return Actor{Type: ActorSystem}
+}
+
+// Event runs Repository.Event against database.DB
 func Event(id, msgtype, message string) {
-	if database.DB.SQL == nil {
-		return
+	New(database.DB).Event(id, msgtype, message)
+}
+
+// Event inserts a new audit event to database attributed to the system
+func (r *Repository) Event(id, msgtype, message string) {
+	r.EventWithContext(context.Background(), msgtype, id, message, nil)
+}
+
+// EventWithContext runs Repository.EventWithContext against database.DB
+func EventWithContext(ctx context.Context, eventType, identifier, message string, data interface{}) {
+	New(database.DB).EventWithContext(ctx, eventType, identifier, message, data)
+}
+
+// EventWithContext inserts a new audit event to database attributed to the
+// actor stored in ctx, failures are logged rather than returned so auditing
+// does not interrupt the action being audited
+func (r *Repository) EventWithContext(ctx context.Context, eventType, identifier, message string, data interface{}) {
+	_, err := r.Log(ctx, eventType, identifier, message, data)
+	if err != nil && err != database.ErrDatabaseSupportDisabled {
+		log.Errorf(log.Global, "Event insert failed: %v", err)
 	}
+}
 
-	ctx := context.Background()
-	ctx = boil.SkipTimestamps(ctx)
+// Log runs Repository.Log against database.DB
+func Log(ctx context.Context, eventType, identifier, message string, data interface{}) (*Record, error) {
+	return New(database.DB).Log(ctx, eventType, identifier, message, data)
+}
 
-	tx, err := database.DB.SQL.BeginTx(ctx, nil)
-	if err != nil {
-		log.Errorf(log.Global, "Event transaction begin failed: %v", err)
-		return
+// Log appends a new record to the audit log, attributed to the actor stored in
+// ctx. data is optional and stored as JSON. Each record stores the hash of the
+// record before it so that modified or removed records can be detected by
+// Verify
+func (r *Repository) Log(ctx context.Context, eventType, identifier, message string, data interface{}) (*Record, error) {
+	if r.db.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if eventType == "" {
+		return nil, errEventTypeUnset
+	}
+	actor := ActorFromContext(ctx)
+	rec := &Record{
+		Type:       eventType,
+		Identifier: identifier,
+		Message:    message,
+		ActorType:  actor.Type,
+		Actor:      actor.ID,
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+	}
+	if data != nil {
+		d, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		rec.Data = string(d)
 	}
 
-	if repository.GetSQLDialect() == database.DBSQLite3 {
-		var tempEvent = modelSQLite.AuditEvent{
-			Type:       msgtype,
-			Identifier: id,
-			Message:    message,
+	chainMtx.Lock()
+	defer chainMtx.Unlock()
+
+	// the record is chained regardless of the callers context being cancelled
+	// as the action being audited has already taken place
+	dbCtx := boil.SkipTimestamps(context.Background())
+	tx, err := r.db.SQL.BeginTx(dbCtx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Log tx.Rollback %v", errRB)
+			}
 		}
-		err = tempEvent.Insert(ctx, tx, boil.Blacklist("created_at"))
+	}()
+
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		err = insertSQLite(dbCtx, tx, rec)
 	} else {
-		var tempEvent = modelPSQL.AuditEvent{
-			Type:       msgtype,
-			Identifier: id,
-			Message:    message,
-		}
-		err = tempEvent.Insert(ctx, tx, boil.Blacklist("created_at"))
+		err = insertPostgres(dbCtx, tx, rec)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
 	}
+	return rec, nil
+}
 
+func insertSQLite(ctx context.Context, tx *sql.Tx, r *Record) error {
+	head, err := modelSQLite.AuditEvents(
+		qm.Where("sequence > 0"),
+		qm.OrderBy("sequence desc")).One(ctx, tx)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if head != nil {
+		r.Sequence = head.Sequence + 1
+		r.PreviousHash = head.Hash
+	} else {
+		r.Sequence = 1
+	}
+	r.Hash, err = r.calculateHash()
 	if err != nil {
-		log.Errorf(log.Global, "Event insert failed: %v", err)
-		err = tx.Rollback()
+		return err
+	}
+	event := modelSQLite.AuditEvent{
+		Type:         r.Type,
+		Identifier:   r.Identifier,
+		Message:      r.Message,
+		ActorType:    r.ActorType,
+		Actor:        r.Actor,
+		Data:         r.Data,
+		Sequence:     r.Sequence,
+		PreviousHash: r.PreviousHash,
+		Hash:         r.Hash,
+		CreatedAt:    r.CreatedAt.Format(sqliteTimeFormat),
+	}
+	err = event.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return err
+	}
+	r.ID = event.ID
+	return nil
+}
+
+func insertPostgres(ctx context.Context, tx *sql.Tx, r *Record) error {
+	head, err := modelPSQL.AuditEvents(
+		qm.Where("sequence > 0"),
+		qm.OrderBy("sequence desc")).One(ctx, tx)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if head != nil {
+		r.Sequence = head.Sequence + 1
+		r.PreviousHash = head.Hash
+	} else {
+		r.Sequence = 1
+	}
+	r.Hash, err = r.calculateHash()
+	if err != nil {
+		return err
+	}
+	event := modelPSQL.AuditEvent{
+		Type:         r.Type,
+		Identifier:   r.Identifier,
+		Message:      r.Message,
+		ActorType:    r.ActorType,
+		Actor:        r.Actor,
+		Data:         r.Data,
+		Sequence:     r.Sequence,
+		PreviousHash: r.PreviousHash,
+		Hash:         r.Hash,
+		CreatedAt:    r.CreatedAt,
+	}
+	err = event.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return err
+	}
+	r.ID = event.ID
+	return nil
+}
+
+// calculateHash returns the hex encoded sha256 hash of the record contents
+// and the hash of the record before it
+func (r *Record) calculateHash() (string, error) {
+	contents, err := json.Marshal(hashContents{
+		Sequence:     r.Sequence,
+		PreviousHash: r.PreviousHash,
+		Type:         r.Type,
+		Identifier:   r.Identifier,
+		Message:      r.Message,
+		ActorType:    r.ActorType,
+		Actor:        r.Actor,
+		Data:         r.Data,
+		CreatedAt:    r.CreatedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Verify runs Repository.Verify against database.DB
+func Verify(expectedHead string) (*VerifyResult, error) {
+	return New(database.DB).Verify(expectedHead)
+}
+
+// Verify walks the audit log in sequence order, recalculating the hash of
+// each record and checking it is chained to the record before it. If
+// expectedHead is set, such as a head hash recorded by a previous
+// verification, the log must still contain it, otherwise records have been
+// removed from the end of the log. The chain is not keyed, so anyone with
+// write access to the database can rewrite it from any point and recalculate
+// the hashes. Only comparing against a head hash kept outside of the database
+// detects that
+
+func (r *Repository) Verify(expectedHead string) (*VerifyResult, error) {
+	if r.db.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	ctx := context.Background()
+	result := &VerifyResult{}
+	var err error
+	result.Unchained, err = r.countUnchained(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *Record
+	headFound := expectedHead == ""
+	for {
+		var batch []Record
+		batch, err = r.getChainedBatch(ctx, result.HeadSequence)
 		if err != nil {
-			log.Errorf(log.Global, "Event Transaction rollback failed: %v", err)
+			return nil, err
 		}
-		return
+		for i := range batch {
+			rec := &batch[i]
+			expectedSequence, expectedPrevious := int64(1), ""
+			if previous != nil {
+				expectedSequence, expectedPrevious = previous.Sequence+1, previous.Hash
+			}
+			switch {
+			case rec.Sequence != expectedSequence:
+				result.Issues = append(result.Issues, Issue{Sequence: rec.Sequence, ID: rec.ID, Reason: IssueSequenceGap})
+			case rec.PreviousHash != expectedPrevious:
+				result.Issues = append(result.Issues, Issue{Sequence: rec.Sequence, ID: rec.ID, Reason: IssueChainBroken})
+			}
+			var hash string
+			hash, err = rec.calculateHash()
+			if err != nil {
+				return nil, err
+			}
+			if hash != rec.Hash {
+				result.Issues = append(result.Issues, Issue{Sequence: rec.Sequence, ID: rec.ID, Reason: IssueModified})
+			}
+			if rec.Hash == expectedHead {
+				headFound = true
+			}
+			result.Verified++
+			result.HeadSequence = rec.Sequence
+			result.HeadHash = rec.Hash
+			previous = rec
+		}
+		if len(batch) < verifyBatchSize {
+			break
+		}
+	}
+	if !headFound {
+		result.Issues = append(result.Issues, Issue{Reason: IssueHeadNotFound})
 	}
+	return result, nil
+}
 
-	err = tx.Commit()
+func (r *Repository) countUnchained(ctx context.Context) (int64, error) {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		return modelSQLite.AuditEvents(qm.Where("sequence = 0")).Count(ctx, r.db.SQL)
+	}
+	return modelPSQL.AuditEvents(qm.Where("sequence = 0")).Count(ctx, r.db.SQL)
+}
+
+// getChainedBatch returns the next batch of chained records after sequence
+func (r *Repository) getChainedBatch(ctx context.Context, sequence int64) ([]Record, error) {
+	mods := []qm.QueryMod{
+		qm.Where("sequence > ?", sequence),
+		qm.OrderBy("sequence"),
+		qm.Limit(verifyBatchSize),
+	}
+	var records []Record
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		events, err := modelSQLite.AuditEvents(mods...).All(ctx, r.db.SQL)
+		if err != nil {
+			return nil, err
+		}
+		for i := range events {
+			createdAt, err := parseSQLiteTime(events[i].CreatedAt)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, Record{
+				ID:           events[i].ID,
+				Sequence:     events[i].Sequence,
+				Type:         events[i].Type,
+				Identifier:   events[i].Identifier,
+				Message:      events[i].Message,
+				ActorType:    events[i].ActorType,
+				Actor:        events[i].Actor,
+				Data:         events[i].Data,
+				PreviousHash: events[i].PreviousHash,
+				Hash:         events[i].Hash,
+				CreatedAt:    createdAt,
+			})
+		}
+		return records, nil
+	}
+	events, err := modelPSQL.AuditEvents(mods...).All(ctx, r.db.SQL)
 	if err != nil {
-		log.Errorf(log.Global, "Event Transaction commit failed: %v", err)
-		return
+		return nil, err
+	}
+	for i := range events {
+		records = append(records, Record{
+			ID:           events[i].ID,
+			Sequence:     events[i].Sequence,
+			Type:         events[i].Type,
+			Identifier:   events[i].Identifier,
+			Message:      events[i].Message,
+			ActorType:    events[i].ActorType,
+			Actor:        events[i].Actor,
+			Data:         events[i].Data,
+			PreviousHash: events[i].PreviousHash,
+			Hash:         events[i].Hash,
+			CreatedAt:    events[i].CreatedAt.UTC(),
+		})
 	}
+	return records, nil
 }
 
-// GetEvent () returns list of order events matching query
+// parseSQLiteTime parses a created_at value, the sqlite driver returns
+// timestamp columns formatted as RFC3339 while values read as raw text are in
+// the format they were written
+func parseSQLiteTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		t, err = time.Parse(sqliteTimeFormat, s)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	return t.UTC(), nil
+}
+
+// GetEvent runs Repository.GetEvent against database.DB
 func GetEvent(startTime, endTime time.Time, order string, limit int) (interface{}, error) {
-	if database.DB.SQL == nil {
+	return New(database.DB).GetEvent(startTime, endTime, order, limit)
+}
+
+// GetEvent () returns list of order events matching query
+func (r *Repository) GetEvent(startTime, endTime time.Time, order string, limit int) (interface{}, error) {
+	if r.db.SQL == nil {
 		return nil, database.ErrDatabaseSupportDisabled
 	}
 
@@ -77,9 +408,9 @@ func GetEvent(startTime, endTime time.Time, order string, limit int) (interface{
 	limitQuery := qm.Limit(limit)
 
 	ctx := context.Background()
-	if repository.GetSQLDialect() == database.DBSQLite3 {
-		return modelSQLite.AuditEvents(query, orderByQuery, limitQuery).All(ctx, database.DB.SQL)
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		return modelSQLite.AuditEvents(query, orderByQuery, limitQuery).All(ctx, r.db.SQL)
 	}
 
-	return modelPSQL.AuditEvents(query, orderByQuery, limitQuery).All(ctx, database.DB.SQL)
+	return modelPSQL.AuditEvents(query, orderByQuery, limitQuery).All(ctx, r.db.SQL)
 }
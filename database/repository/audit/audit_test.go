@@ -1,6 +1,7 @@
 package audit
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -128,3 +129,136 @@ func readHelper(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestActorFromContext(t *testing.T) {
+	t.Parallel()
+	a := ActorFromContext(context.Background())
+	if a.Type != ActorSystem || a.ID != "" {
+		t.Errorf("expected system actor, received %+v", a)
+	}
+	ctx := WithActor(context.Background(), Actor{Type: ActorGRPC, ID: "bob"})
+	a = ActorFromContext(ctx)
+	if a.Type != ActorGRPC || a.ID != "bob" {
+		t.Errorf("expected grpc actor bob, received %+v", a)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		closer func(dbConn *database.Instance) error
+	}{
+		{
+			"SQLite",
+			&database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./verifydb"},
+			},
+			testhelpers.CloseDatabase,
+		},
+		{
+			"Postgres",
+			testhelpers.PostgresTestDatabase,
+			nil,
+		},
+	}
+
+	for _, tests := range testCases {
+		test := tests
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			verifyChain(t)
+
+			if test.closer != nil {
+				err = test.closer(dbConn)
+				if err != nil {
+					t.Log(err)
+				}
+			}
+		})
+	}
+}
+
+func verifyChain(t *testing.T) {
+	t.Helper()
+	_, err := database.DB.SQL.Exec("DELETE FROM audit_event")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a record written before the log was chained
+	_, err = database.DB.SQL.Exec("INSERT INTO audit_event (type, identifier, message) VALUES ('legacy', 'legacy', 'legacy')")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Log(context.Background(), "", "", "", nil)
+	if err != errEventTypeUnset {
+		t.Errorf("received %v expected %v", err, errEventTypeUnset)
+	}
+
+	ctx := WithActor(context.Background(), Actor{Type: ActorScript, ID: "test.gct"})
+	var records []*Record
+	for x := 0; x < 5; x++ {
+		var r *Record
+		r, err = Log(ctx, OrderSubmit, "Bitstamp", fmt.Sprintf("order %v", x), map[string]interface{}{"amount": x})
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, r)
+	}
+	if records[0].Sequence != 1 || records[0].PreviousHash != "" {
+		t.Errorf("unexpected first record %+v", records[0])
+	}
+	if records[1].PreviousHash != records[0].Hash {
+		t.Error("record not chained to the previous record")
+	}
+	if records[4].ActorType != ActorScript || records[4].Actor != "test.gct" || records[4].Data != `{"amount":4}` {
+		t.Errorf("unexpected record %+v", records[4])
+	}
+
+	result, err := Verify(records[4].Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Verified != 5 || result.Unchained != 1 || len(result.Issues) != 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if result.HeadSequence != 5 || result.HeadHash != records[4].Hash {
+		t.Errorf("unexpected head %v %v", result.HeadSequence, result.HeadHash)
+	}
+
+	_, err = database.DB.SQL.Exec("UPDATE audit_event SET message = 'tampered' WHERE sequence = 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = Verify("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Sequence != 2 || result.Issues[0].Reason != IssueModified {
+		t.Errorf("expected modified record to be reported, received %+v", result.Issues)
+	}
+
+	_, err = database.DB.SQL.Exec("DELETE FROM audit_event WHERE sequence IN (3, 5)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = Verify(records[4].Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Issues) != 3 ||
+		result.Issues[1].Sequence != 4 || result.Issues[1].Reason != IssueSequenceGap ||
+		result.Issues[2].Reason != IssueHeadNotFound {
+		t.Errorf("expected removed records to be reported, received %+v", result.Issues)
+	}
+}
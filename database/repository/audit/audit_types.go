@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+// Actor types identify what initiated an audited action
+const (
+	ActorSystem    = "system"
+	ActorGRPC      = "grpc"
+	ActorWebsocket = "websocket"
+	ActorScript    = "script"
+	ActorChat      = "chat"
+)
+
+// Event types recorded by the engine
+const (
+	OrderSubmit         = "order_submit"
+	OrderCancel         = "order_cancel"
+	OrderModify         = "order_modify"
+	Withdrawal          = "withdrawal"
+	AssetTransfer       = "asset_transfer"
+	ConfigSave          = "config_save"
+	ConfigReload        = "config_reload"
+	SubsystemToggle     = "subsystem_toggle"
+	ExchangeToggle      = "exchange_toggle"
+	ExchangePairToggle  = "exchange_pair_toggle"
+	ExchangeAssetToggle = "exchange_asset_toggle"
+	LoggerUpdate        = "logger_update"
+	ScriptUpload        = "script_upload"
+	ScriptExecute       = "script_execute"
+	ScriptStop          = "script_stop"
+	Authorisation       = "authorisation"
+	APITokenCreate      = "api_token_create"
+	APITokenRevoke      = "api_token_revoke"
+)
+
+// Issue reasons reported by Verify
+const (
+	IssueModified     = "record hash does not match its contents"
+	IssueChainBroken  = "previous hash does not match the preceding record"
+	IssueSequenceGap  = "sequence gap, records are missing"
+	IssueHeadNotFound = "expected head hash not found, records are missing from the end of the log"
+)
+
+const verifyBatchSize = 1000
+
+var errEventTypeUnset = errors.New("audit event type not set")
+
+// Actor identifies who or what initiated an audited action
+type Actor struct {
+	Type string
+	ID   string
+}
+
+type actorKey struct{}
+
+// Record is a single entry of the audit log. Records are chained in sequence
+// order, each storing the hash of the record before it
+type Record struct {
+	ID           int64
+	Sequence     int64
+	Type         string
+	Identifier   string
+	Message      string
+	ActorType    string
+	Actor        string
+	Data         string
+	PreviousHash string
+	Hash         string
+	CreatedAt    time.Time
+}
+
+// hashContents is the canonical form of a record used to calculate its hash
+type hashContents struct {
+	Sequence     int64  `json:"sequence"`
+	PreviousHash string `json:"previous_hash"`
+	Type         string `json:"type"`
+	Identifier   string `json:"identifier"`
+	Message      string `json:"message"`
+	ActorType    string `json:"actor_type"`
+	Actor        string `json:"actor"`
+	Data         string `json:"data"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// VerifyResult holds the outcome of verifying the audit log chain
+type VerifyResult struct {
+	// Verified is the number of chained records checked
+	Verified int64
+	// Unchained is the number of records written before the log was hash
+	// chained, these cannot be verified
+	Unchained    int64
+	HeadSequence int64
+	HeadHash     string
+	Issues       []Issue
+}
+
+// Issue describes a record which failed verification
+type Issue struct {
+	Sequence int64
+	ID       int64
+	Reason   string
+}
+
+// Repository reads and writes audit records using a database instance
+type Repository struct {
+	db *database.Instance
+}
@@ -0,0 +1,258 @@
+package balancesnapshot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/volatiletech/null"
+)
+
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
+// Insert runs Repository.Insert against database.DB
+func Insert(snapshots ...Details) error {
+	return New(database.DB).Insert(snapshots...)
+}
+
+// Insert saves balance snapshots to the database. A snapshot that already
+// exists for the same account, asset, currency and timestamp is replaced
+func (r *Repository) Insert(snapshots ...Details) error {
+	if r.db.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	if len(snapshots) == 0 {
+		return errNoSnapshotsToInsert
+	}
+	for i := range snapshots {
+		if snapshots[i].Timestamp.IsZero() {
+			return errTimestampUnset
+		}
+		if snapshots[i].ExchangeID != "" {
+			continue
+		}
+		if snapshots[i].ExchangeName == "" {
+			return errExchangeUnset
+		}
+		exchangeUUID, err := exchange.New(r.db).UUIDByName(snapshots[i].ExchangeName)
+		if err != nil {
+			return err
+		}
+		snapshots[i].ExchangeID = exchangeUUID.String()
+	}
+
+	ctx := context.Background()
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Insert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.Dialect(r.db) == database.DBSQLite3 || repository.Dialect(r.db) == database.DBSQLite {
+		err = insertSQLite(ctx, tx, snapshots...)
+	} else {
+		err = insertPostgres(ctx, tx, snapshots...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertSQLite(ctx context.Context, tx *sql.Tx, snapshots ...Details) error {
+	for i := range snapshots {
+		timestamp := snapshots[i].Timestamp.UTC().Format(time.RFC3339)
+		existing, err := modelSQLite.BalanceSnapshots(
+			qm.Where("exchange_name_id = ? AND account_id = ? AND asset = ? AND currency = ? AND timestamp = ?",
+				snapshots[i].ExchangeID,
+				snapshots[i].AccountID,
+				strings.ToLower(snapshots[i].Asset),
+				strings.ToUpper(snapshots[i].Currency),
+				timestamp)).One(ctx, tx)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if existing != nil {
+			snapshots[i].ID = existing.ID
+		} else {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			snapshots[i].ID = freshUUID.String()
+		}
+		var tempEvent = modelSQLite.BalanceSnapshot{
+			ID:             snapshots[i].ID,
+			ExchangeNameID: snapshots[i].ExchangeID,
+			AccountID:      snapshots[i].AccountID,
+			Asset:          strings.ToLower(snapshots[i].Asset),
+			Currency:       strings.ToUpper(snapshots[i].Currency),
+			Total:          snapshots[i].Total,
+			Hold:           snapshots[i].Hold,
+			FiatCurrency:   strings.ToUpper(snapshots[i].FiatCurrency),
+			FiatValue:      null.NewFloat64(snapshots[i].FiatValue, snapshots[i].Valued),
+			Timestamp:      timestamp,
+		}
+		if existing != nil {
+			_, err = tempEvent.Update(ctx, tx, boil.Infer())
+		} else {
+			err = tempEvent.Insert(ctx, tx, boil.Infer())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertPostgres(ctx context.Context, tx *sql.Tx, snapshots ...Details) error {
+	for i := range snapshots {
+		var tempEvent = modelPSQL.BalanceSnapshot{
+			ExchangeNameID: snapshots[i].ExchangeID,
+			AccountID:      snapshots[i].AccountID,
+			Asset:          strings.ToLower(snapshots[i].Asset),
+			Currency:       strings.ToUpper(snapshots[i].Currency),
+			Total:          snapshots[i].Total,
+			Hold:           snapshots[i].Hold,
+			FiatCurrency:   strings.ToUpper(snapshots[i].FiatCurrency),
+			FiatValue:      null.NewFloat64(snapshots[i].FiatValue, snapshots[i].Valued),
+			Timestamp:      snapshots[i].Timestamp.UTC(),
+		}
+		err := tempEvent.Upsert(ctx, tx, true,
+			[]string{"exchange_name_id", "account_id", "asset", "currency", "timestamp"},
+			boil.Whitelist("total", "hold", "fiat_currency", "fiat_value"),
+			boil.Infer())
+		if err != nil {
+			return err
+		}
+		snapshots[i].ID = tempEvent.ID
+	}
+	return nil
+}
+
+// GetInRange runs Repository.GetInRange against database.DB
+func GetInRange(exchangeName string, start, end time.Time) ([]Details, error) {
+	return New(database.DB).GetInRange(exchangeName, start, end)
+}
+
+// GetInRange returns all balance snapshots taken between the supplied dates
+// ordered by time. An empty exchange name returns snapshots for all exchanges
+func (r *Repository) GetInRange(exchangeName string, start, end time.Time) ([]Details, error) {
+	if r.db.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	var mods []qm.QueryMod
+	if exchangeName != "" {
+		exchangeUUID, err := exchange.New(r.db).UUIDByName(exchangeName)
+		if err != nil {
+			return nil, err
+		}
+		mods = append(mods, qm.Where("exchange_name_id = ?", exchangeUUID.String()))
+	}
+	if repository.Dialect(r.db) == database.DBSQLite3 || repository.Dialect(r.db) == database.DBSQLite {
+		mods = append(mods, qm.Where("timestamp BETWEEN ? AND ?",
+			start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)))
+		snapshots, err := r.getSQLite(mods...)
+		if err != nil {
+			return nil, fmt.Errorf("balancesnapshot.GetInRange getSQLite %w", err)
+		}
+		return snapshots, nil
+	}
+	mods = append(mods, qm.Where("timestamp BETWEEN ? AND ?", start.UTC(), end.UTC()))
+	snapshots, err := r.getPostgres(mods...)
+	if err != nil {
+		return nil, fmt.Errorf("balancesnapshot.GetInRange getPostgres %w", err)
+	}
+	return snapshots, nil
+}
+
+func (r *Repository) getSQLite(mods ...qm.QueryMod) ([]Details, error) {
+	mods = append(mods,
+		qm.Load(modelSQLite.BalanceSnapshotRels.ExchangeName),
+		qm.OrderBy("timestamp"))
+	result, err := modelSQLite.BalanceSnapshots(mods...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Details, len(result))
+	for i := range result {
+		var ts time.Time
+		ts, err = time.Parse(time.RFC3339, result[i].Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = Details{
+			ID:           result[i].ID,
+			ExchangeID:   result[i].ExchangeNameID,
+			AccountID:    result[i].AccountID,
+			Asset:        result[i].Asset,
+			Currency:     result[i].Currency,
+			Total:        result[i].Total,
+			Hold:         result[i].Hold,
+			FiatCurrency: result[i].FiatCurrency,
+			FiatValue:    result[i].FiatValue.Float64,
+			Valued:       result[i].FiatValue.Valid,
+			Timestamp:    ts,
+		}
+		if result[i].R != nil && result[i].R.ExchangeName != nil {
+			resp[i].ExchangeName = result[i].R.ExchangeName.Name
+		}
+	}
+	return resp, nil
+}
+
+func (r *Repository) getPostgres(mods ...qm.QueryMod) ([]Details, error) {
+	mods = append(mods,
+		qm.Load(modelPSQL.BalanceSnapshotRels.ExchangeName),
+		qm.OrderBy("timestamp"))
+	result, err := modelPSQL.BalanceSnapshots(mods...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Details, len(result))
+	for i := range result {
+		resp[i] = Details{
+			ID:           result[i].ID,
+			ExchangeID:   result[i].ExchangeNameID,
+			AccountID:    result[i].AccountID,
+			Asset:        result[i].Asset,
+			Currency:     result[i].Currency,
+			Total:        result[i].Total,
+			Hold:         result[i].Hold,
+			FiatCurrency: result[i].FiatCurrency,
+			FiatValue:    result[i].FiatValue.Float64,
+			Valued:       result[i].FiatValue.Valid,
+			Timestamp:    result[i].Timestamp,
+		}
+		if result[i].R != nil && result[i].R.ExchangeName != nil {
+			resp[i].ExchangeName = result[i].R.ExchangeName.Name
+		}
+	}
+	return resp, nil
+}
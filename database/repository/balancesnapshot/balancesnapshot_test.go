@@ -0,0 +1,182 @@
+package balancesnapshot
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var (
+	verbose       = false
+	testExchanges = []exchange.Details{
+		{
+			Name: "one",
+		},
+		{
+			Name: "two",
+		},
+	}
+)
+
+func TestMain(m *testing.M) {
+	if verbose {
+		testhelpers.EnableVerboseTestOutput()
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	t := m.Run()
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+	os.Exit(t)
+}
+
+func TestBalanceSnapshot(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		seedDB func() error
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+			seedDB: seedDB,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			seedDB: seedDB,
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.seedDB != nil {
+				err = test.seedDB()
+				if err != nil {
+					t.Error(err)
+				}
+			}
+
+			snapshotSQLTester(t)
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func snapshotSQLTester(t *testing.T) {
+	err := Insert()
+	if err != errNoSnapshotsToInsert {
+		t.Errorf("received %v expected %v", err, errNoSnapshotsToInsert)
+	}
+	err = Insert(Details{ExchangeName: "one"})
+	if err != errTimestampUnset {
+		t.Errorf("received %v expected %v", err, errTimestampUnset)
+	}
+	err = Insert(Details{Timestamp: time.Now()})
+	if err != errExchangeUnset {
+		t.Errorf("received %v expected %v", err, errExchangeUnset)
+	}
+
+	start := time.Now().Add(-time.Hour * 24).Truncate(time.Hour)
+	var snapshots []Details
+	for i := 0; i < 3; i++ {
+		for _, exch := range []string{"one", "two"} {
+			snapshots = append(snapshots, Details{
+				ExchangeName: exch,
+				AccountID:    "main",
+				Asset:        "spot",
+				Currency:     "btc",
+				Total:        float64(i + 1),
+				Hold:         0.5,
+				FiatCurrency: "usd",
+				FiatValue:    float64(i+1) * 1000,
+				Valued:       i != 2,
+				Timestamp:    start.Add(time.Hour * time.Duration(i)),
+			})
+		}
+	}
+	err = Insert(snapshots...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// inserting the same snapshot again replaces the stored values
+	replacement := snapshots[0]
+	replacement.ID = ""
+	replacement.FiatValue = 1337
+	err = Insert(replacement)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := GetInRange("", start, start.Add(time.Hour*3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 6 {
+		t.Fatalf("received %v expected %v", len(resp), 6)
+	}
+
+	resp, err = GetInRange("one", start, start.Add(time.Hour*3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 3 {
+		t.Fatalf("received %v expected %v", len(resp), 3)
+	}
+	if resp[0].FiatValue != 1337 || !resp[0].Valued {
+		t.Errorf("received %v expected %v", resp[0].FiatValue, 1337)
+	}
+	if resp[2].Valued || resp[2].FiatValue != 0 {
+		t.Errorf("expected an unvalued balance to be stored without a value, received %+v", resp[2])
+	}
+	if resp[0].ExchangeName != "one" || resp[0].Currency != "BTC" || resp[0].FiatCurrency != "USD" {
+		t.Errorf("unexpected snapshot %+v", resp[0])
+	}
+	if !resp[2].Timestamp.Equal(snapshots[4].Timestamp) {
+		t.Errorf("received %v expected %v", resp[2].Timestamp, snapshots[4].Timestamp)
+	}
+
+	resp, err = GetInRange("one", start.Add(time.Hour), start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 1 {
+		t.Errorf("received %v expected %v", len(resp), 1)
+	}
+}
+
+func seedDB() error {
+	return exchange.InsertMany(testExchanges)
+}
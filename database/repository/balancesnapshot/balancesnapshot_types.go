@@ -0,0 +1,38 @@
+package balancesnapshot
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+var (
+	errNoSnapshotsToInsert = errors.New("no balance snapshots to insert")
+	errExchangeUnset       = errors.New("balance snapshot exchange not set")
+	errTimestampUnset      = errors.New("balance snapshot timestamp not set")
+)
+
+// Details defines a single currency balance of an exchange sub account at a
+// point in time in its simplest db friendly form. Valued is false when the
+// balance could not be valued in the fiat currency, in which case FiatValue
+// is stored as NULL
+type Details struct {
+	ID           string
+	ExchangeID   string
+	ExchangeName string
+	AccountID    string
+	Asset        string
+	Currency     string
+	Total        float64
+	Hold         float64
+	FiatCurrency string
+	FiatValue    float64
+	Valued       bool
+	Timestamp    time.Time
+}
+
+// Repository reads and writes balancesnapshot records using a database instance
+type Repository struct {
+	db *database.Instance
+}
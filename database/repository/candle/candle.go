@@ -23,8 +23,21 @@ import (
 	"github.com/thrasher-corp/sqlboiler/queries/qm"
 )
 
-// Series returns candle data
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
+// Series runs Repository.Series against database.DB
 func Series(exchangeName, base, quote string, interval int64, asset string, start, end time.Time) (out Item, err error) {
+	return New(database.DB).Series(exchangeName, base, quote, interval, asset, start, end)
+}
+
+// Series returns candle data
+func (r *Repository) Series(exchangeName, base, quote string, interval int64, asset string, start, end time.Time) (out Item, err error) {
 	if exchangeName == "" || base == "" || quote == "" || asset == "" || interval <= 0 {
 		return out, errInvalidInput
 	}
@@ -36,14 +49,14 @@ func Series(exchangeName, base, quote string, interval int64, asset string, star
 		qm.Where("asset = ?", strings.ToLower(asset)),
 	}
 
-	exchangeUUID, errS := exchange.UUIDByName(exchangeName)
+	exchangeUUID, errS := exchange.New(r.db).UUIDByName(exchangeName)
 	if errS != nil {
 		return out, errS
 	}
 	queries = append(queries, qm.Where("exchange_name_id = ?", exchangeUUID.String()))
-	if repository.GetSQLDialect() == database.DBSQLite3 {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
 		queries = append(queries, qm.Where("timestamp between ? and ?", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)))
-		retCandle, errC := modelSQLite.Candles(queries...).All(context.Background(), database.DB.SQL)
+		retCandle, errC := modelSQLite.Candles(queries...).All(context.Background(), r.db.SQL)
 		if errC != nil {
 			return out, errC
 		}
@@ -63,7 +76,7 @@ func Series(exchangeName, base, quote string, interval int64, asset string, star
 		}
 	} else {
 		queries = append(queries, qm.Where("timestamp between ? and ?", start.UTC(), end.UTC()))
-		retCandle, errC := modelPSQL.Candles(queries...).All(context.Background(), database.DB.SQL)
+		retCandle, errC := modelPSQL.Candles(queries...).All(context.Background(), r.db.SQL)
 		if errC != nil {
 			return out, errC
 		}
@@ -91,9 +104,14 @@ func Series(exchangeName, base, quote string, interval int64, asset string, star
 	return out, err
 }
 
-// DeleteCandles will delete all existing matching candles
+// DeleteCandles runs Repository.DeleteCandles against database.DB
 func DeleteCandles(in *Item) (int64, error) {
-	if database.DB.SQL == nil {
+	return New(database.DB).DeleteCandles(in)
+}
+
+// DeleteCandles will delete all existing matching candles
+func (r *Repository) DeleteCandles(in *Item) (int64, error) {
+	if r.db.SQL == nil {
 		return 0, database.ErrDatabaseSupportDisabled
 	}
 	if len(in.Candles) < 1 {
@@ -108,22 +126,178 @@ func DeleteCandles(in *Item) (int64, error) {
 		qm.Where("asset = ?", strings.ToLower(in.Asset)),
 		qm.Where("exchange_name_id = ?", in.ExchangeID),
 	}
-	if repository.GetSQLDialect() == database.DBSQLite3 {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
 		queries = append(queries, qm.Where("timestamp between ? and ?", in.Candles[0].Timestamp.UTC().Format(time.RFC3339), in.Candles[len(in.Candles)-1].Timestamp.UTC().Format(time.RFC3339)))
-		return deleteSQLite(ctx, queries)
+		return r.deleteSQLite(ctx, queries)
 	}
 
 	queries = append(queries, qm.Where("timestamp between ? and ?", in.Candles[0].Timestamp.UTC(), in.Candles[len(in.Candles)-1].Timestamp.UTC()))
-	return deletePostgres(ctx, queries)
+	return r.deletePostgres(ctx, queries)
+}
+
+// GetOldestTimestamp runs Repository.GetOldestTimestamp against database.DB
+func GetOldestTimestamp(exchangeName, asset string) (time.Time, error) {
+	return New(database.DB).GetOldestTimestamp(exchangeName, asset)
+}
+
+// GetOldestTimestamp returns the time of the oldest candle stored for an
+// exchange asset across all pairs and intervals
+func (r *Repository) GetOldestTimestamp(exchangeName, asset string) (time.Time, error) {
+	if r.db.SQL == nil {
+		return time.Time{}, database.ErrDatabaseSupportDisabled
+	}
+	queries, err := r.exchangeAssetQuery(exchangeName, asset)
+	if err != nil {
+		return time.Time{}, err
+	}
+	queries = append(queries, qm.OrderBy("timestamp asc"))
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		retCandle, errC := modelSQLite.Candles(queries...).One(context.Background(), r.db.SQL)
+		if errC != nil {
+			if errC == sql.ErrNoRows {
+				return time.Time{}, ErrNoCandlesFound
+			}
+			return time.Time{}, errC
+		}
+		return time.Parse(time.RFC3339, retCandle.Timestamp)
+	}
+	retCandle, err := modelPSQL.Candles(queries...).One(context.Background(), r.db.SQL)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, ErrNoCandlesFound
+		}
+		return time.Time{}, err
+	}
+	return retCandle.Timestamp.UTC(), nil
+}
+
+// SeriesByExchange runs Repository.SeriesByExchange against database.DB
+func SeriesByExchange(exchangeName, asset string, start, end time.Time) ([]Item, error) {
+	return New(database.DB).SeriesByExchange(exchangeName, asset, start, end)
 }
 
-func deleteSQLite(ctx context.Context, queries []qm.QueryMod) (int64, error) {
-	retCandle, err := modelSQLite.Candles(queries...).All(context.Background(), database.DB.SQL)
+// SeriesByExchange returns every candle series stored for an exchange asset
+// in a date range, grouped by pair and interval
+func (r *Repository) SeriesByExchange(exchangeName, asset string, start, end time.Time) ([]Item, error) {
+	if r.db.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	queries, err := r.exchangeAssetQuery(exchangeName, asset)
+	if err != nil {
+		return nil, err
+	}
+	queries = append(queries, qm.OrderBy("timestamp asc"))
+	items := make(map[string]*Item)
+	var keys []string
+	add := func(base, quote string, interval int64, c Candle) {
+		key := base + "-" + quote + "-" + strconv.FormatInt(interval, 10)
+		item, ok := items[key]
+		if !ok {
+			item = &Item{
+				ExchangeID: exchangeName,
+				Base:       base,
+				Quote:      quote,
+				Interval:   interval,
+				Asset:      strings.ToLower(asset),
+			}
+			items[key] = item
+			keys = append(keys, key)
+		}
+		item.Candles = append(item.Candles, c)
+	}
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		queries = append(queries, qm.Where("timestamp between ? and ?", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)))
+		retCandle, errC := modelSQLite.Candles(queries...).All(context.Background(), r.db.SQL)
+		if errC != nil {
+			return nil, errC
+		}
+		for x := range retCandle {
+			t, errT := time.Parse(time.RFC3339, retCandle[x].Timestamp)
+			if errT != nil {
+				return nil, errT
+			}
+			interval, errI := strconv.ParseInt(retCandle[x].Interval, 10, 64)
+			if errI != nil {
+				return nil, errI
+			}
+			add(retCandle[x].Base, retCandle[x].Quote, interval, Candle{
+				Timestamp: t,
+				Open:      retCandle[x].Open,
+				High:      retCandle[x].High,
+				Low:       retCandle[x].Low,
+				Close:     retCandle[x].Close,
+				Volume:    retCandle[x].Volume,
+			})
+		}
+	} else {
+		queries = append(queries, qm.Where("timestamp between ? and ?", start.UTC(), end.UTC()))
+		retCandle, errC := modelPSQL.Candles(queries...).All(context.Background(), r.db.SQL)
+		if errC != nil {
+			return nil, errC
+		}
+		for x := range retCandle {
+			add(retCandle[x].Base, retCandle[x].Quote, retCandle[x].Interval, Candle{
+				Timestamp: retCandle[x].Timestamp.UTC(),
+				Open:      retCandle[x].Open,
+				High:      retCandle[x].High,
+				Low:       retCandle[x].Low,
+				Close:     retCandle[x].Close,
+				Volume:    retCandle[x].Volume,
+			})
+		}
+	}
+	out := make([]Item, len(keys))
+	for i := range keys {
+		out[i] = *items[keys[i]]
+	}
+	return out, nil
+}
+
+// DeleteByExchangeInRange runs Repository.DeleteByExchangeInRange against database.DB
+func DeleteByExchangeInRange(exchangeName, asset string, start, end time.Time) (int64, error) {
+	return New(database.DB).DeleteByExchangeInRange(exchangeName, asset, start, end)
+}
+
+// DeleteByExchangeInRange deletes every candle stored for an exchange asset
+// in a date range regardless of pair or interval
+func (r *Repository) DeleteByExchangeInRange(exchangeName, asset string, start, end time.Time) (int64, error) {
+	if r.db.SQL == nil {
+		return 0, database.ErrDatabaseSupportDisabled
+	}
+	queries, err := r.exchangeAssetQuery(exchangeName, asset)
+	if err != nil {
+		return 0, err
+	}
+	ctx := context.Background()
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		queries = append(queries, qm.Where("timestamp between ? and ?", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)))
+		return r.deleteSQLite(ctx, queries)
+	}
+	queries = append(queries, qm.Where("timestamp between ? and ?", start.UTC(), end.UTC()))
+	return r.deletePostgres(ctx, queries)
+}
+
+func (r *Repository) exchangeAssetQuery(exchangeName, asset string) ([]qm.QueryMod, error) {
+	if exchangeName == "" || asset == "" {
+		return nil, errInvalidInput
+	}
+	exchangeUUID, err := exchange.New(r.db).UUIDByName(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	return []qm.QueryMod{
+		qm.Where("exchange_name_id = ?", exchangeUUID.String()),
+		qm.Where("asset = ?", strings.ToLower(asset)),
+	}, nil
+}
+
+func (r *Repository) deleteSQLite(ctx context.Context, queries []qm.QueryMod) (int64, error) {
+	retCandle, err := modelSQLite.Candles(queries...).All(context.Background(), r.db.SQL)
 	if err != nil {
 		return 0, err
 	}
 	var tx *sql.Tx
-	tx, err = database.DB.SQL.BeginTx(ctx, nil)
+	tx, err = r.db.SQL.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -144,13 +318,13 @@ func deleteSQLite(ctx context.Context, queries []qm.QueryMod) (int64, error) {
 	return totalDeleted, nil
 }
 
-func deletePostgres(ctx context.Context, queries []qm.QueryMod) (int64, error) {
-	retCandle, err := modelPSQL.Candles(queries...).All(context.Background(), database.DB.SQL)
+func (r *Repository) deletePostgres(ctx context.Context, queries []qm.QueryMod) (int64, error) {
+	retCandle, err := modelPSQL.Candles(queries...).All(context.Background(), r.db.SQL)
 	if err != nil {
 		return 0, err
 	}
 	var tx *sql.Tx
-	tx, err = database.DB.SQL.BeginTx(ctx, nil)
+	tx, err = r.db.SQL.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -171,9 +345,14 @@ func deletePostgres(ctx context.Context, queries []qm.QueryMod) (int64, error) {
 	return totalDeleted, nil
 }
 
-// Insert series of candles
+// Insert runs Repository.Insert against database.DB
 func Insert(in *Item) (uint64, error) {
-	if database.DB.SQL == nil {
+	return New(database.DB).Insert(in)
+}
+
+// Insert series of candles
+func (r *Repository) Insert(in *Item) (uint64, error) {
+	if r.db.SQL == nil {
 		return 0, database.ErrDatabaseSupportDisabled
 	}
 
@@ -182,13 +361,13 @@ func Insert(in *Item) (uint64, error) {
 	}
 
 	ctx := context.Background()
-	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
 
 	var totalInserted uint64
-	if repository.GetSQLDialect() == database.DBSQLite3 {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
 		totalInserted, err = insertSQLite(ctx, tx, in)
 	} else {
 		totalInserted, err = insertPostgresSQL(ctx, tx, in)
@@ -267,8 +446,13 @@ func insertPostgresSQL(ctx context.Context, tx *sql.Tx, in *Item) (uint64, error
 	return totalInserted, nil
 }
 
-// InsertFromCSV load a CSV list of candle data and insert into database
+// InsertFromCSV runs Repository.InsertFromCSV against database.DB
 func InsertFromCSV(exchangeName, base, quote string, interval int64, asset, file string) (uint64, error) {
+	return New(database.DB).InsertFromCSV(exchangeName, base, quote, interval, asset, file)
+}
+
+// InsertFromCSV load a CSV list of candle data and insert into database
+func (r *Repository) InsertFromCSV(exchangeName, base, quote string, interval int64, asset, file string) (uint64, error) {
 	csvFile, err := os.Open(file)
 	if err != nil {
 		return 0, err
@@ -283,7 +467,7 @@ func InsertFromCSV(exchangeName, base, quote string, interval int64, asset, file
 
 	csvData := csv.NewReader(csvFile)
 
-	exchangeUUID, err := exchange.UUIDByName(exchangeName)
+	exchangeUUID, err := exchange.New(r.db).UUIDByName(exchangeName)
 	if err != nil {
 		return 0, err
 	}
@@ -346,5 +530,5 @@ func InsertFromCSV(exchangeName, base, quote string, interval int64, asset, file
 		return 0, err
 	}
 
-	return Insert(tempCandle)
+	return r.Insert(tempCandle)
 }
@@ -270,6 +270,96 @@ func TestSeries(t *testing.T) {
 	}
 }
 
+func TestSeriesByExchange(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = GetOldestTimestamp("", "")
+			if !errors.Is(err, errInvalidInput) {
+				t.Errorf("received %v expected %v", err, errInvalidInput)
+			}
+
+			err = seedDB(true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			oldest, err := GetOldestTimestamp(testExchanges[0].Name, "spot")
+			if err != nil {
+				t.Fatal(err)
+			}
+			start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+			if !oldest.Equal(start) {
+				t.Errorf("received %v expected %v", oldest, start)
+			}
+
+			end := start.AddDate(0, 0, 10)
+			items, err := SeriesByExchange(testExchanges[0].Name, "spot", start, end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 1 {
+				t.Fatalf("received %v expected %v", len(items), 1)
+			}
+			if items[0].Base != "BTC" || items[0].Quote != "USDT" || items[0].Interval != 86400 || len(items[0].Candles) != 11 {
+				t.Errorf("unexpected series %v %v %v %v", items[0].Base, items[0].Quote, items[0].Interval, len(items[0].Candles))
+			}
+
+			deleted, err := DeleteByExchangeInRange(testExchanges[0].Name, "spot", start, end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if deleted != 11 {
+				t.Errorf("received %v expected %v", deleted, 11)
+			}
+			oldest, err = GetOldestTimestamp(testExchanges[0].Name, "spot")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !oldest.Equal(start.AddDate(0, 0, 11)) {
+				t.Errorf("received %v expected %v", oldest, start.AddDate(0, 0, 11))
+			}
+
+			_, err = GetOldestTimestamp(testExchanges[0].Name, "futures")
+			if !errors.Is(err, ErrNoCandlesFound) {
+				t.Errorf("received %v expected %v", err, ErrNoCandlesFound)
+			}
+
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 func seedDB(includeOHLCVData bool) error {
 	err := exchange.InsertMany(testExchanges)
 	if err != nil {
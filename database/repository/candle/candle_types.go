@@ -3,6 +3,8 @@ package candle
 import (
 	"errors"
 	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
 )
 
 const (
@@ -12,6 +14,9 @@ const (
 var (
 	errInvalidInput = errors.New("exchange, base, quote, asset, interval, start & end cannot be empty")
 	errNoCandleData = errors.New("no candle data provided")
+	// ErrNoCandlesFound is returned when an exchange asset has no stored
+	// candles
+	ErrNoCandlesFound = errors.New("no candles found")
 )
 
 // Item generic candle holder for modelPSQL & modelSQLite
@@ -34,3 +39,8 @@ type Candle struct {
 	Close     float64
 	Volume    float64
 }
+
+// Repository reads and writes candle records using a database instance
+type Repository struct {
+	db *database.Instance
+}
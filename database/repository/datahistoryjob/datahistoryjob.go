@@ -0,0 +1,341 @@
+package datahistoryjob
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
+// Upsert runs Repository.Upsert against database.DB
+func Upsert(jobs ...*DataHistoryJob) error {
+	return New(database.DB).Upsert(jobs...)
+}
+
+// Upsert inserts or updates data history jobs, matching existing jobs by
+// nickname
+func (r *Repository) Upsert(jobs ...*DataHistoryJob) error {
+	if r.db.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	if len(jobs) == 0 {
+		return errNoJobsToUpsert
+	}
+	for i := range jobs {
+		if jobs[i].Nickname == "" {
+			return errNicknameUnset
+		}
+		if jobs[i].ExchangeID == "" && jobs[i].ExchangeName != "" {
+			exchangeUUID, err := exchange.New(r.db).UUIDByName(jobs[i].ExchangeName)
+			if err != nil {
+				return err
+			}
+			jobs[i].ExchangeID = exchangeUUID.String()
+		} else if jobs[i].ExchangeID == "" {
+			return errExchangeUnset
+		}
+	}
+
+	ctx := context.Background()
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Upsert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.Dialect(r.db) == database.DBSQLite3 || repository.Dialect(r.db) == database.DBSQLite {
+		err = upsertSQLite(ctx, tx, jobs...)
+	} else {
+		err = upsertPostgres(ctx, tx, jobs...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func upsertSQLite(ctx context.Context, tx *sql.Tx, jobs ...*DataHistoryJob) error {
+	for i := range jobs {
+		existing, err := modelSQLite.Datahistoryjobs(
+			qm.Where("nickname = ?", strings.ToLower(jobs[i].Nickname))).One(ctx, tx)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if existing != nil {
+			jobs[i].ID = existing.ID
+			jobs[i].CreatedDate, err = time.Parse(time.RFC3339, existing.Created)
+			if err != nil {
+				return err
+			}
+		}
+		if jobs[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			jobs[i].ID = freshUUID.String()
+		}
+		if jobs[i].CreatedDate.IsZero() {
+			jobs[i].CreatedDate = time.Now()
+		}
+		var tempEvent = modelSQLite.Datahistoryjob{
+			ID:             jobs[i].ID,
+			Nickname:       strings.ToLower(jobs[i].Nickname),
+			ExchangeNameID: jobs[i].ExchangeID,
+			Asset:          strings.ToLower(jobs[i].Asset),
+			Base:           strings.ToUpper(jobs[i].Base),
+			Quote:          strings.ToUpper(jobs[i].Quote),
+			StartTime:      jobs[i].StartDate.UTC().Format(time.RFC3339),
+			EndTime:        jobs[i].EndDate.UTC().Format(time.RFC3339),
+			Interval:       jobs[i].Interval,
+			DataType:       jobs[i].DataType,
+			RequestSize:    jobs[i].RequestSizeLimit,
+			MaxRetries:     jobs[i].MaxRetryAttempts,
+			BatchCount:     jobs[i].BatchSize,
+			Status:         jobs[i].Status,
+			Created:        jobs[i].CreatedDate.UTC().Format(time.RFC3339),
+		}
+		if existing != nil {
+			_, err = tempEvent.Update(ctx, tx, boil.Infer())
+		} else {
+			err = tempEvent.Insert(ctx, tx, boil.Infer())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func upsertPostgres(ctx context.Context, tx *sql.Tx, jobs ...*DataHistoryJob) error {
+	for i := range jobs {
+		existing, err := modelPSQL.Datahistoryjobs(
+			qm.Where("nickname = ?", strings.ToLower(jobs[i].Nickname))).One(ctx, tx)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if existing != nil {
+			jobs[i].ID = existing.ID
+		}
+		if jobs[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			jobs[i].ID = freshUUID.String()
+		}
+		var tempEvent = modelPSQL.Datahistoryjob{
+			ID:             jobs[i].ID,
+			Nickname:       strings.ToLower(jobs[i].Nickname),
+			ExchangeNameID: jobs[i].ExchangeID,
+			Asset:          strings.ToLower(jobs[i].Asset),
+			Base:           strings.ToUpper(jobs[i].Base),
+			Quote:          strings.ToUpper(jobs[i].Quote),
+			StartTime:      jobs[i].StartDate.UTC(),
+			EndTime:        jobs[i].EndDate.UTC(),
+			Interval:       jobs[i].Interval,
+			DataType:       jobs[i].DataType,
+			RequestSize:    jobs[i].RequestSizeLimit,
+			MaxRetries:     jobs[i].MaxRetryAttempts,
+			BatchCount:     jobs[i].BatchSize,
+			Status:         jobs[i].Status,
+		}
+		err = tempEvent.Upsert(ctx, tx, true, []string{"id"},
+			boil.Blacklist("id", "created"), boil.Infer())
+		if err != nil {
+			return err
+		}
+		jobs[i].ID = tempEvent.ID
+		jobs[i].CreatedDate = tempEvent.Created
+	}
+	return nil
+}
+
+// GetByNickname runs Repository.GetByNickname against database.DB
+func GetByNickname(nickname string) (*DataHistoryJob, error) {
+	return New(database.DB).GetByNickname(nickname)
+}
+
+// GetByNickname returns a data history job by its unique nickname
+func (r *Repository) GetByNickname(nickname string) (*DataHistoryJob, error) {
+	return r.getOne(qm.Where("nickname = ?", strings.ToLower(nickname)))
+}
+
+// GetByID runs Repository.GetByID against database.DB
+func GetByID(id string) (*DataHistoryJob, error) {
+	return New(database.DB).GetByID(id)
+}
+
+// GetByID returns a data history job by its unique ID
+func (r *Repository) GetByID(id string) (*DataHistoryJob, error) {
+	return r.getOne(qm.Where("id = ?", id))
+}
+
+// GetJobsBetween runs Repository.GetJobsBetween against database.DB
+func GetJobsBetween(start, end time.Time) ([]DataHistoryJob, error) {
+	return New(database.DB).GetJobsBetween(start, end)
+}
+
+// GetJobsBetween returns all data history jobs created within the supplied
+// date range
+func (r *Repository) GetJobsBetween(start, end time.Time) ([]DataHistoryJob, error) {
+	if repository.Dialect(r.db) == database.DBSQLite3 || repository.Dialect(r.db) == database.DBSQLite {
+		return r.getAll(qm.Where("created BETWEEN ? AND ?",
+			start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)))
+	}
+	return r.getAll(qm.Where("created BETWEEN ? AND ?", start.UTC(), end.UTC()))
+}
+
+// GetJobsByStatus runs Repository.GetJobsByStatus against database.DB
+func GetJobsByStatus(statuses ...int64) ([]DataHistoryJob, error) {
+	return New(database.DB).GetJobsByStatus(statuses...)
+}
+
+// GetJobsByStatus returns all data history jobs that match any of the
+// supplied statuses
+func (r *Repository) GetJobsByStatus(statuses ...int64) ([]DataHistoryJob, error) {
+	s := make([]interface{}, len(statuses))
+	for i := range statuses {
+		s[i] = statuses[i]
+	}
+	return r.getAll(qm.WhereIn("status in ?", s...))
+}
+
+func (r *Repository) getOne(mods ...qm.QueryMod) (*DataHistoryJob, error) {
+	jobs, err := r.getAll(mods...)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &jobs[0], nil
+}
+
+func (r *Repository) getAll(mods ...qm.QueryMod) ([]DataHistoryJob, error) {
+	if r.db.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if repository.Dialect(r.db) == database.DBSQLite3 || repository.Dialect(r.db) == database.DBSQLite {
+		jobs, err := r.getAllSQLite(mods...)
+		if err != nil {
+			return nil, fmt.Errorf("datahistoryjob getAllSQLite %w", err)
+		}
+		return jobs, nil
+	}
+	jobs, err := r.getAllPostgres(mods...)
+	if err != nil {
+		return nil, fmt.Errorf("datahistoryjob getAllPostgres %w", err)
+	}
+	return jobs, nil
+}
+
+func (r *Repository) getAllSQLite(mods ...qm.QueryMod) ([]DataHistoryJob, error) {
+	mods = append(mods,
+		qm.Load(modelSQLite.DatahistoryjobRels.ExchangeName),
+		qm.OrderBy("created"))
+	result, err := modelSQLite.Datahistoryjobs(mods...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]DataHistoryJob, len(result))
+	for i := range result {
+		var start, end, created time.Time
+		start, err = time.Parse(time.RFC3339, result[i].StartTime)
+		if err != nil {
+			return nil, err
+		}
+		end, err = time.Parse(time.RFC3339, result[i].EndTime)
+		if err != nil {
+			return nil, err
+		}
+		created, err = time.Parse(time.RFC3339, result[i].Created)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = DataHistoryJob{
+			ID:               result[i].ID,
+			Nickname:         result[i].Nickname,
+			ExchangeID:       result[i].ExchangeNameID,
+			Asset:            result[i].Asset,
+			Base:             result[i].Base,
+			Quote:            result[i].Quote,
+			StartDate:        start,
+			EndDate:          end,
+			Interval:         result[i].Interval,
+			DataType:         result[i].DataType,
+			RequestSizeLimit: result[i].RequestSize,
+			MaxRetryAttempts: result[i].MaxRetries,
+			BatchSize:        result[i].BatchCount,
+			Status:           result[i].Status,
+			CreatedDate:      created,
+		}
+		if result[i].R != nil && result[i].R.ExchangeName != nil {
+			resp[i].ExchangeName = result[i].R.ExchangeName.Name
+		}
+	}
+	return resp, nil
+}
+
+func (r *Repository) getAllPostgres(mods ...qm.QueryMod) ([]DataHistoryJob, error) {
+	mods = append(mods,
+		qm.Load(modelPSQL.DatahistoryjobRels.ExchangeName),
+		qm.OrderBy("created"))
+	result, err := modelPSQL.Datahistoryjobs(mods...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]DataHistoryJob, len(result))
+	for i := range result {
+		resp[i] = DataHistoryJob{
+			ID:               result[i].ID,
+			Nickname:         result[i].Nickname,
+			ExchangeID:       result[i].ExchangeNameID,
+			Asset:            result[i].Asset,
+			Base:             result[i].Base,
+			Quote:            result[i].Quote,
+			StartDate:        result[i].StartTime,
+			EndDate:          result[i].EndTime,
+			Interval:         result[i].Interval,
+			DataType:         result[i].DataType,
+			RequestSizeLimit: result[i].RequestSize,
+			MaxRetryAttempts: result[i].MaxRetries,
+			BatchSize:        result[i].BatchCount,
+			Status:           result[i].Status,
+			CreatedDate:      result[i].Created,
+		}
+		if result[i].R != nil && result[i].R.ExchangeName != nil {
+			resp[i].ExchangeName = result[i].R.ExchangeName.Name
+		}
+	}
+	return resp, nil
+}
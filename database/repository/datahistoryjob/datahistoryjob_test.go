@@ -0,0 +1,205 @@
+package datahistoryjob
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var (
+	verbose       = false
+	testExchanges = []exchange.Details{
+		{
+			Name: "one",
+		},
+		{
+			Name: "two",
+		},
+	}
+)
+
+func TestMain(m *testing.M) {
+	if verbose {
+		testhelpers.EnableVerboseTestOutput()
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	t := m.Run()
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+	os.Exit(t)
+}
+
+func TestDataHistoryJob(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		seedDB func() error
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+			seedDB: seedDB,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			seedDB: seedDB,
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.seedDB != nil {
+				err = test.seedDB()
+				if err != nil {
+					t.Error(err)
+				}
+			}
+
+			jobSQLTester(t)
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func jobSQLTester(t *testing.T) {
+	err := Upsert()
+	if err != errNoJobsToUpsert {
+		t.Errorf("received %v expected %v", err, errNoJobsToUpsert)
+	}
+	err = Upsert(&DataHistoryJob{})
+	if err != errNicknameUnset {
+		t.Errorf("received %v expected %v", err, errNicknameUnset)
+	}
+	err = Upsert(&DataHistoryJob{Nickname: "meow"})
+	if err != errExchangeUnset {
+		t.Errorf("received %v expected %v", err, errExchangeUnset)
+	}
+
+	start := time.Now().Add(-time.Hour * 24).Truncate(time.Hour)
+	var jobs []*DataHistoryJob
+	for i := 0; i < 5; i++ {
+		jobs = append(jobs, &DataHistoryJob{
+			Nickname:         fmt.Sprintf("TestJob%v", i),
+			ExchangeName:     testExchanges[0].Name,
+			Asset:            "spot",
+			Base:             "btc",
+			Quote:            "usd",
+			StartDate:        start,
+			EndDate:          start.Add(time.Hour * 12),
+			Interval:         int64(time.Hour),
+			DataType:         0,
+			RequestSizeLimit: 500,
+			MaxRetryAttempts: 3,
+			BatchSize:        10,
+			Status:           int64(i % 2),
+		})
+	}
+	err = Upsert(jobs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := GetByNickname("testjob1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ID != jobs[1].ID {
+		t.Errorf("received %v expected %v", resp.ID, jobs[1].ID)
+	}
+	if resp.ExchangeName != testExchanges[0].Name {
+		t.Errorf("received %v expected %v", resp.ExchangeName, testExchanges[0].Name)
+	}
+	if !resp.StartDate.Equal(start) {
+		t.Errorf("received %v expected %v", resp.StartDate, start)
+	}
+	if resp.Base != "BTC" || resp.Asset != "spot" {
+		t.Error("unexpected formatting")
+	}
+
+	// upserting the same nickname should update the existing job rather
+	// than create another
+	update := &DataHistoryJob{
+		Nickname:     "TestJob1",
+		ExchangeName: testExchanges[1].Name,
+		Asset:        "spot",
+		Base:         "btc",
+		Quote:        "usd",
+		StartDate:    start,
+		EndDate:      start.Add(time.Hour * 24),
+		Interval:     int64(time.Hour),
+		Status:       2,
+	}
+	err = Upsert(update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update.ID != jobs[1].ID {
+		t.Errorf("received %v expected %v", update.ID, jobs[1].ID)
+	}
+
+	resp, err = GetByID(jobs[1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != 2 || resp.ExchangeName != testExchanges[1].Name {
+		t.Errorf("job not updated %+v", resp)
+	}
+
+	incomplete, err := GetJobsByStatus(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(incomplete) != 3 {
+		t.Errorf("received %v expected %v", len(incomplete), 3)
+	}
+
+	all, err := GetJobsBetween(time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 5 {
+		t.Errorf("received %v expected %v", len(all), 5)
+	}
+
+	_, err = GetByNickname("does not exist")
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+func seedDB() error {
+	return exchange.InsertMany(testExchanges)
+}
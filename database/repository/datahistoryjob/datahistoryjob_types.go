@@ -0,0 +1,40 @@
+package datahistoryjob
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+var (
+	errNicknameUnset  = errors.New("data history job nickname not set, cannot upsert")
+	errExchangeUnset  = errors.New("exchange name/uuid not set, cannot upsert")
+	errNoJobsToUpsert = errors.New("no data history jobs to upsert")
+)
+
+// DataHistoryJob defines a data history job in its simplest
+// db friendly form
+type DataHistoryJob struct {
+	ID               string
+	Nickname         string
+	ExchangeID       string
+	ExchangeName     string
+	Asset            string
+	Base             string
+	Quote            string
+	StartDate        time.Time
+	EndDate          time.Time
+	Interval         int64
+	DataType         int64
+	RequestSizeLimit int64
+	MaxRetryAttempts int64
+	BatchSize        int64
+	Status           int64
+	CreatedDate      time.Time
+}
+
+// Repository reads and writes datahistoryjob records using a database instance
+type Repository struct {
+	db *database.Instance
+}
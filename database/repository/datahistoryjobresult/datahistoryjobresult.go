@@ -0,0 +1,217 @@
+package datahistoryjobresult
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
+// Upsert runs Repository.Upsert against database.DB
+func Upsert(results ...*DataHistoryJobResult) error {
+	return New(database.DB).Upsert(results...)
+}
+
+// Upsert inserts new data history job results or updates existing results
+// when an ID is already set
+func (r *Repository) Upsert(results ...*DataHistoryJobResult) error {
+	if r.db.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	if len(results) == 0 {
+		return errNoResultsToUpsert
+	}
+	for i := range results {
+		if results[i].JobID == "" {
+			return errJobIDUnset
+		}
+	}
+
+	ctx := context.Background()
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Upsert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.Dialect(r.db) == database.DBSQLite3 || repository.Dialect(r.db) == database.DBSQLite {
+		err = upsertSQLite(ctx, tx, results...)
+	} else {
+		err = upsertPostgres(ctx, tx, results...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func upsertSQLite(ctx context.Context, tx *sql.Tx, results ...*DataHistoryJobResult) error {
+	for i := range results {
+		exists := results[i].ID != ""
+		if !exists {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			results[i].ID = freshUUID.String()
+		}
+		if results[i].Date.IsZero() {
+			results[i].Date = time.Now()
+		}
+		var tempEvent = modelSQLite.Datahistoryjobresult{
+			ID:                results[i].ID,
+			JobID:             results[i].JobID,
+			Result:            results[i].Result,
+			Status:            results[i].Status,
+			IntervalStartTime: results[i].IntervalStartDate.UTC().Format(time.RFC3339),
+			IntervalEndTime:   results[i].IntervalEndDate.UTC().Format(time.RFC3339),
+			RunTime:           results[i].Date.UTC().Format(time.RFC3339),
+		}
+		var err error
+		if exists {
+			_, err = tempEvent.Update(ctx, tx, boil.Infer())
+		} else {
+			err = tempEvent.Insert(ctx, tx, boil.Infer())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func upsertPostgres(ctx context.Context, tx *sql.Tx, results ...*DataHistoryJobResult) error {
+	for i := range results {
+		if results[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			results[i].ID = freshUUID.String()
+		}
+		if results[i].Date.IsZero() {
+			results[i].Date = time.Now()
+		}
+		var tempEvent = modelPSQL.Datahistoryjobresult{
+			ID:                results[i].ID,
+			JobID:             results[i].JobID,
+			Result:            results[i].Result,
+			Status:            results[i].Status,
+			IntervalStartTime: results[i].IntervalStartDate.UTC(),
+			IntervalEndTime:   results[i].IntervalEndDate.UTC(),
+			RunTime:           results[i].Date.UTC(),
+		}
+		err := tempEvent.Upsert(ctx, tx, true, []string{"id"}, boil.Infer(), boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetByJobID runs Repository.GetByJobID against database.DB
+func GetByJobID(jobID string) ([]DataHistoryJobResult, error) {
+	return New(database.DB).GetByJobID(jobID)
+}
+
+// GetByJobID returns all results for a data history job ordered by the
+// interval they cover
+func (r *Repository) GetByJobID(jobID string) ([]DataHistoryJobResult, error) {
+	if r.db.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	mods := []qm.QueryMod{
+		qm.Where("job_id = ?", jobID),
+		qm.OrderBy("interval_start_time, run_time"),
+	}
+	if repository.Dialect(r.db) == database.DBSQLite3 || repository.Dialect(r.db) == database.DBSQLite {
+		results, err := r.getSQLite(mods...)
+		if err != nil {
+			return nil, fmt.Errorf("datahistoryjobresult.GetByJobID getSQLite %w", err)
+		}
+		return results, nil
+	}
+	results, err := r.getPostgres(mods...)
+	if err != nil {
+		return nil, fmt.Errorf("datahistoryjobresult.GetByJobID getPostgres %w", err)
+	}
+	return results, nil
+}
+
+func (r *Repository) getSQLite(mods ...qm.QueryMod) ([]DataHistoryJobResult, error) {
+	result, err := modelSQLite.Datahistoryjobresults(mods...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]DataHistoryJobResult, len(result))
+	for i := range result {
+		var start, end, ran time.Time
+		start, err = time.Parse(time.RFC3339, result[i].IntervalStartTime)
+		if err != nil {
+			return nil, err
+		}
+		end, err = time.Parse(time.RFC3339, result[i].IntervalEndTime)
+		if err != nil {
+			return nil, err
+		}
+		ran, err = time.Parse(time.RFC3339, result[i].RunTime)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = DataHistoryJobResult{
+			ID:                result[i].ID,
+			JobID:             result[i].JobID,
+			Result:            result[i].Result,
+			Status:            result[i].Status,
+			IntervalStartDate: start,
+			IntervalEndDate:   end,
+			Date:              ran,
+		}
+	}
+	return resp, nil
+}
+
+func (r *Repository) getPostgres(mods ...qm.QueryMod) ([]DataHistoryJobResult, error) {
+	result, err := modelPSQL.Datahistoryjobresults(mods...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]DataHistoryJobResult, len(result))
+	for i := range result {
+		resp[i] = DataHistoryJobResult{
+			ID:                result[i].ID,
+			JobID:             result[i].JobID,
+			Result:            result[i].Result,
+			Status:            result[i].Status,
+			IntervalStartDate: result[i].IntervalStartTime,
+			IntervalEndDate:   result[i].IntervalEndTime,
+			Date:              result[i].RunTime,
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,160 @@
+package datahistoryjobresult
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/datahistoryjob"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var (
+	verbose       = false
+	testExchanges = []exchange.Details{
+		{
+			Name: "one",
+		},
+	}
+	testJob = datahistoryjob.DataHistoryJob{
+		Nickname:     "testjob",
+		ExchangeName: "one",
+		Asset:        "spot",
+		Base:         "BTC",
+		Quote:        "USD",
+		StartDate:    time.Now().Add(-time.Hour * 24).Truncate(time.Hour),
+		EndDate:      time.Now().Truncate(time.Hour),
+		Interval:     int64(time.Hour),
+	}
+)
+
+func TestMain(m *testing.M) {
+	if verbose {
+		testhelpers.EnableVerboseTestOutput()
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	t := m.Run()
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+	os.Exit(t)
+}
+
+func TestDataHistoryJobResult(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		seedDB func() error
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+			seedDB: seedDB,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+			seedDB: seedDB,
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.seedDB != nil {
+				err = test.seedDB()
+				if err != nil {
+					t.Error(err)
+				}
+			}
+
+			resultSQLTester(t)
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func resultSQLTester(t *testing.T) {
+	err := Upsert()
+	if err != errNoResultsToUpsert {
+		t.Errorf("received %v expected %v", err, errNoResultsToUpsert)
+	}
+	err = Upsert(&DataHistoryJobResult{})
+	if err != errJobIDUnset {
+		t.Errorf("received %v expected %v", err, errJobIDUnset)
+	}
+
+	var results []*DataHistoryJobResult
+	for i := 0; i < 4; i++ {
+		start := testJob.StartDate.Add(time.Hour * time.Duration(i))
+		results = append(results, &DataHistoryJobResult{
+			JobID:             testJob.ID,
+			Result:            fmt.Sprintf("result %v", i),
+			Status:            1,
+			IntervalStartDate: start,
+			IntervalEndDate:   start.Add(time.Hour),
+		})
+	}
+	err = Upsert(results...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results[0].Status = 2
+	results[0].Result = "failed"
+	err = Upsert(results[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := GetByJobID(testJob.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 4 {
+		t.Fatalf("received %v expected %v", len(resp), 4)
+	}
+	if resp[0].ID != results[0].ID || resp[0].Status != 2 || resp[0].Result != "failed" {
+		t.Errorf("result not updated %+v", resp[0])
+	}
+	if !resp[3].IntervalStartDate.Equal(results[3].IntervalStartDate) {
+		t.Errorf("received %v expected %v", resp[3].IntervalStartDate, results[3].IntervalStartDate)
+	}
+}
+
+func seedDB() error {
+	err := exchange.InsertMany(testExchanges)
+	if err != nil {
+		return err
+	}
+	testJob.ID = ""
+	return datahistoryjob.Upsert(&testJob)
+}
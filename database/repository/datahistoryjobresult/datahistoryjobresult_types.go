@@ -0,0 +1,30 @@
+package datahistoryjobresult
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+var (
+	errJobIDUnset        = errors.New("data history job id not set, cannot upsert result")
+	errNoResultsToUpsert = errors.New("no data history job results to upsert")
+)
+
+// DataHistoryJobResult defines the outcome of a single data history job run
+// against an interval range in its simplest db friendly form
+type DataHistoryJobResult struct {
+	ID                string
+	JobID             string
+	Result            string
+	Status            int64
+	IntervalStartDate time.Time
+	IntervalEndDate   time.Time
+	Date              time.Time
+}
+
+// Repository reads and writes datahistoryjobresult records using a database instance
+type Repository struct {
+	db *database.Instance
+}
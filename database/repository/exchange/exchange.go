@@ -19,25 +19,43 @@ import (
 	"github.com/thrasher-corp/sqlboiler/queries/qm"
 )
 
-// One returns one exchange by Name
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
+// One runs Repository.One against database.DB
 func One(in string) (Details, error) {
-	return one(in, "name")
+	return New(database.DB).One(in)
 }
 
-// OneByUUID returns one exchange by UUID
+// One returns one exchange by Name
+func (r *Repository) One(in string) (Details, error) {
+	return r.one(in, "name")
+}
+
+// OneByUUID runs Repository.OneByUUID against database.DB
 func OneByUUID(in uuid.UUID) (Details, error) {
-	return one(in.String(), "id")
+	return New(database.DB).OneByUUID(in)
+}
+
+// OneByUUID returns one exchange by UUID
+func (r *Repository) OneByUUID(in uuid.UUID) (Details, error) {
+	return r.one(in.String(), "id")
 }
 
 // one returns one exchange by clause
-func one(in, clause string) (out Details, err error) {
-	if database.DB.SQL == nil {
+func (r *Repository) one(in, clause string) (out Details, err error) {
+	if r.db.SQL == nil {
 		return out, database.ErrDatabaseSupportDisabled
 	}
 
 	whereQM := qm.Where(clause+"= ?", in)
-	if repository.GetSQLDialect() == database.DBSQLite3 {
-		ret, errS := modelSQLite.Exchanges(whereQM).One(context.Background(), database.DB.SQL)
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		ret, errS := modelSQLite.Exchanges(whereQM).One(context.Background(), r.db.SQL)
 		if errS != nil {
 			return out, errS
 		}
@@ -47,7 +65,7 @@ func one(in, clause string) (out Details, err error) {
 			return out, errS
 		}
 	} else {
-		ret, errS := modelPSQL.Exchanges(whereQM).One(context.Background(), database.DB.SQL)
+		ret, errS := modelPSQL.Exchanges(whereQM).One(context.Background(), r.db.SQL)
 		if errS != nil {
 			return out, errS
 		}
@@ -61,18 +79,23 @@ func one(in, clause string) (out Details, err error) {
 	return out, err
 }
 
-// Insert writes a single entry into database
+// Insert runs Repository.Insert against database.DB
 func Insert(in Details) error {
-	if database.DB.SQL == nil {
+	return New(database.DB).Insert(in)
+}
+
+// Insert writes a single entry into database
+func (r *Repository) Insert(in Details) error {
+	if r.db.SQL == nil {
 		return database.ErrDatabaseSupportDisabled
 	}
 
 	ctx := context.Background()
-	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	if repository.GetSQLDialect() == database.DBSQLite3 {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
 		err = insertSQLite(ctx, tx, []Details{in})
 	} else {
 		err = insertPostgresql(ctx, tx, []Details{in})
@@ -94,19 +117,24 @@ func Insert(in Details) error {
 	return nil
 }
 
-// InsertMany writes multiple entries into database
+// InsertMany runs Repository.InsertMany against database.DB
 func InsertMany(in []Details) error {
-	if database.DB.SQL == nil {
+	return New(database.DB).InsertMany(in)
+}
+
+// InsertMany writes multiple entries into database
+func (r *Repository) InsertMany(in []Details) error {
+	if r.db.SQL == nil {
 		return database.ErrDatabaseSupportDisabled
 	}
 
 	ctx := context.Background()
-	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
-	if repository.GetSQLDialect() == database.DBSQLite3 {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
 		err = insertSQLite(ctx, tx, in)
 	} else {
 		err = insertPostgresql(ctx, tx, in)
@@ -173,14 +201,19 @@ func insertPostgresql(ctx context.Context, tx *sql.Tx, in []Details) (err error)
 	return nil
 }
 
-// UUIDByName returns UUID of exchange
+// UUIDByName runs Repository.UUIDByName against database.DB
 func UUIDByName(exchange string) (uuid.UUID, error) {
+	return New(database.DB).UUIDByName(exchange)
+}
+
+// UUIDByName returns UUID of exchange
+func (r *Repository) UUIDByName(exchange string) (uuid.UUID, error) {
 	exchange = strings.ToLower(exchange)
-	v := exchangeCache.Get(exchange)
+	v := r.cache().Get(exchange)
 	if v != nil {
 		return v.(uuid.UUID), nil
 	}
-	ret, err := One(exchange)
+	ret, err := r.One(exchange)
 	if err != nil {
 		if err != sql.ErrNoRows {
 			return uuid.UUID{}, err
@@ -188,13 +221,27 @@ func UUIDByName(exchange string) (uuid.UUID, error) {
 		return uuid.UUID{}, ErrNoExchangeFound
 	}
 
-	exchangeCache.Add(exchange, ret.UUID)
+	r.cache().Add(exchange, ret.UUID)
 	return ret.UUID, nil
 }
 
 // ResetExchangeCache reinitialise cache to blank state used to clear cache for testing
 func ResetExchangeCache() {
-	exchangeCache = cache.New(10)
+	exchangeCacheMtx.Lock()
+	exchangeCaches = make(map[*database.Instance]*cache.LRUCache)
+	exchangeCacheMtx.Unlock()
+}
+
+// cache returns the exchange UUID cache of the repository database instance
+func (r *Repository) cache() *cache.LRUCache {
+	exchangeCacheMtx.Lock()
+	defer exchangeCacheMtx.Unlock()
+	c, ok := exchangeCaches[r.db]
+	if !ok {
+		c = cache.New(10)
+		exchangeCaches[r.db] = c
+	}
+	return c
 }
 
 // LoadCSV loads & parses a CSV list of exchanges
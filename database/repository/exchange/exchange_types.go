@@ -2,13 +2,18 @@ package exchange
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common/cache"
+	"github.com/thrasher-corp/gocryptotrader/database"
 )
 
 var (
-	exchangeCache = cache.New(10)
+	// exchangeCaches holds the exchange UUIDs of each database instance as
+	// they differ between databases
+	exchangeCaches   = make(map[*database.Instance]*cache.LRUCache)
+	exchangeCacheMtx sync.Mutex
 	// ErrNoExchangeFound is a basic predefined error
 	ErrNoExchangeFound = errors.New("exchange not found")
 )
@@ -18,3 +23,8 @@ type Details struct {
 	UUID uuid.UUID
 	Name string
 }
+
+// Repository reads and writes exchange records using a database instance
+type Repository struct {
+	db *database.Instance
+}
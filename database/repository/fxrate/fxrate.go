@@ -0,0 +1,225 @@
+package fxrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
+// Insert runs Repository.Insert against database.DB
+func Insert(rates ...Details) error {
+	return New(database.DB).Insert(rates...)
+}
+
+// Insert saves daily rates to the database. A rate that already exists for the
+// same currencies and date is replaced
+func (r *Repository) Insert(rates ...Details) error {
+	if r.db.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	if len(rates) == 0 {
+		return errNoRatesToInsert
+	}
+	for i := range rates {
+		if rates[i].Base == "" || rates[i].Quote == "" {
+			return errCurrencyUnset
+		}
+		if rates[i].Date.IsZero() {
+			return errDateUnset
+		}
+		if rates[i].Rate <= 0 {
+			return fmt.Errorf("%s%s %w", rates[i].Base, rates[i].Quote, errInvalidRate)
+		}
+	}
+
+	ctx := context.Background()
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Insert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.Dialect(r.db) == database.DBSQLite3 || repository.Dialect(r.db) == database.DBSQLite {
+		err = insertSQLite(ctx, tx, rates...)
+	} else {
+		err = insertPostgres(ctx, tx, rates...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertSQLite(ctx context.Context, tx *sql.Tx, rates ...Details) error {
+	for i := range rates {
+		date := Day(rates[i].Date).Format(time.RFC3339)
+		base := strings.ToUpper(rates[i].Base)
+		quote := strings.ToUpper(rates[i].Quote)
+		existing, err := modelSQLite.FxRates(
+			qm.Where("base_currency = ? AND quote_currency = ? AND date = ?", base, quote, date)).One(ctx, tx)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if existing != nil {
+			rates[i].ID = existing.ID
+		} else {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			rates[i].ID = freshUUID.String()
+		}
+		var tempEvent = modelSQLite.FxRate{
+			ID:            rates[i].ID,
+			BaseCurrency:  base,
+			QuoteCurrency: quote,
+			Rate:          rates[i].Rate,
+			Provider:      rates[i].Provider,
+			Date:          date,
+		}
+		if existing != nil {
+			_, err = tempEvent.Update(ctx, tx, boil.Infer())
+		} else {
+			err = tempEvent.Insert(ctx, tx, boil.Infer())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertPostgres(ctx context.Context, tx *sql.Tx, rates ...Details) error {
+	for i := range rates {
+		var tempEvent = modelPSQL.FxRate{
+			BaseCurrency:  strings.ToUpper(rates[i].Base),
+			QuoteCurrency: strings.ToUpper(rates[i].Quote),
+			Rate:          rates[i].Rate,
+			Provider:      rates[i].Provider,
+			Date:          Day(rates[i].Date),
+		}
+		err := tempEvent.Upsert(ctx, tx, true,
+			[]string{"base_currency", "quote_currency", "date"},
+			boil.Whitelist("rate", "provider"),
+			boil.Infer())
+		if err != nil {
+			return err
+		}
+		rates[i].ID = tempEvent.ID
+	}
+	return nil
+}
+
+// GetRates runs Repository.GetRates against database.DB
+func GetRates(date time.Time, base string, quotes ...string) ([]Details, error) {
+	return New(database.DB).GetRates(date, base, quotes...)
+}
+
+// GetRates returns the stored rates from a base currency on the supplied
+// day. No quote currencies returns every stored rate for the base
+func (r *Repository) GetRates(date time.Time, base string, quotes ...string) ([]Details, error) {
+	if r.db.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	mods := []qm.QueryMod{qm.Where("base_currency = ?", strings.ToUpper(base))}
+	if len(quotes) > 0 {
+		in := make([]interface{}, len(quotes))
+		for i := range quotes {
+			in[i] = strings.ToUpper(quotes[i])
+		}
+		mods = append(mods, qm.WhereIn("quote_currency IN ?", in...))
+	}
+	if repository.Dialect(r.db) == database.DBSQLite3 || repository.Dialect(r.db) == database.DBSQLite {
+		mods = append(mods, qm.Where("date = ?", Day(date).Format(time.RFC3339)))
+		rates, err := r.getSQLite(mods...)
+		if err != nil {
+			return nil, fmt.Errorf("fxrate.GetRates getSQLite %w", err)
+		}
+		return rates, nil
+	}
+	mods = append(mods, qm.Where("date = ?", Day(date)))
+	rates, err := r.getPostgres(mods...)
+	if err != nil {
+		return nil, fmt.Errorf("fxrate.GetRates getPostgres %w", err)
+	}
+	return rates, nil
+}
+
+// Day returns the UTC day a time falls on, which is how rates are keyed
+func Day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (r *Repository) getSQLite(mods ...qm.QueryMod) ([]Details, error) {
+	mods = append(mods, qm.OrderBy("quote_currency"))
+	result, err := modelSQLite.FxRates(mods...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Details, len(result))
+	for i := range result {
+		var date time.Time
+		date, err = time.Parse(time.RFC3339, result[i].Date)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = Details{
+			ID:       result[i].ID,
+			Base:     result[i].BaseCurrency,
+			Quote:    result[i].QuoteCurrency,
+			Rate:     result[i].Rate,
+			Provider: result[i].Provider,
+			Date:     date,
+		}
+	}
+	return resp, nil
+}
+
+func (r *Repository) getPostgres(mods ...qm.QueryMod) ([]Details, error) {
+	mods = append(mods, qm.OrderBy("quote_currency"))
+	result, err := modelPSQL.FxRates(mods...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Details, len(result))
+	for i := range result {
+		resp[i] = Details{
+			ID:       result[i].ID,
+			Base:     result[i].BaseCurrency,
+			Quote:    result[i].QuoteCurrency,
+			Rate:     result[i].Rate,
+			Provider: result[i].Provider,
+			Date:     result[i].Date.UTC(),
+		}
+	}
+	return resp, nil
+}
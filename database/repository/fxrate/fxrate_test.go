@@ -0,0 +1,146 @@
+package fxrate
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		testhelpers.EnableVerboseTestOutput()
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	t := m.Run()
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+	os.Exit(t)
+}
+
+func TestFXRate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fxRateSQLTester(t)
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func fxRateSQLTester(t *testing.T) {
+	err := Insert()
+	if err != errNoRatesToInsert {
+		t.Errorf("received %v expected %v", err, errNoRatesToInsert)
+	}
+	err = Insert(Details{Base: "usd", Rate: 1})
+	if err != errCurrencyUnset {
+		t.Errorf("received %v expected %v", err, errCurrencyUnset)
+	}
+	err = Insert(Details{Base: "usd", Quote: "aud", Rate: 1})
+	if err != errDateUnset {
+		t.Errorf("received %v expected %v", err, errDateUnset)
+	}
+	err = Insert(Details{Base: "usd", Quote: "aud", Date: time.Now()})
+	if !errors.Is(err, errInvalidRate) {
+		t.Errorf("received %v expected %v", err, errInvalidRate)
+	}
+
+	date := time.Date(2020, 3, 14, 15, 9, 26, 0, time.UTC)
+	err = Insert(Details{Base: "usd", Quote: "aud", Rate: 1.6, Provider: "Fixer", Date: date},
+		Details{Base: "usd", Quote: "eur", Rate: 0.9, Provider: "Fixer", Date: date},
+		Details{Base: "usd", Quote: "aud", Rate: 1.5, Provider: "Fixer", Date: date.AddDate(0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a rate for the same day replaces the stored rate
+	err = Insert(Details{Base: "USD", Quote: "AUD", Rate: 1.65, Provider: "Offline", Date: date.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := GetRates(date, "usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 2 {
+		t.Fatalf("received %v expected %v", len(resp), 2)
+	}
+	if resp[0].Quote != "AUD" || resp[0].Rate != 1.65 || resp[0].Provider != "Offline" {
+		t.Errorf("unexpected rate %+v", resp[0])
+	}
+	if !resp[0].Date.Equal(Day(date)) {
+		t.Errorf("received %v expected %v", resp[0].Date, Day(date))
+	}
+
+	resp, err = GetRates(date, "USD", "eur")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 1 || resp[0].Rate != 0.9 {
+		t.Errorf("unexpected rates %+v", resp)
+	}
+
+	resp, err = GetRates(date.AddDate(0, 0, 2), "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 0 {
+		t.Errorf("received %v expected %v", len(resp), 0)
+	}
+}
+
+func TestDay(t *testing.T) {
+	loc := time.FixedZone("test", 10*60*60)
+	d := Day(time.Date(2020, 1, 2, 5, 0, 0, 0, loc))
+	if !d.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("received %v expected %v", d, "2020-01-01")
+	}
+}
@@ -0,0 +1,31 @@
+package fxrate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+var (
+	errNoRatesToInsert = errors.New("no fx rates to insert")
+	errCurrencyUnset   = errors.New("fx rate currency not set")
+	errDateUnset       = errors.New("fx rate date not set")
+	errInvalidRate     = errors.New("fx rate must be greater than zero")
+)
+
+// Details defines a daily foreign exchange rate in its simplest db friendly
+// form. Dates are stored as midnight UTC
+type Details struct {
+	ID       string
+	Base     string
+	Quote    string
+	Rate     float64
+	Provider string
+	Date     time.Time
+}
+
+// Repository reads and writes fxrate records using a database instance
+type Repository struct {
+	db *database.Instance
+}
@@ -6,7 +6,15 @@ import (
 
 // GetSQLDialect returns current SQL Dialect based on enabled driver
 func GetSQLDialect() string {
-	switch database.DB.Config.Driver {
+	return Dialect(database.DB)
+}
+
+// Dialect returns the SQL dialect of the driver configured for db
+func Dialect(db *database.Instance) string {
+	if db == nil || db.Config == nil {
+		return "invalid driver"
+	}
+	switch db.Config.Driver {
 	case "sqlite", "sqlite3":
 		return database.DBSQLite3
 	case "psql", "postgres", "postgresql":
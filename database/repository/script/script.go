@@ -14,21 +14,39 @@ import (
 	"github.com/volatiletech/null"
 )
 
-// Event inserts a new script event into database with execution details (script name time status hash of script)
+// Repository reads and writes script records using a database instance
+type Repository struct {
+	db *database.Instance
+}
+
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
+// Event runs Repository.Event against database.DB
 func Event(id, name, path string, data null.Bytes, executionType, status string, time time.Time) {
-	if database.DB.SQL == nil {
+	New(database.DB).Event(id, name, path, data, executionType, status, time)
+}
+
+// Event inserts a new script event into database with execution details (script name time status hash of script)
+func (r *Repository) Event(id, name, path string, data null.Bytes, executionType, status string, time time.Time) {
+	if r.db.SQL == nil {
 		return
 	}
 
 	ctx := context.Background()
 	ctx = boil.SkipTimestamps(ctx)
-	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
 	if err != nil {
 		log.Errorf(log.DatabaseMgr, "Event transaction begin failed: %v", err)
 		return
 	}
 
-	if repository.GetSQLDialect() == database.DBSQLite3 {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
 		query := modelSQLite.ScriptWhere.ScriptID.EQ(id)
 		f, errQry := modelSQLite.Scripts(query).Exists(ctx, tx)
 		if errQry != nil {
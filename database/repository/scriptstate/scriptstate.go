@@ -0,0 +1,254 @@
+package scriptstate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
+// Get runs Repository.Get against database.DB
+func Get(script, key string) (*Details, error) {
+	return New(database.DB).Get(script, key)
+}
+
+// Get returns the value stored for a scripts key
+func (r *Repository) Get(script, key string) (*Details, error) {
+	if r.db.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if script == "" {
+		return nil, errScriptNameUnset
+	}
+	if key == "" {
+		return nil, errKeyUnset
+	}
+	var results []Details
+	var err error
+	if r.isSQLite() {
+		results, err = r.getSQLite(modelSQLite.ScriptStateWhere.ScriptName.EQ(script),
+			modelSQLite.ScriptStateWhere.Key.EQ(key))
+	} else {
+		results, err = r.getPostgres(modelPSQL.ScriptStateWhere.ScriptName.EQ(script),
+			modelPSQL.ScriptStateWhere.Key.EQ(key))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("%s %s %w", script, key, ErrKeyNotFound)
+	}
+	return &results[0], nil
+}
+
+// List runs Repository.List against database.DB
+func List(script string) ([]Details, error) {
+	return New(database.DB).List(script)
+}
+
+// List returns every value stored for a script ordered by key
+func (r *Repository) List(script string) ([]Details, error) {
+	if r.db.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if script == "" {
+		return nil, errScriptNameUnset
+	}
+	if r.isSQLite() {
+		return r.getSQLite(modelSQLite.ScriptStateWhere.ScriptName.EQ(script))
+	}
+	return r.getPostgres(modelPSQL.ScriptStateWhere.ScriptName.EQ(script))
+}
+
+// Set runs Repository.Set against database.DB
+func Set(script, key, value string) error {
+	return New(database.DB).Set(script, key, value)
+}
+
+// Set stores a value for a scripts key, replacing any existing value
+func (r *Repository) Set(script, key, value string) error {
+	if r.db.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	if script == "" {
+		return errScriptNameUnset
+	}
+	if key == "" {
+		return errKeyUnset
+	}
+
+	ctx := context.Background()
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Set tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if r.isSQLite() {
+		err = setSQLite(ctx, tx, script, key, value)
+	} else {
+		err = setPostgres(ctx, tx, script, key, value)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete runs Repository.Delete against database.DB
+func Delete(script, key string) error {
+	return New(database.DB).Delete(script, key)
+}
+
+// Delete removes a scripts key, deleting a key that does not exist is not an
+// error
+func (r *Repository) Delete(script, key string) error {
+	if key == "" {
+		return errKeyUnset
+	}
+	_, err := r.deleteWhere(script, key)
+	return err
+}
+
+// Clear runs Repository.Clear against database.DB
+func Clear(script string) (int64, error) {
+	return New(database.DB).Clear(script)
+}
+
+// Clear removes every value stored for a script returning the amount removed
+func (r *Repository) Clear(script string) (int64, error) {
+	return r.deleteWhere(script, "")
+}
+
+func (r *Repository) deleteWhere(script, key string) (int64, error) {
+	if r.db.SQL == nil {
+		return 0, database.ErrDatabaseSupportDisabled
+	}
+	if script == "" {
+		return 0, errScriptNameUnset
+	}
+	ctx := context.Background()
+	if r.isSQLite() {
+		mods := []qm.QueryMod{modelSQLite.ScriptStateWhere.ScriptName.EQ(script)}
+		if key != "" {
+			mods = append(mods, modelSQLite.ScriptStateWhere.Key.EQ(key))
+		}
+		return modelSQLite.ScriptStates(mods...).DeleteAll(ctx, r.db.SQL)
+	}
+	mods := []qm.QueryMod{modelPSQL.ScriptStateWhere.ScriptName.EQ(script)}
+	if key != "" {
+		mods = append(mods, modelPSQL.ScriptStateWhere.Key.EQ(key))
+	}
+	return modelPSQL.ScriptStates(mods...).DeleteAll(ctx, r.db.SQL)
+}
+
+func (r *Repository) isSQLite() bool {
+	return repository.Dialect(r.db) == database.DBSQLite3 || repository.Dialect(r.db) == database.DBSQLite
+}
+
+func setSQLite(ctx context.Context, tx *sql.Tx, script, key, value string) error {
+	existing, err := modelSQLite.ScriptStates(
+		modelSQLite.ScriptStateWhere.ScriptName.EQ(script),
+		modelSQLite.ScriptStateWhere.Key.EQ(key)).One(ctx, tx)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	updated := time.Now().UTC().Format(time.RFC3339)
+	if existing != nil {
+		existing.Value = value
+		existing.UpdatedAt = updated
+		_, err = existing.Update(ctx, tx, boil.Whitelist("value", "updated_at"))
+		return err
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+	tempState := modelSQLite.ScriptState{
+		ID:         id.String(),
+		ScriptName: script,
+		Key:        key,
+		Value:      value,
+		UpdatedAt:  updated,
+	}
+	return tempState.Insert(ctx, tx, boil.Infer())
+}
+
+func setPostgres(ctx context.Context, tx *sql.Tx, script, key, value string) error {
+	tempState := modelPSQL.ScriptState{
+		ScriptName: script,
+		Key:        key,
+		Value:      value,
+		UpdatedAt:  time.Now().UTC(),
+	}
+	return tempState.Upsert(ctx, tx, true,
+		[]string{"script_name", "key"},
+		boil.Whitelist("value", "updated_at"),
+		boil.Infer())
+}
+
+func (r *Repository) getSQLite(mods ...qm.QueryMod) ([]Details, error) {
+	mods = append(mods, qm.OrderBy("key"))
+	result, err := modelSQLite.ScriptStates(mods...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Details, len(result))
+	for i := range result {
+		var updated time.Time
+		updated, err = time.Parse(time.RFC3339, result[i].UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = Details{
+			Script:    result[i].ScriptName,
+			Key:       result[i].Key,
+			Value:     result[i].Value,
+			UpdatedAt: updated,
+		}
+	}
+	return resp, nil
+}
+
+func (r *Repository) getPostgres(mods ...qm.QueryMod) ([]Details, error) {
+	mods = append(mods, qm.OrderBy("key"))
+	result, err := modelPSQL.ScriptStates(mods...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Details, len(result))
+	for i := range result {
+		resp[i] = Details{
+			Script:    result[i].ScriptName,
+			Key:       result[i].Key,
+			Value:     result[i].Value,
+			UpdatedAt: result[i].UpdatedAt.UTC(),
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,148 @@
+package scriptstate
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		testhelpers.EnableVerboseTestOutput()
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = ioutil.TempDir("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	t := m.Run()
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+	os.Exit(t)
+}
+
+func TestScriptState(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			scriptStateSQLTester(t)
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func scriptStateSQLTester(t *testing.T) {
+	err := Set("", "key", "1")
+	if err != errScriptNameUnset {
+		t.Errorf("received %v expected %v", err, errScriptNameUnset)
+	}
+	err = Set("test.gct", "", "1")
+	if err != errKeyUnset {
+		t.Errorf("received %v expected %v", err, errKeyUnset)
+	}
+
+	err = Set("test.gct", "signal", `"buy"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Set("test.gct", "position", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Set("other.gct", "position", "5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// setting an existing key replaces its value
+	err = Set("test.gct", "position", "2.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := Get("test.gct", "position")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Value != "2.5" || resp.UpdatedAt.IsZero() {
+		t.Errorf("unexpected value %+v", resp)
+	}
+
+	_, err = Get("test.gct", "missing")
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("received %v expected %v", err, ErrKeyNotFound)
+	}
+
+	list, err := List("test.gct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Key != "position" || list[1].Key != "signal" {
+		t.Errorf("unexpected state %+v", list)
+	}
+
+	err = Delete("test.gct", "signal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Get("test.gct", "signal")
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("received %v expected %v", err, ErrKeyNotFound)
+	}
+
+	removed, err := Clear("test.gct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("received %v expected %v", removed, 1)
+	}
+	list, err = List("other.gct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 {
+		t.Error("expected other scripts state to remain")
+	}
+}
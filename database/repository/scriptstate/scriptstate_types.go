@@ -0,0 +1,30 @@
+package scriptstate
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+var (
+	// ErrKeyNotFound is returned when a script has no value stored for a key
+	ErrKeyNotFound = errors.New("script state key not found")
+
+	errScriptNameUnset = errors.New("script name not set")
+	errKeyUnset        = errors.New("script state key not set")
+)
+
+// Details defines a single stored value for a script, values are JSON
+// encoded by the caller
+type Details struct {
+	Script    string
+	Key       string
+	Value     string
+	UpdatedAt time.Time
+}
+
+// Repository reads and writes scriptstate records using a database instance
+type Repository struct {
+	db *database.Instance
+}
@@ -19,11 +19,24 @@ import (
 	"github.com/thrasher-corp/sqlboiler/queries/qm"
 )
 
-// Insert saves trade data to the database
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
+// Insert runs Repository.Insert against database.DB
 func Insert(trades ...Data) error {
+	return New(database.DB).Insert(trades...)
+}
+
+// Insert saves trade data to the database
+func (r *Repository) Insert(trades ...Data) error {
 	for i := range trades {
 		if trades[i].ExchangeNameID == "" && trades[i].Exchange != "" {
-			exchangeUUID, err := exchange.UUIDByName(trades[i].Exchange)
+			exchangeUUID, err := exchange.New(r.db).UUIDByName(trades[i].Exchange)
 			if err != nil {
 				return err
 			}
@@ -36,7 +49,7 @@ func Insert(trades ...Data) error {
 	ctx := context.Background()
 	ctx = boil.SkipTimestamps(ctx)
 
-	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("beginTx %w", err)
 	}
@@ -49,7 +62,7 @@ func Insert(trades ...Data) error {
 		}
 	}()
 
-	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
 		err = insertSQLite(ctx, tx, trades...)
 	} else {
 		err = insertPostgres(ctx, tx, trades...)
@@ -132,15 +145,20 @@ func insertPostgres(ctx context.Context, tx *sql.Tx, trades ...Data) error {
 	return nil
 }
 
-// GetByUUID returns a trade by its unique ID
+// GetByUUID runs Repository.GetByUUID against database.DB
 func GetByUUID(uuid string) (td Data, err error) {
-	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
-		td, err = getByUUIDSQLite(uuid)
+	return New(database.DB).GetByUUID(uuid)
+}
+
+// GetByUUID returns a trade by its unique ID
+func (r *Repository) GetByUUID(uuid string) (td Data, err error) {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		td, err = r.getByUUIDSQLite(uuid)
 		if err != nil {
 			return td, fmt.Errorf("trade.Get getByUUIDSQLite %w", err)
 		}
 	} else {
-		td, err = getByUUIDPostgres(uuid)
+		td, err = r.getByUUIDPostgres(uuid)
 		if err != nil {
 			return td, fmt.Errorf("trade.Get getByUUIDPostgres %w", err)
 		}
@@ -149,11 +167,11 @@ func GetByUUID(uuid string) (td Data, err error) {
 	return td, nil
 }
 
-func getByUUIDSQLite(uuid string) (Data, error) {
+func (r *Repository) getByUUIDSQLite(uuid string) (Data, error) {
 	var td Data
 	var ts time.Time
 	query := modelSQLite.Trades(qm.Where("id = ?", uuid))
-	result, err := query.One(context.Background(), database.DB.SQL)
+	result, err := query.One(context.Background(), r.db.SQL)
 	if err != nil {
 		return td, err
 	}
@@ -178,10 +196,10 @@ func getByUUIDSQLite(uuid string) (Data, error) {
 	return td, nil
 }
 
-func getByUUIDPostgres(uuid string) (td Data, err error) {
+func (r *Repository) getByUUIDPostgres(uuid string) (td Data, err error) {
 	query := modelPSQL.Trades(qm.Where("id = ?", uuid))
 	var result *modelPSQL.Trade
-	result, err = query.One(context.Background(), database.DB.SQL)
+	result, err = query.One(context.Background(), r.db.SQL)
 	if err != nil {
 		return td, err
 	}
@@ -202,15 +220,20 @@ func getByUUIDPostgres(uuid string) (td Data, err error) {
 	return td, nil
 }
 
-// GetInRange returns all trades by an exchange in a date range
+// GetInRange runs Repository.GetInRange against database.DB
 func GetInRange(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (td []Data, err error) {
-	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
-		td, err = getInRangeSQLite(exchangeName, assetType, base, quote, startDate, endDate)
+	return New(database.DB).GetInRange(exchangeName, assetType, base, quote, startDate, endDate)
+}
+
+// GetInRange returns all trades by an exchange in a date range
+func (r *Repository) GetInRange(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (td []Data, err error) {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		td, err = r.getInRangeSQLite(exchangeName, assetType, base, quote, startDate, endDate)
 		if err != nil {
 			return td, fmt.Errorf("trade.GetByExchangeInRange getInRangeSQLite %w", err)
 		}
 	} else {
-		td, err = getInRangePostgres(exchangeName, assetType, base, quote, startDate, endDate)
+		td, err = r.getInRangePostgres(exchangeName, assetType, base, quote, startDate, endDate)
 		if err != nil {
 			return td, fmt.Errorf("trade.GetByExchangeInRange getInRangePostgres %w", err)
 		}
@@ -219,9 +242,9 @@ func GetInRange(exchangeName, assetType, base, quote string, startDate, endDate
 	return td, nil
 }
 
-func getInRangeSQLite(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (td []Data, err error) {
+func (r *Repository) getInRangeSQLite(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (td []Data, err error) {
 	var exchangeUUID uuid.UUID
-	exchangeUUID, err = exchange.UUIDByName(exchangeName)
+	exchangeUUID, err = exchange.New(r.db).UUIDByName(exchangeName)
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +257,7 @@ func getInRangeSQLite(exchangeName, assetType, base, quote string, startDate, en
 	q := generateQuery(wheres, startDate, endDate)
 	query := modelSQLite.Trades(q...)
 	var result []*modelSQLite.Trade
-	result, err = query.All(context.Background(), database.DB.SQL)
+	result, err = query.All(context.Background(), r.db.SQL)
 	if err != nil {
 		return td, err
 	}
@@ -261,9 +284,9 @@ func getInRangeSQLite(exchangeName, assetType, base, quote string, startDate, en
 	return td, nil
 }
 
-func getInRangePostgres(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (td []Data, err error) {
+func (r *Repository) getInRangePostgres(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (td []Data, err error) {
 	var exchangeUUID uuid.UUID
-	exchangeUUID, err = exchange.UUIDByName(exchangeName)
+	exchangeUUID, err = exchange.New(r.db).UUIDByName(exchangeName)
 	if err != nil {
 		return nil, err
 	}
@@ -276,7 +299,7 @@ func getInRangePostgres(exchangeName, assetType, base, quote string, startDate,
 	q := generateQuery(wheres, startDate, endDate)
 	query := modelPSQL.Trades(q...)
 	var result []*modelPSQL.Trade
-	result, err = query.All(context.Background(), database.DB.SQL)
+	result, err = query.All(context.Background(), r.db.SQL)
 	if err != nil {
 		return td, err
 	}
@@ -299,12 +322,194 @@ func getInRangePostgres(exchangeName, assetType, base, quote string, startDate,
 	return td, nil
 }
 
-// DeleteTrades will remove trades from the database using trade.Data
+// GetIntervalsWithTrades runs Repository.GetIntervalsWithTrades against
+// database.DB
+func GetIntervalsWithTrades(exchangeName, assetType, base, quote string, startDate, endDate time.Time, interval time.Duration) ([]time.Time, error) {
+	return New(database.DB).GetIntervalsWithTrades(exchangeName, assetType, base, quote, startDate, endDate, interval)
+}
+
+// GetIntervalsWithTrades returns the start of every interval between the start
+// and end dates which holds at least one trade. Intervals are counted from the
+// start date and grouped by the database so the trades themselves are not
+// loaded
+func (r *Repository) GetIntervalsWithTrades(exchangeName, assetType, base, quote string, startDate, endDate time.Time, interval time.Duration) ([]time.Time, error) {
+	seconds := int64(interval / time.Second)
+	if seconds <= 0 {
+		return nil, errInvalidInterval
+	}
+	exchangeUUID, err := exchange.New(r.db).UUIDByName(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	var query string
+	var args []interface{}
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		query = `SELECT DISTINCT (CAST(strftime('%s', timestamp) AS INTEGER) - ?) / ? FROM trade
+			WHERE exchange_name_id = ? AND asset = ? AND base = ? AND quote = ? AND timestamp BETWEEN ? AND ?`
+		args = []interface{}{
+			startDate.Unix(),
+			seconds,
+			exchangeUUID.String(),
+			strings.ToLower(assetType),
+			strings.ToUpper(base),
+			strings.ToUpper(quote),
+			startDate.UTC().Format(time.RFC3339),
+			endDate.UTC().Format(time.RFC3339),
+		}
+	} else {
+		query = `SELECT DISTINCT FLOOR((EXTRACT(EPOCH FROM timestamp) - $1) / $2) FROM trade
+			WHERE exchange_name_id = $3 AND asset = $4 AND base = $5 AND quote = $6 AND timestamp BETWEEN $7 AND $8`
+		args = []interface{}{
+			startDate.Unix(),
+			seconds,
+			exchangeUUID.String(),
+			strings.ToLower(assetType),
+			strings.ToUpper(base),
+			strings.ToUpper(quote),
+			startDate.UTC(),
+			endDate.UTC(),
+		}
+	}
+	rows, err := r.db.SQL.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []time.Time
+	for rows.Next() {
+		var bucket float64
+		if err = rows.Scan(&bucket); err != nil {
+			return nil, err
+		}
+		resp = append(resp, startDate.Add(time.Duration(bucket)*interval))
+	}
+	return resp, rows.Err()
+}
+
+// GetOldestTimestamp runs Repository.GetOldestTimestamp against database.DB
+func GetOldestTimestamp(exchangeName, assetType string) (time.Time, error) {
+	return New(database.DB).GetOldestTimestamp(exchangeName, assetType)
+}
+
+// GetOldestTimestamp returns the time of the oldest trade stored for an
+// exchange asset across all currency pairs
+func (r *Repository) GetOldestTimestamp(exchangeName, assetType string) (time.Time, error) {
+	exchangeUUID, err := exchange.New(r.db).UUIDByName(exchangeName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	q := []qm.QueryMod{
+		qm.Where("exchange_name_id = ?", exchangeUUID),
+		qm.Where("asset = ?", strings.ToLower(assetType)),
+		qm.OrderBy("timestamp asc"),
+	}
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		result, err := modelSQLite.Trades(q...).One(context.Background(), r.db.SQL)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return time.Time{}, ErrNoTradesFound
+			}
+			return time.Time{}, err
+		}
+		return time.Parse(time.RFC3339, result.Timestamp)
+	}
+	result, err := modelPSQL.Trades(q...).One(context.Background(), r.db.SQL)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, ErrNoTradesFound
+		}
+		return time.Time{}, err
+	}
+	return result.Timestamp.UTC(), nil
+}
+
+// GetByExchangeInRange runs Repository.GetByExchangeInRange against database.DB
+func GetByExchangeInRange(exchangeName, assetType string, startDate, endDate time.Time) (td []Data, err error) {
+	return New(database.DB).GetByExchangeInRange(exchangeName, assetType, startDate, endDate)
+}
+
+// GetByExchangeInRange returns the trades of every currency pair for an
+// exchange asset in a date range ordered by time
+func (r *Repository) GetByExchangeInRange(exchangeName, assetType string, startDate, endDate time.Time) (td []Data, err error) {
+	var exchangeUUID uuid.UUID
+	exchangeUUID, err = exchange.New(r.db).UUIDByName(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	wheres := map[string]interface{}{
+		"exchange_name_id": exchangeUUID,
+		"asset":            strings.ToLower(assetType),
+	}
+	q := append(generateQuery(wheres, startDate, endDate), qm.OrderBy("timestamp asc"))
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		var result []*modelSQLite.Trade
+		result, err = modelSQLite.Trades(q...).All(context.Background(), r.db.SQL)
+		if err != nil {
+			return nil, fmt.Errorf("trade.GetByExchangeInRange %w", err)
+		}
+		for i := range result {
+			ts, err := time.Parse(time.RFC3339, result[i].Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			t := Data{
+				ID:        result[i].ID,
+				Timestamp: ts,
+				Exchange:  strings.ToLower(exchangeName),
+				Base:      strings.ToUpper(result[i].Base),
+				Quote:     strings.ToUpper(result[i].Quote),
+				AssetType: strings.ToLower(result[i].Asset),
+				Price:     result[i].Price,
+				Amount:    result[i].Amount,
+			}
+			if result[i].Side.Valid {
+				t.Side = result[i].Side.String
+			}
+			if result[i].Tid.Valid {
+				t.TID = result[i].Tid.String
+			}
+			td = append(td, t)
+		}
+		return td, nil
+	}
+	var result []*modelPSQL.Trade
+	result, err = modelPSQL.Trades(q...).All(context.Background(), r.db.SQL)
+	if err != nil {
+		return nil, fmt.Errorf("trade.GetByExchangeInRange %w", err)
+	}
+	for i := range result {
+		t := Data{
+			ID:        result[i].ID,
+			Timestamp: result[i].Timestamp.UTC(),
+			Exchange:  strings.ToLower(exchangeName),
+			Base:      strings.ToUpper(result[i].Base),
+			Quote:     strings.ToUpper(result[i].Quote),
+			AssetType: strings.ToLower(result[i].Asset),
+			Price:     result[i].Price,
+			Amount:    result[i].Amount,
+		}
+		if result[i].Side.Valid {
+			t.Side = result[i].Side.String
+		}
+		if result[i].Tid.Valid {
+			t.TID = result[i].Tid.String
+		}
+		td = append(td, t)
+	}
+	return td, nil
+}
+
+// DeleteTrades runs Repository.DeleteTrades against database.DB
 func DeleteTrades(trades ...Data) error {
+	return New(database.DB).DeleteTrades(trades...)
+}
+
+// DeleteTrades will remove trades from the database using trade.Data
+func (r *Repository) DeleteTrades(trades ...Data) error {
 	ctx := context.Background()
 	ctx = boil.SkipTimestamps(ctx)
 
-	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("beginTx %w", err)
 	}
@@ -316,7 +521,7 @@ func DeleteTrades(trades ...Data) error {
 			}
 		}
 	}()
-	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
 		err = deleteTradesSQLite(context.Background(), tx, trades...)
 	} else {
 		err = deleteTradesPostgres(context.Background(), tx, trades...)
@@ -1,6 +1,7 @@
 package trade
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -166,6 +167,42 @@ func tradeSQLTester(t *testing.T) {
 		t.Error("Bad get!")
 	}
 
+	oldest, err := GetOldestTimestamp(testExchanges[0].Name, asset.Spot.String())
+	if err != nil {
+		t.Error(err)
+	}
+	if oldest.After(time.Now()) || oldest.Before(time.Now().Add(-time.Hour)) {
+		t.Errorf("unexpected oldest trade time %v", oldest)
+	}
+
+	v, err = GetByExchangeInRange(
+		testExchanges[0].Name,
+		asset.Spot.String(),
+		time.Now().Add(-time.Hour),
+		time.Now().Add(time.Hour))
+	if err != nil {
+		t.Error(err)
+	}
+	if len(v) != 20 {
+		t.Errorf("received %v expected %v", len(v), 20)
+	}
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	intervals, err := GetIntervalsWithTrades(
+		testExchanges[0].Name,
+		asset.Spot.String(),
+		currency.BTC.String(),
+		currency.USD.String(),
+		start,
+		time.Now().Add(time.Hour),
+		time.Hour*2)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(intervals) != 1 || !intervals[0].Equal(start) {
+		t.Errorf("received %v expected a single interval starting %v", intervals, start)
+	}
+
 	err = DeleteTrades(trades...)
 	if err != nil {
 		t.Error(err)
@@ -189,6 +226,11 @@ func tradeSQLTester(t *testing.T) {
 	if len(v) != 0 {
 		t.Errorf("should all be ded %v", v)
 	}
+
+	_, err = GetOldestTimestamp(testExchanges[0].Name, asset.Spot.String())
+	if !errors.Is(err, ErrNoTradesFound) {
+		t.Errorf("received %v expected %v", err, ErrNoTradesFound)
+	}
 }
 
 func seedDB() error {
@@ -1,6 +1,16 @@
 package trade
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+// ErrNoTradesFound is returned when an exchange asset has no stored trades
+var ErrNoTradesFound = errors.New("no trades found")
+
+var errInvalidInterval = errors.New("interval must be at least one second")
 
 // Data defines trade data in its simplest
 // db friendly form
@@ -17,3 +27,8 @@ type Data struct {
 	Side           string
 	Timestamp      time.Time
 }
+
+// Repository reads and writes trade records using a database instance
+type Repository struct {
+	db *database.Instance
+}
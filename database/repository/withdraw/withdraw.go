@@ -19,34 +19,52 @@ import (
 	"github.com/thrasher-corp/sqlboiler/queries/qm"
 )
 
+// Repository reads and writes withdraw records using a database instance
+type Repository struct {
+	db *database.Instance
+}
+
+// New returns a Repository using db, database.DB is used when db is nil
+func New(db *database.Instance) *Repository {
+	if db == nil {
+		db = database.DB
+	}
+	return &Repository{db: db}
+}
+
 var (
 	// ErrNoResults is the error returned if no results are found
 	ErrNoResults = errors.New("no results found")
 )
 
-// Event stores Withdrawal Response details in database
+// Event runs Repository.Event against database.DB
 func Event(res *withdraw.Response) {
-	if database.DB.SQL == nil {
+	New(database.DB).Event(res)
+}
+
+// Event stores Withdrawal Response details in database
+func (r *Repository) Event(res *withdraw.Response) {
+	if r.db.SQL == nil {
 		return
 	}
 
 	ctx := context.Background()
 	ctx = boil.SkipTimestamps(ctx)
 
-	exchangeUUID, err := exchangeDB.UUIDByName(res.Exchange.Name)
+	exchangeUUID, err := exchangeDB.New(r.db).UUIDByName(res.Exchange.Name)
 	if err != nil {
 		log.Error(log.DatabaseMgr, err)
 		return
 	}
 
 	res.Exchange.Name = exchangeUUID.String()
-	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	tx, err := r.db.SQL.BeginTx(ctx, nil)
 	if err != nil {
 		log.Errorf(log.DatabaseMgr, "Event transaction being failed: %v", err)
 		return
 	}
 
-	if repository.GetSQLDialect() == database.DBSQLite3 {
+	if repository.Dialect(r.db) == database.DBSQLite3 {
 		err = addSQLiteEvent(ctx, tx, res)
 	} else {
 		err = addPSQLEvent(ctx, tx, res)
@@ -219,51 +237,71 @@ func addSQLiteEvent(ctx context.Context, tx *sql.Tx, res *withdraw.Response) (er
 	return nil
 }
 
-// GetEventByUUID return requested withdraw information by ID
+// GetEventByUUID runs Repository.GetEventByUUID against database.DB
 func GetEventByUUID(id string) (*withdraw.Response, error) {
-	resp, err := getByColumns(generateWhereQuery([]string{"id"}, []string{id}, 1))
+	return New(database.DB).GetEventByUUID(id)
+}
+
+// GetEventByUUID return requested withdraw information by ID
+func (r *Repository) GetEventByUUID(id string) (*withdraw.Response, error) {
+	resp, err := r.getByColumns(generateWhereQuery([]string{"id"}, []string{id}, 1))
 	if err != nil {
 		return nil, err
 	}
 	return resp[0], nil
 }
 
-// GetEventsByExchange returns all withdrawal requests by exchange
+// GetEventsByExchange runs Repository.GetEventsByExchange against database.DB
 func GetEventsByExchange(exchange string, limit int) ([]*withdraw.Response, error) {
-	exch, err := exchangeDB.UUIDByName(exchange)
+	return New(database.DB).GetEventsByExchange(exchange, limit)
+}
+
+// GetEventsByExchange returns all withdrawal requests by exchange
+func (r *Repository) GetEventsByExchange(exchange string, limit int) ([]*withdraw.Response, error) {
+	exch, err := exchangeDB.New(r.db).UUIDByName(exchange)
 	if err != nil {
 		log.Error(log.DatabaseMgr, err)
 		return nil, err
 	}
-	return getByColumns(generateWhereQuery([]string{"exchange_name_id"}, []string{exch.String()}, limit))
+	return r.getByColumns(generateWhereQuery([]string{"exchange_name_id"}, []string{exch.String()}, limit))
 }
 
-// GetEventByExchangeID return requested withdraw information by Exchange ID
+// GetEventByExchangeID runs Repository.GetEventByExchangeID against database.DB
 func GetEventByExchangeID(exchange, id string) (*withdraw.Response, error) {
-	exch, err := exchangeDB.UUIDByName(exchange)
+	return New(database.DB).GetEventByExchangeID(exchange, id)
+}
+
+// GetEventByExchangeID return requested withdraw information by Exchange ID
+func (r *Repository) GetEventByExchangeID(exchange, id string) (*withdraw.Response, error) {
+	exch, err := exchangeDB.New(r.db).UUIDByName(exchange)
 	if err != nil {
 		log.Error(log.DatabaseMgr, err)
 		return nil, err
 	}
-	resp, err := getByColumns(generateWhereQuery([]string{"exchange_name_id", "exchange_id"}, []string{exch.String(), id}, 1))
+	resp, err := r.getByColumns(generateWhereQuery([]string{"exchange_name_id", "exchange_id"}, []string{exch.String(), id}, 1))
 	if err != nil {
 		return nil, err
 	}
 	return resp[0], err
 }
 
-// GetEventsByDate returns requested withdraw information by date range
+// GetEventsByDate runs Repository.GetEventsByDate against database.DB
 func GetEventsByDate(exchange string, start, end time.Time, limit int) ([]*withdraw.Response, error) {
+	return New(database.DB).GetEventsByDate(exchange, start, end, limit)
+}
+
+// GetEventsByDate returns requested withdraw information by date range
+func (r *Repository) GetEventsByDate(exchange string, start, end time.Time, limit int) ([]*withdraw.Response, error) {
 	betweenQuery := generateWhereBetweenQuery("created_at", start, end, limit)
 	if exchange == "" {
-		return getByColumns(betweenQuery)
+		return r.getByColumns(betweenQuery)
 	}
-	exch, err := exchangeDB.UUIDByName(exchange)
+	exch, err := exchangeDB.New(r.db).UUIDByName(exchange)
 	if err != nil {
 		log.Error(log.DatabaseMgr, err)
 		return nil, err
 	}
-	return getByColumns(append(generateWhereQuery([]string{"exchange_name_id"}, []string{exch.String()}, 0), betweenQuery...))
+	return r.getByColumns(append(generateWhereQuery([]string{"exchange_name_id"}, []string{exch.String()}, 0), betweenQuery...))
 }
 
 func generateWhereQuery(columns, id []string, limit int) []qm.QueryMod {
@@ -284,15 +322,15 @@ func generateWhereBetweenQuery(column string, start, end interface{}, limit int)
 	}
 }
 
-func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
-	if database.DB.SQL == nil {
+func (r *Repository) getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
+	if r.db.SQL == nil {
 		return nil, database.ErrDatabaseSupportDisabled
 	}
 
 	var resp []*withdraw.Response
 	var ctx = context.Background()
-	if repository.GetSQLDialect() == database.DBSQLite3 {
-		v, err := modelSQLite.WithdrawalHistories(q...).All(ctx, database.DB.SQL)
+	if repository.Dialect(r.db) == database.DBSQLite3 {
+		v, err := modelSQLite.WithdrawalHistories(q...).All(ctx, r.db.SQL)
 		if err != nil {
 			return nil, err
 		}
@@ -313,7 +351,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 				Type:        withdraw.RequestType(v[x].WithdrawType),
 			}
 
-			exchangeName, err := v[x].ExchangeName().One(ctx, database.DB.SQL)
+			exchangeName, err := v[x].ExchangeName().One(ctx, r.db.SQL)
 			if err != nil {
 				log.Errorf(log.DatabaseMgr, "Unable to get exchange name")
 				tempUUID, errUUID := uuid.FromString(v[x].ExchangeNameID)
@@ -343,7 +381,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 			}
 
 			if withdraw.RequestType(v[x].WithdrawType) == withdraw.Crypto {
-				x, err := v[x].WithdrawalCryptos().One(ctx, database.DB.SQL)
+				x, err := v[x].WithdrawalCryptos().One(ctx, r.db.SQL)
 				if err != nil {
 					return nil, err
 				}
@@ -351,7 +389,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 				tempResp.RequestDetails.Crypto.AddressTag = x.AddressTag.String
 				tempResp.RequestDetails.Crypto.FeeAmount = x.Fee
 			} else {
-				x, err := v[x].WithdrawalFiats().One(ctx, database.DB.SQL)
+				x, err := v[x].WithdrawalFiats().One(ctx, r.db.SQL)
 				if err != nil {
 					return nil, err
 				}
@@ -364,7 +402,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 			resp = append(resp, tempResp)
 		}
 	} else {
-		v, err := modelPSQL.WithdrawalHistories(q...).All(ctx, database.DB.SQL)
+		v, err := modelPSQL.WithdrawalHistories(q...).All(ctx, r.db.SQL)
 		if err != nil {
 			return nil, err
 		}
@@ -384,7 +422,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 			tempResp.CreatedAt = v[x].CreatedAt
 			tempResp.UpdatedAt = v[x].UpdatedAt
 
-			exchangeName, err := v[x].ExchangeName().One(ctx, database.DB.SQL)
+			exchangeName, err := v[x].ExchangeName().One(ctx, r.db.SQL)
 			if err != nil {
 				log.Errorf(log.DatabaseMgr, "Unable to get exchange name")
 				tempUUID, errUUID := uuid.FromString(v[x].ExchangeNameID)
@@ -398,7 +436,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 			}
 
 			if withdraw.RequestType(v[x].WithdrawType) == withdraw.Crypto {
-				x, err := v[x].WithdrawalCryptoWithdrawalCryptos().One(ctx, database.DB.SQL)
+				x, err := v[x].WithdrawalCryptoWithdrawalCryptos().One(ctx, r.db.SQL)
 				if err != nil {
 					return nil, err
 				}
@@ -406,7 +444,7 @@ func getByColumns(q []qm.QueryMod) ([]*withdraw.Response, error) {
 				tempResp.RequestDetails.Crypto.AddressTag = x.AddressTag.String
 				tempResp.RequestDetails.Crypto.FeeAmount = x.Fee
 			} else if withdraw.RequestType(v[x].WithdrawType) == withdraw.Fiat {
-				x, err := v[x].WithdrawalFiatWithdrawalFiats().One(ctx, database.DB.SQL)
+				x, err := v[x].WithdrawalFiatWithdrawalFiats().One(ctx, r.db.SQL)
 				if err != nil {
 					return nil, err
 				}
@@ -76,6 +76,21 @@ func SpawnWorker() error {
 	return dispatcher.spawnWorker()
 }
 
+// GetStats returns the amount of jobs waiting to be relayed, the current
+// worker count and the worker ceiling
+func GetStats() (queued, workers, maxWorkers int, err error) {
+	if dispatcher == nil {
+		return 0, 0, 0, errors.New(errNotInitialised)
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	return len(dispatcher.jobs),
+		int(atomic.LoadInt32(&dispatcher.count)),
+		int(dispatcher.maxWorkers),
+		nil
+}
+
 // start compares atomic running value, sets defaults, overides with
 // configuration, then spawns workers
 func (d *Dispatcher) start(workers, channelCapacity int) error {
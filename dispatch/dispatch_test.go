@@ -52,6 +52,11 @@ func TestDispatcher(t *testing.T) {
 		t.Error("error cannot be nil")
 	}
 
+	_, _, _, err = GetStats()
+	if err == nil {
+		t.Error("error cannot be nil")
+	}
+
 	dispatcher = cpyDispatch
 
 	if !IsRunning() {
@@ -88,6 +93,17 @@ func TestDispatcher(t *testing.T) {
 		t.Error("error cannot be nil")
 	}
 
+	queued, workers, maxWorkers, err := GetStats()
+	if err != nil {
+		t.Error(err)
+	}
+	if queued != 0 || workers > maxWorkers || maxWorkers != 10 {
+		t.Errorf("unexpected stats queued %d workers %d max workers %d",
+			queued,
+			workers,
+			maxWorkers)
+	}
+
 	err = Stop()
 	if err != nil {
 		t.Error(err)
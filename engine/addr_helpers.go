@@ -93,8 +93,9 @@ func (d *DepositAddressManager) GetDepositAddressesByExchange(exchName string) (
 	return d.Store.GetDepositAddresses(exchName)
 }
 
-// Sync synchronises all deposit addresses
-func (d *DepositAddressManager) Sync() {
-	result := Bot.GetExchangeCryptocurrencyDepositAddresses()
+// Sync synchronises all deposit addresses from the supplied engine's
+// exchanges
+func (d *DepositAddressManager) Sync(bot *Engine) {
+	result := bot.GetExchangeCryptocurrencyDepositAddresses()
 	d.Store.Seed(result)
 }
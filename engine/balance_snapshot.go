@@ -0,0 +1,246 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/balancesnapshot"
+	dbexchange "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Started returns the status of the balance snapshot manager
+func (m *balanceSnapshotManager) Started() bool {
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start takes a balance snapshot straight away and then on every configured
+// interval
+func (m *balanceSnapshotManager) Start(bot *Engine) error {
+	if bot == nil {
+		return errors.New("cannot start with nil bot")
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", balanceSnapshotManagerName, subsystem.ErrSubSystemAlreadyStarted)
+	}
+	if !bot.DatabaseManager.Started() || !bot.DatabaseInstance().Connected {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+		return fmt.Errorf("%s %w", balanceSnapshotManagerName, database.ErrDatabaseSupportDisabled)
+	}
+	if bot.Config.BalanceSnapshot.FiatCurrency == "" {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+		return errSnapshotNotConfigured
+	}
+	log.Debugf(log.PortfolioMgr, "%s starting...", balanceSnapshotManagerName)
+
+	m.bot = bot
+	m.db = bot.DatabaseInstance()
+	m.interval = bot.Config.BalanceSnapshot.Interval
+	m.fiat = currency.NewCode(bot.Config.BalanceSnapshot.FiatCurrency)
+	m.verbose = bot.Config.BalanceSnapshot.Verbose
+	m.shutdown = make(chan struct{})
+	if m.fetchHoldings == nil {
+		m.fetchHoldings = bot.GetAllEnabledExchangeAccountInfo
+	}
+	if m.valuer == nil {
+		m.valuer = m.valueFromGraph
+	}
+
+	bot.ServicesWG.Add(1)
+	go m.run()
+	return nil
+}
+
+// Stop stops the balance snapshot manager
+func (m *balanceSnapshotManager) Stop() error {
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("%s %w", balanceSnapshotManagerName, subsystem.ErrSubSystemNotStarted)
+	}
+	defer func() {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+	}()
+	log.Debugf(log.PortfolioMgr, "%s shutting down...", balanceSnapshotManagerName)
+	close(m.shutdown)
+	return nil
+}
+
+func (m *balanceSnapshotManager) run() {
+	log.Debugf(log.PortfolioMgr, "%s started.", balanceSnapshotManagerName)
+	tick := time.NewTicker(m.interval)
+	defer func() {
+		tick.Stop()
+		m.bot.ServicesWG.Done()
+		log.Debugf(log.PortfolioMgr, "%s shutdown.", balanceSnapshotManagerName)
+	}()
+
+	for {
+		err := m.snapshot(time.Now())
+		switch {
+		case errors.Is(err, errNoHoldingsToSnapshot):
+			if m.verbose {
+				log.Debugf(log.PortfolioMgr, "%s %v", balanceSnapshotManagerName, err)
+			}
+		case err != nil:
+			log.Errorf(log.PortfolioMgr, "%s %v", balanceSnapshotManagerName, err)
+		}
+		select {
+		case <-m.shutdown:
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+// snapshot fetches the balances of all enabled exchanges and stores them
+// under a single timestamp so that they can be summed into an equity point
+func (m *balanceSnapshotManager) snapshot(ts time.Time) error {
+	holdings := m.fetchHoldings()
+	valueBalance, err := m.valuer()
+	if err != nil {
+		return err
+	}
+	ts = ts.UTC().Truncate(time.Second)
+	var snapshots []balancesnapshot.Details
+	for x := range holdings.Data {
+		if holdings.Data[x].Exchange == "" {
+			continue
+		}
+		if err := ensureExchangeInDatabase(m.db, holdings.Data[x].Exchange); err != nil {
+			log.Errorf(log.PortfolioMgr, "%s %s: %v", balanceSnapshotManagerName, holdings.Data[x].Exchange, err)
+			continue
+		}
+		for y := range holdings.Data[x].Accounts {
+			acc := &holdings.Data[x].Accounts[y]
+			for z := range acc.Currencies {
+				value, err := valueBalance(acc.Currencies[z].CurrencyName,
+					acc.Currencies[z].TotalValue)
+				if err != nil {
+					// the balance is still stored without a value and is
+					// left out of the equity curve
+					log.Warnf(log.PortfolioMgr, "%s %s %s: %v",
+						balanceSnapshotManagerName,
+						holdings.Data[x].Exchange,
+						acc.Currencies[z].CurrencyName,
+						err)
+				}
+				snapshots = append(snapshots, balancesnapshot.Details{
+					ExchangeName: holdings.Data[x].Exchange,
+					AccountID:    acc.ID,
+					Asset:        acc.AssetType.String(),
+					Currency:     acc.Currencies[z].CurrencyName.String(),
+					Total:        acc.Currencies[z].TotalValue,
+					Hold:         acc.Currencies[z].Hold,
+					FiatCurrency: m.fiat.String(),
+					FiatValue:    value,
+					Valued:       err == nil,
+					Timestamp:    ts,
+				})
+			}
+		}
+	}
+	if len(snapshots) == 0 {
+		return errNoHoldingsToSnapshot
+	}
+	if err = balancesnapshot.New(m.db).Insert(snapshots...); err != nil {
+		return err
+	}
+	if m.verbose {
+		log.Debugf(log.PortfolioMgr, "%s stored %d balances", balanceSnapshotManagerName, len(snapshots))
+	}
+	return nil
+}
+
+// valueFromGraph returns a func that values amounts in the manager's fiat
+// currency using a freshly built valuation graph
+func (m *balanceSnapshotManager) valueFromGraph() (func(currency.Code, float64) (float64, error), error) {
+	g, err := m.bot.GetValuationGraph()
+	if err != nil {
+		return nil, err
+	}
+	return func(code currency.Code, amount float64) (float64, error) {
+		if amount == 0 {
+			return 0, nil
+		}
+		v, err := g.Value(amount, code, m.fiat)
+		if err != nil {
+			return 0, err
+		}
+		return v.Value, nil
+	}, nil
+}
+
+// ensureExchangeInDatabase adds an exchange to the database if it has not
+// been seeded so that its balances can reference it
+func ensureExchangeInDatabase(db *database.Instance, exchName string) error {
+	exchanges := dbexchange.New(db)
+	_, err := exchanges.UUIDByName(exchName)
+	if !errors.Is(err, dbexchange.ErrNoExchangeFound) {
+		return err
+	}
+	return exchanges.Insert(dbexchange.Details{Name: exchName})
+}
+
+// getEquityCurve sums stored balance snapshot values per snapshot time.
+// Only snapshots valued in the supplied fiat currency are included, balances
+// that could not be valued are left out of the totals
+func getEquityCurve(db *database.Instance, exchName string, fiat currency.Code, start, end time.Time) ([]EquityPoint, error) {
+	if !end.After(start) {
+		return nil, errInvalidTimes
+	}
+	snapshots, err := balancesnapshot.New(db).GetInRange(exchName, start, end)
+	if err != nil {
+		return nil, err
+	}
+	totals := make(map[int64]float64)
+	for i := range snapshots {
+		if !snapshots[i].Valued ||
+			!currency.NewCode(snapshots[i].FiatCurrency).Match(fiat) {
+			continue
+		}
+		totals[snapshots[i].Timestamp.Unix()] += snapshots[i].FiatValue
+	}
+	points := make([]EquityPoint, 0, len(totals))
+	for ts, value := range totals {
+		points = append(points, EquityPoint{
+			Timestamp: time.Unix(ts, 0).UTC(),
+			Value:     value,
+		})
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp)
+	})
+	return points, nil
+}
+
+// calculateDailyPnL reduces an equity curve to the change in equity for each
+// UTC day. The curve must be sorted by time
+func calculateDailyPnL(points []EquityPoint) []DailyPnL {
+	var days []DailyPnL
+	for i := range points {
+		date := points[i].Timestamp.UTC().Truncate(24 * time.Hour)
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(date) {
+			opening := points[i].Value
+			if len(days) > 0 {
+				opening = days[len(days)-1].ClosingEquity
+			}
+			days = append(days, DailyPnL{
+				Date:          date,
+				OpeningEquity: opening,
+			})
+		}
+		days[len(days)-1].ClosingEquity = points[i].Value
+	}
+	for i := range days {
+		days[i].PnL = days[i].ClosingEquity - days[i].OpeningEquity
+		if days[i].OpeningEquity != 0 {
+			days[i].PnLPercent = days[i].PnL / days[i].OpeningEquity * 100
+		}
+	}
+	return days
+}
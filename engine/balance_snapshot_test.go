@@ -0,0 +1,247 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/currency/valuation"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/balancesnapshot"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/bitstamp"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+)
+
+func testHoldings() AllEnabledExchangeAccounts {
+	return AllEnabledExchangeAccounts{
+		Data: []account.Holdings{
+			{
+				Exchange: testExchange,
+				Accounts: []account.SubAccount{
+					{
+						ID:        "main",
+						AssetType: asset.Spot,
+						Currencies: []account.Balance{
+							{CurrencyName: currency.BTC, TotalValue: 2, Hold: 1},
+							{CurrencyName: currency.USD, TotalValue: 500},
+							// a balance without a rate is stored unvalued
+							{CurrencyName: currency.XRP, TotalValue: 100},
+						},
+					},
+				},
+			},
+			{
+				// an exchange that has not been seeded in the database
+				Exchange: "snapshotexchange",
+				Accounts: []account.SubAccount{
+					{
+						ID:         "sub",
+						AssetType:  asset.Spot,
+						Currencies: []account.Balance{{CurrencyName: currency.BTC, TotalValue: 1}},
+					},
+				},
+			},
+		},
+	}
+}
+
+var errNoTestRate = errors.New("no test rate")
+
+func fixedValuer(btcPrice float64) func() (func(currency.Code, float64) (float64, error), error) {
+	return func() (func(currency.Code, float64) (float64, error), error) {
+		return func(code currency.Code, amount float64) (float64, error) {
+			switch {
+			case code.Match(currency.USD):
+				return amount, nil
+			case code.Match(currency.BTC):
+				return amount * btcPrice, nil
+			}
+			return 0, errNoTestRate
+		}, nil
+	}
+}
+
+func TestBalanceSnapshotManagerStartStop(t *testing.T) {
+	var m balanceSnapshotManager
+	if err := m.Start(nil); err == nil {
+		t.Error("expected error starting with nil bot")
+	}
+	if err := m.Stop(); !errors.Is(err, subsystem.ErrSubSystemNotStarted) {
+		t.Errorf("received '%v' expected '%v'", err, subsystem.ErrSubSystemNotStarted)
+	}
+	if err := m.Start(&Engine{}); err == nil {
+		t.Error("expected error starting without a database")
+	}
+	if m.Started() {
+		t.Error("manager should not be started without a database")
+	}
+}
+
+func TestBalanceSnapshot(t *testing.T) {
+	bot := RPCTestSetup(t)
+	defer CleanRPCTest(t, bot)
+
+	m := balanceSnapshotManager{
+		fiat: currency.USD,
+		fetchHoldings: func() AllEnabledExchangeAccounts {
+			return AllEnabledExchangeAccounts{}
+		},
+		valuer: fixedValuer(1000),
+	}
+	if err := m.snapshot(time.Now()); !errors.Is(err, errNoHoldingsToSnapshot) {
+		t.Errorf("received '%v' expected '%v'", err, errNoHoldingsToSnapshot)
+	}
+
+	m.fetchHoldings = testHoldings
+	start := time.Now().UTC().Truncate(time.Hour).Add(-time.Hour * 48)
+	if err := m.snapshot(start); err != nil {
+		t.Fatal(err)
+	}
+	m.valuer = fixedValuer(1100)
+	if err := m.snapshot(start.Add(time.Hour * 24)); err != nil {
+		t.Fatal(err)
+	}
+
+	points, err := getEquityCurve(database.DB, "", currency.USD, start, start.Add(time.Hour*25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(points), 2)
+	}
+	if points[0].Value != 3500 || points[1].Value != 3800 {
+		t.Errorf("unexpected equity curve %+v", points)
+	}
+
+	points, err = getEquityCurve(database.DB, testExchange, currency.USD, start, start.Add(time.Hour*25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 || points[0].Value != 2500 {
+		t.Errorf("unexpected equity curve %+v", points)
+	}
+
+	points, err = getEquityCurve(database.DB, "", currency.EUR, start, start.Add(time.Hour*25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 0 {
+		t.Errorf("expected snapshots valued in another currency to be excluded, received %v", len(points))
+	}
+
+	snapshots, err := balancesnapshot.New(database.DB).GetInRange(testExchange, start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var unvalued int
+	for i := range snapshots {
+		if !snapshots[i].Valued {
+			unvalued++
+		}
+	}
+	if len(snapshots) != 3 || unvalued != 1 {
+		t.Errorf("expected the XRP balance to be stored unvalued, received %+v", snapshots)
+	}
+
+	if _, err = getEquityCurve(database.DB, "", currency.USD, start, start); !errors.Is(err, errInvalidTimes) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidTimes)
+	}
+
+	s := RPCServer{Engine: bot}
+	bot.Config.BalanceSnapshot.FiatCurrency = "USD"
+	pnl, err := s.GetDailyPnL(context.Background(), &gctrpc.GetBalanceSnapshotRequest{
+		StartDate: start.Format(common.SimpleTimeFormat),
+		EndDate:   start.Add(time.Hour * 25).Format(common.SimpleTimeFormat),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pnl.FiatCurrency != "USD" || len(pnl.Days) != 2 || pnl.Days[1].Pnl != 300 {
+		t.Errorf("unexpected daily pnl %+v", pnl)
+	}
+
+	curve, err := s.GetEquityCurve(context.Background(), &gctrpc.GetBalanceSnapshotRequest{
+		Exchange:  testExchange,
+		StartDate: start.Format(common.SimpleTimeFormat),
+		EndDate:   start.Add(time.Hour * 25).Format(common.SimpleTimeFormat),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(curve.Points) != 2 || curve.Points[1].Value != 2700 {
+		t.Errorf("unexpected equity curve %+v", curve)
+	}
+
+	_, err = s.GetEquityCurve(context.Background(), &gctrpc.GetBalanceSnapshotRequest{})
+	if !errors.Is(err, errInvalidTimes) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidTimes)
+	}
+}
+
+func TestValueFromGraph(t *testing.T) {
+	bot := &Engine{Config: &config.Config{}}
+	b := new(bitstamp.Bitstamp)
+	b.SetDefaults()
+	bot.exchangeManager.add(b)
+	err := ticker.ProcessTicker(&ticker.Price{
+		Pair:         currency.NewPair(currency.XRP, currency.USDT),
+		Last:         0.5,
+		Volume:       100,
+		AssetType:    asset.Spot,
+		ExchangeName: testExchange})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := balanceSnapshotManager{bot: bot, fiat: currency.USD}
+	valueBalance, err := m.valueFromGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := valueBalance(currency.XRP, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 5 {
+		t.Errorf("received '%v' expected '%v'", value, 5)
+	}
+
+	_, err = valueBalance(currency.LTC, 10)
+	if !errors.Is(err, valuation.ErrNoConversionPath) {
+		t.Errorf("received '%v' expected '%v'", err, valuation.ErrNoConversionPath)
+	}
+}
+
+func TestCalculateDailyPnL(t *testing.T) {
+	t.Parallel()
+	if days := calculateDailyPnL(nil); len(days) != 0 {
+		t.Errorf("expected no days, received %v", len(days))
+	}
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	days := calculateDailyPnL([]EquityPoint{
+		{Timestamp: day.Add(time.Hour), Value: 100},
+		{Timestamp: day.Add(time.Hour * 12), Value: 120},
+		{Timestamp: day.Add(time.Hour * 25), Value: 90},
+		{Timestamp: day.Add(time.Hour * 73), Value: 99},
+	})
+	if len(days) != 3 {
+		t.Fatalf("received '%v' expected '%v'", len(days), 3)
+	}
+	if days[0].OpeningEquity != 100 || days[0].ClosingEquity != 120 || days[0].PnL != 20 || days[0].PnLPercent != 20 {
+		t.Errorf("unexpected first day %+v", days[0])
+	}
+	if days[1].OpeningEquity != 120 || days[1].PnL != -30 || days[1].PnLPercent != -25 {
+		t.Errorf("unexpected second day %+v", days[1])
+	}
+	if !days[2].Date.Equal(day.AddDate(0, 0, 3)) || days[2].PnL != 9 {
+		t.Errorf("unexpected third day %+v", days[2])
+	}
+}
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+const balanceSnapshotManagerName = "balance snapshot manager"
+
+var (
+	errNoHoldingsToSnapshot  = errors.New("no exchange holdings to snapshot")
+	errSnapshotNotConfigured = errors.New("balance snapshot fiat currency not configured")
+)
+
+// EquityPoint is the total value of all snapshotted balances at a point in
+// time
+type EquityPoint struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// DailyPnL is the change in equity over a single UTC day. Opening equity is
+// the previous day's closing equity, or the day's first snapshot when there
+// is no earlier day in range
+type DailyPnL struct {
+	Date          time.Time
+	OpeningEquity float64
+	ClosingEquity float64
+	PnL           float64
+	PnLPercent    float64
+}
+
+// balanceSnapshotManager periodically stores the balances of every
+// authenticated exchange along with their value in a fiat currency
+type balanceSnapshotManager struct {
+	started  int32
+	shutdown chan struct{}
+	bot      *Engine
+	db       *database.Instance
+	interval time.Duration
+	fiat     currency.Code
+	verbose  bool
+
+	fetchHoldings func() AllEnabledExchangeAccounts
+	// valuer returns a func for valuing balances in the fiat currency for a
+	// single snapshot
+	valuer func() (func(code currency.Code, amount float64) (float64, error), error)
+}
@@ -0,0 +1,253 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+)
+
+var errChatCommandArgs = errors.New("invalid arguments")
+
+// chatCommands returns the commands that communication relayer users can run.
+// They use the same functions as the gRPC server so that both behave the same
+func (bot *Engine) chatCommands() []*base.Command {
+	s := &RPCServer{Engine: bot}
+	return []*base.Command{
+		{
+			Name:        "balances",
+			Usage:       "<exchange> [asset]",
+			Description: "Displays account balances",
+			Handler:     s.chatBalances,
+		},
+		{
+			Name:        "orders",
+			Usage:       "<exchange>",
+			Description: "Displays open orders tracked by the order manager",
+			Handler:     s.chatOpenOrders,
+		},
+		{
+			Name:        "positions",
+			Usage:       "<exchange> <asset>",
+			Description: "Displays open positions",
+			Handler:     s.chatPositions,
+		},
+		{
+			Name:        "cancelall",
+			Usage:       "<exchange>",
+			Description: "Cancels all open orders",
+			Destructive: true,
+			Handler:     s.chatCancelAll,
+		},
+		{
+			Name:        "enable",
+			Usage:       "<exchange>",
+			Description: "Enables an exchange",
+			Handler:     s.chatEnableExchange,
+		},
+		{
+			Name:        "disable",
+			Usage:       "<exchange>",
+			Description: "Disables an exchange",
+			Destructive: true,
+			Handler:     s.chatDisableExchange,
+		},
+		{
+			Name:        "runscript",
+			Usage:       "<script>",
+			Description: "Runs a gctscript",
+			Handler:     s.chatRunScript,
+		},
+		{
+			Name:        "stopscript",
+			Usage:       "<script uuid>",
+			Description: "Stops a running gctscript",
+			Destructive: true,
+			Handler:     s.chatStopScript,
+		},
+	}
+}
+
+// chatContext returns the context chat commands are run with, so audited
+// actions are attributed to the relayer user that ran them
+func chatContext(u base.CommandUser) context.Context {
+	return audit.WithActor(context.Background(), audit.Actor{
+		Type: audit.ActorChat,
+		ID:   strings.ToLower(u.Relayer) + ":" + u.ID,
+	})
+}
+
+func (s *RPCServer) chatBalances(u base.CommandUser, args []string) (string, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return "", errChatCommandArgs
+	}
+	a := asset.Spot.String()
+	if len(args) == 2 {
+		a = args[1]
+	}
+	resp, err := s.GetAccountInfo(chatContext(u), &gctrpc.GetAccountInfoRequest{
+		Exchange:  args[0],
+		AssetType: a,
+	})
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s balances:", resp.Exchange, a)
+	for i := range resp.Accounts {
+		for j := range resp.Accounts[i].Currencies {
+			c := resp.Accounts[i].Currencies[j]
+			if c.TotalValue == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "\n%s %s %v (hold %v)", resp.Accounts[i].Id, c.Currency, c.TotalValue, c.Hold)
+			if c.Value != 0 {
+				fmt.Fprintf(&sb, " ~%.2f %s", c.Value, resp.ValuationCurrency)
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+func (s *RPCServer) chatOpenOrders(u base.CommandUser, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errChatCommandArgs
+	}
+	if s.GetExchangeByName(args[0]) == nil {
+		return "", errExchangeNotLoaded
+	}
+	if !s.OrderManager.Started() {
+		return "", errors.New("order manager not started")
+	}
+	orders, err := s.OrderManager.orderStore.GetByExchange(args[0])
+	if err != nil && !errors.Is(err, ErrExchangeNotFound) {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s open orders:", args[0])
+	var count int
+	for i := range orders {
+		switch orders[i].Status {
+		case order.New, order.Active, order.Open, order.PartiallyFilled:
+		default:
+			continue
+		}
+		count++
+		fmt.Fprintf(&sb, "\n%s %s %s %s %v @ %v (filled %v)",
+			orders[i].ID,
+			orders[i].Pair,
+			orders[i].Side,
+			orders[i].Type,
+			orders[i].Amount,
+			orders[i].Price,
+			orders[i].ExecutedAmount)
+	}
+	if count == 0 {
+		sb.WriteString(" none")
+	}
+	return sb.String(), nil
+}
+
+func (s *RPCServer) chatPositions(u base.CommandUser, args []string) (string, error) {
+	if len(args) != 2 {
+		return "", errChatCommandArgs
+	}
+	a, err := asset.New(args[1])
+	if err != nil {
+		return "", err
+	}
+	exch := s.GetExchangeByName(args[0])
+	if exch == nil {
+		return "", errExchangeNotLoaded
+	}
+	positions, err := exch.GetPositions(a, nil)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s positions:", exch.GetName(), a)
+	if len(positions) == 0 {
+		sb.WriteString(" none")
+	}
+	for i := range positions {
+		fmt.Fprintf(&sb, "\n%s %s %v @ %v mark %v unrealised PnL %v",
+			positions[i].FutureSymbol,
+			positions[i].Side,
+			positions[i].Qty,
+			positions[i].EntryPrice,
+			positions[i].MarkPrice,
+			positions[i].UnrealisedPnl)
+	}
+	return sb.String(), nil
+}
+
+func (s *RPCServer) chatCancelAll(u base.CommandUser, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errChatCommandArgs
+	}
+	resp, err := s.CancelAllOrders(chatContext(u), &gctrpc.CancelAllOrdersRequest{Exchange: args[0]})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s cancelled %d orders", args[0], resp.Count), nil
+}
+
+func (s *RPCServer) chatEnableExchange(u base.CommandUser, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errChatCommandArgs
+	}
+	_, err := s.EnableExchange(chatContext(u), &gctrpc.GenericExchangeNameRequest{Exchange: args[0]})
+	if err != nil {
+		return "", err
+	}
+	return args[0] + " enabled", nil
+}
+
+func (s *RPCServer) chatDisableExchange(u base.CommandUser, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errChatCommandArgs
+	}
+	_, err := s.DisableExchange(chatContext(u), &gctrpc.GenericExchangeNameRequest{Exchange: args[0]})
+	if err != nil {
+		return "", err
+	}
+	return args[0] + " disabled", nil
+}
+
+func (s *RPCServer) chatRunScript(u base.CommandUser, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errChatCommandArgs
+	}
+	resp, err := s.GCTScriptExecute(chatContext(u), &gctrpc.GCTScriptExecuteRequest{
+		Script: &gctrpc.GCTScript{Name: args[0]},
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.Status != MsgStatusOK {
+		return "", errors.New(strings.TrimSpace(resp.Status + " " + resp.Data))
+	}
+	return resp.Data, nil
+}
+
+func (s *RPCServer) chatStopScript(u base.CommandUser, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errChatCommandArgs
+	}
+	resp, err := s.GCTScriptStop(chatContext(u), &gctrpc.GCTScriptStopRequest{
+		Script: &gctrpc.GCTScript{UUID: args[0]},
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.Status != MsgStatusOK {
+		return "", errors.New(strings.TrimSpace(resp.Status + " " + resp.Data))
+	}
+	return resp.Data, nil
+}
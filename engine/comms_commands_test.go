@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestChatCommands(t *testing.T) {
+	bot := OrdersSetup(t)
+	r := base.NewCommandRegistry(&config.ChatCommandsConfig{
+		AuthorisedUsers: []config.ChatCommandUser{
+			{Relayer: "Telegram", ID: "1", Commands: []string{"*"}},
+		},
+	})
+	if err := r.Register(bot.chatCommands()...); err != nil {
+		t.Fatal(err)
+	}
+
+	if reply := r.Handle("Telegram", "1", "/orders"); !strings.Contains(reply, errChatCommandArgs.Error()) {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if reply := r.Handle("Telegram", "1", "/orders notanexchange"); !strings.Contains(reply, errExchangeNotLoaded.Error()) {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if reply := r.Handle("Telegram", "1", "/orders "+testExchange); !strings.HasSuffix(reply, "none") {
+		t.Errorf("unexpected reply %q", reply)
+	}
+
+	for _, d := range []*order.Detail{
+		{Exchange: testExchange, ID: "chatopen", Status: order.Active, Side: order.Buy, Type: order.Limit},
+		{Exchange: testExchange, ID: "chatfilled", Status: order.Filled},
+	} {
+		if err := bot.OrderManager.orderStore.Add(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reply := r.Handle("Telegram", "1", "/orders "+testExchange)
+	if !strings.Contains(reply, "chatopen") || strings.Contains(reply, "chatfilled") {
+		t.Errorf("unexpected reply %q", reply)
+	}
+
+	if reply = r.Handle("Telegram", "1", "/positions "+testExchange+" notanasset"); !strings.Contains(reply, "positions failed") {
+		t.Errorf("unexpected reply %q", reply)
+	}
+
+	reply = r.Handle("Telegram", "1", "/disable "+testExchange)
+	if !strings.Contains(reply, base.CommandConfirm) {
+		t.Fatalf("unexpected reply %q", reply)
+	}
+	if bot.GetExchangeByName(testExchange) == nil {
+		t.Fatal("exchange disabled before confirmation")
+	}
+	if reply = r.Handle("Telegram", "1", "/confirm"); reply != testExchange+" disabled" {
+		t.Errorf("unexpected reply %q", reply)
+	}
+	if bot.GetExchangeByName(testExchange) != nil {
+		t.Error("exchange should be disabled")
+	}
+	if reply = r.Handle("Telegram", "1", "/enable "+testExchange); reply != testExchange+" enabled" {
+		t.Errorf("unexpected reply %q", reply)
+	}
+}
+
+func TestChatScriptCommands(t *testing.T) {
+	t.Parallel()
+	s := &RPCServer{Engine: &Engine{}}
+	if _, err := s.chatRunScript(base.CommandUser{}, nil); !errors.Is(err, errChatCommandArgs) {
+		t.Errorf("received '%v' expected '%v'", err, errChatCommandArgs)
+	}
+	if _, err := s.chatStopScript(base.CommandUser{}, []string{"a", "b"}); !errors.Is(err, errChatCommandArgs) {
+		t.Errorf("received '%v' expected '%v'", err, errChatCommandArgs)
+	}
+	if _, err := s.chatBalances(base.CommandUser{}, nil); !errors.Is(err, errChatCommandArgs) {
+		t.Errorf("received '%v' expected '%v'", err, errChatCommandArgs)
+	}
+	if _, err := s.chatCancelAll(base.CommandUser{}, nil); !errors.Is(err, errChatCommandArgs) {
+		t.Errorf("received '%v' expected '%v'", err, errChatCommandArgs)
+	}
+}
@@ -4,26 +4,36 @@ import (
 	"errors"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/communications"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
+// commsProcessInterval is how often event digests and delivery retries are
+// processed
+const commsProcessInterval = time.Second
+
 // commsManager starts the NTP manager
 type commsManager struct {
 	started  int32
 	shutdown chan struct{}
 	relayMsg chan base.Event
 	comms    *communications.Communications
+	bot      *Engine
 }
 
 func (c *commsManager) Started() bool {
 	return atomic.LoadInt32(&c.started) == 1
 }
 
-func (c *commsManager) Start() (err error) {
+func (c *commsManager) Start(bot *Engine) (err error) {
+	if bot == nil {
+		return errors.New("cannot start with nil bot")
+	}
 	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
 		return fmt.Errorf("communications manager %w", subsystem.ErrSubSystemAlreadyStarted)
 	}
@@ -35,11 +45,19 @@ func (c *commsManager) Start() (err error) {
 	}()
 
 	log.Debugln(log.CommunicationMgr, "Communications manager starting...")
-	commsCfg := Bot.Config.GetCommunicationsConfig()
+	c.bot = bot
+	commsCfg := c.bot.Config.GetCommunicationsConfig()
 	c.comms, err = communications.NewComm(&commsCfg)
 	if err != nil {
 		return err
 	}
+	if commsCfg.ChatCommands.Enabled {
+		registry := base.NewCommandRegistry(&commsCfg.ChatCommands)
+		if err = registry.Register(c.bot.chatCommands()...); err != nil {
+			return err
+		}
+		c.comms.SetCommandRegistry(registry)
+	}
 
 	c.shutdown = make(chan struct{})
 	c.relayMsg = make(chan base.Event)
@@ -71,6 +89,12 @@ func (c *commsManager) PushEvent(evt base.Event) {
 	if !c.Started() {
 		return
 	}
+	if evt.Severity == "" {
+		evt.Severity = base.SeverityInfo
+	}
+	if evt.Timestamp.IsZero() {
+		evt.Timestamp = time.Now()
+	}
 	select {
 	case c.relayMsg <- evt:
 	default:
@@ -78,16 +102,44 @@ func (c *commsManager) PushEvent(evt base.Event) {
 	}
 }
 
+// orderEvent returns an order event carrying the details of an order so that
+// relayers can template alerts from them
+func orderEvent(msg string, d *order.Detail) base.Event {
+	return base.Event{
+		Type:     base.OrderEvent,
+		Message:  msg,
+		Exchange: d.Exchange,
+		Pair:     d.Pair,
+		Asset:    d.AssetType,
+		Order: &base.OrderDetails{
+			ID:             d.ID,
+			Side:           d.Side.String(),
+			Type:           d.Type.String(),
+			Status:         d.Status.String(),
+			Price:          d.Price,
+			Amount:         d.Amount,
+			ExecutedAmount: d.ExecutedAmount,
+		},
+	}
+}
+
 func (c *commsManager) run() {
 	defer func() {
 		// TO-DO shutdown comms connections for connected services (Slack etc)
+		c.comms.Stop()
 		log.Debugln(log.CommunicationMgr, "Communications manager shutdown.")
 	}()
 
+	// digests and retries are checked on a ticker so that rules with long
+	// intervals don't need their own timers
+	tick := time.NewTicker(commsProcessInterval)
+	defer tick.Stop()
 	for {
 		select {
 		case msg := <-c.relayMsg:
 			c.comms.PushEvent(msg)
+		case <-tick.C:
+			c.comms.Process()
 		case <-c.shutdown:
 			return
 		}
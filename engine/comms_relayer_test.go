@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestOrderEvent(t *testing.T) {
+	t.Parallel()
+	evt := orderEvent("filled", &order.Detail{
+		Exchange:       testExchange,
+		ID:             "1337",
+		Pair:           currency.NewPair(currency.BTC, currency.USD),
+		AssetType:      asset.Spot,
+		Side:           order.Buy,
+		Type:           order.Limit,
+		Status:         order.PartiallyFilled,
+		Price:          1000,
+		Amount:         2,
+		ExecutedAmount: 1,
+	})
+	if evt.Type != base.OrderEvent || evt.Message != "filled" || evt.Exchange != testExchange {
+		t.Errorf("unexpected event %+v", evt)
+	}
+	if evt.Order == nil ||
+		evt.Order.ID != "1337" ||
+		evt.Order.Side != order.Buy.String() ||
+		evt.Order.Status != order.PartiallyFilled.String() ||
+		evt.Order.ExecutedAmount != 1 {
+		t.Errorf("unexpected order details %+v", evt.Order)
+	}
+}
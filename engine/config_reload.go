@@ -0,0 +1,350 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Config sections which are applied to the running engine on reload, every
+// other section is compared and reported as requiring a restart
+const (
+	loggingConfigSection        = "logging"
+	communicationsConfigSection = "communications"
+	orderManagerConfigSection   = "orderManager"
+	exchangesConfigSection      = "exchanges"
+)
+
+var (
+	errConfigReloadEncrypted = errors.New("encrypted config files cannot be reloaded, restart to apply changes")
+	errNilConfig             = errors.New("config is nil")
+	errConfigRestartPending  = errors.New("config file contains reloaded changes which require a restart, not overwriting it")
+)
+
+// ConfigReloadResult reports the outcome of a config reload. Applied changes
+// are live, RequiresRestart changes were left untouched in the running config
+// and Failed changes could not be applied
+type ConfigReloadResult struct {
+	Applied         []string
+	RequiresRestart []string
+	Failed          []string
+}
+
+// ReloadConfig reads the config file the engine was started with, validates
+// it and applies the differences which are safe to change at runtime. These
+// are enabled and available pairs along with the websocket subscriptions and
+// currency pair sync agents that follow them, logging levels, communications
+// and order manager limits
+func (bot *Engine) ReloadConfig() (*ConfigReloadResult, error) {
+	path, _, err := config.GetFilePath(bot.Settings.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	newCfg, err := readConfigForReload(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config %s: %w", path, err)
+	}
+
+	bot.configReloadMtx.Lock()
+	defer bot.configReloadMtx.Unlock()
+	// the running config stays in charge of the logger, database and
+	// script path so the new config is only validated
+	err = newCfg.ValidateConfig()
+	if err != nil {
+		return nil, fmt.Errorf("config %s failed validation: %w", path, err)
+	}
+
+	result, err := bot.applyConfig(newCfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.RequiresRestart) > 0 {
+		bot.configRestartPending = true
+	}
+	return result, nil
+}
+
+// configRestartRequired returns whether a reloaded config contained changes
+// which are waiting on a restart, in which case the config file should not be
+// overwritten with the running config
+func (bot *Engine) configRestartRequired() bool {
+	bot.configReloadMtx.Lock()
+	defer bot.configReloadMtx.Unlock()
+	return bot.configRestartPending
+}
+
+// readConfigForReload reads a config without prompting, encrypted configs
+// need the key entered at startup so are rejected
+func readConfigForReload(path string) (*config.Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, []byte(config.EncryptConfirmString)) {
+		return nil, errConfigReloadEncrypted
+	}
+	c, _, err := config.ReadConfig(bytes.NewReader(data), nil)
+	return c, err
+}
+
+// applyConfig diffs the validated config against the running config and
+// applies the safe changes
+func (bot *Engine) applyConfig(newCfg *config.Config) (*ConfigReloadResult, error) {
+	if bot.Config == nil || newCfg == nil {
+		return nil, errNilConfig
+	}
+	result := &ConfigReloadResult{}
+	bot.reloadLogging(newCfg, result)
+	bot.reloadCommunications(newCfg, result)
+	bot.reloadOrderManager(newCfg, result)
+	bot.reloadExchanges(newCfg, result)
+
+	running := reflect.ValueOf(bot.Config).Elem()
+	updated := reflect.ValueOf(newCfg).Elem()
+	for i := 0; i < running.NumField(); i++ {
+		field := running.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch name {
+		case loggingConfigSection,
+			communicationsConfigSection,
+			orderManagerConfigSection,
+			exchangesConfigSection:
+			continue
+		}
+		if !reflect.DeepEqual(running.Field(i).Interface(), updated.Field(i).Interface()) {
+			result.RequiresRestart = append(result.RequiresRestart, name)
+		}
+	}
+	return result, nil
+}
+
+// reloadLogging applies changed logger levels, changes to outputs and log
+// files require a restart
+func (bot *Engine) reloadLogging(newCfg *config.Config, result *ConfigReloadResult) {
+	if reflect.DeepEqual(bot.Config.Logging, newCfg.Logging) {
+		return
+	}
+	running, updated := bot.Config.Logging, newCfg.Logging
+	running.Level, updated.Level = "", ""
+	running.SubLoggers = withoutLevels(running.SubLoggers)
+	updated.SubLoggers = withoutLevels(updated.SubLoggers)
+	if !reflect.DeepEqual(running, updated) {
+		result.RequiresRestart = append(result.RequiresRestart, loggingConfigSection)
+	}
+
+	// setting the global level resets every sublogger so their own levels
+	// are all reapplied afterwards
+	globalChanged := bot.Config.Logging.Level != newCfg.Logging.Level
+	if globalChanged {
+		log.SetGlobalLevel(newCfg.Logging.Level)
+		log.RWM.Lock()
+		bot.Config.Logging.Level = newCfg.Logging.Level
+		log.RWM.Unlock()
+		result.Applied = append(result.Applied, loggingConfigSection+".level")
+	}
+	for i := range newCfg.Logging.SubLoggers {
+		s := newCfg.Logging.SubLoggers[i]
+		j := findSubLogger(bot.Config.Logging.SubLoggers, s.Name)
+		unchanged := j >= 0 && bot.Config.Logging.SubLoggers[j].Level == s.Level
+		if unchanged && !globalChanged {
+			continue
+		}
+		setting := loggingConfigSection + ".subloggers." + strings.ToLower(s.Name)
+		if _, err := log.SetLevel(strings.ToUpper(s.Name), s.Level); err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", setting, err))
+			continue
+		}
+		if !unchanged {
+			result.Applied = append(result.Applied, setting)
+		}
+	}
+	// outputs are left as they are until a restart, only levels are copied
+	subLoggers := make([]log.SubLoggerConfig, len(bot.Config.Logging.SubLoggers))
+	copy(subLoggers, bot.Config.Logging.SubLoggers)
+	for i := range newCfg.Logging.SubLoggers {
+		if j := findSubLogger(subLoggers, newCfg.Logging.SubLoggers[i].Name); j >= 0 {
+			subLoggers[j].Level = newCfg.Logging.SubLoggers[i].Level
+		}
+	}
+	log.RWM.Lock()
+	bot.Config.Logging.SubLoggers = subLoggers
+	log.RWM.Unlock()
+}
+
+func withoutLevels(s []log.SubLoggerConfig) []log.SubLoggerConfig {
+	resp := make([]log.SubLoggerConfig, len(s))
+	for i := range s {
+		resp[i] = s[i]
+		resp[i].Level = ""
+	}
+	return resp
+}
+
+func findSubLogger(s []log.SubLoggerConfig, name string) int {
+	for i := range s {
+		if strings.EqualFold(s[i].Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// reloadCommunications replaces the communications config and restarts the
+// communications manager so the relayers are recreated
+func (bot *Engine) reloadCommunications(newCfg *config.Config, result *ConfigReloadResult) {
+	if reflect.DeepEqual(bot.Config.Communications, newCfg.Communications) {
+		return
+	}
+	bot.Config.UpdateCommunicationsConfig(&newCfg.Communications)
+	// the manager is restarted directly rather than through the subsystem
+	// registry, the registry holds its lifecycle lock while stopping the
+	// config watcher which may be waiting on this reload
+	if bot.CommsManager.Started() {
+		if err := bot.CommsManager.Stop(); err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", communicationsConfigSection, err))
+			return
+		}
+		if err := bot.CommsManager.Start(bot); err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", communicationsConfigSection, err))
+			return
+		}
+	}
+	result.Applied = append(result.Applied, communicationsConfigSection)
+}
+
+// reloadOrderManager replaces the limits enforced on submitted orders
+func (bot *Engine) reloadOrderManager(newCfg *config.Config, result *ConfigReloadResult) {
+	if reflect.DeepEqual(bot.Config.OrderManager, newCfg.OrderManager) {
+		return
+	}
+	bot.Config.OrderManager = newCfg.OrderManager
+	bot.OrderManager.setConfig(&newCfg.OrderManager)
+	result.Applied = append(result.Applied, orderManagerConfigSection)
+}
+
+// reloadExchanges applies changed asset and pair settings to the exchange
+// configs and loaded exchanges, then refreshes websocket subscriptions. Any
+// other exchange setting requires a restart
+func (bot *Engine) reloadExchanges(newCfg *config.Config, result *ConfigReloadResult) {
+	seen := make(map[string]bool)
+	for i := range newCfg.Exchanges {
+		updated := &newCfg.Exchanges[i]
+		name := strings.ToLower(updated.Name)
+		seen[name] = true
+		running, err := bot.Config.GetExchangeConfig(updated.Name)
+		if err != nil {
+			result.RequiresRestart = append(result.RequiresRestart, exchangesConfigSection+"."+name)
+			continue
+		}
+		runningCpy, updatedCpy := *running, *updated
+		runningCpy.CurrencyPairs, updatedCpy.CurrencyPairs = nil, nil
+		if !reflect.DeepEqual(runningCpy, updatedCpy) ||
+			!pairsManagerSettingsEqual(running.CurrencyPairs, updated.CurrencyPairs) {
+			result.RequiresRestart = append(result.RequiresRestart, exchangesConfigSection+"."+name)
+		}
+		if running.CurrencyPairs == nil || updated.CurrencyPairs == nil {
+			continue
+		}
+		bot.reloadExchangePairs(name, running, updated, result)
+	}
+	for i := range bot.Config.Exchanges {
+		name := strings.ToLower(bot.Config.Exchanges[i].Name)
+		if !seen[name] {
+			result.RequiresRestart = append(result.RequiresRestart, exchangesConfigSection+"."+name)
+		}
+	}
+}
+
+// pairsManagerSettingsEqual compares everything but the pair stores
+func pairsManagerSettingsEqual(a, b *currency.PairsManager) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return reflect.DeepEqual(a.RequestFormat, b.RequestFormat) &&
+		reflect.DeepEqual(a.ConfigFormat, b.ConfigFormat) &&
+		a.UseGlobalFormat == b.UseGlobalFormat
+}
+
+func (bot *Engine) reloadExchangePairs(name string, running, updated *config.ExchangeConfig, result *ConfigReloadResult) {
+	exch := bot.GetExchangeByName(name)
+	var changed bool
+	assets := updated.CurrencyPairs.GetAssetTypes()
+	sort.Slice(assets, func(i, j int) bool { return assets[i] < assets[j] })
+	for _, a := range assets {
+		setting := exchangesConfigSection + "." + name + ".currencyPairs." + a.String()
+		newPS, err := updated.CurrencyPairs.Get(a)
+		if err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", setting, err))
+			continue
+		}
+		oldPS, err := running.CurrencyPairs.Get(a)
+		if err == nil &&
+			reflect.DeepEqual(oldPS.AssetEnabled, newPS.AssetEnabled) &&
+			reflect.DeepEqual(oldPS.Enabled, newPS.Enabled) &&
+			reflect.DeepEqual(oldPS.Available, newPS.Available) {
+			continue
+		}
+		if err == nil &&
+			(!reflect.DeepEqual(oldPS.RequestFormat, newPS.RequestFormat) ||
+				!reflect.DeepEqual(oldPS.ConfigFormat, newPS.ConfigFormat)) {
+			result.RequiresRestart = append(result.RequiresRestart, setting)
+			continue
+		}
+		if exch != nil {
+			if err = applyExchangePairs(&exch.GetBase().CurrencyPairs, a, newPS); err != nil {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", setting, err))
+				continue
+			}
+		}
+		running.CurrencyPairs.Store(a, *newPS)
+		result.Applied = append(result.Applied, setting)
+		changed = true
+	}
+
+	if !changed || exch == nil {
+		return
+	}
+	if bot.ExchangeCurrencyPairManager.Started() {
+		bot.ExchangeCurrencyPairManager.syncExchangePairs(exch)
+		result.Applied = append(result.Applied, exchangesConfigSection+"."+name+".sync")
+	}
+	if !exch.IsWebsocketEnabled() {
+		return
+	}
+	base := exch.GetBase()
+	if base.Websocket == nil || !base.Websocket.IsConnected() {
+		return
+	}
+	setting := exchangesConfigSection + "." + name + ".subscriptions"
+	if err := exch.FlushWebsocketChannels(); err != nil {
+		result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", setting, err))
+		return
+	}
+	result.Applied = append(result.Applied, setting)
+}
+
+// applyExchangePairs stores the pairs and asset state on a loaded exchange
+func applyExchangePairs(m *currency.PairsManager, a asset.Item, ps *currency.PairStore) error {
+	if !m.GetAssetTypes().Contains(a) {
+		return fmt.Errorf("%w %s", asset.ErrNotSupported, a)
+	}
+	m.StorePairs(a, ps.Available, false)
+	m.StorePairs(a, ps.Enabled, true)
+	enabled := ps.AssetEnabled != nil && *ps.AssetEnabled
+	if (m.IsAssetEnabled(a) == nil) != enabled {
+		return m.SetAssetEnabled(a, enabled)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+)
+
+// reloadTestBot returns a test bot without the fake exchange config entry,
+// which is incomplete and would not survive being saved and reloaded
+func reloadTestBot(t *testing.T) *Engine {
+	t.Helper()
+	bot := CreateTestBot(t)
+	for i := range bot.Config.Exchanges {
+		if bot.Config.Exchanges[i].Name == fakePassExchange {
+			bot.Config.Exchanges = append(bot.Config.Exchanges[:i], bot.Config.Exchanges[i+1:]...)
+			break
+		}
+	}
+	return bot
+}
+
+// copyConfig returns a copy of the bot's running config to be modified and
+// applied
+func copyConfig(t *testing.T, bot *Engine) *config.Config {
+	t.Helper()
+	data, err := json.Marshal(bot.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c config.Config
+	if err = json.Unmarshal(data, &c); err != nil {
+		t.Fatal(err)
+	}
+	return &c
+}
+
+// writeReloadConfig saves the config to a temporary file and points the bot
+// at it
+func writeReloadConfig(t *testing.T, bot *Engine, c *config.Config) {
+	t.Helper()
+	if bot.Settings.ConfigFile == config.TestFile {
+		dir, err := ioutil.TempDir("", "gct-config-reload")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.RemoveAll(dir) })
+		bot.Settings.ConfigFile = filepath.Join(dir, "config.json")
+	}
+	if err := c.SaveConfigToFile(bot.Settings.ConfigFile); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadConfigForReload(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "gct-config-reload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	if _, err = readConfigForReload(path); err == nil {
+		t.Error("expected error reading a missing config")
+	}
+	err = ioutil.WriteFile(path, []byte(config.EncryptConfirmString+"encrypted"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = readConfigForReload(path); !errors.Is(err, errConfigReloadEncrypted) {
+		t.Errorf("received '%v' expected '%v'", err, errConfigReloadEncrypted)
+	}
+	if err = ioutil.WriteFile(path, []byte(`{"name":"reload"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := readConfigForReload(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "reload" {
+		t.Errorf("received '%v' expected '%v'", c.Name, "reload")
+	}
+}
+
+func TestApplyConfig(t *testing.T) {
+	bot := reloadTestBot(t)
+	if _, err := bot.applyConfig(nil); !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v' expected '%v'", err, errNilConfig)
+	}
+
+	result, err := bot.applyConfig(copyConfig(t, bot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Applied) != 0 || len(result.RequiresRestart) != 0 || len(result.Failed) != 0 {
+		t.Fatalf("expected no changes for an identical config, received %+v", result)
+	}
+
+	newCfg := copyConfig(t, bot)
+	newCfg.OrderManager.EnforceLimitConfig = true
+	newCfg.OrderManager.LimitAmount = 1337
+	newCfg.NTPClient.Level = 1
+	exchCfg, err := newCfg.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg.HTTPTimeout += time.Second
+	ps, err := exchCfg.CurrencyPairs.Get(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enabled := currency.Pairs{ps.Available[0]}
+	ps.Enabled = enabled
+
+	result, err = bot.applyConfig(newCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{orderManagerConfigSection, "exchanges.bitstamp.currencyPairs.spot"} {
+		if !common.StringDataCompare(result.Applied, s) {
+			t.Errorf("expected %s to be applied, received %v", s, result.Applied)
+		}
+	}
+	for _, s := range []string{"ntpclient", "exchanges.bitstamp"} {
+		if !common.StringDataCompare(result.RequiresRestart, s) {
+			t.Errorf("expected %s to require a restart, received %v", s, result.RequiresRestart)
+		}
+	}
+	if len(result.Failed) != 0 {
+		t.Errorf("expected no failures, received %v", result.Failed)
+	}
+
+	if cfg := bot.OrderManager.getConfig(); !cfg.EnforceLimitConfig || cfg.LimitAmount != 1337 {
+		t.Errorf("expected order manager limits to be applied, received %+v", cfg)
+	}
+	if bot.Config.NTPClient.Level == 1 {
+		t.Error("expected restart only changes to be left untouched")
+	}
+	pairs, err := bot.GetExchangeByName(testExchange).GetEnabledPairs(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairs) != 1 || !pairs[0].Equal(enabled[0]) {
+		t.Errorf("received '%v' expected '%v'", pairs, enabled)
+	}
+	running, err := bot.Config.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if running.HTTPTimeout == exchCfg.HTTPTimeout {
+		t.Error("expected exchange settings which require a restart to be left untouched")
+	}
+}
+
+func TestApplyConfigSyncAgents(t *testing.T) {
+	bot := reloadTestBot(t)
+	syncer, err := NewCurrencyPairSyncer(bot, CurrencyPairSyncerConfig{SyncTicker: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	atomic.StoreInt32(&syncer.initSyncCompleted, 1)
+	bot.ExchangeCurrencyPairManager = syncer
+	exch := bot.GetExchangeByName(testExchange)
+	pairs, err := exch.GetEnabledPairs(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range pairs {
+		syncer.add(syncer.newAgent(exch.GetName(), pairs[i], asset.Spot, true, false))
+	}
+
+	newCfg := copyConfig(t, bot)
+	exchCfg, err := newCfg.GetExchangeConfig(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps, err := exchCfg.CurrencyPairs.Get(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var enabled currency.Pair
+	for i := range ps.Available {
+		if !pairs.Contains(ps.Available[i], true) {
+			enabled = ps.Available[i]
+			break
+		}
+	}
+	if enabled.IsEmpty() {
+		t.Fatal("expected an available pair which is not enabled")
+	}
+	ps.Enabled = currency.Pairs{enabled}
+
+	result, err := bot.applyConfig(newCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !common.StringDataCompare(result.Applied, "exchanges.bitstamp.sync") {
+		t.Errorf("expected sync agents to be updated, received %v", result.Applied)
+	}
+	for i := range pairs {
+		if syncer.exists(exch.GetName(), pairs[i], asset.Spot) {
+			t.Errorf("expected sync agent for disabled pair %v to be removed", pairs[i])
+		}
+	}
+	if !syncer.exists(exch.GetName(), enabled, asset.Spot) {
+		t.Errorf("expected sync agent for enabled pair %v to be added", enabled)
+	}
+}
+
+func TestReloadConfig(t *testing.T) {
+	bot := reloadTestBot(t)
+	newCfg := copyConfig(t, bot)
+	newCfg.OrderManager.AllowMarketOrders = true
+	writeReloadConfig(t, bot, newCfg)
+
+	s := RPCServer{Engine: bot}
+	resp, err := s.ReloadConfig(context.Background(), &gctrpc.ReloadConfigRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !common.StringDataCompare(resp.Applied, orderManagerConfigSection) {
+		t.Errorf("expected %s to be applied, received %v", orderManagerConfigSection, resp.Applied)
+	}
+	if !bot.OrderManager.getConfig().AllowMarketOrders {
+		t.Error("expected order manager config to be applied")
+	}
+	if len(resp.RequiresRestart) != 0 || bot.configRestartRequired() {
+		t.Errorf("expected no changes requiring a restart, received %v", resp.RequiresRestart)
+	}
+
+	newCfg.Name = "reloaded"
+	writeReloadConfig(t, bot, newCfg)
+	resp, err = s.ReloadConfig(context.Background(), &gctrpc.ReloadConfigRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !common.StringDataCompare(resp.RequiresRestart, "name") {
+		t.Errorf("expected name to require a restart, received %v", resp.RequiresRestart)
+	}
+	if !bot.configRestartRequired() {
+		t.Error("expected a restart to be pending")
+	}
+	bot.Settings.EnableDryRun = false
+	if err = s.saveRemoteControlConfig(); !errors.Is(err, errConfigRestartPending) {
+		t.Errorf("received '%v' expected '%v'", err, errConfigRestartPending)
+	}
+
+	newCfg.Exchanges = nil
+	writeReloadConfig(t, bot, newCfg)
+	if _, err = bot.ReloadConfig(); err == nil {
+		t.Error("expected an invalid config to be rejected")
+	}
+}
+
+func TestConfigWatcher(t *testing.T) {
+	var c configWatcher
+	if err := c.Start(nil); err == nil {
+		t.Error("expected error starting with nil bot")
+	}
+	if err := c.Stop(); !errors.Is(err, subsystem.ErrSubSystemNotStarted) {
+		t.Errorf("received '%v' expected '%v'", err, subsystem.ErrSubSystemNotStarted)
+	}
+
+	bot := reloadTestBot(t)
+	newCfg := copyConfig(t, bot)
+	writeReloadConfig(t, bot, newCfg)
+
+	debounce := configWatcherDebounce
+	configWatcherDebounce = time.Millisecond * 10
+	defer func() { configWatcherDebounce = debounce }()
+	if err := c.Start(bot); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Start(bot); !errors.Is(err, subsystem.ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v' expected '%v'", err, subsystem.ErrSubSystemAlreadyStarted)
+	}
+
+	newCfg.OrderManager.LimitAmount = 42
+	writeReloadConfig(t, bot, newCfg)
+	deadline := time.Now().Add(time.Second * 5)
+	for bot.OrderManager.getConfig().LimitAmount != 42 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected config watcher to reload the changed config")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	if err := c.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if c.Started() {
+		t.Error("expected config watcher to be stopped")
+	}
+}
@@ -0,0 +1,151 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// configWatcherDebounce is how long the watcher waits after the last change
+// before reloading, editors commonly write a file in several steps
+var configWatcherDebounce = time.Second
+
+// configWatcher reloads the config whenever its file changes on disk
+type configWatcher struct {
+	started  int32
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+	bot      *Engine
+}
+
+// Started returns whether the config watcher is running
+func (c *configWatcher) Started() bool {
+	return atomic.LoadInt32(&c.started) == 1
+}
+
+// Start watches the directory of the engine's config file, the directory is
+// watched rather than the file as editors often replace the file on save
+func (c *configWatcher) Start(bot *Engine) (err error) {
+	if bot == nil {
+		return errors.New("cannot start with nil bot")
+	}
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return fmt.Errorf("config watcher %w", subsystem.ErrSubSystemAlreadyStarted)
+	}
+	defer func() {
+		if err != nil {
+			atomic.CompareAndSwapInt32(&c.started, 1, 0)
+		}
+	}()
+
+	path, _, err := config.GetFilePath(bot.Settings.ConfigFile)
+	if err != nil {
+		return err
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(filepath.Dir(path)); err != nil {
+		if closeErr := watcher.Close(); closeErr != nil {
+			log.Errorf(log.ConfigMgr, "Config watcher unable to close watcher: %v\n", closeErr)
+		}
+		return err
+	}
+
+	c.bot = bot
+	c.shutdown = make(chan struct{})
+	c.wg.Add(1)
+	go c.run(watcher, path)
+	log.Debugf(log.ConfigMgr, "Config watcher started, watching %s.\n", path)
+	return nil
+}
+
+// Stop stops watching the config file
+func (c *configWatcher) Stop() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 1, 0) {
+		return fmt.Errorf("config watcher %w", subsystem.ErrSubSystemNotStarted)
+	}
+	close(c.shutdown)
+	c.wg.Wait()
+	log.Debugln(log.ConfigMgr, "Config watcher stopped.")
+	return nil
+}
+
+func (c *configWatcher) run(watcher *fsnotify.Watcher, path string) {
+	defer c.wg.Done()
+	defer func() {
+		if err := watcher.Close(); err != nil {
+			log.Errorf(log.ConfigMgr, "Config watcher unable to close watcher: %v\n", err)
+		}
+	}()
+
+	timer := time.NewTimer(configWatcherDebounce)
+	timer.Stop()
+	for {
+		select {
+		case <-c.shutdown:
+			timer.Stop()
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 ||
+				filepath.Clean(event.Name) != path {
+				continue
+			}
+			timer.Reset(configWatcherDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Errorf(log.ConfigMgr, "Config watcher error: %v\n", err)
+		case <-timer.C:
+			c.reload(path)
+		}
+	}
+}
+
+func (c *configWatcher) reload(path string) {
+	result, err := c.bot.ReloadConfig()
+	if err != nil {
+		log.Errorf(log.ConfigMgr, "Config watcher unable to reload config: %v\n", err)
+		return
+	}
+	logConfigReload(result)
+	if len(result.Applied) > 0 || len(result.RequiresRestart) > 0 {
+		c.bot.auditLog().Event(path, audit.ConfigReload, "config reloaded after file change")
+	}
+}
+
+// logConfigReload logs the outcome of a config reload
+func logConfigReload(r *ConfigReloadResult) {
+	if len(r.Applied) == 0 && len(r.RequiresRestart) == 0 && len(r.Failed) == 0 {
+		log.Debugln(log.ConfigMgr, "Config reloaded, no changes found.")
+		return
+	}
+	if len(r.Applied) > 0 {
+		log.Infof(log.ConfigMgr, "Config reloaded, applied: %s\n", strings.Join(r.Applied, ", "))
+	}
+	if len(r.RequiresRestart) > 0 {
+		log.Warnf(log.ConfigMgr, "Config reloaded, changes which require a restart: %s\n", strings.Join(r.RequiresRestart, ", "))
+	}
+	if len(r.Failed) > 0 {
+		log.Errorf(log.ConfigMgr, "Config reloaded, changes which failed to apply: %s\n", strings.Join(r.Failed, ", "))
+	}
+}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"sync/atomic"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
+var errInternetConnectivityLost = errors.New("internet connectivity lost")
+
 // connectionManager manages the connchecker
 type connectionManager struct {
 	started int32
@@ -65,3 +68,11 @@ func (c *connectionManager) IsOnline() bool {
 
 	return c.conn.IsConnected()
 }
+
+// Health reports lost internet connectivity to the subsystem registry
+func (c *connectionManager) Health() error {
+	if !c.IsOnline() {
+		return errInternetConnectivityLost
+	}
+	return nil
+}
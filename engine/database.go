@@ -14,19 +14,31 @@ import (
 	"github.com/thrasher-corp/sqlboiler/boil"
 )
 
-var (
-	dbConn *database.Instance
-)
+var errDatabaseDisconnected = errors.New("database connection lost")
 
 type databaseManager struct {
 	started  int32
 	shutdown chan struct{}
+	instance *database.Instance
 }
 
 func (a *databaseManager) Started() bool {
 	return atomic.LoadInt32(&a.started) == 1
 }
 
+// Health reports a lost database connection to the subsystem registry
+func (a *databaseManager) Health() error {
+	if a.instance == nil {
+		return nil
+	}
+	a.instance.Mu.RLock()
+	defer a.instance.Mu.RUnlock()
+	if !a.instance.Connected {
+		return errDatabaseDisconnected
+	}
+	return nil
+}
+
 func (a *databaseManager) Start(bot *Engine) (err error) {
 	if !atomic.CompareAndSwapInt32(&a.started, 0, 1) {
 		return fmt.Errorf("database manager %w", subsystem.ErrSubSystemAlreadyStarted)
@@ -41,6 +53,13 @@ func (a *databaseManager) Start(bot *Engine) (err error) {
 	log.Debugln(log.DatabaseMgr, "Database manager starting...")
 
 	a.shutdown = make(chan struct{})
+	a.instance = bot.DatabaseInstance()
+	// the package level instance is linked to the config when it is checked,
+	// any other instance needs to be linked to its engine's config here
+	if a.instance.Config == nil {
+		a.instance.Config = &bot.Config.Database
+		a.instance.DataPath = bot.Config.GetDataPath("database")
+	}
 
 	if bot.Config.Database.Enabled {
 		if bot.Config.Database.Driver == database.DBPostgreSQL {
@@ -49,19 +68,19 @@ func (a *databaseManager) Start(bot *Engine) (err error) {
 				bot.Config.Database.Host,
 				bot.Config.Database.Database,
 				bot.Config.Database.Driver)
-			dbConn, err = dbpsql.Connect()
+			_, err = dbpsql.ConnectInstance(a.instance)
 		} else if bot.Config.Database.Driver == database.DBSQLite ||
 			bot.Config.Database.Driver == database.DBSQLite3 {
 			log.Debugf(log.DatabaseMgr,
 				"Attempting to establish database connection to %s utilising %s driver\n",
 				bot.Config.Database.Database,
 				bot.Config.Database.Driver)
-			dbConn, err = dbsqlite3.Connect()
+			_, err = dbsqlite3.ConnectInstance(a.instance)
 		}
 		if err != nil {
 			return fmt.Errorf("database failed to connect: %v Some features that utilise a database will be unavailable", err)
 		}
-		dbConn.Connected = true
+		a.instance.Connected = true
 
 		DBLogger := database.Logger{}
 		if bot.Config.Database.Verbose {
@@ -84,7 +103,7 @@ func (a *databaseManager) Stop() error {
 		atomic.CompareAndSwapInt32(&a.started, 1, 0)
 	}()
 
-	err := dbConn.SQL.Close()
+	err := a.instance.SQL.Close()
 	if err != nil {
 		log.Errorf(log.DatabaseMgr, "Failed to close database: %v", err)
 	}
@@ -115,18 +134,18 @@ func (a *databaseManager) run(bot *Engine) {
 }
 
 func (a *databaseManager) checkConnection() {
-	dbConn.Mu.Lock()
-	defer dbConn.Mu.Unlock()
+	a.instance.Mu.Lock()
+	defer a.instance.Mu.Unlock()
 
-	err := dbConn.SQL.Ping()
+	err := a.instance.SQL.Ping()
 	if err != nil {
 		log.Errorf(log.DatabaseMgr, "Database connection error: %v\n", err)
-		dbConn.Connected = false
+		a.instance.Connected = false
 		return
 	}
 
-	if !dbConn.Connected {
+	if !a.instance.Connected {
 		log.Info(log.DatabaseMgr, "Database connection reestablished")
-		dbConn.Connected = true
+		a.instance.Connected = true
 	}
 }
@@ -0,0 +1,427 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/common/file/archive"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
+	dbexchange "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	tradesql "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Started returns the status of the database retention manager
+func (m *databaseRetentionManager) Started() bool {
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start applies the configured retention policies straight away and then on
+// every check interval
+func (m *databaseRetentionManager) Start(bot *Engine) error {
+	if bot == nil {
+		return errors.New("cannot start with nil bot")
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", databaseRetentionManagerName, subsystem.ErrSubSystemAlreadyStarted)
+	}
+	if !bot.DatabaseManager.Started() || !bot.DatabaseInstance().Connected {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+		return fmt.Errorf("%s %w", databaseRetentionManagerName, database.ErrDatabaseSupportDisabled)
+	}
+	if len(bot.Config.DatabaseRetention.Policies) == 0 {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+		return errNoRetentionPolicies
+	}
+	log.Debugf(log.DatabaseMgr, "%s starting...", databaseRetentionManagerName)
+
+	m.bot = bot
+	m.db = bot.DatabaseInstance()
+	m.interval = bot.Config.DatabaseRetention.CheckInterval
+	m.verbose = bot.Config.DatabaseRetention.Verbose
+	m.shutdown = make(chan struct{})
+
+	bot.ServicesWG.Add(1)
+	go m.run()
+	return nil
+}
+
+// Stop stops the database retention manager
+func (m *databaseRetentionManager) Stop() error {
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("%s %w", databaseRetentionManagerName, subsystem.ErrSubSystemNotStarted)
+	}
+	defer func() {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+	}()
+	log.Debugf(log.DatabaseMgr, "%s shutting down...", databaseRetentionManagerName)
+	close(m.shutdown)
+	return nil
+}
+
+func (m *databaseRetentionManager) run() {
+	log.Debugf(log.DatabaseMgr, "%s started.", databaseRetentionManagerName)
+	tick := time.NewTicker(m.interval)
+	defer func() {
+		tick.Stop()
+		m.bot.ServicesWG.Done()
+		log.Debugf(log.DatabaseMgr, "%s shutdown.", databaseRetentionManagerName)
+	}()
+
+	for {
+		cfg := m.bot.Config.DatabaseRetention
+		reports := m.enforce(m.db, &cfg, cfg.DryRun, time.Now())
+		for i := range reports {
+			logRetentionReport(&reports[i], m.verbose)
+		}
+		select {
+		case <-m.shutdown:
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+// enforce applies every retention policy to data older than the policy
+// allows. A dry run reports what would happen without changing any data
+func (m *databaseRetentionManager) enforce(db *database.Instance, cfg *config.DatabaseRetentionConfig, dryRun bool, now time.Time) []RetentionReport {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	reports := make([]RetentionReport, 0, len(cfg.Policies))
+	for i := range cfg.Policies {
+		p := &cfg.Policies[i]
+		r := RetentionReport{
+			Exchange: p.Exchange,
+			Asset:    p.Asset,
+			Action:   p.Action,
+			DryRun:   dryRun,
+		}
+		err := applyTradeRetention(db, p, cfg.ArchivePath, dryRun, now, &r)
+		if err == nil {
+			err = applyCandleRetention(db, p, cfg.ArchivePath, dryRun, now, &r)
+		}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+// applyTradeRetention downsamples trades older than the policy's trade
+// retention into candles, then archives or deletes them. Only whole UTC days
+// are processed so candles are never built from part of a day's trades
+func applyTradeRetention(db *database.Instance, p *config.RetentionPolicy, archivePath string, dryRun bool, now time.Time, r *RetentionReport) error {
+	if p.TradeRetention <= 0 {
+		return nil
+	}
+	r.TradeCutoff = now.Add(-p.TradeRetention).UTC().Truncate(retentionDay)
+	oldest, err := tradesql.New(db).GetOldestTimestamp(p.Exchange, p.Asset)
+	if errors.Is(err, tradesql.ErrNoTradesFound) || errors.Is(err, dbexchange.ErrNoExchangeFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for start := oldest.UTC().Truncate(retentionDay); start.Before(r.TradeCutoff); start = start.Add(retentionDay) {
+		end := start.Add(retentionDay)
+		trades, err := tradesql.New(db).GetByExchangeInRange(p.Exchange, p.Asset, start, end)
+		if err != nil {
+			return err
+		}
+		// the range is inclusive, trades on the boundary belong to the next
+		// day
+		for len(trades) > 0 && !trades[len(trades)-1].Timestamp.Before(end) {
+			trades = trades[:len(trades)-1]
+		}
+		if len(trades) == 0 {
+			continue
+		}
+		items, err := downsampleTrades(trades, kline.Interval(p.CandleInterval))
+		if err != nil {
+			return err
+		}
+		items, err = withoutStoredCandles(db, p, items, start, end)
+		if err != nil {
+			return err
+		}
+		r.TradesRemoved += int64(len(trades))
+		for i := range items {
+			r.CandlesCreated += int64(len(items[i].Candles))
+		}
+		name := retentionArchiveName(p, "trades", start)
+		if dryRun {
+			if p.Action == config.RetentionActionArchive {
+				r.Archives = append(r.Archives, uniqueArchivePath(archivePath, name))
+			}
+			continue
+		}
+		for i := range items {
+			if _, err = kline.StoreInInstance(db, &items[i], false); err != nil {
+				return err
+			}
+		}
+		if p.Action == config.RetentionActionArchive {
+			path, err := archiveRecords(archivePath, name, tradeRecords(trades))
+			if err != nil {
+				return err
+			}
+			r.Archives = append(r.Archives, path)
+		}
+		for i := 0; i < len(trades); i += maxRetentionDeleteBatch {
+			j := i + maxRetentionDeleteBatch
+			if j > len(trades) {
+				j = len(trades)
+			}
+			if err = tradesql.New(db).DeleteTrades(trades[i:j]...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyCandleRetention archives or deletes candles older than the policy's
+// candle retention across every pair and interval
+func applyCandleRetention(db *database.Instance, p *config.RetentionPolicy, archivePath string, dryRun bool, now time.Time, r *RetentionReport) error {
+	if p.CandleRetention <= 0 {
+		return nil
+	}
+	r.CandleCutoff = now.Add(-p.CandleRetention).UTC().Truncate(retentionDay)
+	oldest, err := candle.New(db).GetOldestTimestamp(p.Exchange, p.Asset)
+	if errors.Is(err, candle.ErrNoCandlesFound) || errors.Is(err, dbexchange.ErrNoExchangeFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for start := oldest.UTC().Truncate(retentionDay); start.Before(r.CandleCutoff); start = start.Add(retentionDay) {
+		// candle timestamps are whole seconds so the last second of the day
+		// is the inclusive end
+		end := start.Add(retentionDay - time.Second)
+		series, err := candle.New(db).SeriesByExchange(p.Exchange, p.Asset, start, end)
+		if err != nil {
+			return err
+		}
+		var count int64
+		for i := range series {
+			count += int64(len(series[i].Candles))
+		}
+		if count == 0 {
+			continue
+		}
+		r.CandlesRemoved += count
+		name := retentionArchiveName(p, "candles", start)
+		if dryRun {
+			if p.Action == config.RetentionActionArchive {
+				r.Archives = append(r.Archives, uniqueArchivePath(archivePath, name))
+			}
+			continue
+		}
+		if p.Action == config.RetentionActionArchive {
+			path, err := archiveRecords(archivePath, name, candleRecords(series))
+			if err != nil {
+				return err
+			}
+			r.Archives = append(r.Archives, path)
+		}
+		if _, err = candle.New(db).DeleteByExchangeInRange(p.Exchange, p.Asset, start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// downsampleTrades converts stored trades into candles, one item per pair
+func downsampleTrades(trades []tradesql.Data, interval kline.Interval) ([]kline.Item, error) {
+	grouped := make(map[string][]trade.Data)
+	var keys []string
+	for i := range trades {
+		pair, err := currency.NewPairFromStrings(trades[i].Base, trades[i].Quote)
+		if err != nil {
+			return nil, err
+		}
+		key := pair.String()
+		if _, ok := grouped[key]; !ok {
+			keys = append(keys, key)
+		}
+		grouped[key] = append(grouped[key], trade.Data{
+			Exchange:     trades[i].Exchange,
+			CurrencyPair: pair,
+			AssetType:    asset.Item(trades[i].AssetType),
+			Price:        trades[i].Price,
+			Amount:       trades[i].Amount,
+			Timestamp:    trades[i].Timestamp,
+		})
+	}
+	items := make([]kline.Item, 0, len(keys))
+	for i := range keys {
+		item, err := trade.ConvertTradesToCandles(interval, grouped[keys[i]]...)
+		if err != nil {
+			return nil, err
+		}
+		item.SortCandlesByTimestamp(false)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// withoutStoredCandles removes the downsampled candles that are already
+// stored so that candles synced from the exchange are never replaced by
+// candles built from the trades being removed
+func withoutStoredCandles(db *database.Instance, p *config.RetentionPolicy, items []kline.Item, start, end time.Time) ([]kline.Item, error) {
+	series, err := candle.New(db).SeriesByExchange(p.Exchange, p.Asset, start, end)
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string]bool)
+	for i := range series {
+		for j := range series[i].Candles {
+			stored[storedCandleKey(series[i].Base, series[i].Quote, series[i].Interval, series[i].Candles[j].Timestamp)] = true
+		}
+	}
+	resp := make([]kline.Item, 0, len(items))
+	for i := range items {
+		interval := int64(items[i].Interval.Duration().Seconds())
+		var candles []kline.Candle
+		for j := range items[i].Candles {
+			if stored[storedCandleKey(items[i].Pair.Base.Upper().String(),
+				items[i].Pair.Quote.Upper().String(),
+				interval,
+				items[i].Candles[j].Time.Truncate(items[i].Interval.Duration()))] {
+				continue
+			}
+			candles = append(candles, items[i].Candles[j])
+		}
+		if len(candles) == 0 {
+			continue
+		}
+		items[i].Candles = candles
+		resp = append(resp, items[i])
+	}
+	return resp, nil
+}
+
+func storedCandleKey(base, quote string, interval int64, ts time.Time) string {
+	return base + "-" + quote + "-" + strconv.FormatInt(interval, 10) + "-" + strconv.FormatInt(ts.Unix(), 10)
+}
+
+func tradeRecords(trades []tradesql.Data) [][]string {
+	records := [][]string{{"timestamp", "tid", "base", "quote", "price", "amount", "side"}}
+	for i := range trades {
+		records = append(records, []string{
+			trades[i].Timestamp.UTC().Format(time.RFC3339),
+			trades[i].TID,
+			trades[i].Base,
+			trades[i].Quote,
+			strconv.FormatFloat(trades[i].Price, 'f', -1, 64),
+			strconv.FormatFloat(trades[i].Amount, 'f', -1, 64),
+			trades[i].Side,
+		})
+	}
+	return records
+}
+
+func candleRecords(series []candle.Item) [][]string {
+	records := [][]string{{"timestamp", "base", "quote", "interval", "open", "high", "low", "close", "volume"}}
+	for i := range series {
+		for j := range series[i].Candles {
+			c := &series[i].Candles[j]
+			records = append(records, []string{
+				c.Timestamp.UTC().Format(time.RFC3339),
+				series[i].Base,
+				series[i].Quote,
+				strconv.FormatInt(series[i].Interval, 10),
+				strconv.FormatFloat(c.Open, 'f', -1, 64),
+				strconv.FormatFloat(c.High, 'f', -1, 64),
+				strconv.FormatFloat(c.Low, 'f', -1, 64),
+				strconv.FormatFloat(c.Close, 'f', -1, 64),
+				strconv.FormatFloat(c.Volume, 'f', -1, 64),
+			})
+		}
+	}
+	return records
+}
+
+func retentionArchiveName(p *config.RetentionPolicy, kind string, day time.Time) string {
+	return fmt.Sprintf("%s_%s_%s_%s", p.Exchange, p.Asset, kind, day.Format("2006-01-02"))
+}
+
+// uniqueArchivePath returns a path for a new archive that won't overwrite one
+// from an earlier run
+func uniqueArchivePath(dir, name string) string {
+	dest := filepath.Join(dir, name+".zip")
+	for i := 1; file.Exists(dest); i++ {
+		dest = filepath.Join(dir, name+"_"+strconv.Itoa(i)+".zip")
+	}
+	return dest
+}
+
+// archiveRecords writes records to a CSV file compressed into a zip archive
+// and returns the archive's path
+func archiveRecords(dir, name string, records [][]string) (string, error) {
+	tmp, err := ioutil.TempDir("", "gct-retention")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if errRm := os.RemoveAll(tmp); errRm != nil {
+			log.Errorf(log.DatabaseMgr, "%s unable to remove %s: %v", databaseRetentionManagerName, tmp, errRm)
+		}
+	}()
+	csvPath := filepath.Join(tmp, name+".csv")
+	if err = file.WriteAsCSV(csvPath, records); err != nil {
+		return "", err
+	}
+	if err = common.CreateDir(dir); err != nil {
+		return "", err
+	}
+	dest := uniqueArchivePath(dir, name)
+	return dest, archive.Zip(csvPath, dest)
+}
+
+func logRetentionReport(r *RetentionReport, verbose bool) {
+	if r.Error != "" {
+		log.Errorf(log.DatabaseMgr, "%s %s %s: %s", databaseRetentionManagerName, r.Exchange, r.Asset, r.Error)
+	}
+	if !verbose && !r.DryRun && r.TradesRemoved == 0 && r.CandlesRemoved == 0 {
+		return
+	}
+	prefix := ""
+	if r.DryRun {
+		prefix = "dry run "
+	}
+	verb := r.Action + "d"
+	log.Infof(log.DatabaseMgr, "%s %s%s %s: %d trades downsampled into %d candles and %s, %d candles %s, %d archives",
+		databaseRetentionManagerName,
+		prefix,
+		r.Exchange,
+		r.Asset,
+		r.TradesRemoved,
+		r.CandlesCreated,
+		verb,
+		r.CandlesRemoved,
+		verb,
+		len(r.Archives))
+	if verbose {
+		for i := range r.Archives {
+			log.Debugf(log.DatabaseMgr, "%s archive %s", databaseRetentionManagerName, r.Archives[i])
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
+	tradesql "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+)
+
+func TestDatabaseRetentionManagerStartStop(t *testing.T) {
+	var m databaseRetentionManager
+	if err := m.Start(nil); err == nil {
+		t.Error("expected error starting with nil bot")
+	}
+	if err := m.Stop(); !errors.Is(err, subsystem.ErrSubSystemNotStarted) {
+		t.Errorf("received '%v' expected '%v'", err, subsystem.ErrSubSystemNotStarted)
+	}
+	if err := m.Start(&Engine{}); !errors.Is(err, database.ErrDatabaseSupportDisabled) {
+		t.Errorf("received '%v' expected '%v'", err, database.ErrDatabaseSupportDisabled)
+	}
+	if m.Started() {
+		t.Error("manager should not be started without a database")
+	}
+}
+
+func TestDownsampleTrades(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	items, err := downsampleTrades([]tradesql.Data{
+		{Exchange: "bitstamp", Base: "BTC", Quote: "USD", AssetType: "spot", Price: 100, Amount: 1, Timestamp: day.Add(time.Minute * 70)},
+		{Exchange: "bitstamp", Base: "BTC", Quote: "USD", AssetType: "spot", Price: 120, Amount: 2, Timestamp: day.Add(time.Minute * 10)},
+		{Exchange: "bitstamp", Base: "BTC", Quote: "USD", AssetType: "spot", Price: 90, Amount: 1, Timestamp: day.Add(time.Minute * 20)},
+		{Exchange: "bitstamp", Base: "LTC", Quote: "USD", AssetType: "spot", Price: 50, Amount: 5, Timestamp: day},
+	}, kline.OneHour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(items), 2)
+	}
+	if !items[0].Pair.Equal(currency.NewPair(currency.BTC, currency.USD)) || len(items[0].Candles) != 2 {
+		t.Fatalf("unexpected item %+v", items[0])
+	}
+	c := items[0].Candles[0]
+	if !c.Time.Equal(day) || c.Open != 120 || c.Close != 90 || c.High != 120 || c.Low != 90 || c.Volume != 3 {
+		t.Errorf("unexpected candle %+v", c)
+	}
+	if !items[0].Candles[1].Time.Equal(day.Add(time.Hour)) {
+		t.Errorf("received '%v' expected '%v'", items[0].Candles[1].Time, day.Add(time.Hour))
+	}
+}
+
+func TestDatabaseRetention(t *testing.T) {
+	bot := RPCTestSetup(t)
+	defer CleanRPCTest(t, bot)
+
+	archiveDir, err := ioutil.TempDir("", "gct-retention-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(archiveDir)
+
+	now := time.Now().UTC()
+	old := now.Add(-time.Hour * 24 * 40).Truncate(retentionDay)
+	trades := []tradesql.Data{
+		{Exchange: testExchange, Base: "BTC", Quote: "USD", AssetType: "spot", Price: 100, Amount: 1, Side: "BUY", Timestamp: old.Add(time.Minute)},
+		{Exchange: testExchange, Base: "BTC", Quote: "USD", AssetType: "spot", Price: 110, Amount: 1, Side: "SELL", Timestamp: old.Add(time.Minute * 30)},
+		{Exchange: testExchange, Base: "BTC", Quote: "USD", AssetType: "spot", Price: 105, Amount: 1, Side: "BUY", Timestamp: old.Add(time.Hour * 25)},
+		{Exchange: testExchange, Base: "BTC", Quote: "USD", AssetType: "spot", Price: 200, Amount: 1, Side: "BUY", Timestamp: now.Add(-time.Hour)},
+	}
+	if err = tradesql.Insert(trades...); err != nil {
+		t.Fatal(err)
+	}
+	// a candle synced from the exchange is kept over the downsampled trades
+	_, err = kline.StoreInDatabase(&kline.Item{
+		Exchange: testExchange,
+		Pair:     currency.NewPair(currency.BTC, currency.USD),
+		Asset:    asset.Spot,
+		Interval: kline.OneHour,
+		Candles:  []kline.Candle{{Time: old, Open: 99, High: 120, Low: 90, Close: 111, Volume: 10}},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DatabaseRetentionConfig{
+		ArchivePath: archiveDir,
+		Policies: []config.RetentionPolicy{{
+			Exchange:       strings.ToLower(testExchange),
+			Asset:          "spot",
+			TradeRetention: time.Hour * 24 * 30,
+			CandleInterval: time.Hour,
+			Action:         config.RetentionActionArchive,
+		}},
+	}
+	var m databaseRetentionManager
+	reports := m.enforce(database.DB, &cfg, true, now)
+	if len(reports) != 1 {
+		t.Fatalf("received '%v' expected '%v'", len(reports), 1)
+	}
+	r := reports[0]
+	if r.Error != "" {
+		t.Fatal(r.Error)
+	}
+	if !r.DryRun || r.TradesRemoved != 3 || r.CandlesCreated != 1 || len(r.Archives) != 2 {
+		t.Errorf("unexpected dry run report %+v", r)
+	}
+	if files, _ := ioutil.ReadDir(archiveDir); len(files) != 0 {
+		t.Errorf("dry run should not write archives, found %v", len(files))
+	}
+	oldest, err := tradesql.GetOldestTimestamp(testExchange, "spot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !oldest.Equal(old.Add(time.Minute)) {
+		t.Errorf("dry run should not remove trades, oldest trade %v", oldest)
+	}
+
+	reports = m.enforce(database.DB, &cfg, false, now)
+	r = reports[0]
+	if r.Error != "" {
+		t.Fatal(r.Error)
+	}
+	if r.DryRun || r.TradesRemoved != 3 || r.CandlesCreated != 1 || len(r.Archives) != 2 {
+		t.Errorf("unexpected report %+v", r)
+	}
+	for i := range r.Archives {
+		if _, err = os.Stat(r.Archives[i]); err != nil {
+			t.Error(err)
+		}
+	}
+	oldest, err = tradesql.GetOldestTimestamp(testExchange, "spot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !oldest.Equal(now.Add(-time.Hour).Truncate(time.Second)) {
+		t.Errorf("received '%v' expected '%v'", oldest, now.Add(-time.Hour).Truncate(time.Second))
+	}
+	series, err := candle.Series(testExchange, "BTC", "USD", 3600, "spot", old, old.Add(retentionDay*2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.Candles) != 2 || series.Candles[0].Open != 99 || series.Candles[0].Close != 111 ||
+		series.Candles[1].Open != 105 {
+		t.Errorf("unexpected candles %+v", series.Candles)
+	}
+
+	// candles older than the candle retention are removed
+	cfg.Policies[0].Action = config.RetentionActionDelete
+	cfg.Policies[0].CandleRetention = time.Hour * 24 * 35
+	reports = m.enforce(database.DB, &cfg, false, now)
+	r = reports[0]
+	if r.Error != "" {
+		t.Fatal(r.Error)
+	}
+	if r.TradesRemoved != 0 || r.CandlesRemoved != 2 || len(r.Archives) != 0 {
+		t.Errorf("unexpected report %+v", r)
+	}
+	if _, err = candle.GetOldestTimestamp(testExchange, "spot"); !errors.Is(err, candle.ErrNoCandlesFound) {
+		t.Errorf("received '%v' expected '%v'", err, candle.ErrNoCandlesFound)
+	}
+
+	s := RPCServer{Engine: bot}
+	_, err = s.RunDatabaseRetention(context.Background(), &gctrpc.RunDatabaseRetentionRequest{})
+	if !errors.Is(err, errNoRetentionPolicies) {
+		t.Errorf("received '%v' expected '%v'", err, errNoRetentionPolicies)
+	}
+	bot.Config.DatabaseRetention = cfg
+	resp, err := s.RunDatabaseRetention(context.Background(), &gctrpc.RunDatabaseRetentionRequest{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Reports) != 1 || !resp.Reports[0].DryRun || resp.Reports[0].TradeCutoff == "" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestRetentionArchiveName(t *testing.T) {
+	t.Parallel()
+	p := config.RetentionPolicy{Exchange: "bitstamp", Asset: asset.Spot.String()}
+	name := retentionArchiveName(&p, "trades", time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC))
+	if name != "bitstamp_spot_trades_2020-01-02" {
+		t.Errorf("received '%v' expected '%v'", name, "bitstamp_spot_trades_2020-01-02")
+	}
+}
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+const (
+	databaseRetentionManagerName = "database retention manager"
+	// maxRetentionDeleteBatch limits how many trades are deleted per query to
+	// stay under database variable limits
+	maxRetentionDeleteBatch = 500
+	retentionDay            = time.Hour * 24
+)
+
+var errNoRetentionPolicies = errors.New("no database retention policies configured")
+
+// RetentionReport summarises what a retention run did, or would do in a dry
+// run, for a single policy
+type RetentionReport struct {
+	Exchange       string
+	Asset          string
+	Action         string
+	DryRun         bool
+	TradeCutoff    time.Time
+	CandleCutoff   time.Time
+	TradesRemoved  int64
+	CandlesCreated int64
+	CandlesRemoved int64
+	Archives       []string
+	Error          string
+}
+
+// databaseRetentionManager periodically applies retention policies to the
+// trade and candle tables so they don't grow without bound
+type databaseRetentionManager struct {
+	started  int32
+	shutdown chan struct{}
+	bot      *Engine
+	db       *database.Instance
+	interval time.Duration
+	verbose  bool
+	// mtx stops a scheduled run and an on demand run from processing the
+	// same data at once
+	mtx sync.Mutex
+}
@@ -0,0 +1,792 @@
+package engine
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/datahistoryjob"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/datahistoryjobresult"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	defaultDataHistoryRequestSizeLimit = 500
+	defaultDataHistoryMaxRetryAttempts = 3
+	defaultDataHistoryBatchSize        = 3
+)
+
+// String returns the name of the status
+func (d dataHistoryStatus) String() string {
+	switch d {
+	case dataHistoryStatusActive:
+		return "active"
+	case dataHistoryStatusFailed:
+		return "failed"
+	case dataHistoryStatusComplete:
+		return "complete"
+	case dataHistoryStatusRemoved:
+		return "removed"
+	case dataHistoryIntervalMissingData:
+		return "missing data"
+	}
+	return ""
+}
+
+// String returns the name of the data type
+func (d dataHistoryDataType) String() string {
+	switch d {
+	case dataHistoryCandleDataType:
+		return "candles"
+	case dataHistoryTradeDataType:
+		return "trades"
+	}
+	return ""
+}
+
+// Started returns the status of the data history manager
+func (m *dataHistoryManager) Started() bool {
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start loads all active jobs from the database and begins processing them
+// on the configured schedule
+func (m *dataHistoryManager) Start(bot *Engine) error {
+	if bot == nil {
+		return errors.New("cannot start with nil bot")
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", dataHistoryManagerName, subsystem.ErrSubSystemAlreadyStarted)
+	}
+	if !bot.DatabaseManager.Started() || !bot.DatabaseInstance().Connected {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+		return errDatabaseNotConnected
+	}
+	log.Debugf(log.DataHistory, "%s starting...", dataHistoryManagerName)
+
+	m.m.Lock()
+	m.bot = bot
+	m.db = bot.DatabaseInstance()
+	m.interval = bot.Config.DataHistoryManager.CheckInterval
+	m.maxJobsPerCycle = bot.Config.DataHistoryManager.MaxJobsPerCycle
+	m.verbose = bot.Config.DataHistoryManager.Verbose
+	m.shutdown = make(chan struct{})
+	m.jobs = nil
+	db := m.db
+	if m.candleLoader == nil {
+		m.candleLoader = func(exch string, p currency.Pair, a asset.Item, i kline.Interval, start, end time.Time) (kline.Item, error) {
+			return kline.LoadFromInstance(db, exch, p, a, i, start, end)
+		}
+	}
+	if m.candleSaver == nil {
+		m.candleSaver = func(in *kline.Item, force bool) (uint64, error) {
+			return kline.StoreInInstance(db, in, force)
+		}
+	}
+	if m.tradeIntervalLoader == nil {
+		m.tradeIntervalLoader = func(exch, a, base, quote string, start, end time.Time, i kline.Interval) ([]time.Time, error) {
+			return trade.GetIntervalsWithTradesFromInstance(db, exch, a, base, quote, start, end, i)
+		}
+	}
+	if m.tradeSaver == nil {
+		m.tradeSaver = func(trades ...trade.Data) error {
+			return trade.SaveTradesToInstance(db, trades...)
+		}
+	}
+	m.m.Unlock()
+
+	if err := m.loadActiveJobs(); err != nil {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+		return err
+	}
+
+	bot.ServicesWG.Add(1)
+	go m.run()
+	return nil
+}
+
+// Stop stops the data history manager, a job that is currently running will
+// finish its current request before the manager shuts down
+func (m *dataHistoryManager) Stop() error {
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("%s %w", dataHistoryManagerName, subsystem.ErrSubSystemNotStarted)
+	}
+	defer func() {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+	}()
+	log.Debugf(log.DataHistory, "%s shutting down...", dataHistoryManagerName)
+	close(m.shutdown)
+	return nil
+}
+
+func (m *dataHistoryManager) run() {
+	log.Debugf(log.DataHistory, "%s started.", dataHistoryManagerName)
+	tick := time.NewTicker(m.interval)
+	defer func() {
+		tick.Stop()
+		m.bot.ServicesWG.Done()
+		log.Debugf(log.DataHistory, "%s shutdown.", dataHistoryManagerName)
+	}()
+
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case <-tick.C:
+			m.bot.ServicesWG.Add(1)
+			go func() {
+				defer m.bot.ServicesWG.Done()
+				if err := m.runJobs(); err != nil {
+					log.Error(log.DataHistory, err)
+				}
+			}()
+		}
+	}
+}
+
+// loadActiveJobs replaces the in memory job list with the active jobs stored
+// in the database so that jobs resume after a restart
+func (m *dataHistoryManager) loadActiveJobs() error {
+	dbJobs, err := datahistoryjob.New(m.db).GetJobsByStatus(int64(dataHistoryStatusActive))
+	if err != nil {
+		return err
+	}
+	jobs := make([]*DataHistoryJob, 0, len(dbJobs))
+	for i := range dbJobs {
+		var job *DataHistoryJob
+		job, err = convertDBJob(&dbJobs[i])
+		if err != nil {
+			log.Errorf(log.DataHistory, "%s could not load job %s: %v", dataHistoryManagerName, dbJobs[i].Nickname, err)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	m.m.Lock()
+	m.jobs = jobs
+	m.m.Unlock()
+	return nil
+}
+
+// runJobs processes up to the configured amount of active jobs. Only one
+// processing cycle can run at a time
+func (m *dataHistoryManager) runJobs() error {
+	if !m.Started() {
+		return fmt.Errorf("%s %w", dataHistoryManagerName, subsystem.ErrSubSystemNotStarted)
+	}
+	if !atomic.CompareAndSwapInt32(&m.processing, 0, 1) {
+		return nil
+	}
+	defer atomic.StoreInt32(&m.processing, 0)
+
+	m.m.Lock()
+	jobs := make([]*DataHistoryJob, len(m.jobs))
+	copy(jobs, m.jobs)
+	m.m.Unlock()
+
+	var processed int64
+	for i := range jobs {
+		if m.maxJobsPerCycle > 0 && processed >= m.maxJobsPerCycle {
+			break
+		}
+		if jobs[i].Status != dataHistoryStatusActive {
+			continue
+		}
+		processed++
+		// jobs are run on a copy so that readers of the job list are not
+		// racing the processing
+		m.m.Lock()
+		job := *jobs[i]
+		m.m.Unlock()
+		if err := m.runJob(&job); err != nil {
+			log.Errorf(log.DataHistory, "%s job %s: %v", dataHistoryManagerName, job.Nickname, err)
+		}
+		if err := m.mergeJob(jobs[i], &job); err != nil {
+			log.Errorf(log.DataHistory, "%s job %s: %v", dataHistoryManagerName, job.Nickname, err)
+		}
+	}
+	return nil
+}
+
+// mergeJob applies the results and status of a processed copy to the stored
+// job it was taken from. Only the fields a run changes are merged so that a
+// job updated or removed while it was being processed keeps its newer
+// settings, in which case the run is discarded
+func (m *dataHistoryManager) mergeJob(original, ran *DataHistoryJob) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	for i := range m.jobs {
+		if m.jobs[i] != original {
+			continue
+		}
+		m.jobs[i].Results = ran.Results
+		if ran.Status == m.jobs[i].Status {
+			return nil
+		}
+		m.jobs[i].Status = ran.Status
+		if err := m.persistJob(m.jobs[i]); err != nil {
+			return err
+		}
+		if ran.Status != dataHistoryStatusActive {
+			m.jobs = append(m.jobs[:i], m.jobs[i+1:]...)
+		}
+		return nil
+	}
+	return nil
+}
+
+// runJob works out which intervals of the job are missing data, requests up
+// to the job's batch size worth of missing ranges from the exchange and
+// persists both the data and the outcome of each request
+func (m *dataHistoryManager) runJob(job *DataHistoryJob) error {
+	if job.Status != dataHistoryStatusActive {
+		return nil
+	}
+	exch := m.bot.GetExchangeByName(job.Exchange)
+	if exch == nil {
+		return fmt.Errorf("%s %w", job.Exchange, ErrExchangeNotFound)
+	}
+
+	dbResults, err := datahistoryjobresult.New(m.db).GetByJobID(job.ID.String())
+	if err != nil {
+		return err
+	}
+	job.Results = convertDBResults(dbResults)
+
+	ranges, err := m.findMissingRanges(job)
+	if err != nil {
+		return err
+	}
+
+	var requests int64
+	for i := range ranges {
+		select {
+		case <-m.shutdown:
+			return nil
+		default:
+		}
+		if requests >= job.BatchSize {
+			break
+		}
+		if job.exhaustedRetries(ranges[i].Start.Time) {
+			continue
+		}
+		requests++
+		result := m.processRange(exch, job, &ranges[i])
+		err = m.upsertResults(&result)
+		if err != nil {
+			return err
+		}
+		job.Results = append(job.Results, result)
+	}
+
+	// re-evaluate against everything the job now knows to determine whether
+	// any work remains
+	remaining := 0
+	failed := false
+	for i := range ranges {
+		if !job.rangeResolved(ranges[i].Start.Time) {
+			if job.exhaustedRetries(ranges[i].Start.Time) {
+				failed = true
+				continue
+			}
+			remaining++
+		}
+	}
+	if remaining > 0 {
+		return nil
+	}
+	job.Status = dataHistoryStatusComplete
+	if failed {
+		job.Status = dataHistoryStatusFailed
+	}
+	if m.verbose {
+		log.Infof(log.DataHistory, "%s job %s finished with status %s", dataHistoryManagerName, job.Nickname, job.Status)
+	}
+	return nil
+}
+
+// findMissingRanges uses the job's interval range holder to determine which
+// request sized ranges contain intervals without stored data
+func (m *dataHistoryManager) findMissingRanges(job *DataHistoryJob) ([]kline.IntervalRange, error) {
+	holder := kline.CalculateCandleDateRanges(job.StartDate, job.EndDate, job.Interval, uint32(job.RequestSizeLimit))
+	var existing []kline.Candle
+	switch job.DataType {
+	case dataHistoryCandleDataType:
+		item, err := m.candleLoader(job.Exchange, job.Pair, job.Asset, job.Interval, job.StartDate, job.EndDate)
+		if err != nil && m.verbose {
+			// no stored candles is reported as an error, in which case every
+			// interval is missing
+			log.Debugf(log.DataHistory, "%s job %s: %v", dataHistoryManagerName, job.Nickname, err)
+		}
+		existing = item.Candles
+	case dataHistoryTradeDataType:
+		// the database groups the trades by interval so only the intervals
+		// holding trades are loaded
+		intervals, err := m.tradeIntervalLoader(job.Exchange, job.Asset.String(), job.Pair.Base.String(), job.Pair.Quote.String(), job.StartDate, job.EndDate, job.Interval)
+		if err != nil {
+			return nil, err
+		}
+		existing = make([]kline.Candle, len(intervals))
+		for i := range intervals {
+			existing[i].Time = intervals[i]
+		}
+	default:
+		return nil, errUnknownDataType
+	}
+	// missing intervals are expected and are tracked in the holder itself
+	_ = holder.VerifyResultsHaveData(existing)
+
+	var missing []kline.IntervalRange
+	for i := range holder.Ranges {
+		if job.rangeResolved(holder.Ranges[i].Start.Time) {
+			continue
+		}
+		for j := range holder.Ranges[i].Intervals {
+			if !holder.Ranges[i].Intervals[j].HasData {
+				missing = append(missing, holder.Ranges[i])
+				break
+			}
+		}
+	}
+	return missing, nil
+}
+
+// processRange requests a single range of data from the exchange and saves
+// it, returning the outcome as a job result
+func (m *dataHistoryManager) processRange(exch exchange.IBotExchange, job *DataHistoryJob, r *kline.IntervalRange) DataHistoryJobResult {
+	result := DataHistoryJobResult{
+		JobID:             job.ID,
+		IntervalStartDate: r.Start.Time,
+		IntervalEndDate:   r.End.Time,
+		Status:            dataHistoryStatusComplete,
+		Date:              time.Now(),
+	}
+	var fetched []kline.Candle
+	switch job.DataType {
+	case dataHistoryCandleDataType:
+		item, err := exch.GetHistoricCandlesExtended(job.Pair, job.Asset, r.Start.Time, r.End.Time, job.Interval)
+		if err != nil {
+			result.Status = dataHistoryStatusFailed
+			result.Result = err.Error()
+			return result
+		}
+		if len(item.Candles) > 0 {
+			if _, err = m.candleSaver(&item, false); err != nil {
+				result.Status = dataHistoryStatusFailed
+				result.Result = err.Error()
+				return result
+			}
+		}
+		fetched = item.Candles
+	case dataHistoryTradeDataType:
+		trades, err := exch.GetHistoricTrades(job.Pair, job.Asset, r.Start.Time, r.End.Time)
+		if err != nil {
+			result.Status = dataHistoryStatusFailed
+			result.Result = err.Error()
+			return result
+		}
+		if len(trades) > 0 {
+			if err = m.tradeSaver(trades...); err != nil {
+				result.Status = dataHistoryStatusFailed
+				result.Result = err.Error()
+				return result
+			}
+		}
+		fetched = make([]kline.Candle, len(trades))
+		for i := range trades {
+			fetched[i].Time = trades[i].Timestamp
+		}
+	}
+
+	holder := kline.IntervalRangeHolder{
+		Start:  r.Start,
+		End:    r.End,
+		Ranges: []kline.IntervalRange{{Start: r.Start, End: r.End, Intervals: append([]kline.IntervalData(nil), r.Intervals...)}},
+	}
+	if err := holder.VerifyResultsHaveData(fetched); err != nil {
+		// the exchange had nothing for some intervals, record this so the
+		// range is not requested again
+		result.Status = dataHistoryIntervalMissingData
+		result.Result = err.Error()
+	}
+	if m.verbose {
+		log.Debugf(log.DataHistory, "%s job %s range %v - %v: %s", dataHistoryManagerName, job.Nickname, r.Start.Time, r.End.Time, result.Status)
+	}
+	return result
+}
+
+// rangeResolved returns whether a range has already been successfully
+// processed, including when the exchange had no data for it
+func (d *DataHistoryJob) rangeResolved(start time.Time) bool {
+	for i := range d.Results {
+		if d.Results[i].IntervalStartDate.Equal(start) &&
+			(d.Results[i].Status == dataHistoryStatusComplete ||
+				d.Results[i].Status == dataHistoryIntervalMissingData) {
+			return true
+		}
+	}
+	return false
+}
+
+// exhaustedRetries returns whether a range has failed as many times as the job
+// allows
+func (d *DataHistoryJob) exhaustedRetries(start time.Time) bool {
+	var failures int64
+	for i := range d.Results {
+		if d.Results[i].IntervalStartDate.Equal(start) &&
+			d.Results[i].Status == dataHistoryStatusFailed {
+			failures++
+		}
+	}
+	return failures >= d.MaxRetryAttempts
+}
+
+// UpsertJob validates and saves a job, updating an existing job with the same
+// nickname. When insertOnly is set an existing job results in an error
+func (m *dataHistoryManager) UpsertJob(job *DataHistoryJob, insertOnly bool) error {
+	if !m.Started() {
+		return fmt.Errorf("%s %w", dataHistoryManagerName, subsystem.ErrSubSystemNotStarted)
+	}
+	if job == nil {
+		return errInvalidDataHistoryJob
+	}
+	err := m.validateJob(job)
+	if err != nil {
+		return err
+	}
+
+	// the lock is held while the job is saved so that a job run finishing
+	// at the same time cannot overwrite the update
+	m.m.Lock()
+	defer m.m.Unlock()
+	existing, err := datahistoryjob.New(m.db).GetByNickname(job.Nickname)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if existing != nil {
+		if insertOnly {
+			return fmt.Errorf("%w: nickname %s already in use", errInvalidDataHistoryJob, job.Nickname)
+		}
+		job.ID, err = uuid.FromString(existing.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = m.persistJob(job)
+	if err != nil {
+		return err
+	}
+
+	for i := range m.jobs {
+		if m.jobs[i].ID != job.ID {
+			continue
+		}
+		if job.Status != dataHistoryStatusActive {
+			m.jobs = append(m.jobs[:i], m.jobs[i+1:]...)
+			return nil
+		}
+		m.jobs[i] = job
+		return nil
+	}
+	if job.Status == dataHistoryStatusActive {
+		m.jobs = append(m.jobs, job)
+	}
+	return nil
+}
+
+// validateJob checks a job is able to be run against a loaded exchange and
+// assigns defaults to unset limits
+func (m *dataHistoryManager) validateJob(job *DataHistoryJob) error {
+	if job.Nickname == "" {
+		return errNicknameUnset
+	}
+	if job.Exchange == "" || job.Pair.IsEmpty() || job.Asset == "" {
+		return fmt.Errorf("%w: exchange, pair and asset must be set", errInvalidDataHistoryJob)
+	}
+	if !job.Asset.IsValid() {
+		return fmt.Errorf("%w: %v", asset.ErrNotSupported, job.Asset)
+	}
+	if job.StartDate.IsZero() || job.EndDate.IsZero() || !job.StartDate.Before(job.EndDate) {
+		return fmt.Errorf("%w: start date must be before end date", errInvalidDataHistoryJob)
+	}
+	if job.Interval <= 0 {
+		return fmt.Errorf("%w: interval must be set", errInvalidDataHistoryJob)
+	}
+	switch job.Status {
+	case dataHistoryStatusActive, dataHistoryStatusFailed, dataHistoryStatusComplete, dataHistoryStatusRemoved:
+	default:
+		return fmt.Errorf("%w: %v", errInvalidJobStatus, job.Status)
+	}
+	exch := m.bot.GetExchangeByName(job.Exchange)
+	if exch == nil {
+		return fmt.Errorf("%s %w", job.Exchange, ErrExchangeNotFound)
+	}
+	b := exch.GetBase()
+	switch job.DataType {
+	case dataHistoryCandleDataType:
+		if err := b.ValidateKline(job.Pair, job.Asset, job.Interval); err != nil {
+			return err
+		}
+	case dataHistoryTradeDataType:
+		if err := b.SupportsPair(job.Pair, true, job.Asset); err != nil {
+			return err
+		}
+	default:
+		return errUnknownDataType
+	}
+	job.Exchange = strings.ToLower(job.Exchange)
+	job.Nickname = strings.ToLower(job.Nickname)
+	job.StartDate = job.StartDate.Truncate(job.Interval.Duration())
+	job.EndDate = job.EndDate.Truncate(job.Interval.Duration())
+	if job.RequestSizeLimit <= 0 {
+		job.RequestSizeLimit = defaultDataHistoryRequestSizeLimit
+	}
+	if job.MaxRetryAttempts <= 0 {
+		job.MaxRetryAttempts = defaultDataHistoryMaxRetryAttempts
+	}
+	if job.BatchSize <= 0 {
+		job.BatchSize = defaultDataHistoryBatchSize
+	}
+	return nil
+}
+
+// GetByNickname returns a job by its nickname along with its results
+func (m *dataHistoryManager) GetByNickname(nickname string) (*DataHistoryJob, error) {
+	if !m.Started() {
+		return nil, fmt.Errorf("%s %w", dataHistoryManagerName, subsystem.ErrSubSystemNotStarted)
+	}
+	if nickname == "" {
+		return nil, errNicknameUnset
+	}
+	return m.getJob(datahistoryjob.New(m.db).GetByNickname(nickname))
+}
+
+// GetByID returns a job by its ID along with its results
+func (m *dataHistoryManager) GetByID(id uuid.UUID) (*DataHistoryJob, error) {
+	if !m.Started() {
+		return nil, fmt.Errorf("%s %w", dataHistoryManagerName, subsystem.ErrSubSystemNotStarted)
+	}
+	return m.getJob(datahistoryjob.New(m.db).GetByID(id.String()))
+}
+
+func (m *dataHistoryManager) getJob(dbJob *datahistoryjob.DataHistoryJob, err error) (*DataHistoryJob, error) {
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errJobNotFound
+		}
+		return nil, err
+	}
+	job, err := convertDBJob(dbJob)
+	if err != nil {
+		return nil, err
+	}
+	dbResults, err := datahistoryjobresult.New(m.db).GetByJobID(dbJob.ID)
+	if err != nil {
+		return nil, err
+	}
+	job.Results = convertDBResults(dbResults)
+	return job, nil
+}
+
+// GetActiveJobs returns all jobs that have not finished
+func (m *dataHistoryManager) GetActiveJobs() ([]DataHistoryJob, error) {
+	if !m.Started() {
+		return nil, fmt.Errorf("%s %w", dataHistoryManagerName, subsystem.ErrSubSystemNotStarted)
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	var resp []DataHistoryJob
+	for i := range m.jobs {
+		if m.jobs[i].Status == dataHistoryStatusActive {
+			resp = append(resp, *m.jobs[i])
+		}
+	}
+	return resp, nil
+}
+
+// GetJobsBetween returns all jobs created between the supplied dates
+func (m *dataHistoryManager) GetJobsBetween(start, end time.Time) ([]DataHistoryJob, error) {
+	if !m.Started() {
+		return nil, fmt.Errorf("%s %w", dataHistoryManagerName, subsystem.ErrSubSystemNotStarted)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: start date must be before end date", errInvalidDataHistoryJob)
+	}
+	dbJobs, err := datahistoryjob.New(m.db).GetJobsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]DataHistoryJob, 0, len(dbJobs))
+	for i := range dbJobs {
+		var job *DataHistoryJob
+		job, err = convertDBJob(&dbJobs[i])
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, *job)
+	}
+	return resp, nil
+}
+
+// DeleteJob marks a job as removed so that it is no longer processed, its
+// results are kept for reference
+func (m *dataHistoryManager) DeleteJob(nickname, id string) error {
+	if !m.Started() {
+		return fmt.Errorf("%s %w", dataHistoryManagerName, subsystem.ErrSubSystemNotStarted)
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	var dbJob *datahistoryjob.DataHistoryJob
+	var err error
+	switch {
+	case nickname != "" && id != "":
+		return fmt.Errorf("%w: set either nickname or id, not both", errInvalidDataHistoryJob)
+	case nickname != "":
+		dbJob, err = datahistoryjob.New(m.db).GetByNickname(nickname)
+	case id != "":
+		dbJob, err = datahistoryjob.New(m.db).GetByID(id)
+	default:
+		return errNicknameUnset
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errJobNotFound
+		}
+		return err
+	}
+	dbJob.Status = int64(dataHistoryStatusRemoved)
+	err = datahistoryjob.New(m.db).Upsert(dbJob)
+	if err != nil {
+		return err
+	}
+
+	for i := range m.jobs {
+		if m.jobs[i].ID.String() == dbJob.ID {
+			m.jobs = append(m.jobs[:i], m.jobs[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *dataHistoryManager) persistJob(job *DataHistoryJob) error {
+	dbJob := &datahistoryjob.DataHistoryJob{
+		Nickname:         job.Nickname,
+		ExchangeName:     job.Exchange,
+		Asset:            job.Asset.String(),
+		Base:             job.Pair.Base.String(),
+		Quote:            job.Pair.Quote.String(),
+		StartDate:        job.StartDate,
+		EndDate:          job.EndDate,
+		Interval:         int64(job.Interval.Duration()),
+		DataType:         int64(job.DataType),
+		RequestSizeLimit: job.RequestSizeLimit,
+		MaxRetryAttempts: job.MaxRetryAttempts,
+		BatchSize:        job.BatchSize,
+		Status:           int64(job.Status),
+		CreatedDate:      job.CreatedDate,
+	}
+	if job.ID != uuid.Nil {
+		dbJob.ID = job.ID.String()
+	}
+	err := datahistoryjob.New(m.db).Upsert(dbJob)
+	if err != nil {
+		return err
+	}
+	job.ID, err = uuid.FromString(dbJob.ID)
+	if err != nil {
+		return err
+	}
+	job.CreatedDate = dbJob.CreatedDate
+	return nil
+}
+
+func (m *dataHistoryManager) upsertResults(results ...*DataHistoryJobResult) error {
+	dbResults := make([]*datahistoryjobresult.DataHistoryJobResult, len(results))
+	for i := range results {
+		dbResults[i] = &datahistoryjobresult.DataHistoryJobResult{
+			JobID:             results[i].JobID.String(),
+			Result:            results[i].Result,
+			Status:            int64(results[i].Status),
+			IntervalStartDate: results[i].IntervalStartDate,
+			IntervalEndDate:   results[i].IntervalEndDate,
+			Date:              results[i].Date,
+		}
+		if results[i].ID != uuid.Nil {
+			dbResults[i].ID = results[i].ID.String()
+		}
+	}
+	err := datahistoryjobresult.New(m.db).Upsert(dbResults...)
+	if err != nil {
+		return err
+	}
+	for i := range dbResults {
+		results[i].ID, err = uuid.FromString(dbResults[i].ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func convertDBJob(dbJob *datahistoryjob.DataHistoryJob) (*DataHistoryJob, error) {
+	id, err := uuid.FromString(dbJob.ID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := asset.New(dbJob.Asset)
+	if err != nil {
+		return nil, err
+	}
+	return &DataHistoryJob{
+		ID:               id,
+		Nickname:         dbJob.Nickname,
+		Exchange:         dbJob.ExchangeName,
+		Asset:            a,
+		Pair:             currency.NewPair(currency.NewCode(dbJob.Base), currency.NewCode(dbJob.Quote)),
+		StartDate:        dbJob.StartDate,
+		EndDate:          dbJob.EndDate,
+		Interval:         kline.Interval(dbJob.Interval),
+		DataType:         dataHistoryDataType(dbJob.DataType),
+		RequestSizeLimit: dbJob.RequestSizeLimit,
+		MaxRetryAttempts: dbJob.MaxRetryAttempts,
+		BatchSize:        dbJob.BatchSize,
+		Status:           dataHistoryStatus(dbJob.Status),
+		CreatedDate:      dbJob.CreatedDate,
+	}, nil
+}
+
+func convertDBResults(dbResults []datahistoryjobresult.DataHistoryJobResult) []DataHistoryJobResult {
+	resp := make([]DataHistoryJobResult, 0, len(dbResults))
+	for i := range dbResults {
+		id, err := uuid.FromString(dbResults[i].ID)
+		if err != nil {
+			continue
+		}
+		jobID, err := uuid.FromString(dbResults[i].JobID)
+		if err != nil {
+			continue
+		}
+		resp = append(resp, DataHistoryJobResult{
+			ID:                id,
+			JobID:             jobID,
+			IntervalStartDate: dbResults[i].IntervalStartDate,
+			IntervalEndDate:   dbResults[i].IntervalEndDate,
+			Status:            dataHistoryStatus(dbResults[i].Status),
+			Result:            dbResults[i].Result,
+			Date:              dbResults[i].Date,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,415 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	dbexchange "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+)
+
+// dataHistoryTestSetup starts a data history manager against the rpc test
+// database with the fake passing exchange loaded and available to jobs
+func dataHistoryTestSetup(t *testing.T) *Engine {
+	t.Helper()
+	bot := RPCTestSetup(t)
+	err := addPassingFakeExchange(testExchange, bot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := bot.GetExchangeByName(fakePassExchange).GetBase()
+	b.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{currency.NewPair(currency.BTC, currency.USD)}, false)
+	b.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{currency.NewPair(currency.BTC, currency.USD)}, true)
+	err = dbexchange.Insert(dbexchange.Details{Name: fakePassExchange})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.Config.DataHistoryManager.CheckInterval = time.Hour
+	err = bot.DataHistoryManager.Start(bot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bot
+}
+
+func dataHistoryTestCleanup(t *testing.T, bot *Engine) {
+	t.Helper()
+	if bot.DataHistoryManager.Started() {
+		err := bot.DataHistoryManager.Stop()
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	CleanRPCTest(t, bot)
+}
+
+func testDataHistoryJob(nickname string) *DataHistoryJob {
+	end := time.Now().Truncate(kline.OneDay.Duration())
+	return &DataHistoryJob{
+		Nickname:  nickname,
+		Exchange:  fakePassExchange,
+		Asset:     asset.Spot,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		StartDate: end.AddDate(0, 0, -5),
+		EndDate:   end,
+		Interval:  kline.OneDay,
+	}
+}
+
+func TestDataHistoryManagerStartStop(t *testing.T) {
+	var m dataHistoryManager
+	if err := m.Start(nil); err == nil {
+		t.Error("expected error starting with nil bot")
+	}
+	if err := m.Stop(); !errors.Is(err, subsystem.ErrSubSystemNotStarted) {
+		t.Errorf("received '%v' expected '%v'", err, subsystem.ErrSubSystemNotStarted)
+	}
+	if err := m.Start(&Engine{}); !errors.Is(err, errDatabaseNotConnected) {
+		t.Errorf("received '%v' expected '%v'", err, errDatabaseNotConnected)
+	}
+	if m.Started() {
+		t.Error("manager should not be started without a database")
+	}
+
+	bot := dataHistoryTestSetup(t)
+	defer dataHistoryTestCleanup(t, bot)
+	if !bot.DataHistoryManager.Started() {
+		t.Fatal("expected manager to be started")
+	}
+	if err := bot.DataHistoryManager.Start(bot); !errors.Is(err, subsystem.ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v' expected '%v'", err, subsystem.ErrSubSystemAlreadyStarted)
+	}
+	if err := bot.DataHistoryManager.Stop(); err != nil {
+		t.Error(err)
+	}
+	if bot.DataHistoryManager.Started() {
+		t.Error("expected manager to be stopped")
+	}
+}
+
+func TestUpsertJob(t *testing.T) {
+	bot := dataHistoryTestSetup(t)
+	defer dataHistoryTestCleanup(t, bot)
+	m := &bot.DataHistoryManager
+
+	if err := m.UpsertJob(nil, false); !errors.Is(err, errInvalidDataHistoryJob) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidDataHistoryJob)
+	}
+	if err := m.UpsertJob(&DataHistoryJob{}, false); !errors.Is(err, errNicknameUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errNicknameUnset)
+	}
+	job := testDataHistoryJob("upsert")
+	job.EndDate = job.StartDate
+	if err := m.UpsertJob(job, false); !errors.Is(err, errInvalidDataHistoryJob) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidDataHistoryJob)
+	}
+	job = testDataHistoryJob("upsert")
+	job.Exchange = "bad"
+	if err := m.UpsertJob(job, false); !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("received '%v' expected '%v'", err, ErrExchangeNotFound)
+	}
+	job = testDataHistoryJob("upsert")
+	job.DataType = 1337
+	if err := m.UpsertJob(job, false); !errors.Is(err, errUnknownDataType) {
+		t.Errorf("received '%v' expected '%v'", err, errUnknownDataType)
+	}
+
+	job = testDataHistoryJob("UPSERT")
+	if err := m.UpsertJob(job, false); err != nil {
+		t.Fatal(err)
+	}
+	if job.Nickname != "upsert" {
+		t.Errorf("expected nickname to be lowercased, received %v", job.Nickname)
+	}
+	if job.RequestSizeLimit != defaultDataHistoryRequestSizeLimit ||
+		job.MaxRetryAttempts != defaultDataHistoryMaxRetryAttempts ||
+		job.BatchSize != defaultDataHistoryBatchSize {
+		t.Error("expected defaults to be applied")
+	}
+	if err := m.UpsertJob(testDataHistoryJob("upsert"), true); !errors.Is(err, errInvalidDataHistoryJob) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidDataHistoryJob)
+	}
+
+	update := testDataHistoryJob("upsert")
+	update.BatchSize = 10
+	if err := m.UpsertJob(update, false); err != nil {
+		t.Fatal(err)
+	}
+	if update.ID != job.ID {
+		t.Errorf("expected updated job to keep ID %v, received %v", job.ID, update.ID)
+	}
+	active, err := m.GetActiveJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 1 || active[0].BatchSize != 10 {
+		t.Errorf("expected a single updated active job, received %+v", active)
+	}
+
+	stored, err := m.GetByNickname("upsert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.ID != job.ID || stored.BatchSize != 10 {
+		t.Errorf("unexpected stored job %+v", stored)
+	}
+	if _, err = m.GetByNickname("missing"); !errors.Is(err, errJobNotFound) {
+		t.Errorf("received '%v' expected '%v'", err, errJobNotFound)
+	}
+
+	jobs, err := m.GetJobsBetween(time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 {
+		t.Errorf("expected 1 job, received %v", len(jobs))
+	}
+
+	if err = m.DeleteJob("upsert", job.ID.String()); !errors.Is(err, errInvalidDataHistoryJob) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidDataHistoryJob)
+	}
+	if err = m.DeleteJob("upsert", ""); err != nil {
+		t.Fatal(err)
+	}
+	active, err = m.GetActiveJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 0 {
+		t.Errorf("expected no active jobs, received %v", len(active))
+	}
+	stored, err = m.GetByID(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != dataHistoryStatusRemoved {
+		t.Errorf("received '%v' expected '%v'", stored.Status, dataHistoryStatusRemoved)
+	}
+}
+
+func TestRunJobs(t *testing.T) {
+	bot := dataHistoryTestSetup(t)
+	defer dataHistoryTestCleanup(t, bot)
+	m := &bot.DataHistoryManager
+
+	job := testDataHistoryJob("runjobs")
+	job.RequestSizeLimit = 2
+	job.BatchSize = 2
+	if err := m.UpsertJob(job, false); err != nil {
+		t.Fatal(err)
+	}
+	// five daily intervals split into ranges of two require three requests,
+	// the batch size leaves one range for the next cycle
+	if err := m.runJobs(); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := m.GetByNickname("runjobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Results) != 2 {
+		t.Fatalf("expected 2 results, received %v", len(stored.Results))
+	}
+	for i := range stored.Results {
+		// the fake exchange returns no data
+		if stored.Results[i].Status != dataHistoryIntervalMissingData {
+			t.Errorf("received '%v' expected '%v'", stored.Results[i].Status, dataHistoryIntervalMissingData)
+		}
+	}
+	if stored.Status != dataHistoryStatusActive {
+		t.Errorf("received '%v' expected '%v'", stored.Status, dataHistoryStatusActive)
+	}
+
+	if err = m.runJobs(); err != nil {
+		t.Fatal(err)
+	}
+	stored, err = m.GetByNickname("runjobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Results) != 3 {
+		t.Errorf("expected 3 results, received %v", len(stored.Results))
+	}
+	if stored.Status != dataHistoryStatusComplete {
+		t.Errorf("received '%v' expected '%v'", stored.Status, dataHistoryStatusComplete)
+	}
+	active, err := m.GetActiveJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 0 {
+		t.Errorf("expected completed job to be removed from active jobs, received %v", len(active))
+	}
+}
+
+func TestMergeJob(t *testing.T) {
+	bot := dataHistoryTestSetup(t)
+	defer dataHistoryTestCleanup(t, bot)
+	m := &bot.DataHistoryManager
+
+	job := testDataHistoryJob("mergejob")
+	if err := m.UpsertJob(job, false); err != nil {
+		t.Fatal(err)
+	}
+	m.m.Lock()
+	original := m.jobs[len(m.jobs)-1]
+	ran := *original
+	m.m.Unlock()
+	ran.Status = dataHistoryStatusComplete
+
+	// the job is updated while the stale copy is being processed
+	update := testDataHistoryJob("mergejob")
+	update.BatchSize = 10
+	if err := m.UpsertJob(update, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.mergeJob(original, &ran); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := m.GetByNickname("mergejob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != dataHistoryStatusActive {
+		t.Errorf("received '%v' expected '%v'", stored.Status, dataHistoryStatusActive)
+	}
+	if stored.BatchSize != 10 {
+		t.Errorf("received '%v' expected '%v'", stored.BatchSize, 10)
+	}
+	active, err := m.GetActiveJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 1 || active[0].BatchSize != 10 {
+		t.Error("expected the updated job to remain active")
+	}
+
+	m.m.Lock()
+	original = m.jobs[len(m.jobs)-1]
+	ran = *original
+	m.m.Unlock()
+	ran.Status = dataHistoryStatusComplete
+	if err = m.mergeJob(original, &ran); err != nil {
+		t.Fatal(err)
+	}
+	stored, err = m.GetByNickname("mergejob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != dataHistoryStatusComplete {
+		t.Errorf("received '%v' expected '%v'", stored.Status, dataHistoryStatusComplete)
+	}
+}
+
+func TestDataHistoryJobRetries(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := DataHistoryJob{MaxRetryAttempts: 2}
+	if job.rangeResolved(start) || job.exhaustedRetries(start) {
+		t.Error("job without results should not be resolved or exhausted")
+	}
+	job.Results = append(job.Results, DataHistoryJobResult{IntervalStartDate: start, Status: dataHistoryStatusFailed})
+	if job.exhaustedRetries(start) {
+		t.Error("a single failure should not exhaust retries")
+	}
+	job.Results = append(job.Results, DataHistoryJobResult{IntervalStartDate: start, Status: dataHistoryStatusFailed})
+	if !job.exhaustedRetries(start) {
+		t.Error("expected retries to be exhausted")
+	}
+	job.Results = append(job.Results, DataHistoryJobResult{IntervalStartDate: start, Status: dataHistoryIntervalMissingData})
+	if !job.rangeResolved(start) {
+		t.Error("a range the exchange has no data for should be resolved")
+	}
+}
+
+func TestDataHistoryRPC(t *testing.T) {
+	bot := dataHistoryTestSetup(t)
+	defer dataHistoryTestCleanup(t, bot)
+	s := RPCServer{Engine: bot}
+
+	_, err := s.UpsertDataHistoryJob(context.Background(), &gctrpc.UpsertDataHistoryJobRequest{})
+	if !errors.Is(err, errInvalidArguments) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidArguments)
+	}
+	job := testDataHistoryJob("rpc")
+	resp, err := s.UpsertDataHistoryJob(context.Background(), &gctrpc.UpsertDataHistoryJobRequest{
+		Nickname: job.Nickname,
+		Exchange: job.Exchange,
+		Asset:    job.Asset.String(),
+		Pair: &gctrpc.CurrencyPair{
+			Base:  job.Pair.Base.String(),
+			Quote: job.Pair.Quote.String(),
+		},
+		StartDate: job.StartDate.Format(common.SimpleTimeFormat),
+		EndDate:   job.EndDate.Format(common.SimpleTimeFormat),
+		Interval:  int64(job.Interval),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.JobId == "" {
+		t.Error("expected job id")
+	}
+
+	details, err := s.GetDataHistoryJobDetails(context.Background(), &gctrpc.GetDataHistoryJobDetailsRequest{Id: resp.JobId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.Nickname != "rpc" || details.Status != dataHistoryStatusActive.String() {
+		t.Errorf("unexpected job details %+v", details)
+	}
+	_, err = s.GetDataHistoryJobDetails(context.Background(), &gctrpc.GetDataHistoryJobDetailsRequest{Id: resp.JobId, Nickname: "rpc"})
+	if !errors.Is(err, errInvalidArguments) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidArguments)
+	}
+
+	active, err := s.GetActiveDataHistoryJobs(context.Background(), &gctrpc.GetInfoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active.Results) != 1 {
+		t.Errorf("expected 1 active job, received %v", len(active.Results))
+	}
+
+	between, err := s.GetDataHistoryJobsBetween(context.Background(), &gctrpc.GetDataHistoryJobsBetweenRequest{
+		StartDate: time.Now().UTC().Add(-time.Hour).Format(common.SimpleTimeFormat),
+		EndDate:   time.Now().UTC().Add(time.Hour).Format(common.SimpleTimeFormat),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(between.Results) != 1 {
+		t.Errorf("expected 1 job, received %v", len(between.Results))
+	}
+
+	_, err = s.DeleteDataHistoryJob(context.Background(), &gctrpc.DeleteDataHistoryJobRequest{Nickname: "rpc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.DeleteDataHistoryJob(context.Background(), &gctrpc.DeleteDataHistoryJobRequest{Nickname: "missing"})
+	if !errors.Is(err, errJobNotFound) {
+		t.Errorf("received '%v' expected '%v'", err, errJobNotFound)
+	}
+}
+
+func TestSummariseDataHistoryJobResults(t *testing.T) {
+	t.Parallel()
+	if s := summariseDataHistoryJobResults(nil); s != "" {
+		t.Errorf("expected empty summary, received %v", s)
+	}
+	s := summariseDataHistoryJobResults([]DataHistoryJobResult{
+		{Status: dataHistoryStatusComplete},
+		{Status: dataHistoryStatusComplete},
+		{Status: dataHistoryStatusFailed},
+	})
+	if s != "complete: 2, failed: 1" {
+		t.Errorf("unexpected summary %v", s)
+	}
+}
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+const dataHistoryManagerName = "data history manager"
+
+// dataHistoryStatus defines the status of a data history job or an
+// individual job result
+type dataHistoryStatus int64
+
+// Data history statuses. The values are persisted to the database so new
+// statuses must only ever be appended
+const (
+	dataHistoryStatusActive dataHistoryStatus = iota
+	dataHistoryStatusFailed
+	dataHistoryStatusComplete
+	dataHistoryStatusRemoved
+	dataHistoryIntervalMissingData
+)
+
+// dataHistoryDataType defines which kind of data a job gathers
+type dataHistoryDataType int64
+
+// Data history data types. The values are persisted to the database so new
+// types must only ever be appended
+const (
+	dataHistoryCandleDataType dataHistoryDataType = iota
+	dataHistoryTradeDataType
+)
+
+var (
+	errJobNotFound           = errors.New("data history job not found")
+	errNicknameUnset         = errors.New("data history job nickname unset")
+	errInvalidDataHistoryJob = errors.New("invalid data history job")
+	errUnknownDataType       = errors.New("unknown data history data type")
+	errInvalidJobStatus      = errors.New("invalid data history job status")
+	errDatabaseNotConnected  = errors.New("data history manager requires a connected database")
+)
+
+// DataHistoryJob gathers candle or trade history for a single exchange, asset
+// and pair over a date range, persisting its progress so that it can resume
+// after a restart
+type DataHistoryJob struct {
+	ID               uuid.UUID
+	Nickname         string
+	Exchange         string
+	Asset            asset.Item
+	Pair             currency.Pair
+	StartDate        time.Time
+	EndDate          time.Time
+	Interval         kline.Interval
+	DataType         dataHistoryDataType
+	RequestSizeLimit int64
+	MaxRetryAttempts int64
+	BatchSize        int64
+	Status           dataHistoryStatus
+	CreatedDate      time.Time
+	Results          []DataHistoryJobResult
+}
+
+// DataHistoryJobResult contains the outcome of a single request made for a
+// data history job
+type DataHistoryJobResult struct {
+	ID                uuid.UUID
+	JobID             uuid.UUID
+	IntervalStartDate time.Time
+	IntervalEndDate   time.Time
+	Status            dataHistoryStatus
+	Result            string
+	Date              time.Time
+}
+
+// dataHistoryManager runs data history jobs on a schedule, only requesting
+// data for the intervals that are not already stored
+type dataHistoryManager struct {
+	started         int32
+	processing      int32
+	shutdown        chan struct{}
+	bot             *Engine
+	db              *database.Instance
+	interval        time.Duration
+	maxJobsPerCycle int64
+	verbose         bool
+	m               sync.Mutex
+	jobs            []*DataHistoryJob
+
+	candleLoader        func(string, currency.Pair, asset.Item, kline.Interval, time.Time, time.Time) (kline.Item, error)
+	candleSaver         func(*kline.Item, bool) (uint64, error)
+	tradeIntervalLoader func(string, string, string, string, time.Time, time.Time, kline.Interval) ([]time.Time, error)
+	tradeSaver          func(...trade.Data) error
+}
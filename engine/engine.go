@@ -14,11 +14,20 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/currency/coinmarketcap"
+	"github.com/thrasher-corp/gocryptotrader/currency/valuation"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	gctlog "github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/metrics"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 	"github.com/thrasher-corp/gocryptotrader/utils"
@@ -37,18 +46,108 @@ type Engine struct {
 	OrderManager                orderManager
 	PortfolioManager            portfolioManager
 	CommsManager                commsManager
+	ExchangeHealthManager       exchangeHealthManager
+	DataHistoryManager          dataHistoryManager
+	BalanceSnapshotManager      balanceSnapshotManager
+	DatabaseRetentionManager    databaseRetentionManager
+	ConfigWatcher               configWatcher
 	exchangeManager             exchangeManager
 	DepositAddressManager       *DepositAddressManager
+	TickerService               *ticker.Service
+	OrderbookService            *orderbook.Service
+	AccountService              *account.Service
+	Database                    *database.Instance
 	Settings                    Settings
 	Uptime                      time.Time
 	ServicesWG                  sync.WaitGroup
+	websocketHub                *WebsocketHub
+	websocketHubMtx             sync.Mutex
+	subsystemRegistry           *subsystem.Registry
+	subsystemRegistryMtx        sync.Mutex
+	metricsRegistry             *metrics.Registry
+	metricsRegistryMtx          sync.Mutex
+	configReloadMtx             sync.Mutex
+	configRestartPending        bool
+	valuationGraph              *valuation.Graph
+	valuationGraphKey           string
+	valuationGraphBuilt         time.Time
+	valuationGraphMtx           sync.Mutex
 }
 
 // Vars for engine
 var (
+	// Bot is a compatibility shim for callers which still expect a single
+	// process wide engine. The engine package itself never reads it, so
+	// additional instances can be created with New or NewFromSettings and
+	// passed around explicitly
 	Bot *Engine
 )
 
+// tickerService returns the ticker service the engine and its exchanges use,
+// falling back to the package level service
+func (bot *Engine) tickerService() *ticker.Service {
+	if bot.TickerService != nil {
+		return bot.TickerService
+	}
+	return ticker.GetService()
+}
+
+// orderbookService returns the orderbook service the engine and its exchanges
+// use, falling back to the package level service
+func (bot *Engine) orderbookService() *orderbook.Service {
+	if bot.OrderbookService != nil {
+		return bot.OrderbookService
+	}
+	return orderbook.GetService()
+}
+
+// accountService returns the account service the engine and its exchanges
+// use, falling back to the package level service
+func (bot *Engine) accountService() *account.Service {
+	if bot.AccountService != nil {
+		return bot.AccountService
+	}
+	return account.GetService()
+}
+
+// SubscribeToExchangeTickers subscribes to the tickers the engine stores for
+// an exchange
+func (bot *Engine) SubscribeToExchangeTickers(exchangeName string) (dispatch.Pipe, error) {
+	return bot.tickerService().SubscribeToExchangeTickers(exchangeName)
+}
+
+// SubscribeToExchangeOrderbooks subscribes to the orderbooks the engine stores
+// for an exchange
+func (bot *Engine) SubscribeToExchangeOrderbooks(exchangeName string) (dispatch.Pipe, error) {
+	return bot.orderbookService().SubscribeToExchangeOrderbooks(exchangeName)
+}
+
+// SubscribeToExchangeTrades subscribes to the trades processed for an
+// exchange
+func (bot *Engine) SubscribeToExchangeTrades(exchangeName string) (dispatch.Pipe, error) {
+	return trade.SubscribeToExchangeTrades(exchangeName)
+}
+
+// SubscribeToExchangeOrders subscribes to the order updates published by the
+// order manager for an exchange
+func (bot *Engine) SubscribeToExchangeOrders(exchangeName string) (dispatch.Pipe, error) {
+	return order.SubscribeToExchangeOrders(exchangeName)
+}
+
+// DatabaseInstance returns the database instance the engine connects,
+// falling back to the package level instance
+func (bot *Engine) DatabaseInstance() *database.Instance {
+	if bot.Database != nil {
+		return bot.Database
+	}
+	return database.DB
+}
+
+// auditLog returns the audit log stored in the engine database instance
+func (bot *Engine) auditLog() *audit.Repository {
+	return audit.New(bot.DatabaseInstance())
+}
+
 // New starts a new engine
 func New() (*Engine, error) {
 	newEngineMutex.Lock()
@@ -143,6 +242,10 @@ func validateSettings(b *Engine, s *Settings, flagSet map[string]bool) {
 	b.Settings.EnableAllPairs = s.EnableAllPairs
 	b.Settings.EnableCoinmarketcapAnalysis = s.EnableCoinmarketcapAnalysis
 	b.Settings.EnableDatabaseManager = s.EnableDatabaseManager
+	b.Settings.EnableDataHistoryManager = s.EnableDataHistoryManager
+	b.Settings.EnableBalanceSnapshotManager = s.EnableBalanceSnapshotManager
+	b.Settings.EnableDatabaseRetentionManager = s.EnableDatabaseRetentionManager
+	b.Settings.EnableConfigWatcher = s.EnableConfigWatcher
 	b.Settings.EnableGCTScriptManager = s.EnableGCTScriptManager && (flagSet["gctscriptmanager"] || b.Config.GCTScript.Enabled)
 	b.Settings.MaxVirtualMachines = s.MaxVirtualMachines
 	b.Settings.EnableDispatcher = s.EnableDispatcher
@@ -203,6 +306,7 @@ func validateSettings(b *Engine, s *Settings, flagSet map[string]bool) {
 	b.Settings.EnableConnectivityMonitor = s.EnableConnectivityMonitor
 	b.Settings.EnableNTPClient = s.EnableNTPClient
 	b.Settings.EnableOrderManager = s.EnableOrderManager
+	b.Settings.EnableExchangeHealthManager = s.EnableExchangeHealthManager
 	b.Settings.EnableExchangeSyncManager = s.EnableExchangeSyncManager
 	b.Settings.EnableTickerSyncing = s.EnableTickerSyncing
 	b.Settings.EnableOrderbookSyncing = s.EnableOrderbookSyncing
@@ -245,6 +349,11 @@ func validateSettings(b *Engine, s *Settings, flagSet map[string]bool) {
 		request.MaxRetryAttempts = b.Settings.RequestMaxRetryAttempts
 	}
 
+	b.Settings.RequestShedThreshold = s.RequestShedThreshold
+	if b.Settings.RequestShedThreshold != request.DefaultMarketDataShedThreshold && s.RequestShedThreshold > 0 {
+		request.MarketDataShedThreshold = b.Settings.RequestShedThreshold
+	}
+
 	b.Settings.HTTPTimeout = s.HTTPTimeout
 	if s.HTTPTimeout != time.Duration(0) && s.HTTPTimeout > 0 {
 		b.Settings.HTTPTimeout = s.HTTPTimeout
@@ -292,11 +401,16 @@ func PrintSettings(s *Settings) {
 	gctlog.Debugf(gctlog.Global, "\t Enable event manager: %v", s.EnableEventManager)
 	gctlog.Debugf(gctlog.Global, "\t Event manager sleep delay: %v", s.EventManagerDelay)
 	gctlog.Debugf(gctlog.Global, "\t Enable order manager: %v", s.EnableOrderManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable exchange health manager: %v", s.EnableExchangeHealthManager)
 	gctlog.Debugf(gctlog.Global, "\t Enable exchange sync manager: %v", s.EnableExchangeSyncManager)
 	gctlog.Debugf(gctlog.Global, "\t Enable deposit address manager: %v\n", s.EnableDepositAddressManager)
 	gctlog.Debugf(gctlog.Global, "\t Enable websocket routine: %v\n", s.EnableWebsocketRoutine)
 	gctlog.Debugf(gctlog.Global, "\t Enable NTP client: %v", s.EnableNTPClient)
 	gctlog.Debugf(gctlog.Global, "\t Enable Database manager: %v", s.EnableDatabaseManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable data history manager: %v", s.EnableDataHistoryManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable balance snapshot manager: %v", s.EnableBalanceSnapshotManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable database retention manager: %v", s.EnableDatabaseRetentionManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable config watcher: %v", s.EnableConfigWatcher)
 	gctlog.Debugf(gctlog.Global, "\t Enable dispatcher: %v", s.EnableDispatcher)
 	gctlog.Debugf(gctlog.Global, "\t Dispatch package max worker amount: %d", s.DispatchMaxWorkerAmount)
 	gctlog.Debugf(gctlog.Global, "\t Dispatch package jobs limit: %d", s.DispatchJobsLimit)
@@ -323,6 +437,7 @@ func PrintSettings(s *Settings) {
 	gctlog.Debugf(gctlog.Global, "\t Enable exchange HTTP debugging: %v", s.EnableExchangeHTTPDebugging)
 	gctlog.Debugf(gctlog.Global, "\t Max HTTP request jobs: %v", s.MaxHTTPRequestJobsLimit)
 	gctlog.Debugf(gctlog.Global, "\t HTTP request max retry attempts: %v", s.RequestMaxRetryAttempts)
+	gctlog.Debugf(gctlog.Global, "\t HTTP request market data shed threshold: %v", s.RequestShedThreshold)
 	gctlog.Debugf(gctlog.Global, "\t Trade buffer processing interval: %v", s.TradeBufferProcessingInterval)
 	gctlog.Debugf(gctlog.Global, "\t HTTP timeout: %v", s.HTTPTimeout)
 	gctlog.Debugf(gctlog.Global, "\t HTTP user agent: %v", s.HTTPUserAgent)
@@ -348,29 +463,22 @@ func (bot *Engine) Start() error {
 	newEngineMutex.Lock()
 	defer newEngineMutex.Unlock()
 
+	// subsystems the exchanges rely on are started before they are set up
+	var early []string
 	if bot.Settings.EnableDatabaseManager {
-		if err := bot.DatabaseManager.Start(bot); err != nil {
-			gctlog.Errorf(gctlog.Global, "Database manager unable to start: %v", err)
-		}
+		early = append(early, databaseSubsystem)
 	}
-
 	if bot.Settings.EnableDispatcher {
-		if err := dispatch.Start(bot.Settings.DispatchMaxWorkerAmount, bot.Settings.DispatchJobsLimit); err != nil {
-			gctlog.Errorf(gctlog.DispatchMgr, "Dispatcher unable to start: %v", err)
-		}
+		early = append(early, dispatchSubsystem)
 	}
-
-	// Sets up internet connectivity monitor
 	if bot.Settings.EnableConnectivityMonitor {
-		if err := bot.ConnectionManager.Start(&bot.Config.ConnectionMonitor); err != nil {
-			gctlog.Errorf(gctlog.Global, "Connection manager unable to start: %v", err)
-		}
+		early = append(early, internetMonitorSubsystem)
 	}
-
 	if bot.Settings.EnableNTPClient {
-		if err := bot.NTPManager.Start(); err != nil {
-			gctlog.Errorf(gctlog.Global, "NTP manager unable to start: %v", err)
-		}
+		early = append(early, ntpTimekeeperSubsystem)
+	}
+	if err := bot.subsystems().StartAll(early...); err != nil {
+		return err
 	}
 
 	bot.Uptime = time.Now()
@@ -404,17 +512,48 @@ func (bot *Engine) Start() error {
 		return err
 	}
 
+	var managed []string
 	if bot.Settings.EnableCommsRelayer {
-		if err = bot.CommsManager.Start(); err != nil {
-			gctlog.Errorf(gctlog.Global, "Communications manager unable to start: %v\n", err)
-		}
+		managed = append(managed, communicationsSubsystem)
+	}
+	if bot.Settings.EnableExchangeHealthManager {
+		managed = append(managed, exchangeHealthSubsystem)
+	}
+	if bot.Settings.EnableDataHistoryManager {
+		managed = append(managed, dataHistorySubsystem)
+	}
+	if bot.Settings.EnableBalanceSnapshotManager {
+		managed = append(managed, balanceSnapshotSubsystem)
+	}
+	if bot.Settings.EnableDatabaseRetentionManager {
+		managed = append(managed, databaseRetentionSubsystem)
+	}
+	if bot.Settings.EnablePortfolioManager {
+		managed = append(managed, portfolioSubsystem)
+	}
+	if bot.Settings.EnableOrderManager {
+		managed = append(managed, ordersSubsystem)
+	}
+	if bot.Settings.EnableExchangeSyncManager {
+		managed = append(managed, exchangeSyncerSubsystem)
+	}
+	if bot.Settings.EnableGCTScriptManager {
+		managed = append(managed, gctScriptSubsystem)
+	}
+	if bot.Settings.EnableConfigWatcher {
+		managed = append(managed, configWatcherSubsystem)
+	}
+	if err = bot.subsystems().StartAll(managed...); err != nil {
+		return err
 	}
+
 	if bot.Settings.EnableCoinmarketcapAnalysis ||
 		bot.Settings.EnableCurrencyConverter ||
 		bot.Settings.EnableCurrencyLayer ||
 		bot.Settings.EnableFixer ||
 		bot.Settings.EnableOpenExchangeRates ||
 		bot.Settings.EnableExchangeRateHost {
+		currency.SetRateCache(&databaseRateCache{db: bot.DatabaseInstance()})
 		err = currency.RunStorageUpdater(currency.BotOverrides{
 			Coinmarketcap:       bot.Settings.EnableCoinmarketcapAnalysis,
 			FxCurrencyConverter: bot.Settings.EnableCurrencyConverter,
@@ -447,44 +586,12 @@ func (bot *Engine) Start() error {
 
 	if bot.Settings.EnableWebsocketRPC {
 		go StartWebsocketServer(bot)
-		StartWebsocketHandler()
-	}
-
-	if bot.Settings.EnablePortfolioManager {
-		if err = bot.PortfolioManager.Start(); err != nil {
-			gctlog.Errorf(gctlog.Global, "Fund manager unable to start: %v", err)
-		}
+		bot.StartWebsocketHandler()
 	}
 
 	if bot.Settings.EnableDepositAddressManager {
 		bot.DepositAddressManager = new(DepositAddressManager)
-		go bot.DepositAddressManager.Sync()
-	}
-
-	if bot.Settings.EnableOrderManager {
-		if err = bot.OrderManager.Start(bot); err != nil {
-			gctlog.Errorf(gctlog.Global, "Order manager unable to start: %v", err)
-		}
-	}
-
-	if bot.Settings.EnableExchangeSyncManager {
-		exchangeSyncCfg := CurrencyPairSyncerConfig{
-			SyncTicker:           bot.Settings.EnableTickerSyncing,
-			SyncOrderbook:        bot.Settings.EnableOrderbookSyncing,
-			SyncTrades:           bot.Settings.EnableTradeSyncing,
-			SyncContinuously:     bot.Settings.SyncContinuously,
-			NumWorkers:           bot.Settings.SyncWorkers,
-			Verbose:              bot.Settings.Verbose,
-			SyncTimeoutREST:      bot.Settings.SyncTimeoutREST,
-			SyncTimeoutWebsocket: bot.Settings.SyncTimeoutWebsocket,
-		}
-
-		bot.ExchangeCurrencyPairManager, err = NewCurrencyPairSyncer(exchangeSyncCfg)
-		if err != nil {
-			gctlog.Warnf(gctlog.Global, "Unable to initialise exchange currency pair syncer. Err: %s", err)
-		} else {
-			go bot.ExchangeCurrencyPairManager.Start()
-		}
+		go bot.DepositAddressManager.Sync(bot)
 	}
 
 	if bot.Settings.EnableEventManager {
@@ -495,10 +602,8 @@ func (bot *Engine) Start() error {
 		go bot.WebsocketRoutine()
 	}
 
-	if bot.Settings.EnableGCTScriptManager {
-		if err := bot.GctScriptManager.Start(&bot.ServicesWG); err != nil {
-			gctlog.Errorf(gctlog.Global, "GCTScript manager unable to start: %v", err)
-		}
+	if err = bot.subsystems().Supervise(); err != nil {
+		gctlog.Errorf(gctlog.Global, "Subsystem supervisor unable to start: %v", err)
 	}
 
 	return nil
@@ -515,51 +620,47 @@ func (bot *Engine) Stop() {
 		bot.Config.Portfolio = portfolio.Portfolio
 	}
 
-	if bot.GctScriptManager.Started() {
-		if err := bot.GctScriptManager.Stop(); err != nil {
-			gctlog.Errorf(gctlog.Global, "GCTScript manager unable to stop. Error: %v", err)
-		}
-	}
-	if bot.OrderManager.Started() {
-		if err := bot.OrderManager.Stop(); err != nil {
-			gctlog.Errorf(gctlog.Global, "Order manager unable to stop. Error: %v", err)
-		}
-	}
-
-	if bot.NTPManager.Started() {
-		if err := bot.NTPManager.Stop(); err != nil {
-			gctlog.Errorf(gctlog.Global, "NTP manager unable to stop. Error: %v", err)
-		}
+	if err := bot.subsystems().StopSupervising(); err != nil && !errors.Is(err, subsystem.ErrSubSystemNotStarted) {
+		gctlog.Errorf(gctlog.Global, "Subsystem supervisor unable to stop. Error: %v", err)
 	}
 
-	if bot.CommsManager.Started() {
-		if err := bot.CommsManager.Stop(); err != nil {
-			gctlog.Errorf(gctlog.Global, "Communication manager unable to stop. Error: %v", err)
-		}
+	// the database and dispatcher are stopped last, once everything which
+	// depends on them has stopped
+	var managed []string
+	order, err := bot.subsystems().Order()
+	if err != nil {
+		gctlog.Errorf(gctlog.Global, "Unable to order subsystems for shutdown. Error: %v", err)
 	}
-
-	if bot.PortfolioManager.Started() {
-		if err := bot.PortfolioManager.Stop(); err != nil {
-			gctlog.Errorf(gctlog.Global, "Fund manager unable to stop. Error: %v", err)
+	for i := range order {
+		if order[i] != databaseSubsystem && order[i] != dispatchSubsystem {
+			managed = append(managed, order[i])
 		}
 	}
-
-	if bot.ConnectionManager.Started() {
-		if err := bot.ConnectionManager.Stop(); err != nil {
-			gctlog.Errorf(gctlog.Global, "Connection manager unable to stop. Error: %v", err)
+	if len(managed) > 0 {
+		if err = bot.subsystems().StopAll(managed...); err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to stop subsystems. Error: %v", err)
 		}
 	}
 
-	if bot.DatabaseManager.Started() {
-		if err := bot.DatabaseManager.Stop(); err != nil {
-			gctlog.Errorf(gctlog.Global, "Database manager unable to stop. Error: %v", err)
+	// config is saved before the database manager stops so the save can be
+	// audited
+	switch {
+	case bot.Settings.EnableDryRun:
+	case bot.configRestartRequired():
+		gctlog.Warnln(gctlog.Global,
+			"Config file contains reloaded changes which require a restart, not overwriting it.")
+	default:
+		err = bot.Config.SaveConfigToFile(bot.Settings.ConfigFile)
+		if err != nil {
+			gctlog.Errorln(gctlog.Global, "Unable to save config.")
+		} else {
+			gctlog.Debugln(gctlog.Global, "Config file saved successfully.")
+			bot.auditLog().Event(bot.Settings.ConfigFile, audit.ConfigSave, "config saved on shutdown")
 		}
 	}
 
-	if dispatch.IsRunning() {
-		if err := dispatch.Stop(); err != nil {
-			gctlog.Errorf(gctlog.DispatchMgr, "Dispatch system unable to stop. Error: %v", err)
-		}
+	if err = bot.subsystems().StopAll(databaseSubsystem, dispatchSubsystem); err != nil {
+		gctlog.Errorf(gctlog.Global, "Unable to stop subsystems. Error: %v", err)
 	}
 
 	if bot.Settings.EnableCoinmarketcapAnalysis ||
@@ -573,18 +674,9 @@ func (bot *Engine) Stop() {
 		}
 	}
 
-	if !bot.Settings.EnableDryRun {
-		err := bot.Config.SaveConfigToFile(bot.Settings.ConfigFile)
-		if err != nil {
-			gctlog.Errorln(gctlog.Global, "Unable to save config.")
-		} else {
-			gctlog.Debugln(gctlog.Global, "Config file saved successfully.")
-		}
-	}
-
 	// Wait for services to gracefully shutdown
 	bot.ServicesWG.Wait()
-	err := gctlog.CloseLogger()
+	err = gctlog.CloseLogger()
 	if err != nil {
 		log.Printf("Failed to close logger. Error: %v\n", err)
 	}
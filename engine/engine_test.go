@@ -5,6 +5,12 @@ import (
 	"testing"
 
 	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
 
 func TestLoadConfigWithSettings(t *testing.T) {
@@ -116,3 +122,61 @@ func TestStartStopTwoDoesNotCausePanic(t *testing.T) {
 	botOne.Stop()
 	botTwo.Stop()
 }
+
+func TestTwoEnginesAreIsolated(t *testing.T) {
+	t.Parallel()
+	newBot := func() *Engine {
+		bot, err := NewFromSettings(&Settings{
+			ConfigFile:   config.TestFile,
+			EnableDryRun: true,
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bot.TickerService = ticker.NewService()
+		bot.OrderbookService = orderbook.NewService()
+		bot.AccountService = account.NewService()
+		bot.Database = &database.Instance{}
+		if err = bot.LoadExchange(testExchange, false, nil); err != nil {
+			t.Fatal(err)
+		}
+		return bot
+	}
+	botOne, botTwo := newBot(), newBot()
+
+	for _, bot := range []*Engine{botOne, botTwo} {
+		b := bot.GetExchangeByName(testExchange).GetBase()
+		if b.GetTickerService() != bot.TickerService {
+			t.Error("exchange ticker service should be its engine's service")
+		}
+		if b.GetOrderbookService() != bot.OrderbookService {
+			t.Error("exchange orderbook service should be its engine's service")
+		}
+		if b.GetAccountService() != bot.AccountService {
+			t.Error("exchange account service should be its engine's service")
+		}
+		if b.GetDatabase() != bot.Database {
+			t.Error("exchange database should be its engine's instance")
+		}
+	}
+
+	cp := currency.NewPair(currency.DOGE, currency.XRP)
+	err := botOne.GetExchangeByName(testExchange).GetBase().GetTickerService().ProcessTicker(&ticker.Price{
+		ExchangeName: testExchange,
+		Pair:         cp,
+		AssetType:    asset.Spot,
+		Last:         1337,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = botOne.TickerService.GetTicker(testExchange, cp, asset.Spot); err != nil {
+		t.Error(err)
+	}
+	if _, err = botTwo.TickerService.GetTicker(testExchange, cp, asset.Spot); err == nil {
+		t.Error("ticker processed by one engine should not be visible to another")
+	}
+	if _, err = ticker.GetTicker(testExchange, cp, asset.Spot); err == nil {
+		t.Error("ticker processed by an engine should not be visible globally")
+	}
+}
@@ -15,28 +15,33 @@ type Settings struct {
 	CheckParamInteraction bool
 
 	// Core Settings
-	EnableDryRun                bool
-	EnableAllExchanges          bool
-	EnableAllPairs              bool
-	EnableCoinmarketcapAnalysis bool
-	EnablePortfolioManager      bool
-	PortfolioManagerDelay       time.Duration
-	EnableGRPC                  bool
-	EnableGRPCProxy             bool
-	EnableWebsocketRPC          bool
-	EnableDeprecatedRPC         bool
-	EnableCommsRelayer          bool
-	EnableExchangeSyncManager   bool
-	EnableDepositAddressManager bool
-	EnableEventManager          bool
-	EnableOrderManager          bool
-	EnableConnectivityMonitor   bool
-	EnableDatabaseManager       bool
-	EnableGCTScriptManager      bool
-	EnableNTPClient             bool
-	EnableWebsocketRoutine      bool
-	EventManagerDelay           time.Duration
-	Verbose                     bool
+	EnableDryRun                   bool
+	EnableAllExchanges             bool
+	EnableAllPairs                 bool
+	EnableCoinmarketcapAnalysis    bool
+	EnablePortfolioManager         bool
+	PortfolioManagerDelay          time.Duration
+	EnableGRPC                     bool
+	EnableGRPCProxy                bool
+	EnableWebsocketRPC             bool
+	EnableDeprecatedRPC            bool
+	EnableCommsRelayer             bool
+	EnableExchangeSyncManager      bool
+	EnableDepositAddressManager    bool
+	EnableEventManager             bool
+	EnableOrderManager             bool
+	EnableExchangeHealthManager    bool
+	EnableConnectivityMonitor      bool
+	EnableDatabaseManager          bool
+	EnableDataHistoryManager       bool
+	EnableBalanceSnapshotManager   bool
+	EnableDatabaseRetentionManager bool
+	EnableConfigWatcher            bool
+	EnableGCTScriptManager         bool
+	EnableNTPClient                bool
+	EnableWebsocketRoutine         bool
+	EventManagerDelay              time.Duration
+	Verbose                        bool
 
 	// Exchange syncer settings
 	EnableTickerSyncing    bool
@@ -66,6 +71,7 @@ type Settings struct {
 	MaxHTTPRequestJobsLimit        int
 	TradeBufferProcessingInterval  time.Duration
 	RequestMaxRetryAttempts        int
+	RequestShedThreshold           int
 
 	// Global HTTP related settings
 	GlobalHTTPTimeout   time.Duration
@@ -120,19 +120,11 @@ func GetEventCounter() (total, executed int) {
 	return total, executed
 }
 
-// ExecuteAction will execute the action pending on the chain
+// ExecuteAction will execute the action pending on the chain. Notification
+// actions are relayed by the event manager through its engine's comms manager
+// once the condition has been met
 func (e *Event) ExecuteAction() bool {
-	if strings.Contains(e.Action, ",") {
-		action := strings.Split(e.Action, ",")
-		if action[0] == ActionSMSNotify {
-			if action[1] == "ALL" {
-				Bot.CommsManager.PushEvent(base.Event{
-					Type:    "event",
-					Message: "Event triggered: " + e.String(),
-				})
-			}
-		}
-	} else {
+	if !strings.Contains(e.Action, ",") {
 		log.Debugf(log.EventMgr, "Event triggered: %s\n", e.String())
 	}
 	return true
@@ -296,7 +288,13 @@ func EventManger(verbose bool, comManager *commsManager) {
 							event.Exchange, event.String(),
 						)
 						log.Infoln(log.EventMgr, msg)
-						comManager.PushEvent(base.Event{Type: "event", Message: msg})
+						comManager.PushEvent(base.Event{
+							Type:     base.TriggeredEvent,
+							Message:  msg,
+							Exchange: event.Exchange,
+							Pair:     event.Pair,
+							Asset:    event.Asset,
+						})
 						event.Executed = true
 					}
 				}
@@ -26,6 +26,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ftx"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/gateio"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/gemini"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/generic"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/hitbtc"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/huobi"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/itbit"
@@ -218,7 +219,15 @@ func (bot *Engine) LoadExchange(name string, useWG bool, wg *sync.WaitGroup) err
 	case "zb":
 		exch = new(zb.ZB)
 	default:
-		return ErrExchangeNotFound
+		// Exchanges without a wrapper can be loaded from a declarative spec
+		exchCfg, err := bot.Config.GetExchangeConfig(name)
+		if err != nil || exchCfg.GenericSpec == "" {
+			return ErrExchangeNotFound
+		}
+		exch, err = generic.NewFromFile(exchCfg.GenericSpec)
+		if err != nil {
+			return err
+		}
 	}
 
 	if exch == nil {
@@ -281,6 +290,10 @@ func (bot *Engine) LoadExchange(name string, useWG bool, wg *sync.WaitGroup) err
 	}
 
 	localWG.Wait()
+	exch.GetBase().SetServices(bot.tickerService(),
+		bot.orderbookService(),
+		bot.accountService(),
+		bot.DatabaseInstance())
 	if !bot.Settings.EnableExchangeHTTPRateLimiter {
 		log.Warnf(log.ExchangeSys,
 			"Loaded exchange %s rate limiting has been turned off.\n",
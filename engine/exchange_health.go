@@ -0,0 +1,411 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Started returns the status of the exchange health manager
+func (h *exchangeHealthManager) Started() bool {
+	return atomic.LoadInt32(&h.started) == 1
+}
+
+// Start begins periodically checking the health of all loaded exchanges
+func (h *exchangeHealthManager) Start(bot *Engine) error {
+	if bot == nil {
+		return errors.New("cannot start with nil bot")
+	}
+	if !atomic.CompareAndSwapInt32(&h.started, 0, 1) {
+		return fmt.Errorf("exchange health manager %w", subsystem.ErrSubSystemAlreadyStarted)
+	}
+	log.Debugln(log.ExchangeSys, "Exchange health manager starting...")
+
+	h.m.Lock()
+	h.bot = bot
+	h.cfg = bot.Config.ExchangeHealth
+	h.exchanges = make(map[string]*ExchangeHealth)
+	h.lastStats = make(map[string]requestStats)
+	h.changed = make(chan struct{})
+	h.shutdown = make(chan struct{})
+	if h.mux == nil {
+		h.mux = dispatch.GetNewMux()
+		h.ids = make(map[string]uuid.UUID)
+	}
+	if h.allID == (uuid.UUID{}) {
+		id, err := h.mux.GetID()
+		if err != nil {
+			h.m.Unlock()
+			atomic.CompareAndSwapInt32(&h.started, 1, 0)
+			return err
+		}
+		h.allID = id
+	}
+	h.m.Unlock()
+
+	bot.ServicesWG.Add(1)
+	go h.run()
+	return nil
+}
+
+// Stop stops the exchange health manager
+func (h *exchangeHealthManager) Stop() error {
+	if atomic.LoadInt32(&h.started) == 0 {
+		return fmt.Errorf("exchange health manager %w", subsystem.ErrSubSystemNotStarted)
+	}
+	defer func() {
+		atomic.CompareAndSwapInt32(&h.started, 1, 0)
+	}()
+	log.Debugln(log.ExchangeSys, "Exchange health manager shutting down...")
+	close(h.shutdown)
+	return nil
+}
+
+func (h *exchangeHealthManager) run() {
+	log.Debugln(log.ExchangeSys, "Exchange health manager started.")
+	tick := time.NewTicker(h.cfg.CheckInterval)
+	defer func() {
+		tick.Stop()
+		h.bot.ServicesWG.Done()
+		log.Debugln(log.ExchangeSys, "Exchange health manager shutdown.")
+	}()
+
+	h.checkExchanges()
+	for {
+		select {
+		case <-h.shutdown:
+			return
+		case <-tick.C:
+			h.checkExchanges()
+		}
+	}
+}
+
+// checkExchanges gathers connectivity observations for every loaded exchange
+// and updates their health
+func (h *exchangeHealthManager) checkExchanges() {
+	exchanges := h.bot.GetExchanges()
+	for x := range exchanges {
+		in := h.gatherInputs(exchanges[x])
+		h.update(exchanges[x].GetName(), &in)
+	}
+}
+
+// gatherInputs collects the current connectivity observations for an exchange
+func (h *exchangeHealthManager) gatherInputs(exch exchange.IBotExchange) healthInputs {
+	in := healthInputs{
+		restSupported: exch.SupportsREST(),
+	}
+
+	if exch.IsWebsocketEnabled() {
+		ws, err := exch.GetWebsocket()
+		if err == nil && ws != nil {
+			in.websocketEnabled = true
+			in.websocketConnected = ws.IsConnected()
+			in.websocketConnecting = ws.IsConnecting()
+			in.lastTrafficTimeout = ws.GetLastTrafficTimeout()
+		}
+	}
+
+	if b := exch.GetBase(); b != nil && b.Requester != nil {
+		in.requests, in.failures = b.Requester.GetRequestStats()
+	}
+
+	skew, err := h.bot.NTPManager.GetClockSkew()
+	if err == nil &&
+		h.bot.Config.NTPClient.AllowedDifference != nil &&
+		h.bot.Config.NTPClient.AllowedNegativeDifference != nil {
+		in.clockSkew = skew
+		in.clockSkewExceeded = skew > *h.bot.Config.NTPClient.AllowedDifference ||
+			skew < -*h.bot.Config.NTPClient.AllowedNegativeDifference
+	}
+	return in
+}
+
+// update evaluates and stores an exchange's health, publishing the result to
+// dispatch subscribers and alerting on state changes
+func (h *exchangeHealthManager) update(exchName string, in *healthInputs) {
+	name := strings.ToLower(exchName)
+	h.m.Lock()
+	current, ok := h.exchanges[name]
+	if !ok {
+		current = &ExchangeHealth{Exchange: exchName, State: HealthUnknown}
+		h.exchanges[name] = current
+	}
+
+	// The REST error rate is only recalculated once enough requests have
+	// been sent since the last calculation, otherwise the previous rate holds
+	// until it expires. Without expiry an exchange which stops receiving REST
+	// traffic, for example because order submissions are blocked, would stay
+	// down on a stale error rate.
+	now := time.Now()
+	sampled := current.RESTRequests > 0
+	last := h.lastStats[name]
+	if requests := in.requests - last.requests; requests >= h.cfg.MinimumSamples {
+		failures := in.failures - last.failures
+		current.RESTRequests = requests
+		current.RESTFailures = failures
+		current.RESTErrorRate = float64(failures) / float64(requests)
+		h.lastStats[name] = requestStats{
+			requests: in.requests,
+			failures: in.failures,
+			sampled:  now,
+		}
+		sampled = true
+	} else if sampled && now.Sub(last.sampled) >= h.cfg.SampleExpiry {
+		current.RESTRequests = 0
+		current.RESTFailures = 0
+		current.RESTErrorRate = 0
+		sampled = false
+	}
+
+	state, reasons := evaluateHealth(&h.cfg, in, current.RESTErrorRate, sampled)
+	previous := current.State
+	current.Reasons = reasons
+	current.WebsocketEnabled = in.websocketEnabled
+	current.WebsocketConnected = in.websocketConnected
+	current.LastTrafficTimeout = in.lastTrafficTimeout
+	current.RESTSupported = in.restSupported
+	current.ClockSkew = in.clockSkew
+	current.LastChecked = now
+	if previous != state {
+		current.State = state
+		current.LastStateChange = now
+		// Wake up any order submissions waiting on a state change
+		close(h.changed)
+		h.changed = make(chan struct{})
+	}
+
+	id, ok := h.ids[name]
+	if !ok {
+		var err error
+		id, err = h.mux.GetID()
+		if err != nil {
+			log.Errorf(log.ExchangeSys, "Exchange health manager: %s unable to get dispatch ID: %v", exchName, err)
+		} else {
+			h.ids[name] = id
+		}
+	}
+	ids := []uuid.UUID{h.allID}
+	if id != (uuid.UUID{}) {
+		ids = append(ids, id)
+	}
+	cpy := *current
+	cpy.Reasons = append([]string(nil), current.Reasons...)
+	h.m.Unlock()
+
+	if err := h.mux.Publish(ids, &cpy); err != nil {
+		log.Errorf(log.ExchangeSys, "Exchange health manager: %s unable to publish health: %v", exchName, err)
+	}
+
+	if previous == state || previous == HealthUnknown && state == HealthHealthy {
+		return
+	}
+	msg := fmt.Sprintf("Exchange health manager: %s health changed from %s to %s",
+		exchName,
+		previous,
+		state)
+	if len(reasons) > 0 {
+		msg += " (" + strings.Join(reasons, ", ") + ")"
+	}
+	severity := base.SeverityInfo
+	switch state {
+	case HealthHealthy:
+		log.Infoln(log.ExchangeSys, msg)
+	case HealthDown:
+		severity = base.SeverityCritical
+		log.Warnln(log.ExchangeSys, msg)
+	default:
+		severity = base.SeverityWarning
+		log.Warnln(log.ExchangeSys, msg)
+	}
+	h.bot.CommsManager.PushEvent(base.Event{
+		Type:     base.ExchangeHealthEvent,
+		Message:  msg,
+		Severity: severity,
+		Exchange: exchName,
+	})
+}
+
+// evaluateHealth determines an exchange's health state and the reasons for it
+// from its connectivity observations. An exchange is down when every
+// transport it uses is unavailable and degraded when any issue is observed.
+func evaluateHealth(cfg *config.ExchangeHealthConfig, in *healthInputs, errorRate float64, sampled bool) (HealthState, []string) {
+	var reasons []string
+	wsDown := false
+	if in.websocketEnabled {
+		switch {
+		case in.websocketConnecting:
+			reasons = append(reasons, healthReasonWebsocketReconnecting)
+		case !in.websocketConnected:
+			wsDown = true
+			reasons = append(reasons, healthReasonWebsocketDown)
+		}
+		if !in.lastTrafficTimeout.IsZero() &&
+			time.Since(in.lastTrafficTimeout) < cfg.TrafficAlertWindow {
+			reasons = append(reasons, healthReasonTrafficTimeout)
+		}
+	}
+
+	restDown := false
+	if in.restSupported && sampled {
+		switch {
+		case errorRate >= cfg.DownErrorRate:
+			restDown = true
+			reasons = append(reasons, healthReasonRESTDown)
+		case errorRate >= cfg.DegradedErrorRate:
+			reasons = append(reasons, healthReasonRESTErrors)
+		}
+	}
+
+	if in.clockSkewExceeded {
+		reasons = append(reasons, healthReasonClockSkew)
+	}
+
+	switch {
+	case (in.websocketEnabled || in.restSupported) &&
+		(!in.websocketEnabled || wsDown) &&
+		(!in.restSupported || restDown):
+		return HealthDown, reasons
+	case len(reasons) > 0:
+		return HealthDegraded, reasons
+	default:
+		return HealthHealthy, nil
+	}
+}
+
+// GetExchangeHealth returns the current health of an exchange
+func (h *exchangeHealthManager) GetExchangeHealth(exchName string) (ExchangeHealth, error) {
+	if !h.Started() {
+		return ExchangeHealth{}, fmt.Errorf("exchange health manager %w", subsystem.ErrSubSystemNotStarted)
+	}
+	h.m.RLock()
+	defer h.m.RUnlock()
+	eh, ok := h.exchanges[strings.ToLower(exchName)]
+	if !ok {
+		return ExchangeHealth{}, fmt.Errorf("%s %w", exchName, errExchangeHealthNotFound)
+	}
+	cpy := *eh
+	cpy.Reasons = append([]string(nil), eh.Reasons...)
+	return cpy, nil
+}
+
+// GetAllExchangeHealth returns the current health of all tracked exchanges
+// sorted by exchange name
+func (h *exchangeHealthManager) GetAllExchangeHealth() ([]ExchangeHealth, error) {
+	if !h.Started() {
+		return nil, fmt.Errorf("exchange health manager %w", subsystem.ErrSubSystemNotStarted)
+	}
+	h.m.RLock()
+	resp := make([]ExchangeHealth, 0, len(h.exchanges))
+	for _, eh := range h.exchanges {
+		cpy := *eh
+		cpy.Reasons = append([]string(nil), eh.Reasons...)
+		resp = append(resp, cpy)
+	}
+	h.m.RUnlock()
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Exchange < resp[j].Exchange
+	})
+	return resp, nil
+}
+
+// Subscribe returns a dispatch pipe which receives ExchangeHealth updates for
+// an exchange, or for all exchanges if no exchange name is supplied
+func (h *exchangeHealthManager) Subscribe(exchName string) (dispatch.Pipe, error) {
+	if !h.Started() {
+		return dispatch.Pipe{}, fmt.Errorf("exchange health manager %w", subsystem.ErrSubSystemNotStarted)
+	}
+	h.m.RLock()
+	defer h.m.RUnlock()
+	if exchName == "" {
+		return h.mux.Subscribe(h.allID)
+	}
+	id, ok := h.ids[strings.ToLower(exchName)]
+	if !ok {
+		return dispatch.Pipe{}, fmt.Errorf("%s %w", exchName, errExchangeHealthNotFound)
+	}
+	return h.mux.Subscribe(id)
+}
+
+// CheckOrderSubmission applies the unhealthy order policy to an order about to
+// be submitted to an exchange. Submissions to an exchange that is down are
+// either blocked or held until the exchange recovers or the queue timeout
+// elapses.
+func (h *exchangeHealthManager) CheckOrderSubmission(exchName string) error {
+	if !h.Started() {
+		return nil
+	}
+
+	name := strings.ToLower(exchName)
+	var timeout <-chan time.Time
+	for {
+		h.m.RLock()
+		eh, ok := h.exchanges[name]
+		policy := h.cfg.UnhealthyOrderPolicy
+		changed := h.changed
+		shutdown := h.shutdown
+		var reasons string
+		down := ok && eh.State == HealthDown
+		if down {
+			reasons = strings.Join(eh.Reasons, ", ")
+		}
+		h.m.RUnlock()
+
+		if !down {
+			return nil
+		}
+
+		switch policy {
+		case config.UnhealthyOrderPolicyBlock:
+			return fmt.Errorf("%s %w: %s", exchName, ErrExchangeUnhealthy, reasons)
+		case config.UnhealthyOrderPolicyQueue:
+			if timeout == nil {
+				log.Warnf(log.OrderMgr,
+					"Order manager: %s is down, holding order submission for up to %s",
+					exchName,
+					h.cfg.OrderQueueTimeout)
+				timer := time.NewTimer(h.cfg.OrderQueueTimeout)
+				defer timer.Stop()
+				timeout = timer.C
+			}
+			select {
+			case <-changed:
+			case <-shutdown:
+				return nil
+			case <-timeout:
+				return fmt.Errorf("%s %w, order queue timeout of %s elapsed: %s",
+					exchName,
+					ErrExchangeUnhealthy,
+					h.cfg.OrderQueueTimeout,
+					reasons)
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+// IsWebsocketDown returns whether the exchange's websocket was found
+// disconnected on the last health check, allowing data syncing to fail over to
+// REST without waiting for the websocket sync timeout
+func (h *exchangeHealthManager) IsWebsocketDown(exchName string) bool {
+	if !h.Started() {
+		return false
+	}
+	h.m.RLock()
+	defer h.m.RUnlock()
+	eh, ok := h.exchanges[strings.ToLower(exchName)]
+	return ok && eh.WebsocketEnabled && !eh.WebsocketConnected
+}
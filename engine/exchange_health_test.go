@@ -0,0 +1,277 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+)
+
+func testHealthConfig() config.ExchangeHealthConfig {
+	return config.ExchangeHealthConfig{
+		CheckInterval:        time.Second,
+		TrafficAlertWindow:   time.Minute,
+		DegradedErrorRate:    0.2,
+		DownErrorRate:        0.8,
+		MinimumSamples:       5,
+		SampleExpiry:         time.Minute,
+		UnhealthyOrderPolicy: config.UnhealthyOrderPolicyAllow,
+		OrderQueueTimeout:    time.Second,
+	}
+}
+
+func TestEvaluateHealth(t *testing.T) {
+	t.Parallel()
+	cfg := testHealthConfig()
+	tests := []struct {
+		name     string
+		in       healthInputs
+		rate     float64
+		sampled  bool
+		expected HealthState
+		reasons  int
+	}{
+		{"rest only", healthInputs{restSupported: true}, 0, false, HealthHealthy, 0},
+		{"websocket connected", healthInputs{websocketEnabled: true, websocketConnected: true, restSupported: true}, 0, true, HealthHealthy, 0},
+		{"websocket down rest up", healthInputs{websocketEnabled: true, restSupported: true}, 0, true, HealthDegraded, 1},
+		{"websocket reconnecting", healthInputs{websocketEnabled: true, websocketConnecting: true}, 0, false, HealthDegraded, 1},
+		{"websocket only down", healthInputs{websocketEnabled: true}, 0, false, HealthDown, 1},
+		{"traffic timeout", healthInputs{websocketEnabled: true, websocketConnected: true, lastTrafficTimeout: time.Now()}, 0, false, HealthDegraded, 1},
+		{"stale traffic timeout", healthInputs{websocketEnabled: true, websocketConnected: true, lastTrafficTimeout: time.Now().Add(-time.Hour)}, 0, false, HealthHealthy, 0},
+		{"rest errors", healthInputs{restSupported: true}, 0.5, true, HealthDegraded, 1},
+		{"rest errors unsampled", healthInputs{restSupported: true}, 0.5, false, HealthHealthy, 0},
+		{"rest down", healthInputs{restSupported: true}, 1, true, HealthDown, 1},
+		{"rest down websocket up", healthInputs{websocketEnabled: true, websocketConnected: true, restSupported: true}, 1, true, HealthDegraded, 1},
+		{"everything down", healthInputs{websocketEnabled: true, restSupported: true}, 1, true, HealthDown, 2},
+		{"clock skew", healthInputs{restSupported: true, clockSkewExceeded: true}, 0, true, HealthDegraded, 1},
+		{"no transports", healthInputs{}, 0, false, HealthHealthy, 0},
+	}
+	for x := range tests {
+		state, reasons := evaluateHealth(&cfg, &tests[x].in, tests[x].rate, tests[x].sampled)
+		if state != tests[x].expected {
+			t.Errorf("%s: received '%v' expected '%v'", tests[x].name, state, tests[x].expected)
+		}
+		if len(reasons) != tests[x].reasons {
+			t.Errorf("%s: received '%v' reasons expected '%v'", tests[x].name, len(reasons), tests[x].reasons)
+		}
+	}
+}
+
+func TestExchangeHealthManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var h exchangeHealthManager
+	err := h.Start(nil)
+	if err == nil {
+		t.Fatal("expected error on nil bot")
+	}
+	err = h.Stop()
+	if !errors.Is(err, subsystem.ErrSubSystemNotStarted) {
+		t.Fatalf("received '%v' expected '%v'", err, subsystem.ErrSubSystemNotStarted)
+	}
+
+	bot := CreateTestBot(t)
+	bot.Config.ExchangeHealth = testHealthConfig()
+	err = h.Start(bot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = h.Start(bot)
+	if !errors.Is(err, subsystem.ErrSubSystemAlreadyStarted) {
+		t.Fatalf("received '%v' expected '%v'", err, subsystem.ErrSubSystemAlreadyStarted)
+	}
+	err = h.Stop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.ServicesWG.Wait()
+}
+
+func TestExchangeHealthUpdate(t *testing.T) {
+	t.Parallel()
+	bot := CreateTestBot(t)
+	bot.Config.ExchangeHealth = testHealthConfig()
+	var h exchangeHealthManager
+	_, err := h.GetExchangeHealth(testExchange)
+	if !errors.Is(err, subsystem.ErrSubSystemNotStarted) {
+		t.Fatalf("received '%v' expected '%v'", err, subsystem.ErrSubSystemNotStarted)
+	}
+	err = h.Start(bot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err = h.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	_, err = h.GetExchangeHealth("bogus")
+	if !errors.Is(err, errExchangeHealthNotFound) {
+		t.Fatalf("received '%v' expected '%v'", err, errExchangeHealthNotFound)
+	}
+
+	h.update("Test", &healthInputs{restSupported: true, requests: 10, failures: 10})
+	eh, err := h.GetExchangeHealth("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eh.State != HealthDown || eh.RESTRequests != 10 || eh.RESTErrorRate != 1 {
+		t.Fatalf("unexpected health %+v", eh)
+	}
+	if eh.LastStateChange.IsZero() {
+		t.Fatal("state change time should be set")
+	}
+
+	err = dispatch.Start(dispatch.DefaultMaxWorkers, dispatch.DefaultJobsLimit)
+	if err != nil && !dispatch.IsRunning() {
+		t.Fatal(err)
+	}
+	_, err = h.Subscribe("bogus")
+	if !errors.Is(err, errExchangeHealthNotFound) {
+		t.Fatalf("received '%v' expected '%v'", err, errExchangeHealthNotFound)
+	}
+	pipe, err := h.Subscribe("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err = pipe.Release(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// Too few requests since the last reading keeps the previous error rate
+	h.update("Test", &healthInputs{restSupported: true, requests: 12, failures: 10})
+	eh, err = h.GetExchangeHealth("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eh.State != HealthDown || eh.RESTRequests != 10 {
+		t.Fatalf("unexpected health %+v", eh)
+	}
+
+	h.update("Test", &healthInputs{restSupported: true, requests: 20, failures: 10})
+	eh, err = h.GetExchangeHealth("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eh.State != HealthHealthy || eh.RESTErrorRate != 0 {
+		t.Fatalf("unexpected health %+v", eh)
+	}
+
+	// An expired error rate no longer holds the exchange down when no new
+	// requests are sent
+	h.update("Test", &healthInputs{restSupported: true, requests: 30, failures: 20})
+	eh, err = h.GetExchangeHealth("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eh.State != HealthDown {
+		t.Fatalf("unexpected health %+v", eh)
+	}
+	h.m.Lock()
+	stats := h.lastStats["test"]
+	stats.sampled = stats.sampled.Add(-time.Minute)
+	h.lastStats["test"] = stats
+	h.m.Unlock()
+	h.update("Test", &healthInputs{restSupported: true, requests: 30, failures: 20})
+	eh, err = h.GetExchangeHealth("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eh.State != HealthHealthy || eh.RESTRequests != 0 || eh.RESTErrorRate != 0 {
+		t.Fatalf("unexpected health %+v", eh)
+	}
+
+	all, err := h.GetAllExchangeHealth()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) == 0 {
+		t.Fatal("expected exchange health")
+	}
+}
+
+func TestCheckOrderSubmission(t *testing.T) {
+	t.Parallel()
+	var h exchangeHealthManager
+	if err := h.CheckOrderSubmission("test"); err != nil {
+		t.Fatal("checks should be skipped when not started")
+	}
+
+	bot := CreateTestBot(t)
+	bot.Config.ExchangeHealth = testHealthConfig()
+	bot.Config.ExchangeHealth.OrderQueueTimeout = time.Millisecond * 50
+	if err := h.Start(bot); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := h.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+	h.update("Test", &healthInputs{websocketEnabled: true})
+
+	h.m.Lock()
+	h.cfg.UnhealthyOrderPolicy = config.UnhealthyOrderPolicyAllow
+	h.m.Unlock()
+	if err := h.CheckOrderSubmission("test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.CheckOrderSubmission("untracked"); err != nil {
+		t.Fatal(err)
+	}
+
+	h.m.Lock()
+	h.cfg.UnhealthyOrderPolicy = config.UnhealthyOrderPolicyBlock
+	h.m.Unlock()
+	if err := h.CheckOrderSubmission("test"); !errors.Is(err, ErrExchangeUnhealthy) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrExchangeUnhealthy)
+	}
+
+	h.m.Lock()
+	h.cfg.UnhealthyOrderPolicy = config.UnhealthyOrderPolicyQueue
+	h.m.Unlock()
+	if err := h.CheckOrderSubmission("test"); !errors.Is(err, ErrExchangeUnhealthy) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrExchangeUnhealthy)
+	}
+
+	errs := make(chan error)
+	go func() {
+		errs <- h.CheckOrderSubmission("test")
+	}()
+	time.Sleep(time.Millisecond * 10)
+	h.update("Test", &healthInputs{websocketEnabled: true, websocketConnected: true})
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsWebsocketDown(t *testing.T) {
+	t.Parallel()
+	var h exchangeHealthManager
+	if h.IsWebsocketDown("test") {
+		t.Fatal("should not report down when not started")
+	}
+	bot := CreateTestBot(t)
+	bot.Config.ExchangeHealth = testHealthConfig()
+	if err := h.Start(bot); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := h.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+	h.update("Test", &healthInputs{websocketEnabled: true})
+	if !h.IsWebsocketDown("test") {
+		t.Fatal("expected websocket down")
+	}
+	h.update("Test", &healthInputs{websocketEnabled: true, websocketConnected: true})
+	if h.IsWebsocketDown("test") {
+		t.Fatal("expected websocket up")
+	}
+}
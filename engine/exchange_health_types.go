@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+)
+
+// HealthState defines the connectivity health of an exchange
+type HealthState string
+
+// Exchange health states
+const (
+	HealthUnknown  HealthState = "unknown"
+	HealthHealthy  HealthState = "healthy"
+	HealthDegraded HealthState = "degraded"
+	HealthDown     HealthState = "down"
+)
+
+// Exchange health reasons
+const (
+	healthReasonWebsocketDown         = "websocket disconnected"
+	healthReasonWebsocketReconnecting = "websocket reconnecting"
+	healthReasonTrafficTimeout        = "websocket traffic timeout"
+	healthReasonRESTErrors            = "elevated REST error rate"
+	healthReasonRESTDown              = "REST requests failing"
+	healthReasonClockSkew             = "system clock skewed"
+)
+
+var (
+	errExchangeHealthNotFound = errors.New("exchange health not found")
+	// ErrExchangeUnhealthy is returned when an order is submitted to an
+	// exchange that is down and the unhealthy order policy blocks it
+	ErrExchangeUnhealthy = errors.New("exchange is unhealthy")
+)
+
+// ExchangeHealth holds the connectivity health of an exchange
+type ExchangeHealth struct {
+	Exchange           string
+	State              HealthState
+	Reasons            []string
+	WebsocketEnabled   bool
+	WebsocketConnected bool
+	LastTrafficTimeout time.Time
+	RESTSupported      bool
+	RESTRequests       uint32
+	RESTFailures       uint32
+	RESTErrorRate      float64
+	ClockSkew          time.Duration
+	LastStateChange    time.Time
+	LastChecked        time.Time
+}
+
+// healthInputs are the connectivity observations for a single exchange
+// health check
+type healthInputs struct {
+	websocketEnabled    bool
+	websocketConnected  bool
+	websocketConnecting bool
+	lastTrafficTimeout  time.Time
+	restSupported       bool
+	requests            uint32
+	failures            uint32
+	clockSkew           time.Duration
+	clockSkewExceeded   bool
+}
+
+// requestStats is a reading of an exchange requester's running totals and
+// when the REST error rate was last calculated from it
+type requestStats struct {
+	requests uint32
+	failures uint32
+	sampled  time.Time
+}
+
+// exchangeHealthManager tracks per exchange connectivity health by combining
+// websocket status, traffic monitor alerts, REST error rates and clock skew
+type exchangeHealthManager struct {
+	started   int32
+	shutdown  chan struct{}
+	bot       *Engine
+	cfg       config.ExchangeHealthConfig
+	m         sync.RWMutex
+	exchanges map[string]*ExchangeHealth
+	lastStats map[string]requestStats
+	changed   chan struct{}
+	mux       *dispatch.Mux
+	ids       map[string]uuid.UUID
+	allID     uuid.UUID
+}
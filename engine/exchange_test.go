@@ -1,8 +1,16 @@
 package engine
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/bitfinex"
 )
 
@@ -168,3 +176,61 @@ func TestDryRunParamInteraction(t *testing.T) {
 		t.Error("dryrun should be true and verbose should be true")
 	}
 }
+
+func TestLoadGenericExchange(t *testing.T) {
+	bot := CreateTestBot(t)
+
+	dir, err := ioutil.TempDir("", "generic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	specFile := filepath.Join(dir, "spectest.yaml")
+	spec := `
+name: Spectest
+requestFormat:
+  uppercase: true
+  delimiter: "-"
+configFormat:
+  uppercase: true
+  delimiter: "-"
+rest:
+  url: https://api.spectest.invalid
+  endpoints:
+    ticker:
+      path: /ticker/{symbol}
+      fields:
+        last: price
+`
+	err = ioutil.WriteFile(specFile, []byte(spec), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = bot.LoadExchange("Spectest", false, nil); !errors.Is(err, ErrExchangeNotFound) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrExchangeNotFound)
+	}
+
+	pm := &currency.PairsManager{}
+	pm.Store(asset.Spot, currency.PairStore{
+		AssetEnabled: convert.BoolPtr(true),
+		Available:    currency.Pairs{currency.NewPair(currency.BTC, currency.USD)},
+		Enabled:      currency.Pairs{currency.NewPair(currency.BTC, currency.USD)},
+	})
+	bot.Config.Exchanges = append(bot.Config.Exchanges, config.ExchangeConfig{
+		Name:          "Spectest",
+		GenericSpec:   specFile,
+		CurrencyPairs: pm,
+	})
+	if err = bot.LoadExchange("Spectest", false, nil); err != nil {
+		t.Fatal(err)
+	}
+	exch := bot.GetExchangeByName("spectest")
+	if exch == nil {
+		t.Fatal("generic exchange should be loaded")
+	}
+	if !exch.GetBase().Features.Supports.RESTCapabilities.TickerFetching {
+		t.Error("generic exchange features should be derived from the spec")
+	}
+}
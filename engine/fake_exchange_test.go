@@ -168,8 +168,10 @@ func (h *FakePassingExchange) SubmitOrder(_ *order.Submit) (order.SubmitResponse
 		OrderID:       "FakePassingExchangeOrder",
 	}, nil
 }
-func (h *FakePassingExchange) ModifyOrder(_ *order.Modify) (string, error) { return "", nil }
-func (h *FakePassingExchange) CancelOrder(_ *order.Cancel) error           { return nil }
+func (h *FakePassingExchange) ModifyOrder(m *order.Modify) (string, error) {
+	return m.ID + "-modified", nil
+}
+func (h *FakePassingExchange) CancelOrder(_ *order.Cancel) error { return nil }
 func (h *FakePassingExchange) CancelBatchOrders(_ []order.Cancel) (order.CancelBatchResponse, error) {
 	return order.CancelBatchResponse{}, nil
 }
@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/fxrate"
+)
+
+// databaseRateCache caches historical fiat rates for currency storage in the
+// database. Rates are not cached while database support is disabled
+type databaseRateCache struct {
+	db *database.Instance
+}
+
+// GetRate returns the rate stored for the day of the date
+func (d *databaseRateCache) GetRate(base, quote string, date time.Time) (rate float64, ok bool, err error) {
+	rates, err := fxrate.New(d.db).GetRates(date, base, quote)
+	if err != nil {
+		if errors.Is(err, database.ErrDatabaseSupportDisabled) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	if len(rates) == 0 {
+		return 0, false, nil
+	}
+	return rates[0].Rate, true, nil
+}
+
+// StoreRate stores the rate for the day of the date
+func (d *databaseRateCache) StoreRate(base, quote, provider string, rate float64, date time.Time) error {
+	err := fxrate.New(d.db).Insert(fxrate.Details{
+		Base:     base,
+		Quote:    quote,
+		Rate:     rate,
+		Provider: provider,
+		Date:     date,
+	})
+	if errors.Is(err, database.ErrDatabaseSupportDisabled) {
+		return nil
+	}
+	return err
+}
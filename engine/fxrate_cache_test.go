@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+func TestDatabaseRateCache(t *testing.T) {
+	c := databaseRateCache{db: &database.Instance{}}
+	_, ok, err := c.GetRate("USD", "AUD", time.Now())
+	if err != nil || ok {
+		t.Errorf("expected no rate without database support, received %v %v", ok, err)
+	}
+	if err = c.StoreRate("USD", "AUD", "test", 1.4, time.Now()); err != nil {
+		t.Error(err)
+	}
+
+	bot := RPCTestSetup(t)
+	defer CleanRPCTest(t, bot)
+	c.db = bot.DatabaseInstance()
+	date := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	_, ok, err = c.GetRate("USD", "AUD", date)
+	if err != nil || ok {
+		t.Errorf("expected no cached rate, received %v %v", ok, err)
+	}
+	if err = c.StoreRate("USD", "AUD", "test", 1.4, date); err != nil {
+		t.Fatal(err)
+	}
+	r, ok, err := c.GetRate("USD", "AUD", date.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || r != 1.4 {
+		t.Errorf("received %v %v expected %v", r, ok, 1.4)
+	}
+}
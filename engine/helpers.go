@@ -14,6 +14,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -21,7 +22,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/currency"
-	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/currency/valuation"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -41,19 +42,14 @@ var (
 // GetSubsystemsStatus returns the status of various subsystems
 func (bot *Engine) GetSubsystemsStatus() map[string]bool {
 	systems := make(map[string]bool)
-	systems["communications"] = bot.CommsManager.Started()
-	systems["internet_monitor"] = bot.ConnectionManager.Started()
-	systems["orders"] = bot.OrderManager.Started()
-	systems["portfolio"] = bot.PortfolioManager.Started()
-	systems["ntp_timekeeper"] = bot.NTPManager.Started()
-	systems["database"] = bot.DatabaseManager.Started()
-	systems["exchange_syncer"] = bot.Settings.EnableExchangeSyncManager
+	statuses := bot.subsystems().Statuses()
+	for i := range statuses {
+		systems[statuses[i].Name] = statuses[i].Running
+	}
 	systems["grpc"] = bot.Settings.EnableGRPC
 	systems["grpc_proxy"] = bot.Settings.EnableGRPCProxy
-	systems["gctscript"] = bot.GctScriptManager.Started()
 	systems["deprecated_rpc"] = bot.Settings.EnableDeprecatedRPC
 	systems["websocket_rpc"] = bot.Settings.EnableWebsocketRPC
-	systems["dispatch"] = dispatch.IsRunning()
 	return systems
 }
 
@@ -64,78 +60,33 @@ type RPCEndpoint struct {
 }
 
 // GetRPCEndpoints returns a list of RPC endpoints and their listen addrs
-func GetRPCEndpoints() map[string]RPCEndpoint {
+func (bot *Engine) GetRPCEndpoints() map[string]RPCEndpoint {
 	endpoints := make(map[string]RPCEndpoint)
 	endpoints["grpc"] = RPCEndpoint{
-		Started:    Bot.Settings.EnableGRPC,
-		ListenAddr: "grpc://" + Bot.Config.RemoteControl.GRPC.ListenAddress,
+		Started:    bot.Settings.EnableGRPC,
+		ListenAddr: "grpc://" + bot.Config.RemoteControl.GRPC.ListenAddress,
 	}
 	endpoints["grpc_proxy"] = RPCEndpoint{
-		Started:    Bot.Settings.EnableGRPCProxy,
-		ListenAddr: "http://" + Bot.Config.RemoteControl.GRPC.GRPCProxyListenAddress,
+		Started:    bot.Settings.EnableGRPCProxy,
+		ListenAddr: "http://" + bot.Config.RemoteControl.GRPC.GRPCProxyListenAddress,
 	}
 	endpoints["deprecated_rpc"] = RPCEndpoint{
-		Started:    Bot.Settings.EnableDeprecatedRPC,
-		ListenAddr: "http://" + Bot.Config.RemoteControl.DeprecatedRPC.ListenAddress,
+		Started:    bot.Settings.EnableDeprecatedRPC,
+		ListenAddr: "http://" + bot.Config.RemoteControl.DeprecatedRPC.ListenAddress,
 	}
 	endpoints["websocket_rpc"] = RPCEndpoint{
-		Started:    Bot.Settings.EnableWebsocketRPC,
-		ListenAddr: "ws://" + Bot.Config.RemoteControl.WebsocketRPC.ListenAddress,
+		Started:    bot.Settings.EnableWebsocketRPC,
+		ListenAddr: "ws://" + bot.Config.RemoteControl.WebsocketRPC.ListenAddress,
 	}
 	return endpoints
 }
 
 // SetSubsystem enables or disables an engine subsystem
 func (bot *Engine) SetSubsystem(subsys string, enable bool) error {
-	switch strings.ToLower(subsys) {
-	case "communications":
-		if enable {
-			return bot.CommsManager.Start()
-		}
-		return bot.CommsManager.Stop()
-	case "internet_monitor":
-		if enable {
-			return bot.ConnectionManager.Start(&bot.Config.ConnectionMonitor)
-		}
-		return bot.CommsManager.Stop()
-	case "orders":
-		if enable {
-			return bot.OrderManager.Start(bot)
-		}
-		return bot.OrderManager.Stop()
-	case "portfolio":
-		if enable {
-			return bot.PortfolioManager.Start()
-		}
-		return bot.OrderManager.Stop()
-	case "ntp_timekeeper":
-		if enable {
-			return bot.NTPManager.Start()
-		}
-		return bot.NTPManager.Stop()
-	case "database":
-		if enable {
-			return bot.DatabaseManager.Start(bot)
-		}
-		return bot.DatabaseManager.Stop()
-	case "exchange_syncer":
-		if enable {
-			bot.ExchangeCurrencyPairManager.Start()
-		}
-		bot.ExchangeCurrencyPairManager.Stop()
-	case "dispatch":
-		if enable {
-			return dispatch.Start(bot.Settings.DispatchMaxWorkerAmount, bot.Settings.DispatchJobsLimit)
-		}
-		return dispatch.Stop()
-	case "gctscript":
-		if enable {
-			return bot.GctScriptManager.Start(&bot.ServicesWG)
-		}
-		return bot.GctScriptManager.Stop()
+	if enable {
+		return bot.subsystems().Start(subsys)
 	}
-
-	return errors.New("subsystem not found")
+	return bot.subsystems().Stop(subsys)
 }
 
 // GetExchangeOTPs returns OTP codes for all exchanges which have a otpsecret
@@ -770,6 +721,45 @@ func (bot *Engine) GetAllEnabledExchangeAccountInfo() AllEnabledExchangeAccounts
 	return response
 }
 
+// valuationGraphCacheDuration is how long a built valuation graph is reused
+// before being rebuilt from the latest tickers
+var valuationGraphCacheDuration = time.Second * 5
+
+// GetValuationGraph returns a graph of conversion rates from the stored spot
+// tickers of all enabled exchanges and the forex rates of the configured
+// reporting currencies. The graph is cached briefly so that repeated
+// requests do not each rebuild it
+func (bot *Engine) GetValuationGraph() (*valuation.Graph, error) {
+	exchanges := bot.GetExchanges()
+	names := make([]string, 0, len(exchanges))
+	for x := range exchanges {
+		if exchanges[x] == nil || !exchanges[x].IsEnabled() {
+			continue
+		}
+		names = append(names, exchanges[x].GetName())
+	}
+	sort.Strings(names)
+	display := bot.Config.Currency.FiatDisplayCurrency
+	snapshot := currency.NewCode(bot.Config.BalanceSnapshot.FiatCurrency)
+	key := strings.Join(names, ",") + "|" + display.String() + "|" + snapshot.String()
+
+	bot.valuationGraphMtx.Lock()
+	defer bot.valuationGraphMtx.Unlock()
+	if bot.valuationGraph != nil &&
+		bot.valuationGraphKey == key &&
+		time.Since(bot.valuationGraphBuilt) < valuationGraphCacheDuration {
+		return bot.valuationGraph, nil
+	}
+	g, err := valuation.BuildFromService(bot.tickerService(), names, display, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	bot.valuationGraph = g
+	bot.valuationGraphKey = key
+	bot.valuationGraphBuilt = time.Now()
+	return g, nil
+}
+
 func verifyCert(pemData []byte) error {
 	var pemBlock *pem.Block
 	pemBlock, _ = pem.Decode(pemData)
@@ -733,6 +733,61 @@ func TestGetExchangeLowestPriceByCurrencyPair(t *testing.T) {
 	}
 }
 
+func TestGetValuationGraph(t *testing.T) {
+	bot := CreateTestBot(t)
+	bot.TickerService = ticker.NewService()
+	pair := currency.NewPair(currency.XRP, currency.USD)
+	tick := &ticker.Price{
+		ExchangeName: testExchange,
+		Pair:         pair,
+		AssetType:    asset.Spot,
+		Last:         1,
+		Volume:       100,
+		LastUpdated:  time.Now(),
+	}
+	if err := bot.TickerService.ProcessTicker(tick); err != nil {
+		t.Fatal(err)
+	}
+	g, err := bot.GetValuationGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := g.Value(10, currency.XRP, currency.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Value != 10 {
+		t.Errorf("received '%v' expected '%v'", v.Value, 10)
+	}
+
+	tick.Last = 2
+	if err = bot.TickerService.ProcessTicker(tick); err != nil {
+		t.Fatal(err)
+	}
+	cached, err := bot.GetValuationGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached != g {
+		t.Error("expected the valuation graph to be reused within the cache duration")
+	}
+
+	bot.valuationGraphBuilt = time.Now().Add(-valuationGraphCacheDuration)
+	rebuilt, err := bot.GetValuationGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rebuilt == g {
+		t.Error("expected the valuation graph to be rebuilt after the cache duration")
+	}
+	if v, err = rebuilt.Value(10, currency.XRP, currency.USD); err != nil {
+		t.Fatal(err)
+	}
+	if v.Value != 20 {
+		t.Errorf("received '%v' expected '%v'", v.Value, 20)
+	}
+}
+
 func TestGetCryptocurrenciesByExchange(t *testing.T) {
 	e := CreateTestBot(t)
 
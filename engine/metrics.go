@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/metrics"
+)
+
+// Order rejection reasons reported by the order submission metrics
+const (
+	orderRejectValidation       = "validation"
+	orderRejectExchangeNotFound = "exchange_not_found"
+	orderRejectLimits           = "limits"
+	orderRejectExchangeHealth   = "exchange_health"
+	orderRejectExchange         = "exchange"
+	orderRejectNotPlaced        = "not_placed"
+)
+
+// metrics returns the engine's metrics registry, creating it along with its
+// scrape time gauges on first use
+func (bot *Engine) metrics() *metrics.Registry {
+	bot.metricsRegistryMtx.Lock()
+	defer bot.metricsRegistryMtx.Unlock()
+	if bot.metricsRegistry == nil {
+		bot.metricsRegistry = bot.newMetricsRegistry()
+	}
+	return bot.metricsRegistry
+}
+
+// newMetricsRegistry registers the gauges which are read from engine state
+// when scraped. Exchange, request and websocket metrics live in the package
+// level registry as they are shared by every engine instance
+func (bot *Engine) newMetricsRegistry() *metrics.Registry {
+	r := metrics.NewRegistry()
+	gauges := []struct {
+		name   string
+		help   string
+		fn     func() []metrics.Sample
+		labels []string
+	}{
+		{"gct_dispatch_queue_depth", "Number of dispatch jobs waiting to be relayed", dispatchStat(func(queued, _, _ int) int { return queued }), nil},
+		{"gct_dispatch_workers", "Number of running dispatch workers", dispatchStat(func(_, workers, _ int) int { return workers }), nil},
+		{"gct_dispatch_max_workers", "Dispatch worker ceiling", dispatchStat(func(_, _, maxWorkers int) int { return maxWorkers }), nil},
+		{"gct_gctscript_running_vms", "Number of running gctscript virtual machines", runningVMs, nil},
+		{"gct_syncer_lag_seconds", "Seconds since the currency pair syncer last received an update", bot.syncerLag, []string{"exchange", "asset", "pair", "item"}},
+	}
+	for i := range gauges {
+		if err := r.GaugeFunc(gauges[i].name, gauges[i].help, gauges[i].fn, gauges[i].labels...); err != nil {
+			log.Errorf(log.Global, "Metrics unable to register %s: %v\n", gauges[i].name, err)
+		}
+	}
+	return r
+}
+
+// recordOrderSubmission counts an order submission and, when reason is set,
+// the stage at which it was rejected
+func (bot *Engine) recordOrderSubmission(exchName, reason string) {
+	r := bot.metrics()
+	r.Counter("gct_order_submissions_total",
+		"Number of orders submitted through the order manager",
+		"exchange").Inc(exchName)
+	if reason == "" {
+		return
+	}
+	r.Counter("gct_order_rejections_total",
+		"Number of submitted orders which were rejected",
+		"exchange", "reason").Inc(exchName, reason)
+}
+
+// RESTGetMetrics writes the engine and package level metrics in the
+// Prometheus text exposition format
+func (bot *Engine) RESTGetMetrics(w http.ResponseWriter, r *http.Request) {
+	metrics.Handler(bot.metrics(), metrics.GetDefault()).ServeHTTP(w, r)
+}
+
+func dispatchStat(fn func(queued, workers, maxWorkers int) int) func() []metrics.Sample {
+	return func() []metrics.Sample {
+		queued, workers, maxWorkers, err := dispatch.GetStats()
+		if err != nil {
+			return nil
+		}
+		return []metrics.Sample{{Value: float64(fn(queued, workers, maxWorkers))}}
+	}
+}
+
+func runningVMs() []metrics.Sample {
+	return []metrics.Sample{{Value: float64(gctscript.VMSCount.Len())}}
+}
+
+// syncerLag reports how long each enabled sync item has gone without an
+// update, items which have never received data are measured from when the
+// agent was created
+func (bot *Engine) syncerLag() []metrics.Sample {
+	e := bot.ExchangeCurrencyPairManager
+	if !e.Started() {
+		return nil
+	}
+	now := time.Now()
+	lag := func(s *SyncBase, created time.Time) float64 {
+		if !s.HaveData {
+			return now.Sub(created).Seconds()
+		}
+		return now.Sub(s.LastUpdated).Seconds()
+	}
+
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	var resp []metrics.Sample
+	for i := range e.CurrencyPairs {
+		c := &e.CurrencyPairs[i]
+		labels := func(item string) []string {
+			return []string{c.Exchange, c.AssetType.String(), c.Pair.String(), item}
+		}
+		if e.Cfg.SyncTicker {
+			resp = append(resp, metrics.Sample{LabelValues: labels("ticker"), Value: lag(&c.Ticker, c.Created)})
+		}
+		if e.Cfg.SyncOrderbook {
+			resp = append(resp, metrics.Sample{LabelValues: labels("orderbook"), Value: lag(&c.Orderbook, c.Created)})
+		}
+		if e.Cfg.SyncTrades {
+			resp = append(resp, metrics.Sample{LabelValues: labels("trade"), Value: lag(&c.Trade, c.Created)})
+		}
+	}
+	return resp
+}
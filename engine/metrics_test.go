@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestSyncerLag(t *testing.T) {
+	bot := &Engine{}
+	if s := bot.syncerLag(); len(s) != 0 {
+		t.Errorf("expected no samples without a syncer, received %v", s)
+	}
+
+	now := time.Now()
+	bot.ExchangeCurrencyPairManager = &ExchangeCurrencyPairSyncer{
+		Cfg: CurrencyPairSyncerConfig{SyncTicker: true, SyncOrderbook: true},
+		CurrencyPairs: []CurrencyPairSyncAgent{{
+			Created:   now.Add(-time.Hour),
+			Exchange:  testExchange,
+			AssetType: asset.Spot,
+			Pair:      currency.NewPair(currency.BTC, currency.USD),
+			Ticker:    SyncBase{HaveData: true, LastUpdated: now.Add(-time.Minute)},
+		}},
+	}
+	samples := bot.syncerLag()
+	if len(samples) != 2 {
+		t.Fatalf("received %v samples expected 2", len(samples))
+	}
+	if samples[0].LabelValues[3] != "ticker" || samples[0].Value < 60 || samples[0].Value >= 3600 {
+		t.Errorf("unexpected ticker lag sample %+v", samples[0])
+	}
+	if samples[1].LabelValues[3] != "orderbook" || samples[1].Value < 3600 {
+		t.Errorf("expected orderbook lag to be measured from creation, received %+v", samples[1])
+	}
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -19,9 +21,10 @@ import (
 
 // vars for the fund manager package
 var (
-	orderManagerDelay      = time.Second * 10
-	ErrOrdersAlreadyExists = errors.New("order already exists")
-	ErrOrderNotFound       = errors.New("order does not exist")
+	orderManagerDelay        = time.Second * 10
+	orderReconciliationDelay = time.Minute * 5
+	ErrOrdersAlreadyExists   = errors.New("order already exists")
+	ErrOrderNotFound         = errors.New("order does not exist")
 )
 
 // get returns all orders for all exchanges
@@ -127,6 +130,52 @@ func (o *orderStore) Add(det *order.Detail) error {
 	return nil
 }
 
+// upsert adds an untracked order or updates a tracked order from an exchange
+// order update, returning true if the order was added. Partial updates which
+// do not identify the order's pair and asset are only applied to tracked
+// orders.
+func (o *orderStore) upsert(det *order.Detail) (bool, error) {
+	if det == nil {
+		return false, errors.New("order store: Order is nil")
+	}
+	od, err := o.GetByExchangeAndID(det.Exchange, det.ID)
+	switch {
+	case err == nil:
+		o.m.Lock()
+		od.UpdateOrderFromDetail(det)
+		o.m.Unlock()
+		return false, nil
+	case errors.Is(err, ErrOrderNotFound), errors.Is(err, ErrExchangeNotFound):
+		if det.Pair.IsEmpty() || det.AssetType == "" {
+			log.Debugf(log.OrderMgr,
+				"Order manager: Exchange %s ignoring partial update for untracked order ID=%v.",
+				det.Exchange, det.ID)
+			return false, nil
+		}
+		err = o.Add(det)
+		if errors.Is(err, ErrOrdersAlreadyExists) {
+			// Added concurrently, apply this update on top of it
+			return o.upsert(det)
+		}
+		return err == nil, err
+	default:
+		return false, err
+	}
+}
+
+// publish pushes a copy of a tracked order to any order update subscribers
+func (o *orderStore) publish(od *order.Detail) {
+	o.m.RLock()
+	cpy := *od
+	o.m.RUnlock()
+	err := order.PublishUpdate(&cpy)
+	if err != nil {
+		log.Errorf(log.OrderMgr,
+			"Order manager: Unable to publish %s order ID=%v update: %s",
+			cpy.Exchange, cpy.ID, err)
+	}
+}
+
 // Started returns the status of the orderManager
 func (o *orderManager) Started() bool {
 	return atomic.LoadInt32(&o.started) == 1
@@ -145,6 +194,10 @@ func (o *orderManager) Start(bot *Engine) error {
 	o.shutdown = make(chan struct{})
 	o.orderStore.Orders = make(map[string][]*order.Detail)
 	o.orderStore.bot = bot
+	o.lastReconciled = make(map[string]time.Time)
+	if bot.Config != nil {
+		o.setConfig(&bot.Config.OrderManager)
+	}
 
 	go o.run()
 	return nil
@@ -166,7 +219,7 @@ func (o *orderManager) Stop() error {
 }
 
 func (o *orderManager) gracefulShutdown() {
-	if o.cfg.CancelOrdersOnShutdown {
+	if o.getConfig().CancelOrdersOnShutdown {
 		log.Debugln(log.OrderMgr, "Order manager: Cancelling any open orders...")
 		o.CancelAllOrders(o.orderStore.bot.Config.GetEnabledExchanges())
 	}
@@ -232,10 +285,18 @@ func (o *orderManager) Cancel(cancel *order.Cancel) error {
 	var err error
 	defer func() {
 		if err != nil {
-			o.orderStore.bot.CommsManager.PushEvent(base.Event{
-				Type:    "order",
-				Message: err.Error(),
-			})
+			evt := base.Event{
+				Type:     base.OrderEvent,
+				Message:  err.Error(),
+				Severity: base.SeverityWarning,
+			}
+			if cancel != nil {
+				evt.Exchange = cancel.Exchange
+				evt.Pair = cancel.Pair
+				evt.Asset = cancel.AssetType
+				evt.Order = &base.OrderDetails{ID: cancel.ID, Side: cancel.Side.String()}
+			}
+			o.orderStore.bot.CommsManager.PushEvent(evt)
 		}
 	}()
 
@@ -278,18 +339,71 @@ func (o *orderManager) Cancel(cancel *order.Cancel) error {
 		return err
 	}
 
+	o.orderStore.m.Lock()
 	od.Status = order.Cancelled
+	o.orderStore.m.Unlock()
 	msg := fmt.Sprintf("Order manager: Exchange %s order ID=%v cancelled.",
 		od.Exchange, od.ID)
 	log.Debugln(log.OrderMgr, msg)
-	o.orderStore.bot.CommsManager.PushEvent(base.Event{
-		Type:    "order",
-		Message: msg,
-	})
+	o.orderStore.bot.CommsManager.PushEvent(orderEvent(msg, od))
+	o.orderStore.publish(od)
 
 	return nil
 }
 
+// Modify sends an order amendment to the exchange and, if successful, applies
+// it to the tracked order. Exchanges which replace the order on amendment
+// return a new order ID, which the tracked order is moved to
+func (o *orderManager) Modify(mod *order.Modify) (string, error) {
+	if mod == nil {
+		return "", order.ErrModifyOrderIsNil
+	}
+	if mod.Exchange == "" {
+		return "", errors.New("order exchange name is empty")
+	}
+	err := mod.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	exch := o.orderStore.bot.GetExchangeByName(mod.Exchange)
+	if exch == nil {
+		return "", ErrExchangeNotFound
+	}
+
+	log.Debugf(log.OrderMgr, "Order manager: Modifying order ID %v [%+v]",
+		mod.ID, mod)
+
+	id, err := exch.ModifyOrder(mod)
+	if err != nil {
+		return "", fmt.Errorf("%v - Failed to modify order: %w", mod.Exchange, err)
+	}
+	if id == "" {
+		id = mod.ID
+	}
+
+	od, err := o.orderStore.GetByExchangeAndID(mod.Exchange, mod.ID)
+	if err != nil {
+		log.Debugf(log.OrderMgr,
+			"Order manager: Exchange %s modified untracked order ID=%v.",
+			mod.Exchange, mod.ID)
+		return id, nil
+	}
+
+	o.orderStore.m.Lock()
+	od.UpdateOrderFromModify(mod)
+	od.ID = id
+	msg := fmt.Sprintf("Order manager: Exchange %s order ID=%v modified, new ID=%v price=%v amount=%v.",
+		od.Exchange, mod.ID, id, od.Price, od.Amount)
+	evt := orderEvent(msg, od)
+	o.orderStore.m.Unlock()
+	log.Debugln(log.OrderMgr, msg)
+	o.orderStore.bot.CommsManager.PushEvent(evt)
+	o.orderStore.publish(od)
+
+	return id, nil
+}
+
 // GetOrderInfo calls the exchange's wrapper GetOrderInfo function
 // and stores the result in the order manager
 func (o *orderManager) GetOrderInfo(exchangeName, orderID string, cp currency.Pair, a asset.Item) (order.Detail, error) {
@@ -314,6 +428,25 @@ func (o *orderManager) GetOrderInfo(exchangeName, orderID string, cp currency.Pa
 	return result, nil
 }
 
+// setConfig replaces the limits enforced on submitted orders
+func (o *orderManager) setConfig(cfg *config.OrderManagerConfig) {
+	o.cfgMtx.Lock()
+	o.cfg.EnforceLimitConfig = cfg.EnforceLimitConfig
+	o.cfg.AllowMarketOrders = cfg.AllowMarketOrders
+	o.cfg.CancelOrdersOnShutdown = cfg.CancelOrdersOnShutdown
+	o.cfg.LimitAmount = cfg.LimitAmount
+	o.cfg.AllowedExchanges = append([]string(nil), cfg.AllowedExchanges...)
+	o.cfg.AllowedPairs = append(currency.Pairs(nil), cfg.AllowedPairs...)
+	o.cfgMtx.Unlock()
+}
+
+// getConfig returns a copy of the limits enforced on submitted orders
+func (o *orderManager) getConfig() orderManagerConfig {
+	o.cfgMtx.RLock()
+	defer o.cfgMtx.RUnlock()
+	return o.cfg
+}
+
 func (o *orderManager) validate(newOrder *order.Submit) error {
 	if newOrder == nil {
 		return errors.New("order cannot be nil")
@@ -327,21 +460,22 @@ func (o *orderManager) validate(newOrder *order.Submit) error {
 		return fmt.Errorf("order manager: %w", err)
 	}
 
-	if o.cfg.EnforceLimitConfig {
-		if !o.cfg.AllowMarketOrders && newOrder.Type == order.Market {
+	cfg := o.getConfig()
+	if cfg.EnforceLimitConfig {
+		if !cfg.AllowMarketOrders && newOrder.Type == order.Market {
 			return errors.New("order market type is not allowed")
 		}
 
-		if o.cfg.LimitAmount > 0 && newOrder.Amount > o.cfg.LimitAmount {
+		if cfg.LimitAmount > 0 && newOrder.Amount > cfg.LimitAmount {
 			return errors.New("order limit exceeds allowed limit")
 		}
 
-		if len(o.cfg.AllowedExchanges) > 0 &&
-			!common.StringDataCompareInsensitive(o.cfg.AllowedExchanges, newOrder.Exchange) {
+		if len(cfg.AllowedExchanges) > 0 &&
+			!common.StringDataCompareInsensitive(cfg.AllowedExchanges, newOrder.Exchange) {
 			return errors.New("order exchange not found in allowed list")
 		}
 
-		if len(o.cfg.AllowedPairs) > 0 && !o.cfg.AllowedPairs.Contains(newOrder.Pair, true) {
+		if len(cfg.AllowedPairs) > 0 && !cfg.AllowedPairs.Contains(newOrder.Pair, true) {
 			return errors.New("order pair not found in allowed list")
 		}
 	}
@@ -351,13 +485,23 @@ func (o *orderManager) validate(newOrder *order.Submit) error {
 // Submit will take in an order struct, send it to the exchange and
 // populate it in the orderManager if successful
 func (o *orderManager) Submit(newOrder *order.Submit) (*orderSubmitResponse, error) {
+	resp, reason, err := o.submit(newOrder)
+	if newOrder != nil {
+		o.orderStore.bot.recordOrderSubmission(newOrder.Exchange, reason)
+	}
+	return resp, err
+}
+
+// submit sends the order and returns the stage at which it was rejected on
+// failure for the order metrics
+func (o *orderManager) submit(newOrder *order.Submit) (*orderSubmitResponse, string, error) {
 	err := o.validate(newOrder)
 	if err != nil {
-		return nil, err
+		return nil, orderRejectValidation, err
 	}
 	exch := o.orderStore.bot.GetExchangeByName(newOrder.Exchange)
 	if exch == nil {
-		return nil, ErrExchangeNotFound
+		return nil, orderRejectExchangeNotFound, ErrExchangeNotFound
 	}
 
 	// Checks for exchange min max limits for order amounts before order
@@ -368,17 +512,30 @@ func (o *orderManager) Submit(newOrder *order.Submit) (*orderSubmitResponse, err
 		newOrder.Amount,
 		newOrder.Type)
 	if err != nil {
-		return nil, fmt.Errorf("order manager: exchange %s unable to place order: %w",
+		return nil, orderRejectLimits, fmt.Errorf("order manager: exchange %s unable to place order: %w",
+			newOrder.Exchange,
+			err)
+	}
+
+	// Applies the unhealthy order policy, this either blocks the order or
+	// holds it until the exchange recovers
+	err = o.orderStore.bot.ExchangeHealthManager.CheckOrderSubmission(newOrder.Exchange)
+	if err != nil {
+		return nil, orderRejectExchangeHealth, fmt.Errorf("order manager: exchange %s unable to place order: %w",
 			newOrder.Exchange,
 			err)
 	}
 
 	result, err := exch.SubmitOrder(newOrder)
 	if err != nil {
-		return nil, err
+		return nil, orderRejectExchange, err
 	}
 
-	return o.processSubmittedOrder(newOrder, result)
+	resp, err := o.processSubmittedOrder(newOrder, result)
+	if err != nil {
+		return nil, orderRejectNotPlaced, err
+	}
+	return resp, "", nil
 }
 
 // SubmitFakeOrder runs through the same process as order submission
@@ -459,8 +616,18 @@ func (o *orderManager) processSubmittedOrder(newOrder *order.Submit, result orde
 
 	log.Debugln(log.OrderMgr, msg)
 	o.orderStore.bot.CommsManager.PushEvent(base.Event{
-		Type:    "order",
-		Message: msg,
+		Type:     base.OrderEvent,
+		Message:  msg,
+		Exchange: newOrder.Exchange,
+		Pair:     newOrder.Pair,
+		Asset:    newOrder.AssetType,
+		Order: &base.OrderDetails{
+			ID:     result.OrderID,
+			Side:   newOrder.Side.String(),
+			Type:   newOrder.Type.String(),
+			Price:  newOrder.Price,
+			Amount: newOrder.Amount,
+		},
 	})
 	status := order.New
 	if result.FullyMatched {
@@ -498,6 +665,10 @@ func (o *orderManager) processSubmittedOrder(newOrder *order.Submit, result orde
 	if err != nil {
 		return nil, fmt.Errorf("unable to add %v order %v to orderStore: %s", newOrder.Exchange, result.OrderID, err)
 	}
+	od, err := o.orderStore.GetByExchangeAndID(newOrder.Exchange, result.OrderID)
+	if err == nil {
+		o.orderStore.publish(od)
+	}
 
 	return &orderSubmitResponse{
 		SubmitResponse: order.SubmitResponse{
@@ -511,11 +682,14 @@ func (o *orderManager) processSubmittedOrder(newOrder *order.Submit, result orde
 func (o *orderManager) processOrders() {
 	authExchanges := o.orderStore.bot.GetAuthAPISupportedExchanges()
 	for x := range authExchanges {
+		exch := o.orderStore.bot.GetExchangeByName(authExchanges[x])
+		if exch == nil || !o.requiresReconciliation(exch) {
+			continue
+		}
 		log.Debugf(log.OrderMgr,
 			"Order manager: Processing orders for exchange %v.",
 			authExchanges[x])
 
-		exch := o.orderStore.bot.GetExchangeByName(authExchanges[x])
 		supportedAssets := exch.GetAssetTypes()
 		for y := range supportedAssets {
 			pairs, err := exch.GetEnabledPairs(supportedAssets[y])
@@ -555,19 +729,123 @@ func (o *orderManager) processOrders() {
 			}
 
 			for z := range result {
-				ord := &result[z]
-				result := o.orderStore.Add(ord)
-				if result != ErrOrdersAlreadyExists {
-					msg := fmt.Sprintf("Order manager: Exchange %s added order ID=%v pair=%v price=%v amount=%v side=%v type=%v.",
-						ord.Exchange, ord.ID, ord.Pair, ord.Price, ord.Amount, ord.Side, ord.Type)
-					log.Debugf(log.OrderMgr, "%v", msg)
-					o.orderStore.bot.CommsManager.PushEvent(base.Event{
-						Type:    "order",
-						Message: msg,
-					})
-					continue
+				err = o.processOrderUpdate(&result[z])
+				if err != nil {
+					log.Errorf(log.OrderMgr,
+						"Order manager: Unable to track order for %s: %s",
+						authExchanges[x],
+						err)
 				}
 			}
 		}
 	}
 }
+
+// requiresReconciliation returns whether an exchange's orders should be polled
+// via REST. Exchanges pushing order updates over an authenticated websocket
+// connection are only polled every orderReconciliationDelay to catch any
+// missed updates.
+func (o *orderManager) requiresReconciliation(exch exchange.IBotExchange) bool {
+	name := strings.ToLower(exch.GetName())
+	o.reconcileMtx.Lock()
+	defer o.reconcileMtx.Unlock()
+	if hasOrderStream(exch) &&
+		time.Since(o.lastReconciled[name]) < orderReconciliationDelay {
+		return false
+	}
+	o.lastReconciled[name] = time.Now()
+	return true
+}
+
+// hasOrderStream returns whether an exchange is currently pushing order
+// updates over its authenticated websocket connection
+func hasOrderStream(exch exchange.IBotExchange) bool {
+	if !exch.IsWebsocketEnabled() ||
+		!exch.GetBase().Features.Supports.WebsocketCapabilities.OrderUpdates {
+		return false
+	}
+	ws, err := exch.GetWebsocket()
+	return err == nil &&
+		ws != nil &&
+		ws.IsConnected() &&
+		ws.CanUseAuthenticatedEndpoints()
+}
+
+// processOrderUpdate tracks an order pushed by an exchange websocket or
+// returned when polling active orders
+func (o *orderManager) processOrderUpdate(d *order.Detail) error {
+	if !o.Started() {
+		return fmt.Errorf("order manager %w", subsystem.ErrSubSystemNotStarted)
+	}
+	added, err := o.orderStore.upsert(d)
+	if err != nil {
+		return err
+	}
+	if od, getErr := o.orderStore.GetByExchangeAndID(d.Exchange, d.ID); getErr == nil {
+		o.orderStore.publish(od)
+	}
+	if !added {
+		return nil
+	}
+	msg := fmt.Sprintf("Order manager: Exchange %s added order ID=%v pair=%v price=%v amount=%v side=%v type=%v.",
+		d.Exchange, d.ID, d.Pair, d.Price, d.Amount, d.Side, d.Type)
+	log.Debugf(log.OrderMgr, "%v", msg)
+	o.orderStore.bot.CommsManager.PushEvent(orderEvent(msg, d))
+	return nil
+}
+
+// processFill applies an execution pushed by an exchange websocket to its
+// order, tracking the order if it has not been seen yet
+func (o *orderManager) processFill(f *order.Fill) error {
+	if !o.Started() {
+		return fmt.Errorf("order manager %w", subsystem.ErrSubSystemNotStarted)
+	}
+	if f == nil {
+		return errors.New("order manager: fill is nil")
+	}
+	if f.OrderID == "" {
+		return errOrderIDCannotBeEmpty
+	}
+
+	od, err := o.orderStore.GetByExchangeAndID(f.Exchange, f.OrderID)
+	if err != nil {
+		if !errors.Is(err, ErrOrderNotFound) && !errors.Is(err, ErrExchangeNotFound) {
+			return err
+		}
+		// The fill has arrived before the order update or the order was
+		// placed outside of the order manager
+		_, err = o.orderStore.upsert(&order.Detail{
+			Exchange:      f.Exchange,
+			ID:            f.OrderID,
+			ClientOrderID: f.ClientOrderID,
+			Side:          f.Side,
+			AssetType:     f.AssetType,
+			Pair:          f.Pair,
+			Date:          f.Timestamp,
+		})
+		if err != nil {
+			return err
+		}
+		od, err = o.orderStore.GetByExchangeAndID(f.Exchange, f.OrderID)
+		if err != nil {
+			return err
+		}
+	}
+
+	o.orderStore.m.Lock()
+	applied := od.UpdateOrderFromFill(f)
+	status := od.Status
+	evt := orderEvent("", od)
+	o.orderStore.m.Unlock()
+	if !applied {
+		return nil
+	}
+	o.orderStore.publish(od)
+
+	msg := fmt.Sprintf("Order manager: Exchange %s order ID=%v filled pair=%v price=%v amount=%v side=%v status=%v.",
+		f.Exchange, f.OrderID, f.Pair, f.Price, f.Amount, f.Side, status)
+	log.Debugf(log.OrderMgr, "%v", msg)
+	evt.Message = msg
+	o.orderStore.bot.CommsManager.PushEvent(evt)
+	return nil
+}
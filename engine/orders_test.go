@@ -1,10 +1,12 @@
 package engine
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
@@ -275,6 +277,76 @@ func TestCancelOrder(t *testing.T) {
 	}
 }
 
+func TestModifyOrder(t *testing.T) {
+	bot := OrdersSetup(t)
+	pair, err := currency.NewPairFromString("BTCUSD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = bot.OrderManager.Modify(nil)
+	if !errors.Is(err, order.ErrModifyOrderIsNil) {
+		t.Errorf("received '%v' expected '%v'", err, order.ErrModifyOrderIsNil)
+	}
+
+	_, err = bot.OrderManager.Modify(&order.Modify{ID: "ID", Pair: pair, AssetType: asset.Spot})
+	if err == nil {
+		t.Error("Expected error due to no Exchange")
+	}
+
+	_, err = bot.OrderManager.Modify(&order.Modify{Exchange: "invalid", ID: "ID", Pair: pair, AssetType: asset.Spot})
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("received '%v' expected '%v'", err, ErrExchangeNotFound)
+	}
+
+	o := &order.Detail{
+		Exchange:  fakePassExchange,
+		ID:        "TestModifyOrder",
+		Pair:      pair,
+		AssetType: asset.Spot,
+		Status:    order.New,
+		Price:     1,
+		Amount:    1,
+	}
+	err = bot.OrderManager.orderStore.Add(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := bot.OrderManager.Modify(&order.Modify{
+		Exchange:  fakePassExchange,
+		ID:        "TestModifyOrder",
+		Pair:      pair,
+		AssetType: asset.Spot,
+		Price:     2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "TestModifyOrder-modified" {
+		t.Errorf("received '%v' expected '%v'", id, "TestModifyOrder-modified")
+	}
+	if o.ID != id || o.Price != 2 || o.Amount != 1 {
+		t.Errorf("tracked order not updated %+v", o)
+	}
+	if _, err = bot.OrderManager.orderStore.GetByExchangeAndID(fakePassExchange, id); err != nil {
+		t.Error(err)
+	}
+
+	id, err = bot.OrderManager.Modify(&order.Modify{
+		Exchange:  fakePassExchange,
+		ID:        "Untracked",
+		Pair:      pair,
+		AssetType: asset.Spot,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "Untracked-modified" {
+		t.Errorf("received '%v' expected '%v'", id, "Untracked-modified")
+	}
+}
+
 func TestGetOrderInfo(t *testing.T) {
 	bot := OrdersSetup(t)
 	_, err := bot.OrderManager.GetOrderInfo("", "", currency.Pair{}, "")
@@ -408,9 +480,143 @@ func TestSubmit(t *testing.T) {
 	if o2.InternalOrderID == "" {
 		t.Error("Failed to assign internal order id")
 	}
+
+	r := bot.metrics()
+	if v := r.Counter("gct_order_submissions_total", "", "exchange").Value(fakePassExchange); v != 6 {
+		t.Errorf("received %v order submissions expected 6", v)
+	}
+	if v := r.Counter("gct_order_rejections_total", "", "exchange", "reason").Value(fakePassExchange, orderRejectValidation); v != 5 {
+		t.Errorf("received %v order rejections expected 5", v)
+	}
 }
 
 func TestProcessOrders(t *testing.T) {
 	bot := OrdersSetup(t)
 	bot.OrderManager.processOrders()
 }
+
+func TestProcessOrderUpdate(t *testing.T) {
+	var o orderManager
+	err := o.processOrderUpdate(&order.Detail{})
+	if !errors.Is(err, subsystem.ErrSubSystemNotStarted) {
+		t.Fatalf("received '%v' expected '%v'", err, subsystem.ErrSubSystemNotStarted)
+	}
+
+	bot := OrdersSetup(t)
+	// Partial updates for untracked orders are ignored
+	err = bot.OrderManager.processOrderUpdate(&order.Detail{
+		Exchange: testExchange,
+		ID:       "TestProcessOrderUpdate",
+		Status:   order.Cancelled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = bot.OrderManager.orderStore.GetByExchangeAndID(testExchange, "TestProcessOrderUpdate")
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrExchangeNotFound)
+	}
+
+	err = bot.OrderManager.processOrderUpdate(&order.Detail{
+		Exchange:  testExchange,
+		ID:        "TestProcessOrderUpdate",
+		Amount:    1,
+		Status:    order.New,
+		AssetType: asset.Spot,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bot.OrderManager.processOrderUpdate(&order.Detail{
+		Exchange:       testExchange,
+		ID:             "TestProcessOrderUpdate",
+		ExecutedAmount: 1,
+		Status:         order.Filled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	od, err := bot.OrderManager.orderStore.GetByExchangeAndID(testExchange, "TestProcessOrderUpdate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if od.Status != order.Filled || od.Amount != 1 || od.ExecutedAmount != 1 {
+		t.Fatalf("unexpected order %+v", od)
+	}
+}
+
+func TestProcessFill(t *testing.T) {
+	var o orderManager
+	err := o.processFill(&order.Fill{})
+	if !errors.Is(err, subsystem.ErrSubSystemNotStarted) {
+		t.Fatalf("received '%v' expected '%v'", err, subsystem.ErrSubSystemNotStarted)
+	}
+
+	bot := OrdersSetup(t)
+	err = bot.OrderManager.processFill(&order.Fill{Exchange: testExchange})
+	if !errors.Is(err, errOrderIDCannotBeEmpty) {
+		t.Fatalf("received '%v' expected '%v'", err, errOrderIDCannotBeEmpty)
+	}
+
+	// Fills for untracked orders start tracking the order
+	f := &order.Fill{
+		Exchange:  testExchange,
+		ID:        "1",
+		OrderID:   "TestProcessFill",
+		Price:     100,
+		Amount:    0.5,
+		Side:      order.Buy,
+		AssetType: asset.Spot,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+	}
+	err = bot.OrderManager.processFill(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bot.OrderManager.processOrderUpdate(&order.Detail{
+		Exchange: testExchange,
+		ID:       "TestProcessFill",
+		Amount:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.ID = "2"
+	err = bot.OrderManager.processFill(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Duplicate fills are ignored
+	err = bot.OrderManager.processFill(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	od, err := bot.OrderManager.orderStore.GetByExchangeAndID(testExchange, "TestProcessFill")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(od.Trades) != 2 || od.ExecutedAmount != 1 || od.Status != order.Filled {
+		t.Fatalf("unexpected order %+v", od)
+	}
+	if od.InternalOrderID == "" {
+		t.Fatal("Failed to assign internal order id")
+	}
+}
+
+func TestRequiresReconciliation(t *testing.T) {
+	bot := OrdersSetup(t)
+	exch := bot.GetExchangeByName(testExchange)
+	if exch == nil {
+		t.Fatal(ErrExchangeNotFound)
+	}
+	// Exchanges without an order update stream are always polled
+	if !bot.OrderManager.requiresReconciliation(exch) ||
+		!bot.OrderManager.requiresReconciliation(exch) {
+		t.Fatal("expected reconciliation")
+	}
+	if hasOrderStream(exch) {
+		t.Fatal("websocket is not connected")
+	}
+}
@@ -3,6 +3,7 @@ package engine
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
@@ -32,7 +33,12 @@ type orderManager struct {
 	started    int32
 	shutdown   chan struct{}
 	orderStore orderStore
+	cfgMtx     sync.RWMutex
 	cfg        orderManagerConfig
+	// lastReconciled tracks when orders were last polled via REST for
+	// exchanges which push order updates over their websocket connection
+	reconcileMtx   sync.Mutex
+	lastReconciled map[string]time.Time
 }
 
 type orderSubmitResponse struct {
@@ -20,22 +20,27 @@ type portfolioManager struct {
 	started    int32
 	processing int32
 	shutdown   chan struct{}
+	bot        *Engine
 }
 
 func (p *portfolioManager) Started() bool {
 	return atomic.LoadInt32(&p.started) == 1
 }
 
-func (p *portfolioManager) Start() error {
-	if atomic.AddInt32(&p.started, 1) != 1 {
-		return errors.New("portfolio manager already started")
+func (p *portfolioManager) Start(bot *Engine) error {
+	if bot == nil {
+		return errors.New("cannot start with nil bot")
 	}
+	if !atomic.CompareAndSwapInt32(&p.started, 0, 1) {
+		return fmt.Errorf("portfolio manager %w", subsystem.ErrSubSystemAlreadyStarted)
+	}
+	p.bot = bot
 
 	log.Debugln(log.PortfolioMgr, "Portfolio manager starting...")
-	Bot.Portfolio = &portfolio.Portfolio
-	Bot.Portfolio.Seed(Bot.Config.Portfolio)
+	p.bot.Portfolio = &portfolio.Portfolio
+	p.bot.Portfolio.Seed(p.bot.Config.Portfolio)
 	p.shutdown = make(chan struct{})
-	portfolio.Verbose = Bot.Settings.Verbose
+	portfolio.Verbose = p.bot.Settings.Verbose
 
 	go p.run()
 	return nil
@@ -55,11 +60,11 @@ func (p *portfolioManager) Stop() error {
 
 func (p *portfolioManager) run() {
 	log.Debugln(log.PortfolioMgr, "Portfolio manager started.")
-	Bot.ServicesWG.Add(1)
-	tick := time.NewTicker(Bot.Settings.PortfolioManagerDelay)
+	p.bot.ServicesWG.Add(1)
+	tick := time.NewTicker(p.bot.Settings.PortfolioManagerDelay)
 	defer func() {
 		tick.Stop()
-		Bot.ServicesWG.Done()
+		p.bot.ServicesWG.Done()
 		log.Debugf(log.PortfolioMgr, "Portfolio manager shutdown.")
 	}()
 
@@ -95,6 +100,6 @@ func (p *portfolioManager) processPortfolio() {
 			key,
 			value)
 	}
-	SeedExchangeAccountInfo(Bot.GetAllEnabledExchangeAccountInfo().Data)
+	SeedExchangeAccountInfo(p.bot.GetAllEnabledExchangeAccountInfo().Data)
 	atomic.CompareAndSwapInt32(&p.processing, 1, 0)
 }
@@ -77,12 +77,13 @@ func newRouter(bot *Engine, isREST bool) *mux.Router {
 	if isREST {
 		routes = []Route{
 			{"", http.MethodGet, "/", getIndex},
-			{"GetAllSettings", http.MethodGet, "/config/all", RESTGetAllSettings},
-			{"SaveAllSettings", http.MethodPost, "/config/all/save", RESTSaveAllSettings},
-			{"AllEnabledAccountInfo", http.MethodGet, "/exchanges/enabled/accounts/all", RESTGetAllEnabledAccountInfo},
-			{"AllActiveExchangesAndCurrencies", http.MethodGet, "/exchanges/enabled/latest/all", RESTGetAllActiveTickers},
-			{"GetPortfolio", http.MethodGet, "/portfolio/all", RESTGetPortfolio},
-			{"AllActiveExchangesAndOrderbooks", http.MethodGet, "/exchanges/orderbook/latest/all", RESTGetAllActiveOrderbooks},
+			{"GetAllSettings", http.MethodGet, "/config/all", bot.RESTGetAllSettings},
+			{"SaveAllSettings", http.MethodPost, "/config/all/save", bot.RESTSaveAllSettings},
+			{"AllEnabledAccountInfo", http.MethodGet, "/exchanges/enabled/accounts/all", bot.RESTGetAllEnabledAccountInfo},
+			{"AllActiveExchangesAndCurrencies", http.MethodGet, "/exchanges/enabled/latest/all", bot.RESTGetAllActiveTickers},
+			{"GetPortfolio", http.MethodGet, "/portfolio/all", bot.RESTGetPortfolio},
+			{"AllActiveExchangesAndOrderbooks", http.MethodGet, "/exchanges/orderbook/latest/all", bot.RESTGetAllActiveOrderbooks},
+			{"Metrics", http.MethodGet, "/metrics", bot.RESTGetMetrics},
 		}
 
 		if bot.Config.Profiler.Enabled {
@@ -97,7 +98,7 @@ func newRouter(bot *Engine, isREST bool) *mux.Router {
 		}
 	} else {
 		routes = []Route{
-			{"ws", http.MethodGet, "/ws", WebsocketClientHandler},
+			{"ws", http.MethodGet, "/ws", bot.WebsocketClientHandler},
 		}
 	}
 
@@ -24,8 +24,8 @@ func RESTfulError(method string, err error) {
 
 // RESTGetAllSettings replies to a request with an encoded JSON response about the
 // trading Bots configuration.
-func RESTGetAllSettings(w http.ResponseWriter, r *http.Request) {
-	err := RESTfulJSONResponse(w, config.Cfg)
+func (bot *Engine) RESTGetAllSettings(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, bot.Config)
 	if err != nil {
 		RESTfulError(r.Method, err)
 	}
@@ -33,7 +33,7 @@ func RESTGetAllSettings(w http.ResponseWriter, r *http.Request) {
 
 // RESTSaveAllSettings saves all current settings from request body as a JSON
 // document then reloads state and returns the settings
-func RESTSaveAllSettings(w http.ResponseWriter, r *http.Request) {
+func (bot *Engine) RESTSaveAllSettings(w http.ResponseWriter, r *http.Request) {
 	// Get the data from the request
 	decoder := json.NewDecoder(r.Body)
 	var responseData config.Post
@@ -42,23 +42,23 @@ func RESTSaveAllSettings(w http.ResponseWriter, r *http.Request) {
 		RESTfulError(r.Method, err)
 	}
 	// Save change the settings
-	err = Bot.Config.UpdateConfig(Bot.Settings.ConfigFile, &responseData.Data, false)
+	err = bot.Config.UpdateConfig(bot.Settings.ConfigFile, &responseData.Data, false)
 	if err != nil {
 		RESTfulError(r.Method, err)
 	}
 
-	err = RESTfulJSONResponse(w, Bot.Config)
+	err = RESTfulJSONResponse(w, bot.Config)
 	if err != nil {
 		RESTfulError(r.Method, err)
 	}
 
-	Bot.SetupExchanges()
+	bot.SetupExchanges()
 }
 
 // GetAllActiveOrderbooks returns all enabled exchanges orderbooks
-func GetAllActiveOrderbooks() []EnabledExchangeOrderbooks {
+func (bot *Engine) GetAllActiveOrderbooks() []EnabledExchangeOrderbooks {
 	var orderbookData []EnabledExchangeOrderbooks
-	exchanges := Bot.GetExchanges()
+	exchanges := bot.GetExchanges()
 	for x := range exchanges {
 		assets := exchanges[x].GetAssetTypes()
 		exchName := exchanges[x].GetName()
@@ -93,9 +93,9 @@ func GetAllActiveOrderbooks() []EnabledExchangeOrderbooks {
 }
 
 // RESTGetAllActiveOrderbooks returns all enabled exchange orderbooks
-func RESTGetAllActiveOrderbooks(w http.ResponseWriter, r *http.Request) {
+func (bot *Engine) RESTGetAllActiveOrderbooks(w http.ResponseWriter, r *http.Request) {
 	var response AllEnabledExchangeOrderbooks
-	response.Data = GetAllActiveOrderbooks()
+	response.Data = bot.GetAllActiveOrderbooks()
 
 	err := RESTfulJSONResponse(w, response)
 	if err != nil {
@@ -103,8 +103,8 @@ func RESTGetAllActiveOrderbooks(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// RESTGetPortfolio returns the Bot portfolio
-func RESTGetPortfolio(w http.ResponseWriter, r *http.Request) {
+// RESTGetPortfolio returns the bot portfolio
+func (bot *Engine) RESTGetPortfolio(w http.ResponseWriter, r *http.Request) {
 	p := portfolio.GetPortfolio()
 	result := p.GetPortfolioSummary()
 	err := RESTfulJSONResponse(w, result)
@@ -114,9 +114,9 @@ func RESTGetPortfolio(w http.ResponseWriter, r *http.Request) {
 }
 
 // RESTGetAllActiveTickers returns all active tickers
-func RESTGetAllActiveTickers(w http.ResponseWriter, r *http.Request) {
+func (bot *Engine) RESTGetAllActiveTickers(w http.ResponseWriter, r *http.Request) {
 	var response AllEnabledExchangeCurrencies
-	response.Data = Bot.GetAllActiveTickers()
+	response.Data = bot.GetAllActiveTickers()
 
 	err := RESTfulJSONResponse(w, response)
 	if err != nil {
@@ -126,8 +126,8 @@ func RESTGetAllActiveTickers(w http.ResponseWriter, r *http.Request) {
 
 // RESTGetAllEnabledAccountInfo via get request returns JSON response of account
 // info
-func RESTGetAllEnabledAccountInfo(w http.ResponseWriter, r *http.Request) {
-	response := Bot.GetAllEnabledExchangeAccountInfo()
+func (bot *Engine) RESTGetAllEnabledAccountInfo(w http.ResponseWriter, r *http.Request) {
+	response := bot.GetAllEnabledExchangeAccountInfo()
 	err := RESTfulJSONResponse(w, response)
 	if err != nil {
 		RESTfulError(r.Method, err)
@@ -7,9 +7,11 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/metrics"
 )
 
 func makeHTTPGetRequest(t *testing.T, response interface{}) *http.Response {
@@ -78,6 +80,32 @@ func TestValidHostRequest(t *testing.T) {
 	}
 }
 
+func TestRESTGetMetrics(t *testing.T) {
+	e := CreateTestBot(t)
+	req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Host = "localhost:9050"
+
+	resp := httptest.NewRecorder()
+	newRouter(e, true).ServeHTTP(resp, req)
+	if status := resp.Code; status != http.StatusOK {
+		t.Fatalf("Response returned wrong status code expected %v got %v", http.StatusOK, status)
+	}
+	if ct := resp.Header().Get("Content-Type"); ct != metrics.ContentType {
+		t.Errorf("received content type %v expected %v", ct, metrics.ContentType)
+	}
+	for _, s := range []string{
+		"# TYPE gct_gctscript_running_vms gauge\n",
+		"# TYPE gct_dispatch_max_workers gauge\n",
+	} {
+		if !strings.Contains(resp.Body.String(), s) {
+			t.Errorf("expected metrics response to contain %q", s)
+		}
+	}
+}
+
 func TestProfilerEnabledShouldEnableProfileEndPoint(t *testing.T) {
 	e := CreateTestBot(t)
 	req, err := http.NewRequest(http.MethodGet, "/debug/pprof/", nil)
@@ -114,3 +142,27 @@ func TestProfilerEnabledShouldEnableProfileEndPoint(t *testing.T) {
 		t.Errorf("Response returned wrong status code expected %v got %v", http.StatusOK, status)
 	}
 }
+
+func TestWebsocketHubPerEngine(t *testing.T) {
+	a := &Engine{Config: &config.Config{}}
+	b := &Engine{Config: &config.Config{}}
+	a.Config.RemoteControl.GRPC.ListenAddress = "localhost:9052"
+	b.Config.RemoteControl.GRPC.ListenAddress = "localhost:9053"
+
+	err := a.BroadcastWebsocketMessage(WebsocketEvent{Event: "test"})
+	if err == nil {
+		t.Error("expected error broadcasting before the websocket handler is started")
+	}
+
+	a.StartWebsocketHandler()
+	if a.getWebsocketHub() == nil {
+		t.Fatal("expected websocket hub to be started")
+	}
+	if b.getWebsocketHub() != nil {
+		t.Error("expected websocket hub to be scoped to its engine")
+	}
+
+	if a.GetRPCEndpoints()["grpc"].ListenAddr == b.GetRPCEndpoints()["grpc"].ListenAddr {
+		t.Error("expected RPC endpoints to be scoped to their engine")
+	}
+}
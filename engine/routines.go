@@ -9,9 +9,11 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/currency/valuation"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
@@ -27,12 +29,16 @@ func printCurrencyFormat(price float64, displayCurrency currency.Code) string {
 	return fmt.Sprintf("%s%.8f", displaySymbol, price)
 }
 
-func printConvertCurrencyFormat(origCurrency currency.Code, origPrice float64, displayCurrency currency.Code) string {
-	conv, err := currency.ConvertCurrency(origPrice,
-		origCurrency,
-		displayCurrency)
+// printConvertCurrencyFormat formats an amount alongside its value in the
+// display currency, valued through the supplied graph so that crypto amounts
+// are converted via the most liquid ticker path
+func printConvertCurrencyFormat(g *valuation.Graph, origCurrency currency.Code, origPrice float64, displayCurrency currency.Code) string {
+	var conv float64
+	v, err := g.Value(origPrice, origCurrency, displayCurrency)
 	if err != nil {
 		log.Errorf(log.Global, "Failed to convert currency: %s\n", err)
+	} else {
+		conv = v.Value
 	}
 
 	displaySymbol, err := currency.GetSymbolByCurrencyName(displayCurrency)
@@ -40,6 +46,16 @@ func printConvertCurrencyFormat(origCurrency currency.Code, origPrice float64, d
 		log.Errorf(log.Global, "Failed to get display symbol: %s\n", err)
 	}
 
+	if !origCurrency.IsFiatCurrency() {
+		return fmt.Sprintf("%s%.2f %s (%.8f %s)",
+			displaySymbol,
+			conv,
+			displayCurrency,
+			origPrice,
+			origCurrency,
+		)
+	}
+
 	origSymbol, err := currency.GetSymbolByCurrencyName(origCurrency)
 	if err != nil {
 		log.Errorf(log.Global, "Failed to get original currency symbol for %s: %s\n",
@@ -57,8 +73,31 @@ func printConvertCurrencyFormat(origCurrency currency.Code, origPrice float64, d
 	)
 }
 
-func printTickerSummary(result *ticker.Price, protocol string, err error) {
+// displayValuationGraph returns the valuation graph used to show amounts of
+// the supplied currency in the display currency, or nil when the currency
+// already is the display currency or cannot be valued in it
+func displayValuationGraph(bot *Engine, c currency.Code) *valuation.Graph {
+	if bot == nil || c.Match(bot.Config.Currency.FiatDisplayCurrency) {
+		return nil
+	}
+	g, err := bot.GetValuationGraph()
+	if err != nil {
+		return nil
+	}
+	if _, err = g.Value(1, c, bot.Config.Currency.FiatDisplayCurrency); err != nil {
+		return nil
+	}
+	return g
+}
+
+func printTickerSummary(result *ticker.Price, protocol string, bot *Engine, err error) {
 	if err != nil {
+		if errors.Is(err, request.ErrRequestShed) {
+			log.Debugf(log.Ticker, "Skipped %s ticker. Error: %s\n",
+				protocol,
+				err)
+			return
+		}
 		if err == common.ErrNotYetImplemented {
 			log.Warnf(log.Ticker, "Failed to get %s ticker. Error: %s\n",
 				protocol,
@@ -72,41 +111,39 @@ func printTickerSummary(result *ticker.Price, protocol string, err error) {
 	}
 
 	stats.Add(result.ExchangeName, result.Pair, result.AssetType, result.Last, result.Volume)
-	if result.Pair.Quote.IsFiatCurrency() &&
-		Bot != nil &&
-		result.Pair.Quote != Bot.Config.Currency.FiatDisplayCurrency {
+	if g := displayValuationGraph(bot, result.Pair.Quote); g != nil {
 		origCurrency := result.Pair.Quote.Upper()
 		log.Infof(log.Ticker, "%s %s %s %s: TICKER: Last %s Ask %s Bid %s High %s Low %s Volume %.8f\n",
 			result.ExchangeName,
 			protocol,
-			Bot.FormatCurrency(result.Pair),
+			bot.FormatCurrency(result.Pair),
 			strings.ToUpper(result.AssetType.String()),
-			printConvertCurrencyFormat(origCurrency, result.Last, Bot.Config.Currency.FiatDisplayCurrency),
-			printConvertCurrencyFormat(origCurrency, result.Ask, Bot.Config.Currency.FiatDisplayCurrency),
-			printConvertCurrencyFormat(origCurrency, result.Bid, Bot.Config.Currency.FiatDisplayCurrency),
-			printConvertCurrencyFormat(origCurrency, result.High, Bot.Config.Currency.FiatDisplayCurrency),
-			printConvertCurrencyFormat(origCurrency, result.Low, Bot.Config.Currency.FiatDisplayCurrency),
+			printConvertCurrencyFormat(g, origCurrency, result.Last, bot.Config.Currency.FiatDisplayCurrency),
+			printConvertCurrencyFormat(g, origCurrency, result.Ask, bot.Config.Currency.FiatDisplayCurrency),
+			printConvertCurrencyFormat(g, origCurrency, result.Bid, bot.Config.Currency.FiatDisplayCurrency),
+			printConvertCurrencyFormat(g, origCurrency, result.High, bot.Config.Currency.FiatDisplayCurrency),
+			printConvertCurrencyFormat(g, origCurrency, result.Low, bot.Config.Currency.FiatDisplayCurrency),
 			result.Volume)
 	} else {
 		if result.Pair.Quote.IsFiatCurrency() &&
-			Bot != nil &&
-			result.Pair.Quote == Bot.Config.Currency.FiatDisplayCurrency {
+			bot != nil &&
+			result.Pair.Quote == bot.Config.Currency.FiatDisplayCurrency {
 			log.Infof(log.Ticker, "%s %s %s %s: TICKER: Last %s Ask %s Bid %s High %s Low %s Volume %.8f\n",
 				result.ExchangeName,
 				protocol,
-				Bot.FormatCurrency(result.Pair),
+				bot.FormatCurrency(result.Pair),
 				strings.ToUpper(result.AssetType.String()),
-				printCurrencyFormat(result.Last, Bot.Config.Currency.FiatDisplayCurrency),
-				printCurrencyFormat(result.Ask, Bot.Config.Currency.FiatDisplayCurrency),
-				printCurrencyFormat(result.Bid, Bot.Config.Currency.FiatDisplayCurrency),
-				printCurrencyFormat(result.High, Bot.Config.Currency.FiatDisplayCurrency),
-				printCurrencyFormat(result.Low, Bot.Config.Currency.FiatDisplayCurrency),
+				printCurrencyFormat(result.Last, bot.Config.Currency.FiatDisplayCurrency),
+				printCurrencyFormat(result.Ask, bot.Config.Currency.FiatDisplayCurrency),
+				printCurrencyFormat(result.Bid, bot.Config.Currency.FiatDisplayCurrency),
+				printCurrencyFormat(result.High, bot.Config.Currency.FiatDisplayCurrency),
+				printCurrencyFormat(result.Low, bot.Config.Currency.FiatDisplayCurrency),
 				result.Volume)
 		} else {
 			log.Infof(log.Ticker, "%s %s %s %s: TICKER: Last %.8f Ask %.8f Bid %.8f High %.8f Low %.8f Volume %.8f\n",
 				result.ExchangeName,
 				protocol,
-				Bot.FormatCurrency(result.Pair),
+				bot.FormatCurrency(result.Pair),
 				strings.ToUpper(result.AssetType.String()),
 				result.Last,
 				result.Ask,
@@ -124,6 +161,12 @@ const (
 
 func printOrderbookSummary(result *orderbook.Base, protocol string, bot *Engine, err error) {
 	if err != nil {
+		if errors.Is(err, request.ErrRequestShed) {
+			log.Debugf(log.OrderBook, "Skipped %s orderbook. Error: %s\n",
+				protocol,
+				err)
+			return
+		}
 		if result == nil {
 			log.Errorf(log.OrderBook, "Failed to get %s orderbook. Error: %s\n",
 				protocol,
@@ -152,11 +195,12 @@ func printOrderbookSummary(result *orderbook.Base, protocol string, bot *Engine,
 	asksAmount, asksValue := result.TotalAsksAmount()
 
 	var bidValueResult, askValueResult string
+	g := displayValuationGraph(bot, result.Pair.Quote)
 	switch {
-	case result.Pair.Quote.IsFiatCurrency() && bot != nil && result.Pair.Quote != bot.Config.Currency.FiatDisplayCurrency:
+	case g != nil:
 		origCurrency := result.Pair.Quote.Upper()
-		bidValueResult = printConvertCurrencyFormat(origCurrency, bidsValue, bot.Config.Currency.FiatDisplayCurrency)
-		askValueResult = printConvertCurrencyFormat(origCurrency, asksValue, bot.Config.Currency.FiatDisplayCurrency)
+		bidValueResult = printConvertCurrencyFormat(g, origCurrency, bidsValue, bot.Config.Currency.FiatDisplayCurrency)
+		askValueResult = printConvertCurrencyFormat(g, origCurrency, asksValue, bot.Config.Currency.FiatDisplayCurrency)
 	case result.Pair.Quote.IsFiatCurrency() && bot != nil && result.Pair.Quote == bot.Config.Currency.FiatDisplayCurrency:
 		bidValueResult = printCurrencyFormat(bidsValue, bot.Config.Currency.FiatDisplayCurrency)
 		askValueResult = printCurrencyFormat(asksValue, bot.Config.Currency.FiatDisplayCurrency)
@@ -181,14 +225,14 @@ func printOrderbookSummary(result *orderbook.Base, protocol string, bot *Engine,
 	)
 }
 
-func relayWebsocketEvent(result interface{}, event, assetType, exchangeName string) {
+func (bot *Engine) relayWebsocketEvent(result interface{}, event, assetType, exchangeName string) {
 	evt := WebsocketEvent{
 		Data:      result,
 		Event:     event,
 		AssetType: assetType,
 		Exchange:  exchangeName,
 	}
-	err := BroadcastWebsocketMessage(evt)
+	err := bot.BroadcastWebsocketMessage(evt)
 	if err != nil {
 		log.Errorf(log.WebsocketMgr, "Failed to broadcast websocket event %v. Error: %s\n",
 			event, err)
@@ -304,8 +348,8 @@ func (bot *Engine) WebsocketDataHandler(exchName string, data interface{}) error
 				SyncItemTicker,
 				nil)
 		}
-		err := ticker.ProcessTicker(d)
-		printTickerSummary(d, "websocket", err)
+		err := bot.tickerService().ProcessTicker(d)
+		printTickerSummary(d, "websocket", bot, err)
 	case stream.KlineData:
 		if bot.Settings.Verbose {
 			log.Infof(log.WebsocketMgr, "%s websocket %s %s kline updated %+v",
@@ -327,19 +371,15 @@ func (bot *Engine) WebsocketDataHandler(exchName string, data interface{}) error
 		if bot.Settings.Verbose {
 			printOrderSummary(d)
 		}
-		// TODO: Dont check if exists this creates two locks, on conflict update
-		// else insert.
-		if !bot.OrderManager.orderStore.exists(d) {
-			err := bot.OrderManager.orderStore.Add(d)
-			if err != nil {
-				return err
-			}
-		} else {
-			od, err := bot.OrderManager.orderStore.GetByExchangeAndID(d.Exchange, d.ID)
-			if err != nil {
-				return err
-			}
-			od.UpdateOrderFromDetail(d)
+		if bot.OrderManager.Started() {
+			return bot.OrderManager.processOrderUpdate(d)
+		}
+	case *order.Fill:
+		if bot.Settings.Verbose {
+			printFillSummary(d)
+		}
+		if bot.OrderManager.Started() {
+			return bot.OrderManager.processFill(d)
 		}
 	case *order.Modify:
 		if bot.Settings.Verbose {
@@ -351,6 +391,7 @@ func (bot *Engine) WebsocketDataHandler(exchName string, data interface{}) error
 			return err
 		}
 		od.UpdateOrderFromModify(d)
+		bot.OrderManager.orderStore.publish(od)
 	case order.ClassificationError:
 		return errors.New(d.Error())
 	case stream.UnhandledMessageWarning:
@@ -392,6 +433,27 @@ func printOrderChangeSummary(m *order.Modify) {
 		m.RemainingAmount)
 }
 
+// printFillSummary logs an order execution received over a websocket
+func printFillSummary(f *order.Fill) {
+	if f == nil {
+		return
+	}
+	log.Debugf(log.WebsocketMgr,
+		"Order Fill: %s %s %s %s OrderID:%s ClientOrderID:%s TradeID:%s Price:%f Amount:%f Fee:%f %s Maker:%v",
+		f.Exchange,
+		f.AssetType,
+		f.Pair,
+		f.Side,
+		f.OrderID,
+		f.ClientOrderID,
+		f.ID,
+		f.Price,
+		f.Amount,
+		f.Fee,
+		f.FeeAsset,
+		f.IsMaker)
+}
+
 // printOrderSummary this function will be deprecated when a order manager
 // update is done.
 func printOrderSummary(m *order.Detail) {
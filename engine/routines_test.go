@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/currency/valuation"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
@@ -47,10 +49,12 @@ func TestHandleData(t *testing.T) {
 		t.Error(err)
 	}
 	origOrder := &order.Detail{
-		Exchange: fakePassExchange,
-		ID:       orderID,
-		Amount:   1337,
-		Price:    1337,
+		Exchange:  fakePassExchange,
+		ID:        orderID,
+		Amount:    1337,
+		Price:     1337,
+		AssetType: asset.Spot,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
 	}
 	err = b.WebsocketDataHandler(exchName, origOrder)
 	if err != nil {
@@ -81,6 +85,20 @@ func TestHandleData(t *testing.T) {
 		t.Error("Expected order to be modified to Active")
 	}
 
+	err = b.WebsocketDataHandler(exchName, &order.Fill{
+		Exchange: fakePassExchange,
+		ID:       "1",
+		OrderID:  orderID,
+		Price:    1337,
+		Amount:   1,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if origOrder.ExecutedAmount != 1 || origOrder.Status != order.PartiallyFilled {
+		t.Error("Expected fill to be applied to order")
+	}
+
 	// Send some gibberish
 	err = b.WebsocketDataHandler(exchName, order.Stop)
 	if err != nil {
@@ -119,3 +137,53 @@ func TestHandleData(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestPrintConvertCurrencyFormat(t *testing.T) {
+	t.Parallel()
+	g := valuation.NewGraph(0)
+	err := g.AddTicker(&ticker.Price{
+		ExchangeName: testExchange,
+		Pair:         currency.NewPair(currency.ETH, currency.USD),
+		AssetType:    asset.Spot,
+		Last:         2000,
+		Volume:       10,
+		LastUpdated:  time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := printConvertCurrencyFormat(g, currency.ETH, 0.5, currency.USD); s != "$1000.00 USD (0.50000000 ETH)" {
+		t.Errorf("received '%v' expected '%v'", s, "$1000.00 USD (0.50000000 ETH)")
+	}
+	if s := printConvertCurrencyFormat(g, currency.USD, 2, currency.USD); s != "$2.00 USD ($2.00 USD)" {
+		t.Errorf("received '%v' expected '%v'", s, "$2.00 USD ($2.00 USD)")
+	}
+}
+
+func TestDisplayValuationGraph(t *testing.T) {
+	bot := CreateTestBot(t)
+	bot.TickerService = ticker.NewService()
+	if g := displayValuationGraph(nil, currency.ETH); g != nil {
+		t.Error("expected no graph without a bot")
+	}
+	if g := displayValuationGraph(bot, currency.USD); g != nil {
+		t.Error("expected no graph for the display currency")
+	}
+	if g := displayValuationGraph(bot, currency.ETH); g != nil {
+		t.Error("expected no graph for a currency which cannot be valued")
+	}
+	err := bot.TickerService.ProcessTicker(&ticker.Price{
+		ExchangeName: testExchange,
+		Pair:         currency.NewPair(currency.ETH, currency.USD),
+		AssetType:    asset.Spot,
+		Last:         2000,
+		LastUpdated:  time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.valuationGraphBuilt = time.Time{}
+	if g := displayValuationGraph(bot, currency.ETH); g == nil {
+		t.Error("expected a graph for a currency which can be valued")
+	}
+}
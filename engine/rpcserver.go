@@ -15,7 +15,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
-	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
@@ -23,6 +23,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/file/archive"
 	"github.com/thrasher-corp/gocryptotrader/common/timeperiods"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/currency/valuation"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
 	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
@@ -38,15 +39,20 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
+	scriptstate "github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/gct/state"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 	"github.com/thrasher-corp/gocryptotrader/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -62,6 +68,9 @@ var (
 	errDispatchSystem       = errors.New("dispatch system offline")
 	errCurrencyNotEnabled   = errors.New("currency not enabled")
 	errCurrencyPairInvalid  = errors.New("currency provided is not found in the available pairs list")
+	errScriptNameUnset      = errors.New("script name unset")
+	errTokenIDUnset         = errors.New("api token ID unset")
+	errInvalidTokenExpiry   = errors.New("api token expiry must be a positive duration")
 )
 
 // RPCServer struct
@@ -70,34 +79,99 @@ type RPCServer struct {
 	gctrpc.UnimplementedGoCryptoTraderServer
 }
 
-func (bot *Engine) authenticateClient(ctx context.Context) (context.Context, error) {
+// authenticateClient resolves the principal from the authorization metadata
+// supplied with a request
+func (bot *Engine) authenticateClient(ctx context.Context) (*auth.Principal, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return ctx, fmt.Errorf("unable to extract metadata")
+		return nil, fmt.Errorf("unable to extract metadata")
 	}
 
 	authStr, ok := md["authorization"]
-	if !ok {
-		return ctx, fmt.Errorf("authorization header missing")
+	if !ok || len(authStr) == 0 {
+		return nil, fmt.Errorf("authorization header missing")
 	}
 
-	if !strings.Contains(authStr[0], "Basic") {
-		return ctx, fmt.Errorf("basic not found in authorization header")
+	creds := bot.Config.GetRemoteControlCredentials()
+	return creds.Authenticate(authStr[0])
+}
+
+// authoriseRequest authenticates the client and ensures it has been granted
+// the scope required by the gRPC method
+func (bot *Engine) authoriseRequest(ctx context.Context, method string) (context.Context, error) {
+	scope := auth.RequiredScope(method)
+	principal, err := bot.authenticateClient(ctx)
+	code := codes.Unauthenticated
+	if err == nil {
+		err = principal.Authorise(scope)
+		code = codes.PermissionDenied
 	}
 
-	decoded, err := crypto.Base64Decode(strings.Split(authStr[0], " ")[1])
+	actor := audit.Actor{Type: audit.ActorGRPC}
+	if principal != nil {
+		actor.ID = principalActorID(principal)
+	} else if p, ok := peer.FromContext(ctx); ok {
+		actor.ID = p.Addr.String()
+	}
+	ctx = audit.WithActor(ctx, actor)
+	bot.auditAuthorisation(ctx, method, scope, err)
 	if err != nil {
-		return ctx, fmt.Errorf("unable to base64 decode authorization header")
+		return ctx, status.Error(code, err.Error())
 	}
+	return auth.WithPrincipal(ctx, principal), nil
+}
 
-	username := strings.Split(string(decoded), ":")[0]
-	password := strings.Split(string(decoded), ":")[1]
+// unaryAuthInterceptor enforces remote control scopes on unary RPCs
+func (bot *Engine) unaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := bot.authoriseRequest(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
 
-	if username != bot.Config.RemoteControl.Username || password != bot.Config.RemoteControl.Password {
-		return ctx, fmt.Errorf("username/password mismatch")
+// streamAuthInterceptor enforces remote control scopes on streaming RPCs
+func (bot *Engine) streamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := bot.authoriseRequest(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
 	}
+	wrapped := grpcmiddleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+	return handler(srv, wrapped)
+}
+
+// principalActorID returns the audit actor ID for an authenticated principal
+func principalActorID(p *auth.Principal) string {
+	if p.Type == auth.PrincipalToken {
+		return auth.PrincipalToken + ":" + p.Name
+	}
+	return p.Name
+}
+
+// auditAuthorisation records failed and denied remote control requests along
+// with any request granted a scope beyond read access
+func (bot *Engine) auditAuthorisation(ctx context.Context, identifier, scope string, err error) {
+	if err == nil && scope == auth.ScopeRead {
+		return
+	}
+	message := "access granted"
+	data := map[string]interface{}{"scope": scope}
+	if err != nil {
+		message = "access denied"
+		data["error"] = err.Error()
+	}
+	bot.auditLog().EventWithContext(ctx, audit.Authorisation, identifier, message, data)
+}
 
-	return ctx, nil
+// auditRequest records an action requested by a client in the audit log along
+// with its outcome
+func (bot *Engine) auditRequest(ctx context.Context, eventType, identifier, message string, request interface{}, err error) {
+	data := map[string]interface{}{"request": request}
+	if err != nil {
+		data["error"] = err.Error()
+	}
+	bot.auditLog().EventWithContext(ctx, eventType, identifier, message, data)
 }
 
 // StartRPCServer starts a gRPC server with TLS auth
@@ -124,7 +198,8 @@ func StartRPCServer(engine *Engine) {
 
 	opts := []grpc.ServerOption{
 		grpc.Creds(creds),
-		grpc.UnaryInterceptor(grpcauth.UnaryServerInterceptor(engine.authenticateClient)),
+		grpc.UnaryInterceptor(engine.unaryAuthInterceptor),
+		grpc.StreamInterceptor(engine.streamAuthInterceptor),
 	}
 	server := grpc.NewServer(opts...)
 	s := RPCServer{Engine: engine}
@@ -155,13 +230,10 @@ func (s *RPCServer) StartRPCRESTProxy() {
 		return
 	}
 
+	// The REST client's Authorization header is forwarded to the gRPC server
+	// so each request is authorised with the caller's own credentials
 	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds),
-		grpc.WithPerRPCCredentials(auth.BasicAuth{
-			Username: s.Config.RemoteControl.Username,
-			Password: s.Config.RemoteControl.Password,
-		}),
-	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
 	err = gctrpc.RegisterGoCryptoTraderHandlerFromEndpoint(context.Background(),
 		mux, s.Config.RemoteControl.GRPC.ListenAddress, opts)
 	if err != nil {
@@ -190,7 +262,7 @@ func (s *RPCServer) GetInfo(_ context.Context, r *gctrpc.GetInfoRequest) (*gctrp
 		DefaultForexProvider: s.Config.GetPrimaryForexProvider(),
 		SubsystemStatus:      s.GetSubsystemsStatus(),
 	}
-	endpoints := GetRPCEndpoints()
+	endpoints := s.Engine.GetRPCEndpoints()
 	resp.RpcEndpoints = make(map[string]*gctrpc.RPCEndpoint)
 	for k, v := range endpoints {
 		resp.RpcEndpoints[k] = &gctrpc.RPCEndpoint{
@@ -201,14 +273,41 @@ func (s *RPCServer) GetInfo(_ context.Context, r *gctrpc.GetInfoRequest) (*gctrp
 	return &resp, nil
 }
 
-// GetSubsystems returns a list of subsystems and their status
+// GetSubsystems returns a list of subsystems, their status and the health of
+// the managed subsystems
 func (s *RPCServer) GetSubsystems(_ context.Context, r *gctrpc.GetSubsystemsRequest) (*gctrpc.GetSusbsytemsResponse, error) {
-	return &gctrpc.GetSusbsytemsResponse{SubsystemsStatus: s.GetSubsystemsStatus()}, nil
+	statuses := s.GetSubsystemsHealth()
+	resp := &gctrpc.GetSusbsytemsResponse{
+		SubsystemsStatus: s.GetSubsystemsStatus(),
+		Subsystems:       make([]*gctrpc.SubsystemHealth, len(statuses)),
+	}
+	for i := range statuses {
+		var lastError string
+		var lastErrorTime int64
+		if statuses[i].LastError != nil {
+			lastError = statuses[i].LastError.Error()
+		}
+		if !statuses[i].LastErrorTime.IsZero() {
+			lastErrorTime = statuses[i].LastErrorTime.Unix()
+		}
+		resp.Subsystems[i] = &gctrpc.SubsystemHealth{
+			Name:          statuses[i].Name,
+			Enabled:       statuses[i].Enabled,
+			Running:       statuses[i].Running,
+			Health:        statuses[i].Health,
+			LastError:     lastError,
+			LastErrorTime: lastErrorTime,
+			Restarts:      statuses[i].Restarts,
+			DependsOn:     statuses[i].DependsOn,
+		}
+	}
+	return resp, nil
 }
 
 // EnableSubsystem enables a engine subsytem
-func (s *RPCServer) EnableSubsystem(_ context.Context, r *gctrpc.GenericSubsystemRequest) (*gctrpc.GenericResponse, error) {
+func (s *RPCServer) EnableSubsystem(ctx context.Context, r *gctrpc.GenericSubsystemRequest) (*gctrpc.GenericResponse, error) {
 	err := s.SetSubsystem(r.Subsystem, true)
+	s.auditRequest(ctx, audit.SubsystemToggle, r.Subsystem, "subsystem enabled", r, err)
 	if err != nil {
 		return nil, err
 	}
@@ -217,8 +316,9 @@ func (s *RPCServer) EnableSubsystem(_ context.Context, r *gctrpc.GenericSubsyste
 }
 
 // DisableSubsystem disables a engine subsytem
-func (s *RPCServer) DisableSubsystem(_ context.Context, r *gctrpc.GenericSubsystemRequest) (*gctrpc.GenericResponse, error) {
+func (s *RPCServer) DisableSubsystem(ctx context.Context, r *gctrpc.GenericSubsystemRequest) (*gctrpc.GenericResponse, error) {
 	err := s.SetSubsystem(r.Subsystem, false)
+	s.auditRequest(ctx, audit.SubsystemToggle, r.Subsystem, "subsystem disabled", r, err)
 	if err != nil {
 		return nil, err
 	}
@@ -228,7 +328,7 @@ func (s *RPCServer) DisableSubsystem(_ context.Context, r *gctrpc.GenericSubsyst
 
 // GetRPCEndpoints returns a list of API endpoints
 func (s *RPCServer) GetRPCEndpoints(_ context.Context, r *gctrpc.GetRPCEndpointsRequest) (*gctrpc.GetRPCEndpointsResponse, error) {
-	endpoints := GetRPCEndpoints()
+	endpoints := s.Engine.GetRPCEndpoints()
 	var resp gctrpc.GetRPCEndpointsResponse
 	resp.Endpoints = make(map[string]*gctrpc.RPCEndpoint)
 	for k, v := range endpoints {
@@ -266,8 +366,9 @@ func (s *RPCServer) GetExchanges(_ context.Context, r *gctrpc.GetExchangesReques
 }
 
 // DisableExchange disables an exchange
-func (s *RPCServer) DisableExchange(_ context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GenericResponse, error) {
+func (s *RPCServer) DisableExchange(ctx context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GenericResponse, error) {
 	err := s.UnloadExchange(r.Exchange)
+	s.auditRequest(ctx, audit.ExchangeToggle, r.Exchange, "exchange disabled", r, err)
 	if err != nil {
 		return nil, err
 	}
@@ -275,8 +376,9 @@ func (s *RPCServer) DisableExchange(_ context.Context, r *gctrpc.GenericExchange
 }
 
 // EnableExchange enables an exchange
-func (s *RPCServer) EnableExchange(_ context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GenericResponse, error) {
+func (s *RPCServer) EnableExchange(ctx context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GenericResponse, error) {
 	err := s.LoadExchange(r.Exchange, false, nil)
+	s.auditRequest(ctx, audit.ExchangeToggle, r.Exchange, "exchange enabled", r, err)
 	if err != nil {
 		return nil, err
 	}
@@ -464,7 +566,7 @@ func (s *RPCServer) GetOrderbook(_ context.Context, r *gctrpc.GetOrderbookReques
 // GetOrderbooks returns a list of orderbooks for all enabled exchanges and all
 // enabled currency pairs
 func (s *RPCServer) GetOrderbooks(_ context.Context, r *gctrpc.GetOrderbooksRequest) (*gctrpc.GetOrderbooksResponse, error) {
-	activeOrderbooks := GetAllActiveOrderbooks()
+	activeOrderbooks := s.GetAllActiveOrderbooks()
 	var orderbooks []*gctrpc.Orderbooks
 
 	for x := range activeOrderbooks {
@@ -524,7 +626,7 @@ func (s *RPCServer) GetAccountInfo(_ context.Context, r *gctrpc.GetAccountInfoRe
 		return nil, err
 	}
 
-	return createAccountInfoRequest(resp)
+	return s.createAccountInfoRequest(resp)
 }
 
 // UpdateAccountInfo forces an update of the account info
@@ -545,25 +647,71 @@ func (s *RPCServer) UpdateAccountInfo(ctx context.Context, r *gctrpc.GetAccountI
 		return nil, err
 	}
 
-	return createAccountInfoRequest(resp)
+	return s.createAccountInfoRequest(resp)
 }
 
-func createAccountInfoRequest(h account.Holdings) (*gctrpc.GetAccountInfoResponse, error) {
+func (s *RPCServer) createAccountInfoRequest(h account.Holdings) (*gctrpc.GetAccountInfoResponse, error) {
+	fiat := s.Config.Currency.FiatDisplayCurrency
+	g, err := s.GetValuationGraph()
+	if err != nil {
+		return nil, err
+	}
 	var accounts []*gctrpc.Account
 	for x := range h.Accounts {
 		var a gctrpc.Account
 		a.Id = h.Accounts[x].ID
 		for _, y := range h.Accounts[x].Currencies {
+			value, v := valueOrZero(g, y.CurrencyName, y.TotalValue, fiat)
 			a.Currencies = append(a.Currencies, &gctrpc.AccountCurrencyInfo{
 				Currency:   y.CurrencyName.String(),
 				Hold:       y.Hold,
 				TotalValue: y.TotalValue,
+				Value:      value,
+				Valuation:  v,
 			})
 		}
 		accounts = append(accounts, &a)
 	}
 
-	return &gctrpc.GetAccountInfoResponse{Exchange: h.Exchange, Accounts: accounts}, nil
+	return &gctrpc.GetAccountInfoResponse{
+		Exchange:          h.Exchange,
+		Accounts:          accounts,
+		ValuationCurrency: fiat.String(),
+	}, nil
+}
+
+// valueOrZero values an amount in the supplied currency along with the path
+// and age of the rates used, returning zero when there is no path to value it
+// with
+func valueOrZero(g *valuation.Graph, code currency.Code, amount float64, to currency.Code) (float64, *gctrpc.Valuation) {
+	if amount == 0 {
+		return 0, nil
+	}
+	v, err := g.Value(amount, code, to)
+	if err != nil {
+		return 0, nil
+	}
+	resp := &gctrpc.Valuation{
+		Rate:       v.Rate,
+		Confidence: v.Confidence,
+		Liquidity:  v.Liquidity,
+		Timestamp:  v.Timestamp.Unix(),
+		AgeSeconds: int64(time.Since(v.Timestamp) / time.Second),
+	}
+	for i := range v.Path {
+		resp.Path = append(resp.Path, &gctrpc.ValuationHop{
+			From:        v.Path[i].From.String(),
+			To:          v.Path[i].To.String(),
+			Rate:        v.Path[i].Rate,
+			Exchange:    v.Path[i].Exchange,
+			Pair:        v.Path[i].Pair.String(),
+			Asset:       v.Path[i].Asset.String(),
+			Source:      v.Path[i].Source,
+			LastUpdated: v.Path[i].LastUpdated.Unix(),
+			Confidence:  v.Path[i].Confidence,
+		})
+	}
+	return v.Value, resp
 }
 
 // GetAccountInfoStream streams an account balance for a specific exchange
@@ -608,7 +756,7 @@ func (s *RPCServer) GetAccountInfoStream(r *gctrpc.GetAccountInfoRequest, stream
 		return err
 	}
 
-	pipe, err := account.SubscribeToExchangeAccount(r.Exchange)
+	pipe, err := s.accountService().SubscribeToExchangeAccount(r.Exchange)
 	if err != nil {
 		return err
 	}
@@ -678,17 +826,25 @@ func (s *RPCServer) GetPortfolio(_ context.Context, r *gctrpc.GetPortfolioReques
 // GetPortfolioSummary returns the portfolio summary
 func (s *RPCServer) GetPortfolioSummary(_ context.Context, r *gctrpc.GetPortfolioSummaryRequest) (*gctrpc.GetPortfolioSummaryResponse, error) {
 	result := s.Portfolio.GetPortfolioSummary()
-	var resp gctrpc.GetPortfolioSummaryResponse
+	fiat := s.Config.Currency.FiatDisplayCurrency
+	g, err := s.GetValuationGraph()
+	if err != nil {
+		return nil, err
+	}
+	resp := gctrpc.GetPortfolioSummaryResponse{ValuationCurrency: fiat.String()}
 
 	p := func(coins []portfolio.Coin) []*gctrpc.Coin {
 		var c []*gctrpc.Coin
 		for x := range coins {
+			value, v := valueOrZero(g, coins[x].Coin, coins[x].Balance, fiat)
 			c = append(c,
 				&gctrpc.Coin{
 					Coin:       coins[x].Coin.String(),
 					Balance:    coins[x].Balance,
 					Address:    coins[x].Address,
 					Percentage: coins[x].Percentage,
+					Value:      value,
+					Valuation:  v,
 				},
 			)
 		}
@@ -993,7 +1149,7 @@ func (s *RPCServer) GetOrder(_ context.Context, r *gctrpc.GetOrderRequest) (*gct
 
 // SubmitOrder submits an order specified by exchange, currency pair and asset
 // type
-func (s *RPCServer) SubmitOrder(_ context.Context, r *gctrpc.SubmitOrderRequest) (*gctrpc.SubmitOrderResponse, error) {
+func (s *RPCServer) SubmitOrder(ctx context.Context, r *gctrpc.SubmitOrderRequest) (*gctrpc.SubmitOrderResponse, error) {
 	a, err := asset.New(r.AssetType)
 	if err != nil {
 		return nil, err
@@ -1028,8 +1184,10 @@ func (s *RPCServer) SubmitOrder(_ context.Context, r *gctrpc.SubmitOrderRequest)
 
 	resp, err := s.OrderManager.Submit(submission)
 	if err != nil {
+		s.auditRequest(ctx, audit.OrderSubmit, r.Exchange, "order submission failed", submission, err)
 		return &gctrpc.SubmitOrderResponse{}, err
 	}
+	s.auditRequest(ctx, audit.OrderSubmit, r.Exchange, "order "+resp.OrderID+" submitted", submission, nil)
 
 	var trades []*gctrpc.Trades
 	for i := range resp.Trades {
@@ -1144,7 +1302,7 @@ func (s *RPCServer) WhaleBomb(_ context.Context, r *gctrpc.WhaleBombRequest) (*g
 
 // CancelOrder cancels an order specified by exchange, currency pair and asset
 // type
-func (s *RPCServer) CancelOrder(_ context.Context, r *gctrpc.CancelOrderRequest) (*gctrpc.GenericResponse, error) {
+func (s *RPCServer) CancelOrder(ctx context.Context, r *gctrpc.CancelOrderRequest) (*gctrpc.GenericResponse, error) {
 	if r.Pair == nil {
 		return nil, errCurrencyPairUnset
 	}
@@ -1166,23 +1324,26 @@ func (s *RPCServer) CancelOrder(_ context.Context, r *gctrpc.CancelOrderRequest)
 		return nil, err
 	}
 
-	err = exch.CancelOrder(&order.Cancel{
+	cancel := &order.Cancel{
 		AccountID:     r.AccountId,
 		ID:            r.OrderId,
 		Side:          order.Side(r.Side),
 		WalletAddress: r.WalletAddress,
 		Pair:          p,
 		AssetType:     a,
-	})
+	}
+	err = exch.CancelOrder(cancel)
 	if err != nil {
+		s.auditRequest(ctx, audit.OrderCancel, r.Exchange, "order "+r.OrderId+" cancellation failed", cancel, err)
 		return nil, err
 	}
+	s.auditRequest(ctx, audit.OrderCancel, r.Exchange, "order "+r.OrderId+" cancelled", cancel, nil)
 	return &gctrpc.GenericResponse{Status: MsgStatusSuccess,
 		Data: fmt.Sprintf("order %s cancelled", r.OrderId)}, nil
 }
 
 // CancelBatchOrders cancels an orders specified by exchange, currency pair and asset type
-func (s *RPCServer) CancelBatchOrders(_ context.Context, r *gctrpc.CancelBatchOrdersRequest) (*gctrpc.CancelBatchOrdersResponse, error) {
+func (s *RPCServer) CancelBatchOrders(ctx context.Context, r *gctrpc.CancelBatchOrdersRequest) (*gctrpc.CancelBatchOrdersResponse, error) {
 	pair := currency.Pair{
 		Delimiter: r.Pair.Delimiter,
 		Base:      currency.NewCode(r.Pair.Base),
@@ -1217,8 +1378,10 @@ func (s *RPCServer) CancelBatchOrders(_ context.Context, r *gctrpc.CancelBatchOr
 
 	_, err = exch.CancelBatchOrders(request)
 	if err != nil {
+		s.auditRequest(ctx, audit.OrderCancel, r.Exchange, "batch order cancellation failed", request, err)
 		return nil, err
 	}
+	s.auditRequest(ctx, audit.OrderCancel, r.Exchange, fmt.Sprintf("%d orders cancelled", len(request)), request, nil)
 
 	return &gctrpc.CancelBatchOrdersResponse{
 		Orders: []*gctrpc.CancelBatchOrdersResponse_Orders{{
@@ -1228,7 +1391,7 @@ func (s *RPCServer) CancelBatchOrders(_ context.Context, r *gctrpc.CancelBatchOr
 }
 
 // CancelAllOrders cancels all orders, filterable by exchange
-func (s *RPCServer) CancelAllOrders(_ context.Context, r *gctrpc.CancelAllOrdersRequest) (*gctrpc.CancelAllOrdersResponse, error) {
+func (s *RPCServer) CancelAllOrders(ctx context.Context, r *gctrpc.CancelAllOrdersRequest) (*gctrpc.CancelAllOrdersResponse, error) {
 	exch := s.GetExchangeByName(r.Exchange)
 	if exch == nil {
 		return &gctrpc.CancelAllOrdersResponse{}, errExchangeNotLoaded
@@ -1236,8 +1399,10 @@ func (s *RPCServer) CancelAllOrders(_ context.Context, r *gctrpc.CancelAllOrders
 
 	resp, err := exch.CancelAllOrders(nil)
 	if err != nil {
+		s.auditRequest(ctx, audit.OrderCancel, r.Exchange, "cancel all orders failed", r, err)
 		return &gctrpc.CancelAllOrdersResponse{}, err
 	}
+	s.auditRequest(ctx, audit.OrderCancel, r.Exchange, fmt.Sprintf("all orders cancelled, %d cancelled", resp.Count), r, nil)
 
 	return &gctrpc.CancelAllOrdersResponse{
 		Count: resp.Count, // count of deleted orders
@@ -1316,7 +1481,7 @@ func (s *RPCServer) GetCryptocurrencyDepositAddress(_ context.Context, r *gctrpc
 
 // WithdrawCryptocurrencyFunds withdraws cryptocurrency funds specified by
 // exchange
-func (s *RPCServer) WithdrawCryptocurrencyFunds(_ context.Context, r *gctrpc.WithdrawCryptoRequest) (*gctrpc.WithdrawResponse, error) {
+func (s *RPCServer) WithdrawCryptocurrencyFunds(ctx context.Context, r *gctrpc.WithdrawCryptoRequest) (*gctrpc.WithdrawResponse, error) {
 	exch := s.GetExchangeByName(r.Exchange)
 	if exch == nil {
 		return nil, errExchangeNotLoaded
@@ -1335,7 +1500,7 @@ func (s *RPCServer) WithdrawCryptocurrencyFunds(_ context.Context, r *gctrpc.Wit
 		},
 	}
 
-	resp, err := s.Engine.SubmitWithdrawal(request)
+	resp, err := s.Engine.SubmitWithdrawal(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -1347,7 +1512,7 @@ func (s *RPCServer) WithdrawCryptocurrencyFunds(_ context.Context, r *gctrpc.Wit
 }
 
 // WithdrawFiatFunds withdraws fiat funds specified by exchange
-func (s *RPCServer) WithdrawFiatFunds(_ context.Context, r *gctrpc.WithdrawFiatRequest) (*gctrpc.WithdrawResponse, error) {
+func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFiatRequest) (*gctrpc.WithdrawResponse, error) {
 	exch := s.GetExchangeByName(r.Exchange)
 	if exch == nil {
 		return nil, errExchangeNotLoaded
@@ -1377,7 +1542,7 @@ func (s *RPCServer) WithdrawFiatFunds(_ context.Context, r *gctrpc.WithdrawFiatR
 		},
 	}
 
-	resp, err := s.Engine.SubmitWithdrawal(request)
+	resp, err := s.Engine.SubmitWithdrawal(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -1393,7 +1558,7 @@ func (s *RPCServer) WithdrawalEventByID(_ context.Context, r *gctrpc.WithdrawalE
 	if !s.Config.Database.Enabled {
 		return nil, database.ErrDatabaseSupportDisabled
 	}
-	v, err := WithdrawalEventByID(r.Id)
+	v, err := WithdrawalEventByID(s.DatabaseInstance(), r.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -1468,14 +1633,14 @@ func (s *RPCServer) WithdrawalEventsByExchange(_ context.Context, r *gctrpc.With
 		return nil, database.ErrDatabaseSupportDisabled
 	}
 	if r.Id == "" {
-		ret, err := WithdrawalEventByExchange(r.Exchange, int(r.Limit))
+		ret, err := WithdrawalEventByExchange(s.DatabaseInstance(), r.Exchange, int(r.Limit))
 		if err != nil {
 			return nil, err
 		}
 		return parseMultipleEvents(ret), nil
 	}
 
-	ret, err := WithdrawalEventByExchangeID(r.Exchange, r.Id)
+	ret, err := WithdrawalEventByExchangeID(s.DatabaseInstance(), r.Exchange, r.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -1495,7 +1660,7 @@ func (s *RPCServer) WithdrawalEventsByDate(_ context.Context, r *gctrpc.Withdraw
 		return nil, err
 	}
 	var ret []*withdraw.Response
-	ret, err = WithdrawEventByDate(r.Exchange, UTCStartTime, UTCEndTime, int(r.Limit))
+	ret, err = WithdrawEventByDate(s.DatabaseInstance(), r.Exchange, UTCStartTime, UTCEndTime, int(r.Limit))
 	if err != nil {
 		return nil, err
 	}
@@ -1518,8 +1683,9 @@ func (s *RPCServer) GetLoggerDetails(_ context.Context, r *gctrpc.GetLoggerDetai
 }
 
 // SetLoggerDetails sets a loggers details
-func (s *RPCServer) SetLoggerDetails(_ context.Context, r *gctrpc.SetLoggerDetailsRequest) (*gctrpc.GetLoggerDetailsResponse, error) {
+func (s *RPCServer) SetLoggerDetails(ctx context.Context, r *gctrpc.SetLoggerDetailsRequest) (*gctrpc.GetLoggerDetailsResponse, error) {
 	levels, err := log.SetLevel(r.Logger, r.Level)
+	s.auditRequest(ctx, audit.LoggerUpdate, r.Logger, "logger levels set to "+r.Level, r, err)
 	if err != nil {
 		return nil, err
 	}
@@ -1572,31 +1738,44 @@ func (s *RPCServer) GetExchangePairs(_ context.Context, r *gctrpc.GetExchangePai
 }
 
 // SetExchangePair enables/disabled the specified pair(s) on an exchange
-func (s *RPCServer) SetExchangePair(_ context.Context, r *gctrpc.SetExchangePairRequest) (*gctrpc.GenericResponse, error) {
-	exchCfg, err := s.Config.GetExchangeConfig(r.Exchange)
+func (s *RPCServer) SetExchangePair(ctx context.Context, r *gctrpc.SetExchangePairRequest) (*gctrpc.GenericResponse, error) {
+	err := s.setExchangePair(r)
+	message := "pairs disabled"
+	if r.Enable {
+		message = "pairs enabled"
+	}
+	s.auditRequest(ctx, audit.ExchangePairToggle, r.Exchange, message, r, err)
 	if err != nil {
 		return nil, err
 	}
+	return &gctrpc.GenericResponse{Status: MsgStatusSuccess}, nil
+}
+
+func (s *RPCServer) setExchangePair(r *gctrpc.SetExchangePairRequest) error {
+	exchCfg, err := s.Config.GetExchangeConfig(r.Exchange)
+	if err != nil {
+		return err
+	}
 
 	a, err := asset.New(r.AssetType)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	exch := s.GetExchangeByName(r.Exchange)
 	err = checkParams(r.Exchange, exch, a, currency.Pair{})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	base := exch.GetBase()
 	if base == nil {
-		return nil, errExchangeBaseNotFound
+		return errExchangeBaseNotFound
 	}
 
 	pairFmt, err := s.Config.GetPairFormat(r.Exchange, a)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	var pass bool
 	var newErrors common.Errors
@@ -1604,7 +1783,7 @@ func (s *RPCServer) SetExchangePair(_ context.Context, r *gctrpc.SetExchangePair
 		var p currency.Pair
 		p, err = currency.NewPairFromStrings(r.Pairs[i].Base, r.Pairs[i].Quote)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		if r.Enable {
@@ -1645,10 +1824,9 @@ func (s *RPCServer) SetExchangePair(_ context.Context, r *gctrpc.SetExchangePair
 	}
 
 	if newErrors != nil {
-		return nil, newErrors
+		return newErrors
 	}
-
-	return &gctrpc.GenericResponse{Status: MsgStatusSuccess}, nil
+	return nil
 }
 
 // GetOrderbookStream streams the requested updated orderbook
@@ -1670,7 +1848,7 @@ func (s *RPCServer) GetOrderbookStream(r *gctrpc.GetOrderbookStreamRequest, stre
 		return err
 	}
 
-	depth, err := orderbook.GetDepth(r.Exchange, p, a)
+	depth, err := s.orderbookService().GetDepth(r.Exchange, p, a)
 	if err != nil {
 		return err
 	}
@@ -1710,7 +1888,7 @@ func (s *RPCServer) GetExchangeOrderbookStream(r *gctrpc.GetExchangeOrderbookStr
 		return errExchangeNameUnset
 	}
 
-	pipe, err := orderbook.SubscribeToExchangeOrderbooks(r.Exchange)
+	pipe, err := s.orderbookService().SubscribeToExchangeOrderbooks(r.Exchange)
 	if err != nil {
 		return err
 	}
@@ -1775,7 +1953,7 @@ func (s *RPCServer) GetTickerStream(r *gctrpc.GetTickerStreamRequest, stream gct
 		return err
 	}
 
-	pipe, err := ticker.SubscribeTicker(r.Exchange, p, a)
+	pipe, err := s.tickerService().SubscribeTicker(r.Exchange, p, a)
 	if err != nil {
 		return err
 	}
@@ -1815,7 +1993,7 @@ func (s *RPCServer) GetExchangeTickerStream(r *gctrpc.GetExchangeTickerStreamReq
 		return errExchangeNameUnset
 	}
 
-	pipe, err := ticker.SubscribeToExchangeTickers(r.Exchange)
+	pipe, err := s.tickerService().SubscribeToExchangeTickers(r.Exchange)
 	if err != nil {
 		return err
 	}
@@ -1860,7 +2038,7 @@ func (s *RPCServer) GetAuditEvent(_ context.Context, r *gctrpc.GetAuditEventRequ
 	if err != nil {
 		return nil, err
 	}
-	events, err := audit.GetEvent(UTCStartTime, UTCEndTime, r.OrderBy, int(r.Limit))
+	events, err := s.auditLog().GetEvent(UTCStartTime, UTCEndTime, r.OrderBy, int(r.Limit))
 	if err != nil {
 		return nil, err
 	}
@@ -1875,6 +2053,11 @@ func (s *RPCServer) GetAuditEvent(_ context.Context, r *gctrpc.GetAuditEventRequ
 				Identifier: v[x].Identifier,
 				Message:    v[x].Message,
 				Timestamp:  v[x].CreatedAt.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+				ActorType:  v[x].ActorType,
+				Actor:      v[x].Actor,
+				Data:       v[x].Data,
+				Sequence:   v[x].Sequence,
+				Hash:       v[x].Hash,
 			}
 
 			resp.Events = append(resp.Events, tempEvent)
@@ -1886,6 +2069,11 @@ func (s *RPCServer) GetAuditEvent(_ context.Context, r *gctrpc.GetAuditEventRequ
 				Identifier: v[x].Identifier,
 				Message:    v[x].Message,
 				Timestamp:  v[x].CreatedAt,
+				ActorType:  v[x].ActorType,
+				Actor:      v[x].Actor,
+				Data:       v[x].Data,
+				Sequence:   v[x].Sequence,
+				Hash:       v[x].Hash,
 			}
 			resp.Events = append(resp.Events, tempEvent)
 		}
@@ -1894,6 +2082,30 @@ func (s *RPCServer) GetAuditEvent(_ context.Context, r *gctrpc.GetAuditEventRequ
 	return &resp, nil
 }
 
+// VerifyAuditLog verifies the audit log hash chain, reporting any records
+// which have been modified or removed
+func (s *RPCServer) VerifyAuditLog(_ context.Context, r *gctrpc.VerifyAuditLogRequest) (*gctrpc.VerifyAuditLogResponse, error) {
+	result, err := s.auditLog().Verify(r.ExpectedHeadHash)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.VerifyAuditLogResponse{
+		Valid:        len(result.Issues) == 0,
+		Verified:     result.Verified,
+		Unchained:    result.Unchained,
+		HeadSequence: result.HeadSequence,
+		HeadHash:     result.HeadHash,
+	}
+	for i := range result.Issues {
+		resp.Issues = append(resp.Issues, &gctrpc.AuditLogIssue{
+			Sequence: result.Issues[i].Sequence,
+			Id:       result.Issues[i].ID,
+			Reason:   result.Issues[i].Reason,
+		})
+	}
+	return resp, nil
+}
+
 // GetHistoricCandles returns historical candles for a given exchange
 func (s *RPCServer) GetHistoricCandles(_ context.Context, r *gctrpc.GetHistoricCandlesRequest) (*gctrpc.GetHistoricCandlesResponse, error) {
 	UTCStartTime, err := time.Parse(common.SimpleTimeFormat, r.Start)
@@ -2120,9 +2332,19 @@ func (s *RPCServer) GCTScriptQuery(_ context.Context, r *gctrpc.GCTScriptQueryRe
 }
 
 // GCTScriptExecute execute a script
-func (s *RPCServer) GCTScriptExecute(_ context.Context, r *gctrpc.GCTScriptExecuteRequest) (*gctrpc.GenericResponse, error) {
+func (s *RPCServer) GCTScriptExecute(ctx context.Context, r *gctrpc.GCTScriptExecuteRequest) (*gctrpc.GenericResponse, error) {
+	resp := s.gctScriptExecute(r)
+	var err error
+	if resp.Status != MsgStatusOK {
+		err = scriptResponseError(resp)
+	}
+	s.auditRequest(ctx, audit.ScriptExecute, r.Script.Name, "script executed", r, err)
+	return resp, nil
+}
+
+func (s *RPCServer) gctScriptExecute(r *gctrpc.GCTScriptExecuteRequest) *gctrpc.GenericResponse {
 	if !s.GctScriptManager.Started() {
-		return &gctrpc.GenericResponse{Status: gctscript.ErrScriptingDisabled.Error()}, nil
+		return &gctrpc.GenericResponse{Status: gctscript.ErrScriptingDisabled.Error()}
 	}
 
 	if r.Script.Path == "" {
@@ -2131,7 +2353,7 @@ func (s *RPCServer) GCTScriptExecute(_ context.Context, r *gctrpc.GCTScriptExecu
 
 	gctVM := s.GctScriptManager.New()
 	if gctVM == nil {
-		return &gctrpc.GenericResponse{Status: MsgStatusError, Data: "unable to create VM instance"}, nil
+		return &gctrpc.GenericResponse{Status: MsgStatusError, Data: "unable to create VM instance"}
 	}
 
 	script := filepath.Join(r.Script.Path, r.Script.Name)
@@ -2140,7 +2362,7 @@ func (s *RPCServer) GCTScriptExecute(_ context.Context, r *gctrpc.GCTScriptExecu
 		return &gctrpc.GenericResponse{
 			Status: MsgStatusError,
 			Data:   err.Error(),
-		}, nil
+		}
 	}
 
 	go gctVM.CompileAndRun()
@@ -2148,11 +2370,22 @@ func (s *RPCServer) GCTScriptExecute(_ context.Context, r *gctrpc.GCTScriptExecu
 	return &gctrpc.GenericResponse{
 		Status: MsgStatusOK,
 		Data:   gctVM.ShortName() + " (" + gctVM.ID.String() + ") executed",
-	}, nil
+	}
 }
 
 // GCTScriptStop terminate a running script
-func (s *RPCServer) GCTScriptStop(_ context.Context, r *gctrpc.GCTScriptStopRequest) (*gctrpc.GenericResponse, error) {
+func (s *RPCServer) GCTScriptStop(ctx context.Context, r *gctrpc.GCTScriptStopRequest) (*gctrpc.GenericResponse, error) {
+	resp, err := s.gctScriptStop(r)
+	if err == nil && resp.Status != MsgStatusOK {
+		err = scriptResponseError(resp)
+	}
+	s.auditRequest(ctx, audit.ScriptStop, r.Script.UUID, "script stopped", r, err)
+	return resp, nil
+}
+
+// gctScriptStop stops the requested script, returning the error the script
+// failed to shut down with alongside the response
+func (s *RPCServer) gctScriptStop(r *gctrpc.GCTScriptStopRequest) (*gctrpc.GenericResponse, error) {
 	if !s.GctScriptManager.Started() {
 		return &gctrpc.GenericResponse{Status: gctscript.ErrScriptingDisabled.Error()}, nil
 	}
@@ -2168,17 +2401,41 @@ func (s *RPCServer) GCTScriptStop(_ context.Context, r *gctrpc.GCTScriptStopRequ
 		if err != nil {
 			status = " " + err.Error()
 		}
-		return &gctrpc.GenericResponse{Status: MsgStatusOK, Data: v.(*gctscript.VM).ID.String() + status}, nil
+		return &gctrpc.GenericResponse{Status: MsgStatusOK, Data: v.(*gctscript.VM).ID.String() + status}, err
 	}
 	return &gctrpc.GenericResponse{Status: MsgStatusError, Data: "no running script found"}, nil
 }
 
+// scriptResponseError returns the failure reported in a script response as an
+// error for the audit log
+func scriptResponseError(resp *gctrpc.GenericResponse) error {
+	if resp.Data == "" {
+		return errors.New(resp.Status)
+	}
+	return errors.New(resp.Data)
+}
+
 // GCTScriptUpload upload a new script to ScriptPath
-func (s *RPCServer) GCTScriptUpload(_ context.Context, r *gctrpc.GCTScriptUploadRequest) (*gctrpc.GenericResponse, error) {
+func (s *RPCServer) GCTScriptUpload(ctx context.Context, r *gctrpc.GCTScriptUploadRequest) (*gctrpc.GenericResponse, error) {
 	if !s.GctScriptManager.Started() {
 		return &gctrpc.GenericResponse{Status: gctscript.ErrScriptingDisabled.Error()}, nil
 	}
 
+	var manifest *permission.Manifest
+	if r.Permissions != nil {
+		manifest = &permission.Manifest{
+			Modules:            r.Permissions.Modules,
+			Exchanges:          r.Permissions.Exchanges,
+			Pairs:              r.Permissions.Pairs,
+			MaxOrderNotional:   r.Permissions.MaxOrderNotional,
+			MaxOrdersPerMinute: int(r.Permissions.MaxOrdersPerMinute),
+			AllowWithdrawals:   r.Permissions.AllowWithdrawals,
+		}
+		if err := manifest.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	fPath := filepath.Join(gctscript.ScriptPath, r.ScriptName)
 	var fPathExits = fPath
 	if filepath.Ext(fPath) == ".zip" {
@@ -2223,6 +2480,7 @@ func (s *RPCServer) GCTScriptUpload(_ context.Context, r *gctrpc.GCTScriptUpload
 		log.Errorln(log.Global, "Failed to close file handle, archive removal may fail")
 	}
 
+	scripts := []string{r.ScriptName}
 	if r.Archived {
 		files, errExtract := archive.UnZip(fPath, filepath.Join(gctscript.ScriptPath, r.ScriptName[:len(r.ScriptName)-4]))
 		if errExtract != nil {
@@ -2247,6 +2505,7 @@ func (s *RPCServer) GCTScriptUpload(_ context.Context, r *gctrpc.GCTScriptUpload
 			}
 			return &gctrpc.GenericResponse{Status: gctscript.ErrScriptFailedValidation, Data: strings.Join(failedFiles, ", ")}, nil
 		}
+		scripts = files
 	} else {
 		err = s.GctScriptManager.Validate(fPath)
 		if err != nil {
@@ -2258,6 +2517,24 @@ func (s *RPCServer) GCTScriptUpload(_ context.Context, r *gctrpc.GCTScriptUpload
 		}
 	}
 
+	if manifest != nil {
+		for i := range scripts {
+			if filepath.Ext(scripts[i]) != common.GctExt {
+				continue
+			}
+			err = gctscript.SavePermissions(scripts[i], manifest)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	s.auditLog().EventWithContext(ctx, audit.ScriptUpload, r.ScriptName, "script uploaded", map[string]interface{}{
+		"overwrite":   r.Overwrite,
+		"archived":    r.Archived,
+		"sha256":      crypto.HexEncodeToString(crypto.GetSHA256(r.Data)),
+		"permissions": manifest,
+	})
 	return &gctrpc.GenericResponse{
 		Status: MsgStatusOK,
 		Data:   fmt.Sprintf("script %s written", newFile.Name()),
@@ -2353,42 +2630,116 @@ func (s *RPCServer) GCTScriptAutoLoadToggle(_ context.Context, r *gctrpc.GCTScri
 	return &gctrpc.GenericResponse{Status: "success", Data: "script " + r.Script + " added to autoload list"}, nil
 }
 
+// GCTScriptGetState returns the persisted state of a script
+func (s *RPCServer) GCTScriptGetState(_ context.Context, r *gctrpc.GCTScriptGetStateRequest) (*gctrpc.GCTScriptGetStateResponse, error) {
+	if !s.GctScriptManager.Started() {
+		return &gctrpc.GCTScriptGetStateResponse{Status: gctscript.ErrScriptingDisabled.Error()}, nil
+	}
+
+	script, err := scriptStateName(r.Script)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := scriptstate.State{DB: s.DatabaseInstance()}.List(script)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GCTScriptGetStateResponse{
+		Status: MsgStatusOK,
+		Script: script,
+		State:  make([]*gctrpc.GCTScriptStateEntry, len(entries)),
+	}
+	for i := range entries {
+		resp.State[i] = &gctrpc.GCTScriptStateEntry{
+			Key:       entries[i].Key,
+			Value:     entries[i].Value,
+			UpdatedAt: entries[i].UpdatedAt.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+		}
+	}
+	return resp, nil
+}
+
+// GCTScriptClearState removes a key from a scripts persisted state or all of
+// its state when no key is supplied
+func (s *RPCServer) GCTScriptClearState(_ context.Context, r *gctrpc.GCTScriptClearStateRequest) (*gctrpc.GenericResponse, error) {
+	if !s.GctScriptManager.Started() {
+		return &gctrpc.GenericResponse{Status: gctscript.ErrScriptingDisabled.Error()}, nil
+	}
+
+	script, err := scriptStateName(r.Script)
+	if err != nil {
+		return nil, err
+	}
+	if r.Key != "" {
+		err = scriptstate.State{DB: s.DatabaseInstance()}.Delete(script, r.Key)
+		if err != nil {
+			return nil, err
+		}
+		return &gctrpc.GenericResponse{Status: MsgStatusSuccess, Data: "key " + r.Key + " removed from " + script + " state"}, nil
+	}
+	removed, err := scriptstate.State{DB: s.DatabaseInstance()}.Clear(script)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: MsgStatusSuccess, Data: fmt.Sprintf("%d keys removed from %s state", removed, script)}, nil
+}
+
+// scriptStateName returns the name state is stored under for a script
+func scriptStateName(name string) (string, error) {
+	if name == "" {
+		return "", errScriptNameUnset
+	}
+	name = filepath.Base(name)
+	if filepath.Ext(name) != common.GctExt {
+		name += common.GctExt
+	}
+	return name, nil
+}
+
 // SetExchangeAsset enables or disables an exchanges asset type
-func (s *RPCServer) SetExchangeAsset(_ context.Context, r *gctrpc.SetExchangeAssetRequest) (*gctrpc.GenericResponse, error) {
+func (s *RPCServer) SetExchangeAsset(ctx context.Context, r *gctrpc.SetExchangeAssetRequest) (*gctrpc.GenericResponse, error) {
+	err := s.setExchangeAsset(r)
+	message := "asset " + r.Asset + " disabled"
+	if r.Enable {
+		message = "asset " + r.Asset + " enabled"
+	}
+	s.auditRequest(ctx, audit.ExchangeAssetToggle, r.Exchange, message, r, err)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: MsgStatusSuccess}, nil
+}
+
+func (s *RPCServer) setExchangeAsset(r *gctrpc.SetExchangeAssetRequest) error {
 	exch := s.GetExchangeByName(r.Exchange)
 	if exch == nil {
-		return nil, errExchangeNotLoaded
+		return errExchangeNotLoaded
 	}
 
 	exchCfg, err := s.Config.GetExchangeConfig(r.Exchange)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	base := exch.GetBase()
 	if base == nil {
-		return nil, errExchangeBaseNotFound
+		return errExchangeBaseNotFound
 	}
 
 	if r.Asset == "" {
-		return nil, errors.New("asset type must be specified")
+		return errors.New("asset type must be specified")
 	}
 
 	a, err := asset.New(r.Asset)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	err = base.CurrencyPairs.SetAssetEnabled(a, r.Enable)
 	if err != nil {
-		return nil, err
-	}
-	err = exchCfg.CurrencyPairs.SetAssetEnabled(a, r.Enable)
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	return &gctrpc.GenericResponse{Status: MsgStatusSuccess}, nil
+	return exchCfg.CurrencyPairs.SetAssetEnabled(a, r.Enable)
 }
 
 // SetAllExchangePairs enables or disables an exchanges pairs
@@ -3100,3 +3451,433 @@ func checkParams(exchName string, e exchange.IBotExchange, a asset.Item, p curre
 	}
 	return fmt.Errorf("%v %w", p, errCurrencyPairInvalid)
 }
+
+// GetExchangeHealth returns the connectivity health of an exchange, or all
+// exchanges if no exchange is specified
+func (s *RPCServer) GetExchangeHealth(_ context.Context, r *gctrpc.GetExchangeHealthRequest) (*gctrpc.GetExchangeHealthResponse, error) {
+	var health []ExchangeHealth
+	if r.Exchange == "" {
+		var err error
+		health, err = s.ExchangeHealthManager.GetAllExchangeHealth()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		eh, err := s.ExchangeHealthManager.GetExchangeHealth(r.Exchange)
+		if err != nil {
+			return nil, err
+		}
+		health = append(health, eh)
+	}
+
+	unix := func(t time.Time) int64 {
+		if t.IsZero() {
+			return 0
+		}
+		return t.Unix()
+	}
+	resp := &gctrpc.GetExchangeHealthResponse{}
+	for i := range health {
+		resp.Exchanges = append(resp.Exchanges, &gctrpc.ExchangeHealth{
+			Exchange:           health[i].Exchange,
+			State:              string(health[i].State),
+			Reasons:            health[i].Reasons,
+			WebsocketEnabled:   health[i].WebsocketEnabled,
+			WebsocketConnected: health[i].WebsocketConnected,
+			LastTrafficTimeout: unix(health[i].LastTrafficTimeout),
+			RestSupported:      health[i].RESTSupported,
+			RestRequests:       health[i].RESTRequests,
+			RestFailures:       health[i].RESTFailures,
+			RestErrorRate:      health[i].RESTErrorRate,
+			ClockSkew:          int64(health[i].ClockSkew),
+			LastStateChange:    unix(health[i].LastStateChange),
+			LastChecked:        unix(health[i].LastChecked),
+		})
+	}
+	return resp, nil
+}
+
+// UpsertDataHistoryJob adds or updates a data history job for the data history
+// manager to process
+func (s *RPCServer) UpsertDataHistoryJob(_ context.Context, r *gctrpc.UpsertDataHistoryJobRequest) (*gctrpc.UpsertDataHistoryJobResponse, error) {
+	if r == nil || r.Pair == nil {
+		return nil, errInvalidArguments
+	}
+	a, err := asset.New(r.Asset)
+	if err != nil {
+		return nil, err
+	}
+	p := currency.Pair{
+		Delimiter: r.Pair.Delimiter,
+		Base:      currency.NewCode(r.Pair.Base),
+		Quote:     currency.NewCode(r.Pair.Quote),
+	}
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse start date %v", errInvalidTimes, err)
+	}
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse end date %v", errInvalidTimes, err)
+	}
+	job := DataHistoryJob{
+		Nickname:         r.Nickname,
+		Exchange:         r.Exchange,
+		Asset:            a,
+		Pair:             p,
+		StartDate:        start,
+		EndDate:          end,
+		Interval:         kline.Interval(r.Interval),
+		DataType:         dataHistoryDataType(r.DataType),
+		RequestSizeLimit: r.RequestSizeLimit,
+		MaxRetryAttempts: r.MaxRetryAttempts,
+		BatchSize:        r.BatchSize,
+	}
+	err = s.DataHistoryManager.UpsertJob(&job, r.InsertOnly)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.UpsertDataHistoryJobResponse{
+		Message: "successfully upserted job: " + job.Nickname,
+		JobId:   job.ID.String(),
+	}, nil
+}
+
+// GetDataHistoryJobDetails returns a data history job and, when requested,
+// the results of every request it has made
+func (s *RPCServer) GetDataHistoryJobDetails(_ context.Context, r *gctrpc.GetDataHistoryJobDetailsRequest) (*gctrpc.DataHistoryJob, error) {
+	if r == nil || (r.Nickname == "" && r.Id == "") || (r.Nickname != "" && r.Id != "") {
+		return nil, errInvalidArguments
+	}
+	var job *DataHistoryJob
+	var err error
+	if r.Id != "" {
+		var id uuid.UUID
+		id, err = uuid.FromString(r.Id)
+		if err != nil {
+			return nil, err
+		}
+		job, err = s.DataHistoryManager.GetByID(id)
+	} else {
+		job, err = s.DataHistoryManager.GetByNickname(r.Nickname)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := convertDataHistoryJobToRPC(job)
+	if r.FullDetails {
+		for i := range job.Results {
+			resp.JobResults = append(resp.JobResults, &gctrpc.DataHistoryJobResult{
+				StartDate: job.Results[i].IntervalStartDate.Format(common.SimpleTimeFormat),
+				EndDate:   job.Results[i].IntervalEndDate.Format(common.SimpleTimeFormat),
+				HasData:   job.Results[i].Status == dataHistoryStatusComplete,
+				Message:   job.Results[i].Result,
+				RunDate:   job.Results[i].Date.Format(common.SimpleTimeFormat),
+				Status:    job.Results[i].Status.String(),
+			})
+		}
+	} else {
+		resp.ResultSummaries = summariseDataHistoryJobResults(job.Results)
+	}
+	return resp, nil
+}
+
+// GetActiveDataHistoryJobs returns all data history jobs that are still being
+// processed
+func (s *RPCServer) GetActiveDataHistoryJobs(_ context.Context, _ *gctrpc.GetInfoRequest) (*gctrpc.DataHistoryJobs, error) {
+	jobs, err := s.DataHistoryManager.GetActiveJobs()
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.DataHistoryJobs{}
+	for i := range jobs {
+		resp.Results = append(resp.Results, convertDataHistoryJobToRPC(&jobs[i]))
+	}
+	return resp, nil
+}
+
+// GetDataHistoryJobsBetween returns all data history jobs created between the
+// supplied dates
+func (s *RPCServer) GetDataHistoryJobsBetween(_ context.Context, r *gctrpc.GetDataHistoryJobsBetweenRequest) (*gctrpc.DataHistoryJobs, error) {
+	if r == nil {
+		return nil, errInvalidArguments
+	}
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse start date %v", errInvalidTimes, err)
+	}
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse end date %v", errInvalidTimes, err)
+	}
+	jobs, err := s.DataHistoryManager.GetJobsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.DataHistoryJobs{}
+	for i := range jobs {
+		resp.Results = append(resp.Results, convertDataHistoryJobToRPC(&jobs[i]))
+	}
+	return resp, nil
+}
+
+// DeleteDataHistoryJob marks a data history job as removed so that it is no
+// longer processed
+func (s *RPCServer) DeleteDataHistoryJob(_ context.Context, r *gctrpc.DeleteDataHistoryJobRequest) (*gctrpc.GenericResponse, error) {
+	if r == nil || (r.Nickname == "" && r.Id == "") || (r.Nickname != "" && r.Id != "") {
+		return nil, errInvalidArguments
+	}
+	err := s.DataHistoryManager.DeleteJob(r.Nickname, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: MsgStatusSuccess}, nil
+}
+
+func convertDataHistoryJobToRPC(job *DataHistoryJob) *gctrpc.DataHistoryJob {
+	return &gctrpc.DataHistoryJob{
+		Id:       job.ID.String(),
+		Nickname: job.Nickname,
+		Exchange: job.Exchange,
+		Asset:    job.Asset.String(),
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: job.Pair.Delimiter,
+			Base:      job.Pair.Base.String(),
+			Quote:     job.Pair.Quote.String(),
+		},
+		StartDate:        job.StartDate.Format(common.SimpleTimeFormat),
+		EndDate:          job.EndDate.Format(common.SimpleTimeFormat),
+		Interval:         int64(job.Interval.Duration()),
+		RequestSizeLimit: job.RequestSizeLimit,
+		DataType:         job.DataType.String(),
+		MaxRetryAttempts: job.MaxRetryAttempts,
+		BatchSize:        job.BatchSize,
+		Status:           job.Status.String(),
+	}
+}
+
+// summariseDataHistoryJobResults counts job results by status, eg
+// "complete: 10, failed: 2"
+func summariseDataHistoryJobResults(results []DataHistoryJobResult) string {
+	counts := make(map[dataHistoryStatus]int)
+	for i := range results {
+		counts[results[i].Status]++
+	}
+	var summary []string
+	for _, status := range []dataHistoryStatus{
+		dataHistoryStatusComplete,
+		dataHistoryIntervalMissingData,
+		dataHistoryStatusFailed,
+	} {
+		if counts[status] > 0 {
+			summary = append(summary, fmt.Sprintf("%s: %d", status, counts[status]))
+		}
+	}
+	return strings.Join(summary, ", ")
+}
+
+// GetEquityCurve returns the total value of stored balance snapshots over
+// time, optionally limited to a single exchange
+func (s *RPCServer) GetEquityCurve(_ context.Context, r *gctrpc.GetBalanceSnapshotRequest) (*gctrpc.GetEquityCurveResponse, error) {
+	fiat, points, err := s.equityCurveFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetEquityCurveResponse{FiatCurrency: fiat.String()}
+	for i := range points {
+		resp.Points = append(resp.Points, &gctrpc.EquityPoint{
+			Timestamp: points[i].Timestamp.Format(common.SimpleTimeFormat),
+			Value:     points[i].Value,
+		})
+	}
+	return resp, nil
+}
+
+// GetDailyPnL returns the day over day change in value of stored balance
+// snapshots, optionally limited to a single exchange
+func (s *RPCServer) GetDailyPnL(_ context.Context, r *gctrpc.GetBalanceSnapshotRequest) (*gctrpc.GetDailyPnLResponse, error) {
+	fiat, points, err := s.equityCurveFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	days := calculateDailyPnL(points)
+	resp := &gctrpc.GetDailyPnLResponse{FiatCurrency: fiat.String()}
+	for i := range days {
+		resp.Days = append(resp.Days, &gctrpc.DailyPnL{
+			Date:          days[i].Date.Format(common.SimpleTimeFormat),
+			OpeningEquity: days[i].OpeningEquity,
+			ClosingEquity: days[i].ClosingEquity,
+			Pnl:           days[i].PnL,
+			PnlPercent:    days[i].PnLPercent,
+		})
+	}
+	return resp, nil
+}
+
+func (s *RPCServer) equityCurveFromRequest(r *gctrpc.GetBalanceSnapshotRequest) (currency.Code, []EquityPoint, error) {
+	if r == nil {
+		return currency.Code{}, nil, errInvalidArguments
+	}
+	start, err := time.Parse(common.SimpleTimeFormat, r.StartDate)
+	if err != nil {
+		return currency.Code{}, nil, fmt.Errorf("%w cannot parse start date %v", errInvalidTimes, err)
+	}
+	end, err := time.Parse(common.SimpleTimeFormat, r.EndDate)
+	if err != nil {
+		return currency.Code{}, nil, fmt.Errorf("%w cannot parse end date %v", errInvalidTimes, err)
+	}
+	if s.Config.BalanceSnapshot.FiatCurrency == "" {
+		return currency.Code{}, nil, errSnapshotNotConfigured
+	}
+	fiat := currency.NewCode(s.Config.BalanceSnapshot.FiatCurrency)
+	points, err := getEquityCurve(s.DatabaseInstance(), r.Exchange, fiat, start, end)
+	if err != nil {
+		return currency.Code{}, nil, err
+	}
+	return fiat, points, nil
+}
+
+// RunDatabaseRetention applies the configured database retention policies
+// straight away. A dry run reports what would happen without changing data
+func (s *RPCServer) RunDatabaseRetention(_ context.Context, r *gctrpc.RunDatabaseRetentionRequest) (*gctrpc.RunDatabaseRetentionResponse, error) {
+	if r == nil {
+		return nil, errInvalidArguments
+	}
+	if !s.DatabaseManager.Started() || !s.DatabaseInstance().Connected {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	cfg := s.Config.DatabaseRetention
+	if len(cfg.Policies) == 0 {
+		return nil, errNoRetentionPolicies
+	}
+	reports := s.DatabaseRetentionManager.enforce(s.DatabaseInstance(), &cfg, r.DryRun, time.Now())
+	resp := &gctrpc.RunDatabaseRetentionResponse{}
+	for i := range reports {
+		report := &gctrpc.DatabaseRetentionReport{
+			Exchange:       reports[i].Exchange,
+			Asset:          reports[i].Asset,
+			Action:         reports[i].Action,
+			DryRun:         reports[i].DryRun,
+			TradesRemoved:  reports[i].TradesRemoved,
+			CandlesCreated: reports[i].CandlesCreated,
+			CandlesRemoved: reports[i].CandlesRemoved,
+			Archives:       reports[i].Archives,
+			Error:          reports[i].Error,
+		}
+		if !reports[i].TradeCutoff.IsZero() {
+			report.TradeCutoff = reports[i].TradeCutoff.Format(common.SimpleTimeFormat)
+		}
+		if !reports[i].CandleCutoff.IsZero() {
+			report.CandleCutoff = reports[i].CandleCutoff.Format(common.SimpleTimeFormat)
+		}
+		resp.Reports = append(resp.Reports, report)
+	}
+	return resp, nil
+}
+
+// CreateAPIToken creates a scoped remote control API token. The token value is
+// only returned here, the config stores its hash
+func (s *RPCServer) CreateAPIToken(ctx context.Context, r *gctrpc.CreateAPITokenRequest) (*gctrpc.CreateAPITokenResponse, error) {
+	if r == nil {
+		return nil, errInvalidArguments
+	}
+	var expiry time.Duration
+	if r.Expiry != "" {
+		var err error
+		expiry, err = time.ParseDuration(r.Expiry)
+		if err != nil {
+			return nil, err
+		}
+		if expiry <= 0 {
+			return nil, errInvalidTokenExpiry
+		}
+	}
+	var owner string
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		owner = principalActorID(p)
+	}
+	value, token, err := auth.NewToken(r.Name, owner, r.Scopes, expiry)
+	if err != nil {
+		return nil, err
+	}
+	err = s.Config.AddAPIToken(&token)
+	if err == nil {
+		err = s.saveRemoteControlConfig()
+	}
+	s.auditRequest(ctx, audit.APITokenCreate, token.ID, "api token created", r, err)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.CreateAPITokenResponse{
+		Token:  apiTokenToRPC(&token),
+		Secret: value,
+	}, nil
+}
+
+// RevokeAPIToken removes a remote control API token
+func (s *RPCServer) RevokeAPIToken(ctx context.Context, r *gctrpc.RevokeAPITokenRequest) (*gctrpc.GenericResponse, error) {
+	if r == nil || r.Id == "" {
+		return nil, errTokenIDUnset
+	}
+	err := s.Config.RemoveAPIToken(r.Id)
+	if err == nil {
+		err = s.saveRemoteControlConfig()
+	}
+	s.auditRequest(ctx, audit.APITokenRevoke, r.Id, "api token revoked", r, err)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: MsgStatusSuccess, Data: r.Id}, nil
+}
+
+// ListAPITokens returns the configured remote control API tokens without their
+// hashes
+func (s *RPCServer) ListAPITokens(_ context.Context, _ *gctrpc.ListAPITokensRequest) (*gctrpc.ListAPITokensResponse, error) {
+	tokens := s.Config.GetAPITokens()
+	resp := &gctrpc.ListAPITokensResponse{Tokens: make([]*gctrpc.APIToken, len(tokens))}
+	for i := range tokens {
+		resp.Tokens[i] = apiTokenToRPC(&tokens[i])
+	}
+	return resp, nil
+}
+
+// ReloadConfig reloads the config file, applying the changes which are safe
+// to make at runtime and reporting those which require a restart
+func (s *RPCServer) ReloadConfig(ctx context.Context, r *gctrpc.ReloadConfigRequest) (*gctrpc.ReloadConfigResponse, error) {
+	result, err := s.Engine.ReloadConfig()
+	s.auditRequest(ctx, audit.ConfigReload, s.Settings.ConfigFile, "config reloaded", r, err)
+	if err != nil {
+		return nil, err
+	}
+	logConfigReload(result)
+	return &gctrpc.ReloadConfigResponse{
+		Applied:         result.Applied,
+		RequiresRestart: result.RequiresRestart,
+		Failed:          result.Failed,
+	}, nil
+}
+
+// saveRemoteControlConfig persists API token changes unless in dry run mode
+func (s *RPCServer) saveRemoteControlConfig() error {
+	if s.Settings.EnableDryRun {
+		return nil
+	}
+	if s.configRestartRequired() {
+		return errConfigRestartPending
+	}
+	return s.Config.SaveConfigToFile(s.Settings.ConfigFile)
+}
+
+func apiTokenToRPC(t *auth.Token) *gctrpc.APIToken {
+	resp := &gctrpc.APIToken{
+		Id:      t.ID,
+		Name:    t.Name,
+		Owner:   t.Owner,
+		Scopes:  t.Scopes,
+		Created: t.Created.Format(common.SimpleTimeFormat),
+	}
+	if !t.Expires.IsZero() {
+		resp.Expires = t.Expires.Format(common.SimpleTimeFormat)
+	}
+	return resp
+}
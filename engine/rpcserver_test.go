@@ -2,32 +2,46 @@ package engine
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"log"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/currency/valuation"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
 	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
 	dbexchange "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
 	sqltrade "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/binance"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
+	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
+	scriptstate "github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/gct/state"
 	"github.com/thrasher-corp/goose"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -67,7 +81,7 @@ func RPCTestSetup(t *testing.T) *Engine {
 		log.Fatal(err)
 	}
 	path := filepath.Join("..", databaseFolder, migrationsFolder)
-	err = goose.Run("up", dbConn.SQL, repository.GetSQLDialect(), path, "")
+	err = goose.Run("up", engerino.DatabaseInstance().SQL, repository.GetSQLDialect(), path, "")
 	if err != nil {
 		t.Fatalf("failed to run migrations %v", err)
 	}
@@ -93,6 +107,8 @@ func CleanRPCTest(t *testing.T, engerino *Engine) {
 	if err != nil {
 		t.Error(err)
 	}
+	// exchange IDs differ each time the database is recreated
+	dbexchange.ResetExchangeCache()
 }
 
 func TestGetSavedTrades(t *testing.T) {
@@ -1100,3 +1116,324 @@ func TestCheckVars(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestGetSubsystems(t *testing.T) {
+	bot := RPCTestSetup(t)
+	defer CleanRPCTest(t, bot)
+	s := RPCServer{Engine: bot}
+
+	resp, err := s.GetSubsystems(context.Background(), &gctrpc.GetSubsystemsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.SubsystemsStatus["database"] {
+		t.Error("expected database status to be running")
+	}
+	for i := range resp.Subsystems {
+		if resp.Subsystems[i].Name != "database" {
+			continue
+		}
+		if !resp.Subsystems[i].Running || resp.Subsystems[i].Health != subsystem.HealthHealthy {
+			t.Errorf("expected healthy database, received %+v", resp.Subsystems[i])
+		}
+		return
+	}
+	t.Error("database subsystem health not reported")
+}
+
+func TestVerifyAuditLog(t *testing.T) {
+	bot := RPCTestSetup(t)
+	defer CleanRPCTest(t, bot)
+	s := RPCServer{Engine: bot}
+
+	ctx := audit.WithActor(context.Background(), audit.Actor{Type: audit.ActorGRPC, ID: "tester"})
+	_, err := s.EnableSubsystem(ctx, &gctrpc.GenericSubsystemRequest{Subsystem: "invalid"})
+	if err == nil {
+		t.Fatal("expected an error enabling an invalid subsystem")
+	}
+
+	events, err := s.GetAuditEvent(context.Background(), &gctrpc.GetAuditEventRequest{
+		StartDate: time.Now().UTC().Add(-time.Hour).Format(common.SimpleTimeFormat),
+		EndDate:   time.Now().UTC().Add(time.Hour).Format(common.SimpleTimeFormat),
+		OrderBy:   "desc",
+		Limit:     1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events.Events) != 1 {
+		t.Fatalf("expected 1 audit event, received %v", len(events.Events))
+	}
+	e := events.Events[0]
+	if e.Type != audit.SubsystemToggle || e.ActorType != audit.ActorGRPC || e.Actor != "tester" || e.Hash == "" {
+		t.Errorf("unexpected audit event %+v", e)
+	}
+
+	resp, err := s.VerifyAuditLog(context.Background(), &gctrpc.VerifyAuditLogRequest{ExpectedHeadHash: e.Hash})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Valid || resp.HeadHash != e.Hash || resp.HeadSequence != e.Sequence {
+		t.Errorf("unexpected verification %+v", resp)
+	}
+
+	resp, err = s.VerifyAuditLog(context.Background(), &gctrpc.VerifyAuditLogRequest{ExpectedHeadHash: "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Valid || len(resp.Issues) != 1 || resp.Issues[0].Reason != audit.IssueHeadNotFound {
+		t.Errorf("unexpected verification %+v", resp)
+	}
+}
+
+func TestSetExchangeAssetAudited(t *testing.T) {
+	bot := RPCTestSetup(t)
+	defer CleanRPCTest(t, bot)
+	s := RPCServer{Engine: bot}
+
+	ctx := chatContext(base.CommandUser{Relayer: "Telegram", ID: "1337"})
+	_, err := s.SetExchangeAsset(ctx, &gctrpc.SetExchangeAssetRequest{Exchange: "invalid", Asset: "spot", Enable: true})
+	if !errors.Is(err, errExchangeNotLoaded) {
+		t.Fatalf("received '%v' expected '%v'", err, errExchangeNotLoaded)
+	}
+
+	events, err := s.GetAuditEvent(context.Background(), &gctrpc.GetAuditEventRequest{
+		StartDate: time.Now().UTC().Add(-time.Hour).Format(common.SimpleTimeFormat),
+		EndDate:   time.Now().UTC().Add(time.Hour).Format(common.SimpleTimeFormat),
+		OrderBy:   "desc",
+		Limit:     1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events.Events) != 1 {
+		t.Fatalf("expected 1 audit event, received %v", len(events.Events))
+	}
+	e := events.Events[0]
+	if e.Type != audit.ExchangeAssetToggle || e.ActorType != audit.ActorChat || e.Actor != "telegram:1337" {
+		t.Errorf("unexpected audit event %+v", e)
+	}
+}
+
+func TestGCTScriptState(t *testing.T) {
+	engerino := RPCTestSetup(t)
+	defer CleanRPCTest(t, engerino)
+	s := RPCServer{Engine: engerino}
+
+	var err error
+	engerino.GctScriptManager, err = gctscript.NewManager(&gctscript.Config{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := s.GCTScriptGetState(context.Background(), &gctrpc.GCTScriptGetStateRequest{Script: "state"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != gctscript.ErrScriptingDisabled.Error() {
+		t.Errorf("received %v, expected %v", resp.Status, gctscript.ErrScriptingDisabled)
+	}
+
+	var wg sync.WaitGroup
+	err = engerino.GctScriptManager.Start(&wg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err = engerino.GctScriptManager.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	_, err = s.GCTScriptGetState(context.Background(), &gctrpc.GCTScriptGetStateRequest{})
+	if !errors.Is(err, errScriptNameUnset) {
+		t.Errorf("received %v, expected %v", err, errScriptNameUnset)
+	}
+
+	for _, k := range []string{"a", "b"} {
+		if err = scriptstate.Set("state.gct", k, `"`+k+`"`); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp, err = s.GCTScriptGetState(context.Background(), &gctrpc.GCTScriptGetStateRequest{Script: "state"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Script != "state.gct" || len(resp.State) != 2 || resp.State[0].Key != "a" || resp.State[0].Value != `"a"` {
+		t.Errorf("unexpected state %+v", resp)
+	}
+
+	_, err = s.GCTScriptClearState(context.Background(), &gctrpc.GCTScriptClearStateRequest{Script: "state.gct", Key: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = s.GCTScriptGetState(context.Background(), &gctrpc.GCTScriptGetStateRequest{Script: "state.gct"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.State) != 1 || resp.State[0].Key != "b" {
+		t.Errorf("unexpected state %+v", resp)
+	}
+
+	cleared, err := s.GCTScriptClearState(context.Background(), &gctrpc.GCTScriptClearStateRequest{Script: "state.gct"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cleared.Data != "1 keys removed from state.gct state" {
+		t.Errorf("unexpected response %v", cleared.Data)
+	}
+}
+
+type authTestStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (a *authTestStream) Context() context.Context {
+	return a.ctx
+}
+
+func authContext(authorization string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", authorization))
+}
+
+func basicAuthorization(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func TestAuthoriseRequest(t *testing.T) {
+	t.Parallel()
+	users := []auth.User{{Username: "reader", Password: "readpass", Scopes: []string{auth.ScopeRead}}}
+	if _, err := auth.CheckUsers(users); err != nil {
+		t.Fatal(err)
+	}
+	bot := &Engine{
+		Config: &config.Config{RemoteControl: config.RemoteControlConfig{
+			Username: "admin",
+			Password: "adminpass",
+			Users:    users,
+		}},
+		Settings: Settings{EnableDryRun: true},
+	}
+	s := RPCServer{Engine: bot}
+	const (
+		getInfo     = "/gctrpc.GoCryptoTrader/GetInfo"
+		submitOrder = "/gctrpc.GoCryptoTrader/SubmitOrder"
+		withdraw    = "/gctrpc.GoCryptoTrader/WithdrawCryptocurrencyFunds"
+	)
+
+	_, err := bot.authoriseRequest(context.Background(), getInfo)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("received: %v but expected: %v", err, codes.Unauthenticated)
+	}
+	_, err = bot.authoriseRequest(authContext(basicAuthorization("reader", "wrong")), getInfo)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("received: %v but expected: %v", err, codes.Unauthenticated)
+	}
+	ctx, err := bot.authoriseRequest(authContext(basicAuthorization("reader", "readpass")), getInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := auth.PrincipalFromContext(ctx); !ok || p.Name != "reader" {
+		t.Error("expected reader principal in context")
+	}
+	_, err = bot.authoriseRequest(authContext(basicAuthorization("reader", "readpass")), submitOrder)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("received: %v but expected: %v", err, codes.PermissionDenied)
+	}
+
+	adminCtx, err := bot.authoriseRequest(authContext(basicAuthorization("admin", "adminpass")), "/gctrpc.GoCryptoTrader/CreateAPIToken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.CreateAPIToken(adminCtx, &gctrpc.CreateAPITokenRequest{Name: "bot", Scopes: []string{auth.ScopeTrade}, Expiry: "-1h"}); !errors.Is(err, errInvalidTokenExpiry) {
+		t.Errorf("received: %v but expected: %v", err, errInvalidTokenExpiry)
+	}
+	created, err := s.CreateAPIToken(adminCtx, &gctrpc.CreateAPITokenRequest{Name: "bot", Scopes: []string{auth.ScopeTrade}, Expiry: "1h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Secret == "" || created.Token.Owner != "admin" || created.Token.Expires == "" {
+		t.Errorf("unexpected token %+v", created)
+	}
+	tokens, err := s.ListAPITokens(adminCtx, &gctrpc.ListAPITokensRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens.Tokens) != 1 || tokens.Tokens[0].Id != created.Token.Id {
+		t.Errorf("unexpected tokens %+v", tokens.Tokens)
+	}
+
+	bearer := authContext("Bearer " + created.Secret)
+	if _, err = bot.authoriseRequest(bearer, submitOrder); err != nil {
+		t.Error(err)
+	}
+	_, err = bot.authoriseRequest(bearer, withdraw)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("received: %v but expected: %v", err, codes.PermissionDenied)
+	}
+
+	var called bool
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		p, ok := auth.PrincipalFromContext(stream.Context())
+		called = ok && p.Type == auth.PrincipalToken
+		return nil
+	}
+	err = bot.streamAuthInterceptor(nil, &authTestStream{ctx: bearer}, &grpc.StreamServerInfo{FullMethod: "/gctrpc.GoCryptoTrader/GetTickerStream"}, handler)
+	if err != nil || !called {
+		t.Errorf("expected stream handler to be called with token principal, received %v", err)
+	}
+
+	if _, err = s.RevokeAPIToken(adminCtx, &gctrpc.RevokeAPITokenRequest{}); !errors.Is(err, errTokenIDUnset) {
+		t.Errorf("received: %v but expected: %v", err, errTokenIDUnset)
+	}
+	if _, err = s.RevokeAPIToken(adminCtx, &gctrpc.RevokeAPITokenRequest{Id: created.Token.Id}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = bot.authoriseRequest(bearer, submitOrder)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("received: %v but expected: %v", err, codes.Unauthenticated)
+	}
+}
+
+func TestValueOrZero(t *testing.T) {
+	t.Parallel()
+	g := valuation.NewGraph(0)
+	updated := time.Now().Add(-time.Minute)
+	err := g.AddTicker(&ticker.Price{
+		ExchangeName: testExchange,
+		Pair:         currency.NewPair(currency.XRP, currency.BTC),
+		AssetType:    asset.Spot,
+		Last:         0.00002,
+		Volume:       1000,
+		LastUpdated:  updated,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = g.AddForexRate(currency.BTC, currency.USD, 50000, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, v := valueOrZero(g, currency.XRP, 0, currency.USD); value != 0 || v != nil {
+		t.Errorf("expected no valuation for a zero amount, received %v %+v", value, v)
+	}
+	if value, v := valueOrZero(g, currency.LTC, 1, currency.USD); value != 0 || v != nil {
+		t.Errorf("expected no valuation without a path, received %v %+v", value, v)
+	}
+	value, v := valueOrZero(g, currency.XRP, 100, currency.USD)
+	if value != 100 {
+		t.Errorf("received '%v' expected '%v'", value, 100)
+	}
+	if v == nil || len(v.Path) != 2 {
+		t.Fatalf("expected a two hop valuation path, received %+v", v)
+	}
+	if v.Path[0].Exchange != testExchange || v.Path[0].Pair != "XRPBTC" || v.Path[1].Source != valuation.ForexSource {
+		t.Errorf("unexpected valuation path %+v", v.Path)
+	}
+	if v.Timestamp != updated.Unix() || v.AgeSeconds < 60 {
+		t.Errorf("expected the valuation to be dated by the oldest rate, received %v aged %vs", v.Timestamp, v.AgeSeconds)
+	}
+	if v.Confidence <= 0 || v.Confidence > 1 {
+		t.Errorf("unexpected confidence %v", v.Confidence)
+	}
+}
@@ -0,0 +1,450 @@
+package subsystem
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// NewRegistry returns a subsystem registry with default supervisor settings
+func NewRegistry() *Registry {
+	return &Registry{
+		CheckInterval:     DefaultCheckInterval,
+		MinRestartBackoff: DefaultMinRestartBackoff,
+		MaxRestartBackoff: DefaultMaxRestartBackoff,
+		entries:           make(map[string]*entry),
+	}
+}
+
+// Register adds a subsystem to the registry. Dependencies are started before
+// and stopped after the subsystem, they do not need to be registered yet but
+// must be by the time the registry is ordered
+func (r *Registry) Register(name string, s Subsystem, dependsOn ...string) error {
+	if name == "" {
+		return errEmptySubsystemName
+	}
+	if s == nil {
+		return fmt.Errorf("%s %w", name, errNilSubsystem)
+	}
+	name = strings.ToLower(name)
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.entries[name]; ok {
+		return fmt.Errorf("%s %w", name, ErrSubSystemAlreadyRegistered)
+	}
+	deps := make([]string, len(dependsOn))
+	for i := range dependsOn {
+		deps[i] = strings.ToLower(dependsOn[i])
+	}
+	r.entries[name] = &entry{
+		name:      name,
+		subsystem: s,
+		dependsOn: deps,
+	}
+	r.names = append(r.names, name)
+	return nil
+}
+
+// IsRegistered returns whether a subsystem is registered under the name
+func (r *Registry) IsRegistered(name string) bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	_, ok := r.entries[strings.ToLower(name)]
+	return ok
+}
+
+// Order returns the registered subsystem names in the order they should be
+// started, subsystems without a dependency relationship keep their
+// registration order
+func (r *Registry) Order() ([]string, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.order()
+}
+
+func (r *Registry) order() ([]string, error) {
+	for _, name := range r.names {
+		for _, dep := range r.entries[name].dependsOn {
+			if _, ok := r.entries[dep]; !ok {
+				return nil, fmt.Errorf("%s depends on %s: %w", name, dep, ErrDependencyNotFound)
+			}
+		}
+	}
+
+	ordered := make([]string, 0, len(r.names))
+	added := make(map[string]bool, len(r.names))
+	for len(ordered) < len(r.names) {
+		progressed := false
+		for _, name := range r.names {
+			if added[name] {
+				continue
+			}
+			ready := true
+			for _, dep := range r.entries[name].dependsOn {
+				if !added[dep] {
+					ready = false
+					break
+				}
+			}
+			if !ready {
+				continue
+			}
+			ordered = append(ordered, name)
+			added[name] = true
+			progressed = true
+		}
+		if !progressed {
+			var remaining []string
+			for _, name := range r.names {
+				if !added[name] {
+					remaining = append(remaining, name)
+				}
+			}
+			return nil, fmt.Errorf("%w between %s", ErrDependencyCycle, strings.Join(remaining, ", "))
+		}
+	}
+	return ordered, nil
+}
+
+// Start starts a single subsystem and marks it as wanted so the supervisor
+// restarts it should it crash
+func (r *Registry) Start(name string) error {
+	e, err := r.get(name)
+	if err != nil {
+		return err
+	}
+	r.lifecycle.Lock()
+	defer r.lifecycle.Unlock()
+	return r.start(e)
+}
+
+// Stop stops a single subsystem, the supervisor will not restart it
+func (r *Registry) Stop(name string) error {
+	e, err := r.get(name)
+	if err != nil {
+		return err
+	}
+	r.lifecycle.Lock()
+	defer r.lifecycle.Unlock()
+	return r.stop(e)
+}
+
+// StartAll starts the named subsystems in dependency order. Failures are
+// logged and recorded against the subsystem rather than aborting the
+// remaining starts, an error is only returned when the names or dependency
+// graph are invalid. Nothing is started when no names are supplied
+func (r *Registry) StartAll(names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	ordered, err := r.filter(names)
+	if err != nil {
+		return err
+	}
+	r.lifecycle.Lock()
+	defer r.lifecycle.Unlock()
+	for i := range ordered {
+		if err := r.start(ordered[i]); err != nil {
+			log.Errorf(log.Global, "%s subsystem unable to start: %v\n", ordered[i].name, err)
+		}
+	}
+	return nil
+}
+
+// StopAll stops the named subsystems in reverse dependency order, when no
+// names are supplied every running subsystem is stopped
+func (r *Registry) StopAll(names ...string) error {
+	ordered, err := r.filter(names)
+	if err != nil {
+		return err
+	}
+	r.lifecycle.Lock()
+	defer r.lifecycle.Unlock()
+	for i := len(ordered) - 1; i >= 0; i-- {
+		if !ordered[i].subsystem.Started() {
+			r.mtx.Lock()
+			ordered[i].enabled = false
+			ordered[i].running = false
+			r.mtx.Unlock()
+			continue
+		}
+		if err := r.stop(ordered[i]); err != nil {
+			log.Errorf(log.Global, "%s subsystem unable to stop: %v\n", ordered[i].name, err)
+		}
+	}
+	return nil
+}
+
+// Supervise starts checking registered subsystems for crashes every
+// CheckInterval and restarts wanted subsystems with exponential backoff
+func (r *Registry) Supervise() error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.shutdown != nil {
+		return fmt.Errorf("subsystem supervisor %w", ErrSubSystemAlreadyStarted)
+	}
+	interval := r.CheckInterval
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	r.shutdown = make(chan struct{})
+	r.wg.Add(1)
+	go r.supervise(r.shutdown, interval)
+	return nil
+}
+
+// StopSupervising stops the supervisor, running subsystems are left running
+func (r *Registry) StopSupervising() error {
+	r.mtx.Lock()
+	if r.shutdown == nil {
+		r.mtx.Unlock()
+		return fmt.Errorf("subsystem supervisor %w", ErrSubSystemNotStarted)
+	}
+	close(r.shutdown)
+	r.shutdown = nil
+	r.mtx.Unlock()
+	r.wg.Wait()
+	return nil
+}
+
+// Statuses returns the status of every registered subsystem in start order
+func (r *Registry) Statuses() []Status {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	names, err := r.order()
+	if err != nil {
+		names = r.names
+	}
+	resp := make([]Status, len(names))
+	for i := range names {
+		resp[i] = r.status(r.entries[names[i]])
+	}
+	return resp
+}
+
+// GetStatus returns the status of a single subsystem
+func (r *Registry) GetStatus(name string) (Status, error) {
+	e, err := r.get(name)
+	if err != nil {
+		return Status{}, err
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.status(e), nil
+}
+
+func (r *Registry) status(e *entry) Status {
+	s := Status{
+		Name:          e.name,
+		Enabled:       e.enabled,
+		Running:       e.subsystem.Started(),
+		LastError:     e.lastError,
+		LastErrorTime: e.lastErrorTime,
+		Restarts:      e.restarts,
+		DependsOn:     append([]string(nil), e.dependsOn...),
+	}
+	switch {
+	case s.Running:
+		s.Health = HealthHealthy
+		if err := r.degraded(e); err != nil {
+			s.Health = HealthDegraded
+			s.LastError = err
+		}
+	case e.enabled:
+		s.Health = HealthRestarting
+	case e.failed:
+		s.Health = HealthFailed
+	default:
+		s.Health = HealthStopped
+	}
+	return s
+}
+
+// degraded returns why a running subsystem is unhealthy, either its own
+// health check or an enabled dependency which is not running
+func (r *Registry) degraded(e *entry) error {
+	if hc, ok := e.subsystem.(HealthChecker); ok {
+		if err := hc.Health(); err != nil {
+			return err
+		}
+	}
+	for _, dep := range e.dependsOn {
+		d, ok := r.entries[dep]
+		if ok && d.enabled && !d.subsystem.Started() {
+			return fmt.Errorf("dependency %s is not running", dep)
+		}
+	}
+	return nil
+}
+
+func (r *Registry) get(name string) (*entry, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	e, ok := r.entries[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%s %w", name, ErrSubSystemNotFound)
+	}
+	return e, nil
+}
+
+// filter returns the entries for names in dependency order, all entries are
+// returned when names is empty
+func (r *Registry) filter(names []string) ([]*entry, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	ordered, err := r.order()
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(names))
+	for i := range names {
+		name := strings.ToLower(names[i])
+		if _, ok := r.entries[name]; !ok {
+			return nil, fmt.Errorf("%s %w", names[i], ErrSubSystemNotFound)
+		}
+		wanted[name] = true
+	}
+	resp := make([]*entry, 0, len(ordered))
+	for i := range ordered {
+		if len(names) == 0 || wanted[ordered[i]] {
+			resp = append(resp, r.entries[ordered[i]])
+		}
+	}
+	return resp, nil
+}
+
+// start must be called with the lifecycle lock held
+func (r *Registry) start(e *entry) error {
+	err := e.subsystem.Start()
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err != nil && !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		e.enabled = false
+		e.running = false
+		e.failed = true
+		e.setError(err, time.Now())
+		return err
+	}
+	e.enabled = true
+	e.running = true
+	e.failed = false
+	e.backoff = 0
+	return err
+}
+
+// stop must be called with the lifecycle lock held
+func (r *Registry) stop(e *entry) error {
+	r.mtx.Lock()
+	e.enabled = false
+	r.mtx.Unlock()
+
+	err := e.subsystem.Stop()
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	e.running = e.subsystem.Started()
+	if err != nil && !errors.Is(err, ErrSubSystemNotStarted) {
+		e.setError(err, time.Now())
+	}
+	return err
+}
+
+func (r *Registry) supervise(shutdown chan struct{}, interval time.Duration) {
+	defer r.wg.Done()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-shutdown:
+			return
+		case now := <-t.C:
+			r.check(now)
+		}
+	}
+}
+
+// check detects crashed subsystems and restarts the ones due for another
+// attempt
+func (r *Registry) check(now time.Time) {
+	r.lifecycle.Lock()
+	defer r.lifecycle.Unlock()
+
+	r.mtx.RLock()
+	names, err := r.order()
+	if err != nil {
+		names = r.names
+	}
+	r.mtx.RUnlock()
+
+	for i := range names {
+		e, err := r.get(names[i])
+		if err != nil {
+			continue
+		}
+		if !r.due(e, now) {
+			continue
+		}
+
+		err = e.subsystem.Start()
+		r.mtx.Lock()
+		if err != nil && !errors.Is(err, ErrSubSystemAlreadyStarted) {
+			e.setError(err, now)
+			e.backoff *= 2
+			if e.backoff > r.MaxRestartBackoff {
+				e.backoff = r.MaxRestartBackoff
+			}
+			e.nextAttempt = now.Add(e.backoff)
+			log.Errorf(log.Global, "%s subsystem restart failed: %v, retrying in %s\n", e.name, err, e.backoff)
+		} else {
+			e.running = true
+			e.restarts++
+			e.backoff = 0
+			log.Infof(log.Global, "%s subsystem restarted\n", e.name)
+		}
+		r.mtx.Unlock()
+	}
+}
+
+// due updates the tracked state of an entry and returns whether it needs to
+// be restarted now
+func (r *Registry) due(e *entry, now time.Time) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	running := e.subsystem.Started()
+	if running {
+		e.running = true
+		if hc, ok := e.subsystem.(HealthChecker); ok {
+			if err := hc.Health(); err != nil {
+				e.setError(err, now)
+			}
+		}
+		return false
+	}
+	if !e.enabled {
+		e.running = false
+		return false
+	}
+	if f, ok := e.subsystem.(Finisher); ok && f.Finished() {
+		e.enabled = false
+		e.running = false
+		log.Infof(log.Global, "%s subsystem finished\n", e.name)
+		return false
+	}
+	if e.running {
+		e.running = false
+		e.setError(fmt.Errorf("%s %w", e.name, ErrSubSystemCrashed), now)
+		e.backoff = r.MinRestartBackoff
+		e.nextAttempt = now.Add(e.backoff)
+		log.Warnf(log.Global, "%s subsystem %v, restarting in %s\n", e.name, ErrSubSystemCrashed, e.backoff)
+		return false
+	}
+	return !now.Before(e.nextAttempt)
+}
+
+func (e *entry) setError(err error, t time.Time) {
+	e.lastError = err
+	e.lastErrorTime = t
+}
@@ -0,0 +1,325 @@
+package subsystem
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeSubsystem struct {
+	name     string
+	started  int32
+	startErr error
+	health   error
+	finished int32
+	events   *[]string
+}
+
+func (f *fakeSubsystem) Start() error {
+	if f.startErr != nil {
+		return f.startErr
+	}
+	if !atomic.CompareAndSwapInt32(&f.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	if f.events != nil {
+		*f.events = append(*f.events, "start "+f.name)
+	}
+	return nil
+}
+
+func (f *fakeSubsystem) Stop() error {
+	if !atomic.CompareAndSwapInt32(&f.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	if f.events != nil {
+		*f.events = append(*f.events, "stop "+f.name)
+	}
+	return nil
+}
+
+func (f *fakeSubsystem) Started() bool {
+	return atomic.LoadInt32(&f.started) == 1
+}
+
+func (f *fakeSubsystem) Health() error {
+	return f.health
+}
+
+func (f *fakeSubsystem) Finished() bool {
+	return atomic.LoadInt32(&f.finished) == 1
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	if err := r.Register("", &fakeSubsystem{}); !errors.Is(err, errEmptySubsystemName) {
+		t.Errorf("received '%v' expected '%v'", err, errEmptySubsystemName)
+	}
+	if err := r.Register("test", nil); !errors.Is(err, errNilSubsystem) {
+		t.Errorf("received '%v' expected '%v'", err, errNilSubsystem)
+	}
+	if err := r.Register("Test", &fakeSubsystem{}); err != nil {
+		t.Error(err)
+	}
+	if err := r.Register("test", &fakeSubsystem{}); !errors.Is(err, ErrSubSystemAlreadyRegistered) {
+		t.Errorf("received '%v' expected '%v'", err, ErrSubSystemAlreadyRegistered)
+	}
+	if !r.IsRegistered("TEST") {
+		t.Error("expected subsystem to be registered")
+	}
+}
+
+func TestOrder(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	for _, s := range []struct {
+		name string
+		deps []string
+	}{
+		{"orders", []string{"communications", "database"}},
+		{"communications", nil},
+		{"retention", []string{"database"}},
+		{"database", nil},
+	} {
+		if err := r.Register(s.name, &fakeSubsystem{}, s.deps...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	order, err := r.Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"communications", "database", "orders", "retention"}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("received '%v' expected '%v'", order, expected)
+		}
+	}
+
+	if err = r.Register("unknown", &fakeSubsystem{}, "missing"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = r.Order(); !errors.Is(err, ErrDependencyNotFound) {
+		t.Errorf("received '%v' expected '%v'", err, ErrDependencyNotFound)
+	}
+
+	cyclic := NewRegistry()
+	if err = cyclic.Register("a", &fakeSubsystem{}, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err = cyclic.Register("b", &fakeSubsystem{}, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cyclic.Order(); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("received '%v' expected '%v'", err, ErrDependencyCycle)
+	}
+}
+
+func TestStartAllStopAll(t *testing.T) {
+	t.Parallel()
+	var events []string
+	r := NewRegistry()
+	if err := r.Register("orders", &fakeSubsystem{name: "orders", events: &events}, "database"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register("database", &fakeSubsystem{name: "database", events: &events}); err != nil {
+		t.Fatal(err)
+	}
+	failing := &fakeSubsystem{name: "failing", startErr: errors.New("no config")}
+	if err := r.Register("failing", failing); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.StartAll("nope"); !errors.Is(err, ErrSubSystemNotFound) {
+		t.Errorf("received '%v' expected '%v'", err, ErrSubSystemNotFound)
+	}
+	if err := r.StartAll("orders", "database", "failing"); err != nil {
+		t.Fatal(err)
+	}
+	s, err := r.GetStatus("failing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Health != HealthFailed || s.LastError == nil {
+		t.Errorf("expected failed health with error, received %+v", s)
+	}
+
+	if err = r.StopAll(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"start database", "start orders", "stop orders", "stop database"}
+	if len(events) != len(expected) {
+		t.Fatalf("received '%v' expected '%v'", events, expected)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("received '%v' expected '%v'", events, expected)
+		}
+	}
+}
+
+func TestStatuses(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	db := &fakeSubsystem{}
+	orders := &fakeSubsystem{}
+	if err := r.Register("database", db); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register("orders", orders, "database"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register("idle", &fakeSubsystem{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.StartAll("database", "orders"); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := r.Statuses()
+	if len(statuses) != 3 {
+		t.Fatalf("received '%v' expected '%v'", len(statuses), 3)
+	}
+	for i := range statuses {
+		expected := HealthHealthy
+		if statuses[i].Name == "idle" {
+			expected = HealthStopped
+		}
+		if statuses[i].Health != expected {
+			t.Errorf("%s received '%v' expected '%v'", statuses[i].Name, statuses[i].Health, expected)
+		}
+	}
+
+	db.health = errors.New("connection lost")
+	s, err := r.GetStatus("database")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Health != HealthDegraded || !errors.Is(s.LastError, db.health) {
+		t.Errorf("expected degraded database, received %+v", s)
+	}
+	db.health = nil
+
+	// a crashed dependency degrades its dependents
+	atomic.StoreInt32(&db.started, 0)
+	s, err = r.GetStatus("orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Health != HealthDegraded {
+		t.Errorf("received '%v' expected '%v'", s.Health, HealthDegraded)
+	}
+	s, err = r.GetStatus("database")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Health != HealthRestarting {
+		t.Errorf("received '%v' expected '%v'", s.Health, HealthRestarting)
+	}
+}
+
+func TestCheckRestartsWithBackoff(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	r.MinRestartBackoff = time.Second
+	r.MaxRestartBackoff = time.Second * 3
+	f := &fakeSubsystem{}
+	if err := r.Register("crashy", f); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Start("crashy"); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	atomic.StoreInt32(&f.started, 0)
+	r.check(now)
+	s, err := r.GetStatus("crashy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(s.LastError, ErrSubSystemCrashed) {
+		t.Errorf("received '%v' expected '%v'", s.LastError, ErrSubSystemCrashed)
+	}
+
+	// restart fails and backs off until the maximum
+	f.startErr = errors.New("still broken")
+	r.check(now.Add(time.Second))
+	r.check(now.Add(time.Second * 2))
+	if f.Started() {
+		t.Fatal("expected subsystem to remain stopped")
+	}
+	r.mtx.RLock()
+	backoff := r.entries["crashy"].backoff
+	r.mtx.RUnlock()
+	if backoff != time.Second*2 {
+		t.Errorf("received '%v' expected '%v'", backoff, time.Second*2)
+	}
+
+	f.startErr = nil
+	r.check(now.Add(time.Second * 4))
+	s, err = r.GetStatus("crashy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Running || s.Restarts != 1 {
+		t.Errorf("expected running subsystem with one restart, received %+v", s)
+	}
+
+	// subsystems stopped through the registry are not restarted
+	if err = r.Stop("crashy"); err != nil {
+		t.Fatal(err)
+	}
+	r.check(now.Add(time.Hour))
+	if f.Started() {
+		t.Error("expected stopped subsystem to stay stopped")
+	}
+}
+
+func TestCheckFinished(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	f := &fakeSubsystem{}
+	if err := r.Register("oneshot", f); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Start("oneshot"); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	atomic.StoreInt32(&f.started, 0)
+	atomic.StoreInt32(&f.finished, 1)
+	r.check(now)
+	r.check(now.Add(time.Hour))
+	if f.Started() {
+		t.Fatal("expected finished subsystem not to be restarted")
+	}
+	s, err := r.GetStatus("oneshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Enabled || s.Health != HealthStopped || s.LastError != nil || s.Restarts != 0 {
+		t.Errorf("expected cleanly stopped subsystem, received %+v", s)
+	}
+}
+
+func TestSupervise(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	if err := r.StopSupervising(); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v' expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	r.CheckInterval = time.Millisecond
+	if err := r.Supervise(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Supervise(); !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v' expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if err := r.StopSupervising(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,116 @@
+package subsystem
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCheckInterval is how often the supervisor checks registered
+	// subsystems for crashes
+	DefaultCheckInterval = time.Second * 5
+	// DefaultMinRestartBackoff is the delay before the first restart attempt
+	// of a crashed subsystem
+	DefaultMinRestartBackoff = time.Second * 5
+	// DefaultMaxRestartBackoff caps the delay between restart attempts
+	DefaultMaxRestartBackoff = time.Minute * 5
+
+	// HealthHealthy subsystem is running and all of its enabled dependencies
+	// are running
+	HealthHealthy = "healthy"
+	// HealthDegraded subsystem is running but reports an error or an enabled
+	// dependency is not running
+	HealthDegraded = "degraded"
+	// HealthRestarting subsystem stopped unexpectedly and is waiting to be
+	// restarted
+	HealthRestarting = "restarting"
+	// HealthFailed subsystem failed to start
+	HealthFailed = "failed"
+	// HealthStopped subsystem is not running
+	HealthStopped = "stopped"
+)
+
+var (
+	// ErrSubSystemNotFound returned when a subsystem is not registered
+	ErrSubSystemNotFound = errors.New("subsystem not found")
+	// ErrSubSystemAlreadyRegistered returned when a subsystem name is
+	// registered twice
+	ErrSubSystemAlreadyRegistered = errors.New("subsystem already registered")
+	// ErrSubSystemCrashed recorded when a subsystem stops without being asked
+	ErrSubSystemCrashed = errors.New("stopped unexpectedly")
+	// ErrDependencyNotFound returned when a subsystem depends on a subsystem
+	// which is not registered
+	ErrDependencyNotFound = errors.New("dependency not registered")
+	// ErrDependencyCycle returned when subsystem dependencies form a cycle
+	ErrDependencyCycle = errors.New("dependency cycle")
+
+	errNilSubsystem       = errors.New("subsystem is nil")
+	errEmptySubsystemName = errors.New("subsystem name is empty")
+)
+
+// Subsystem is a long running engine component which can be managed by a
+// Registry
+type Subsystem interface {
+	Start() error
+	Stop() error
+	Started() bool
+}
+
+// HealthChecker can be implemented by a Subsystem to report problems while it
+// is running, such as a lost database connection
+type HealthChecker interface {
+	Health() error
+}
+
+// Finisher can be implemented by a Subsystem which stops by itself once its
+// work is done. A finished subsystem is marked as not enabled rather than
+// restarted by the supervisor
+type Finisher interface {
+	Finished() bool
+}
+
+// Registry starts and stops subsystems in dependency order, restarts crashed
+// subsystems with backoff and tracks their health
+type Registry struct {
+	CheckInterval     time.Duration
+	MinRestartBackoff time.Duration
+	MaxRestartBackoff time.Duration
+
+	// lifecycle serialises calls to Start and Stop on subsystems so slow
+	// starts do not block status queries
+	lifecycle sync.Mutex
+	mtx       sync.RWMutex
+	entries   map[string]*entry
+	names     []string
+	shutdown  chan struct{}
+	wg        sync.WaitGroup
+}
+
+// Status holds the state and health of a registered subsystem
+type Status struct {
+	Name          string
+	Enabled       bool
+	Running       bool
+	Health        string
+	LastError     error
+	LastErrorTime time.Time
+	Restarts      int64
+	DependsOn     []string
+}
+
+type entry struct {
+	name      string
+	subsystem Subsystem
+	dependsOn []string
+	// enabled is whether the subsystem is wanted running, the supervisor
+	// only restarts enabled subsystems
+	enabled       bool
+	running       bool
+	failed        bool
+	lastError     error
+	lastErrorTime time.Time
+	restarts      int64
+	backoff       time.Duration
+	nextAttempt   time.Time
+}
@@ -0,0 +1,221 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Registered subsystem names, these are the names accepted by SetSubsystem
+// and the gRPC enable/disable subsystem calls
+const (
+	databaseSubsystem          = "database"
+	dispatchSubsystem          = "dispatch"
+	internetMonitorSubsystem   = "internet_monitor"
+	ntpTimekeeperSubsystem     = "ntp_timekeeper"
+	communicationsSubsystem    = "communications"
+	exchangeHealthSubsystem    = "exchange_health"
+	dataHistorySubsystem       = "data_history_manager"
+	balanceSnapshotSubsystem   = "balance_snapshot"
+	databaseRetentionSubsystem = "database_retention"
+	portfolioSubsystem         = "portfolio"
+	ordersSubsystem            = "orders"
+	exchangeSyncerSubsystem    = "exchange_syncer"
+	gctScriptSubsystem         = "gctscript"
+	configWatcherSubsystem     = "config_watcher"
+)
+
+var errScriptManagerNotSetup = errors.New("gctscript manager not setup")
+
+// managedSubsystem adapts an engine manager to subsystem.Subsystem, managers
+// take different arguments to start so each is wrapped in closures
+type managedSubsystem struct {
+	start    func() error
+	stop     func() error
+	started  func() bool
+	health   func() error
+	finished func() bool
+}
+
+// Start starts the wrapped manager
+func (m *managedSubsystem) Start() error {
+	return m.start()
+}
+
+// Stop stops the wrapped manager
+func (m *managedSubsystem) Stop() error {
+	return m.stop()
+}
+
+// Started returns whether the wrapped manager is running
+func (m *managedSubsystem) Started() bool {
+	return m.started()
+}
+
+// Health returns any problem reported by the wrapped manager
+func (m *managedSubsystem) Health() error {
+	if m.health == nil {
+		return nil
+	}
+	return m.health()
+}
+
+// Finished returns whether the wrapped manager stopped by itself once its work
+// was done
+func (m *managedSubsystem) Finished() bool {
+	return m.finished != nil && m.finished()
+}
+
+// subsystems returns the engine's subsystem registry, creating it on first
+// use so a zero value engine can still toggle subsystems
+func (bot *Engine) subsystems() *subsystem.Registry {
+	bot.subsystemRegistryMtx.Lock()
+	defer bot.subsystemRegistryMtx.Unlock()
+	if bot.subsystemRegistry == nil {
+		bot.subsystemRegistry = bot.newSubsystemRegistry()
+	}
+	return bot.subsystemRegistry
+}
+
+// newSubsystemRegistry registers every engine manager along with the
+// subsystems it relies on. Dependencies only dictate start and stop order
+// and health, a dependency which is disabled does not block its dependents
+func (bot *Engine) newSubsystemRegistry() *subsystem.Registry {
+	r := subsystem.NewRegistry()
+	register := func(name string, s *managedSubsystem, dependsOn ...string) {
+		if err := r.Register(name, s, dependsOn...); err != nil {
+			log.Errorf(log.Global, "Unable to register %s subsystem: %v\n", name, err)
+		}
+	}
+
+	register(databaseSubsystem, &managedSubsystem{
+		start:   func() error { return bot.DatabaseManager.Start(bot) },
+		stop:    bot.DatabaseManager.Stop,
+		started: bot.DatabaseManager.Started,
+		health:  bot.DatabaseManager.Health,
+	})
+	register(dispatchSubsystem, &managedSubsystem{
+		start: func() error {
+			return dispatch.Start(bot.Settings.DispatchMaxWorkerAmount, bot.Settings.DispatchJobsLimit)
+		},
+		stop:    dispatch.Stop,
+		started: dispatch.IsRunning,
+	})
+	register(internetMonitorSubsystem, &managedSubsystem{
+		start:   func() error { return bot.ConnectionManager.Start(&bot.Config.ConnectionMonitor) },
+		stop:    bot.ConnectionManager.Stop,
+		started: bot.ConnectionManager.Started,
+		health:  bot.ConnectionManager.Health,
+	})
+	register(ntpTimekeeperSubsystem, &managedSubsystem{
+		start:   func() error { return bot.NTPManager.Start(bot) },
+		stop:    bot.NTPManager.Stop,
+		started: bot.NTPManager.Started,
+	})
+	register(communicationsSubsystem, &managedSubsystem{
+		start:   func() error { return bot.CommsManager.Start(bot) },
+		stop:    bot.CommsManager.Stop,
+		started: bot.CommsManager.Started,
+	})
+	register(exchangeHealthSubsystem, &managedSubsystem{
+		start:   func() error { return bot.ExchangeHealthManager.Start(bot) },
+		stop:    bot.ExchangeHealthManager.Stop,
+		started: bot.ExchangeHealthManager.Started,
+	}, communicationsSubsystem, ntpTimekeeperSubsystem, dispatchSubsystem)
+	register(dataHistorySubsystem, &managedSubsystem{
+		start:   func() error { return bot.DataHistoryManager.Start(bot) },
+		stop:    bot.DataHistoryManager.Stop,
+		started: bot.DataHistoryManager.Started,
+	}, databaseSubsystem)
+	register(balanceSnapshotSubsystem, &managedSubsystem{
+		start:   func() error { return bot.BalanceSnapshotManager.Start(bot) },
+		stop:    bot.BalanceSnapshotManager.Stop,
+		started: bot.BalanceSnapshotManager.Started,
+	}, databaseSubsystem)
+	register(databaseRetentionSubsystem, &managedSubsystem{
+		start:   func() error { return bot.DatabaseRetentionManager.Start(bot) },
+		stop:    bot.DatabaseRetentionManager.Stop,
+		started: bot.DatabaseRetentionManager.Started,
+	}, databaseSubsystem)
+	register(portfolioSubsystem, &managedSubsystem{
+		start:   func() error { return bot.PortfolioManager.Start(bot) },
+		stop:    bot.PortfolioManager.Stop,
+		started: bot.PortfolioManager.Started,
+	})
+	register(ordersSubsystem, &managedSubsystem{
+		start:   func() error { return bot.OrderManager.Start(bot) },
+		stop:    bot.OrderManager.Stop,
+		started: bot.OrderManager.Started,
+	}, communicationsSubsystem, databaseSubsystem, exchangeHealthSubsystem)
+	register(exchangeSyncerSubsystem, &managedSubsystem{
+		start:    bot.startExchangeSyncer,
+		stop:     bot.stopExchangeSyncer,
+		started:  func() bool { return bot.ExchangeCurrencyPairManager.Started() },
+		finished: func() bool { return bot.ExchangeCurrencyPairManager.Finished() },
+	}, dispatchSubsystem)
+	register(gctScriptSubsystem, &managedSubsystem{
+		start: func() error {
+			if bot.GctScriptManager == nil {
+				return errScriptManagerNotSetup
+			}
+			bot.GctScriptManager.SetDatabase(bot.DatabaseInstance())
+			return bot.GctScriptManager.Start(&bot.ServicesWG)
+		},
+		stop: func() error {
+			if bot.GctScriptManager == nil {
+				return errScriptManagerNotSetup
+			}
+			return bot.GctScriptManager.Stop()
+		},
+		started: func() bool {
+			return bot.GctScriptManager != nil && bot.GctScriptManager.Started()
+		},
+	}, ordersSubsystem)
+	register(configWatcherSubsystem, &managedSubsystem{
+		start:   func() error { return bot.ConfigWatcher.Start(bot) },
+		stop:    bot.ConfigWatcher.Stop,
+		started: bot.ConfigWatcher.Started,
+	})
+	return r
+}
+
+// startExchangeSyncer creates a new currency pair syncer from the engine
+// settings and starts it, a stopped syncer cannot be restarted
+func (bot *Engine) startExchangeSyncer() error {
+	if bot.ExchangeCurrencyPairManager.Started() {
+		return fmt.Errorf("exchange syncer %w", subsystem.ErrSubSystemAlreadyStarted)
+	}
+	s, err := NewCurrencyPairSyncer(bot, CurrencyPairSyncerConfig{
+		SyncTicker:           bot.Settings.EnableTickerSyncing,
+		SyncOrderbook:        bot.Settings.EnableOrderbookSyncing,
+		SyncTrades:           bot.Settings.EnableTradeSyncing,
+		SyncContinuously:     bot.Settings.SyncContinuously,
+		NumWorkers:           bot.Settings.SyncWorkers,
+		Verbose:              bot.Settings.Verbose,
+		SyncTimeoutREST:      bot.Settings.SyncTimeoutREST,
+		SyncTimeoutWebsocket: bot.Settings.SyncTimeoutWebsocket,
+	})
+	if err != nil {
+		return err
+	}
+	bot.ExchangeCurrencyPairManager = s
+	go s.Start()
+	return nil
+}
+
+func (bot *Engine) stopExchangeSyncer() error {
+	if !bot.ExchangeCurrencyPairManager.Started() {
+		return fmt.Errorf("exchange syncer %w", subsystem.ErrSubSystemNotStarted)
+	}
+	bot.ExchangeCurrencyPairManager.Stop()
+	return nil
+}
+
+// GetSubsystemsHealth returns the state, health and last error of every
+// registered subsystem in start order
+func (bot *Engine) GetSubsystemsHealth() []subsystem.Status {
+	return bot.subsystems().Statuses()
+}
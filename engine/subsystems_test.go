@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+)
+
+func TestSubsystemOrder(t *testing.T) {
+	bot := &Engine{}
+	order, err := bot.subsystems().Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	position := make(map[string]int, len(order))
+	for i := range order {
+		position[order[i]] = i
+	}
+	for _, pair := range [][2]string{
+		{databaseSubsystem, ordersSubsystem},
+		{communicationsSubsystem, exchangeHealthSubsystem},
+		{exchangeHealthSubsystem, ordersSubsystem},
+		{ordersSubsystem, gctScriptSubsystem},
+		{databaseSubsystem, databaseRetentionSubsystem},
+		{dispatchSubsystem, exchangeSyncerSubsystem},
+	} {
+		if position[pair[0]] > position[pair[1]] {
+			t.Errorf("expected %s to start before %s, received order %v", pair[0], pair[1], order)
+		}
+	}
+}
+
+func TestSetSubsystem(t *testing.T) {
+	bot := CreateTestBot(t)
+	bot.Config.ExchangeHealth = testHealthConfig()
+	err := bot.SetSubsystem("invalid", true)
+	if !errors.Is(err, subsystem.ErrSubSystemNotFound) {
+		t.Errorf("received '%v' expected '%v'", err, subsystem.ErrSubSystemNotFound)
+	}
+
+	err = bot.SetSubsystem(exchangeHealthSubsystem, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bot.ExchangeHealthManager.Started() {
+		t.Fatal("expected exchange health manager to be started")
+	}
+	err = bot.SetSubsystem("EXCHANGE_HEALTH", true)
+	if !errors.Is(err, subsystem.ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v' expected '%v'", err, subsystem.ErrSubSystemAlreadyStarted)
+	}
+	if !bot.GetSubsystemsStatus()[exchangeHealthSubsystem] {
+		t.Error("expected exchange health status to be running")
+	}
+
+	s, err := bot.subsystems().GetStatus(exchangeHealthSubsystem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Enabled || s.Health != subsystem.HealthHealthy {
+		t.Errorf("expected enabled healthy exchange health manager, received %+v", s)
+	}
+
+	err = bot.SetSubsystem(exchangeHealthSubsystem, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = bot.SetSubsystem(exchangeHealthSubsystem, false)
+	if !errors.Is(err, subsystem.ErrSubSystemNotStarted) {
+		t.Errorf("received '%v' expected '%v'", err, subsystem.ErrSubSystemNotStarted)
+	}
+	bot.ServicesWG.Wait()
+
+	statuses := bot.GetSubsystemsHealth()
+	for i := range statuses {
+		if statuses[i].Name != exchangeHealthSubsystem {
+			continue
+		}
+		if statuses[i].Running || statuses[i].Health != subsystem.HealthStopped {
+			t.Errorf("expected stopped exchange health manager, received %+v", statuses[i])
+		}
+		return
+	}
+	t.Error("exchange health subsystem not reported")
+}
+
+func TestGetSubsystemsHealthFailedStart(t *testing.T) {
+	bot := &Engine{}
+	err := bot.SetSubsystem(gctScriptSubsystem, true)
+	if !errors.Is(err, errScriptManagerNotSetup) {
+		t.Fatalf("received '%v' expected '%v'", err, errScriptManagerNotSetup)
+	}
+	s, err := bot.subsystems().GetStatus(gctScriptSubsystem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Health != subsystem.HealthFailed || !errors.Is(s.LastError, errScriptManagerNotSetup) {
+		t.Errorf("expected failed gctscript subsystem, received %+v", s)
+	}
+}
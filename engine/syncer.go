@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
@@ -23,13 +25,12 @@ const (
 	DefaultSyncerTimeoutWebsocket = time.Minute
 )
 
-var (
-	createdCounter = 0
-	removedCounter = 0
-)
-
 // NewCurrencyPairSyncer starts a new CurrencyPairSyncer
-func NewCurrencyPairSyncer(c CurrencyPairSyncerConfig) (*ExchangeCurrencyPairSyncer, error) {
+func NewCurrencyPairSyncer(bot *Engine, c CurrencyPairSyncerConfig) (*ExchangeCurrencyPairSyncer, error) {
+	if bot == nil {
+		return nil, errors.New("cannot create syncer with nil bot")
+	}
+
 	if !c.SyncOrderbook && !c.SyncTicker && !c.SyncTrades {
 		return nil, errors.New("no sync items enabled")
 	}
@@ -46,7 +47,7 @@ func NewCurrencyPairSyncer(c CurrencyPairSyncerConfig) (*ExchangeCurrencyPairSyn
 		c.SyncTimeoutWebsocket = DefaultSyncerTimeoutWebsocket
 	}
 
-	s := ExchangeCurrencyPairSyncer{Cfg: c}
+	s := ExchangeCurrencyPairSyncer{Cfg: c, bot: bot}
 
 	s.tickerBatchLastRequested = make(map[string]time.Time)
 
@@ -97,12 +98,12 @@ func (e *ExchangeCurrencyPairSyncer) add(c *CurrencyPairSyncAgent) {
 		if e.Cfg.Verbose {
 			log.Debugf(log.SyncMgr,
 				"%s: Added ticker sync item %v: using websocket: %v using REST: %v\n",
-				c.Exchange, Bot.FormatCurrency(c.Pair).String(), c.Ticker.IsUsingWebsocket,
+				c.Exchange, e.bot.FormatCurrency(c.Pair).String(), c.Ticker.IsUsingWebsocket,
 				c.Ticker.IsUsingREST)
 		}
 		if atomic.LoadInt32(&e.initSyncCompleted) != 1 {
 			e.initSyncWG.Add(1)
-			createdCounter++
+			e.createdCounter++
 		}
 	}
 
@@ -110,12 +111,12 @@ func (e *ExchangeCurrencyPairSyncer) add(c *CurrencyPairSyncAgent) {
 		if e.Cfg.Verbose {
 			log.Debugf(log.SyncMgr,
 				"%s: Added orderbook sync item %v: using websocket: %v using REST: %v\n",
-				c.Exchange, Bot.FormatCurrency(c.Pair).String(), c.Orderbook.IsUsingWebsocket,
+				c.Exchange, e.bot.FormatCurrency(c.Pair).String(), c.Orderbook.IsUsingWebsocket,
 				c.Orderbook.IsUsingREST)
 		}
 		if atomic.LoadInt32(&e.initSyncCompleted) != 1 {
 			e.initSyncWG.Add(1)
-			createdCounter++
+			e.createdCounter++
 		}
 	}
 
@@ -123,12 +124,12 @@ func (e *ExchangeCurrencyPairSyncer) add(c *CurrencyPairSyncAgent) {
 		if e.Cfg.Verbose {
 			log.Debugf(log.SyncMgr,
 				"%s: Added trade sync item %v: using websocket: %v using REST: %v\n",
-				c.Exchange, Bot.FormatCurrency(c.Pair).String(), c.Trade.IsUsingWebsocket,
+				c.Exchange, e.bot.FormatCurrency(c.Pair).String(), c.Trade.IsUsingWebsocket,
 				c.Trade.IsUsingREST)
 		}
 		if atomic.LoadInt32(&e.initSyncCompleted) != 1 {
 			e.initSyncWG.Add(1)
-			createdCounter++
+			e.createdCounter++
 		}
 	}
 
@@ -136,6 +137,115 @@ func (e *ExchangeCurrencyPairSyncer) add(c *CurrencyPairSyncAgent) {
 	e.CurrencyPairs = append(e.CurrencyPairs, *c)
 }
 
+// newAgent returns a sync agent with the enabled sync items set to use REST
+// or the websocket
+func (e *ExchangeCurrencyPairSyncer) newAgent(exchangeName string, p currency.Pair, a asset.Item, usingREST, usingWebsocket bool) *CurrencyPairSyncAgent {
+	c := CurrencyPairSyncAgent{
+		AssetType: a,
+		Exchange:  exchangeName,
+		Pair:      p,
+	}
+
+	sBase := SyncBase{
+		IsUsingREST:      usingREST,
+		IsUsingWebsocket: usingWebsocket,
+	}
+
+	if e.Cfg.SyncTicker {
+		c.Ticker = sBase
+	}
+
+	if e.Cfg.SyncOrderbook {
+		c.Orderbook = sBase
+	}
+
+	if e.Cfg.SyncTrades {
+		c.Trade = sBase
+	}
+	return &c
+}
+
+// syncExchangePairs brings the sync agents of an exchange in line with its
+// enabled assets and pairs after they have been changed at runtime. Agents
+// are added for newly enabled pairs and removed for pairs or assets which are
+// no longer enabled
+func (e *ExchangeCurrencyPairSyncer) syncExchangePairs(exch exchange.IBotExchange) {
+	exchangeName := exch.GetName()
+	enabled := make(map[asset.Item]currency.Pairs)
+	assetTypes := exch.GetAssetTypes()
+	for y := range assetTypes {
+		if exch.GetBase().CurrencyPairs.IsAssetEnabled(assetTypes[y]) != nil {
+			continue
+		}
+		pairs, err := exch.GetEnabledPairs(assetTypes[y])
+		if err != nil {
+			log.Errorf(log.SyncMgr,
+				"%s failed to get enabled pairs. Err: %s\n",
+				exchangeName,
+				err)
+			continue
+		}
+		enabled[assetTypes[y]] = pairs
+	}
+
+	e.mux.Lock()
+	// a new slice is built so agents handed out by get are left intact
+	kept := make([]CurrencyPairSyncAgent, 0, len(e.CurrencyPairs))
+	for x := range e.CurrencyPairs {
+		c := &e.CurrencyPairs[x]
+		if c.Exchange != exchangeName || enabled[c.AssetType].Contains(c.Pair, true) {
+			kept = append(kept, *c)
+			continue
+		}
+		if e.Cfg.Verbose {
+			log.Debugf(log.SyncMgr,
+				"%s: Removed sync item %v %v\n",
+				exchangeName, e.bot.FormatCurrency(c.Pair).String(), c.AssetType)
+		}
+		if atomic.LoadInt32(&e.initSyncCompleted) == 1 {
+			continue
+		}
+		// items which never synced are released from the initial sync
+		for _, item := range []struct {
+			enabled bool
+			base    *SyncBase
+		}{
+			{e.Cfg.SyncTicker, &c.Ticker},
+			{e.Cfg.SyncOrderbook, &c.Orderbook},
+			{e.Cfg.SyncTrades, &c.Trade},
+		} {
+			if item.enabled && !item.base.HaveData {
+				e.createdCounter--
+				e.initSyncWG.Done()
+			}
+		}
+	}
+	e.CurrencyPairs = kept
+	e.mux.Unlock()
+
+	var usingREST, usingWebsocket bool
+	if exch.SupportsWebsocket() && exch.IsWebsocketEnabled() {
+		ws, err := exch.GetWebsocket()
+		if err == nil && ws.IsConnected() {
+			usingWebsocket = true
+		} else {
+			usingREST = true
+		}
+	} else if exch.SupportsREST() {
+		usingREST = true
+	}
+	for a, pairs := range enabled {
+		wsAssetSupported := exch.IsAssetWebsocketSupported(a)
+		for i := range pairs {
+			if e.exists(exchangeName, pairs[i], a) {
+				continue
+			}
+			e.add(e.newAgent(exchangeName, pairs[i], a,
+				usingREST || !wsAssetSupported, usingWebsocket && wsAssetSupported))
+		}
+	}
+}
+
 func (e *ExchangeCurrencyPairSyncer) isProcessing(exchangeName string, p currency.Pair, a asset.Item, syncType int) bool {
 	e.mux.Lock()
 	defer e.mux.Unlock()
@@ -214,54 +324,54 @@ func (e *ExchangeCurrencyPairSyncer) update(exchangeName string, p currency.Pair
 			case SyncItemTicker:
 				origHadData := e.CurrencyPairs[x].Ticker.HaveData
 				e.CurrencyPairs[x].Ticker.LastUpdated = time.Now()
-				if err != nil {
+				if err != nil && !errors.Is(err, request.ErrRequestShed) {
 					e.CurrencyPairs[x].Ticker.NumErrors++
 				}
 				e.CurrencyPairs[x].Ticker.HaveData = true
 				e.CurrencyPairs[x].Ticker.IsProcessing = false
 				if atomic.LoadInt32(&e.initSyncCompleted) != 1 && !origHadData {
-					removedCounter++
+					e.removedCounter++
 					log.Debugf(log.SyncMgr, "%s ticker sync complete %v [%d/%d].\n",
 						exchangeName,
-						Bot.FormatCurrency(p).String(),
-						removedCounter,
-						createdCounter)
+						e.bot.FormatCurrency(p).String(),
+						e.removedCounter,
+						e.createdCounter)
 					e.initSyncWG.Done()
 				}
 
 			case SyncItemOrderbook:
 				origHadData := e.CurrencyPairs[x].Orderbook.HaveData
 				e.CurrencyPairs[x].Orderbook.LastUpdated = time.Now()
-				if err != nil {
+				if err != nil && !errors.Is(err, request.ErrRequestShed) {
 					e.CurrencyPairs[x].Orderbook.NumErrors++
 				}
 				e.CurrencyPairs[x].Orderbook.HaveData = true
 				e.CurrencyPairs[x].Orderbook.IsProcessing = false
 				if atomic.LoadInt32(&e.initSyncCompleted) != 1 && !origHadData {
-					removedCounter++
+					e.removedCounter++
 					log.Debugf(log.SyncMgr, "%s orderbook sync complete %v [%d/%d].\n",
 						exchangeName,
-						Bot.FormatCurrency(p).String(),
-						removedCounter,
-						createdCounter)
+						e.bot.FormatCurrency(p).String(),
+						e.removedCounter,
+						e.createdCounter)
 					e.initSyncWG.Done()
 				}
 
 			case SyncItemTrade:
 				origHadData := e.CurrencyPairs[x].Trade.HaveData
 				e.CurrencyPairs[x].Trade.LastUpdated = time.Now()
-				if err != nil {
+				if err != nil && !errors.Is(err, request.ErrRequestShed) {
 					e.CurrencyPairs[x].Trade.NumErrors++
 				}
 				e.CurrencyPairs[x].Trade.HaveData = true
 				e.CurrencyPairs[x].Trade.IsProcessing = false
 				if atomic.LoadInt32(&e.initSyncCompleted) != 1 && !origHadData {
-					removedCounter++
+					e.removedCounter++
 					log.Debugf(log.SyncMgr, "%s trade sync complete %v [%d/%d].\n",
 						exchangeName,
-						Bot.FormatCurrency(p).String(),
-						removedCounter,
-						createdCounter)
+						e.bot.FormatCurrency(p).String(),
+						e.removedCounter,
+						e.createdCounter)
 					e.initSyncWG.Done()
 				}
 			}
@@ -277,7 +387,7 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 	defer cleanup()
 
 	for atomic.LoadInt32(&e.shutdown) != 1 {
-		exchanges := Bot.GetExchanges()
+		exchanges := e.bot.GetExchanges()
 		for x := range exchanges {
 			exchangeName := exchanges[x].GetName()
 			assetTypes := exchanges[x].GetAssetTypes()
@@ -286,6 +396,9 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 			var usingREST bool
 			var usingWebsocket bool
 			var switchedToRest bool
+			// Fail over to REST straight away when the health manager has seen
+			// the websocket drop rather than waiting for the sync timeout
+			wsDown := e.bot.ExchangeHealthManager.IsWebsocketDown(exchangeName)
 			if exchanges[x].SupportsWebsocket() && exchanges[x].IsWebsocketEnabled() {
 				ws, err := exchanges[x].GetWebsocket()
 				if err != nil {
@@ -325,30 +438,8 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 					}
 
 					if !e.exists(exchangeName, enabledPairs[i], assetTypes[y]) {
-						c := CurrencyPairSyncAgent{
-							AssetType: assetTypes[y],
-							Exchange:  exchangeName,
-							Pair:      enabledPairs[i],
-						}
-
-						sBase := SyncBase{
-							IsUsingREST:      usingREST || !wsAssetSupported,
-							IsUsingWebsocket: usingWebsocket && wsAssetSupported,
-						}
-
-						if e.Cfg.SyncTicker {
-							c.Ticker = sBase
-						}
-
-						if e.Cfg.SyncOrderbook {
-							c.Orderbook = sBase
-						}
-
-						if e.Cfg.SyncTrades {
-							c.Trade = sBase
-						}
-
-						e.add(&c)
+						e.add(e.newAgent(exchangeName, enabledPairs[i], assetTypes[y],
+							usingREST || !wsAssetSupported, usingWebsocket && wsAssetSupported))
 					}
 
 					c, err := e.get(exchangeName, enabledPairs[i], assetTypes[y])
@@ -359,16 +450,17 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 					if switchedToRest && usingWebsocket {
 						log.Warnf(log.SyncMgr,
 							"%s %s: Websocket re-enabled, switching from rest to websocket\n",
-							c.Exchange, Bot.FormatCurrency(enabledPairs[i]).String())
+							c.Exchange, e.bot.FormatCurrency(enabledPairs[i]).String())
 						switchedToRest = false
 					}
 					if e.Cfg.SyncTicker {
 						if !e.isProcessing(exchangeName, c.Pair, c.AssetType, SyncItemTicker) {
 							if c.Ticker.LastUpdated.IsZero() ||
 								(time.Since(c.Ticker.LastUpdated) > e.Cfg.SyncTimeoutREST && c.Ticker.IsUsingREST) ||
-								(time.Since(c.Ticker.LastUpdated) > e.Cfg.SyncTimeoutWebsocket && c.Ticker.IsUsingWebsocket) {
+								(time.Since(c.Ticker.LastUpdated) > e.Cfg.SyncTimeoutWebsocket && c.Ticker.IsUsingWebsocket) ||
+								(wsDown && c.Ticker.IsUsingWebsocket) {
 								if c.Ticker.IsUsingWebsocket {
-									if time.Since(c.Created) < e.Cfg.SyncTimeoutWebsocket {
+									if !wsDown && time.Since(c.Created) < e.Cfg.SyncTimeoutWebsocket {
 										continue
 									}
 
@@ -376,13 +468,22 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 										e.setProcessing(c.Exchange, c.Pair, c.AssetType, SyncItemTicker, true)
 										c.Ticker.IsUsingWebsocket = false
 										c.Ticker.IsUsingREST = true
-										log.Warnf(log.SyncMgr,
-											"%s %s %s: No ticker update after %s, switching from websocket to rest\n",
-											c.Exchange,
-											Bot.FormatCurrency(enabledPairs[i]).String(),
-											strings.ToUpper(c.AssetType.String()),
-											e.Cfg.SyncTimeoutWebsocket,
-										)
+										if wsDown {
+											log.Warnf(log.SyncMgr,
+												"%s %s %s: Websocket down, switching ticker from websocket to rest\n",
+												c.Exchange,
+												e.bot.FormatCurrency(enabledPairs[i]).String(),
+												strings.ToUpper(c.AssetType.String()),
+											)
+										} else {
+											log.Warnf(log.SyncMgr,
+												"%s %s %s: No ticker update after %s, switching from websocket to rest\n",
+												c.Exchange,
+												e.bot.FormatCurrency(enabledPairs[i]).String(),
+												strings.ToUpper(c.AssetType.String()),
+												e.Cfg.SyncTimeoutWebsocket,
+											)
+										}
 										switchedToRest = true
 										e.setProcessing(c.Exchange, c.Pair, c.AssetType, SyncItemTicker, false)
 									}
@@ -418,10 +519,10 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 									} else {
 										result, err = exchanges[x].UpdateTicker(c.Pair, c.AssetType)
 									}
-									printTickerSummary(result, "REST", err)
+									printTickerSummary(result, "REST", e.bot, err)
 									if err == nil {
-										if Bot.Config.RemoteControl.WebsocketRPC.Enabled {
-											relayWebsocketEvent(result, "ticker_update", c.AssetType.String(), exchangeName)
+										if e.bot.Config.RemoteControl.WebsocketRPC.Enabled {
+											e.bot.relayWebsocketEvent(result, "ticker_update", c.AssetType.String(), exchangeName)
 										}
 									}
 									e.update(c.Exchange, c.Pair, c.AssetType, SyncItemTicker, err)
@@ -436,22 +537,32 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 						if !e.isProcessing(exchangeName, c.Pair, c.AssetType, SyncItemOrderbook) {
 							if c.Orderbook.LastUpdated.IsZero() ||
 								(time.Since(c.Orderbook.LastUpdated) > e.Cfg.SyncTimeoutREST && c.Orderbook.IsUsingREST) ||
-								(time.Since(c.Orderbook.LastUpdated) > e.Cfg.SyncTimeoutWebsocket && c.Orderbook.IsUsingWebsocket) {
+								(time.Since(c.Orderbook.LastUpdated) > e.Cfg.SyncTimeoutWebsocket && c.Orderbook.IsUsingWebsocket) ||
+								(wsDown && c.Orderbook.IsUsingWebsocket) {
 								if c.Orderbook.IsUsingWebsocket {
-									if time.Since(c.Created) < e.Cfg.SyncTimeoutWebsocket {
+									if !wsDown && time.Since(c.Created) < e.Cfg.SyncTimeoutWebsocket {
 										continue
 									}
 									if supportsREST {
 										e.setProcessing(c.Exchange, c.Pair, c.AssetType, SyncItemOrderbook, true)
 										c.Orderbook.IsUsingWebsocket = false
 										c.Orderbook.IsUsingREST = true
-										log.Warnf(log.SyncMgr,
-											"%s %s %s: No orderbook update after %s, switching from websocket to rest\n",
-											c.Exchange,
-											Bot.FormatCurrency(c.Pair).String(),
-											strings.ToUpper(c.AssetType.String()),
-											e.Cfg.SyncTimeoutWebsocket,
-										)
+										if wsDown {
+											log.Warnf(log.SyncMgr,
+												"%s %s %s: Websocket down, switching orderbook from websocket to rest\n",
+												c.Exchange,
+												e.bot.FormatCurrency(c.Pair).String(),
+												strings.ToUpper(c.AssetType.String()),
+											)
+										} else {
+											log.Warnf(log.SyncMgr,
+												"%s %s %s: No orderbook update after %s, switching from websocket to rest\n",
+												c.Exchange,
+												e.bot.FormatCurrency(c.Pair).String(),
+												strings.ToUpper(c.AssetType.String()),
+												e.Cfg.SyncTimeoutWebsocket,
+											)
+										}
 										switchedToRest = true
 										e.setProcessing(c.Exchange, c.Pair, c.AssetType, SyncItemOrderbook, false)
 									}
@@ -459,10 +570,10 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 
 								e.setProcessing(c.Exchange, c.Pair, c.AssetType, SyncItemOrderbook, true)
 								result, err := exchanges[x].UpdateOrderbook(c.Pair, c.AssetType)
-								printOrderbookSummary(result, "REST", Bot, err)
+								printOrderbookSummary(result, "REST", e.bot, err)
 								if err == nil {
-									if Bot.Config.RemoteControl.WebsocketRPC.Enabled {
-										relayWebsocketEvent(result, "orderbook_update", c.AssetType.String(), exchangeName)
+									if e.bot.Config.RemoteControl.WebsocketRPC.Enabled {
+										e.bot.relayWebsocketEvent(result, "orderbook_update", c.AssetType.String(), exchangeName)
 									}
 								}
 								e.update(c.Exchange, c.Pair, c.AssetType, SyncItemOrderbook, err)
@@ -488,7 +599,7 @@ func (e *ExchangeCurrencyPairSyncer) worker() {
 // Start starts an exchange currency pair syncer
 func (e *ExchangeCurrencyPairSyncer) Start() {
 	log.Debugln(log.SyncMgr, "Exchange CurrencyPairSyncer started.")
-	exchanges := Bot.GetExchanges()
+	exchanges := e.bot.GetExchanges()
 	for x := range exchanges {
 		exchangeName := exchanges[x].GetName()
 		supportsWebsocket := exchanges[x].SupportsWebsocket()
@@ -515,7 +626,7 @@ func (e *ExchangeCurrencyPairSyncer) Start() {
 			}
 
 			if !ws.IsConnected() && !ws.IsConnecting() {
-				go Bot.WebsocketDataReceiver(ws)
+				go e.bot.WebsocketDataReceiver(ws)
 
 				err = ws.Connect()
 				if err == nil {
@@ -565,31 +676,8 @@ func (e *ExchangeCurrencyPairSyncer) Start() {
 				if e.exists(exchangeName, enabledPairs[i], assetTypes[y]) {
 					continue
 				}
-
-				c := CurrencyPairSyncAgent{
-					AssetType: assetTypes[y],
-					Exchange:  exchangeName,
-					Pair:      enabledPairs[i],
-				}
-
-				sBase := SyncBase{
-					IsUsingREST:      usingREST || !wsAssetSupported,
-					IsUsingWebsocket: usingWebsocket && wsAssetSupported,
-				}
-
-				if e.Cfg.SyncTicker {
-					c.Ticker = sBase
-				}
-
-				if e.Cfg.SyncOrderbook {
-					c.Orderbook = sBase
-				}
-
-				if e.Cfg.SyncTrades {
-					c.Trade = sBase
-				}
-
-				e.add(&c)
+				e.add(e.newAgent(exchangeName, enabledPairs[i], assetTypes[y],
+					usingREST || !wsAssetSupported, usingWebsocket && wsAssetSupported))
 			}
 		}
 	}
@@ -597,7 +685,7 @@ func (e *ExchangeCurrencyPairSyncer) Start() {
 	if atomic.CompareAndSwapInt32(&e.initSyncStarted, 0, 1) {
 		log.Debugf(log.SyncMgr,
 			"Exchange CurrencyPairSyncer initial sync started. %d items to process.\n",
-			createdCounter)
+			e.createdCounter)
 		e.initSyncStartTime = time.Now()
 	}
 
@@ -607,7 +695,7 @@ func (e *ExchangeCurrencyPairSyncer) Start() {
 			log.Debugf(log.SyncMgr, "Exchange CurrencyPairSyncer initial sync is complete.\n")
 			completedTime := time.Now()
 			log.Debugf(log.SyncMgr, "Exchange CurrencyPairSyncer initial sync took %v [%v sync items].\n",
-				completedTime.Sub(e.initSyncStartTime), createdCounter)
+				completedTime.Sub(e.initSyncStartTime), e.createdCounter)
 
 			if !e.Cfg.SyncContinuously {
 				log.Debugln(log.SyncMgr, "Exchange CurrencyPairSyncer stopping.")
@@ -626,6 +714,18 @@ func (e *ExchangeCurrencyPairSyncer) Start() {
 	}
 }
 
+// Started returns whether the exchange currency pair syncer is running
+func (e *ExchangeCurrencyPairSyncer) Started() bool {
+	return e != nil && atomic.LoadInt32(&e.shutdown) == 0
+}
+
+// Finished returns whether a syncer which does not sync continuously has
+// stopped, it stops by itself once the initial sync is complete and must not
+// be restarted
+func (e *ExchangeCurrencyPairSyncer) Finished() bool {
+	return e != nil && !e.Cfg.SyncContinuously && atomic.LoadInt32(&e.shutdown) == 1
+}
+
 // Stop shuts down the exchange currency pair syncer
 func (e *ExchangeCurrencyPairSyncer) Stop() {
 	stopped := atomic.CompareAndSwapInt32(&e.shutdown, 0, 1)
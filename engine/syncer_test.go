@@ -1,10 +1,13 @@
 package engine
 
 import (
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
 func TestNewCurrencyPairSyncer(t *testing.T) {
@@ -27,7 +30,7 @@ func TestNewCurrencyPairSyncer(t *testing.T) {
 		t.Log(err)
 	}
 
-	Bot.ExchangeCurrencyPairManager, err = NewCurrencyPairSyncer(CurrencyPairSyncerConfig{
+	Bot.ExchangeCurrencyPairManager, err = NewCurrencyPairSyncer(Bot, CurrencyPairSyncerConfig{
 		SyncTicker:       true,
 		SyncOrderbook:    false,
 		SyncTrades:       false,
@@ -41,3 +44,67 @@ func TestNewCurrencyPairSyncer(t *testing.T) {
 	time.Sleep(time.Second * 15)
 	Bot.ExchangeCurrencyPairManager.Stop()
 }
+
+func TestSyncerFinished(t *testing.T) {
+	t.Parallel()
+	var e *ExchangeCurrencyPairSyncer
+	if e.Finished() {
+		t.Error("nil syncer should not be finished")
+	}
+	e = &ExchangeCurrencyPairSyncer{}
+	if e.Finished() {
+		t.Error("running syncer should not be finished")
+	}
+	e.Stop()
+	if !e.Finished() {
+		t.Error("stopped syncer which does not sync continuously should be finished")
+	}
+	e = &ExchangeCurrencyPairSyncer{Cfg: CurrencyPairSyncerConfig{SyncContinuously: true}}
+	e.Stop()
+	if e.Finished() {
+		t.Error("stopped continuous syncer should be restarted")
+	}
+}
+
+func TestSyncExchangePairs(t *testing.T) {
+	t.Parallel()
+	bot := CreateTestBot(t)
+	e, err := NewCurrencyPairSyncer(bot, CurrencyPairSyncerConfig{SyncTicker: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	atomic.StoreInt32(&e.initSyncStarted, 1)
+	exch := bot.GetExchangeByName(testExchange)
+	available, err := exch.GetAvailablePairs(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(available) < 2 {
+		t.Fatal("expected at least two available pairs")
+	}
+	stale := available[0]
+	e.add(e.newAgent(exch.GetName(), stale, asset.Spot, true, false))
+
+	exch.GetBase().CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{available[1]}, true)
+	e.syncExchangePairs(exch)
+	if e.exists(exch.GetName(), stale, asset.Spot) {
+		t.Error("expected agent of a disabled pair to be removed")
+	}
+	if !e.exists(exch.GetName(), available[1], asset.Spot) {
+		t.Error("expected agent of an enabled pair to be added")
+	}
+
+	// the removed agent never synced, so it must not hold up the initial
+	// sync, leaving only the added agent outstanding
+	e.update(exch.GetName(), available[1], asset.Spot, SyncItemTicker, nil)
+	done := make(chan struct{})
+	go func() {
+		e.initSyncWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("expected initial sync to complete")
+	}
+}
@@ -38,6 +38,9 @@ type ExchangeCurrencyPairSyncer struct {
 	initSyncStarted   int32
 	initSyncStartTime time.Time
 	shutdown          int32
+	createdCounter    int
+	removedCounter    int
+	bot               *Engine
 }
 
 // SyncBase stores information
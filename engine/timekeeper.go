@@ -17,6 +17,7 @@ var (
 	NTPCheckInterval = time.Second * 30
 	NTPRetryLimit    = 3
 	errNTPDisabled   = errors.New("ntp client disabled")
+	errNoClockSkew   = errors.New("clock skew has not been measured")
 )
 
 // ntpManager starts the NTP manager
@@ -24,24 +25,31 @@ type ntpManager struct {
 	started      int32
 	initialCheck bool
 	shutdown     chan struct{}
+	clockSkew    int64
+	skewMeasured int32
+	bot          *Engine
 }
 
 func (n *ntpManager) Started() bool {
 	return atomic.LoadInt32(&n.started) == 1
 }
 
-func (n *ntpManager) Start() error {
+func (n *ntpManager) Start(bot *Engine) error {
+	if bot == nil {
+		return errors.New("cannot start with nil bot")
+	}
 	if !atomic.CompareAndSwapInt32(&n.started, 0, 1) {
 		return fmt.Errorf("NTP manager %w", subsystem.ErrSubSystemAlreadyStarted)
 	}
+	n.bot = bot
 
-	if Bot.Config.NTPClient.Level == -1 {
+	if n.bot.Config.NTPClient.Level == -1 {
 		atomic.CompareAndSwapInt32(&n.started, 1, 0)
 		return errors.New("NTP client disabled")
 	}
 
 	log.Debugln(log.TimeMgr, "NTP manager starting...")
-	if Bot.Config.NTPClient.Level == 0 && *Bot.Config.Logging.Enabled {
+	if n.bot.Config.NTPClient.Level == 0 && *n.bot.Config.Logging.Enabled {
 		// Initial NTP check (prompts user on how we should proceed)
 		n.initialCheck = true
 		// Sometimes the NTP client can have transient issues due to UDP, try
@@ -102,15 +110,26 @@ func (n *ntpManager) run() {
 }
 
 func (n *ntpManager) FetchNTPTime() time.Time {
-	return ntpclient.NTPClient(Bot.Config.NTPClient.Pool)
+	return ntpclient.NTPClient(n.bot.Config.NTPClient.Pool)
+}
+
+// GetClockSkew returns the difference between NTP time and the system clock
+// from the most recent check
+func (n *ntpManager) GetClockSkew() (time.Duration, error) {
+	if atomic.LoadInt32(&n.skewMeasured) == 0 {
+		return 0, errNoClockSkew
+	}
+	return time.Duration(atomic.LoadInt64(&n.clockSkew)), nil
 }
 
 func (n *ntpManager) processTime() error {
 	NTPTime := n.FetchNTPTime()
 	currentTime := time.Now()
 	diff := NTPTime.Sub(currentTime)
-	configNTPTime := *Bot.Config.NTPClient.AllowedDifference
-	negDiff := *Bot.Config.NTPClient.AllowedNegativeDifference
+	atomic.StoreInt64(&n.clockSkew, int64(diff))
+	atomic.StoreInt32(&n.skewMeasured, 1)
+	configNTPTime := *n.bot.Config.NTPClient.AllowedDifference
+	negDiff := *n.bot.Config.NTPClient.AllowedNegativeDifference
 	configNTPNegativeTime := -negDiff
 	if diff > configNTPTime || diff < configNTPNegativeTime {
 		log.Warnf(log.TimeMgr, "NTP manager: Time out of sync (NTP): %v | (time.Now()): %v | (Difference): %v | (Allowed): +%v / %v\n",
@@ -121,12 +140,12 @@ func (n *ntpManager) processTime() error {
 			configNTPNegativeTime)
 		if n.initialCheck {
 			n.initialCheck = false
-			disable, err := Bot.Config.DisableNTPCheck(os.Stdin)
+			disable, err := n.bot.Config.DisableNTPCheck(os.Stdin)
 			if err != nil {
 				return fmt.Errorf("unable to disable NTP check: %s", err)
 			}
 			log.Infoln(log.TimeMgr, disable)
-			if Bot.Config.NTPClient.Level == -1 {
+			if n.bot.Config.NTPClient.Level == -1 {
 				return errNTPDisabled
 			}
 		}
@@ -1,16 +1,18 @@
 package engine
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gorilla/websocket"
-	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -19,27 +21,24 @@ const (
 	WebsocketResponseSuccess = "OK"
 )
 
-var (
-	wsHub        *WebsocketHub
-	wsHubStarted bool
-)
-
+// wsCommandHandler holds a websocket command and the scope a client requires
+// to call it. Commands without a scope do not require authentication
 type wsCommandHandler struct {
-	authRequired bool
-	handler      func(client *WebsocketClient, data interface{}) error
+	scope   string
+	handler func(client *WebsocketClient, data interface{}) error
 }
 
 var wsHandlers = map[string]wsCommandHandler{
-	"auth":             {authRequired: false, handler: wsAuth},
-	"getconfig":        {authRequired: true, handler: wsGetConfig},
-	"saveconfig":       {authRequired: true, handler: wsSaveConfig},
-	"getaccountinfo":   {authRequired: true, handler: wsGetAccountInfo},
-	"gettickers":       {authRequired: false, handler: wsGetTickers},
-	"getticker":        {authRequired: false, handler: wsGetTicker},
-	"getorderbooks":    {authRequired: false, handler: wsGetOrderbooks},
-	"getorderbook":     {authRequired: false, handler: wsGetOrderbook},
-	"getexchangerates": {authRequired: false, handler: wsGetExchangeRates},
-	"getportfolio":     {authRequired: true, handler: wsGetPortfolio},
+	"auth":             {handler: wsAuth},
+	"getconfig":        {scope: auth.ScopeAdmin, handler: wsGetConfig},
+	"saveconfig":       {scope: auth.ScopeAdmin, handler: wsSaveConfig},
+	"getaccountinfo":   {scope: auth.ScopeRead, handler: wsGetAccountInfo},
+	"gettickers":       {handler: wsGetTickers},
+	"getticker":        {handler: wsGetTicker},
+	"getorderbooks":    {handler: wsGetOrderbooks},
+	"getorderbook":     {handler: wsGetOrderbook},
+	"getexchangerates": {handler: wsGetExchangeRates},
+	"getportfolio":     {scope: auth.ScopeRead, handler: wsGetPortfolio},
 }
 
 // NewWebsocketHub Creates a new websocket hub
@@ -132,10 +131,14 @@ func (c *WebsocketClient) read() {
 				continue
 			}
 
-			if result.authRequired && !c.Authenticated {
-				log.Warnf(log.WebsocketMgr, "Websocket: request %s failed due to unauthenticated request on an authenticated API\n", evt.Event)
-				c.SendWebsocketMessage(WebsocketEventResponse{Event: evt.Event, Error: "unauthorised request on authenticated API"})
-				continue
+			if result.scope != "" {
+				err = c.principal.Authorise(result.scope)
+				c.bot.auditAuthorisation(c.auditContext(), req, result.scope, err)
+				if err != nil {
+					log.Warnf(log.WebsocketMgr, "Websocket: request %s failed. Error %s\n", evt.Event, err)
+					c.SendWebsocketMessage(WebsocketEventResponse{Event: evt.Event, Error: "unauthorised request on authenticated API"})
+					continue
+				}
 			}
 
 			err = result.handler(c, dataJSON)
@@ -181,19 +184,30 @@ func (c *WebsocketClient) write() {
 	}
 }
 
-// StartWebsocketHandler starts the websocket hub and routine which
+// StartWebsocketHandler starts the engine's websocket hub and routine which
 // handles clients
-func StartWebsocketHandler() {
-	if !wsHubStarted {
-		wsHubStarted = true
-		wsHub = NewWebsocketHub()
-		go wsHub.run()
+func (bot *Engine) StartWebsocketHandler() {
+	bot.websocketHubMtx.Lock()
+	defer bot.websocketHubMtx.Unlock()
+	if bot.websocketHub == nil {
+		bot.websocketHub = NewWebsocketHub()
+		go bot.websocketHub.run()
 	}
 }
 
-// BroadcastWebsocketMessage meow
-func BroadcastWebsocketMessage(evt WebsocketEvent) error {
-	if !wsHubStarted {
+// getWebsocketHub returns the engine's websocket hub or nil if the handler
+// has not been started
+func (bot *Engine) getWebsocketHub() *WebsocketHub {
+	bot.websocketHubMtx.Lock()
+	defer bot.websocketHubMtx.Unlock()
+	return bot.websocketHub
+}
+
+// BroadcastWebsocketMessage sends an event to all clients connected to the
+// engine's websocket hub
+func (bot *Engine) BroadcastWebsocketMessage(evt WebsocketEvent) error {
+	hub := bot.getWebsocketHub()
+	if hub == nil {
 		return errors.New("websocket service not started")
 	}
 
@@ -202,19 +216,18 @@ func BroadcastWebsocketMessage(evt WebsocketEvent) error {
 		return err
 	}
 
-	wsHub.Broadcast <- data
+	hub.Broadcast <- data
 	return nil
 }
 
 // WebsocketClientHandler upgrades the HTTP connection to a websocket
 // compatible one
-func WebsocketClientHandler(w http.ResponseWriter, r *http.Request) {
-	if !wsHubStarted {
-		StartWebsocketHandler()
-	}
+func (bot *Engine) WebsocketClientHandler(w http.ResponseWriter, r *http.Request) {
+	bot.StartWebsocketHandler()
+	hub := bot.getWebsocketHub()
 
-	connectionLimit := Bot.Config.RemoteControl.WebsocketRPC.ConnectionLimit
-	numClients := len(wsHub.Clients)
+	connectionLimit := bot.Config.RemoteControl.WebsocketRPC.ConnectionLimit
+	numClients := len(hub.Clients)
 
 	if numClients >= connectionLimit {
 		log.Warnf(log.WebsocketMgr,
@@ -231,7 +244,7 @@ func WebsocketClientHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Allow insecure origin if the Origin request header is present and not
 	// equal to the Host request header. Default to false
-	if Bot.Config.RemoteControl.WebsocketRPC.AllowInsecureOrigin {
+	if bot.Config.RemoteControl.WebsocketRPC.AllowInsecureOrigin {
 		upgrader.CheckOrigin = func(r *http.Request) bool { return true }
 	}
 
@@ -241,7 +254,7 @@ func WebsocketClientHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	client := &WebsocketClient{Hub: wsHub, Conn: conn, Send: make(chan []byte, 1024)}
+	client := &WebsocketClient{Hub: hub, Conn: conn, Send: make(chan []byte, 1024), bot: bot}
 	client.Hub.Register <- client
 	log.Debugf(log.WebsocketMgr,
 		"websocket: client connected. Connected clients: %d. Limit %d.\n",
@@ -256,44 +269,66 @@ func wsAuth(client *WebsocketClient, data interface{}) error {
 		Event: "auth",
 	}
 
-	var auth WebsocketAuth
-	err := json.Unmarshal(data.([]byte), &auth)
+	var req WebsocketAuth
+	err := json.Unmarshal(data.([]byte), &req)
 	if err != nil {
 		wsResp.Error = err.Error()
 		client.SendWebsocketMessage(wsResp)
 		return err
 	}
 
-	hashPW := crypto.HexEncodeToString(crypto.GetSHA256([]byte(Bot.Config.RemoteControl.Password)))
-	if auth.Username == Bot.Config.RemoteControl.Username && auth.Password == hashPW {
+	creds := client.bot.Config.GetRemoteControlCredentials()
+	var principal *auth.Principal
+	if req.Token != "" {
+		principal, err = creds.AuthenticateToken(req.Token)
+	} else {
+		principal, err = creds.AuthenticateDigest(req.Username, req.Password)
+	}
+	if err == nil {
+		client.principal = principal
 		client.Authenticated = true
 		wsResp.Data = WebsocketResponseSuccess
 		log.Debugln(log.WebsocketMgr,
 			"websocket: client authenticated successfully")
 		return client.SendWebsocketMessage(wsResp)
 	}
+	client.bot.auditAuthorisation(client.auditContext(), "auth", "", err)
 
 	wsResp.Error = "invalid username/password"
 	client.authFailures++
 	client.SendWebsocketMessage(wsResp)
-	if client.authFailures >= Bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures {
+	if client.authFailures >= client.bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures {
 		log.Debugf(log.WebsocketMgr,
 			"websocket: disconnecting client, maximum auth failures threshold reached (failures: %d limit: %d)\n",
-			client.authFailures, Bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures)
-		wsHub.Unregister <- client
+			client.authFailures, client.bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures)
+		client.Hub.Unregister <- client
 		return nil
 	}
 
 	log.Debugf(log.WebsocketMgr,
 		"websocket: client sent wrong username/password (failures: %d limit: %d)\n",
-		client.authFailures, Bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures)
+		client.authFailures, client.bot.Config.RemoteControl.WebsocketRPC.MaxAuthFailures)
 	return nil
 }
 
+// auditContext returns a context identifying the client as an audit actor,
+// using the authenticated principal when available and the remote address
+// otherwise
+func (c *WebsocketClient) auditContext() context.Context {
+	actor := audit.Actor{Type: audit.ActorWebsocket}
+	switch {
+	case c.principal != nil:
+		actor.ID = principalActorID(c.principal)
+	case c.Conn != nil:
+		actor.ID = c.Conn.RemoteAddr().String()
+	}
+	return audit.WithActor(context.Background(), actor)
+}
+
 func wsGetConfig(client *WebsocketClient, data interface{}) error {
 	wsResp := WebsocketEventResponse{
 		Event: "GetConfig",
-		Data:  Bot.Config,
+		Data:  client.bot.Config,
 	}
 	return client.SendWebsocketMessage(wsResp)
 }
@@ -310,20 +345,22 @@ func wsSaveConfig(client *WebsocketClient, data interface{}) error {
 		return err
 	}
 
-	err = Bot.Config.UpdateConfig(Bot.Settings.ConfigFile, &cfg, Bot.Settings.EnableDryRun)
+	err = client.bot.Config.UpdateConfig(client.bot.Settings.ConfigFile, &cfg, client.bot.Settings.EnableDryRun)
 	if err != nil {
 		wsResp.Error = err.Error()
 		client.SendWebsocketMessage(wsResp)
 		return err
 	}
+	client.bot.auditLog().EventWithContext(client.auditContext(),
+		audit.ConfigSave, client.bot.Settings.ConfigFile, "config saved", nil)
 
-	Bot.SetupExchanges()
+	client.bot.SetupExchanges()
 	wsResp.Data = WebsocketResponseSuccess
 	return client.SendWebsocketMessage(wsResp)
 }
 
 func wsGetAccountInfo(client *WebsocketClient, data interface{}) error {
-	accountInfo := Bot.GetAllEnabledExchangeAccountInfo()
+	accountInfo := client.bot.GetAllEnabledExchangeAccountInfo()
 	wsResp := WebsocketEventResponse{
 		Event: "GetAccountInfo",
 		Data:  accountInfo,
@@ -335,7 +372,7 @@ func wsGetTickers(client *WebsocketClient, data interface{}) error {
 	wsResp := WebsocketEventResponse{
 		Event: "GetTickers",
 	}
-	wsResp.Data = Bot.GetAllActiveTickers()
+	wsResp.Data = client.bot.GetAllActiveTickers()
 	return client.SendWebsocketMessage(wsResp)
 }
 
@@ -361,7 +398,7 @@ func wsGetTicker(client *WebsocketClient, data interface{}) error {
 		return err
 	}
 
-	result, err := Bot.GetSpecificTicker(p, tickerReq.Exchange, a)
+	result, err := client.bot.GetSpecificTicker(p, tickerReq.Exchange, a)
 	if err != nil {
 		wsResp.Error = err.Error()
 		client.SendWebsocketMessage(wsResp)
@@ -375,7 +412,7 @@ func wsGetOrderbooks(client *WebsocketClient, data interface{}) error {
 	wsResp := WebsocketEventResponse{
 		Event: "GetOrderbooks",
 	}
-	wsResp.Data = GetAllActiveOrderbooks()
+	wsResp.Data = client.bot.GetAllActiveOrderbooks()
 	return client.SendWebsocketMessage(wsResp)
 }
 
@@ -401,7 +438,7 @@ func wsGetOrderbook(client *WebsocketClient, data interface{}) error {
 		return err
 	}
 
-	result, err := Bot.GetSpecificOrderbook(p, orderbookReq.Exchange, a)
+	result, err := client.bot.GetSpecificOrderbook(p, orderbookReq.Exchange, a)
 	if err != nil {
 		wsResp.Error = err.Error()
 		client.SendWebsocketMessage(wsResp)
@@ -429,6 +466,6 @@ func wsGetPortfolio(client *WebsocketClient, data interface{}) error {
 	wsResp := WebsocketEventResponse{
 		Event: "GetPortfolio",
 	}
-	wsResp.Data = Bot.Portfolio.GetPortfolioSummary()
+	wsResp.Data = client.bot.Portfolio.GetPortfolioSummary()
 	return client.SendWebsocketMessage(wsResp)
 }
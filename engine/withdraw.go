@@ -1,9 +1,12 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
 	withdrawDataStore "github.com/thrasher-corp/gocryptotrader/database/repository/withdraw"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
@@ -22,8 +25,8 @@ const (
 )
 
 // SubmitWithdrawal performs validation and submits a new withdraw request to
-// exchange
-func (bot *Engine) SubmitWithdrawal(req *withdraw.Request) (*withdraw.Response, error) {
+// exchange. The request is audited against the actor stored in ctx
+func (bot *Engine) SubmitWithdrawal(ctx context.Context, req *withdraw.Request) (*withdraw.Response, error) {
 	if req == nil {
 		return nil, withdraw.ErrRequestCannotBeNil
 	}
@@ -67,22 +70,48 @@ func (bot *Engine) SubmitWithdrawal(req *withdraw.Request) (*withdraw.Response,
 				resp.Exchange.ID = ret.ID
 			}
 		}
-		withdrawDataStore.Event(resp)
+		withdrawDataStore.New(bot.DatabaseInstance()).Event(resp)
 	}
 	if err == nil {
 		withdraw.Cache.Add(resp.ID, resp)
 	}
+	bot.auditWithdrawal(ctx, resp, err)
 	return resp, nil
 }
 
-// WithdrawalEventByID returns a withdrawal request by ID
-func WithdrawalEventByID(id string) (*withdraw.Response, error) {
+// auditWithdrawal records a withdrawal request and its outcome in the audit
+// log. Only the destination and amount are stored, not the full request
+func (bot *Engine) auditWithdrawal(ctx context.Context, resp *withdraw.Response, err error) {
+	message := "withdrawal " + resp.ID.String() + " requested"
+	data := map[string]interface{}{
+		"currency":    resp.RequestDetails.Currency.String(),
+		"amount":      resp.RequestDetails.Amount,
+		"description": resp.RequestDetails.Description,
+		"status":      resp.Exchange.Status,
+	}
+	if resp.RequestDetails.Type == withdraw.Fiat {
+		data["bank"] = resp.RequestDetails.Fiat.Bank.BankName
+		data["account"] = resp.RequestDetails.Fiat.Bank.AccountNumber
+	} else {
+		data["address"] = resp.RequestDetails.Crypto.Address
+		data["address_tag"] = resp.RequestDetails.Crypto.AddressTag
+	}
+	if err != nil {
+		message = "withdrawal request failed"
+		data["error"] = err.Error()
+	}
+	audit.New(bot.DatabaseInstance()).EventWithContext(ctx, audit.Withdrawal, resp.Exchange.Name, message, data)
+}
+
+// WithdrawalEventByID returns a withdrawal request by ID, requests not cached
+// are looked up in the database instance db
+func WithdrawalEventByID(db *database.Instance, id string) (*withdraw.Response, error) {
 	v := withdraw.Cache.Get(id)
 	if v != nil {
 		return v.(*withdraw.Response), nil
 	}
 
-	l, err := withdrawDataStore.GetEventByUUID(id)
+	l, err := withdrawDataStore.New(db).GetEventByUUID(id)
 	if err != nil {
 		return nil, fmt.Errorf(ErrWithdrawRequestNotFound, id)
 	}
@@ -91,18 +120,18 @@ func WithdrawalEventByID(id string) (*withdraw.Response, error) {
 }
 
 // WithdrawalEventByExchange returns a withdrawal request by ID
-func WithdrawalEventByExchange(exchange string, limit int) ([]*withdraw.Response, error) {
-	return withdrawDataStore.GetEventsByExchange(exchange, limit)
+func WithdrawalEventByExchange(db *database.Instance, exchange string, limit int) ([]*withdraw.Response, error) {
+	return withdrawDataStore.New(db).GetEventsByExchange(exchange, limit)
 }
 
 // WithdrawEventByDate returns a withdrawal request by ID
-func WithdrawEventByDate(exchange string, start, end time.Time, limit int) ([]*withdraw.Response, error) {
-	return withdrawDataStore.GetEventsByDate(exchange, start, end, limit)
+func WithdrawEventByDate(db *database.Instance, exchange string, start, end time.Time, limit int) ([]*withdraw.Response, error) {
+	return withdrawDataStore.New(db).GetEventsByDate(exchange, start, end, limit)
 }
 
 // WithdrawalEventByExchangeID returns a withdrawal request by Exchange ID
-func WithdrawalEventByExchangeID(exchange, id string) (*withdraw.Response, error) {
-	return withdrawDataStore.GetEventByExchangeID(exchange, id)
+func WithdrawalEventByExchangeID(db *database.Instance, exchange, id string) (*withdraw.Response, error) {
+	return withdrawDataStore.New(db).GetEventByExchangeID(exchange, id)
 }
 
 func parseMultipleEvents(ret []*withdraw.Response) *gctrpc.WithdrawalEventsByExchangeResponse {
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
@@ -76,12 +78,12 @@ func TestSubmitWithdrawal(t *testing.T) {
 		},
 	}
 
-	_, err = bot.SubmitWithdrawal(req)
+	_, err = bot.SubmitWithdrawal(context.Background(), req)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	_, err = bot.SubmitWithdrawal(nil)
+	_, err = bot.SubmitWithdrawal(context.Background(), nil)
 	if err != nil {
 		if err.Error() != withdraw.ErrRequestCannotBeNil.Error() {
 			t.Fatal(err)
@@ -94,14 +96,14 @@ func TestWithdrawEventByID(t *testing.T) {
 	tempResp := &withdraw.Response{
 		ID: withdraw.DryRunID,
 	}
-	_, err := WithdrawalEventByID(withdraw.DryRunID.String())
+	_, err := WithdrawalEventByID(database.DB, withdraw.DryRunID.String())
 	if err != nil {
 		if err.Error() != fmt.Errorf(ErrWithdrawRequestNotFound, withdraw.DryRunID.String()).Error() {
 			t.Fatal(err)
 		}
 	}
 	withdraw.Cache.Add(withdraw.DryRunID.String(), tempResp)
-	v, err := WithdrawalEventByID(withdraw.DryRunID.String())
+	v, err := WithdrawalEventByID(database.DB, withdraw.DryRunID.String())
 	if err != nil {
 		if err != fmt.Errorf(ErrWithdrawRequestNotFound, withdraw.DryRunID.String()) {
 			t.Fatal(err)
@@ -113,21 +115,21 @@ func TestWithdrawEventByID(t *testing.T) {
 }
 
 func TestWithdrawalEventByExchange(t *testing.T) {
-	_, err := WithdrawalEventByExchange(testExchange, 1)
+	_, err := WithdrawalEventByExchange(database.DB, testExchange, 1)
 	if err == nil {
 		t.Fatal(err)
 	}
 }
 
 func TestWithdrawEventByDate(t *testing.T) {
-	_, err := WithdrawEventByDate(testExchange, time.Now(), time.Now(), 1)
+	_, err := WithdrawEventByDate(database.DB, testExchange, time.Now(), time.Now(), 1)
 	if err == nil {
 		t.Fatal(err)
 	}
 }
 
 func TestWithdrawalEventByExchangeID(t *testing.T) {
-	_, err := WithdrawalEventByExchangeID(testExchange, testExchange)
+	_, err := WithdrawalEventByExchangeID(database.DB, testExchange, testExchange)
 	if err == nil {
 		t.Fatal(err)
 	}
@@ -11,29 +11,53 @@ import (
 )
 
 func init() {
-	service = new(Service)
-	service.accounts = make(map[string]*Account)
-	service.mux = dispatch.GetNewMux()
+	service = NewService()
+}
+
+// NewService returns a new account holdings store which is independent of the
+// package level service used by the exchange wrappers
+func NewService() *Service {
+	return &Service{
+		accounts: make(map[string]*Account),
+		mux:      dispatch.GetNewMux(),
+	}
+}
+
+// GetService returns the package level account service which the exchange
+// wrappers process their holdings through
+func GetService() *Service {
+	return service
 }
 
 // SubscribeToExchangeAccount subcribes to your exchange account
 func SubscribeToExchangeAccount(exchange string) (dispatch.Pipe, error) {
+	return service.SubscribeToExchangeAccount(exchange)
+}
+
+// SubscribeToExchangeAccount subcribes to exchange account holdings stored by
+// the service
+func (s *Service) SubscribeToExchangeAccount(exchange string) (dispatch.Pipe, error) {
 	exchange = strings.ToLower(exchange)
-	service.Lock()
+	s.Lock()
 
-	acc, ok := service.accounts[exchange]
+	acc, ok := s.accounts[exchange]
 	if !ok {
-		service.Unlock()
+		s.Unlock()
 		return dispatch.Pipe{},
 			fmt.Errorf("%s exchange account holdings not found", exchange)
 	}
 
-	defer service.Unlock()
-	return service.mux.Subscribe(acc.ID)
+	defer s.Unlock()
+	return s.mux.Subscribe(acc.ID)
 }
 
 // Process processes new account holdings updates
 func Process(h *Holdings) error {
+	return service.Process(h)
+}
+
+// Process processes new account holdings updates for the service
+func (s *Service) Process(h *Holdings) error {
 	if h == nil {
 		return errors.New("cannot be nil")
 	}
@@ -42,11 +66,16 @@ func Process(h *Holdings) error {
 		return errors.New("exchange name unset")
 	}
 
-	return service.Update(h)
+	return s.Update(h)
 }
 
 // GetHoldings returns full holdings for an exchange
 func GetHoldings(exch string, assetType asset.Item) (Holdings, error) {
+	return service.GetHoldings(exch, assetType)
+}
+
+// GetHoldings returns full holdings for an exchange stored by the service
+func (s *Service) GetHoldings(exch string, assetType asset.Item) (Holdings, error) {
 	if exch == "" {
 		return Holdings{}, errors.New("exchange name unset")
 	}
@@ -57,9 +86,9 @@ func GetHoldings(exch string, assetType asset.Item) (Holdings, error) {
 		return Holdings{}, fmt.Errorf("assetType %v is invalid", assetType)
 	}
 
-	service.Lock()
-	defer service.Unlock()
-	h, ok := service.accounts[exch]
+	s.Lock()
+	defer s.Unlock()
+	h, ok := s.accounts[exch]
 	if !ok {
 		return Holdings{}, errors.New("exchange account holdings not found")
 	}
@@ -546,6 +546,15 @@ func (a *Alphapoint) SendHTTPRequest(ep exchange.URL, method, path string, data
 		HTTPRecording: a.HTTPRecording})
 }
 
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	alphapointCreateOrder,
+	alphapointModifyOrder,
+	alphapointCancelOrder,
+	alphapointCancelAllOrders,
+}
+
 // SendAuthenticatedHTTPRequest sends an authenticated request
 func (a *Alphapoint) SendAuthenticatedHTTPRequest(ep exchange.URL, method, path string, data map[string]interface{}, result interface{}) error {
 	if !a.AllowAuthenticatedRequest() {
@@ -567,6 +576,7 @@ func (a *Alphapoint) SendAuthenticatedHTTPRequest(ep exchange.URL, method, path
 		[]byte(n.String()+a.API.Credentials.ClientID+a.API.Credentials.Key),
 		[]byte(a.API.Credentials.Secret))
 	data["apiSig"] = strings.ToUpper(crypto.HexEncodeToString(hmac))
+	priority := request.PriorityByEndpoint(path, orderEndpoints...)
 	path = fmt.Sprintf("%s/ajax/v%s/%s", endpoint, alphapointAPIVersion, path)
 
 	PayloadJSON, err := json.Marshal(data)
@@ -581,6 +591,7 @@ func (a *Alphapoint) SendAuthenticatedHTTPRequest(ep exchange.URL, method, path
 		Body:          bytes.NewBuffer(PayloadJSON),
 		Result:        result,
 		AuthRequest:   true,
+		Priority:      priority,
 		NonceEnabled:  true,
 		Verbose:       a.Verbose,
 		HTTPDebugging: a.HTTPDebugging,
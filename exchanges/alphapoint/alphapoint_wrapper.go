@@ -111,7 +111,7 @@ func (a *Alphapoint) UpdateAccountInfo(assetType asset.Item) (account.Holdings,
 		Currencies: balances,
 	})
 
-	err = account.Process(&response)
+	err = a.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -122,7 +122,7 @@ func (a *Alphapoint) UpdateAccountInfo(assetType asset.Item) (account.Holdings,
 // FetchAccountInfo retrieves balances for all enabled currencies on the
 // Alphapoint exchange
 func (a *Alphapoint) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(a.Name, assetType)
+	acc, err := a.GetAccountService().GetHoldings(a.Name, assetType)
 	if err != nil {
 		return a.UpdateAccountInfo(assetType)
 	}
@@ -137,7 +137,7 @@ func (a *Alphapoint) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticke
 		return nil, err
 	}
 
-	err = ticker.ProcessTicker(&ticker.Price{
+	err = a.GetTickerService().ProcessTicker(&ticker.Price{
 		Pair:         p,
 		Ask:          tick.Ask,
 		Bid:          tick.Bid,
@@ -152,12 +152,12 @@ func (a *Alphapoint) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticke
 		return nil, err
 	}
 
-	return ticker.GetTicker(a.Name, p, assetType)
+	return a.GetTickerService().GetTicker(a.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (a *Alphapoint) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tick, err := ticker.GetTicker(a.Name, p, assetType)
+	tick, err := a.GetTickerService().GetTicker(a.Name, p, assetType)
 	if err != nil {
 		return a.UpdateTicker(p, assetType)
 	}
@@ -190,17 +190,17 @@ func (a *Alphapoint) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*or
 	orderBook.Exchange = a.Name
 	orderBook.Asset = assetType
 
-	err = orderBook.Process()
+	err = a.GetOrderbookService().Process(orderBook)
 	if err != nil {
 		return orderBook, err
 	}
 
-	return orderbook.Get(a.Name, p, assetType)
+	return a.GetOrderbookService().Retrieve(a.Name, p, assetType)
 }
 
 // FetchOrderbook returns the orderbook for a currency pair
 func (a *Alphapoint) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(a.Name, p, assetType)
+	ob, err := a.GetOrderbookService().Retrieve(a.Name, p, assetType)
 	if err != nil {
 		return a.UpdateOrderbook(p, assetType)
 	}
@@ -225,7 +225,7 @@ func (b *Binance) wsHandleData(respRaw []byte) error {
 					}
 				}
 				var oStatus order.Status
-				oStatus, err = stringToOrderStatus(data.Data.CurrentExecutionType)
+				oStatus, err = stringToOrderStatus(data.Data.OrderStatus)
 				if err != nil {
 					b.Websocket.DataHandler <- order.ClassificationError{
 						Exchange: b.Name,
@@ -244,15 +244,35 @@ func (b *Binance) wsHandleData(respRaw []byte) error {
 					Amount:          data.Data.Quantity,
 					ExecutedAmount:  data.Data.CumulativeFilledQuantity,
 					RemainingAmount: data.Data.Quantity - data.Data.CumulativeFilledQuantity,
+					Cost:            data.Data.CumulativeQuoteTransactedQuantity,
 					Exchange:        b.Name,
 					ID:              orderID,
+					ClientOrderID:   data.Data.ClientOrderID,
 					Type:            oType,
 					Side:            oSide,
 					Status:          oStatus,
 					AssetType:       a,
 					Date:            data.Data.OrderCreationTime,
+					LastUpdated:     data.Data.TransactionTime,
 					Pair:            p,
 				}
+				if data.Data.CurrentExecutionType == "TRADE" {
+					b.Websocket.DataHandler <- &order.Fill{
+						Price:         data.Data.LastExecutedPrice,
+						Amount:        data.Data.LastExecutedQuantity,
+						Fee:           data.Data.Commission,
+						FeeAsset:      data.Data.CommissionAsset,
+						Exchange:      b.Name,
+						ID:            strconv.FormatInt(data.Data.TradeID, 10),
+						OrderID:       orderID,
+						ClientOrderID: data.Data.ClientOrderID,
+						IsMaker:       data.Data.IsMaker,
+						Side:          oSide,
+						AssetType:     a,
+						Pair:          p,
+						Timestamp:     data.Data.TransactionTime,
+					}
+				}
 				return nil
 			case "listStatus":
 				var data wsListStatus
@@ -288,7 +308,7 @@ func (b *Binance) wsHandleData(respRaw []byte) error {
 
 				switch streamType[1] {
 				case "trade":
-					if !b.IsSaveTradeDataEnabled() {
+					if !b.IsTradeProcessingRequired() {
 						return nil
 					}
 					var t TradeStream
@@ -421,8 +441,14 @@ func stringToOrderStatus(status string) (order.Status, error) {
 	switch status {
 	case "NEW":
 		return order.New, nil
-	case "CANCELLED":
+	case "PARTIALLY_FILLED":
+		return order.PartiallyFilled, nil
+	case "FILLED":
+		return order.Filled, nil
+	case "CANCELED", "CANCELLED":
 		return order.Cancelled, nil
+	case "PENDING_CANCEL":
+		return order.PendingCancel, nil
 	case "REJECTED":
 		return order.Rejected, nil
 	case "TRADE":
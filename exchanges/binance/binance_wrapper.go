@@ -149,6 +149,7 @@ func (b *Binance) SetDefaults() {
 				GetOrders:              true,
 				Subscribe:              true,
 				Unsubscribe:            true,
+				OrderUpdates:           true,
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCrypto |
 				exchange.NoFiatWithdrawals,
@@ -429,7 +430,7 @@ func (b *Binance) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 			if err != nil {
 				return nil, err
 			}
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = b.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         tick[y].LastPrice,
 				High:         tick[y].HighPrice,
 				Low:          tick[y].LowPrice,
@@ -458,7 +459,7 @@ func (b *Binance) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 			if err != nil {
 				return nil, err
 			}
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = b.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         tick[y].LastPrice,
 				High:         tick[y].HighPrice,
 				Low:          tick[y].LowPrice,
@@ -485,7 +486,7 @@ func (b *Binance) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 			if err != nil {
 				return nil, err
 			}
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = b.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         tick[y].LastPrice,
 				High:         tick[y].HighPrice,
 				Low:          tick[y].LowPrice,
@@ -504,7 +505,7 @@ func (b *Binance) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 	default:
 		return nil, fmt.Errorf("assetType not supported: %v", assetType)
 	}
-	return ticker.GetTicker(b.Name, p, assetType)
+	return b.GetTickerService().GetTicker(b.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -514,7 +515,7 @@ func (b *Binance) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 		return nil, err
 	}
 
-	tickerNew, err := ticker.GetTicker(b.Name, fPair, assetType)
+	tickerNew, err := b.GetTickerService().GetTicker(b.Name, fPair, assetType)
 	if err != nil {
 		return b.UpdateTicker(p, assetType)
 	}
@@ -523,7 +524,7 @@ func (b *Binance) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (b *Binance) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(b.Name, p, assetType)
+	ob, err := b.GetOrderbookService().Retrieve(b.Name, p, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(p, assetType)
 	}
@@ -566,11 +567,11 @@ func (b *Binance) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*order
 		})
 	}
 
-	err = book.Process()
+	err = b.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(b.Name, p, assetType)
+	return b.GetOrderbookService().Retrieve(b.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -645,7 +646,7 @@ func (b *Binance) UpdateAccountInfo(assetType asset.Item) (account.Holdings, err
 	}
 	acc.AssetType = assetType
 	info.Accounts = append(info.Accounts, acc)
-	err := account.Process(&info)
+	err := b.GetAccountService().Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -654,7 +655,7 @@ func (b *Binance) UpdateAccountInfo(assetType asset.Item) (account.Holdings, err
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Binance) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name, assetType)
+	acc, err := b.GetAccountService().GetHoldings(b.Name, assetType)
 	if err != nil {
 		return b.UpdateAccountInfo(assetType)
 	}
@@ -710,11 +711,9 @@ func (b *Binance) GetRecentTrades(p currency.Pair, assetType asset.Item) ([]trad
 			Timestamp:    tradeData[i].Time,
 		})
 	}
-	if b.IsSaveTradeDataEnabled() {
-		err := trade.AddTradesToBuffer(b.Name, resp...)
-		if err != nil {
-			return nil, err
-		}
+	err = b.AddTradesToBuffer(resp...)
+	if err != nil {
+		return nil, err
 	}
 
 	sort.Sort(trade.ByDate(resp))
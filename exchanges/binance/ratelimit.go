@@ -101,8 +101,26 @@ type RateLimit struct {
 
 // Limit executes rate limiting functionality for Binance
 func (r *RateLimit) Limit(f request.EndpointLimit) error {
-	var limiter *rate.Limiter
-	var tokens int
+	limiter, tokens := r.limiterTokens(f)
+	var finalDelay time.Duration
+	for i := 0; i < tokens; i++ {
+		// Consume tokens 1 at a time as this avoids needing burst capacity in the limiter,
+		// which would otherwise allow the rate limit to be exceeded over short periods
+		finalDelay = limiter.Reserve().Delay()
+	}
+	time.Sleep(finalDelay)
+	return nil
+}
+
+// LimiterFor returns the rate limiter an endpoint limit draws from
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
+	limiter, _ := r.limiterTokens(f)
+	return limiter
+}
+
+// limiterTokens returns the rate limiter an endpoint limit draws from and the
+// weight of a request to it
+func (r *RateLimit) limiterTokens(f request.EndpointLimit) (limiter *rate.Limiter, tokens int) {
 	switch f {
 	case spotDefaultRate:
 		limiter, tokens = r.SpotRate, 1
@@ -204,15 +222,7 @@ func (r *RateLimit) Limit(f request.EndpointLimit) error {
 	default:
 		limiter, tokens = r.SpotRate, 1
 	}
-
-	var finalDelay time.Duration
-	for i := 0; i < tokens; i++ {
-		// Consume tokens 1 at a time as this avoids needing burst capacity in the limiter,
-		// which would otherwise allow the rate limit to be exceeded over short periods
-		finalDelay = limiter.Reserve().Delay()
-	}
-	time.Sleep(finalDelay)
-	return nil
+	return limiter, tokens
 }
 
 // SetRateLimit returns the rate limit for the exchange
@@ -1388,6 +1388,20 @@ func (b *Bitfinex) SendHTTPRequest(ep exchange.URL, path string, result interfac
 }
 
 // SendAuthenticatedHTTPRequest sends an autheticated http request and json
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	bitfinexOrderNew,
+	bitfinexOrderNewMulti,
+	bitfinexOrderCancel,
+	bitfinexOrderCancelMulti,
+	bitfinexOrderCancelAll,
+	bitfinexOrderCancelReplace,
+	bitfinexOfferNew,
+	bitfinexOfferCancel,
+	bitfinexMarginClose,
+}
+
 // unmarshals result to a supplied variable
 func (b *Bitfinex) SendAuthenticatedHTTPRequest(ep exchange.URL, method, path string, params map[string]interface{}, result interface{}, endpoint request.EndpointLimit) error {
 	if !b.AllowAuthenticatedRequest() {
@@ -1433,6 +1447,7 @@ func (b *Bitfinex) SendAuthenticatedHTTPRequest(ep exchange.URL, method, path st
 		Headers:       headers,
 		Result:        result,
 		AuthRequest:   true,
+		Priority:      request.PriorityByEndpoint(path, orderEndpoints...),
 		NonceEnabled:  true,
 		Verbose:       b.Verbose,
 		HTTPDebugging: b.HTTPDebugging,
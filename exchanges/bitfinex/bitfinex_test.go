@@ -1292,6 +1292,20 @@ func TestWsNotifications(t *testing.T) {
 	}
 }
 
+func TestWsTradeExecution(t *testing.T) {
+	pressXToJSON := `[0,"te",[402088407,"tETHUST",1574963975602,34663658,-0.005,157.44,"EXCHANGE LIMIT",157.44,-1,null,null,1574963975602]]`
+	err := b.wsHandleData([]byte(pressXToJSON))
+	if err != nil {
+		t.Error(err)
+	}
+
+	pressXToJSON = `[0,"tu",[402088407,"tETHUST",1574963975602,34663658,-0.005,157.44,"EXCHANGE LIMIT",157.44,-1,-0.00157440,"UST",1574963975602]]`
+	err = b.wsHandleData([]byte(pressXToJSON))
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestGetHistoricCandles(t *testing.T) {
 	currencyPair, err := currency.NewPairFromString("BTCUSD")
 	if err != nil {
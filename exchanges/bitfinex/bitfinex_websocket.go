@@ -208,7 +208,8 @@ func (b *Bitfinex) wsHandleData(respRaw []byte) error {
 			if err != nil {
 				return err
 			}
-		case len(pairInfo) == 1:
+		case len(pairInfo) == 1 && pairInfo[0] != "":
+			// Authenticated channel 0 messages have no pair
 			newPair := pairInfo[0]
 			if newPair[0] == 'f' {
 				chanAsset = asset.MarginFunding
@@ -395,7 +396,7 @@ func (b *Bitfinex) wsHandleData(respRaw []byte) error {
 
 			return nil
 		case wsTrades:
-			if !b.IsSaveTradeDataEnabled() {
+			if !b.IsTradeProcessingRequired() {
 				return nil
 			}
 			if chanAsset == asset.MarginFunding {
@@ -569,8 +570,8 @@ func (b *Bitfinex) wsHandleData(respRaw []byte) error {
 					b.Websocket.DataHandler <- position
 				}
 			case wsTradeExecuted, wsTradeExecutionUpdate:
-				if tradeData, ok := d[2].([]interface{}); ok && len(tradeData) > 4 {
-					b.Websocket.DataHandler <- WebsocketTradeData{
+				if tradeData, ok := d[2].([]interface{}); ok && len(tradeData) > 10 {
+					td := WebsocketTradeData{
 						TradeID:        int64(tradeData[0].(float64)),
 						Pair:           tradeData[1].(string),
 						Timestamp:      int64(tradeData[2].(float64)),
@@ -580,8 +581,17 @@ func (b *Bitfinex) wsHandleData(respRaw []byte) error {
 						OrderType:      tradeData[6].(string),
 						OrderPrice:     tradeData[7].(float64),
 						Maker:          tradeData[8].(float64) == 1,
-						Fee:            tradeData[9].(float64),
-						FeeCurrency:    tradeData[10].(string),
+					}
+					// Fees are only populated on trade execution updates
+					if fee, ok := tradeData[9].(float64); ok {
+						td.Fee = fee
+					}
+					if feeCurrency, ok := tradeData[10].(string); ok {
+						td.FeeCurrency = feeCurrency
+					}
+					b.Websocket.DataHandler <- td
+					if d[1].(string) == wsTradeExecutionUpdate {
+						b.wsHandleFill(&td)
 					}
 				}
 			case wsFundingOrderSnapshot:
@@ -931,6 +941,45 @@ func (b *Bitfinex) wsHandleOrder(data []interface{}) {
 	b.Websocket.DataHandler <- &od
 }
 
+// wsHandleFill sends a trade execution update as an order fill. Only execution
+// updates are used as the preceding execution notice does not include fees.
+func (b *Bitfinex) wsHandleFill(td *WebsocketTradeData) {
+	orderID := strconv.FormatInt(td.OrderID, 10)
+	if len(td.Pair) < 2 {
+		b.Websocket.DataHandler <- order.ClassificationError{
+			Exchange: b.Name,
+			OrderID:  orderID,
+			Err:      fmt.Errorf("invalid trade pair %q", td.Pair),
+		}
+		return
+	}
+	p, a, err := b.GetRequestFormattedPairAndAssetType(td.Pair[1:])
+	if err != nil {
+		b.Websocket.DataHandler <- err
+		return
+	}
+	side := order.Buy
+	amount := td.AmountExecuted
+	if amount < 0 {
+		side = order.Sell
+		amount *= -1
+	}
+	b.Websocket.DataHandler <- &order.Fill{
+		Price:     td.PriceExecuted,
+		Amount:    amount,
+		Fee:       -td.Fee, // Bitfinex reports fees paid as negative values
+		FeeAsset:  td.FeeCurrency,
+		Exchange:  b.Name,
+		ID:        strconv.FormatInt(td.TradeID, 10),
+		OrderID:   orderID,
+		IsMaker:   td.Maker,
+		Side:      side,
+		AssetType: a,
+		Pair:      p,
+		Timestamp: time.Unix(0, td.Timestamp*int64(time.Millisecond)),
+	}
+}
+
 // WsInsertSnapshot add the initial orderbook snapshot when subscribed to a
 // channel
 func (b *Bitfinex) WsInsertSnapshot(p currency.Pair, assetType asset.Item, books []WebsocketBook, fundingRate bool) error {
@@ -129,6 +129,7 @@ func (b *Bitfinex) SetDefaults() {
 				DeadMansSwitch:         true,
 				GetOrders:              true,
 				GetOrder:               true,
+				OrderUpdates:           true,
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCryptoWithAPIPermission |
 				exchange.AutoWithdrawFiatWithAPIPermission,
@@ -345,7 +346,7 @@ func (b *Bitfinex) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 			continue
 		}
 
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = b.GetTickerService().ProcessTicker(&ticker.Price{
 			Last:         v.Last,
 			High:         v.High,
 			Low:          v.Low,
@@ -359,7 +360,7 @@ func (b *Bitfinex) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 			return nil, err
 		}
 	}
-	return ticker.GetTicker(b.Name, p, assetType)
+	return b.GetTickerService().GetTicker(b.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -370,7 +371,7 @@ func (b *Bitfinex) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 	}
 
 	b.appendOptionalDelimiter(&fPair)
-	tick, err := ticker.GetTicker(b.Name, fPair, asset.Spot)
+	tick, err := b.GetTickerService().GetTicker(b.Name, fPair, asset.Spot)
 	if err != nil {
 		return b.UpdateTicker(fPair, assetType)
 	}
@@ -385,7 +386,7 @@ func (b *Bitfinex) FetchOrderbook(p currency.Pair, assetType asset.Item) (*order
 	}
 
 	b.appendOptionalDelimiter(&fPair)
-	ob, err := orderbook.Get(b.Name, fPair, assetType)
+	ob, err := b.GetOrderbookService().Retrieve(b.Name, fPair, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(fPair, assetType)
 	}
@@ -453,11 +454,11 @@ func (b *Bitfinex) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orde
 			})
 		}
 	}
-	err = o.Process()
+	err = b.GetOrderbookService().Process(o)
 	if err != nil {
 		return nil, err
 	}
-	return orderbook.Get(b.Name, fPair, assetType)
+	return b.GetOrderbookService().Retrieve(b.Name, fPair, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies on the
@@ -493,7 +494,7 @@ func (b *Bitfinex) UpdateAccountInfo(assetType asset.Item) (account.Holdings, er
 	}
 
 	response.Accounts = Accounts
-	err = account.Process(&response)
+	err = b.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -503,7 +504,7 @@ func (b *Bitfinex) UpdateAccountInfo(assetType asset.Item) (account.Holdings, er
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bitfinex) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name, assetType)
+	acc, err := b.GetAccountService().GetHoldings(b.Name, assetType)
 	if err != nil {
 		return b.UpdateAccountInfo(assetType)
 	}
@@ -255,155 +255,164 @@ type RateLimit struct {
 
 // Limit limits outbound requests
 func (r *RateLimit) Limit(f request.EndpointLimit) error {
+	limiter := r.LimiterFor(f)
+	if limiter == nil {
+		return errors.New("endpoint rate limit functionality not found")
+	}
+	time.Sleep(limiter.Reserve().Delay())
+	return nil
+}
+
+// LimiterFor returns the rate limiter an endpoint limit draws from
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
 	switch f {
 	case platformStatus:
-		time.Sleep(r.PlatformStatus.Reserve().Delay())
+		return r.PlatformStatus
 	case tickerBatch:
-		time.Sleep(r.TickerBatch.Reserve().Delay())
+		return r.TickerBatch
 	case tickerFunction:
-		time.Sleep(r.Ticker.Reserve().Delay())
+		return r.Ticker
 	case tradeRateLimit:
-		time.Sleep(r.Trade.Reserve().Delay())
+		return r.Trade
 	case orderbookFunction:
-		time.Sleep(r.Orderbook.Reserve().Delay())
+		return r.Orderbook
 	case stats:
-		time.Sleep(r.Stats.Reserve().Delay())
+		return r.Stats
 	case candle:
-		time.Sleep(r.Candle.Reserve().Delay())
+		return r.Candle
 	case configs:
-		time.Sleep(r.Configs.Reserve().Delay())
+		return r.Configs
 	case status:
-		time.Sleep(r.Stats.Reserve().Delay())
+		return r.Stats
 	case liquid:
-		time.Sleep(r.Liquid.Reserve().Delay())
+		return r.Liquid
 	case leaderBoard:
-		time.Sleep(r.LeaderBoard.Reserve().Delay())
+		return r.LeaderBoard
 	case marketAveragePrice:
-		time.Sleep(r.MarketAveragePrice.Reserve().Delay())
+		return r.MarketAveragePrice
 	case fx:
-		time.Sleep(r.Fx.Reserve().Delay())
+		return r.Fx
 	case accountWalletBalance:
-		time.Sleep(r.AccountWalletBalance.Reserve().Delay())
+		return r.AccountWalletBalance
 	case accountWalletHistory:
-		time.Sleep(r.AccountWalletHistory.Reserve().Delay())
+		return r.AccountWalletHistory
 	case retrieveOrder:
-		time.Sleep(r.RetrieveOrder.Reserve().Delay())
+		return r.RetrieveOrder
 	case submitOrder:
-		time.Sleep(r.SubmitOrder.Reserve().Delay())
+		return r.SubmitOrder
 	case updateOrder:
-		time.Sleep(r.UpdateOrder.Reserve().Delay())
+		return r.UpdateOrder
 	case cancelOrder:
-		time.Sleep(r.CancelOrder.Reserve().Delay())
+		return r.CancelOrder
 	case orderBatch:
-		time.Sleep(r.OrderBatch.Reserve().Delay())
+		return r.OrderBatch
 	case cancelBatch:
-		time.Sleep(r.CancelBatch.Reserve().Delay())
+		return r.CancelBatch
 	case orderHistory:
-		time.Sleep(r.OrderHistory.Reserve().Delay())
+		return r.OrderHistory
 	case getOrderTrades:
-		time.Sleep(r.GetOrderTrades.Reserve().Delay())
+		return r.GetOrderTrades
 	case getTrades:
-		time.Sleep(r.GetTrades.Reserve().Delay())
+		return r.GetTrades
 	case getLedgers:
-		time.Sleep(r.GetLedgers.Reserve().Delay())
+		return r.GetLedgers
 	case getAccountMarginInfo:
-		time.Sleep(r.GetAccountMarginInfo.Reserve().Delay())
+		return r.GetAccountMarginInfo
 	case getActivePositions:
-		time.Sleep(r.GetActivePositions.Reserve().Delay())
+		return r.GetActivePositions
 	case claimPosition:
-		time.Sleep(r.ClaimPosition.Reserve().Delay())
+		return r.ClaimPosition
 	case getPositionHistory:
-		time.Sleep(r.GetPositionHistory.Reserve().Delay())
+		return r.GetPositionHistory
 	case getPositionAudit:
-		time.Sleep(r.GetPositionAudit.Reserve().Delay())
+		return r.GetPositionAudit
 	case updateCollateralOnPosition:
-		time.Sleep(r.UpdateCollateralOnPosition.Reserve().Delay())
+		return r.UpdateCollateralOnPosition
 	case getActiveFundingOffers:
-		time.Sleep(r.GetActiveFundingOffers.Reserve().Delay())
+		return r.GetActiveFundingOffers
 	case submitFundingOffer:
-		time.Sleep(r.SubmitFundingOffer.Reserve().Delay())
+		return r.SubmitFundingOffer
 	case cancelFundingOffer:
-		time.Sleep(r.CancelFundingOffer.Reserve().Delay())
+		return r.CancelFundingOffer
 	case cancelAllFundingOffer:
-		time.Sleep(r.CancelAllFundingOffer.Reserve().Delay())
+		return r.CancelAllFundingOffer
 	case closeFunding:
-		time.Sleep(r.CloseFunding.Reserve().Delay())
+		return r.CloseFunding
 	case fundingAutoRenew:
-		time.Sleep(r.FundingAutoRenew.Reserve().Delay())
+		return r.FundingAutoRenew
 	case keepFunding:
-		time.Sleep(r.KeepFunding.Reserve().Delay())
+		return r.KeepFunding
 	case getOffersHistory:
-		time.Sleep(r.GetOffersHistory.Reserve().Delay())
+		return r.GetOffersHistory
 	case getFundingLoans:
-		time.Sleep(r.GetFundingLoans.Reserve().Delay())
+		return r.GetFundingLoans
 	case getFundingLoanHistory:
-		time.Sleep(r.GetFundingLoanHistory.Reserve().Delay())
+		return r.GetFundingLoanHistory
 	case getFundingCredits:
-		time.Sleep(r.GetFundingCredits.Reserve().Delay())
+		return r.GetFundingCredits
 	case getFundingCreditsHistory:
-		time.Sleep(r.GetFundingCreditsHistory.Reserve().Delay())
+		return r.GetFundingCreditsHistory
 	case getFundingTrades:
-		time.Sleep(r.GetFundingTrades.Reserve().Delay())
+		return r.GetFundingTrades
 	case getFundingInfo:
-		time.Sleep(r.GetFundingInfo.Reserve().Delay())
+		return r.GetFundingInfo
 	case getUserInfo:
-		time.Sleep(r.GetUserInfo.Reserve().Delay())
+		return r.GetUserInfo
 	case transferBetweenWallets:
-		time.Sleep(r.TransferBetweenWallets.Reserve().Delay())
+		return r.TransferBetweenWallets
 	case getDepositAddress:
-		time.Sleep(r.GetDepositAddress.Reserve().Delay())
+		return r.GetDepositAddress
 	case withdrawal:
-		time.Sleep(r.Withdrawal.Reserve().Delay())
+		return r.Withdrawal
 	case getMovements:
-		time.Sleep(r.GetMovements.Reserve().Delay())
+		return r.GetMovements
 	case getAlertList:
-		time.Sleep(r.GetAlertList.Reserve().Delay())
+		return r.GetAlertList
 	case setPriceAlert:
-		time.Sleep(r.SetPriceAlert.Reserve().Delay())
+		return r.SetPriceAlert
 	case deletePriceAlert:
-		time.Sleep(r.DeletePriceAlert.Reserve().Delay())
+		return r.DeletePriceAlert
 	case getBalanceForOrdersOffers:
-		time.Sleep(r.GetBalanceForOrdersOffers.Reserve().Delay())
+		return r.GetBalanceForOrdersOffers
 	case userSettingsWrite:
-		time.Sleep(r.UserSettingsWrite.Reserve().Delay())
+		return r.UserSettingsWrite
 	case userSettingsRead:
-		time.Sleep(r.UserSettingsRead.Reserve().Delay())
+		return r.UserSettingsRead
 	case userSettingsDelete:
-		time.Sleep(r.UserSettingsDelete.Reserve().Delay())
+		return r.UserSettingsDelete
 
 		//  Bitfinex V1 API
 	case getAccountFees:
-		time.Sleep(r.GetAccountFees.Reserve().Delay())
+		return r.GetAccountFees
 	case getWithdrawalFees:
-		time.Sleep(r.GetWithdrawalFees.Reserve().Delay())
+		return r.GetWithdrawalFees
 	case getAccountSummary:
-		time.Sleep(r.GetAccountSummary.Reserve().Delay())
+		return r.GetAccountSummary
 	case newDepositAddress:
-		time.Sleep(r.NewDepositAddress.Reserve().Delay())
+		return r.NewDepositAddress
 	case getKeyPermissions:
-		time.Sleep(r.GetKeyPermissions.Reserve().Delay())
+		return r.GetKeyPermissions
 	case getMarginInfo:
-		time.Sleep(r.GetMarginInfo.Reserve().Delay())
+		return r.GetMarginInfo
 	case getAccountBalance:
-		time.Sleep(r.GetAccountBalance.Reserve().Delay())
+		return r.GetAccountBalance
 	case walletTransfer:
-		time.Sleep(r.WalletTransfer.Reserve().Delay())
+		return r.WalletTransfer
 	case withdrawV1:
-		time.Sleep(r.WithdrawV1.Reserve().Delay())
+		return r.WithdrawV1
 	case orderV1:
-		time.Sleep(r.OrderV1.Reserve().Delay())
+		return r.OrderV1
 	case orderMulti:
-		time.Sleep(r.OrderMulti.Reserve().Delay())
+		return r.OrderMulti
 	case statsV1:
-		time.Sleep(r.Stats.Reserve().Delay())
+		return r.Stats
 	case fundingbook:
-		time.Sleep(r.Fundingbook.Reserve().Delay())
+		return r.Fundingbook
 	case lends:
-		time.Sleep(r.Lends.Reserve().Delay())
+		return r.Lends
 	default:
-		return errors.New("endpoint rate limit functionality not found")
+		return nil
 	}
-	return nil
 }
 
 // SetRateLimit returns the rate limit for the exchange
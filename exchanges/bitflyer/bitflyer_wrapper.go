@@ -199,7 +199,7 @@ func (b *Bitflyer) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 		return nil, err
 	}
 
-	err = ticker.ProcessTicker(&ticker.Price{
+	err = b.GetTickerService().ProcessTicker(&ticker.Price{
 		Pair:         fPair,
 		Ask:          tickerNew.BestAsk,
 		Bid:          tickerNew.BestBid,
@@ -211,7 +211,7 @@ func (b *Bitflyer) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 		return nil, err
 	}
 
-	return ticker.GetTicker(b.Name, fPair, assetType)
+	return b.GetTickerService().GetTicker(b.Name, fPair, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -221,7 +221,7 @@ func (b *Bitflyer) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 		return nil, err
 	}
 
-	tick, err := ticker.GetTicker(b.Name, fPair, assetType)
+	tick, err := b.GetTickerService().GetTicker(b.Name, fPair, assetType)
 	if err != nil {
 		return b.UpdateTicker(fPair, assetType)
 	}
@@ -244,7 +244,7 @@ func (b *Bitflyer) FetchOrderbook(p currency.Pair, assetType asset.Item) (*order
 		return nil, err
 	}
 
-	ob, err := orderbook.Get(b.Name, fPair, assetType)
+	ob, err := b.GetOrderbookService().Retrieve(b.Name, fPair, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(fPair, assetType)
 	}
@@ -282,12 +282,12 @@ func (b *Bitflyer) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orde
 			Amount: orderbookNew.Bids[x].Size})
 	}
 
-	err = book.Process()
+	err = b.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
 
-	return orderbook.Get(b.Name, fPair, assetType)
+	return b.GetOrderbookService().Retrieve(b.Name, fPair, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies on the
@@ -298,7 +298,7 @@ func (b *Bitflyer) UpdateAccountInfo(assetType asset.Item) (account.Holdings, er
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bitflyer) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name, assetType)
+	acc, err := b.GetAccountService().GetHoldings(b.Name, assetType)
 	if err != nil {
 		return b.UpdateAccountInfo(assetType)
 	}
@@ -49,6 +49,17 @@ func (r *RateLimit) Limit(f request.EndpointLimit) error {
 	return nil
 }
 
+// LimiterFor returns the rate limiter an endpoint limit draws from, order
+// limits are also drawn from the private API limit so share its budget
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
+	switch f {
+	case request.Auth, orders, lowVolume:
+		return r.Auth
+	default:
+		return r.UnAuth
+	}
+}
+
 // SetRateLimit returns the rate limit for the exchange
 func SetRateLimit() *RateLimit {
 	return &RateLimit{
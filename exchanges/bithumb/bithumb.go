@@ -465,6 +465,15 @@ func (b *Bithumb) SendHTTPRequest(ep exchange.URL, path string, result interface
 	})
 }
 
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	privatePlaceTrade,
+	privateCancelTrade,
+	privateMarketBuy,
+	privateMarketSell,
+}
+
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to bithumb
 func (b *Bithumb) SendAuthenticatedHTTPRequest(ep exchange.URL, path string, params url.Values, result interface{}) error {
 	if !b.AllowAuthenticatedRequest() {
@@ -508,6 +517,7 @@ func (b *Bithumb) SendAuthenticatedHTTPRequest(ep exchange.URL, path string, par
 		Body:          bytes.NewBufferString(payload),
 		Result:        &intermediary,
 		AuthRequest:   true,
+		Priority:      request.PriorityByEndpoint(path, orderEndpoints...),
 		NonceEnabled:  true,
 		Verbose:       b.Verbose,
 		HTTPDebugging: b.HTTPDebugging,
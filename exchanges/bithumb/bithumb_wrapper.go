@@ -208,7 +208,7 @@ func (b *Bithumb) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 				fmt.Errorf("enabled pair %s [%s] not found in returned ticker map %v",
 					pairs[i], pairs, tickers)
 		}
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = b.GetTickerService().ProcessTicker(&ticker.Price{
 			High:         t.MaxPrice,
 			Low:          t.MinPrice,
 			Volume:       t.UnitsTraded24Hr,
@@ -222,12 +222,12 @@ func (b *Bithumb) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 			return nil, err
 		}
 	}
-	return ticker.GetTicker(b.Name, p, assetType)
+	return b.GetTickerService().GetTicker(b.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (b *Bithumb) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(b.Name, p, assetType)
+	tickerNew, err := b.GetTickerService().GetTicker(b.Name, p, assetType)
 	if err != nil {
 		return b.UpdateTicker(p, assetType)
 	}
@@ -236,7 +236,7 @@ func (b *Bithumb) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (b *Bithumb) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(b.Name, p, assetType)
+	ob, err := b.GetOrderbookService().Retrieve(b.Name, p, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(p, assetType)
 	}
@@ -274,11 +274,11 @@ func (b *Bithumb) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*order
 			})
 	}
 
-	err = book.Process()
+	err = b.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(b.Name, p, assetType)
+	return b.GetOrderbookService().Retrieve(b.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -310,7 +310,7 @@ func (b *Bithumb) UpdateAccountInfo(assetType asset.Item) (account.Holdings, err
 	})
 
 	info.Exchange = b.Name
-	err = account.Process(&info)
+	err = b.GetAccountService().Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -320,7 +320,7 @@ func (b *Bithumb) UpdateAccountInfo(assetType asset.Item) (account.Holdings, err
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bithumb) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name, assetType)
+	acc, err := b.GetAccountService().GetHoldings(b.Name, assetType)
 	if err != nil {
 		return b.UpdateAccountInfo(assetType)
 	}
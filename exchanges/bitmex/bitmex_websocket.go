@@ -206,7 +206,7 @@ func (b *Bitmex) wsHandleData(respRaw []byte) error {
 			}
 
 		case bitmexWSTrade:
-			if !b.IsSaveTradeDataEnabled() {
+			if !b.IsTradeProcessingRequired() {
 				return nil
 			}
 			var tradeHolder TradeData
@@ -301,7 +301,7 @@ func (b *Bitmex) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 			continue
 		}
 
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = b.GetTickerService().ProcessTicker(&ticker.Price{
 			Last:         tick[j].LastPrice,
 			High:         tick[j].HighPrice,
 			Low:          tick[j].LowPrice,
@@ -317,7 +317,7 @@ func (b *Bitmex) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 			return nil, err
 		}
 	}
-	return ticker.GetTicker(b.Name, fPair, assetType)
+	return b.GetTickerService().GetTicker(b.Name, fPair, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -327,7 +327,7 @@ func (b *Bitmex) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 		return nil, err
 	}
 
-	tickerNew, err := ticker.GetTicker(b.Name, fPair, assetType)
+	tickerNew, err := b.GetTickerService().GetTicker(b.Name, fPair, assetType)
 	if err != nil {
 		return b.UpdateTicker(fPair, assetType)
 	}
@@ -341,7 +341,7 @@ func (b *Bitmex) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbo
 		return nil, err
 	}
 
-	ob, err := orderbook.Get(b.Name, fPair, assetType)
+	ob, err := b.GetOrderbookService().Retrieve(b.Name, fPair, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(fPair, assetType)
 	}
@@ -391,11 +391,11 @@ func (b *Bitmex) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderb
 	}
 	book.Asks.Reverse() // Reverse order of asks to ascending
 
-	err = book.Process()
+	err = b.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(b.Name, p, assetType)
+	return b.GetOrderbookService().Retrieve(b.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -422,7 +422,7 @@ func (b *Bitmex) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 		Currencies: balances,
 	})
 
-	err = account.Process(&info)
+	err = b.GetAccountService().Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -432,7 +432,7 @@ func (b *Bitmex) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bitmex) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name, assetType)
+	acc, err := b.GetAccountService().GetHoldings(b.Name, assetType)
 	if err != nil {
 		return b.UpdateAccountInfo(assetType)
 	}
@@ -22,12 +22,16 @@ type RateLimit struct {
 
 // Limit limits outbound calls
 func (r *RateLimit) Limit(f request.EndpointLimit) error {
+	time.Sleep(r.LimiterFor(f).Reserve().Delay())
+	return nil
+}
+
+// LimiterFor returns the rate limiter an endpoint limit draws from
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
 	if f == request.Auth {
-		time.Sleep(r.Auth.Reserve().Delay())
-		return nil
+		return r.Auth
 	}
-	time.Sleep(r.UnAuth.Reserve().Delay())
-	return nil
+	return r.UnAuth
 }
 
 // SetRateLimit returns the rate limit for the exchange
@@ -608,6 +608,15 @@ func (b *Bitstamp) SendHTTPRequest(ep exchange.URL, path string, result interfac
 	})
 }
 
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	order.Buy.Lower() + "/",
+	order.Sell.Lower() + "/",
+	bitstampAPICancelOrder,
+	bitstampAPICancelAllOrders,
+}
+
 // SendAuthenticatedHTTPRequest sends an authenticated request
 func (b *Bitstamp) SendAuthenticatedHTTPRequest(ep exchange.URL, path string, v2 bool, values url.Values, result interface{}) error {
 	if !b.AllowAuthenticatedRequest() {
@@ -630,6 +639,7 @@ func (b *Bitstamp) SendAuthenticatedHTTPRequest(ep exchange.URL, path string, v2
 		[]byte(b.API.Credentials.Secret))
 	values.Set("signature", strings.ToUpper(crypto.HexEncodeToString(hmac)))
 
+	priority := request.PriorityByEndpoint(path, orderEndpoints...)
 	if v2 {
 		path = endpoint + "/v" + bitstampAPIVersion + "/" + path + "/"
 	} else {
@@ -661,6 +671,7 @@ func (b *Bitstamp) SendAuthenticatedHTTPRequest(ep exchange.URL, path string, v2
 		Body:          readerValues,
 		Result:        &interim,
 		AuthRequest:   true,
+		Priority:      priority,
 		NonceEnabled:  true,
 		Verbose:       b.Verbose,
 		HTTPDebugging: b.HTTPDebugging,
@@ -99,7 +99,7 @@ func (b *Bitstamp) wsHandleData(respRaw []byte) error {
 			return err
 		}
 	case "trade":
-		if !b.IsSaveTradeDataEnabled() {
+		if !b.IsTradeProcessingRequired() {
 			return nil
 		}
 		wsTradeTemp := websocketTradeResponse{}
@@ -122,7 +122,7 @@ func (b *Bitstamp) wsHandleData(respRaw []byte) error {
 		if err != nil {
 			return err
 		}
-		return trade.AddTradesToBuffer(b.Name, trade.Data{
+		return b.AddTradesToBuffer(trade.Data{
 			Timestamp:    time.Unix(wsTradeTemp.Data.Timestamp, 0),
 			CurrencyPair: p,
 			AssetType:    a,
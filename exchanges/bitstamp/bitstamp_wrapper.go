@@ -265,7 +265,7 @@ func (b *Bitstamp) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 		return nil, err
 	}
 
-	err = ticker.ProcessTicker(&ticker.Price{
+	err = b.GetTickerService().ProcessTicker(&ticker.Price{
 		Last:         tick.Last,
 		High:         tick.High,
 		Low:          tick.Low,
@@ -281,7 +281,7 @@ func (b *Bitstamp) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 		return nil, err
 	}
 
-	return ticker.GetTicker(b.Name, fPair, assetType)
+	return b.GetTickerService().GetTicker(b.Name, fPair, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -291,7 +291,7 @@ func (b *Bitstamp) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 		return nil, err
 	}
 
-	tick, err := ticker.GetTicker(b.Name, fPair, assetType)
+	tick, err := b.GetTickerService().GetTicker(b.Name, fPair, assetType)
 	if err != nil {
 		return b.UpdateTicker(fPair, assetType)
 	}
@@ -314,7 +314,7 @@ func (b *Bitstamp) FetchOrderbook(p currency.Pair, assetType asset.Item) (*order
 		return nil, err
 	}
 
-	ob, err := orderbook.Get(b.Name, fPair, assetType)
+	ob, err := b.GetOrderbookService().Retrieve(b.Name, fPair, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(fPair, assetType)
 	}
@@ -352,11 +352,11 @@ func (b *Bitstamp) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orde
 			Price:  orderbookNew.Asks[x].Price,
 		})
 	}
-	err = book.Process()
+	err = b.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(b.Name, fPair, assetType)
+	return b.GetOrderbookService().Retrieve(b.Name, fPair, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -381,7 +381,7 @@ func (b *Bitstamp) UpdateAccountInfo(assetType asset.Item) (account.Holdings, er
 		Currencies: currencies,
 	})
 
-	err = account.Process(&response)
+	err = b.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -391,7 +391,7 @@ func (b *Bitstamp) UpdateAccountInfo(assetType asset.Item) (account.Holdings, er
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bitstamp) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name, assetType)
+	acc, err := b.GetAccountService().GetHoldings(b.Name, assetType)
 	if err != nil {
 		return b.UpdateAccountInfo(assetType)
 	}
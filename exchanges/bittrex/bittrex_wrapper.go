@@ -249,7 +249,7 @@ func (b *Bittrex) UpdateAccountInfo(assetType asset.Item) (account.Holdings, err
 		Currencies: currencies,
 	})
 
-	err = account.Process(&response)
+	err = b.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -259,7 +259,7 @@ func (b *Bittrex) UpdateAccountInfo(assetType asset.Item) (account.Holdings, err
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *Bittrex) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name, assetType)
+	acc, err := b.GetAccountService().GetHoldings(b.Name, assetType)
 	if err != nil {
 		return b.UpdateAccountInfo(assetType)
 	}
@@ -292,7 +292,7 @@ func (b *Bittrex) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 			return nil, err
 		}
 
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = b.GetTickerService().ProcessTicker(&ticker.Price{
 			Last:         ticks.Result[j].Last,
 			High:         ticks.Result[j].High,
 			Low:          ticks.Result[j].Low,
@@ -309,12 +309,12 @@ func (b *Bittrex) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 			return nil, err
 		}
 	}
-	return ticker.GetTicker(b.Name, p, assetType)
+	return b.GetTickerService().GetTicker(b.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (b *Bittrex) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tick, err := ticker.GetTicker(b.Name, p, assetType)
+	tick, err := b.GetTickerService().GetTicker(b.Name, p, assetType)
 	if err != nil {
 		return b.UpdateTicker(p, assetType)
 	}
@@ -323,7 +323,7 @@ func (b *Bittrex) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 
 // FetchOrderbook returns the orderbook for a currency pair
 func (b *Bittrex) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(b.Name, p, assetType)
+	ob, err := b.GetOrderbookService().Retrieve(b.Name, p, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(p, assetType)
 	}
@@ -365,11 +365,11 @@ func (b *Bittrex) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*order
 			},
 		)
 	}
-	err = book.Process()
+	err = b.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(b.Name, p, assetType)
+	return b.GetOrderbookService().Retrieve(b.Name, p, assetType)
 }
 
 // GetFundingHistory returns funding history, deposits and
@@ -141,7 +141,7 @@ func (b *BTCMarkets) wsHandleData(respRaw []byte) error {
 			return err
 		}
 	case tradeEndPoint:
-		if !b.IsSaveTradeDataEnabled() {
+		if !b.IsTradeProcessingRequired() {
 			return nil
 		}
 		var t WsTrade
@@ -160,7 +160,7 @@ func (b *BTCMarkets) wsHandleData(respRaw []byte) error {
 			side = order.Sell
 		}
 
-		return trade.AddTradesToBuffer(b.Name, trade.Data{
+		return b.AddTradesToBuffer(trade.Data{
 			Timestamp:    t.Timestamp,
 			CurrencyPair: p,
 			AssetType:    asset.Spot,
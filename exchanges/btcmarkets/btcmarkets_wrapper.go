@@ -310,7 +310,7 @@ func (b *BTCMarkets) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticke
 			return nil, err
 		}
 
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = b.GetTickerService().ProcessTicker(&ticker.Price{
 			Pair:         newP,
 			Last:         tickers[x].LastPrice,
 			High:         tickers[x].High24h,
@@ -326,7 +326,7 @@ func (b *BTCMarkets) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticke
 			return nil, err
 		}
 	}
-	return ticker.GetTicker(b.Name, p, assetType)
+	return b.GetTickerService().GetTicker(b.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -336,7 +336,7 @@ func (b *BTCMarkets) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker
 		return nil, err
 	}
 
-	tickerNew, err := ticker.GetTicker(b.Name, fPair, assetType)
+	tickerNew, err := b.GetTickerService().GetTicker(b.Name, fPair, assetType)
 	if err != nil {
 		return b.UpdateTicker(p, assetType)
 	}
@@ -350,7 +350,7 @@ func (b *BTCMarkets) FetchOrderbook(p currency.Pair, assetType asset.Item) (*ord
 		return nil, err
 	}
 
-	ob, err := orderbook.Get(b.Name, fPair, assetType)
+	ob, err := b.GetOrderbookService().Retrieve(b.Name, fPair, assetType)
 	if err != nil {
 		return b.UpdateOrderbook(p, assetType)
 	}
@@ -387,11 +387,11 @@ func (b *BTCMarkets) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*or
 			Amount: tempResp.Asks[y].Volume,
 			Price:  tempResp.Asks[y].Price})
 	}
-	err = book.Process()
+	err = b.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(b.Name, p, assetType)
+	return b.GetOrderbookService().Retrieve(b.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies
@@ -414,7 +414,7 @@ func (b *BTCMarkets) UpdateAccountInfo(assetType asset.Item) (account.Holdings,
 	resp.Accounts = append(resp.Accounts, acc)
 	resp.Exchange = b.Name
 
-	err = account.Process(&resp)
+	err = b.GetAccountService().Process(&resp)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -424,7 +424,7 @@ func (b *BTCMarkets) UpdateAccountInfo(assetType asset.Item) (account.Holdings,
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (b *BTCMarkets) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(b.Name, assetType)
+	acc, err := b.GetAccountService().GetHoldings(b.Name, assetType)
 	if err != nil {
 		return b.UpdateAccountInfo(assetType)
 	}
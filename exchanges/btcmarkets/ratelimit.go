@@ -36,21 +36,26 @@ type RateLimit struct {
 
 // Limit limits the outbound requests
 func (r *RateLimit) Limit(f request.EndpointLimit) error {
+	time.Sleep(r.LimiterFor(f).Reserve().Delay())
+	return nil
+}
+
+// LimiterFor returns the rate limiter an endpoint limit draws from
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
 	switch f {
 	case request.Auth:
-		time.Sleep(r.Auth.Reserve().Delay())
+		return r.Auth
 	case orderFunc:
-		time.Sleep(r.OrderPlacement.Reserve().Delay())
+		return r.OrderPlacement
 	case batchFunc:
-		time.Sleep(r.BatchOrders.Reserve().Delay())
+		return r.BatchOrders
 	case withdrawFunc:
-		time.Sleep(r.WithdrawRequest.Reserve().Delay())
+		return r.WithdrawRequest
 	case newReportFunc:
-		time.Sleep(r.CreateNewReport.Reserve().Delay())
+		return r.CreateNewReport
 	default:
-		time.Sleep(r.UnAuth.Reserve().Delay())
+		return r.UnAuth
 	}
-	return nil
 }
 
 // SetRateLimit returns the rate limit for the exchange
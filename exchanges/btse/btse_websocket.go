@@ -215,7 +215,7 @@ func (b *BTSE) wsHandleData(respRaw []byte) error {
 			}
 		}
 	case strings.Contains(topic, "tradeHistory"):
-		if !b.IsSaveTradeDataEnabled() {
+		if !b.IsTradeProcessingRequired() {
 			return nil
 		}
 		var tradeHistory wsTradeHistory
@@ -254,7 +254,7 @@ func (b *BTSE) wsHandleData(respRaw []byte) error {
 				TID:          strconv.FormatInt(tradeHistory.Data[x].ID, 10),
 			})
 		}
-		return trade.AddTradesToBuffer(b.Name, trades...)
+		return b.AddTradesToBuffer(trades...)
 	case strings.Contains(topic, "orderBookL2Api"):
 		var t wsOrderBook
 		err = json.Unmarshal(respRaw, &t)
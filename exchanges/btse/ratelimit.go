@@ -24,13 +24,18 @@ type RateLimit struct {
 
 // Limit executes rate limiting functionality for exchange
 func (r *RateLimit) Limit(f request.EndpointLimit) error {
+	time.Sleep(r.LimiterFor(f).Reserve().Delay())
+	return nil
+}
+
+// LimiterFor returns the rate limiter an endpoint limit draws from
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
 	switch f {
 	case orderFunc:
-		time.Sleep(r.Orders.Reserve().Delay())
+		return r.Orders
 	default:
-		time.Sleep(r.Query.Reserve().Delay())
+		return r.Query
 	}
-	return nil
 }
 
 // SetRateLimit returns the rate limit for the exchange
@@ -390,6 +390,7 @@ type wsOrderReceived struct {
 	StopType      string    `json:"stop_type"`
 	StopPrice     float64   `json:"stop_price,string"`
 	TakerFeeRate  float64   `json:"taker_fee_rate,string"`
+	MakerFeeRate  float64   `json:"maker_fee_rate,string"`
 	Private       bool      `json:"private"`
 	TradeID       int64     `json:"trade_id"`
 	MakerOrderID  string    `json:"maker_order_id"`
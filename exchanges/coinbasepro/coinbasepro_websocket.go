@@ -216,27 +216,40 @@ func (c *CoinbasePro) wsHandleData(respRaw []byte) error {
 		}
 
 		if wsOrder.UserID != "" {
-			c.Websocket.DataHandler <- &order.Detail{
-				ID:        wsOrder.OrderID,
-				Pair:      p,
+			// Match sides are reported from the maker's perspective
+			isMaker := wsOrder.TakerUserID == ""
+			orderID, feeRate := wsOrder.MakerOrderID, wsOrder.MakerFeeRate
+			if !isMaker {
+				orderID, feeRate = wsOrder.TakerOrderID, wsOrder.TakerFeeRate
+				switch oSide {
+				case order.Buy:
+					oSide = order.Sell
+				case order.Sell:
+					oSide = order.Buy
+				}
+			}
+			if orderID == "" {
+				orderID = wsOrder.OrderID
+			}
+			c.Websocket.DataHandler <- &order.Fill{
+				Price:     wsOrder.Price,
+				Amount:    wsOrder.Size,
+				Fee:       wsOrder.Price * wsOrder.Size * feeRate,
+				FeeAsset:  p.Quote.String(),
+				Exchange:  c.Name,
+				ID:        strconv.FormatInt(wsOrder.TradeID, 10),
+				OrderID:   orderID,
+				IsMaker:   isMaker,
+				Side:      oSide,
 				AssetType: a,
-				Trades: []order.TradeHistory{
-					{
-						Price:     wsOrder.Price,
-						Amount:    wsOrder.Size,
-						Exchange:  c.Name,
-						TID:       strconv.FormatInt(wsOrder.TradeID, 10),
-						Side:      oSide,
-						Timestamp: wsOrder.Time,
-						IsMaker:   wsOrder.TakerUserID == "",
-					},
-				},
+				Pair:      p,
+				Timestamp: wsOrder.Time,
 			}
 		} else {
-			if !c.IsSaveTradeDataEnabled() {
+			if !c.IsTradeProcessingRequired() {
 				return nil
 			}
-			return trade.AddTradesToBuffer(c.Name, trade.Data{
+			return c.AddTradesToBuffer(trade.Data{
 				Timestamp:    wsOrder.Time,
 				Exchange:     c.Name,
 				CurrencyPair: p,
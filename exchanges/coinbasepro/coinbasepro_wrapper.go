@@ -104,6 +104,7 @@ func (c *CoinbasePro) SetDefaults() {
 				MessageSequenceNumbers: true,
 				GetOrders:              true,
 				GetOrder:               true,
+				OrderUpdates:           true,
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCryptoWithAPIPermission |
 				exchange.AutoWithdrawFiatWithAPIPermission,
@@ -335,7 +336,7 @@ func (c *CoinbasePro) UpdateAccountInfo(assetType asset.Item) (account.Holdings,
 		Currencies: currencies,
 	})
 
-	err = account.Process(&response)
+	err = c.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -345,7 +346,7 @@ func (c *CoinbasePro) UpdateAccountInfo(assetType asset.Item) (account.Holdings,
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (c *CoinbasePro) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(c.Name, assetType)
+	acc, err := c.GetAccountService().GetHoldings(c.Name, assetType)
 	if err != nil {
 		return c.UpdateAccountInfo(assetType)
 	}
@@ -382,17 +383,17 @@ func (c *CoinbasePro) UpdateTicker(p currency.Pair, assetType asset.Item) (*tick
 		ExchangeName: c.Name,
 		AssetType:    assetType}
 
-	err = ticker.ProcessTicker(tickerPrice)
+	err = c.GetTickerService().ProcessTicker(tickerPrice)
 	if err != nil {
 		return tickerPrice, err
 	}
 
-	return ticker.GetTicker(c.Name, p, assetType)
+	return c.GetTickerService().GetTicker(c.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (c *CoinbasePro) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(c.Name, p, assetType)
+	tickerNew, err := c.GetTickerService().GetTicker(c.Name, p, assetType)
 	if err != nil {
 		return c.UpdateTicker(p, assetType)
 	}
@@ -401,7 +402,7 @@ func (c *CoinbasePro) FetchTicker(p currency.Pair, assetType asset.Item) (*ticke
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (c *CoinbasePro) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(c.Name, p, assetType)
+	ob, err := c.GetOrderbookService().Retrieve(c.Name, p, assetType)
 	if err != nil {
 		return c.UpdateOrderbook(p, assetType)
 	}
@@ -438,11 +439,11 @@ func (c *CoinbasePro) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*o
 			Amount: obNew.Asks[x].Amount,
 			Price:  obNew.Asks[x].Price})
 	}
-	err = book.Process()
+	err = c.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(c.Name, p, assetType)
+	return c.GetOrderbookService().Retrieve(c.Name, p, assetType)
 }
 
 // GetFundingHistory returns funding history, deposits and
@@ -184,7 +184,7 @@ func (c *Coinbene) wsHandleData(respRaw []byte) error {
 			}
 		}
 	case strings.Contains(result[topic].(string), "tradeList"):
-		if !c.IsSaveTradeDataEnabled() {
+		if !c.IsTradeProcessingRequired() {
 			return nil
 		}
 		var tradeList WsTradeList
@@ -225,7 +225,7 @@ func (c *Coinbene) wsHandleData(respRaw []byte) error {
 				Side:         tSide,
 			})
 		}
-		return trade.AddTradesToBuffer(c.Name, trades...)
+		return c.AddTradesToBuffer(trades...)
 	case strings.Contains(result[topic].(string), "orderBook"):
 		var orderBook WsOrderbookData
 		err = json.Unmarshal(respRaw, &orderBook)
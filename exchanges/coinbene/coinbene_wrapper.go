@@ -327,7 +327,7 @@ func (c *Coinbene) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = c.GetTickerService().ProcessTicker(&ticker.Price{
 				Pair:         newP,
 				Last:         tickers[i].LatestPrice,
 				High:         tickers[i].DailyHigh,
@@ -361,7 +361,7 @@ func (c *Coinbene) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = c.GetTickerService().ProcessTicker(&ticker.Price{
 				Pair:         allPairs[x],
 				Last:         tick.LastPrice,
 				High:         tick.High24Hour,
@@ -377,7 +377,7 @@ func (c *Coinbene) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.
 			}
 		}
 	}
-	return ticker.GetTicker(c.Name, p, assetType)
+	return c.GetTickerService().GetTicker(c.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -387,7 +387,7 @@ func (c *Coinbene) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 			fmt.Errorf("%s does not support asset type %s", c.Name, assetType)
 	}
 
-	tickerNew, err := ticker.GetTicker(c.Name, p, assetType)
+	tickerNew, err := c.GetTickerService().GetTicker(c.Name, p, assetType)
 	if err != nil {
 		return c.UpdateTicker(p, assetType)
 	}
@@ -401,7 +401,7 @@ func (c *Coinbene) FetchOrderbook(p currency.Pair, assetType asset.Item) (*order
 			fmt.Errorf("%s does not support asset type %s", c.Name, assetType)
 	}
 
-	ob, err := orderbook.Get(c.Name, p, assetType)
+	ob, err := c.GetOrderbookService().Retrieve(c.Name, p, assetType)
 	if err != nil {
 		return c.UpdateOrderbook(p, assetType)
 	}
@@ -460,11 +460,11 @@ func (c *Coinbene) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orde
 		}
 		book.Bids = append(book.Bids, item)
 	}
-	err = book.Process()
+	err = c.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(c.Name, p, assetType)
+	return c.GetOrderbookService().Retrieve(c.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -490,7 +490,7 @@ func (c *Coinbene) UpdateAccountInfo(assetType asset.Item) (account.Holdings, er
 	info.Accounts = append(info.Accounts, acc)
 	info.Exchange = c.Name
 
-	err = account.Process(&info)
+	err = c.GetAccountService().Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -500,7 +500,7 @@ func (c *Coinbene) UpdateAccountInfo(assetType asset.Item) (account.Holdings, er
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (c *Coinbene) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(c.Name, assetType)
+	acc, err := c.GetAccountService().GetHoldings(c.Name, assetType)
 	if err != nil {
 		return c.UpdateAccountInfo(assetType)
 	}
@@ -130,81 +130,90 @@ type RateLimit struct {
 
 // Limit limits outbound requests
 func (r *RateLimit) Limit(f request.EndpointLimit) error {
+	limiter := r.LimiterFor(f)
+	if limiter == nil {
+		return errors.New("rate limit error endpoint functionality not set")
+	}
+	time.Sleep(limiter.Reserve().Delay())
+	return nil
+}
+
+// LimiterFor returns the rate limiter an endpoint limit draws from
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
 	switch f {
 	case contractOrderbook:
-		time.Sleep(r.ContractOrderbook.Reserve().Delay())
+		return r.ContractOrderbook
 	case contractTickers:
-		time.Sleep(r.ContractTickers.Reserve().Delay())
+		return r.ContractTickers
 	case contractKline:
-		time.Sleep(r.ContractKline.Reserve().Delay())
+		return r.ContractKline
 	case contractTrades:
-		time.Sleep(r.ContractTrades.Reserve().Delay())
+		return r.ContractTrades
 	case contractInstruments:
-		time.Sleep(r.ContractInstruments.Reserve().Delay())
+		return r.ContractInstruments
 	case contractAccountInfo:
-		time.Sleep(r.ContractAccountInfo.Reserve().Delay())
+		return r.ContractAccountInfo
 	case contractPositionInfo:
-		time.Sleep(r.ContractPositionInfo.Reserve().Delay())
+		return r.ContractPositionInfo
 	case contractPlaceOrder:
-		time.Sleep(r.ContractPlaceOrder.Reserve().Delay())
+		return r.ContractPlaceOrder
 	case contractCancelOrder:
-		time.Sleep(r.ContractCancelOrder.Reserve().Delay())
+		return r.ContractCancelOrder
 	case contractGetOpenOrders:
-		time.Sleep(r.ContractGetOpenOrders.Reserve().Delay())
+		return r.ContractGetOpenOrders
 	case contractOpenOrdersByPage:
-		time.Sleep(r.ContractOpenOrdersByPage.Reserve().Delay())
+		return r.ContractOpenOrdersByPage
 	case contractGetOrderInfo:
-		time.Sleep(r.ContractGetOrderInfo.Reserve().Delay())
+		return r.ContractGetOrderInfo
 	case contractGetClosedOrders:
-		time.Sleep(r.ContractGetClosedOrders.Reserve().Delay())
+		return r.ContractGetClosedOrders
 	case contractGetClosedOrdersbyPage:
-		time.Sleep(r.ContractGetClosedOrdersbyPage.Reserve().Delay())
+		return r.ContractGetClosedOrdersbyPage
 	case contractCancelMultipleOrders:
-		time.Sleep(r.ContractCancelMultipleOrders.Reserve().Delay())
+		return r.ContractCancelMultipleOrders
 	case contractGetOrderFills:
-		time.Sleep(r.ContractGetOrderFills.Reserve().Delay())
+		return r.ContractGetOrderFills
 	case contractGetFundingRates:
-		time.Sleep(r.ContractGetFundingRates.Reserve().Delay())
+		return r.ContractGetFundingRates
 	case spotPairs:
-		time.Sleep(r.SpotPairs.Reserve().Delay())
+		return r.SpotPairs
 	case spotPairInfo:
-		time.Sleep(r.SpotPairInfo.Reserve().Delay())
+		return r.SpotPairInfo
 	case spotOrderbook:
-		time.Sleep(r.SpotOrderbook.Reserve().Delay())
+		return r.SpotOrderbook
 	case spotTickerList:
-		time.Sleep(r.SpotTickerList.Reserve().Delay())
+		return r.SpotTickerList
 	case spotSpecificTicker:
-		time.Sleep(r.SpotSpecificTicker.Reserve().Delay())
+		return r.SpotSpecificTicker
 	case spotMarketTrades:
-		time.Sleep(r.SpotMarketTrades.Reserve().Delay())
+		return r.SpotMarketTrades
 	// case spotKline: // Not implemented yet
-	// 	time.Sleep(r.SpotKline.Reserve().Delay())
+	// 	return r.SpotKline
 	// case spotExchangeRate:
-	// 	time.Sleep(r.SpotExchangeRate.Reserve().Delay())
+	// 	return r.SpotExchangeRate
 	case spotAccountInfo:
-		time.Sleep(r.SpotAccountInfo.Reserve().Delay())
+		return r.SpotAccountInfo
 	case spotAccountAssetInfo:
-		time.Sleep(r.SpotAccountAssetInfo.Reserve().Delay())
+		return r.SpotAccountAssetInfo
 	case spotPlaceOrder:
-		time.Sleep(r.SpotPlaceOrder.Reserve().Delay())
+		return r.SpotPlaceOrder
 	case spotBatchOrder:
-		time.Sleep(r.SpotBatchOrder.Reserve().Delay())
+		return r.SpotBatchOrder
 	case spotQueryOpenOrders:
-		time.Sleep(r.SpotQueryOpenOrders.Reserve().Delay())
+		return r.SpotQueryOpenOrders
 	case spotQueryClosedOrders:
-		time.Sleep(r.SpotQueryClosedOrders.Reserve().Delay())
+		return r.SpotQueryClosedOrders
 	case spotQuerySpecficOrder:
-		time.Sleep(r.SpotQuerySpecficOrder.Reserve().Delay())
+		return r.SpotQuerySpecficOrder
 	case spotQueryTradeFills:
-		time.Sleep(r.SpotQueryTradeFills.Reserve().Delay())
+		return r.SpotQueryTradeFills
 	case spotCancelOrder:
-		time.Sleep(r.SpotCancelOrder.Reserve().Delay())
+		return r.SpotCancelOrder
 	case spotCancelOrdersBatch:
-		time.Sleep(r.SpotCancelOrdersBatch.Reserve().Delay())
+		return r.SpotCancelOrdersBatch
 	default:
-		return errors.New("rate limit error endpoint functionality not set")
+		return nil
 	}
-	return nil
 }
 
 // SetRateLimit returns the rate limit for the exchange
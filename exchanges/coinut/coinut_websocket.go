@@ -280,7 +280,7 @@ func (c *COINUT) wsHandleData(respRaw []byte) error {
 			return err
 		}
 	case "inst_trade":
-		if !c.IsSaveTradeDataEnabled() {
+		if !c.IsTradeProcessingRequired() {
 			return nil
 		}
 		var tradeSnap WsTradeSnapshot
@@ -321,9 +321,9 @@ func (c *COINUT) wsHandleData(respRaw []byte) error {
 				TID:          strconv.FormatInt(tradeSnap.Trades[i].TransID, 10),
 			})
 		}
-		return trade.AddTradesToBuffer(c.Name, trades...)
+		return c.AddTradesToBuffer(trades...)
 	case "inst_trade_update":
-		if !c.IsSaveTradeDataEnabled() {
+		if !c.IsTradeProcessingRequired() {
 			return nil
 		}
 		var tradeUpdate WsTradeUpdate
@@ -352,7 +352,7 @@ func (c *COINUT) wsHandleData(respRaw []byte) error {
 			}
 		}
 
-		return trade.AddTradesToBuffer(c.Name, trade.Data{
+		return c.AddTradesToBuffer(trade.Data{
 			Timestamp:    time.Unix(0, tradeUpdate.Timestamp*1000),
 			CurrencyPair: p,
 			AssetType:    asset.Spot,
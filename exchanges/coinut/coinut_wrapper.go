@@ -384,7 +384,7 @@ func (c *COINUT) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 		Currencies: balances,
 	})
 
-	err = account.Process(&info)
+	err = c.GetAccountService().Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -394,7 +394,7 @@ func (c *COINUT) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (c *COINUT) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(c.Name, assetType)
+	acc, err := c.GetAccountService().GetHoldings(c.Name, assetType)
 	if err != nil {
 		return c.UpdateAccountInfo(assetType)
 	}
@@ -424,7 +424,7 @@ func (c *COINUT) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 		return nil, err
 	}
 
-	err = ticker.ProcessTicker(&ticker.Price{
+	err = c.GetTickerService().ProcessTicker(&ticker.Price{
 		Last:         tick.Last,
 		High:         tick.High24,
 		Low:          tick.Low24,
@@ -439,12 +439,12 @@ func (c *COINUT) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 		return nil, err
 	}
 
-	return ticker.GetTicker(c.Name, p, assetType)
+	return c.GetTickerService().GetTicker(c.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (c *COINUT) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(c.Name, p, assetType)
+	tickerNew, err := c.GetTickerService().GetTicker(c.Name, p, assetType)
 	if err != nil {
 		return c.UpdateTicker(p, assetType)
 	}
@@ -453,7 +453,7 @@ func (c *COINUT) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (c *COINUT) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(c.Name, p, assetType)
+	ob, err := c.GetOrderbookService().Retrieve(c.Name, p, assetType)
 	if err != nil {
 		return c.UpdateOrderbook(p, assetType)
 	}
@@ -499,11 +499,11 @@ func (c *COINUT) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderb
 			Amount: orderbookNew.Sell[x].Quantity,
 			Price:  orderbookNew.Sell[x].Price})
 	}
-	err = book.Process()
+	err = c.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(c.Name, p, assetType)
+	return c.GetOrderbookService().Retrieve(c.Name, p, assetType)
 }
 
 // GetFundingHistory returns funding history, deposits and
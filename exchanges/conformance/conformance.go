@@ -0,0 +1,535 @@
+package conformance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/mock"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// capability defines whether an exchange declares support for a check
+type capability uint8
+
+const (
+	capabilityUnknown capability = iota
+	capabilityDeclared
+	capabilityUndeclared
+)
+
+// limitProbes are price and amount pairs used to compare
+// GetOrderExecutionLimits against CheckOrderExecutionLimits
+var limitProbes = [][2]float64{
+	{0.00000001, 0.00000001},
+	{1, 1},
+	{100000000, 100000000},
+	{1, 0.00000001},
+	{0.00000001, 100000000},
+}
+
+// runner holds state for a single exchange conformance run
+type runner struct {
+	exch     Exchange
+	opts     *Options
+	features exchange.FeaturesSupported
+	skip     map[string]bool
+}
+
+// Run executes every conformance check against the exchange for each asset
+// and pair selected by the options and returns a report of the outcomes.
+// Exchanges should be set up before being passed in, either against live
+// endpoints or a VCR mock server via UseMockServer
+func Run(exch Exchange, opts *Options) (*Report, error) {
+	if exch == nil {
+		return nil, errExchangeIsNil
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	o := *opts
+	err := o.setDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	r := runner{
+		exch:     exch,
+		opts:     &o,
+		features: exch.GetBase().GetSupportedFeatures(),
+		skip:     make(map[string]bool),
+	}
+	for x := range o.Skip {
+		r.skip[o.Skip[x]] = true
+	}
+
+	report := &Report{
+		Exchange: exch.GetName(),
+		Started:  time.Now(),
+	}
+	assets := o.Assets
+	if len(assets) == 0 {
+		assets = exch.GetAssetTypes()
+	}
+	for x := range assets {
+		report.Results = append(report.Results, r.runAsset(assets[x])...)
+	}
+	report.Duration = time.Since(report.Started)
+	return report, nil
+}
+
+// setDefaults fills in unset options
+func (o *Options) setDefaults() error {
+	now := time.Now().UTC()
+	if o.KlineInterval == 0 {
+		o.KlineInterval = kline.OneHour
+	}
+	if o.KlineEnd.IsZero() {
+		o.KlineEnd = now.Truncate(o.KlineInterval.Duration())
+	}
+	if o.KlineStart.IsZero() {
+		o.KlineStart = o.KlineEnd.Add(-24 * time.Hour)
+	}
+	if !o.KlineStart.Before(o.KlineEnd) {
+		return errInvalidKlineRange
+	}
+	if o.HistoricTradesEnd.IsZero() {
+		o.HistoricTradesEnd = now
+	}
+	if o.HistoricTradesStart.IsZero() {
+		o.HistoricTradesStart = o.HistoricTradesEnd.Add(-time.Hour)
+	}
+	return nil
+}
+
+// runAsset runs the asset level checks and then every pair level check for
+// the selected pairs
+func (r *runner) runAsset(a asset.Item) []Result {
+	var results []Result
+	results = append(results, r.run(CheckTradablePairs, a, currency.Pair{}, r.checkTradablePairs))
+	if r.authenticated() {
+		results = append(results, r.run(CheckAccountInfo, a, currency.Pair{}, r.checkAccountInfo))
+	}
+
+	pairs, err := r.pairs(a)
+	if err != nil {
+		return append(results, Result{
+			Asset:  a,
+			Status: StatusSkipped,
+			Detail: err.Error(),
+		})
+	}
+	for x := range pairs {
+		results = append(results,
+			r.run(CheckTicker, a, pairs[x], r.checkTicker),
+			r.run(CheckOrderbook, a, pairs[x], r.checkOrderbook),
+			r.run(CheckRecentTrades, a, pairs[x], r.checkRecentTrades),
+			r.run(CheckHistoricTrades, a, pairs[x], r.checkHistoricTrades),
+			r.run(CheckKlines, a, pairs[x], r.checkKlines),
+			r.run(CheckExecutionLimits, a, pairs[x], r.checkExecutionLimits))
+		if r.authenticated() {
+			results = append(results, r.run(CheckActiveOrders, a, pairs[x], r.checkActiveOrders))
+		}
+	}
+	return results
+}
+
+// pairs returns the pairs to test for an asset
+func (r *runner) pairs(a asset.Item) (currency.Pairs, error) {
+	if p, ok := r.opts.Pairs[a]; ok {
+		if len(p) == 0 {
+			return nil, errNoPairsToTest
+		}
+		return p, nil
+	}
+	enabled, err := r.exch.GetEnabledPairs(a)
+	if err != nil {
+		return nil, err
+	}
+	if len(enabled) == 0 {
+		return nil, errNoPairsToTest
+	}
+	return enabled[:1], nil
+}
+
+func (r *runner) authenticated() bool {
+	return r.opts.Authenticated && r.exch.GetBase().AllowAuthenticatedRequest()
+}
+
+// run executes a check unless it has been skipped
+func (r *runner) run(check string, a asset.Item, p currency.Pair, fn func(*Result)) Result {
+	res := Result{Check: check, Asset: a, Pair: p}
+	if r.skip[check] {
+		res.Status = StatusSkipped
+		return res
+	}
+	fn(&res)
+	return res
+}
+
+// handled classifies a wrapper error against the declared capability. It
+// returns true when the result is final and no invariants should be checked
+func handled(res *Result, c capability, err error) bool {
+	if err == nil {
+		res.Status = StatusPass
+		if c == capabilityUndeclared {
+			res.Detail = "wrapper succeeded but capability is not declared"
+		}
+		return false
+	}
+	unsupported := errors.Is(err, common.ErrFunctionNotSupported) ||
+		errors.Is(err, common.ErrNotYetImplemented)
+	switch {
+	case unsupported && c == capabilityDeclared:
+		res.Status = StatusFail
+		res.Detail = "capability is declared but wrapper returned: " + err.Error()
+	case unsupported:
+		res.Status = StatusUnsupported
+		res.Detail = err.Error()
+	case c == capabilityUndeclared:
+		res.Status = StatusFail
+		res.Detail = fmt.Sprintf("capability is not declared, expected %q or %q but wrapper returned: %v",
+			common.ErrFunctionNotSupported,
+			common.ErrNotYetImplemented,
+			err)
+	default:
+		res.Status = StatusError
+		res.Detail = err.Error()
+	}
+	return true
+}
+
+// violated marks the result as failed when invariants are broken
+func violated(res *Result, violations []string) {
+	if len(violations) == 0 {
+		return
+	}
+	res.Status = StatusFail
+	res.Detail = strings.Join(violations, "; ")
+}
+
+func declared(supported bool) capability {
+	if supported {
+		return capabilityDeclared
+	}
+	return capabilityUndeclared
+}
+
+func (r *runner) checkTradablePairs(res *Result) {
+	pairs, err := r.exch.FetchTradablePairs(res.Asset)
+	if handled(res, declared(r.features.REST), err) {
+		return
+	}
+	if len(pairs) == 0 {
+		violated(res, []string{"no tradable pairs returned"})
+	}
+}
+
+func (r *runner) checkTicker(res *Result) {
+	rest := r.features.RESTCapabilities
+	tick, err := r.exch.UpdateTicker(res.Pair, res.Asset)
+	if handled(res, declared(rest.TickerFetching || rest.TickerBatching), err) {
+		return
+	}
+	if tick == nil {
+		violated(res, []string{"nil ticker returned without error"})
+		return
+	}
+	var v []string
+	if !tick.Pair.Equal(res.Pair) {
+		v = append(v, fmt.Sprintf("ticker pair %s does not match requested pair %s", tick.Pair, res.Pair))
+	}
+	if tick.Bid > 0 && tick.Ask > 0 && tick.Bid > tick.Ask {
+		v = append(v, fmt.Sprintf("bid %v exceeds ask %v", tick.Bid, tick.Ask))
+	}
+	if tick.High > 0 && tick.Low > 0 && tick.Low > tick.High {
+		v = append(v, fmt.Sprintf("low %v exceeds high %v", tick.Low, tick.High))
+	}
+	if tick.Last < 0 || tick.Bid < 0 || tick.Ask < 0 || tick.Volume < 0 {
+		v = append(v, "negative ticker values returned")
+	}
+	violated(res, v)
+}
+
+func (r *runner) checkOrderbook(res *Result) {
+	ob, err := r.exch.UpdateOrderbook(res.Pair, res.Asset)
+	if handled(res, declared(r.features.RESTCapabilities.OrderbookFetching), err) {
+		return
+	}
+	if ob == nil {
+		violated(res, []string{"nil orderbook returned without error"})
+		return
+	}
+	var v []string
+	if !ob.Pair.Equal(res.Pair) {
+		v = append(v, fmt.Sprintf("orderbook pair %s does not match requested pair %s", ob.Pair, res.Pair))
+	}
+	if len(ob.Bids) == 0 && len(ob.Asks) == 0 {
+		v = append(v, "orderbook has no bids or asks")
+	}
+	// Verification can be toggled off by user config, always run it here
+	book := *ob
+	book.VerifyOrderbook = true
+	if err = book.Verify(); err != nil {
+		v = append(v, err.Error())
+	}
+	if !ob.IsFundingRate &&
+		len(ob.Bids) > 0 &&
+		len(ob.Asks) > 0 &&
+		ob.Bids[0].Price >= ob.Asks[0].Price {
+		v = append(v, fmt.Sprintf("orderbook is crossed, best bid %v best ask %v",
+			ob.Bids[0].Price,
+			ob.Asks[0].Price))
+	}
+	violated(res, v)
+}
+
+func (r *runner) checkRecentTrades(res *Result) {
+	trades, err := r.exch.GetRecentTrades(res.Pair, res.Asset)
+	if handled(res, declared(r.features.RESTCapabilities.TradeFetching), err) {
+		return
+	}
+	for x := range trades {
+		v := tradeViolations(res.Pair, trades[x].CurrencyPair, trades[x].Price, trades[x].Amount, trades[x].Timestamp)
+		if len(v) > 0 {
+			violated(res, prefix(fmt.Sprintf("trade %d", x), v))
+			return
+		}
+	}
+}
+
+func (r *runner) checkHistoricTrades(res *Result) {
+	start, end := r.opts.HistoricTradesStart, r.opts.HistoricTradesEnd
+	trades, err := r.exch.GetHistoricTrades(res.Pair, res.Asset, start, end)
+	if handled(res, capabilityUnknown, err) {
+		return
+	}
+	for x := range trades {
+		v := tradeViolations(res.Pair, trades[x].CurrencyPair, trades[x].Price, trades[x].Amount, trades[x].Timestamp)
+		if trades[x].Timestamp.Before(start) || trades[x].Timestamp.After(end) {
+			v = append(v, fmt.Sprintf("timestamp %v outside requested range %v - %v",
+				trades[x].Timestamp,
+				start,
+				end))
+		}
+		if len(v) > 0 {
+			violated(res, prefix(fmt.Sprintf("trade %d", x), v))
+			return
+		}
+	}
+}
+
+func tradeViolations(want, got currency.Pair, price, amount float64, ts time.Time) []string {
+	var v []string
+	if !got.Equal(want) {
+		v = append(v, fmt.Sprintf("pair %s does not match requested pair %s", got, want))
+	}
+	if price <= 0 {
+		v = append(v, fmt.Sprintf("invalid price %v", price))
+	}
+	if amount == 0 {
+		v = append(v, "zero amount")
+	}
+	if ts.IsZero() {
+		v = append(v, "missing timestamp")
+	}
+	return v
+}
+
+func (r *runner) checkKlines(res *Result) {
+	start, end := r.opts.KlineStart, r.opts.KlineEnd
+	c := declared(r.features.Kline.Intervals || r.features.RESTCapabilities.KlineFetching)
+	item, err := r.exch.GetHistoricCandles(res.Pair, res.Asset, start, end, r.opts.KlineInterval)
+	if handled(res, c, err) {
+		return
+	}
+	var v []string
+	if !item.Pair.Equal(res.Pair) {
+		v = append(v, fmt.Sprintf("kline pair %s does not match requested pair %s", item.Pair, res.Pair))
+	}
+	if item.Interval != r.opts.KlineInterval {
+		v = append(v, fmt.Sprintf("kline interval %s does not match requested interval %s",
+			item.Interval,
+			r.opts.KlineInterval))
+	}
+	for x := range item.Candles {
+		candle := &item.Candles[x]
+		if candle.Time.Before(start) || candle.Time.After(end) {
+			v = append(v, fmt.Sprintf("candle %d time %v outside requested range %v - %v",
+				x,
+				candle.Time,
+				start,
+				end))
+			break
+		}
+		if x > 0 && !candle.Time.After(item.Candles[x-1].Time) {
+			v = append(v, fmt.Sprintf("candle %d time %v is not after previous candle", x, candle.Time))
+			break
+		}
+		if candle.Low > candle.High ||
+			candle.Open > candle.High ||
+			candle.Close > candle.High ||
+			candle.Open < candle.Low ||
+			candle.Close < candle.Low {
+			v = append(v, fmt.Sprintf("candle %d OHLC values are inconsistent", x))
+			break
+		}
+	}
+	violated(res, v)
+}
+
+func (r *runner) checkExecutionLimits(res *Result) {
+	err := r.exch.UpdateOrderExecutionLimits(res.Asset)
+	if handled(res, capabilityUnknown, err) {
+		return
+	}
+	limits, err := r.exch.GetOrderExecutionLimits(res.Asset, res.Pair)
+	if err != nil {
+		violated(res, []string{"limits updated but could not be retrieved: " + err.Error()})
+		return
+	}
+	var v []string
+	for x := range limitProbes {
+		for _, t := range []order.Type{order.Limit, order.Market} {
+			price, amount := limitProbes[x][0], limitProbes[x][1]
+			want := limits.Conforms(price, amount, t)
+			got := r.exch.CheckOrderExecutionLimits(res.Asset, res.Pair, price, amount, t)
+			if (want == nil) != (got == nil) ||
+				(want != nil && !errors.Is(got, errors.Unwrap(want))) {
+				v = append(v, fmt.Sprintf("%s price %v amount %v: limits conform returned %v, check returned %v",
+					t,
+					price,
+					amount,
+					want,
+					got))
+			}
+		}
+	}
+	violated(res, v)
+}
+
+func (r *runner) checkAccountInfo(res *Result) {
+	rest := r.features.RESTCapabilities
+	h, err := r.exch.UpdateAccountInfo(res.Asset)
+	if handled(res, declared(rest.AccountInfo || rest.AccountBalance), err) {
+		return
+	}
+	for x := range h.Accounts {
+		for y := range h.Accounts[x].Currencies {
+			b := &h.Accounts[x].Currencies[y]
+			if b.TotalValue < 0 || b.Hold < 0 {
+				violated(res, []string{fmt.Sprintf("account %q currency %s has negative balance",
+					h.Accounts[x].ID,
+					b.CurrencyName)})
+				return
+			}
+		}
+	}
+}
+
+func (r *runner) checkActiveOrders(res *Result) {
+	orders, err := r.exch.GetActiveOrders(&order.GetOrdersRequest{
+		Type:      order.AnyType,
+		Side:      order.AnySide,
+		Pairs:     currency.Pairs{res.Pair},
+		AssetType: res.Asset,
+	})
+	if handled(res, declared(r.features.RESTCapabilities.GetOrders), err) {
+		return
+	}
+	for x := range orders {
+		var v []string
+		if !orders[x].Pair.IsEmpty() && !orders[x].Pair.Equal(res.Pair) {
+			v = append(v, fmt.Sprintf("pair %s does not match requested pair %s", orders[x].Pair, res.Pair))
+		}
+		if orders[x].Amount > 0 && orders[x].ExecutedAmount > orders[x].Amount {
+			v = append(v, fmt.Sprintf("executed amount %v exceeds amount %v",
+				orders[x].ExecutedAmount,
+				orders[x].Amount))
+		}
+		if len(v) > 0 {
+			violated(res, prefix(fmt.Sprintf("order %q", orders[x].ID), v))
+			return
+		}
+	}
+}
+
+func prefix(p string, v []string) []string {
+	for x := range v {
+		v[x] = p + " " + v[x]
+	}
+	return v
+}
+
+// Failures returns every result that did not pass or was not cleanly
+// reported as unsupported
+func (r *Report) Failures() []Result {
+	var failed []Result
+	for x := range r.Results {
+		if r.Results[x].Status == StatusFail || r.Results[x].Status == StatusError {
+			failed = append(failed, r.Results[x])
+		}
+	}
+	return failed
+}
+
+// severity orders statuses so the worst outcome is kept per matrix cell
+var severity = map[Status]int{
+	StatusPass:        0,
+	StatusUnsupported: 1,
+	StatusSkipped:     2,
+	StatusError:       3,
+	StatusFail:        4,
+}
+
+// NewMatrix collapses reports into a compatibility matrix keeping the worst
+// status seen for each exchange and check
+func NewMatrix(reports ...*Report) Matrix {
+	m := make(Matrix)
+	for x := range reports {
+		if reports[x] == nil {
+			continue
+		}
+		row, ok := m[reports[x].Exchange]
+		if !ok {
+			row = make(map[string]Status)
+			m[reports[x].Exchange] = row
+		}
+		for y := range reports[x].Results {
+			res := &reports[x].Results[y]
+			if res.Check == "" {
+				continue
+			}
+			if current, ok := row[res.Check]; ok && severity[current] >= severity[res.Status] {
+				continue
+			}
+			row[res.Check] = res.Status
+		}
+	}
+	return m
+}
+
+// UseMockServer starts a VCR server from the supplied mock file and points
+// every exchange endpoint at it. Some recordings are stored with a route
+// prefix e.g. "/api" which is appended to the server URL
+func UseMockServer(b *exchange.Base, mockFile, routePrefix string) error {
+	serverURL, client, err := mock.NewVCRServer(mockFile)
+	if err != nil {
+		return err
+	}
+	b.SetHTTPClient(client)
+	endpoints := b.API.Endpoints.GetURLMap()
+	for k := range endpoints {
+		err = b.API.Endpoints.SetRunning(k, serverURL+routePrefix)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,544 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/bitstamp"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/gemini"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/generic"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/localbitcoins"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/poloniex"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/zb"
+)
+
+var updateMatrix = flag.Bool("update-matrix", false, "rewrites the wrapper compatibility matrix artifact")
+
+const (
+	mockFile   = "../../testdata/http_mock/generic/generic.json"
+	matrixFile = "../../testdata/conformance/matrix.json"
+	mockSpec   = `{
+	"name": "Mockgeneric",
+	"requestFormat": {"uppercase": true, "delimiter": "-"},
+	"configFormat": {"uppercase": true, "delimiter": "-"},
+	"orderSides": {"BUY": "bid", "SELL": "ask"},
+	"rest": {
+		"url": "http://localhost",
+		"endpoints": {
+			"pairs": {"path": "/symbols", "result": "data", "fields": {"symbol": "name"}},
+			"ticker": {
+				"path": "/ticker",
+				"query": {"symbol": "{symbol}"},
+				"result": "data",
+				"fields": {"last": "last", "high": "high", "low": "low", "bid": "bid", "ask": "ask", "volume": "vol", "timestamp": "ts"}
+			},
+			"orderbook": {"path": "/book/{symbol}", "fields": {"bids": "bids", "asks": "asks", "price": "0", "amount": "1"}},
+			"trades": {
+				"path": "/trades",
+				"query": {"symbol": "{symbol}", "limit": "{limit}"},
+				"limit": 2,
+				"fields": {"id": "id", "price": "price", "amount": "qty", "side": "side", "timestamp": "time"}
+			},
+			"candles": {
+				"path": "/candles/{symbol}",
+				"query": {"interval": "{interval}", "from": "{start}", "to": "{end}"},
+				"intervals": {"onehour": "1h"},
+				"fields": {"time": "0", "open": "1", "high": "2", "low": "3", "close": "4", "volume": "5"}
+			},
+			"account": {"path": "/balances", "authenticated": true, "fields": {"currency": "asset", "total": "total", "available": "free"}},
+			"activeOrders": {
+				"path": "/openOrders",
+				"query": {"symbol": "{symbol}"},
+				"authenticated": true,
+				"fields": {"id": "id", "symbol": "symbol", "side": "side", "price": "price", "amount": "qty", "executedAmount": "filled"}
+			}
+		}
+	},
+	"auth": {"scheme": "hmac-sha256", "payload": "{timestamp}{path}{query}", "keyName": "X-KEY", "signatureName": "X-SIGN", "timestampName": "X-TS"}
+}`
+)
+
+var (
+	btcusd   = currency.NewPair(currency.BTC, currency.USD)
+	mockOpts = Options{
+		Assets:        asset.Items{asset.Spot},
+		Pairs:         map[asset.Item]currency.Pairs{asset.Spot: {btcusd}},
+		KlineInterval: kline.OneHour,
+		KlineStart:    time.Unix(1609459200, 0),
+		KlineEnd:      time.Unix(1609470000, 0),
+		Authenticated: true,
+	}
+)
+
+// newMockExchange returns a generic exchange served from VCR mock data
+func newMockExchange(t *testing.T) *generic.Generic {
+	t.Helper()
+	s, err := generic.LoadSpec([]byte(mockSpec), "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := generic.New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Default config is built here as GetDefaultConfig fetches pairs from the
+	// spec URL before the mock server is in place
+	g.SetDefaults()
+	cfg := &config.ExchangeConfig{
+		Name:        g.Name,
+		Enabled:     true,
+		HTTPTimeout: exchange.DefaultHTTPTimeout,
+	}
+	err = g.SetupDefaults(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.API.AuthenticatedSupport = true
+	cfg.API.Credentials.Key = "key"
+	cfg.API.Credentials.Secret = "secret"
+	cfg.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{btcusd}, true)
+	err = cfg.CurrencyPairs.SetAssetEnabled(asset.Spot, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = g.Setup(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = UseMockServer(&g.Base, mockFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// brokenExchange wraps a working exchange and overrides selected wrapper
+// functions with non conforming behaviour
+type brokenExchange struct {
+	Exchange
+	tick       *ticker.Price
+	book       *orderbook.Base
+	candles    *kline.Item
+	tradesErr  error
+	limitCheck error
+}
+
+func (e *brokenExchange) UpdateTicker(p currency.Pair, a asset.Item) (*ticker.Price, error) {
+	if e.tick != nil {
+		return e.tick, nil
+	}
+	return e.Exchange.UpdateTicker(p, a)
+}
+
+func (e *brokenExchange) UpdateOrderbook(p currency.Pair, a asset.Item) (*orderbook.Base, error) {
+	if e.book != nil {
+		return e.book, nil
+	}
+	return e.Exchange.UpdateOrderbook(p, a)
+}
+
+func (e *brokenExchange) GetHistoricCandles(p currency.Pair, a asset.Item, start, end time.Time, i kline.Interval) (kline.Item, error) {
+	if e.candles != nil {
+		return *e.candles, nil
+	}
+	return e.Exchange.GetHistoricCandles(p, a, start, end, i)
+}
+
+func (e *brokenExchange) GetHistoricTrades(p currency.Pair, a asset.Item, start, end time.Time) ([]trade.Data, error) {
+	if e.tradesErr != nil {
+		return nil, e.tradesErr
+	}
+	return e.Exchange.GetHistoricTrades(p, a, start, end)
+}
+
+func (e *brokenExchange) UpdateOrderExecutionLimits(a asset.Item) error {
+	if e.limitCheck != nil {
+		return e.GetBase().LoadLimits([]order.MinMaxLevel{{
+			Pair:      btcusd,
+			Asset:     a,
+			MinAmount: 1,
+			MaxAmount: 1000,
+		}})
+	}
+	return e.Exchange.UpdateOrderExecutionLimits(a)
+}
+
+func (e *brokenExchange) CheckOrderExecutionLimits(a asset.Item, p currency.Pair, price, amount float64, t order.Type) error {
+	if e.limitCheck != nil {
+		return e.limitCheck
+	}
+	return e.Exchange.CheckOrderExecutionLimits(a, p, price, amount, t)
+}
+
+func resultFor(t *testing.T, r *Report, check string) Result {
+	t.Helper()
+	for x := range r.Results {
+		if r.Results[x].Check == check {
+			return r.Results[x]
+		}
+	}
+	t.Fatalf("no result for check %s", check)
+	return Result{}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	_, err := Run(nil, nil)
+	if !errors.Is(err, errExchangeIsNil) {
+		t.Fatalf("received: %v but expected: %v", err, errExchangeIsNil)
+	}
+
+	opts := mockOpts
+	opts.KlineStart, opts.KlineEnd = opts.KlineEnd, opts.KlineStart
+	_, err = Run(newMockExchange(t), &opts)
+	if !errors.Is(err, errInvalidKlineRange) {
+		t.Fatalf("received: %v but expected: %v", err, errInvalidKlineRange)
+	}
+}
+
+func TestRunMock(t *testing.T) {
+	t.Parallel()
+	g := newMockExchange(t)
+	r, err := Run(g, &mockOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed := r.Failures(); len(failed) > 0 {
+		t.Fatalf("unexpected failures: %+v", failed)
+	}
+
+	expected := map[string]Status{
+		CheckTradablePairs:   StatusPass,
+		CheckAccountInfo:     StatusPass,
+		CheckTicker:          StatusPass,
+		CheckOrderbook:       StatusPass,
+		CheckRecentTrades:    StatusPass,
+		CheckHistoricTrades:  StatusUnsupported,
+		CheckKlines:          StatusPass,
+		CheckExecutionLimits: StatusUnsupported,
+		CheckActiveOrders:    StatusPass,
+	}
+	m := NewMatrix(r)
+	if len(m[g.Name]) != len(expected) {
+		t.Fatalf("received %d checks but expected %d", len(m[g.Name]), len(expected))
+	}
+	for check, status := range expected {
+		if m[g.Name][check] != status {
+			t.Errorf("%s received: %s but expected: %s", check, m[g.Name][check], status)
+		}
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Matrix
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded[g.Name][CheckKlines] != StatusPass {
+		t.Error("matrix did not survive a JSON round trip")
+	}
+}
+
+func TestRunSkip(t *testing.T) {
+	t.Parallel()
+	opts := mockOpts
+	opts.Authenticated = false
+	opts.Skip = []string{CheckOrderbook}
+	g := newMockExchange(t)
+	r, err := Run(g, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := resultFor(t, r, CheckOrderbook); res.Status != StatusSkipped {
+		t.Errorf("received: %s but expected: %s", res.Status, StatusSkipped)
+	}
+	for x := range r.Results {
+		if r.Results[x].Check == CheckAccountInfo || r.Results[x].Check == CheckActiveOrders {
+			t.Errorf("authenticated check %s run when disabled", r.Results[x].Check)
+		}
+	}
+
+	opts.Pairs = map[asset.Item]currency.Pairs{asset.Spot: {}}
+	r, err = Run(g, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := r.Results[len(r.Results)-1]
+	if last.Status != StatusSkipped || last.Detail != errNoPairsToTest.Error() {
+		t.Errorf("received: %+v but expected pairs to be skipped", last)
+	}
+}
+
+func TestRunViolations(t *testing.T) {
+	t.Parallel()
+	e := &brokenExchange{
+		Exchange: newMockExchange(t),
+		tick: &ticker.Price{
+			Pair: btcusd,
+			Bid:  2,
+			Ask:  1,
+		},
+		book: &orderbook.Base{
+			Pair: btcusd,
+			Bids: orderbook.Items{{Price: 1, Amount: 1}, {Price: 2, Amount: 1}},
+			Asks: orderbook.Items{{Price: 3, Amount: 1}},
+		},
+		candles: &kline.Item{
+			Pair:     btcusd,
+			Interval: kline.OneDay,
+			Candles: []kline.Candle{
+				{Time: time.Unix(1546300800, 0), Open: 1, High: 1, Low: 1, Close: 1},
+				{Time: time.Unix(1600000000, 0), Open: 1, High: 1, Low: 1, Close: 1},
+			},
+		},
+		tradesErr:  errors.New("upstream 404"),
+		limitCheck: errors.New("inconsistent"),
+	}
+	opts := mockOpts
+	opts.Authenticated = false
+	r, err := Run(e, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, check := range []string{CheckTicker, CheckOrderbook, CheckKlines, CheckExecutionLimits} {
+		if res := resultFor(t, r, check); res.Status != StatusFail {
+			t.Errorf("%s received: %s but expected: %s", check, res.Status, StatusFail)
+		}
+	}
+	// Historic trades have no declared capability so a non standard error is
+	// an error rather than a conformance failure
+	if res := resultFor(t, r, CheckHistoricTrades); res.Status != StatusError {
+		t.Errorf("received: %s but expected: %s", res.Status, StatusError)
+	}
+	if m := NewMatrix(r); m[e.GetName()][CheckTicker] != StatusFail {
+		t.Errorf("received: %s but expected: %s", m[e.GetName()][CheckTicker], StatusFail)
+	}
+}
+
+func TestHandled(t *testing.T) {
+	t.Parallel()
+	var res Result
+	if handled(&res, capabilityUndeclared, nil) || res.Status != StatusPass || res.Detail == "" {
+		t.Errorf("unexpected result for undeclared success: %+v", res)
+	}
+	res = Result{}
+	if !handled(&res, capabilityUndeclared, common.ErrFunctionNotSupported) || res.Status != StatusUnsupported {
+		t.Errorf("unexpected result for undeclared unsupported: %+v", res)
+	}
+	res = Result{}
+	if !handled(&res, capabilityDeclared, common.ErrNotYetImplemented) || res.Status != StatusFail {
+		t.Errorf("unexpected result for declared unsupported: %+v", res)
+	}
+	res = Result{}
+	if !handled(&res, capabilityUndeclared, errors.New("nope")) || res.Status != StatusFail {
+		t.Errorf("unexpected result for undeclared non standard error: %+v", res)
+	}
+	res = Result{}
+	if !handled(&res, capabilityDeclared, errors.New("nope")) || res.Status != StatusError {
+		t.Errorf("unexpected result for declared error: %+v", res)
+	}
+}
+
+func TestNewMatrix(t *testing.T) {
+	t.Parallel()
+	m := NewMatrix(nil, &Report{
+		Exchange: "test",
+		Results: []Result{
+			{Check: CheckTicker, Status: StatusPass},
+			{Check: CheckTicker, Status: StatusFail},
+			{Check: CheckTicker, Status: StatusUnsupported},
+			{Status: StatusSkipped},
+		},
+	})
+	if len(m["test"]) != 1 || m["test"][CheckTicker] != StatusFail {
+		t.Errorf("unexpected matrix %v", m)
+	}
+}
+
+// mockWrapper is an exchange wrapper which can be set up from config
+type mockWrapper interface {
+	Exchange
+	SetDefaults()
+	Setup(exch *config.ExchangeConfig) error
+}
+
+// wrapperRun describes a conformance run of an existing wrapper against its
+// recorded VCR mock data. Checks which cannot run against the recording are
+// skipped with the reason, the mock server exits on unmatched requests
+type wrapperRun struct {
+	exch        mockWrapper
+	name        string
+	routePrefix string
+	pair        string
+	start, end  time.Time
+	skip        map[string]string
+}
+
+var wrapperRuns = []wrapperRun{
+	{
+		exch:        new(bitstamp.Bitstamp),
+		name:        "Bitstamp",
+		routePrefix: "/api",
+		pair:        "BTCUSD",
+		start:       time.Unix(1546300800, 0),
+		end:         time.Unix(1577836799, 0),
+		skip: map[string]string{
+			CheckRecentTrades: "transactions route not recorded",
+			CheckKlines:       "recorded daily candles contain a duplicate timestamp",
+		},
+	},
+	{
+		exch:  new(gemini.Gemini),
+		name:  "Gemini",
+		pair:  "BTCUSD",
+		start: time.Date(2020, 6, 6, 0, 0, 0, 0, time.UTC),
+		end:   time.Date(2020, 6, 7, 0, 0, 0, 0, time.UTC),
+		skip: map[string]string{
+			CheckOrderbook: "book route not recorded",
+		},
+	},
+	{
+		exch:  new(poloniex.Poloniex),
+		name:  "Poloniex",
+		pair:  "BTC_LTC",
+		start: time.Unix(1588741402, 0),
+		end:   time.Unix(1588745003, 0),
+		skip: map[string]string{
+			CheckOrderbook:      "order book route not recorded for pair",
+			CheckRecentTrades:   "trade history route not recorded for range",
+			CheckHistoricTrades: "trade history route not recorded for range",
+			CheckKlines:         "chart data route not recorded for interval",
+			CheckAccountInfo:    "complete balances route not recorded",
+		},
+	},
+	{
+		exch:  new(localbitcoins.LocalBitcoins),
+		name:  "LocalBitcoins",
+		pair:  "BTC-LTC",
+		start: time.Date(2020, 6, 6, 0, 0, 0, 0, time.UTC),
+		end:   time.Date(2020, 6, 7, 0, 0, 0, 0, time.UTC),
+		skip: map[string]string{
+			CheckTicker:      "recorded tickers contain no LTC quote",
+			CheckOrderbook:   "order book route not recorded",
+			CheckAccountInfo: "wallet route not recorded",
+		},
+	},
+	{
+		exch:  new(zb.ZB),
+		name:  "ZB",
+		pair:  "btc_usdt",
+		start: time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+		end:   time.Date(2020, 9, 2, 0, 0, 0, 0, time.UTC),
+		skip: map[string]string{
+			CheckAccountInfo:  "account info route not recorded",
+			CheckActiveOrders: "unfinished orders route not recorded",
+		},
+	},
+}
+
+// setupMockWrapper sets up a wrapper from the test config with dummy
+// credentials and points it at its recorded mock data
+func setupMockWrapper(t *testing.T, cfg *config.Config, w *wrapperRun) {
+	t.Helper()
+	exchCfg, err := cfg.GetExchangeConfig(w.name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exchCfg.API.AuthenticatedSupport = true
+	exchCfg.API.Credentials.Key = "key"
+	exchCfg.API.Credentials.Secret = "secret"
+	exchCfg.API.Credentials.ClientID = "clientid"
+	w.exch.SetDefaults()
+	w.exch.GetBase().SkipAuthCheck = true
+	if err = w.exch.Setup(exchCfg); err != nil {
+		t.Fatal(err)
+	}
+	lower := strings.ToLower(w.name)
+	err = UseMockServer(w.exch.GetBase(), "../../testdata/http_mock/"+lower+"/"+lower+".json", w.routePrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestWrapperConformance runs every wrapper with recorded mock data through
+// the harness. Any failure or error fails the test and the resulting matrix
+// must match the artifact in testdata/conformance. When a wrapper gains or
+// loses support regenerate the artifact with
+// go test ./exchanges/conformance -run TestWrapperConformance -update-matrix
+func TestWrapperConformance(t *testing.T) {
+	var cfg config.Config
+	if err := cfg.LoadConfig("../../testdata/configtest.json", true); err != nil {
+		t.Fatal(err)
+	}
+	reports := make([]*Report, 0, len(wrapperRuns))
+	for i := range wrapperRuns {
+		w := &wrapperRuns[i]
+		setupMockWrapper(t, &cfg, w)
+		p, err := currency.NewPairFromString(w.pair)
+		if err != nil {
+			t.Fatal(err)
+		}
+		skip := make([]string, 0, len(w.skip))
+		for check := range w.skip {
+			skip = append(skip, check)
+		}
+		r, err := Run(w.exch, &Options{
+			Assets:              asset.Items{asset.Spot},
+			Pairs:               map[asset.Item]currency.Pairs{asset.Spot: {p}},
+			KlineInterval:       kline.OneDay,
+			KlineStart:          w.start,
+			KlineEnd:            w.end,
+			HistoricTradesStart: w.start,
+			HistoricTradesEnd:   w.end,
+			Authenticated:       true,
+			Skip:                skip,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		reports = append(reports, r)
+		for _, res := range r.Failures() {
+			t.Errorf("%s %s %s: %s", w.name, res.Check, res.Status, res.Detail)
+		}
+	}
+
+	data, err := json.MarshalIndent(NewMatrix(reports...), "", " ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, '\n')
+	if *updateMatrix {
+		err = file.Write(matrixFile, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	artifact, err := ioutil.ReadFile(matrixFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(artifact, data) {
+		t.Errorf("wrapper matrix does not match %s, received:\n%s", matrixFile, data)
+	}
+}
@@ -0,0 +1,114 @@
+package conformance
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+// Check names used in reports and the compatibility matrix
+const (
+	CheckTradablePairs   = "tradablePairs"
+	CheckTicker          = "ticker"
+	CheckOrderbook       = "orderbook"
+	CheckRecentTrades    = "recentTrades"
+	CheckHistoricTrades  = "historicTrades"
+	CheckKlines          = "klines"
+	CheckExecutionLimits = "executionLimits"
+	CheckAccountInfo     = "accountInfo"
+	CheckActiveOrders    = "activeOrders"
+)
+
+// Status defines the outcome of a single check
+type Status string
+
+// Check outcomes, ordered from best to worst when collapsed into a matrix
+// cell
+const (
+	StatusPass        Status = "pass"
+	StatusUnsupported Status = "unsupported"
+	StatusSkipped     Status = "skipped"
+	StatusError       Status = "error"
+	StatusFail        Status = "fail"
+)
+
+var (
+	errExchangeIsNil     = errors.New("exchange is nil")
+	errNoPairsToTest     = errors.New("no enabled pairs to test")
+	errInvalidKlineRange = errors.New("kline start time must be before end time")
+)
+
+// Exchange is the subset of the exchange.IBotExchange wrapper functions the
+// conformance checks call, any IBotExchange satisfies it
+type Exchange interface {
+	GetName() string
+	GetBase() *exchange.Base
+	GetAssetTypes() asset.Items
+	GetEnabledPairs(a asset.Item) (currency.Pairs, error)
+	FetchTradablePairs(a asset.Item) ([]string, error)
+	UpdateTicker(p currency.Pair, a asset.Item) (*ticker.Price, error)
+	UpdateOrderbook(p currency.Pair, a asset.Item) (*orderbook.Base, error)
+	GetRecentTrades(p currency.Pair, a asset.Item) ([]trade.Data, error)
+	GetHistoricTrades(p currency.Pair, a asset.Item, startTime, endTime time.Time) ([]trade.Data, error)
+	GetHistoricCandles(p currency.Pair, a asset.Item, timeStart, timeEnd time.Time, interval kline.Interval) (kline.Item, error)
+	UpdateOrderExecutionLimits(a asset.Item) error
+	GetOrderExecutionLimits(a asset.Item, cp currency.Pair) (*order.Limits, error)
+	CheckOrderExecutionLimits(a asset.Item, cp currency.Pair, price, amount float64, orderType order.Type) error
+	UpdateAccountInfo(a asset.Item) (account.Holdings, error)
+	GetActiveOrders(getOrdersRequest *order.GetOrdersRequest) ([]order.Detail, error)
+}
+
+// Options configures a conformance run. Zero values select sensible defaults
+// so that a run can be made against any exchange without setup; when running
+// against VCR mock data the ranges and pairs must match what was recorded
+type Options struct {
+	// Assets restricts the run to these asset types, defaults to all
+	// supported assets
+	Assets asset.Items
+	// Pairs overrides the pairs tested per asset, defaults to the first
+	// enabled pair for each asset
+	Pairs map[asset.Item]currency.Pairs
+	// KlineInterval defaults to one hour
+	KlineInterval kline.Interval
+	// KlineStart and KlineEnd default to the last 24 hours
+	KlineStart time.Time
+	KlineEnd   time.Time
+	// HistoricTradesStart and HistoricTradesEnd default to the last hour
+	HistoricTradesStart time.Time
+	HistoricTradesEnd   time.Time
+	// Authenticated enables checks against authenticated endpoints when the
+	// exchange allows authenticated requests
+	Authenticated bool
+	// Skip lists check names that should not be run
+	Skip []string
+}
+
+// Result is the outcome of a single check for an asset and pair
+type Result struct {
+	Check  string        `json:"check"`
+	Asset  asset.Item    `json:"asset"`
+	Pair   currency.Pair `json:"pair"`
+	Status Status        `json:"status"`
+	Detail string        `json:"detail,omitempty"`
+}
+
+// Report holds every check result for a single exchange
+type Report struct {
+	Exchange string        `json:"exchange"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	Results  []Result      `json:"results"`
+}
+
+// Matrix maps exchange name to check name to the worst status seen across
+// all tested assets and pairs
+type Matrix map[string]map[string]Status
@@ -15,11 +15,15 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
@@ -938,7 +942,8 @@ func (b *Base) GetBase() *Base { return b }
 // CheckTransientError catches transient errors and returns nil if found, used
 // for validation of API credentials
 func (b *Base) CheckTransientError(err error) error {
-	if _, ok := err.(net.Error); ok {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
 		log.Warnf(log.ExchangeSys,
 			"%s net error captured, will not disable authentication %s",
 			b.Name,
@@ -1129,13 +1134,78 @@ func (b *Base) ValidateKline(pair currency.Pair, a asset.Item, interval kline.In
 	return nil
 }
 
-// AddTradesToBuffer is a helper function that will only
-// add trades to the buffer if it is allowed
+// AddTradesToBuffer is a helper function that will publish trades to any
+// subscribers and only add trades to the buffer if it is allowed
 func (b *Base) AddTradesToBuffer(trades ...trade.Data) error {
 	if !b.IsSaveTradeDataEnabled() {
+		trade.Publish(b.Name, trades...)
 		return nil
 	}
-	return trade.AddTradesToBuffer(b.Name, trades...)
+	return trade.AddTradesToInstanceBuffer(b.GetDatabase(), b.Name, trades...)
+}
+
+// SetServices sets the services the exchange processes tickers, orderbooks
+// and account holdings through and the database instance trades are saved
+// to. Nil values fall back to the package level defaults
+func (b *Base) SetServices(t *ticker.Service, o *orderbook.Service, a *account.Service, db *database.Instance) {
+	b.settingsMutex.Lock()
+	b.tickerService = t
+	b.orderbookService = o
+	b.accountService = a
+	b.database = db
+	b.settingsMutex.Unlock()
+	if b.Websocket != nil {
+		b.Websocket.Orderbook.SetService(o)
+	}
+}
+
+// GetTickerService returns the ticker service the exchange processes tickers
+// through
+func (b *Base) GetTickerService() *ticker.Service {
+	b.settingsMutex.RLock()
+	defer b.settingsMutex.RUnlock()
+	if b.tickerService != nil {
+		return b.tickerService
+	}
+	return ticker.GetService()
+}
+
+// GetOrderbookService returns the orderbook service the exchange processes
+// orderbooks through
+func (b *Base) GetOrderbookService() *orderbook.Service {
+	b.settingsMutex.RLock()
+	defer b.settingsMutex.RUnlock()
+	if b.orderbookService != nil {
+		return b.orderbookService
+	}
+	return orderbook.GetService()
+}
+
+// GetAccountService returns the account service the exchange processes
+// account holdings through
+func (b *Base) GetAccountService() *account.Service {
+	b.settingsMutex.RLock()
+	defer b.settingsMutex.RUnlock()
+	if b.accountService != nil {
+		return b.accountService
+	}
+	return account.GetService()
+}
+
+// GetDatabase returns the database instance the exchange saves trades to
+func (b *Base) GetDatabase() *database.Instance {
+	b.settingsMutex.RLock()
+	defer b.settingsMutex.RUnlock()
+	if b.database != nil {
+		return b.database
+	}
+	return database.DB
+}
+
+// IsTradeProcessingRequired returns whether received trades need to be
+// processed, either to be saved or to be published to subscribers
+func (b *Base) IsTradeProcessingRequired() bool {
+	return b.IsSaveTradeDataEnabled() || trade.HasSubscribers(b.Name)
 }
 
 // IsSaveTradeDataEnabled checks the state of
@@ -13,11 +13,13 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 )
@@ -2172,6 +2174,67 @@ func TestAddTradesToBuffer(t *testing.T) {
 	}
 }
 
+func TestAddTradesToBufferPublishesWhenSavingDisabled(t *testing.T) {
+	b := Base{
+		Name: "publishTest",
+		Config: &config.ExchangeConfig{
+			Features: &config.FeaturesConfig{},
+		},
+	}
+	if b.IsTradeProcessingRequired() {
+		t.Error("trades should not need processing without saving or subscribers")
+	}
+	if !dispatch.IsRunning() {
+		err := dispatch.Start(1, dispatch.DefaultJobsLimit)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	p, err := trade.SubscribeToExchangeTrades(b.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.IsTradeProcessingRequired() {
+		t.Error("trades should need processing with a subscriber")
+	}
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	tr := trade.Data{
+		Timestamp:    time.Now(),
+		Exchange:     b.Name,
+		CurrencyPair: cp,
+		AssetType:    asset.Spot,
+		Price:        1337,
+		Amount:       1,
+	}
+	// dispatch drops payloads when the receiver is not immediately ready so
+	// keep publishing until one is received
+	timeout := time.After(time.Second * 5)
+	for {
+		err = b.AddTradesToBuffer(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case data := <-p.C:
+			received, ok := (*data.(*interface{})).(trade.Data)
+			if !ok {
+				t.Fatal("unexpected payload type")
+			}
+			if received.Price != 1337 || !received.CurrencyPair.Equal(cp) {
+				t.Errorf("unexpected trade received %+v", received)
+			}
+			err = p.Release()
+			if err != nil {
+				t.Error(err)
+			}
+			return
+		case <-timeout:
+			t.Fatal("expected trade to be published with saving disabled")
+		case <-time.After(time.Millisecond * 50):
+		}
+	}
+}
+
 func TestString(t *testing.T) {
 	if RestSpot.String() != "RestSpotURL" {
 		t.Errorf("invalid string conversion")
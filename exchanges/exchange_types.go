@@ -6,12 +6,16 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
 
 // Endpoint authentication types
@@ -231,6 +235,13 @@ type Base struct {
 	order.ExecutionLimits
 
 	AssetWebsocketSupport
+
+	// Services tickers, orderbooks, account holdings and trades are
+	// processed through, the package level services are used when unset
+	tickerService    *ticker.Service
+	orderbookService *orderbook.Service
+	accountService   *account.Service
+	database         *database.Instance
 }
 
 // url lookup consts
@@ -315,6 +315,13 @@ func (e *EXMO) SendHTTPRequest(endpoint exchange.URL, path string, result interf
 	})
 }
 
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	exmoOrderCreate,
+	exmoOrderCancel,
+}
+
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request
 func (e *EXMO) SendAuthenticatedHTTPRequest(epath exchange.URL, method, endpoint string, vals url.Values, result interface{}) error {
 	if !e.AllowAuthenticatedRequest() {
@@ -356,6 +363,7 @@ func (e *EXMO) SendAuthenticatedHTTPRequest(epath exchange.URL, method, endpoint
 		Body:          strings.NewReader(payload),
 		Result:        result,
 		AuthRequest:   true,
+		Priority:      request.PriorityByEndpoint(endpoint, orderEndpoints...),
 		NonceEnabled:  true,
 		Verbose:       e.Verbose,
 		HTTPDebugging: e.HTTPDebugging,
@@ -201,7 +201,7 @@ func (e *EXMO) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = e.GetTickerService().ProcessTicker(&ticker.Price{
 				Pair:         pairs[i],
 				Last:         result[j].Last,
 				Ask:          result[j].Sell,
@@ -216,12 +216,12 @@ func (e *EXMO) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 			}
 		}
 	}
-	return ticker.GetTicker(e.Name, p, assetType)
+	return e.GetTickerService().GetTicker(e.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (e *EXMO) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tick, err := ticker.GetTicker(e.Name, p, assetType)
+	tick, err := e.GetTickerService().GetTicker(e.Name, p, assetType)
 	if err != nil {
 		return e.UpdateTicker(p, assetType)
 	}
@@ -230,7 +230,7 @@ func (e *EXMO) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price
 
 // FetchOrderbook returns the orderbook for a currency pair
 func (e *EXMO) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(e.Name, p, assetType)
+	ob, err := e.GetOrderbookService().Retrieve(e.Name, p, assetType)
 	if err != nil {
 		return e.UpdateOrderbook(p, assetType)
 	}
@@ -314,12 +314,12 @@ func (e *EXMO) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderboo
 			})
 		}
 
-		err = book.Process()
+		err = e.GetOrderbookService().Process(book)
 		if err != nil {
 			return book, err
 		}
 	}
-	return orderbook.Get(e.Name, p, assetType)
+	return e.GetOrderbookService().Retrieve(e.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -351,7 +351,7 @@ func (e *EXMO) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error)
 		Currencies: currencies,
 	})
 
-	err = account.Process(&response)
+	err = e.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -361,7 +361,7 @@ func (e *EXMO) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error)
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (e *EXMO) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(e.Name, assetType)
+	acc, err := e.GetAccountService().GetHoldings(e.Name, assetType)
 	if err != nil {
 		return e.UpdateAccountInfo(assetType)
 	}
@@ -289,7 +289,7 @@ func (f *FTX) wsHandleData(respRaw []byte) error {
 				return err
 			}
 		case wsTrades:
-			if !f.IsSaveTradeDataEnabled() {
+			if !f.IsTradeProcessingRequired() {
 				return nil
 			}
 			var resultData WsTradeDataStore
@@ -318,7 +318,7 @@ func (f *FTX) wsHandleData(respRaw []byte) error {
 					TID:          strconv.FormatInt(resultData.TradeData[z].ID, 10),
 				})
 			}
-			return trade.AddTradesToBuffer(f.Name, trades...)
+			return f.AddTradesToBuffer(trades...)
 		case wsOrders:
 			var resultData WsOrderDataStore
 			err = json.Unmarshal(respRaw, &resultData)
@@ -374,7 +374,37 @@ func (f *FTX) wsHandleData(respRaw []byte) error {
 			if err != nil {
 				return err
 			}
-			f.Websocket.DataHandler <- resultData.FillsData
+			var pair currency.Pair
+			pair, err = currency.NewPairFromString(resultData.FillsData.Market)
+			if err != nil {
+				return err
+			}
+			var assetType asset.Item
+			assetType, err = f.GetPairAssetType(pair)
+			if err != nil {
+				return err
+			}
+			var oSide order.Side
+			oSide, err = order.StringToOrderSide(resultData.FillsData.Side)
+			if err != nil {
+				f.Websocket.DataHandler <- order.ClassificationError{
+					Exchange: f.Name,
+					Err:      err,
+				}
+			}
+			f.Websocket.DataHandler <- &order.Fill{
+				Price:     resultData.FillsData.Price,
+				Amount:    resultData.FillsData.Size,
+				Fee:       resultData.FillsData.Fee,
+				Exchange:  f.Name,
+				ID:        strconv.FormatInt(resultData.FillsData.ID, 10),
+				OrderID:   strconv.FormatInt(resultData.FillsData.OrderID, 10),
+				IsMaker:   resultData.FillsData.Liquidity == "maker",
+				Side:      oSide,
+				AssetType: assetType,
+				Pair:      pair,
+				Timestamp: resultData.FillsData.Time,
+			}
 		default:
 			f.Websocket.DataHandler <- stream.UnhandledMessageWarning{Message: f.Name + stream.UnhandledMessage + string(respRaw)}
 		}
@@ -115,6 +115,7 @@ func (f *FTX) SetDefaults() {
 				Unsubscribe:       true,
 				GetOrders:         true,
 				GetOrder:          true,
+				OrderUpdates:      true,
 			},
 			WithdrawPermissions: exchange.NoAPIWithdrawalMethods,
 			Kline: kline.ExchangeCapabilitiesSupported{
@@ -314,18 +315,18 @@ func (f *FTX) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Price
 			resp.LastUpdated = time.Now()
 			resp.AssetType = assetType
 			resp.ExchangeName = f.Name
-			err = ticker.ProcessTicker(&resp)
+			err = f.GetTickerService().ProcessTicker(&resp)
 			if err != nil {
 				return nil, err
 			}
 		}
 	}
-	return ticker.GetTicker(f.Name, p, assetType)
+	return f.GetTickerService().GetTicker(f.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (f *FTX) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(f.Name, p, assetType)
+	tickerNew, err := f.GetTickerService().GetTicker(f.Name, p, assetType)
 	if err != nil {
 		return f.UpdateTicker(p, assetType)
 	}
@@ -334,7 +335,7 @@ func (f *FTX) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price,
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (f *FTX) FetchOrderbook(currency currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(f.Name, currency, assetType)
+	ob, err := f.GetOrderbookService().Retrieve(f.Name, currency, assetType)
 	if err != nil {
 		return f.UpdateOrderbook(currency, assetType)
 	}
@@ -367,11 +368,11 @@ func (f *FTX) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderbook
 			Amount: tempResp.Asks[y].Size,
 			Price:  tempResp.Asks[y].Price})
 	}
-	err = book.Process()
+	err = f.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(f.Name, p, assetType)
+	return f.GetOrderbookService().Retrieve(f.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies
@@ -394,7 +395,7 @@ func (f *FTX) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error)
 	resp.Accounts = append(resp.Accounts, acc)
 	resp.Exchange = f.Name
 
-	err = account.Process(&resp)
+	err = f.GetAccountService().Process(&resp)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -404,7 +405,7 @@ func (f *FTX) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error)
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (f *FTX) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(f.Name, assetType)
+	acc, err := f.GetAccountService().GetHoldings(f.Name, assetType)
 	if err != nil {
 		return f.UpdateAccountInfo(assetType)
 	}
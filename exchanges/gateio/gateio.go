@@ -402,6 +402,14 @@ func (g *Gateio) GenerateSignature(message string) []byte {
 }
 
 // SendAuthenticatedHTTPRequest sends authenticated requests to the Gateio API
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	gateioOrder + "/",
+	gateioCancelOrder,
+	gateioCancelAllOrders,
+}
+
 // To use this you must setup an APIKey and APISecret from the exchange
 func (g *Gateio) SendAuthenticatedHTTPRequest(ep exchange.URL, method, endpoint, param string, result interface{}) error {
 	if !g.AllowAuthenticatedRequest() {
@@ -429,6 +437,7 @@ func (g *Gateio) SendAuthenticatedHTTPRequest(ep exchange.URL, method, endpoint,
 		Body:          strings.NewReader(param),
 		Result:        &intermidiary,
 		AuthRequest:   true,
+		Priority:      request.PriorityByEndpoint(endpoint, orderEndpoints...),
 		Verbose:       g.Verbose,
 		HTTPDebugging: g.HTTPDebugging,
 		HTTPRecording: g.HTTPRecording,
@@ -167,7 +167,7 @@ func (g *Gateio) wsHandleData(respRaw []byte) error {
 		}
 
 	case strings.Contains(result.Method, "trades"):
-		if !g.IsSaveTradeDataEnabled() {
+		if !g.IsTradeProcessingRequired() {
 			return nil
 		}
 		var tradeData []WebsocketTrade
@@ -207,7 +207,7 @@ func (g *Gateio) wsHandleData(respRaw []byte) error {
 				TID:          strconv.FormatInt(tradeData[i].ID, 10),
 			})
 		}
-		return trade.AddTradesToBuffer(g.Name, trades...)
+		return g.AddTradesToBuffer(trades...)
 	case strings.Contains(result.Method, "balance.update"):
 		var balance wsBalanceSubscription
 		err = json.Unmarshal(respRaw, &balance)
@@ -246,7 +246,7 @@ func (g *Gateio) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = g.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         result[k].Last,
 				High:         result[k].High,
 				Low:          result[k].Low,
@@ -263,12 +263,12 @@ func (g *Gateio) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 		}
 	}
 
-	return ticker.GetTicker(g.Name, p, assetType)
+	return g.GetTickerService().GetTicker(g.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (g *Gateio) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(g.Name, p, assetType)
+	tickerNew, err := g.GetTickerService().GetTicker(g.Name, p, assetType)
 	if err != nil {
 		return g.UpdateTicker(p, assetType)
 	}
@@ -277,7 +277,7 @@ func (g *Gateio) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (g *Gateio) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(g.Name, p, assetType)
+	ob, err := g.GetOrderbookService().Retrieve(g.Name, p, assetType)
 	if err != nil {
 		return g.UpdateOrderbook(p, assetType)
 	}
@@ -315,11 +315,11 @@ func (g *Gateio) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderb
 			Price:  orderbookNew.Asks[x].Price,
 		})
 	}
-	err = book.Process()
+	err = g.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(g.Name, p, assetType)
+	return g.GetOrderbookService().Retrieve(g.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -400,7 +400,7 @@ func (g *Gateio) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 	}
 
 	info.Exchange = g.Name
-	err := account.Process(&info)
+	err := g.GetAccountService().Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -410,7 +410,7 @@ func (g *Gateio) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (g *Gateio) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(g.Name, assetType)
+	acc, err := g.GetAccountService().GetHoldings(g.Name, assetType)
 	if err != nil {
 		return g.UpdateAccountInfo(assetType)
 	}
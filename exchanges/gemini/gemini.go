@@ -141,11 +141,17 @@ func (g *Gemini) GetAuction(currencyPair string) (Auction, error) {
 //
 // currencyPair - example "btcusd"
 // params -- [optional]
-//          since - [timestamp] Only returns auction events after the specified
+//
+//	since - [timestamp] Only returns auction events after the specified
+//
 // timestamp.
-//          limit_auction_results - [integer] The maximum number of auction
+//
+//	limit_auction_results - [integer] The maximum number of auction
+//
 // events to return.
-//          include_indicative - [bool] Whether to include publication of
+//
+//	include_indicative - [bool] Whether to include publication of
+//
 // indicative prices and quantities.
 func (g *Gemini) GetAuctionHistory(currencyPair string, params url.Values) ([]AuctionHistory, error) {
 	path := common.EncodeURLValues(fmt.Sprintf("/v%s/%s/%s/%s", geminiAPIVersion, geminiAuction, currencyPair, geminiAuctionHistory), params)
@@ -362,6 +368,15 @@ func (g *Gemini) SendHTTPRequest(ep exchange.URL, path string, result interface{
 }
 
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to the
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	geminiOrderNew,
+	geminiOrderCancel,
+	geminiOrderCancelSession,
+	geminiOrderCancelAll,
+}
+
 // exchange and returns an error
 func (g *Gemini) SendAuthenticatedHTTPRequest(ep exchange.URL, method, path string, params map[string]interface{}, result interface{}) (err error) {
 	if !g.AllowAuthenticatedRequest() {
@@ -407,6 +422,7 @@ func (g *Gemini) SendAuthenticatedHTTPRequest(ep exchange.URL, method, path stri
 		Headers:       headers,
 		Result:        result,
 		AuthRequest:   true,
+		Priority:      request.PriorityByEndpoint(path, orderEndpoints...),
 		NonceEnabled:  true,
 		Verbose:       g.Verbose,
 		HTTPDebugging: g.HTTPDebugging,
@@ -325,7 +325,7 @@ func (g *Gemini) wsHandleData(respRaw []byte) error {
 			}
 			return g.wsProcessUpdate(l2MarketData)
 		case "trade":
-			if !g.IsSaveTradeDataEnabled() {
+			if !g.IsTradeProcessingRequired() {
 				return nil
 			}
 
@@ -369,7 +369,7 @@ func (g *Gemini) wsHandleData(respRaw []byte) error {
 				TID:          strconv.FormatInt(result.EventID, 10),
 			}
 
-			return trade.AddTradesToBuffer(g.Name, tradeEvent)
+			return g.AddTradesToBuffer(tradeEvent)
 		case "subscription_ack":
 			var result WsSubscriptionAcknowledgementResponse
 			err := json.Unmarshal(respRaw, &result)
@@ -553,7 +553,7 @@ func (g *Gemini) wsProcessUpdate(result *wsL2MarketData) error {
 		g.Websocket.DataHandler <- result.AuctionEvents
 	}
 
-	if !g.IsSaveTradeDataEnabled() {
+	if !g.IsTradeProcessingRequired() {
 		return nil
 	}
 
@@ -578,5 +578,5 @@ func (g *Gemini) wsProcessUpdate(result *wsL2MarketData) error {
 		})
 	}
 
-	return trade.AddTradesToBuffer(g.Name, trades...)
+	return g.AddTradesToBuffer(trades...)
 }
@@ -325,7 +325,7 @@ func (g *Gemini) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 		Currencies: currencies,
 	})
 
-	err = account.Process(&response)
+	err = g.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -335,7 +335,7 @@ func (g *Gemini) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (g *Gemini) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(g.Name, assetType)
+	acc, err := g.GetAccountService().GetHoldings(g.Name, assetType)
 	if err != nil {
 		return g.UpdateAccountInfo(assetType)
 	}
@@ -355,7 +355,7 @@ func (g *Gemini) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 		return nil, err
 	}
 
-	err = ticker.ProcessTicker(&ticker.Price{
+	err = g.GetTickerService().ProcessTicker(&ticker.Price{
 		High:         tick.High,
 		Low:          tick.Low,
 		Bid:          tick.Bid,
@@ -369,7 +369,7 @@ func (g *Gemini) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 		return nil, err
 	}
 
-	return ticker.GetTicker(g.Name, fPair, assetType)
+	return g.GetTickerService().GetTicker(g.Name, fPair, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -379,7 +379,7 @@ func (g *Gemini) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 		return nil, err
 	}
 
-	tickerNew, err := ticker.GetTicker(g.Name, fPair, assetType)
+	tickerNew, err := g.GetTickerService().GetTicker(g.Name, fPair, assetType)
 	if err != nil {
 		return g.UpdateTicker(fPair, assetType)
 	}
@@ -393,7 +393,7 @@ func (g *Gemini) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbo
 		return nil, err
 	}
 
-	ob, err := orderbook.Get(g.Name, fPair, assetType)
+	ob, err := g.GetOrderbookService().Retrieve(g.Name, fPair, assetType)
 	if err != nil {
 		return g.UpdateOrderbook(fPair, assetType)
 	}
@@ -429,11 +429,11 @@ func (g *Gemini) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderb
 			Amount: orderbookNew.Asks[x].Amount,
 			Price:  orderbookNew.Asks[x].Price})
 	}
-	err = book.Process()
+	err = g.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(g.Name, fPair, assetType)
+	return g.GetOrderbookService().Retrieve(g.Name, fPair, assetType)
 }
 
 // GetFundingHistory returns funding history, deposits and
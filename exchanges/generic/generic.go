@@ -0,0 +1,590 @@
+package generic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"gopkg.in/yaml.v3"
+)
+
+// Generic is an exchange implementation driven entirely by a declarative
+// Spec instead of a hand written wrapper
+type Generic struct {
+	exchange.Base
+	spec *Spec
+}
+
+// New returns a generic exchange for the supplied spec
+func New(s *Spec) (*Generic, error) {
+	err := s.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return &Generic{spec: s}, nil
+}
+
+// NewFromFile loads a JSON or YAML spec from disk and returns a generic
+// exchange for it
+func NewFromFile(path string) (*Generic, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := LoadSpec(data, filepath.Ext(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return New(s)
+}
+
+// LoadSpec decodes a spec from data, format is either json or yaml and may be
+// supplied as a file extension
+func LoadSpec(data []byte, format string) (*Spec, error) {
+	var s Spec
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case "json":
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return nil, err
+		}
+	case "yaml", "yml":
+		// Round trip through JSON so the spec only needs one set of tags
+		var raw interface{}
+		err := yaml.Unmarshal(data, &raw)
+		if err != nil {
+			return nil, err
+		}
+		j, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(j, &s)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedSpecFormat, format)
+	}
+	return &s, nil
+}
+
+// Validate checks the spec for missing or unsupported values
+func (s *Spec) Validate() error {
+	if s.Name == "" {
+		return errSpecNameEmpty
+	}
+	if s.REST.URL == "" {
+		return errSpecRESTURLEmpty
+	}
+	for x := range s.Assets {
+		if _, err := asset.New(s.Assets[x]); err != nil {
+			return err
+		}
+	}
+	if s.REST.RateLimit.Interval != "" {
+		if _, err := time.ParseDuration(s.REST.RateLimit.Interval); err != nil {
+			return err
+		}
+	}
+
+	ep := &s.REST.Endpoints
+	endpoints := []struct {
+		name     string
+		e        *Endpoint
+		required []string
+	}{
+		{"pairs", ep.Pairs, nil},
+		{"ticker", ep.Ticker, []string{"last"}},
+		{"orderbook", ep.Orderbook, []string{"bids", "asks", "price", "amount"}},
+		{"trades", ep.Trades, []string{"price", "amount"}},
+		{"candles", ep.Candles, []string{"time", "open", "high", "low", "close"}},
+		{"account", ep.Account, []string{"currency", "total"}},
+		{"submitOrder", ep.SubmitOrder, []string{"id"}},
+		{"cancelOrder", ep.CancelOrder, nil},
+		{"orderInfo", ep.OrderInfo, []string{"id"}},
+		{"activeOrders", ep.ActiveOrders, []string{"id"}},
+	}
+	for x := range endpoints {
+		e := endpoints[x].e
+		if e == nil {
+			continue
+		}
+		if e.Path == "" {
+			return fmt.Errorf("%s %w", endpoints[x].name, errEndpointPathEmpty)
+		}
+		if e.BodyEncoding != "" && e.BodyEncoding != BodyJSON && e.BodyEncoding != BodyForm {
+			return fmt.Errorf("%s body %w: %s", endpoints[x].name, errUnsupportedEncoding, e.BodyEncoding)
+		}
+		if e.Authenticated && s.Auth == nil {
+			return fmt.Errorf("%s: %w", endpoints[x].name, errAuthNotConfigured)
+		}
+		for y := range endpoints[x].required {
+			if _, ok := e.Fields[endpoints[x].required[y]]; !ok {
+				return fmt.Errorf("%s %w: %s", endpoints[x].name, errEndpointFieldMissing, endpoints[x].required[y])
+			}
+		}
+	}
+
+	if s.Auth != nil {
+		switch s.Auth.Scheme {
+		case SchemeHMACSHA256, SchemeHMACSHA512:
+		default:
+			return fmt.Errorf("%w: %s", errUnsupportedScheme, s.Auth.Scheme)
+		}
+		switch s.Auth.Placement {
+		case "", PlacementHeader, PlacementQuery:
+		default:
+			return fmt.Errorf("%w: %s", errUnsupportedPlacement, s.Auth.Placement)
+		}
+		switch s.Auth.Encoding {
+		case "", EncodingHex, EncodingBase64:
+		default:
+			return fmt.Errorf("signature %w: %s", errUnsupportedEncoding, s.Auth.Encoding)
+		}
+	}
+
+	if s.Websocket != nil {
+		if s.Websocket.URL == "" || s.Websocket.Subscribe == "" {
+			return fmt.Errorf("websocket %w: url and subscribe template", errEndpointFieldMissing)
+		}
+		if s.Websocket.Ticker != nil {
+			if _, ok := s.Websocket.Ticker.Fields["last"]; !ok {
+				return fmt.Errorf("websocket ticker %w: last", errEndpointFieldMissing)
+			}
+		}
+		if s.Websocket.Trades != nil {
+			for _, f := range []string{"price", "amount"} {
+				if _, ok := s.Websocket.Trades.Fields[f]; !ok {
+					return fmt.Errorf("websocket trades %w: %s", errEndpointFieldMissing, f)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// assetTypes returns the asset types declared by the spec, defaulting to
+// spot
+func (s *Spec) assetTypes() asset.Items {
+	if len(s.Assets) == 0 {
+		return asset.Items{asset.Spot}
+	}
+	items := make(asset.Items, 0, len(s.Assets))
+	for x := range s.Assets {
+		a, _ := asset.New(s.Assets[x]) // Already validated
+		items = append(items, a)
+	}
+	return items
+}
+
+// sendRequest renders and sends an endpoint request, returning the decoded
+// response
+func (g *Generic) sendRequest(e *Endpoint, params map[string]string) (interface{}, error) {
+	endpoint, err := g.API.Endpoints.GetURL(exchange.RestSpot)
+	if err != nil {
+		return nil, err
+	}
+	replacer := newReplacer(params)
+
+	method := http.MethodGet
+	if e.Method != "" {
+		method = strings.ToUpper(e.Method)
+	}
+	path := replacer.Replace(e.Path)
+
+	values := url.Values{}
+	for k, v := range e.Query {
+		if r := replacer.Replace(v); r != "" {
+			values.Set(k, r)
+		}
+	}
+
+	headers := make(map[string]string)
+	var body string
+	if len(e.Body) > 0 {
+		if e.BodyEncoding == BodyForm {
+			form := url.Values{}
+			for k, v := range e.Body {
+				if r := replacer.Replace(v); r != "" {
+					form.Set(k, r)
+				}
+			}
+			body = form.Encode()
+			headers["Content-Type"] = "application/x-www-form-urlencoded"
+		} else {
+			payload := make(map[string]string)
+			for k, v := range e.Body {
+				if r := replacer.Replace(v); r != "" {
+					payload[k] = r
+				}
+			}
+			var data []byte
+			data, err = json.Marshal(payload)
+			if err != nil {
+				return nil, err
+			}
+			body = string(data)
+			headers["Content-Type"] = "application/json"
+		}
+	}
+
+	if e.Authenticated {
+		if !g.AllowAuthenticatedRequest() {
+			return nil, fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, g.Name)
+		}
+		var u *url.URL
+		u, err = url.Parse(endpoint + path)
+		if err != nil {
+			return nil, err
+		}
+		err = g.sign(method, u.Path, values, body, headers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fullPath := endpoint + path
+	if len(values) > 0 {
+		fullPath += "?" + values.Encode()
+	}
+
+	var bodyReader io.Reader
+	if body != "" {
+		bodyReader = bytes.NewBufferString(body)
+	}
+
+	var resp json.RawMessage
+	err = g.SendPayload(context.Background(), &request.Item{
+		Method:        method,
+		Path:          fullPath,
+		Headers:       headers,
+		Body:          bodyReader,
+		Result:        &resp,
+		AuthRequest:   e.Authenticated,
+		Verbose:       g.Verbose,
+		HTTPDebugging: g.HTTPDebugging,
+		HTTPRecording: g.HTTPRecording,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeJSON(resp)
+	if err != nil {
+		return nil, err
+	}
+	if g.spec.REST.ErrorPath != "" {
+		if v, lookupErr := lookup(decoded, g.spec.REST.ErrorPath); lookupErr == nil && !isEmpty(v) {
+			return nil, fmt.Errorf("%s %w: %v", g.Name, errExchangeError, v)
+		}
+	}
+	return decoded, nil
+}
+
+// sign adds the API key, timestamp and signature to an authenticated request
+func (g *Generic) sign(method, path string, values url.Values, body string, headers map[string]string) error {
+	a := g.spec.Auth
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10)
+	if a.TimestampUnit == "s" {
+		timestamp = strconv.FormatInt(now.Unix(), 10)
+	}
+
+	secret := []byte(g.API.Credentials.Secret)
+	if a.SecretBase64 {
+		var err error
+		secret, err = crypto.Base64Decode(g.API.Credentials.Secret)
+		if err != nil {
+			return err
+		}
+	}
+
+	if a.Placement == PlacementQuery {
+		values.Set(a.KeyName, g.API.Credentials.Key)
+		if a.TimestampName != "" {
+			values.Set(a.TimestampName, timestamp)
+		}
+	}
+
+	payload := strings.NewReplacer(
+		"{timestamp}", timestamp,
+		"{method}", method,
+		"{path}", path,
+		"{query}", values.Encode(),
+		"{body}", body,
+		"{key}", g.API.Credentials.Key,
+	).Replace(a.Payload)
+
+	hashType := crypto.HashSHA256
+	if a.Scheme == SchemeHMACSHA512 {
+		hashType = crypto.HashSHA512
+	}
+	hmac := crypto.GetHMAC(hashType, []byte(payload), secret)
+
+	signature := crypto.HexEncodeToString(hmac)
+	if a.Encoding == EncodingBase64 {
+		signature = crypto.Base64Encode(hmac)
+	}
+
+	if a.Placement == PlacementQuery {
+		values.Set(a.SignatureName, signature)
+		return nil
+	}
+	headers[a.KeyName] = g.API.Credentials.Key
+	headers[a.SignatureName] = signature
+	if a.TimestampName != "" {
+		headers[a.TimestampName] = timestamp
+	}
+	return nil
+}
+
+// pairParams returns the placeholder values for a currency pair
+func (g *Generic) pairParams(p currency.Pair, a asset.Item) (map[string]string, error) {
+	fPair, err := g.FormatExchangeCurrency(p, a)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"symbol": fPair.String(),
+		"base":   fPair.Base.String(),
+		"quote":  fPair.Quote.String(),
+		"asset":  a.String(),
+	}, nil
+}
+
+// matchPair resolves an exchange symbol to an available pair
+func (g *Generic) matchPair(symbol string, a asset.Item) (currency.Pair, error) {
+	pairs, err := g.GetAvailablePairs(a)
+	if err != nil {
+		return currency.Pair{}, err
+	}
+	pFmt, err := g.GetPairFormat(a, true)
+	if err != nil {
+		return currency.Pair{}, err
+	}
+	for x := range pairs {
+		if strings.EqualFold(pFmt.Format(pairs[x]), symbol) {
+			return pairs[x], nil
+		}
+	}
+	return currency.Pair{}, fmt.Errorf("%w: %s", errPairNotMatched, symbol)
+}
+
+// parseSymbol parses a pair from an exchange symbol using the spec request
+// format
+func (g *Generic) parseSymbol(symbol string) (currency.Pair, error) {
+	if g.spec.RequestFormat.Delimiter != "" {
+		return currency.NewPairDelimiter(symbol, g.spec.RequestFormat.Delimiter)
+	}
+	return currency.NewPairFromString(symbol)
+}
+
+// placeholders are the template values available to endpoints, any not
+// supplied for a request render empty
+var placeholders = []string{
+	"symbol", "base", "quote", "asset", "interval", "start", "end", "limit",
+	"id", "clientOrderId", "side", "type", "price", "amount", "channel",
+}
+
+func newReplacer(params map[string]string) *strings.Replacer {
+	oldnew := make([]string, 0, len(placeholders)*2)
+	for x := range placeholders {
+		oldnew = append(oldnew, "{"+placeholders[x]+"}", params[placeholders[x]])
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	err := d.Decode(&v)
+	return v, err
+}
+
+// lookup walks a dot separated path through decoded JSON, numeric segments
+// index into arrays
+func lookup(v interface{}, path string) (interface{}, error) {
+	if path == "" {
+		return v, nil
+	}
+	keys := strings.Split(path, ".")
+	for x := range keys {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			val, ok := t[keys[x]]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", errPathNotFound, path)
+			}
+			v = val
+		case []interface{}:
+			idx, err := strconv.Atoi(keys[x])
+			if err != nil || idx < 0 || idx >= len(t) {
+				return nil, fmt.Errorf("%w: %s", errPathNotFound, path)
+			}
+			v = t[idx]
+		default:
+			return nil, fmt.Errorf("%w: %s", errPathNotFound, path)
+		}
+	}
+	return v, nil
+}
+
+// resultItems returns the items at the endpoint result path, a single object
+// is returned as one item
+func resultItems(v interface{}, path string) ([]interface{}, error) {
+	r, err := lookup(v, path)
+	if err != nil {
+		return nil, err
+	}
+	switch t := r.(type) {
+	case []interface{}:
+		return t, nil
+	case nil:
+		return nil, nil
+	default:
+		return []interface{}{t}, nil
+	}
+}
+
+func isEmpty(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case bool:
+		return !t
+	case json.Number:
+		return t.String() == "0"
+	case []interface{}:
+		return len(t) == 0
+	case map[string]interface{}:
+		return len(t) == 0
+	}
+	return false
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch t := v.(type) {
+	case json.Number:
+		return t.Float64()
+	case float64:
+		return t, nil
+	case string:
+		if t == "" {
+			return 0, nil
+		}
+		f, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", errValueNotNumeric, t)
+		}
+		return f, nil
+	case nil:
+		return 0, nil
+	}
+	return 0, fmt.Errorf("%w: %v", errValueNotNumeric, v)
+}
+
+func toString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// toTime converts unix timestamps in seconds, milliseconds, microseconds or
+// nanoseconds and RFC3339 strings
+func toTime(v interface{}) (time.Time, error) {
+	if s, ok := v.(string); ok {
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("%w: %s", errValueNotTimestamp, s)
+			}
+			return t, nil
+		}
+	}
+	f, err := toFloat(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", errValueNotTimestamp, v)
+	}
+	switch {
+	case f > 1e17:
+		return time.Unix(0, int64(f)), nil
+	case f > 1e14:
+		return time.Unix(0, int64(f)*int64(time.Microsecond)), nil
+	case f > 1e11:
+		return time.Unix(0, int64(f)*int64(time.Millisecond)), nil
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*float64(time.Second))), nil
+}
+
+// fieldFloat returns the float for a mapped field, unmapped fields are zero
+func fieldFloat(item interface{}, fields map[string]string, name string) (float64, error) {
+	path, ok := fields[name]
+	if !ok {
+		return 0, nil
+	}
+	v, err := lookup(item, path)
+	if err != nil {
+		return 0, err
+	}
+	f, err := toFloat(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s %w", name, err)
+	}
+	return f, nil
+}
+
+// fieldString returns the string for a mapped field, unmapped fields are
+// empty
+func fieldString(item interface{}, fields map[string]string, name string) (string, error) {
+	path, ok := fields[name]
+	if !ok {
+		return "", nil
+	}
+	v, err := lookup(item, path)
+	if err != nil {
+		return "", err
+	}
+	return toString(v), nil
+}
+
+// fieldTime returns the time for a mapped field, unmapped fields are zero
+func fieldTime(item interface{}, fields map[string]string, name string) (time.Time, error) {
+	path, ok := fields[name]
+	if !ok {
+		return time.Time{}, nil
+	}
+	v, err := lookup(item, path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := toTime(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s %w", name, err)
+	}
+	return t, nil
+}
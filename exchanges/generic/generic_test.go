@@ -0,0 +1,602 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	testAPIKey    = "key"
+	testAPISecret = "secret"
+)
+
+const testSpec = `{
+	"name": "Spectest",
+	"requestFormat": {"uppercase": true, "delimiter": "-"},
+	"configFormat": {"uppercase": true, "delimiter": "-"},
+	"orderSides": {"BUY": "bid", "SELL": "ask"},
+	"rest": {
+		"url": "http://localhost",
+		"errorPath": "error",
+		"endpoints": {
+			"pairs": {"path": "/symbols", "result": "data", "fields": {"symbol": "name"}},
+			"ticker": {
+				"path": "/ticker",
+				"query": {"symbol": "{symbol}"},
+				"result": "data",
+				"fields": {"last": "last", "high": "high", "low": "low", "bid": "bid", "ask": "ask", "volume": "vol", "timestamp": "ts"}
+			},
+			"orderbook": {
+				"path": "/book/{symbol}",
+				"fields": {"bids": "bids", "asks": "asks", "price": "0", "amount": "1"}
+			},
+			"trades": {
+				"path": "/trades",
+				"query": {"symbol": "{symbol}", "limit": "{limit}"},
+				"limit": 2,
+				"fields": {"id": "id", "price": "price", "amount": "qty", "side": "side", "timestamp": "time"}
+			},
+			"candles": {
+				"path": "/candles/{symbol}",
+				"query": {"interval": "{interval}", "from": "{start}", "to": "{end}"},
+				"intervals": {"onehour": "1h"},
+				"limit": 100,
+				"fields": {"time": "0", "open": "1", "high": "2", "low": "3", "close": "4", "volume": "5"}
+			},
+			"account": {
+				"path": "/balances",
+				"authenticated": true,
+				"fields": {"currency": "asset", "total": "total", "available": "free"}
+			},
+			"submitOrder": {
+				"method": "POST",
+				"path": "/order",
+				"authenticated": true,
+				"body": {"symbol": "{symbol}", "side": "{side}", "type": "{type}", "price": "{price}", "qty": "{amount}", "clientId": "{clientOrderId}"},
+				"fields": {"id": "orderId"}
+			},
+			"cancelOrder": {
+				"method": "DELETE",
+				"path": "/order/{id}",
+				"authenticated": true
+			},
+			"orderInfo": {
+				"path": "/order/{id}",
+				"authenticated": true,
+				"fields": {"id": "id", "symbol": "symbol", "side": "side", "type": "type", "status": "status", "price": "price", "amount": "qty", "executedAmount": "filled", "timestamp": "time"}
+			},
+			"activeOrders": {
+				"path": "/openOrders",
+				"query": {"symbol": "{symbol}"},
+				"authenticated": true,
+				"fields": {"id": "id", "symbol": "symbol", "side": "side", "price": "price", "amount": "qty", "executedAmount": "filled"}
+			}
+		}
+	},
+	"auth": {
+		"scheme": "hmac-sha256",
+		"payload": "{timestamp}{method}{path}{query}{body}",
+		"keyName": "X-KEY",
+		"signatureName": "X-SIGN",
+		"timestampName": "X-TS"
+	},
+	"websocket": {
+		"url": "ws://localhost",
+		"subscribe": "{\"op\":\"sub\",\"ch\":\"{channel}\",\"s\":\"{symbol}\"}",
+		"ticker": {"channel": "ticker", "match": {"ch": "ticker"}, "symbol": "s", "result": "d", "fields": {"last": "c", "volume": "v"}},
+		"trades": {"channel": "trades", "match": {"ch": "trades"}, "symbol": "s", "result": "d", "fields": {"id": "i", "price": "p", "amount": "q", "timestamp": "t"}}
+	}
+}`
+
+// checkSignature verifies the authenticated request headers against the
+// spec payload template
+func checkSignature(r *http.Request, body string) bool {
+	payload := r.Header.Get("X-TS") + r.Method + r.URL.Path + r.URL.RawQuery + body
+	expected := crypto.HexEncodeToString(crypto.GetHMAC(crypto.HashSHA256, []byte(payload), []byte(testAPISecret)))
+	return r.Header.Get("X-KEY") == testAPIKey && r.Header.Get("X-SIGN") == expected
+}
+
+func testHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	write := func(s string) {
+		_, _ = w.Write([]byte(s))
+	}
+	switch r.URL.Path {
+	case "/symbols":
+		write(`{"data":[{"name":"BTC-USD"},{"name":"ETH-USD"}]}`)
+	case "/ticker":
+		if r.URL.Query().Get("symbol") != "BTC-USD" {
+			write(`{"error":"unknown symbol"}`)
+			return
+		}
+		write(`{"data":{"last":"100.5","high":"110","low":"90","bid":"100","ask":"101","vol":12.5,"ts":1600000000000}}`)
+	case "/book/BTC-USD":
+		write(`{"bids":[["100","1"],["99","2"]],"asks":[["101","1"],["102","3"]]}`)
+	case "/trades":
+		if r.URL.Query().Get("limit") != "2" {
+			write(`{"error":"bad limit"}`)
+			return
+		}
+		write(`[{"id":2,"price":"101","qty":"1","side":"sell","time":"2021-01-01T00:00:01Z"},{"id":1,"price":"100","qty":"0.5","side":"buy","time":"2021-01-01T00:00:00Z"}]`)
+	case "/candles/BTC-USD":
+		if r.URL.Query().Get("interval") != "1h" {
+			write(`{"error":"bad interval"}`)
+			return
+		}
+		write(`[[1609462800,"1","2","0.5","1.5","10"],[1609459200,"1","2","0.5","1.5","10"]]`)
+	case "/balances":
+		if !checkSignature(r, string(body)) {
+			write(`{"error":"bad signature"}`)
+			return
+		}
+		write(`[{"asset":"BTC","free":"1","total":"1.5"}]`)
+	case "/order":
+		var req map[string]string
+		if !checkSignature(r, string(body)) || json.Unmarshal(body, &req) != nil {
+			write(`{"error":"bad signature"}`)
+			return
+		}
+		if _, ok := req["clientId"]; ok || req["side"] != "bid" || req["type"] != "limit" || req["qty"] != "1" || req["price"] != "100" {
+			write(`{"error":"bad order"}`)
+			return
+		}
+		write(`{"orderId":"1234"}`)
+	case "/order/1234":
+		if !checkSignature(r, string(body)) {
+			write(`{"error":"bad signature"}`)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			write(`{}`)
+			return
+		}
+		write(`{"id":"1234","symbol":"BTC-USD","side":"bid","type":"LIMIT","status":"NEW","price":"100","qty":"1","filled":"0.25","time":1609459200}`)
+	case "/openOrders":
+		if !checkSignature(r, string(body)) {
+			write(`{"error":"bad signature"}`)
+			return
+		}
+		write(`[{"id":"1234","symbol":"BTC-USD","side":"ask","price":"100","qty":"1","filled":"0"}]`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var serverURL string
+
+func TestMain(m *testing.M) {
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	serverURL = server.URL
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
+func newTestGeneric(t *testing.T) *Generic {
+	t.Helper()
+	s, err := LoadSpec([]byte(testSpec), "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.REST.URL = serverURL
+
+	g, err := New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := g.GetDefaultConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Enabled = true
+	cfg.API.AuthenticatedSupport = true
+	cfg.API.Credentials.Key = testAPIKey
+	cfg.API.Credentials.Secret = testAPISecret
+	cfg.WebsocketTrafficTimeout = time.Minute
+	cfg.WebsocketResponseCheckTimeout = exchange.DefaultWebsocketResponseCheckTimeout
+	cfg.WebsocketResponseMaxLimit = exchange.DefaultWebsocketResponseMaxLimit
+	cfg.CurrencyPairs.StorePairs(asset.Spot, currency.Pairs{currency.NewPair(currency.BTC, currency.USD)}, true)
+	err = cfg.CurrencyPairs.SetAssetEnabled(asset.Spot, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = g.Setup(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestLoadSpec(t *testing.T) {
+	t.Parallel()
+	_, err := LoadSpec([]byte(testSpec), "toml")
+	if !errors.Is(err, errUnsupportedSpecFormat) {
+		t.Fatalf("received '%v' expected '%v'", err, errUnsupportedSpecFormat)
+	}
+
+	yamlSpec := `
+name: Yamltest
+requestFormat:
+  uppercase: true
+rest:
+  url: https://api.example.com
+  rateLimit:
+    interval: 1s
+    requests: 5
+  endpoints:
+    ticker:
+      path: /ticker/{symbol}
+      fields:
+        last: price
+`
+	s, err := LoadSpec([]byte(yamlSpec), ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != "Yamltest" || !s.RequestFormat.Uppercase || s.REST.RateLimit.Requests != 5 {
+		t.Fatalf("unexpected spec %+v", s)
+	}
+	if s.REST.Endpoints.Ticker == nil || s.REST.Endpoints.Ticker.Fields["last"] != "price" {
+		t.Fatal("ticker endpoint not loaded")
+	}
+	g, err := New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.SetDefaults()
+	if !g.Features.Supports.RESTCapabilities.TickerFetching ||
+		g.Features.Supports.RESTCapabilities.OrderbookFetching {
+		t.Fatal("features should reflect the declared endpoints")
+	}
+}
+
+func TestSpecValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		spec     Spec
+		expected error
+	}{
+		{"no name", Spec{}, errSpecNameEmpty},
+		{"no url", Spec{Name: "test"}, errSpecRESTURLEmpty},
+		{"no path", Spec{Name: "test", REST: RESTSpec{URL: "url", Endpoints: EndpointsSpec{
+			Ticker: &Endpoint{},
+		}}}, errEndpointPathEmpty},
+		{"missing field", Spec{Name: "test", REST: RESTSpec{URL: "url", Endpoints: EndpointsSpec{
+			Ticker: &Endpoint{Path: "/"},
+		}}}, errEndpointFieldMissing},
+		{"no auth", Spec{Name: "test", REST: RESTSpec{URL: "url", Endpoints: EndpointsSpec{
+			CancelOrder: &Endpoint{Path: "/", Authenticated: true},
+		}}}, errAuthNotConfigured},
+		{"bad scheme", Spec{Name: "test", REST: RESTSpec{URL: "url"}, Auth: &AuthSpec{Scheme: "md5"}}, errUnsupportedScheme},
+		{"bad placement", Spec{Name: "test", REST: RESTSpec{URL: "url"}, Auth: &AuthSpec{Scheme: SchemeHMACSHA512, Placement: "body"}}, errUnsupportedPlacement},
+		{"bad encoding", Spec{Name: "test", REST: RESTSpec{URL: "url"}, Auth: &AuthSpec{Scheme: SchemeHMACSHA512, Encoding: "base32"}}, errUnsupportedEncoding},
+		{"websocket", Spec{Name: "test", REST: RESTSpec{URL: "url"}, Websocket: &WebsocketSpec{URL: "url"}}, errEndpointFieldMissing},
+		{"valid", Spec{Name: "test", REST: RESTSpec{URL: "url"}}, nil},
+	}
+	for x := range tests {
+		if err := tests[x].spec.Validate(); !errors.Is(err, tests[x].expected) {
+			t.Errorf("%s: received '%v' expected '%v'", tests[x].name, err, tests[x].expected)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+	v, err := decodeJSON([]byte(`{"a":{"b":[1,{"c":"d"}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := lookup(v, "a.b.1.c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if toString(r) != "d" {
+		t.Fatalf("received '%v' expected 'd'", r)
+	}
+	for _, path := range []string{"a.x", "a.b.5", "a.b.0.c"} {
+		if _, err = lookup(v, path); !errors.Is(err, errPathNotFound) {
+			t.Errorf("%s: received '%v' expected '%v'", path, err, errPathNotFound)
+		}
+	}
+}
+
+func TestToTime(t *testing.T) {
+	t.Parallel()
+	expected := time.Unix(1609459200, 0)
+	for _, v := range []interface{}{
+		json.Number("1609459200"),
+		"1609459200000",
+		json.Number("1609459200000000"),
+		json.Number("1609459200000000000"),
+		"2021-01-01T00:00:00Z",
+	} {
+		tm, err := toTime(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !tm.Equal(expected) {
+			t.Errorf("%v: received '%v' expected '%v'", v, tm, expected)
+		}
+	}
+	if _, err := toTime("yesterday"); !errors.Is(err, errValueNotTimestamp) {
+		t.Fatalf("received '%v' expected '%v'", err, errValueNotTimestamp)
+	}
+}
+
+func TestIBotExchange(t *testing.T) {
+	t.Parallel()
+	var _ exchange.IBotExchange = &Generic{}
+}
+
+func TestSetupNameMismatch(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	cfg := *g.Config
+	cfg.Name = "Different"
+	if err := g.Setup(&cfg); !errors.Is(err, errSpecNameMismatch) {
+		t.Fatalf("received '%v' expected '%v'", err, errSpecNameMismatch)
+	}
+}
+
+func TestUpdateTradablePairs(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	err := g.UpdateTradablePairs(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs, err := g.GetAvailablePairs(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairs) != 2 || !pairs.Contains(currency.NewPair(currency.ETH, currency.USD), true) {
+		t.Fatalf("unexpected pairs %v", pairs)
+	}
+}
+
+func TestUpdateTicker(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	p := currency.NewPair(currency.BTC, currency.USD)
+	tick, err := g.UpdateTicker(p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tick.Last != 100.5 || tick.Volume != 12.5 || tick.Bid != 100 || tick.Ask != 101 {
+		t.Fatalf("unexpected ticker %+v", tick)
+	}
+	if !tick.LastUpdated.Equal(time.Unix(1600000000, 0)) {
+		t.Fatalf("received '%v' expected '%v'", tick.LastUpdated, time.Unix(1600000000, 0))
+	}
+
+	_, err = g.UpdateTicker(currency.NewPair(currency.ETH, currency.USD), asset.Spot)
+	if !errors.Is(err, errExchangeError) {
+		t.Fatalf("received '%v' expected '%v'", err, errExchangeError)
+	}
+}
+
+func TestUpdateOrderbook(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	ob, err := g.UpdateOrderbook(currency.NewPair(currency.BTC, currency.USD), asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ob.Bids) != 2 || len(ob.Asks) != 2 || ob.Bids[1].Price != 99 || ob.Asks[1].Amount != 3 {
+		t.Fatalf("unexpected orderbook %+v", ob)
+	}
+}
+
+func TestGetRecentTrades(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	trades, err := g.GetRecentTrades(currency.NewPair(currency.BTC, currency.USD), asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 2 {
+		t.Fatalf("received '%v' trades expected '2'", len(trades))
+	}
+	if trades[0].TID != "1" || trades[0].Side != order.Buy || trades[0].Amount != 0.5 {
+		t.Fatalf("unexpected trade %+v", trades[0])
+	}
+}
+
+func TestGetHistoricCandles(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	p := currency.NewPair(currency.BTC, currency.USD)
+	start := time.Unix(1609459200, 0)
+	end := start.Add(time.Hour * 2)
+	_, err := g.GetHistoricCandles(p, asset.Spot, start, end, kline.OneMin)
+	if err == nil {
+		t.Fatal("expected error for unmapped interval")
+	}
+	k, err := g.GetHistoricCandles(p, asset.Spot, start, end, kline.OneHour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(k.Candles) != 2 || !k.Candles[0].Time.Equal(start) || k.Candles[0].Close != 1.5 {
+		t.Fatalf("unexpected candles %+v", k.Candles)
+	}
+	if g.FormatExchangeKlineInterval(kline.OneHour) != "1h" {
+		t.Fatal("interval should be mapped from spec")
+	}
+}
+
+func TestUpdateAccountInfo(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	acc, err := g.UpdateAccountInfo(asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(acc.Accounts) != 1 || len(acc.Accounts[0].Currencies) != 1 {
+		t.Fatalf("unexpected holdings %+v", acc)
+	}
+	bal := acc.Accounts[0].Currencies[0]
+	if bal.CurrencyName != currency.BTC || bal.TotalValue != 1.5 || bal.Hold != 0.5 {
+		t.Fatalf("unexpected balance %+v", bal)
+	}
+
+	g.API.Credentials.Secret = "wrong"
+	_, err = g.UpdateAccountInfo(asset.Spot)
+	if !errors.Is(err, errExchangeError) {
+		t.Fatalf("received '%v' expected '%v'", err, errExchangeError)
+	}
+}
+
+func TestSubmitOrder(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	resp, err := g.SubmitOrder(&order.Submit{
+		Exchange:  g.Name,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Price:     100,
+		Amount:    1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.IsOrderPlaced || resp.OrderID != "1234" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	err := g.CancelOrder(&order.Cancel{
+		Exchange:  g.Name,
+		ID:        "1234",
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		AssetType: asset.Spot,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetOrderInfo(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	p := currency.NewPair(currency.BTC, currency.USD)
+	d, err := g.GetOrderInfo("1234", p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.ID != "1234" || d.Side != order.Buy || d.Type != order.Limit || d.Status != order.New {
+		t.Fatalf("unexpected order %+v", d)
+	}
+	if d.ExecutedAmount != 0.25 || d.RemainingAmount != 0.75 || !d.Pair.Equal(p) {
+		t.Fatalf("unexpected order %+v", d)
+	}
+}
+
+func TestGetActiveOrders(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	orders, err := g.GetActiveOrders(&order.GetOrdersRequest{AssetType: asset.Spot})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 || orders[0].Side != order.Sell {
+		t.Fatalf("unexpected orders %+v", orders)
+	}
+}
+
+func TestSignQueryPlacement(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	g.spec.Auth = &AuthSpec{
+		Scheme:        SchemeHMACSHA512,
+		Placement:     PlacementQuery,
+		Encoding:      EncodingBase64,
+		Payload:       "{query}",
+		KeyName:       "apiKey",
+		SignatureName: "signature",
+		TimestampName: "timestamp",
+		TimestampUnit: "s",
+	}
+	values := make(map[string][]string)
+	headers := make(map[string]string)
+	err := g.sign("GET", "/", values, "", headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 0 {
+		t.Fatal("query placement should not set headers")
+	}
+	if values["apiKey"][0] != testAPIKey || values["timestamp"][0] == "" {
+		t.Fatalf("unexpected values %v", values)
+	}
+	payload := "apiKey=" + testAPIKey + "&timestamp=" + values["timestamp"][0]
+	expected := crypto.Base64Encode(crypto.GetHMAC(crypto.HashSHA512, []byte(payload), []byte(testAPISecret)))
+	if values["signature"][0] != expected {
+		t.Fatalf("received '%v' expected '%v'", values["signature"][0], expected)
+	}
+}
+
+func TestWsHandleData(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- g.wsHandleData([]byte(`{"ch":"ticker","s":"BTC-USD","d":{"c":"100.1","v":"5"}}`))
+	}()
+	select {
+	case data := <-g.Websocket.DataHandler:
+		tick, ok := data.(*ticker.Price)
+		if !ok {
+			t.Fatalf("received '%T' expected '*ticker.Price'", data)
+		}
+		if tick.Last != 100.1 || !tick.Pair.Equal(currency.NewPair(currency.BTC, currency.USD)) {
+			t.Fatalf("unexpected ticker %+v", tick)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for ticker")
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+
+	err := g.wsHandleData([]byte(`{"ch":"trades","s":"BTC-USD","d":[{"i":1,"p":"100","q":"1","t":1609459200000}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = g.wsHandleData([]byte(`{"ch":"ticker","s":"DOGE-USD","d":{"c":"1"}}`))
+	if !errors.Is(err, errPairNotMatched) {
+		t.Fatalf("received '%v' expected '%v'", err, errPairNotMatched)
+	}
+}
+
+func TestGenerateSubscriptions(t *testing.T) {
+	t.Parallel()
+	g := newTestGeneric(t)
+	subs, err := g.generateSubscriptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 2 {
+		t.Fatalf("received '%v' subscriptions expected '2'", len(subs))
+	}
+}
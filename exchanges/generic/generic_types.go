@@ -0,0 +1,153 @@
+package generic
+
+import (
+	"errors"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// Supported signing schemes
+const (
+	SchemeHMACSHA256 = "hmac-sha256"
+	SchemeHMACSHA512 = "hmac-sha512"
+)
+
+// Supported signature placements
+const (
+	PlacementHeader = "header"
+	PlacementQuery  = "query"
+)
+
+// Supported signature encodings
+const (
+	EncodingHex    = "hex"
+	EncodingBase64 = "base64"
+)
+
+// Supported request body encodings
+const (
+	BodyJSON = "json"
+	BodyForm = "form"
+)
+
+var (
+	errSpecNameEmpty         = errors.New("spec exchange name cannot be empty")
+	errSpecRESTURLEmpty      = errors.New("spec REST url cannot be empty")
+	errEndpointPathEmpty     = errors.New("endpoint path cannot be empty")
+	errEndpointFieldMissing  = errors.New("endpoint is missing required field mapping")
+	errUnsupportedScheme     = errors.New("unsupported signing scheme")
+	errUnsupportedPlacement  = errors.New("unsupported signature placement")
+	errUnsupportedEncoding   = errors.New("unsupported encoding")
+	errUnsupportedSpecFormat = errors.New("unsupported spec file format")
+	errAuthNotConfigured     = errors.New("spec does not define authentication")
+	errPathNotFound          = errors.New("path not found in response")
+	errValueNotNumeric       = errors.New("value is not numeric")
+	errValueNotTimestamp     = errors.New("value is not a timestamp")
+	errExchangeError         = errors.New("exchange returned an error")
+	errPairNotMatched        = errors.New("symbol does not match an available pair")
+	errSpecNameMismatch      = errors.New("spec exchange name does not match config name")
+	errIntervalNotMapped     = errors.New("interval not mapped in spec")
+)
+
+// Spec declares how an exchange API maps onto GoCryptoTrader types. It can be
+// loaded from JSON or YAML
+type Spec struct {
+	Name          string              `json:"name"`
+	Assets        []string            `json:"assets,omitempty"`
+	RequestFormat currency.PairFormat `json:"requestFormat"`
+	ConfigFormat  currency.PairFormat `json:"configFormat"`
+	OrderSides    map[string]string   `json:"orderSides,omitempty"`
+	OrderTypes    map[string]string   `json:"orderTypes,omitempty"`
+	REST          RESTSpec            `json:"rest"`
+	Auth          *AuthSpec           `json:"auth,omitempty"`
+	Websocket     *WebsocketSpec      `json:"websocket,omitempty"`
+}
+
+// RESTSpec defines the REST API base URL, limits and endpoints
+type RESTSpec struct {
+	URL       string        `json:"url"`
+	RateLimit RateLimitSpec `json:"rateLimit"`
+	// ErrorPath is checked on every response, a non empty value is returned
+	// as an error
+	ErrorPath string        `json:"errorPath,omitempty"`
+	Endpoints EndpointsSpec `json:"endpoints"`
+}
+
+// RateLimitSpec defines a basic rate limit of requests per interval
+type RateLimitSpec struct {
+	Interval string `json:"interval,omitempty"`
+	Requests int    `json:"requests,omitempty"`
+}
+
+// EndpointsSpec holds the REST endpoints that back wrapper functionality,
+// unset endpoints are reported as unsupported
+type EndpointsSpec struct {
+	Pairs        *Endpoint `json:"pairs,omitempty"`
+	Ticker       *Endpoint `json:"ticker,omitempty"`
+	Orderbook    *Endpoint `json:"orderbook,omitempty"`
+	Trades       *Endpoint `json:"trades,omitempty"`
+	Candles      *Endpoint `json:"candles,omitempty"`
+	Account      *Endpoint `json:"account,omitempty"`
+	SubmitOrder  *Endpoint `json:"submitOrder,omitempty"`
+	CancelOrder  *Endpoint `json:"cancelOrder,omitempty"`
+	OrderInfo    *Endpoint `json:"orderInfo,omitempty"`
+	ActiveOrders *Endpoint `json:"activeOrders,omitempty"`
+}
+
+// Endpoint defines a single REST call. Path, query and body values may contain
+// placeholders such as {symbol}, {base}, {quote}, {asset}, {interval}, {start},
+// {end}, {limit}, {id}, {clientOrderId}, {side}, {type}, {price} and {amount}.
+// Query and body values which render empty are omitted
+type Endpoint struct {
+	Method        string            `json:"method,omitempty"`
+	Path          string            `json:"path"`
+	Query         map[string]string `json:"query,omitempty"`
+	Body          map[string]string `json:"body,omitempty"`
+	BodyEncoding  string            `json:"bodyEncoding,omitempty"`
+	Authenticated bool              `json:"authenticated,omitempty"`
+	// Result is the path to the payload within the response, an empty path
+	// uses the whole response
+	Result string `json:"result,omitempty"`
+	// Fields maps GoCryptoTrader field names to paths relative to each
+	// result item
+	Fields map[string]string `json:"fields"`
+	// Intervals maps kline interval words e.g. "onemin" to the exchange value
+	Intervals map[string]string `json:"intervals,omitempty"`
+	Limit     int64             `json:"limit,omitempty"`
+}
+
+// AuthSpec defines how authenticated requests are signed. The payload is a
+// template built from {timestamp}, {method}, {path}, {query}, {body} and {key}
+type AuthSpec struct {
+	Scheme        string `json:"scheme"`
+	Placement     string `json:"placement,omitempty"`
+	Encoding      string `json:"encoding,omitempty"`
+	Payload       string `json:"payload"`
+	KeyName       string `json:"keyName"`
+	SignatureName string `json:"signatureName"`
+	TimestampName string `json:"timestampName,omitempty"`
+	// TimestampUnit is either "s" or "ms", defaults to "ms"
+	TimestampUnit string `json:"timestampUnit,omitempty"`
+	// SecretBase64 decodes the API secret before signing
+	SecretBase64 bool `json:"secretBase64,omitempty"`
+}
+
+// WebsocketSpec defines a websocket feed for public market data. Subscribe
+// and unsubscribe messages are templates rendered with {channel} and {symbol}
+type WebsocketSpec struct {
+	URL         string            `json:"url"`
+	Subscribe   string            `json:"subscribe"`
+	Unsubscribe string            `json:"unsubscribe,omitempty"`
+	Ticker      *WebsocketChannel `json:"ticker,omitempty"`
+	Trades      *WebsocketChannel `json:"trades,omitempty"`
+}
+
+// WebsocketChannel routes inbound messages to a data type. A message is
+// handled by the channel when every Match path equals its value
+type WebsocketChannel struct {
+	Channel string            `json:"channel"`
+	Match   map[string]string `json:"match"`
+	Symbol  string            `json:"symbol"`
+	Result  string            `json:"result,omitempty"`
+	Fields  map[string]string `json:"fields"`
+}
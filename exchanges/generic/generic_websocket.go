@@ -0,0 +1,219 @@
+package generic
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// WsConnect connects to the websocket feed declared by the spec
+func (g *Generic) WsConnect() error {
+	if !g.Websocket.IsEnabled() || !g.IsEnabled() {
+		return errors.New(stream.WebsocketNotEnabled)
+	}
+	var dialer websocket.Dialer
+	err := g.Websocket.Conn.Dial(&dialer, http.Header{})
+	if err != nil {
+		return err
+	}
+	if g.Verbose {
+		log.Debugf(log.ExchangeSys, "%s Connected to Websocket.\n", g.Name)
+	}
+	go g.wsReadData()
+	return nil
+}
+
+// wsReadData receives and passes on websocket messages for processing
+func (g *Generic) wsReadData() {
+	g.Websocket.Wg.Add(1)
+	defer g.Websocket.Wg.Done()
+
+	for {
+		resp := g.Websocket.Conn.ReadMessage()
+		if resp.Raw == nil {
+			return
+		}
+		err := g.wsHandleData(resp.Raw)
+		if err != nil {
+			g.Websocket.DataHandler <- err
+		}
+	}
+}
+
+// wsHandleData routes a message to the first spec channel it matches
+func (g *Generic) wsHandleData(respRaw []byte) error {
+	msg, err := decodeJSON(respRaw)
+	if err != nil {
+		return err
+	}
+
+	ws := g.spec.Websocket
+	switch {
+	case ws.Ticker != nil && channelMatches(ws.Ticker, msg):
+		a, p, item, err := g.wsChannelData(ws.Ticker, msg)
+		if err != nil {
+			return err
+		}
+		tick, err := parseTicker(item, ws.Ticker.Fields)
+		if err != nil {
+			return err
+		}
+		tick.Pair = p
+		tick.AssetType = a
+		tick.ExchangeName = g.Name
+		g.Websocket.DataHandler <- tick
+	case ws.Trades != nil && channelMatches(ws.Trades, msg):
+		if !g.IsTradeProcessingRequired() {
+			return nil
+		}
+		a, p, root, err := g.wsChannelData(ws.Trades, msg)
+		if err != nil {
+			return err
+		}
+		items, err := resultItems(root, "")
+		if err != nil {
+			return err
+		}
+		trades := make([]trade.Data, len(items))
+		for x := range items {
+			trades[x], err = parseTrade(items[x], ws.Trades.Fields)
+			if err != nil {
+				return err
+			}
+			trades[x].Exchange = g.Name
+			trades[x].CurrencyPair = p
+			trades[x].AssetType = a
+		}
+		return g.AddTradesToBuffer(trades...)
+	default:
+		g.Websocket.DataHandler <- stream.UnhandledMessageWarning{Message: g.Name + stream.UnhandledMessage + string(respRaw)}
+	}
+	return nil
+}
+
+// wsChannelData resolves the pair and payload for a matched message
+func (g *Generic) wsChannelData(c *WebsocketChannel, msg interface{}) (asset.Item, currency.Pair, interface{}, error) {
+	symbol, err := lookup(msg, c.Symbol)
+	if err != nil {
+		return "", currency.Pair{}, nil, err
+	}
+	assets := g.GetAssetTypes()
+	var p currency.Pair
+	var a asset.Item
+	for x := range assets {
+		p, err = g.matchPair(toString(symbol), assets[x])
+		if err == nil {
+			a = assets[x]
+			break
+		}
+	}
+	if err != nil {
+		return "", currency.Pair{}, nil, err
+	}
+	item, err := lookup(msg, c.Result)
+	if err != nil {
+		return "", currency.Pair{}, nil, err
+	}
+	return a, p, item, nil
+}
+
+// channelMatches reports whether every match path in the channel equals the
+// message value
+func channelMatches(c *WebsocketChannel, msg interface{}) bool {
+	if len(c.Match) == 0 {
+		return false
+	}
+	for path, want := range c.Match {
+		v, err := lookup(msg, path)
+		if err != nil || !strings.EqualFold(toString(v), want) {
+			return false
+		}
+	}
+	return true
+}
+
+// generateSubscriptions returns a subscription per channel and enabled pair
+func (g *Generic) generateSubscriptions() ([]stream.ChannelSubscription, error) {
+	var channels []string
+	if g.spec.Websocket.Ticker != nil {
+		channels = append(channels, g.spec.Websocket.Ticker.Channel)
+	}
+	if g.spec.Websocket.Trades != nil {
+		channels = append(channels, g.spec.Websocket.Trades.Channel)
+	}
+	var subscriptions []stream.ChannelSubscription
+	assets := g.GetAssetTypes()
+	for x := range assets {
+		if !g.IsAssetWebsocketSupported(assets[x]) {
+			continue
+		}
+		pairs, err := g.GetEnabledPairs(assets[x])
+		if err != nil {
+			return nil, err
+		}
+		for y := range channels {
+			for z := range pairs {
+				subscriptions = append(subscriptions, stream.ChannelSubscription{
+					Channel:  channels[y],
+					Currency: pairs[z],
+					Asset:    assets[x],
+				})
+			}
+		}
+	}
+	return subscriptions, nil
+}
+
+// Subscribe sends the spec subscribe message for each channel
+func (g *Generic) Subscribe(channelsToSubscribe []stream.ChannelSubscription) error {
+	var errs common.Errors
+	for i := range channelsToSubscribe {
+		err := g.sendSubscription(g.spec.Websocket.Subscribe, &channelsToSubscribe[i])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		g.Websocket.AddSuccessfulSubscriptions(channelsToSubscribe[i])
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// Unsubscribe sends the spec unsubscribe message for each channel
+func (g *Generic) Unsubscribe(channelsToUnsubscribe []stream.ChannelSubscription) error {
+	if g.spec.Websocket.Unsubscribe == "" {
+		return common.ErrFunctionNotSupported
+	}
+	var errs common.Errors
+	for i := range channelsToUnsubscribe {
+		err := g.sendSubscription(g.spec.Websocket.Unsubscribe, &channelsToUnsubscribe[i])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		g.Websocket.RemoveSuccessfulUnsubscriptions(channelsToUnsubscribe[i])
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+func (g *Generic) sendSubscription(tmpl string, sub *stream.ChannelSubscription) error {
+	params, err := g.pairParams(sub.Currency, sub.Asset)
+	if err != nil {
+		return err
+	}
+	params["channel"] = sub.Channel
+	return g.Websocket.Conn.SendRawMessage(websocket.TextMessage, []byte(newReplacer(params).Replace(tmpl)))
+}
@@ -0,0 +1,1066 @@
+package generic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/position"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// GetDefaultConfig returns a default exchange config
+func (g *Generic) GetDefaultConfig() (*config.ExchangeConfig, error) {
+	g.SetDefaults()
+	exchCfg := new(config.ExchangeConfig)
+	exchCfg.Name = g.Name
+	exchCfg.HTTPTimeout = exchange.DefaultHTTPTimeout
+	exchCfg.BaseCurrencies = g.BaseCurrencies
+
+	err := g.SetupDefaults(exchCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.Features.Supports.RESTCapabilities.AutoPairUpdates {
+		err = g.UpdateTradablePairs(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return exchCfg, nil
+}
+
+// SetDefaults sets the exchange defaults from the spec
+func (g *Generic) SetDefaults() {
+	g.Name = g.spec.Name
+	g.Enabled = true
+	g.Verbose = true
+	if g.spec.Auth != nil {
+		g.API.CredentialsValidator.RequiresKey = true
+		g.API.CredentialsValidator.RequiresSecret = true
+	}
+
+	requestFmt := g.spec.RequestFormat
+	configFmt := g.spec.ConfigFormat
+	err := g.SetGlobalPairsManager(&requestFmt, &configFmt, g.spec.assetTypes()...)
+	if err != nil {
+		log.Errorln(log.ExchangeSys, err)
+	}
+
+	ep := &g.spec.REST.Endpoints
+	var intervals map[string]bool
+	if ep.Candles != nil {
+		intervals = make(map[string]bool)
+		for word := range ep.Candles.Intervals {
+			intervals[word] = true
+		}
+	}
+	g.Features = exchange.Features{
+		Supports: exchange.FeaturesSupported{
+			REST:      true,
+			Websocket: g.spec.Websocket != nil,
+			RESTCapabilities: protocol.Features{
+				AutoPairUpdates:   ep.Pairs != nil,
+				TickerFetching:    ep.Ticker != nil,
+				OrderbookFetching: ep.Orderbook != nil,
+				TradeFetching:     ep.Trades != nil,
+				KlineFetching:     ep.Candles != nil,
+				AccountInfo:       ep.Account != nil,
+				SubmitOrder:       ep.SubmitOrder != nil,
+				CancelOrder:       ep.CancelOrder != nil,
+				GetOrder:          ep.OrderInfo != nil,
+				GetOrders:         ep.ActiveOrders != nil,
+			},
+			Kline: kline.ExchangeCapabilitiesSupported{
+				Intervals: ep.Candles != nil,
+			},
+		},
+		Enabled: exchange.FeaturesEnabled{
+			AutoPairUpdates: ep.Pairs != nil,
+			Kline: kline.ExchangeCapabilitiesEnabled{
+				Intervals: intervals,
+			},
+		},
+	}
+	if ep.Candles != nil {
+		g.Features.Enabled.Kline.ResultLimit = uint32(ep.Candles.Limit)
+	}
+
+	var opts []request.RequesterOption
+	if rl := g.spec.REST.RateLimit; rl.Interval != "" && rl.Requests > 0 {
+		interval, _ := time.ParseDuration(rl.Interval) // Already validated
+		opts = append(opts, request.WithLimiter(request.NewBasicRateLimit(interval, rl.Requests)))
+	}
+	g.Requester = request.New(g.Name,
+		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout),
+		opts...)
+
+	urls := map[exchange.URL]string{
+		exchange.RestSpot: g.spec.REST.URL,
+	}
+	if g.spec.Websocket != nil {
+		g.Features.Supports.WebsocketCapabilities = protocol.Features{
+			TickerFetching: g.spec.Websocket.Ticker != nil,
+			TradeFetching:  g.spec.Websocket.Trades != nil,
+			Subscribe:      true,
+			Unsubscribe:    g.spec.Websocket.Unsubscribe != "",
+		}
+		urls[exchange.WebsocketSpot] = g.spec.Websocket.URL
+	}
+	g.API.Endpoints = g.NewEndpoints()
+	err = g.API.Endpoints.SetDefaultEndpoints(urls)
+	if err != nil {
+		log.Errorln(log.ExchangeSys, err)
+	}
+
+	if g.spec.Websocket != nil {
+		g.Websocket = stream.New()
+		g.WebsocketResponseMaxLimit = exchange.DefaultWebsocketResponseMaxLimit
+		g.WebsocketResponseCheckTimeout = exchange.DefaultWebsocketResponseCheckTimeout
+		g.WebsocketOrderbookBufferLimit = exchange.DefaultWebsocketOrderbookBufferLimit
+	}
+}
+
+// Setup takes in the supplied exchange configuration details and sets params
+func (g *Generic) Setup(exch *config.ExchangeConfig) error {
+	if !exch.Enabled {
+		g.SetEnabled(false)
+		return nil
+	}
+	if !strings.EqualFold(exch.Name, g.spec.Name) {
+		return fmt.Errorf("%w: %s %s", errSpecNameMismatch, g.spec.Name, exch.Name)
+	}
+
+	err := g.SetupDefaults(exch)
+	if err != nil {
+		return err
+	}
+
+	if g.spec.Websocket == nil {
+		return nil
+	}
+
+	wsRunningEndpoint, err := g.API.Endpoints.GetURL(exchange.WebsocketSpot)
+	if err != nil {
+		return err
+	}
+
+	err = g.Websocket.Setup(&stream.WebsocketSetup{
+		Enabled:                          exch.Features.Enabled.Websocket,
+		Verbose:                          exch.Verbose,
+		AuthenticatedWebsocketAPISupport: exch.API.AuthenticatedWebsocketSupport,
+		WebsocketTimeout:                 exch.WebsocketTrafficTimeout,
+		DefaultURL:                       g.spec.Websocket.URL,
+		ExchangeName:                     exch.Name,
+		RunningURL:                       wsRunningEndpoint,
+		Connector:                        g.WsConnect,
+		Subscriber:                       g.Subscribe,
+		UnSubscriber:                     g.Unsubscribe,
+		GenerateSubscriptions:            g.generateSubscriptions,
+		Features:                         &g.Features.Supports.WebsocketCapabilities,
+		OrderbookBufferLimit:             exch.OrderbookConfig.WebsocketBufferLimit,
+		BufferEnabled:                    exch.OrderbookConfig.WebsocketBufferEnabled,
+	})
+	if err != nil {
+		return err
+	}
+
+	return g.Websocket.SetupNewConnection(stream.ConnectionSetup{
+		URL:                  g.Websocket.GetWebsocketURL(),
+		ResponseCheckTimeout: exch.WebsocketResponseCheckTimeout,
+		ResponseMaxLimit:     exch.WebsocketResponseMaxLimit,
+	})
+}
+
+// Start starts the generic exchange go routine
+func (g *Generic) Start(wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		g.Run()
+		wg.Done()
+	}()
+}
+
+// Run implements the generic exchange wrapper
+func (g *Generic) Run() {
+	if g.Verbose {
+		if g.Websocket != nil {
+			log.Debugf(log.ExchangeSys,
+				"%s Websocket: %s.",
+				g.Name,
+				common.IsEnabled(g.Websocket.IsEnabled()))
+		}
+		g.PrintEnabledPairs()
+	}
+
+	if !g.GetEnabledFeatures().AutoPairUpdates {
+		return
+	}
+
+	err := g.UpdateTradablePairs(false)
+	if err != nil {
+		log.Errorf(log.ExchangeSys,
+			"%s failed to update tradable pairs. Err: %s",
+			g.Name,
+			err)
+	}
+}
+
+// FetchTradablePairs returns a list of the exchanges tradable pairs
+func (g *Generic) FetchTradablePairs(a asset.Item) ([]string, error) {
+	e := g.spec.REST.Endpoints.Pairs
+	if e == nil {
+		return nil, common.ErrFunctionNotSupported
+	}
+	resp, err := g.sendRequest(e, map[string]string{"asset": a.String()})
+	if err != nil {
+		return nil, err
+	}
+	items, err := resultItems(resp, e.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]string, 0, len(items))
+	for x := range items {
+		var p currency.Pair
+		_, hasBase := e.Fields["base"]
+		_, hasQuote := e.Fields["quote"]
+		if hasBase && hasQuote {
+			var base, quote string
+			base, err = fieldString(items[x], e.Fields, "base")
+			if err != nil {
+				return nil, err
+			}
+			quote, err = fieldString(items[x], e.Fields, "quote")
+			if err != nil {
+				return nil, err
+			}
+			p = currency.NewPair(currency.NewCode(base), currency.NewCode(quote))
+		} else {
+			var symbol interface{}
+			symbol, err = lookup(items[x], e.Fields["symbol"])
+			if err != nil {
+				return nil, err
+			}
+			p, err = g.parseSymbol(toString(symbol))
+			if err != nil {
+				return nil, err
+			}
+		}
+		pairs = append(pairs, p.Base.String()+currency.DashDelimiter+p.Quote.String())
+	}
+	return pairs, nil
+}
+
+// UpdateTradablePairs updates the exchanges available pairs and stores
+// them in the exchanges config
+func (g *Generic) UpdateTradablePairs(forceUpdate bool) error {
+	assets := g.GetAssetTypes()
+	for x := range assets {
+		pairs, err := g.FetchTradablePairs(assets[x])
+		if err != nil {
+			return err
+		}
+		p, err := currency.NewPairsFromStrings(pairs)
+		if err != nil {
+			return err
+		}
+		err = g.UpdatePairs(p, assets[x], false, forceUpdate)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateTicker updates and returns the ticker for a currency pair
+func (g *Generic) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
+	e := g.spec.REST.Endpoints.Ticker
+	if e == nil {
+		return nil, common.ErrFunctionNotSupported
+	}
+	params, err := g.pairParams(p, assetType)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.sendRequest(e, params)
+	if err != nil {
+		return nil, err
+	}
+	item, err := lookup(resp, e.Result)
+	if err != nil {
+		return nil, err
+	}
+	tick, err := parseTicker(item, e.Fields)
+	if err != nil {
+		return nil, err
+	}
+	tick.Pair = p
+	tick.ExchangeName = g.Name
+	tick.AssetType = assetType
+	err = g.GetTickerService().ProcessTicker(tick)
+	if err != nil {
+		return nil, err
+	}
+	return g.GetTickerService().GetTicker(g.Name, p, assetType)
+}
+
+// FetchTicker returns the ticker for a currency pair
+func (g *Generic) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
+	tickerNew, err := g.GetTickerService().GetTicker(g.Name, p, assetType)
+	if err != nil {
+		return g.UpdateTicker(p, assetType)
+	}
+	return tickerNew, nil
+}
+
+// FetchOrderbook returns orderbook base on the currency pair
+func (g *Generic) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
+	ob, err := g.GetOrderbookService().Retrieve(g.Name, p, assetType)
+	if err != nil {
+		return g.UpdateOrderbook(p, assetType)
+	}
+	return ob, nil
+}
+
+// UpdateOrderbook updates and returns the orderbook for a currency pair
+func (g *Generic) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
+	e := g.spec.REST.Endpoints.Orderbook
+	if e == nil {
+		return nil, common.ErrFunctionNotSupported
+	}
+	book := &orderbook.Base{
+		Exchange:        g.Name,
+		Pair:            p,
+		Asset:           assetType,
+		VerifyOrderbook: g.CanVerifyOrderbook,
+	}
+	params, err := g.pairParams(p, assetType)
+	if err != nil {
+		return book, err
+	}
+	resp, err := g.sendRequest(e, params)
+	if err != nil {
+		return book, err
+	}
+	root, err := lookup(resp, e.Result)
+	if err != nil {
+		return book, err
+	}
+	book.Bids, err = parseLevels(root, e.Fields, "bids")
+	if err != nil {
+		return book, err
+	}
+	book.Asks, err = parseLevels(root, e.Fields, "asks")
+	if err != nil {
+		return book, err
+	}
+	err = g.GetOrderbookService().Process(book)
+	if err != nil {
+		return book, err
+	}
+	return g.GetOrderbookService().Retrieve(g.Name, p, assetType)
+}
+
+// UpdateAccountInfo retrieves balances for all enabled currencies
+func (g *Generic) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error) {
+	e := g.spec.REST.Endpoints.Account
+	if e == nil {
+		return account.Holdings{}, common.ErrFunctionNotSupported
+	}
+	resp, err := g.sendRequest(e, map[string]string{"asset": assetType.String()})
+	if err != nil {
+		return account.Holdings{}, err
+	}
+	items, err := resultItems(resp, e.Result)
+	if err != nil {
+		return account.Holdings{}, err
+	}
+
+	_, hasAvailable := e.Fields["available"]
+	balances := make([]account.Balance, 0, len(items))
+	for x := range items {
+		var code string
+		code, err = fieldString(items[x], e.Fields, "currency")
+		if err != nil {
+			return account.Holdings{}, err
+		}
+		var total, hold float64
+		total, err = fieldFloat(items[x], e.Fields, "total")
+		if err != nil {
+			return account.Holdings{}, err
+		}
+		if hasAvailable {
+			var available float64
+			available, err = fieldFloat(items[x], e.Fields, "available")
+			if err != nil {
+				return account.Holdings{}, err
+			}
+			hold = total - available
+		} else {
+			hold, err = fieldFloat(items[x], e.Fields, "hold")
+			if err != nil {
+				return account.Holdings{}, err
+			}
+		}
+		balances = append(balances, account.Balance{
+			CurrencyName: currency.NewCode(code),
+			TotalValue:   total,
+			Hold:         hold,
+		})
+	}
+
+	info := account.Holdings{
+		Exchange: g.Name,
+		Accounts: []account.SubAccount{{
+			AssetType:  assetType,
+			Currencies: balances,
+		}},
+	}
+	err = g.GetAccountService().Process(&info)
+	if err != nil {
+		return account.Holdings{}, err
+	}
+	return info, nil
+}
+
+// FetchAccountInfo retrieves balances for all enabled currencies
+func (g *Generic) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
+	acc, err := g.GetAccountService().GetHoldings(g.Name, assetType)
+	if err != nil {
+		return g.UpdateAccountInfo(assetType)
+	}
+	return acc, nil
+}
+
+// GetFundingHistory returns funding history, deposits and
+// withdrawals
+func (g *Generic) GetFundingHistory() ([]exchange.FundHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetWithdrawalsHistory returns previous withdrawals data
+func (g *Generic) GetWithdrawalsHistory(c currency.Code) ([]exchange.WithdrawalHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetRecentTrades returns the most recent trades for a currency and asset
+func (g *Generic) GetRecentTrades(p currency.Pair, assetType asset.Item) ([]trade.Data, error) {
+	e := g.spec.REST.Endpoints.Trades
+	if e == nil {
+		return nil, common.ErrFunctionNotSupported
+	}
+	params, err := g.pairParams(p, assetType)
+	if err != nil {
+		return nil, err
+	}
+	if e.Limit > 0 {
+		params["limit"] = strconv.FormatInt(e.Limit, 10)
+	}
+	resp, err := g.sendRequest(e, params)
+	if err != nil {
+		return nil, err
+	}
+	items, err := resultItems(resp, e.Result)
+	if err != nil {
+		return nil, err
+	}
+	trades := make([]trade.Data, 0, len(items))
+	for x := range items {
+		var t trade.Data
+		t, err = parseTrade(items[x], e.Fields)
+		if err != nil {
+			return nil, err
+		}
+		t.Exchange = g.Name
+		t.CurrencyPair = p
+		t.AssetType = assetType
+		trades = append(trades, t)
+	}
+
+	err = g.AddTradesToBuffer(trades...)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Sort(trade.ByDate(trades))
+	return trades, nil
+}
+
+// GetHistoricTrades returns historic trade data within the timeframe provided
+func (g *Generic) GetHistoricTrades(_ currency.Pair, _ asset.Item, _, _ time.Time) ([]trade.Data, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// SubmitOrder submits a new order
+func (g *Generic) SubmitOrder(s *order.Submit) (order.SubmitResponse, error) {
+	var submitOrderResponse order.SubmitResponse
+	e := g.spec.REST.Endpoints.SubmitOrder
+	if e == nil {
+		return submitOrderResponse, common.ErrFunctionNotSupported
+	}
+	if err := s.Validate(); err != nil {
+		return submitOrderResponse, err
+	}
+	params, err := g.pairParams(s.Pair, s.AssetType)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	params["side"] = g.orderSide(s.Side)
+	params["type"] = g.orderType(s.Type)
+	params["amount"] = strconv.FormatFloat(s.Amount, 'f', -1, 64)
+	params["clientOrderId"] = s.ClientOrderID
+	if s.Price != 0 {
+		params["price"] = strconv.FormatFloat(s.Price, 'f', -1, 64)
+	}
+
+	resp, err := g.sendRequest(e, params)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	item, err := lookup(resp, e.Result)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	submitOrderResponse.OrderID, err = fieldString(item, e.Fields, "id")
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	if _, ok := e.Fields["executedAmount"]; ok {
+		var executed float64
+		executed, err = fieldFloat(item, e.Fields, "executedAmount")
+		if err != nil {
+			return submitOrderResponse, err
+		}
+		submitOrderResponse.FullyMatched = executed >= s.Amount
+	}
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// ModifyOrder will allow of changing orderbook placement and limit to
+// market conversion
+func (g *Generic) ModifyOrder(action *order.Modify) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// CancelOrder cancels an order by its corresponding ID number
+func (g *Generic) CancelOrder(o *order.Cancel) error {
+	e := g.spec.REST.Endpoints.CancelOrder
+	if e == nil {
+		return common.ErrFunctionNotSupported
+	}
+	if err := o.Validate(o.StandardCancel()); err != nil {
+		return err
+	}
+	params, err := g.pairParams(o.Pair, o.AssetType)
+	if err != nil {
+		return err
+	}
+	params["id"] = o.ID
+	params["clientOrderId"] = o.ClientOrderID
+	_, err = g.sendRequest(e, params)
+	return err
+}
+
+// CancelBatchOrders cancels an orders by their corresponding ID numbers
+func (g *Generic) CancelBatchOrders(o []order.Cancel) (order.CancelBatchResponse, error) {
+	return order.CancelBatchResponse{}, common.ErrFunctionNotSupported
+}
+
+// CancelAllOrders cancels all orders associated with a currency pair
+func (g *Generic) CancelAllOrders(orderCancellation *order.Cancel) (order.CancelAllResponse, error) {
+	return order.CancelAllResponse{}, common.ErrFunctionNotSupported
+}
+
+// GetOrderInfo returns order information based on order ID
+func (g *Generic) GetOrderInfo(orderID string, pair currency.Pair, assetType asset.Item) (order.Detail, error) {
+	e := g.spec.REST.Endpoints.OrderInfo
+	if e == nil {
+		return order.Detail{}, common.ErrFunctionNotSupported
+	}
+	params, err := g.pairParams(pair, assetType)
+	if err != nil {
+		return order.Detail{}, err
+	}
+	params["id"] = orderID
+	resp, err := g.sendRequest(e, params)
+	if err != nil {
+		return order.Detail{}, err
+	}
+	item, err := lookup(resp, e.Result)
+	if err != nil {
+		return order.Detail{}, err
+	}
+	d, err := g.parseOrder(item, e.Fields, pair, assetType)
+	if err != nil {
+		return order.Detail{}, err
+	}
+	return *d, nil
+}
+
+// GetDepositAddress returns a deposit address for a specified currency
+func (g *Generic) GetDepositAddress(cryptocurrency currency.Code, accountID string) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
+// submitted
+func (g *Generic) WithdrawCryptocurrencyFunds(withdrawRequest *withdraw.Request) (*withdraw.ExchangeResponse, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// WithdrawFiatFunds returns a withdrawal ID when a withdrawal is
+// submitted
+func (g *Generic) WithdrawFiatFunds(withdrawRequest *withdraw.Request) (*withdraw.ExchangeResponse, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
+// withdrawal is submitted
+func (g *Generic) WithdrawFiatFundsToInternationalBank(withdrawRequest *withdraw.Request) (*withdraw.ExchangeResponse, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetActiveOrders retrieves any orders that are active/open
+func (g *Generic) GetActiveOrders(req *order.GetOrdersRequest) ([]order.Detail, error) {
+	e := g.spec.REST.Endpoints.ActiveOrders
+	if e == nil {
+		return nil, common.ErrFunctionNotSupported
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Endpoints scoped to a symbol are queried once per requested pair
+	pairs := currency.Pairs{currency.Pair{}}
+	if endpointUsesSymbol(e) {
+		if len(req.Pairs) == 0 {
+			var err error
+			pairs, err = g.GetEnabledPairs(req.AssetType)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			pairs = req.Pairs
+		}
+	}
+
+	var orders []order.Detail
+	for x := range pairs {
+		params := map[string]string{"asset": req.AssetType.String()}
+		if !pairs[x].IsEmpty() {
+			var err error
+			params, err = g.pairParams(pairs[x], req.AssetType)
+			if err != nil {
+				return nil, err
+			}
+		}
+		resp, err := g.sendRequest(e, params)
+		if err != nil {
+			return nil, err
+		}
+		items, err := resultItems(resp, e.Result)
+		if err != nil {
+			return nil, err
+		}
+		for y := range items {
+			d, err := g.parseOrder(items[y], e.Fields, pairs[x], req.AssetType)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, *d)
+		}
+	}
+
+	order.FilterOrdersByType(&orders, req.Type)
+	order.FilterOrdersByTimeRange(&orders, req.StartTime, req.EndTime)
+	order.FilterOrdersBySide(&orders, req.Side)
+	order.FilterOrdersByCurrencies(&orders, req.Pairs)
+	return orders, nil
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (g *Generic) GetOrderHistory(req *order.GetOrdersRequest) ([]order.Detail, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetFeeByType returns an estimate of fee based on type of transaction
+func (g *Generic) GetFeeByType(feeBuilder *exchange.FeeBuilder) (float64, error) {
+	return 0, common.ErrFunctionNotSupported
+}
+
+// ValidateCredentials validates current credentials used for wrapper
+// functionality
+func (g *Generic) ValidateCredentials(assetType asset.Item) error {
+	_, err := g.UpdateAccountInfo(assetType)
+	return g.CheckTransientError(err)
+}
+
+// FormatExchangeKlineInterval returns the exchange value mapped for the
+// interval in the spec
+func (g *Generic) FormatExchangeKlineInterval(in kline.Interval) string {
+	if e := g.spec.REST.Endpoints.Candles; e != nil {
+		if v, ok := e.Intervals[in.Word()]; ok {
+			return v
+		}
+	}
+	return g.Base.FormatExchangeKlineInterval(in)
+}
+
+// GetHistoricCandles returns candles between a time period for a set time interval
+func (g *Generic) GetHistoricCandles(pair currency.Pair, a asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error) {
+	e := g.spec.REST.Endpoints.Candles
+	if e == nil {
+		return kline.Item{}, common.ErrFunctionNotSupported
+	}
+	if err := g.ValidateKline(pair, a, interval); err != nil {
+		return kline.Item{}, err
+	}
+
+	ret := kline.Item{
+		Exchange: g.Name,
+		Pair:     pair,
+		Asset:    a,
+		Interval: interval,
+	}
+	candles, err := g.fetchCandles(e, pair, a, start, end, interval)
+	if err != nil {
+		return kline.Item{}, err
+	}
+	ret.Candles = candles
+	ret.RemoveOutsideRange(start, end)
+	ret.SortCandlesByTimestamp(false)
+	return ret, nil
+}
+
+// GetHistoricCandlesExtended returns candles between a time period for a set time interval
+func (g *Generic) GetHistoricCandlesExtended(pair currency.Pair, a asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error) {
+	e := g.spec.REST.Endpoints.Candles
+	if e == nil {
+		return kline.Item{}, common.ErrFunctionNotSupported
+	}
+	if err := g.ValidateKline(pair, a, interval); err != nil {
+		return kline.Item{}, err
+	}
+
+	ret := kline.Item{
+		Exchange: g.Name,
+		Pair:     pair,
+		Asset:    a,
+		Interval: interval,
+	}
+	dates := kline.CalculateCandleDateRanges(start, end, interval, g.Features.Enabled.Kline.ResultLimit)
+	for x := range dates.Ranges {
+		candles, err := g.fetchCandles(e,
+			pair,
+			a,
+			dates.Ranges[x].Start.Time,
+			dates.Ranges[x].End.Time,
+			interval)
+		if err != nil {
+			return kline.Item{}, err
+		}
+		ret.Candles = append(ret.Candles, candles...)
+	}
+	err := dates.VerifyResultsHaveData(ret.Candles)
+	if err != nil {
+		log.Warnf(log.ExchangeSys, "%s - %s", g.Name, err)
+	}
+	ret.RemoveDuplicates()
+	ret.RemoveOutsideRange(start, end)
+	ret.SortCandlesByTimestamp(false)
+	return ret, nil
+}
+
+// GetPositions is not supported by generic exchanges
+func (g *Generic) GetPositions(a asset.Item, cp *currency.Pair) ([]position.Position, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+func (g *Generic) fetchCandles(e *Endpoint, pair currency.Pair, a asset.Item, start, end time.Time, interval kline.Interval) ([]kline.Candle, error) {
+	exchInterval, ok := e.Intervals[interval.Word()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errIntervalNotMapped, interval.Word())
+	}
+	params, err := g.pairParams(pair, a)
+	if err != nil {
+		return nil, err
+	}
+	params["interval"] = exchInterval
+	params["start"] = strconv.FormatInt(start.Unix(), 10)
+	params["end"] = strconv.FormatInt(end.Unix(), 10)
+	if e.Limit > 0 {
+		params["limit"] = strconv.FormatInt(e.Limit, 10)
+	}
+	resp, err := g.sendRequest(e, params)
+	if err != nil {
+		return nil, err
+	}
+	items, err := resultItems(resp, e.Result)
+	if err != nil {
+		return nil, err
+	}
+	candles := make([]kline.Candle, 0, len(items))
+	for x := range items {
+		var c kline.Candle
+		c, err = parseCandle(items[x], e.Fields)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, c)
+	}
+	return candles, nil
+}
+
+// orderSide returns the exchange value for an order side
+func (g *Generic) orderSide(s order.Side) string {
+	if v, ok := g.spec.OrderSides[s.String()]; ok {
+		return v
+	}
+	return s.Lower()
+}
+
+// orderType returns the exchange value for an order type
+func (g *Generic) orderType(t order.Type) string {
+	if v, ok := g.spec.OrderTypes[t.String()]; ok {
+		return v
+	}
+	return t.Lower()
+}
+
+// parseOrder converts a response item to an order detail, pair is used
+// when the item does not map a symbol
+func (g *Generic) parseOrder(item interface{}, fields map[string]string, pair currency.Pair, a asset.Item) (*order.Detail, error) {
+	d := &order.Detail{
+		Exchange:  g.Name,
+		AssetType: a,
+		Pair:      pair,
+	}
+	var err error
+	if d.ID, err = fieldString(item, fields, "id"); err != nil {
+		return nil, err
+	}
+	if d.ClientOrderID, err = fieldString(item, fields, "clientOrderId"); err != nil {
+		return nil, err
+	}
+	if d.Price, err = fieldFloat(item, fields, "price"); err != nil {
+		return nil, err
+	}
+	if d.Amount, err = fieldFloat(item, fields, "amount"); err != nil {
+		return nil, err
+	}
+	if d.ExecutedAmount, err = fieldFloat(item, fields, "executedAmount"); err != nil {
+		return nil, err
+	}
+	d.RemainingAmount = d.Amount - d.ExecutedAmount
+	if d.Date, err = fieldTime(item, fields, "timestamp"); err != nil {
+		return nil, err
+	}
+
+	symbol, err := fieldString(item, fields, "symbol")
+	if err != nil {
+		return nil, err
+	}
+	if symbol != "" {
+		d.Pair, err = g.matchPair(symbol, a)
+		if err != nil {
+			d.Pair, err = g.parseSymbol(symbol)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	side, err := fieldString(item, fields, "side")
+	if err != nil {
+		return nil, err
+	}
+	if side != "" {
+		d.Side, err = order.StringToOrderSide(reverseLookup(g.spec.OrderSides, side))
+		if err != nil {
+			return nil, err
+		}
+	}
+	oType, err := fieldString(item, fields, "type")
+	if err != nil {
+		return nil, err
+	}
+	if oType != "" {
+		d.Type, err = order.StringToOrderType(reverseLookup(g.spec.OrderTypes, oType))
+		if err != nil {
+			return nil, err
+		}
+	}
+	status, err := fieldString(item, fields, "status")
+	if err != nil {
+		return nil, err
+	}
+	if status != "" {
+		d.Status, err = order.StringToOrderStatus(status)
+		if err != nil {
+			log.Errorf(log.ExchangeSys, "%s %v", g.Name, err)
+		}
+	}
+	return d, nil
+}
+
+// reverseLookup maps an exchange value back to its GoCryptoTrader key
+func reverseLookup(m map[string]string, v string) string {
+	for k := range m {
+		if strings.EqualFold(m[k], v) {
+			return k
+		}
+	}
+	return v
+}
+
+func endpointUsesSymbol(e *Endpoint) bool {
+	tags := []string{"{symbol}", "{base}", "{quote}"}
+	contains := func(s string) bool {
+		for x := range tags {
+			if strings.Contains(s, tags[x]) {
+				return true
+			}
+		}
+		return false
+	}
+	if contains(e.Path) {
+		return true
+	}
+	for _, v := range e.Query {
+		if contains(v) {
+			return true
+		}
+	}
+	for _, v := range e.Body {
+		if contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTicker(item interface{}, fields map[string]string) (*ticker.Price, error) {
+	var t ticker.Price
+	var err error
+	targets := []struct {
+		name string
+		dst  *float64
+	}{
+		{"last", &t.Last},
+		{"high", &t.High},
+		{"low", &t.Low},
+		{"bid", &t.Bid},
+		{"ask", &t.Ask},
+		{"volume", &t.Volume},
+		{"quoteVolume", &t.QuoteVolume},
+		{"open", &t.Open},
+		{"close", &t.Close},
+	}
+	for x := range targets {
+		*targets[x].dst, err = fieldFloat(item, fields, targets[x].name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	t.LastUpdated, err = fieldTime(item, fields, "timestamp")
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func parseLevels(root interface{}, fields map[string]string, side string) ([]orderbook.Item, error) {
+	levels, err := resultItems(root, fields[side])
+	if err != nil {
+		return nil, err
+	}
+	items := make([]orderbook.Item, len(levels))
+	for x := range levels {
+		items[x].Price, err = fieldFloat(levels[x], fields, "price")
+		if err != nil {
+			return nil, err
+		}
+		items[x].Amount, err = fieldFloat(levels[x], fields, "amount")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+func parseTrade(item interface{}, fields map[string]string) (trade.Data, error) {
+	var t trade.Data
+	var err error
+	if t.TID, err = fieldString(item, fields, "id"); err != nil {
+		return t, err
+	}
+	if t.Price, err = fieldFloat(item, fields, "price"); err != nil {
+		return t, err
+	}
+	if t.Amount, err = fieldFloat(item, fields, "amount"); err != nil {
+		return t, err
+	}
+	if t.Timestamp, err = fieldTime(item, fields, "timestamp"); err != nil {
+		return t, err
+	}
+	side, err := fieldString(item, fields, "side")
+	if err != nil {
+		return t, err
+	}
+	if side != "" {
+		t.Side, err = order.StringToOrderSide(side)
+		if err != nil {
+			return t, err
+		}
+	}
+	return t, nil
+}
+
+func parseCandle(item interface{}, fields map[string]string) (kline.Candle, error) {
+	var c kline.Candle
+	var err error
+	if c.Time, err = fieldTime(item, fields, "time"); err != nil {
+		return c, err
+	}
+	targets := []struct {
+		name string
+		dst  *float64
+	}{
+		{"open", &c.Open},
+		{"high", &c.High},
+		{"low", &c.Low},
+		{"close", &c.Close},
+		{"volume", &c.Volume},
+	}
+	for x := range targets {
+		*targets[x].dst, err = fieldFloat(item, fields, targets[x].name)
+		if err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
@@ -192,7 +192,7 @@ func (h *HitBTC) wsHandleData(respRaw []byte) error {
 			return err
 		}
 	case "snapshotTrades", "updateTrades":
-		if !h.IsSaveTradeDataEnabled() {
+		if !h.IsTradeProcessingRequired() {
 			return nil
 		}
 		var tradeSnapshot WsTrade
@@ -227,7 +227,7 @@ func (h *HitBTC) wsHandleData(respRaw []byte) error {
 				TID:          strconv.FormatInt(tradeSnapshot.Params.Data[i].ID, 10),
 			})
 		}
-		return trade.AddTradesToBuffer(h.Name, trades...)
+		return h.AddTradesToBuffer(trades...)
 	case "activeOrders":
 		var o wsActiveOrdersResponse
 		err := json.Unmarshal(respRaw, &o)
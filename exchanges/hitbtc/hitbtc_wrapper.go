@@ -334,7 +334,7 @@ func (h *HitBTC) UpdateTicker(p currency.Pair, a asset.Item) (*ticker.Price, err
 				}
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = h.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         tick[j].Last,
 				High:         tick[j].High,
 				Low:          tick[j].Low,
@@ -352,12 +352,12 @@ func (h *HitBTC) UpdateTicker(p currency.Pair, a asset.Item) (*ticker.Price, err
 			}
 		}
 	}
-	return ticker.GetTicker(h.Name, p, a)
+	return h.GetTickerService().GetTicker(h.Name, p, a)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (h *HitBTC) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(h.Name, p, assetType)
+	tickerNew, err := h.GetTickerService().GetTicker(h.Name, p, assetType)
 	if err != nil {
 		return h.UpdateTicker(p, assetType)
 	}
@@ -366,7 +366,7 @@ func (h *HitBTC) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (h *HitBTC) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(h.Name, p, assetType)
+	ob, err := h.GetOrderbookService().Retrieve(h.Name, p, assetType)
 	if err != nil {
 		return h.UpdateOrderbook(p, assetType)
 	}
@@ -404,11 +404,11 @@ func (h *HitBTC) UpdateOrderbook(c currency.Pair, assetType asset.Item) (*orderb
 			Price:  orderbookNew.Asks[x].Price,
 		})
 	}
-	err = book.Process()
+	err = h.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(h.Name, c, assetType)
+	return h.GetOrderbookService().Retrieve(h.Name, c, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -434,7 +434,7 @@ func (h *HitBTC) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 		Currencies: currencies,
 	})
 
-	err = account.Process(&response)
+	err = h.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -444,7 +444,7 @@ func (h *HitBTC) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (h *HitBTC) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(h.Name, assetType)
+	acc, err := h.GetAccountService().GetHoldings(h.Name, assetType)
 	if err != nil {
 		return h.UpdateAccountInfo(assetType)
 	}
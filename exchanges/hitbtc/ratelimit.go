@@ -28,17 +28,26 @@ type RateLimit struct {
 
 // Limit limits outbound requests
 func (r *RateLimit) Limit(f request.EndpointLimit) error {
+	limiter := r.LimiterFor(f)
+	if limiter == nil {
+		return errors.New("functionality not found")
+	}
+	time.Sleep(limiter.Reserve().Delay())
+	return nil
+}
+
+// LimiterFor returns the rate limiter an endpoint limit draws from
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
 	switch f {
 	case marketRequests:
-		time.Sleep(r.MarketData.Reserve().Delay())
+		return r.MarketData
 	case tradingRequests:
-		time.Sleep(r.Trading.Reserve().Delay())
+		return r.Trading
 	case otherRequests:
-		time.Sleep(r.Other.Reserve().Delay())
+		return r.Other
 	default:
-		return errors.New("functionality not found")
+		return nil
 	}
-	return nil
 }
 
 // SetRateLimit returns the rate limit for the exchange
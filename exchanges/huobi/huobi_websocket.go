@@ -342,7 +342,7 @@ func (h *HUOBI) wsHandleData(respRaw []byte) error {
 			Interval:   data[3],
 		}
 	case strings.Contains(init.Channel, "trade.detail"):
-		if !h.IsSaveTradeDataEnabled() {
+		if !h.IsTradeProcessingRequired() {
 			return nil
 		}
 		var t WsTrade
@@ -375,7 +375,7 @@ func (h *HUOBI) wsHandleData(respRaw []byte) error {
 				TID:    strconv.FormatFloat(t.Tick.Data[i].TradeID, 'f', -1, 64),
 			})
 		}
-		return trade.AddTradesToBuffer(h.Name, trades...)
+		return h.AddTradesToBuffer(trades...)
 	case strings.Contains(init.Channel, "detail"),
 		strings.Contains(init.Rep, "detail"):
 		var wsTicker WsTick
@@ -435,7 +435,7 @@ func (h *HUOBI) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 		if err != nil {
 			return nil, err
 		}
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = h.GetTickerService().ProcessTicker(&ticker.Price{
 			High:         tickerData.Tick.High,
 			Low:          tickerData.Tick.Low,
 			Volume:       tickerData.Tick.Volume,
@@ -461,7 +461,7 @@ func (h *HUOBI) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 			return nil, fmt.Errorf("invalid data for Ask")
 		}
 
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = h.GetTickerService().ProcessTicker(&ticker.Price{
 			High:         marketData.Tick.High,
 			Low:          marketData.Tick.Low,
 			Volume:       marketData.Tick.Vol,
@@ -482,7 +482,7 @@ func (h *HUOBI) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 			return nil, err
 		}
 
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = h.GetTickerService().ProcessTicker(&ticker.Price{
 			High:         marketData.Tick.High,
 			Low:          marketData.Tick.Low,
 			Volume:       marketData.Tick.Vol,
@@ -498,12 +498,12 @@ func (h *HUOBI) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 			return nil, err
 		}
 	}
-	return ticker.GetTicker(h.Name, p, assetType)
+	return h.GetTickerService().GetTicker(h.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (h *HUOBI) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(h.Name, p, assetType)
+	tickerNew, err := h.GetTickerService().GetTicker(h.Name, p, assetType)
 	if err != nil {
 		return h.UpdateTicker(p, assetType)
 	}
@@ -512,7 +512,7 @@ func (h *HUOBI) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (h *HUOBI) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(h.Name, p, assetType)
+	ob, err := h.GetOrderbookService().Retrieve(h.Name, p, assetType)
 	if err != nil {
 		return h.UpdateOrderbook(p, assetType)
 	}
@@ -593,11 +593,11 @@ func (h *HUOBI) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderbo
 			})
 		}
 	}
-	err = book.Process()
+	err = h.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(h.Name, p, assetType)
+	return h.GetOrderbookService().Retrieve(h.Name, p, assetType)
 }
 
 // GetAccountID returns the account ID for trades
@@ -730,7 +730,7 @@ func (h *HUOBI) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error
 	}
 	acc.AssetType = asset.Futures
 	info.Accounts = append(info.Accounts, acc)
-	err := account.Process(&info)
+	err := h.GetAccountService().Process(&info)
 	if err != nil {
 		return info, err
 	}
@@ -739,7 +739,7 @@ func (h *HUOBI) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (h *HUOBI) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(h.Name, assetType)
+	acc, err := h.GetAccountService().GetHoldings(h.Name, assetType)
 	if err != nil {
 		return h.UpdateAccountInfo(assetType)
 	}
@@ -42,23 +42,28 @@ type RateLimit struct {
 
 // Limit limits outbound requests
 func (r *RateLimit) Limit(f request.EndpointLimit) error {
+	time.Sleep(r.LimiterFor(f).Reserve().Delay())
+	return nil
+}
+
+// LimiterFor returns the rate limiter an endpoint limit draws from
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
 	switch f {
 	// TODO: Add futures and swap functionality
 	case huobiFuturesAuth:
-		time.Sleep(r.FuturesAuth.Reserve().Delay())
+		return r.FuturesAuth
 	case huobiFuturesUnAuth:
-		time.Sleep(r.FuturesUnauth.Reserve().Delay())
+		return r.FuturesUnauth
 	case huobiFuturesTransfer:
-		time.Sleep(r.FuturesXfer.Reserve().Delay())
+		return r.FuturesXfer
 	case huobiSwapAuth:
-		time.Sleep(r.SwapAuth.Reserve().Delay())
+		return r.SwapAuth
 	case huobiSwapUnauth:
-		time.Sleep(r.SwapUnauth.Reserve().Delay())
+		return r.SwapUnauth
 	default:
 		// Spot calls
-		time.Sleep(r.Spot.Reserve().Delay())
+		return r.Spot
 	}
-	return nil
 }
 
 // SetRateLimit returns the rate limit for the exchange
@@ -151,7 +151,7 @@ func (i *ItBit) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 		return nil, err
 	}
 
-	err = ticker.ProcessTicker(&ticker.Price{
+	err = i.GetTickerService().ProcessTicker(&ticker.Price{
 		Last:         tick.LastPrice,
 		High:         tick.High24h,
 		Low:          tick.Low24h,
@@ -167,12 +167,12 @@ func (i *ItBit) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 		return nil, err
 	}
 
-	return ticker.GetTicker(i.Name, p, assetType)
+	return i.GetTickerService().GetTicker(i.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (i *ItBit) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(i.Name, p, assetType)
+	tickerNew, err := i.GetTickerService().GetTicker(i.Name, p, assetType)
 	if err != nil {
 		return i.UpdateTicker(p, assetType)
 	}
@@ -181,7 +181,7 @@ func (i *ItBit) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (i *ItBit) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(i.Name, p, assetType)
+	ob, err := i.GetOrderbookService().Retrieve(i.Name, p, assetType)
 	if err != nil {
 		return i.UpdateOrderbook(p, assetType)
 	}
@@ -240,11 +240,11 @@ func (i *ItBit) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderbo
 				Price:  price,
 			})
 	}
-	err = book.Process()
+	err = i.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(i.Name, p, assetType)
+	return i.GetOrderbookService().Retrieve(i.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies
@@ -288,7 +288,7 @@ func (i *ItBit) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error
 		Currencies: fullBalance,
 	})
 
-	err = account.Process(&info)
+	err = i.GetAccountService().Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -298,7 +298,7 @@ func (i *ItBit) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (i *ItBit) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(i.Name, assetType)
+	acc, err := i.GetAccountService().GetHoldings(i.Name, assetType)
 	if err != nil {
 		return i.UpdateAccountInfo(assetType)
 	}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -18,7 +19,12 @@ import (
 
 // LoadFromDatabase returns Item from database seeded data
 func LoadFromDatabase(exchange string, pair currency.Pair, a asset.Item, interval Interval, start, end time.Time) (Item, error) {
-	retCandle, err := candle.Series(exchange,
+	return LoadFromInstance(database.DB, exchange, pair, a, interval, start, end)
+}
+
+// LoadFromInstance returns Item from data seeded in the database instance db
+func LoadFromInstance(db *database.Instance, exchange string, pair currency.Pair, a asset.Item, interval Interval, start, end time.Time) (Item, error) {
+	retCandle, err := candle.New(db).Series(exchange,
 		pair.Base.String(), pair.Quote.String(),
 		int64(interval.Duration().Seconds()), a.String(), start, end)
 	if err != nil {
@@ -47,6 +53,11 @@ func LoadFromDatabase(exchange string, pair currency.Pair, a asset.Item, interva
 
 // StoreInDatabase returns Item from database seeded data
 func StoreInDatabase(in *Item, force bool) (uint64, error) {
+	return StoreInInstance(database.DB, in, force)
+}
+
+// StoreInInstance stores Item in the database instance db
+func StoreInInstance(db *database.Instance, in *Item, force bool) (uint64, error) {
 	if in.Exchange == "" {
 		return 0, errors.New("name cannot be blank")
 	}
@@ -63,7 +74,7 @@ func StoreInDatabase(in *Item, force bool) (uint64, error) {
 		return 0, errors.New("candle data is empty")
 	}
 
-	exchangeUUID, err := exchange.UUIDByName(in.Exchange)
+	exchangeUUID, err := exchange.New(db).UUIDByName(in.Exchange)
 	if err != nil {
 		return 0, err
 	}
@@ -87,12 +98,12 @@ func StoreInDatabase(in *Item, force bool) (uint64, error) {
 		})
 	}
 	if force {
-		_, err := candle.DeleteCandles(&databaseCandles)
+		_, err := candle.New(db).DeleteCandles(&databaseCandles)
 		if err != nil {
 			return 0, err
 		}
 	}
-	return candle.Insert(&databaseCandles)
+	return candle.New(db).Insert(&databaseCandles)
 }
 
 // LoadFromGCTScriptCSV loads kline data from a CSV file
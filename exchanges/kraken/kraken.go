@@ -935,9 +935,10 @@ func (k *Kraken) CancelExistingOrder(txid string) (CancelOrderResponse, error) {
 
 // GetError parse Exchange errors in response and return the first one
 // Error format from API doc:
-//   error = array of error messages in the format of:
-//       <char-severity code><string-error category>:<string-error type>[:<string-extra info>]
-//       severity code can be E for error or W for warning
+//
+//	error = array of error messages in the format of:
+//	    <char-severity code><string-error category>:<string-error type>[:<string-extra info>]
+//	    severity code can be E for error or W for warning
 func GetError(apiErrors []string) error {
 	const exchangeName = "Kraken"
 	for _, e := range apiErrors {
@@ -968,6 +969,13 @@ func (k *Kraken) SendHTTPRequest(ep exchange.URL, path string, result interface{
 	})
 }
 
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	krakenOrderPlace,
+	krakenOrderCancel,
+}
+
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request
 func (k *Kraken) SendAuthenticatedHTTPRequest(ep exchange.URL, method string, params url.Values, result interface{}) error {
 	if !k.AllowAuthenticatedRequest() {
@@ -1007,6 +1015,7 @@ func (k *Kraken) SendAuthenticatedHTTPRequest(ep exchange.URL, method string, pa
 		Body:          strings.NewReader(encoded),
 		Result:        &interim,
 		AuthRequest:   true,
+		Priority:      request.PriorityByEndpoint(method, orderEndpoints...),
 		NonceEnabled:  true,
 		Verbose:       k.Verbose,
 		HTTPDebugging: k.HTTPDebugging,
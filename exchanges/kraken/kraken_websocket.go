@@ -477,29 +477,26 @@ func (k *Kraken) wsProcessOwnTrades(ownOrders interface{}) error {
 						Err:      err,
 					}
 				}
-				oType, err := order.StringToOrderType(val.OrderType)
+				p, err := currency.NewPairFromString(val.Pair)
 				if err != nil {
-					k.Websocket.DataHandler <- order.ClassificationError{
-						Exchange: k.Name,
-						OrderID:  key,
-						Err:      err,
-					}
+					return err
 				}
-				trade := order.TradeHistory{
+				a, err := k.GetPairAssetType(p)
+				if err != nil {
+					return err
+				}
+				k.Websocket.DataHandler <- &order.Fill{
 					Price:     val.Price,
 					Amount:    val.Vol,
 					Fee:       val.Fee,
 					Exchange:  k.Name,
-					TID:       key,
-					Type:      oType,
+					ID:        key,
+					OrderID:   val.OrderTransactionID,
 					Side:      oSide,
+					AssetType: a,
+					Pair:      p,
 					Timestamp: convert.TimeFromUnixTimestampDecimal(val.Time),
 				}
-				k.Websocket.DataHandler <- &order.Detail{
-					Exchange: k.Name,
-					ID:       val.OrderTransactionID,
-					Trades:   []order.TradeHistory{trade},
-				}
 			}
 		}
 		return nil
@@ -564,7 +561,7 @@ func (k *Kraken) wsProcessOpenOrders(ownOrders interface{}) error {
 					if err != nil {
 						return err
 					}
-					k.Websocket.DataHandler <- &order.Modify{
+					k.Websocket.DataHandler <- &order.Detail{
 						Leverage:        val.Description.Leverage,
 						Price:           val.Price,
 						Amount:          val.Volume,
@@ -582,7 +579,7 @@ func (k *Kraken) wsProcessOpenOrders(ownOrders interface{}) error {
 						Pair:            p,
 					}
 				} else {
-					k.Websocket.DataHandler <- &order.Modify{
+					k.Websocket.DataHandler <- &order.Detail{
 						Exchange: k.Name,
 						ID:       key,
 						Status:   oStatus,
@@ -707,7 +704,7 @@ func (k *Kraken) wsProcessSpread(channelData *WebsocketChannelData, data []inter
 
 // wsProcessTrades converts trade data and sends it to the datahandler
 func (k *Kraken) wsProcessTrades(channelData *WebsocketChannelData, data []interface{}) error {
-	if !k.IsSaveTradeDataEnabled() {
+	if !k.IsTradeProcessingRequired() {
 		return nil
 	}
 	var trades []trade.Data
@@ -745,7 +742,7 @@ func (k *Kraken) wsProcessTrades(channelData *WebsocketChannelData, data []inter
 			Side:         tSide,
 		})
 	}
-	return trade.AddTradesToBuffer(k.Name, trades...)
+	return k.AddTradesToBuffer(trades...)
 }
 
 // wsProcessOrderBook determines if the orderbook data is partial or update
@@ -138,6 +138,7 @@ func (k *Kraken) SetDefaults() {
 				CancelOrders:       true,
 				GetOrders:          true,
 				GetOrder:           true,
+				OrderUpdates:       true,
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCryptoWithSetup |
 				exchange.WithdrawCryptoWith2FA |
@@ -436,7 +437,7 @@ func (k *Kraken) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 					}
 				}
 
-				err = ticker.ProcessTicker(&ticker.Price{
+				err = k.GetTickerService().ProcessTicker(&ticker.Price{
 					Last:         t.Last,
 					High:         t.High,
 					Low:          t.Low,
@@ -462,7 +463,7 @@ func (k *Kraken) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 			if err != nil {
 				return nil, err
 			}
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = k.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         t.Tickers[x].Last,
 				Bid:          t.Tickers[x].Bid,
 				Ask:          t.Tickers[x].Ask,
@@ -478,12 +479,12 @@ func (k *Kraken) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 	default:
 		return nil, fmt.Errorf("assetType not supported: %v", assetType)
 	}
-	return ticker.GetTicker(k.Name, p, assetType)
+	return k.GetTickerService().GetTicker(k.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (k *Kraken) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(k.Name, p, assetType)
+	tickerNew, err := k.GetTickerService().GetTicker(k.Name, p, assetType)
 	if err != nil {
 		return k.UpdateTicker(p, assetType)
 	}
@@ -492,7 +493,7 @@ func (k *Kraken) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (k *Kraken) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(k.Name, p, assetType)
+	ob, err := k.GetOrderbookService().Retrieve(k.Name, p, assetType)
 	if err != nil {
 		return k.UpdateOrderbook(p, assetType)
 	}
@@ -548,11 +549,11 @@ func (k *Kraken) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderb
 	default:
 		return book, fmt.Errorf("invalid assetType: %v", assetType)
 	}
-	err = book.Process()
+	err = k.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(k.Name, p, assetType)
+	return k.GetOrderbookService().Retrieve(k.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -602,7 +603,7 @@ func (k *Kraken) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 			})
 		}
 	}
-	err := account.Process(&info)
+	err := k.GetAccountService().Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -611,7 +612,7 @@ func (k *Kraken) UpdateAccountInfo(assetType asset.Item) (account.Holdings, erro
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (k *Kraken) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(k.Name, assetType)
+	acc, err := k.GetAccountService().GetHoldings(k.Name, assetType)
 	if err != nil {
 		return k.UpdateAccountInfo(assetType)
 	}
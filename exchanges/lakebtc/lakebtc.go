@@ -286,6 +286,14 @@ func (l *LakeBTC) SendHTTPRequest(endpoint exchange.URL, path string, result int
 	})
 }
 
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	lakeBTCBuyOrder,
+	lakeBTCSellOrder,
+	lakeBTCCancelOrder,
+}
+
 // SendAuthenticatedHTTPRequest sends an autheticated HTTP request to a LakeBTC
 func (l *LakeBTC) SendAuthenticatedHTTPRequest(ep exchange.URL, method, params string, result interface{}) (err error) {
 	if !l.AllowAuthenticatedRequest() {
@@ -326,6 +334,7 @@ func (l *LakeBTC) SendAuthenticatedHTTPRequest(ep exchange.URL, method, params s
 		Body:          strings.NewReader(string(data)),
 		Result:        result,
 		AuthRequest:   true,
+		Priority:      request.PriorityByEndpoint(method, orderEndpoints...),
 		NonceEnabled:  true,
 		Verbose:       l.Verbose,
 		HTTPDebugging: l.HTTPDebugging,
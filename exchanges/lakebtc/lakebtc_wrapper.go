@@ -240,12 +240,12 @@ func (l *LakeBTC) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.P
 		tickerPrice.ExchangeName = l.Name
 		tickerPrice.AssetType = assetType
 
-		err = ticker.ProcessTicker(tickerPrice)
+		err = l.GetTickerService().ProcessTicker(tickerPrice)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return ticker.GetTicker(l.Name, p, assetType)
+	return l.GetTickerService().GetTicker(l.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -255,7 +255,7 @@ func (l *LakeBTC) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 		return nil, err
 	}
 
-	tickerNew, err := ticker.GetTicker(l.Name, fPair, assetType)
+	tickerNew, err := l.GetTickerService().GetTicker(l.Name, fPair, assetType)
 	if err != nil {
 		return l.UpdateTicker(fPair, assetType)
 	}
@@ -269,7 +269,7 @@ func (l *LakeBTC) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderb
 		return nil, err
 	}
 
-	ob, err := orderbook.Get(l.Name, fPair, assetType)
+	ob, err := l.GetOrderbookService().Retrieve(l.Name, fPair, assetType)
 	if err != nil {
 		return l.UpdateOrderbook(fPair, assetType)
 	}
@@ -305,11 +305,11 @@ func (l *LakeBTC) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*order
 			Amount: orderbookNew.Asks[x].Amount,
 			Price:  orderbookNew.Asks[x].Price})
 	}
-	err = book.Process()
+	err = l.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(l.Name, fPair, assetType)
+	return l.GetOrderbookService().Retrieve(l.Name, fPair, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -340,7 +340,7 @@ func (l *LakeBTC) UpdateAccountInfo(assetType asset.Item) (account.Holdings, err
 		Currencies: currencies,
 	})
 
-	err = account.Process(&response)
+	err = l.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -350,7 +350,7 @@ func (l *LakeBTC) UpdateAccountInfo(assetType asset.Item) (account.Holdings, err
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (l *LakeBTC) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(l.Name, assetType)
+	acc, err := l.GetAccountService().GetHoldings(l.Name, assetType)
 	if err != nil {
 		return l.UpdateAccountInfo(assetType)
 	}
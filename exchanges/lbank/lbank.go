@@ -553,6 +553,13 @@ func (l *Lbank) sign(data string) (string, error) {
 	return gctcrypto.Base64Encode(r), nil
 }
 
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	"/v" + lbankAPIVersion + "/" + lbankPlaceOrder,
+	"/v" + lbankAPIVersion + "/" + lbankCancelOrder,
+}
+
 // SendAuthHTTPRequest sends an authenticated request
 func (l *Lbank) SendAuthHTTPRequest(method, endpoint string, vals url.Values, result interface{}) error {
 	if !l.AllowAuthenticatedRequest() {
@@ -581,6 +588,7 @@ func (l *Lbank) SendAuthHTTPRequest(method, endpoint string, vals url.Values, re
 		Body:          bytes.NewBufferString(payload),
 		Result:        result,
 		AuthRequest:   true,
+		Priority:      request.PriorityByEndpoint(strings.TrimSuffix(endpoint, "?"), orderEndpoints...),
 		Verbose:       l.Verbose,
 		HTTPDebugging: l.HTTPDebugging,
 		HTTPRecording: l.HTTPRecording,
@@ -204,7 +204,7 @@ func (l *Lbank) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = l.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         tickerInfo[j].Ticker.Latest,
 				High:         tickerInfo[j].Ticker.High,
 				Low:          tickerInfo[j].Ticker.Low,
@@ -218,7 +218,7 @@ func (l *Lbank) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 			}
 		}
 	}
-	return ticker.GetTicker(l.Name, p, assetType)
+	return l.GetTickerService().GetTicker(l.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -228,7 +228,7 @@ func (l *Lbank) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 		return nil, err
 	}
 
-	tickerNew, err := ticker.GetTicker(l.Name, fpair, assetType)
+	tickerNew, err := l.GetTickerService().GetTicker(l.Name, fpair, assetType)
 	if err != nil {
 		return l.UpdateTicker(p, assetType)
 	}
@@ -237,7 +237,7 @@ func (l *Lbank) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (l *Lbank) FetchOrderbook(currency currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(l.Name, currency, assetType)
+	ob, err := l.GetOrderbookService().Retrieve(l.Name, currency, assetType)
 	if err != nil {
 		return l.UpdateOrderbook(currency, assetType)
 	}
@@ -287,11 +287,11 @@ func (l *Lbank) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderbo
 			Price:  price,
 			Amount: amount})
 	}
-	err = book.Process()
+	err = l.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(l.Name, p, assetType)
+	return l.GetOrderbookService().Retrieve(l.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -326,7 +326,7 @@ func (l *Lbank) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error
 	info.Accounts = append(info.Accounts, acc)
 	info.Exchange = l.Name
 
-	err = account.Process(&info)
+	err = l.GetAccountService().Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -335,7 +335,7 @@ func (l *Lbank) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (l *Lbank) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(l.Name, assetType)
+	acc, err := l.GetAccountService().GetHoldings(l.Name, assetType)
 	if err != nil {
 		return l.UpdateAccountInfo(assetType)
 	}
@@ -188,18 +188,18 @@ func (l *LocalBitcoins) UpdateTicker(p currency.Pair, assetType asset.Item) (*ti
 		tp.ExchangeName = l.Name
 		tp.AssetType = assetType
 
-		err = ticker.ProcessTicker(&tp)
+		err = l.GetTickerService().ProcessTicker(&tp)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return ticker.GetTicker(l.Name, p, assetType)
+	return l.GetTickerService().GetTicker(l.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (l *LocalBitcoins) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(l.Name, p, assetType)
+	tickerNew, err := l.GetTickerService().GetTicker(l.Name, p, assetType)
 	if err != nil {
 		return l.UpdateTicker(p, assetType)
 	}
@@ -208,7 +208,7 @@ func (l *LocalBitcoins) FetchTicker(p currency.Pair, assetType asset.Item) (*tic
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (l *LocalBitcoins) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(l.Name, p, assetType)
+	ob, err := l.GetOrderbookService().Retrieve(l.Name, p, assetType)
 	if err != nil {
 		return l.UpdateOrderbook(p, assetType)
 	}
@@ -244,12 +244,12 @@ func (l *LocalBitcoins) UpdateOrderbook(p currency.Pair, assetType asset.Item) (
 	}
 
 	book.PriceDuplication = true
-	err = book.Process()
+	err = l.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
 
-	return orderbook.Get(l.Name, p, assetType)
+	return l.GetOrderbookService().Retrieve(l.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -269,7 +269,7 @@ func (l *LocalBitcoins) UpdateAccountInfo(assetType asset.Item) (account.Holding
 		Currencies: []account.Balance{exchangeCurrency},
 	})
 
-	err = account.Process(&response)
+	err = l.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -279,7 +279,7 @@ func (l *LocalBitcoins) UpdateAccountInfo(assetType asset.Item) (account.Holding
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (l *LocalBitcoins) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(l.Name, assetType)
+	acc, err := l.GetAccountService().GetHoldings(l.Name, assetType)
 	if err != nil {
 		return l.UpdateAccountInfo(assetType)
 	}
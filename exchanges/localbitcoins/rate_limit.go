@@ -18,14 +18,25 @@ type RateLimit struct {
 
 // Limit executes rate limiting functionality for Binance
 func (r *RateLimit) Limit(f request.EndpointLimit) error {
-	if f == orderBookLimiter {
-		time.Sleep(r.Orderbook.Reserve().Delay())
-	} else if f == tickerLimiter {
-		time.Sleep(r.Ticker.Reserve().Delay())
+	if limiter := r.LimiterFor(f); limiter != nil {
+		time.Sleep(limiter.Reserve().Delay())
 	}
 	return nil
 }
 
+// LimiterFor returns the rate limiter an endpoint limit draws from, endpoints
+// which are not rate limited return nil
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
+	switch f {
+	case orderBookLimiter:
+		return r.Orderbook
+	case tickerLimiter:
+		return r.Ticker
+	default:
+		return nil
+	}
+}
+
 // SetRateLimit returns the rate limit for the exchange
 func SetRateLimit() *RateLimit {
 	return &RateLimit{
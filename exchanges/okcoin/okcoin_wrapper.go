@@ -288,7 +288,7 @@ func (o *OKCoin) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 					continue
 				}
 
-				err = ticker.ProcessTicker(&ticker.Price{
+				err = o.GetTickerService().ProcessTicker(&ticker.Price{
 					Last:         resp[j].Last,
 					High:         resp[j].High24h,
 					Low:          resp[j].Low24h,
@@ -307,12 +307,12 @@ func (o *OKCoin) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pr
 			}
 		}
 	}
-	return ticker.GetTicker(o.Name, p, assetType)
+	return o.GetTickerService().GetTicker(o.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (o *OKCoin) FetchTicker(p currency.Pair, assetType asset.Item) (tickerData *ticker.Price, err error) {
-	tickerData, err = ticker.GetTicker(o.Name, p, assetType)
+	tickerData, err = o.GetTickerService().GetTicker(o.Name, p, assetType)
 	if err != nil {
 		return o.UpdateTicker(p, assetType)
 	}
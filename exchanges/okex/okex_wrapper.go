@@ -444,7 +444,7 @@ func (o *OKEX) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = o.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         resp[j].Last,
 				High:         resp[j].High24h,
 				Low:          resp[j].Low24h,
@@ -482,7 +482,7 @@ func (o *OKEX) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = o.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         resp[j].Last,
 				High:         resp[j].High24H,
 				Low:          resp[j].Low24H,
@@ -518,7 +518,7 @@ func (o *OKEX) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = o.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         resp[j].Last,
 				High:         resp[j].High24h,
 				Low:          resp[j].Low24h,
@@ -535,7 +535,7 @@ func (o *OKEX) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 		}
 	}
 
-	return ticker.GetTicker(o.Name, p, assetType)
+	return o.GetTickerService().GetTicker(o.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -548,7 +548,7 @@ func (o *OKEX) FetchTicker(p currency.Pair, assetType asset.Item) (tickerData *t
 		return nil, err
 	}
 
-	tickerData, err = ticker.GetTicker(o.Name, fPair, assetType)
+	tickerData, err = o.GetTickerService().GetTicker(o.Name, fPair, assetType)
 	if err != nil {
 		return o.UpdateTicker(fPair, assetType)
 	}
@@ -407,7 +407,7 @@ func (o *OKEX) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = o.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         resp[j].Last,
 				High:         resp[j].High24h,
 				Low:          resp[j].Low24h,
@@ -445,7 +445,7 @@ func (o *OKEX) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = o.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         resp[j].Last,
 				High:         resp[j].High24H,
 				Low:          resp[j].Low24H,
@@ -481,7 +481,7 @@ func (o *OKEX) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 				continue
 			}
 
-			err = ticker.ProcessTicker(&ticker.Price{
+			err = o.GetTickerService().ProcessTicker(&ticker.Price{
 				Last:         resp[j].Last,
 				High:         resp[j].High24h,
 				Low:          resp[j].Low24h,
@@ -498,7 +498,7 @@ func (o *OKEX) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 		}
 	}
 
-	return ticker.GetTicker(o.Name, p, assetType)
+	return o.GetTickerService().GetTicker(o.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
@@ -511,7 +511,7 @@ func (o *OKEX) FetchTicker(p currency.Pair, assetType asset.Item) (tickerData *t
 		return nil, err
 	}
 
-	tickerData, err = ticker.GetTicker(o.Name, fPair, assetType)
+	tickerData, err = o.GetTickerService().GetTicker(o.Name, fPair, assetType)
 	if err != nil {
 		return o.UpdateTicker(fPair, assetType)
 	}
@@ -843,7 +843,7 @@ func (o *OKEX) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error)
 
 	resp.Accounts = append(resp.Accounts, currencyAccount)
 
-	err = account.Process(&resp)
+	err = o.GetAccountService().Process(&resp)
 	if err != nil {
 		return resp, err
 	}
@@ -442,7 +442,7 @@ func (o *OKGroup) wsProcessTickers(respRaw []byte) error {
 
 // wsProcessTrades converts trade data and sends it to the datahandler
 func (o *OKGroup) wsProcessTrades(respRaw []byte) error {
-	if !o.IsSaveTradeDataEnabled() {
+	if !o.IsTradeProcessingRequired() {
 		return nil
 	}
 	var response WebsocketTradeResponse
@@ -489,7 +489,7 @@ func (o *OKGroup) wsProcessTrades(respRaw []byte) error {
 			TID:          response.Data[i].TradeID,
 		})
 	}
-	return trade.AddTradesToBuffer(o.Name, trades...)
+	return o.AddTradesToBuffer(trades...)
 }
 
 // wsProcessCandles converts candle data and sends it to the data handler
@@ -76,7 +76,7 @@ func (o *OKGroup) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderb
 	if err != nil {
 		return nil, err
 	}
-	ob, err := orderbook.Get(o.Name, fPair, assetType)
+	ob, err := o.GetOrderbookService().Retrieve(o.Name, fPair, assetType)
 	if err != nil {
 		return o.UpdateOrderbook(fPair, assetType)
 	}
@@ -173,12 +173,12 @@ func (o *OKGroup) UpdateOrderbook(p currency.Pair, a asset.Item) (*orderbook.Bas
 		})
 	}
 
-	err = book.Process()
+	err = o.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
 
-	return orderbook.Get(o.Name, fPair, a)
+	return o.GetOrderbookService().Retrieve(o.Name, fPair, a)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies
@@ -211,7 +211,7 @@ func (o *OKGroup) UpdateAccountInfo(assetType asset.Item) (account.Holdings, err
 
 	resp.Accounts = append(resp.Accounts, currencyAccount)
 
-	err = account.Process(&resp)
+	err = o.GetAccountService().Process(&resp)
 	if err != nil {
 		return resp, err
 	}
@@ -221,7 +221,7 @@ func (o *OKGroup) UpdateAccountInfo(assetType asset.Item) (account.Holdings, err
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (o *OKGroup) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(o.Name, assetType)
+	acc, err := o.GetAccountService().GetHoldings(o.Name, assetType)
 	if err != nil {
 		return o.UpdateAccountInfo(assetType)
 	}
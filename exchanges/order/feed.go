@@ -0,0 +1,60 @@
+package order
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+)
+
+var (
+	errOrderDetailIsNil = errors.New("order detail is nil")
+
+	updates = feed{
+		mux: dispatch.GetNewMux(),
+		ids: make(map[string]uuid.UUID),
+	}
+)
+
+// feed routes order updates to dispatch subscribers on a per exchange basis,
+// IDs are only generated once something has subscribed
+type feed struct {
+	mu  sync.RWMutex
+	mux *dispatch.Mux
+	ids map[string]uuid.UUID
+}
+
+// SubscribeToExchangeOrders subscribes to all order updates tracked for an
+// exchange
+func SubscribeToExchangeOrders(exchange string) (dispatch.Pipe, error) {
+	exchange = strings.ToLower(exchange)
+	updates.mu.Lock()
+	defer updates.mu.Unlock()
+	id, ok := updates.ids[exchange]
+	if !ok {
+		var err error
+		id, err = updates.mux.GetID()
+		if err != nil {
+			return dispatch.Pipe{}, err
+		}
+		updates.ids[exchange] = id
+	}
+	return updates.mux.Subscribe(id)
+}
+
+// PublishUpdate pushes a copy of an order detail to any subscribers of its
+// exchange
+func PublishUpdate(d *Detail) error {
+	if d == nil {
+		return errOrderDetailIsNil
+	}
+	updates.mu.RLock()
+	id, ok := updates.ids[strings.ToLower(d.Exchange)]
+	updates.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return updates.mux.Publish([]uuid.UUID{id}, d)
+}
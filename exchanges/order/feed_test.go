@@ -0,0 +1,64 @@
+package order
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+)
+
+func TestPublishUpdate(t *testing.T) {
+	err := PublishUpdate(nil)
+	if !errors.Is(err, errOrderDetailIsNil) {
+		t.Fatalf("received: %v but expected: %v", err, errOrderDetailIsNil)
+	}
+
+	// no subscribers so this is a no-op
+	err = PublishUpdate(&Detail{Exchange: "unsubscribed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !dispatch.IsRunning() {
+		err = dispatch.Start(1, dispatch.DefaultJobsLimit)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := SubscribeToExchangeOrders("feedTest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err = p.Release(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	d := &Detail{Exchange: "FEEDTEST", ID: "1337", Status: Filled}
+	// dispatch drops payloads when the receiver is not immediately ready so
+	// keep publishing until one is received
+	timeout := time.After(time.Second * 5)
+	for {
+		err = PublishUpdate(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case data := <-p.C:
+			received, ok := (*data.(*interface{})).(Detail)
+			if !ok {
+				t.Fatal("unexpected payload type")
+			}
+			if received.ID != "1337" || received.Status != Filled {
+				t.Errorf("unexpected order received %+v", received)
+			}
+			return
+		case <-timeout:
+			t.Fatal("expected order update to be published")
+		case <-time.After(time.Millisecond * 50):
+		}
+	}
+}
@@ -379,3 +379,31 @@ func (l *Limits) ConformToAmount(amount float64) float64 {
 	fVal, _ := rVal.Float64()
 	return fVal
 }
+
+// Levels returns the limit values as a MinMaxLevel, the pair and asset are not
+// stored and are left unset
+func (l *Limits) Levels() MinMaxLevel {
+	if l == nil {
+		return MinMaxLevel{}
+	}
+	l.m.RLock()
+	defer l.m.RUnlock()
+	return MinMaxLevel{
+		MinPrice:            l.minPrice,
+		MaxPrice:            l.maxPrice,
+		StepPrice:           l.stepIncrementSizePrice,
+		MultiplierUp:        l.multiplierUp,
+		MultiplierDown:      l.multiplierDown,
+		AveragePriceMinutes: l.averagePriceMinutes,
+		MinAmount:           l.minAmount,
+		MaxAmount:           l.maxAmount,
+		StepAmount:          l.stepIncrementSizeAmount,
+		MinNotional:         l.minNotional,
+		MaxIcebergParts:     l.maxIcebergParts,
+		MarketMinQty:        l.marketMinQty,
+		MarketMaxQty:        l.marketMaxQty,
+		MarketStepSize:      l.marketStepIncrementSize,
+		MaxTotalOrders:      l.maxTotalOrders,
+		MaxAlgoOrders:       l.maxAlgoOrders,
+	}
+}
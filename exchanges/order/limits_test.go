@@ -317,3 +317,42 @@ func TestConformToAmount(t *testing.T) {
 		t.Fatal("unexpected amount", val)
 	}
 }
+
+func TestLevels(t *testing.T) {
+	t.Parallel()
+	var l *Limits
+	if l.Levels() != (MinMaxLevel{}) {
+		t.Fatal("expected empty levels for nil limits")
+	}
+
+	level := MinMaxLevel{
+		Pair:           btcusd,
+		Asset:          asset.Spot,
+		MinPrice:       1,
+		MaxPrice:       100,
+		StepPrice:      0.1,
+		MinAmount:      0.01,
+		MaxAmount:      10,
+		StepAmount:     0.01,
+		MinNotional:    5,
+		MarketMinQty:   0.1,
+		MarketMaxQty:   5,
+		MarketStepSize: 0.1,
+		MaxTotalOrders: 200,
+		MaxAlgoOrders:  5,
+	}
+	e := ExecutionLimits{}
+	err := e.LoadLimits([]MinMaxLevel{level})
+	if !errors.Is(err, nil) {
+		t.Fatalf("expected error %v but received %v", nil, err)
+	}
+	l, err = e.GetOrderExecutionLimits(asset.Spot, btcusd)
+	if !errors.Is(err, nil) {
+		t.Fatalf("expected error %v but received %v", nil, err)
+	}
+	level.Pair = currency.Pair{}
+	level.Asset = ""
+	if got := l.Levels(); got != level {
+		t.Fatalf("expected %+v but received %+v", level, got)
+	}
+}
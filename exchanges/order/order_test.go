@@ -982,6 +982,30 @@ func TestUpdateOrderFromDetail(t *testing.T) {
 	}
 }
 
+func TestUpdateOrderFromDetailPartial(t *testing.T) {
+	od := Detail{
+		ImmediateOrCancel: true,
+		HiddenOrder:       true,
+		FillOrKill:        true,
+		PostOnly:          true,
+		Price:             1,
+		Amount:            1,
+		AssetType:         asset.Spot,
+		Pair:              currency.NewPair(currency.BTC, currency.USD),
+		Status:            New,
+	}
+	od.UpdateOrderFromDetail(&Detail{Exchange: "test", ID: "1", Status: Cancelled})
+	if od.Status != Cancelled {
+		t.Error("Failed to update status")
+	}
+	if !od.ImmediateOrCancel || !od.HiddenOrder || !od.FillOrKill || !od.PostOnly {
+		t.Error("Order flags should not be cleared by a partial update")
+	}
+	if od.Price != 1 || od.Amount != 1 || od.AssetType != asset.Spot || od.Pair.IsEmpty() {
+		t.Error("Order fields should not be cleared by a partial update")
+	}
+}
+
 func TestClassificationError_Error(t *testing.T) {
 	class := ClassificationError{OrderID: "1337", Exchange: "test", Err: errors.New("test error")}
 	if class.Error() != "test - OrderID: 1337 classification error: test error" {
@@ -1092,3 +1116,49 @@ func TestValidationOnOrderTypes(t *testing.T) {
 		t.Fatal("unexpected error")
 	}
 }
+
+func TestUpdateOrderFromFill(t *testing.T) {
+	od := Detail{
+		Amount: 2,
+		Status: New,
+		Type:   Limit,
+	}
+	f := Fill{
+		ID:        "1",
+		Price:     100,
+		Amount:    1,
+		Fee:       0.1,
+		FeeAsset:  "USD",
+		Timestamp: time.Unix(1337, 0),
+	}
+	if !od.UpdateOrderFromFill(&f) {
+		t.Fatal("fill should be applied")
+	}
+	if od.ExecutedAmount != 1 || od.RemainingAmount != 1 || od.Status != PartiallyFilled {
+		t.Fatalf("unexpected order %+v", od)
+	}
+	if od.Fee != 0.1 || od.FeeAsset != "USD" || od.Cost != 100 || od.ExecutedPrice != 100 {
+		t.Fatalf("unexpected order %+v", od)
+	}
+	if !od.LastUpdated.Equal(f.Timestamp) || len(od.Trades) != 1 || od.Trades[0].Type != Limit {
+		t.Fatalf("unexpected order %+v", od)
+	}
+
+	if od.UpdateOrderFromFill(&f) {
+		t.Fatal("duplicate fill should be ignored")
+	}
+
+	// Cumulative order updates arriving first should not be double counted
+	od.UpdateOrderFromDetail(&Detail{ExecutedAmount: 2})
+	f.ID = "2"
+	f.Price = 200
+	if !od.UpdateOrderFromFill(&f) {
+		t.Fatal("fill should be applied")
+	}
+	if od.ExecutedAmount != 2 || od.RemainingAmount != 0 || od.Status != Filled {
+		t.Fatalf("unexpected order %+v", od)
+	}
+	if od.ExecutedPrice != 150 {
+		t.Fatalf("received '%v' expected '%v'", od.ExecutedPrice, 150)
+	}
+}
@@ -206,6 +206,25 @@ type TradeHistory struct {
 	Total       float64
 }
 
+// Fill defines a single execution against an order. It is sent by exchange
+// authenticated websocket feeds alongside *Detail order updates so the order
+// manager can track order state without polling
+type Fill struct {
+	Price         float64
+	Amount        float64
+	Fee           float64
+	FeeAsset      string
+	Exchange      string
+	ID            string
+	OrderID       string
+	ClientOrderID string
+	IsMaker       bool
+	Side          Side
+	AssetType     asset.Item
+	Pair          currency.Pair
+	Timestamp     time.Time
+}
+
 // GetOrdersRequest used for GetOrderHistory and GetOpenOrders wrapper functions
 type GetOrdersRequest struct {
 	Type      Type
@@ -56,19 +56,22 @@ func (s *Submit) Validate(opt ...validate.Checker) error {
 }
 
 // UpdateOrderFromDetail Will update an order detail (used in order management)
-// by comparing passed in and existing values
+// by comparing passed in and existing values. Only fields set on the passed in
+// detail are applied, so partial updates such as status changes leave the
+// rest of the order intact. Order flags are fixed when an order is placed and
+// are only ever set, never cleared.
 func (d *Detail) UpdateOrderFromDetail(m *Detail) {
 	var updated bool
-	if d.ImmediateOrCancel != m.ImmediateOrCancel {
-		d.ImmediateOrCancel = m.ImmediateOrCancel
+	if m.ImmediateOrCancel && !d.ImmediateOrCancel {
+		d.ImmediateOrCancel = true
 		updated = true
 	}
-	if d.HiddenOrder != m.HiddenOrder {
-		d.HiddenOrder = m.HiddenOrder
+	if m.HiddenOrder && !d.HiddenOrder {
+		d.HiddenOrder = true
 		updated = true
 	}
-	if d.FillOrKill != m.FillOrKill {
-		d.FillOrKill = m.FillOrKill
+	if m.FillOrKill && !d.FillOrKill {
+		d.FillOrKill = true
 		updated = true
 	}
 	if m.Price > 0 && m.Price != d.Price {
@@ -107,8 +110,8 @@ func (d *Detail) UpdateOrderFromDetail(m *Detail) {
 		d.AccountID = m.AccountID
 		updated = true
 	}
-	if m.PostOnly != d.PostOnly {
-		d.PostOnly = m.PostOnly
+	if m.PostOnly && !d.PostOnly {
+		d.PostOnly = true
 		updated = true
 	}
 	if !m.Pair.IsEmpty() && m.Pair != d.Pair {
@@ -204,6 +207,76 @@ func (d *Detail) UpdateOrderFromDetail(m *Detail) {
 	}
 }
 
+// UpdateOrderFromFill applies an execution to an order detail (used in order
+// management). Fills already recorded against the order by trade ID are
+// ignored and false is returned.
+func (d *Detail) UpdateOrderFromFill(f *Fill) bool {
+	if f.ID != "" {
+		for x := range d.Trades {
+			if d.Trades[x].TID == f.ID {
+				return false
+			}
+		}
+	}
+	d.Trades = append(d.Trades, TradeHistory{
+		Price:     f.Price,
+		Amount:    f.Amount,
+		Fee:       f.Fee,
+		Exchange:  f.Exchange,
+		TID:       f.ID,
+		Type:      d.Type,
+		Side:      f.Side,
+		Timestamp: f.Timestamp,
+		IsMaker:   f.IsMaker,
+		FeeAsset:  f.FeeAsset,
+		Total:     f.Price * f.Amount,
+	})
+
+	// Order updates carry cumulative totals which may already include this
+	// fill, so totals are only raised to what the recorded trades account for
+	var executed, cost, fee float64
+	for x := range d.Trades {
+		executed += d.Trades[x].Amount
+		cost += d.Trades[x].Total
+		fee += d.Trades[x].Fee
+	}
+	if executed > d.ExecutedAmount {
+		d.ExecutedAmount = executed
+	}
+	if cost > d.Cost {
+		d.Cost = cost
+	}
+	if fee > d.Fee {
+		d.Fee = fee
+	}
+	if d.ExecutedAmount > 0 && d.Cost > 0 {
+		d.ExecutedPrice = d.Cost / d.ExecutedAmount
+	}
+	if d.Amount > 0 {
+		d.RemainingAmount = d.Amount - d.ExecutedAmount
+		if d.RemainingAmount < 0 {
+			d.RemainingAmount = 0
+		}
+	}
+	if d.FeeAsset == "" {
+		d.FeeAsset = f.FeeAsset
+	}
+
+	switch {
+	case d.Amount > 0 && d.RemainingAmount == 0:
+		d.Status = Filled
+	case d.Status == "" || d.Status == UnknownStatus || d.Status == New || d.Status == Active:
+		d.Status = PartiallyFilled
+	}
+
+	if f.Timestamp.IsZero() {
+		d.LastUpdated = time.Now()
+	} else {
+		d.LastUpdated = f.Timestamp
+	}
+	return true
+}
+
 // UpdateOrderFromModify Will update an order detail (used in order management)
 // by comparing passed in and existing values
 func (d *Detail) UpdateOrderFromModify(m *Modify) {
@@ -27,12 +27,13 @@ type Depth struct {
 	m sync.Mutex
 }
 
-// NewDepth returns a new depth item
-func newDepth(id uuid.UUID) *Depth {
+// newDepth returns a new depth item which publishes updates through the
+// supplied mux
+func newDepth(id uuid.UUID, mux *dispatch.Mux) *Depth {
 	return &Depth{
 		stack: newStack(),
 		id:    id,
-		mux:   service.Mux,
+		mux:   mux,
 	}
 }
 
@@ -16,7 +16,7 @@ import (
 var id, _ = uuid.NewV4()
 
 func TestGetLength(t *testing.T) {
-	d := newDepth(id)
+	d := newDepth(id, service.Mux)
 	if d.GetAskLength() != 0 {
 		t.Errorf("expected len %v, but received %v", 0, d.GetAskLength())
 	}
@@ -27,7 +27,7 @@ func TestGetLength(t *testing.T) {
 		t.Errorf("expected len %v, but received %v", 1, d.GetAskLength())
 	}
 
-	d = newDepth(id)
+	d = newDepth(id, service.Mux)
 	if d.GetBidLength() != 0 {
 		t.Errorf("expected len %v, but received %v", 0, d.GetBidLength())
 	}
@@ -40,7 +40,7 @@ func TestGetLength(t *testing.T) {
 }
 
 func TestRetrieve(t *testing.T) {
-	d := newDepth(id)
+	d := newDepth(id, service.Mux)
 	d.asks.load([]Item{{Price: 1337}}, d.stack)
 	d.bids.load([]Item{{Price: 1337}}, d.stack)
 	d.options = options{
@@ -77,7 +77,7 @@ func TestRetrieve(t *testing.T) {
 }
 
 func TestTotalAmounts(t *testing.T) {
-	d := newDepth(id)
+	d := newDepth(id, service.Mux)
 
 	liquidity, value := d.TotalBidAmounts()
 	if liquidity != 0 || value != 0 {
@@ -120,7 +120,7 @@ func TestTotalAmounts(t *testing.T) {
 }
 
 func TestLoadSnapshot(t *testing.T) {
-	d := newDepth(id)
+	d := newDepth(id, service.Mux)
 	d.LoadSnapshot(Items{{Price: 1337, Amount: 1}}, Items{{Price: 1337, Amount: 10}})
 	if d.Retrieve().Asks[0].Price != 1337 || d.Retrieve().Bids[0].Price != 1337 {
 		t.Fatal("not set")
@@ -128,7 +128,7 @@ func TestLoadSnapshot(t *testing.T) {
 }
 
 func TestFlush(t *testing.T) {
-	d := newDepth(id)
+	d := newDepth(id, service.Mux)
 	d.LoadSnapshot(Items{{Price: 1337, Amount: 1}}, Items{{Price: 1337, Amount: 10}})
 	d.Flush()
 	if len(d.Retrieve().Asks) != 0 || len(d.Retrieve().Bids) != 0 {
@@ -142,7 +142,7 @@ func TestFlush(t *testing.T) {
 }
 
 func TestUpdateBidAskByPrice(t *testing.T) {
-	d := newDepth(id)
+	d := newDepth(id, service.Mux)
 	d.LoadSnapshot(Items{{Price: 1337, Amount: 1, ID: 1}}, Items{{Price: 1337, Amount: 10, ID: 2}})
 	d.UpdateBidAskByPrice(Items{{Price: 1337, Amount: 2, ID: 1}}, Items{{Price: 1337, Amount: 2, ID: 2}}, 0)
 	if d.Retrieve().Asks[0].Amount != 2 || d.Retrieve().Bids[0].Amount != 2 {
@@ -155,7 +155,7 @@ func TestUpdateBidAskByPrice(t *testing.T) {
 }
 
 func TestDeleteBidAskByID(t *testing.T) {
-	d := newDepth(id)
+	d := newDepth(id, service.Mux)
 	d.LoadSnapshot(Items{{Price: 1337, Amount: 1, ID: 1}}, Items{{Price: 1337, Amount: 10, ID: 2}})
 	err := d.DeleteBidAskByID(Items{{Price: 1337, Amount: 2, ID: 1}}, Items{{Price: 1337, Amount: 2, ID: 2}}, false)
 	if err != nil {
@@ -182,7 +182,7 @@ func TestDeleteBidAskByID(t *testing.T) {
 }
 
 func TestUpdateBidAskByID(t *testing.T) {
-	d := newDepth(id)
+	d := newDepth(id, service.Mux)
 	d.LoadSnapshot(Items{{Price: 1337, Amount: 1, ID: 1}}, Items{{Price: 1337, Amount: 10, ID: 2}})
 	err := d.UpdateBidAskByID(Items{{Price: 1337, Amount: 2, ID: 1}}, Items{{Price: 1337, Amount: 2, ID: 2}})
 	if err != nil {
@@ -205,7 +205,7 @@ func TestUpdateBidAskByID(t *testing.T) {
 }
 
 func TestInsertBidAskByID(t *testing.T) {
-	d := newDepth(id)
+	d := newDepth(id, service.Mux)
 	d.LoadSnapshot(Items{{Price: 1337, Amount: 1, ID: 1}}, Items{{Price: 1337, Amount: 10, ID: 2}})
 	err := d.InsertBidAskByID(Items{{Price: 1338, Amount: 2, ID: 3}}, Items{{Price: 1336, Amount: 2, ID: 4}})
 	if err != nil {
@@ -217,7 +217,7 @@ func TestInsertBidAskByID(t *testing.T) {
 }
 
 func TestUpdateInsertByID(t *testing.T) {
-	d := newDepth(id)
+	d := newDepth(id, service.Mux)
 	d.LoadSnapshot(Items{{Price: 1337, Amount: 1, ID: 1}}, Items{{Price: 1337, Amount: 10, ID: 2}})
 
 	err := d.UpdateInsertByID(Items{{Price: 1338, Amount: 0, ID: 3}}, Items{{Price: 1336, Amount: 2, ID: 4}})
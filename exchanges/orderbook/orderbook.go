@@ -13,6 +13,21 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
+// NewService returns a new orderbook store which is independent of the package
+// level service used by the exchange wrappers
+func NewService() *Service {
+	return &Service{
+		books: make(map[string]Exchange),
+		Mux:   dispatch.GetNewMux(),
+	}
+}
+
+// GetService returns the package level orderbook service which the exchange
+// wrappers process their orderbooks through
+func GetService() *Service {
+	return service
+}
+
 // Get checks and returns the orderbook given an exchange name and currency pair
 func Get(exchange string, p currency.Pair, a asset.Item) (*Base, error) {
 	return service.Retrieve(exchange, p, a)
@@ -33,14 +48,20 @@ func DeployDepth(exchange string, p currency.Pair, a asset.Item) (*Depth, error)
 
 // SubscribeToExchangeOrderbooks returns a pipe to an exchange feed
 func SubscribeToExchangeOrderbooks(exchange string) (dispatch.Pipe, error) {
-	service.Lock()
-	defer service.Unlock()
-	exch, ok := service.books[strings.ToLower(exchange)]
+	return service.SubscribeToExchangeOrderbooks(exchange)
+}
+
+// SubscribeToExchangeOrderbooks returns a pipe to an exchange feed stored by
+// the service
+func (s *Service) SubscribeToExchangeOrderbooks(exchange string) (dispatch.Pipe, error) {
+	s.Lock()
+	defer s.Unlock()
+	exch, ok := s.books[strings.ToLower(exchange)]
 	if !ok {
 		return dispatch.Pipe{}, fmt.Errorf("%w for %s exchange",
 			errCannotFindOrderbook, exchange)
 	}
-	return service.Mux.Subscribe(exch.ID)
+	return s.Mux.Subscribe(exch.ID)
 }
 
 // Update stores orderbook data
@@ -75,7 +96,7 @@ func (s *Service) Update(b *Base) error {
 
 	book, ok := m3[b.Pair.Quote.Item]
 	if !ok {
-		book = newDepth(m1.ID)
+		book = newDepth(m1.ID, s.Mux)
 		book.AssignOptions(b)
 		m3[b.Pair.Quote.Item] = book
 	}
@@ -123,7 +144,7 @@ func (s *Service) DeployDepth(exchange string, p currency.Pair, a asset.Item) (*
 	}
 	book, ok := m3[p.Quote.Item]
 	if !ok {
-		book = newDepth(m1.ID)
+		book = newDepth(m1.ID, s.Mux)
 		m3[p.Quote.Item] = book
 	}
 	return book, nil
@@ -303,6 +324,12 @@ func checkAlignment(depth Items, fundingRate, priceDuplication, isIDAligned bool
 // Process processes incoming orderbooks, creating or updating the orderbook
 // list
 func (b *Base) Process() error {
+	return service.Process(b)
+}
+
+// Process processes incoming orderbooks, creating or updating the service
+// orderbook list
+func (s *Service) Process(b *Base) error {
 	if b.Exchange == "" {
 		return errExchangeNameUnset
 	}
@@ -323,7 +350,7 @@ func (b *Base) Process() error {
 	if err != nil {
 		return err
 	}
-	return service.Update(b)
+	return s.Update(b)
 }
 
 // Reverse reverses the order of orderbook items; some bid/asks are
@@ -33,10 +33,7 @@ var (
 	errPeriodUnset         = errors.New("funding rate period is unset")
 )
 
-var service = Service{
-	books: make(map[string]Exchange),
-	Mux:   dispatch.GetNewMux(),
-}
+var service = NewService()
 
 // Service provides a store for difference exchange orderbooks
 type Service struct {
@@ -844,6 +844,20 @@ func (p *Poloniex) SendHTTPRequest(ep exchange.URL, path string, result interfac
 	})
 }
 
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	order.Buy.Lower(),
+	order.Sell.Lower(),
+	poloniexOrderCancel,
+	poloniexOrderMove,
+	poloniexMarginBuy,
+	poloniexMarginSell,
+	poloniexMarginPositionClose,
+	poloniexCreateLoanOffer,
+	poloniexCancelLoanOffer,
+}
+
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request
 func (p *Poloniex) SendAuthenticatedHTTPRequest(ep exchange.URL, method, endpoint string, values url.Values, result interface{}) error {
 	if !p.AllowAuthenticatedRequest() {
@@ -875,6 +889,7 @@ func (p *Poloniex) SendAuthenticatedHTTPRequest(ep exchange.URL, method, endpoin
 		Body:          bytes.NewBufferString(values.Encode()),
 		Result:        result,
 		AuthRequest:   true,
+		Priority:      request.PriorityByEndpoint(endpoint, orderEndpoints...),
 		Verbose:       p.Verbose,
 		HTTPDebugging: p.HTTPDebugging,
 		HTTPRecording: p.HTTPRecording,
@@ -1070,7 +1070,7 @@ func (p *Poloniex) processAccountKilledOrder(notification []interface{}) error {
 }
 
 func (p *Poloniex) processTrades(currencyID float64, subData []interface{}) error {
-	if !p.IsSaveTradeDataEnabled() {
+	if !p.IsTradeProcessingRequired() {
 		return nil
 	}
 	pair, err := p.details.GetPair(currencyID)
@@ -291,7 +291,7 @@ func (p *Poloniex) UpdateTicker(currencyPair currency.Pair, assetType asset.Item
 			continue
 		}
 
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = p.GetTickerService().ProcessTicker(&ticker.Price{
 			Pair:         enabledPairs[i],
 			Ask:          tick[curr].LowestAsk,
 			Bid:          tick[curr].HighestBid,
@@ -306,12 +306,12 @@ func (p *Poloniex) UpdateTicker(currencyPair currency.Pair, assetType asset.Item
 			return nil, err
 		}
 	}
-	return ticker.GetTicker(p.Name, currencyPair, assetType)
+	return p.GetTickerService().GetTicker(p.Name, currencyPair, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (p *Poloniex) FetchTicker(currencyPair currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(p.Name, currencyPair, assetType)
+	tickerNew, err := p.GetTickerService().GetTicker(p.Name, currencyPair, assetType)
 	if err != nil {
 		return p.UpdateTicker(currencyPair, assetType)
 	}
@@ -320,7 +320,7 @@ func (p *Poloniex) FetchTicker(currencyPair currency.Pair, assetType asset.Item)
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (p *Poloniex) FetchOrderbook(currencyPair currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(p.Name, currencyPair, assetType)
+	ob, err := p.GetOrderbookService().Retrieve(p.Name, currencyPair, assetType)
 	if err != nil {
 		return p.UpdateOrderbook(currencyPair, assetType)
 	}
@@ -374,12 +374,12 @@ func (p *Poloniex) UpdateOrderbook(c currency.Pair, assetType asset.Item) (*orde
 				Price:  data.Asks[y].Price,
 			})
 		}
-		err = book.Process()
+		err = p.GetOrderbookService().Process(book)
 		if err != nil {
 			return book, err
 		}
 	}
-	return orderbook.Get(p.Name, c, assetType)
+	return p.GetOrderbookService().Retrieve(p.Name, c, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -404,7 +404,7 @@ func (p *Poloniex) UpdateAccountInfo(assetType asset.Item) (account.Holdings, er
 		Currencies: currencies,
 	})
 
-	err = account.Process(&response)
+	err = p.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -414,7 +414,7 @@ func (p *Poloniex) UpdateAccountInfo(assetType asset.Item) (account.Holdings, er
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (p *Poloniex) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(p.Name, assetType)
+	acc, err := p.GetAccountService().GetHoldings(p.Name, assetType)
 	if err != nil {
 		return p.UpdateAccountInfo(assetType)
 	}
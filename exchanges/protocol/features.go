@@ -41,4 +41,7 @@ type Features struct {
 	MessageCorrelation     bool `json:"messageCorrelation,omitempty"`
 	MessageSequenceNumbers bool `json:"messageSequenceNumbers,omitempty"`
 	CandleHistory          bool `json:"candlehistory,omitempty"`
+	// OrderUpdates pushes order status changes and fills over the
+	// authenticated connection as *order.Detail and *order.Fill
+	OrderUpdates bool `json:"orderUpdates,omitempty"`
 }
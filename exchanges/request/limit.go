@@ -27,6 +27,11 @@ func (b *BasicLimit) Limit(_ EndpointLimit) error {
 	return nil
 }
 
+// LimiterFor returns the single rate limiter every request draws from
+func (b *BasicLimit) LimiterFor(_ EndpointLimit) *rate.Limiter {
+	return b.r
+}
+
 // EndpointLimit defines individual endpoint rate limits that are set when
 // New is called.
 type EndpointLimit int
@@ -38,6 +43,14 @@ type Limiter interface {
 	Limit(EndpointLimit) error
 }
 
+// LimiterResolver is implemented by limiters which can report the rate
+// limiter an endpoint limit draws from. Endpoint limits commonly share a rate
+// limiter, requests to them are queued together so priority applies across
+// the whole budget
+type LimiterResolver interface {
+	LimiterFor(EndpointLimit) *rate.Limiter
+}
+
 // NewRateLimit creates a new RateLimit based of time interval and how many
 // actions allowed and breaks it down to an actions-per-second basis -- Burst
 // rate is kept as one as this is not supported for out-bound requests.
@@ -20,3 +20,11 @@ func WithRetryPolicy(p RetryPolicy) RequesterOption {
 		r.retryPolicy = p
 	}
 }
+
+// WithShedThreshold configures the amount of requests waiting on the rate
+// limiter at which market data requests are shed for a Requester.
+func WithShedThreshold(n int) RequesterOption {
+	return func(r *Requester) {
+		r.shedThreshold = n
+	}
+}
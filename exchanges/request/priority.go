@@ -0,0 +1,214 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Priority defines the scheduling class of a request. When requests are
+// queued behind an exchange's rate limiter, higher classes are served first.
+type Priority uint8
+
+// Const here define the request priority classes from highest to lowest
+const (
+	// UnsetPriority derives the class from the request item, see
+	// Item.GetPriority
+	UnsetPriority Priority = iota
+	// TradingPriority is for order submission, modification and cancellation
+	TradingPriority
+	// AccountPriority is for balance, position and order status queries
+	AccountPriority
+	// MarketDataPriority is for public ticker, orderbook and trade polling
+	MarketDataPriority
+)
+
+// priorityLanes is the amount of resolvable priority classes
+const priorityLanes = int(MarketDataPriority)
+
+// ErrRequestShed is returned when a market data request is dropped because
+// too many requests are already waiting on the rate limiter
+var ErrRequestShed = errors.New("market data request shed, rate limit budget reserved for higher priority requests")
+
+// String implements the stringer interface
+func (p Priority) String() string {
+	switch p {
+	case TradingPriority:
+		return "trading"
+	case AccountPriority:
+		return "account"
+	case MarketDataPriority:
+		return "market data"
+	default:
+		return "unset"
+	}
+}
+
+// GetPriority returns the scheduling class of the request. If no valid class
+// is set, unauthenticated requests are treated as market data,
+// authenticated GET requests as account queries and all other authenticated
+// requests as trading actions.
+func (i *Item) GetPriority() Priority {
+	if i.Priority != UnsetPriority && i.Priority <= MarketDataPriority {
+		return i.Priority
+	}
+	if !i.AuthRequest {
+		return MarketDataPriority
+	}
+	if i.Method == "" || i.Method == http.MethodGet {
+		return AccountPriority
+	}
+	return TradingPriority
+}
+
+// PriorityByEndpoint returns TradingPriority when the endpoint is one of the
+// supplied order endpoints and AccountPriority otherwise. It is used by
+// exchanges which send every private request with the same HTTP method. An
+// order endpoint ending in a slash matches any endpoint it prefixes.
+func PriorityByEndpoint(endpoint string, orderEndpoints ...string) Priority {
+	for i := range orderEndpoints {
+		if endpoint == orderEndpoints[i] ||
+			(strings.HasSuffix(orderEndpoints[i], "/") &&
+				strings.HasPrefix(endpoint, orderEndpoints[i])) {
+			return TradingPriority
+		}
+	}
+	return AccountPriority
+}
+
+// scheduler serialises access to the rate limiter and hands out turns to
+// waiting requests in priority order, FIFO within a priority class
+type scheduler struct {
+	m      sync.Mutex
+	active bool
+	queued int
+	lanes  [priorityLanes][]chan struct{}
+}
+
+// acquire blocks until the caller holds the rate limiter turn. Market data
+// requests are shed when the amount of waiting requests meets the threshold,
+// a threshold of zero or less disables shedding.
+func (s *scheduler) acquire(ctx context.Context, p Priority, shedThreshold int) error {
+	s.m.Lock()
+	if !s.active {
+		s.active = true
+		s.m.Unlock()
+		return nil
+	}
+	if p == MarketDataPriority && shedThreshold > 0 && s.queued >= shedThreshold {
+		s.m.Unlock()
+		return ErrRequestShed
+	}
+	lane := int(p) - 1
+	turn := make(chan struct{})
+	s.lanes[lane] = append(s.lanes[lane], turn)
+	s.queued++
+	s.m.Unlock()
+
+	select {
+	case <-turn:
+		return nil
+	case <-ctx.Done():
+		s.m.Lock()
+		for i := range s.lanes[lane] {
+			if s.lanes[lane][i] == turn {
+				s.lanes[lane] = append(s.lanes[lane][:i], s.lanes[lane][i+1:]...)
+				s.queued--
+				s.m.Unlock()
+				return ctx.Err()
+			}
+		}
+		s.m.Unlock()
+		// The turn was handed over while the context was cancelled, pass it
+		// on so the queue does not stall
+		s.release()
+		return ctx.Err()
+	}
+}
+
+// release hands the rate limiter turn to the next waiting request with the
+// highest priority
+func (s *scheduler) release() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for i := range s.lanes {
+		if len(s.lanes[i]) == 0 {
+			continue
+		}
+		turn := s.lanes[i][0]
+		s.lanes[i][0] = nil
+		s.lanes[i] = s.lanes[i][1:]
+		s.queued--
+		close(turn)
+		return
+	}
+	s.active = false
+}
+
+// getQueued returns the amount of requests waiting on the rate limiter
+func (s *scheduler) getQueued() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.queued
+}
+
+// getScheduler returns the scheduler for an endpoint limit. Endpoint limits
+// are scheduled by the rate limiter they draw from, so requests competing for
+// the same budget share a queue while a throttled limiter does not hold up
+// requests to limiters which have room. Limiters which cannot report their
+// rate limiters are scheduled per endpoint limit
+func (r *Requester) getScheduler(e EndpointLimit) *scheduler {
+	var key interface{} = e
+	if resolver, ok := r.limiter.(LimiterResolver); ok {
+		if l := resolver.LimiterFor(e); l != nil {
+			key = l
+		}
+	}
+	r.schedulersMtx.Lock()
+	defer r.schedulersMtx.Unlock()
+	if r.schedulers == nil {
+		r.schedulers = make(map[interface{}]*scheduler)
+	}
+	s, ok := r.schedulers[key]
+	if !ok {
+		s = new(scheduler)
+		r.schedulers[key] = s
+	}
+	return s
+}
+
+// limit waits for a prioritised turn on the endpoint's rate limiter and then
+// sleeps for the designated endpoint rate limit
+func (r *Requester) limit(ctx context.Context, i *Item) error {
+	if r.limiter == nil || atomic.LoadInt32(&r.disableRateLimiter) == 1 {
+		return nil
+	}
+
+	threshold := r.shedThreshold
+	if threshold == 0 {
+		threshold = MarketDataShedThreshold
+	}
+
+	s := r.getScheduler(i.Endpoint)
+	err := s.acquire(ctx, i.GetPriority(), threshold)
+	if err != nil {
+		return err
+	}
+	defer s.release()
+	return r.InitiateRateLimit(i.Endpoint)
+}
+
+// GetQueuedRequests returns the amount of requests currently waiting on the
+// rate limiter across all endpoints
+func (r *Requester) GetQueuedRequests() int {
+	r.schedulersMtx.Lock()
+	defer r.schedulersMtx.Unlock()
+	var queued int
+	for _, s := range r.schedulers {
+		queued += s.getQueued()
+	}
+	return queued
+}
@@ -0,0 +1,260 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestGetPriority(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		item     Item
+		expected Priority
+	}{
+		{Item{Method: http.MethodGet}, MarketDataPriority},
+		{Item{Method: http.MethodPost}, MarketDataPriority},
+		{Item{Method: http.MethodGet, AuthRequest: true}, AccountPriority},
+		{Item{AuthRequest: true}, AccountPriority},
+		{Item{Method: http.MethodPost, AuthRequest: true}, TradingPriority},
+		{Item{Method: http.MethodDelete, AuthRequest: true}, TradingPriority},
+		{Item{Method: http.MethodGet, Priority: TradingPriority}, TradingPriority},
+		{Item{Method: http.MethodPost, AuthRequest: true, Priority: AccountPriority}, AccountPriority},
+		{Item{Method: http.MethodGet, Priority: 200}, MarketDataPriority},
+	}
+	for x := range tests {
+		if p := tests[x].item.GetPriority(); p != tests[x].expected {
+			t.Errorf("test %d: received '%v' expected '%v'", x, p, tests[x].expected)
+		}
+	}
+}
+
+func TestPriorityString(t *testing.T) {
+	t.Parallel()
+	if TradingPriority.String() != "trading" ||
+		AccountPriority.String() != "account" ||
+		MarketDataPriority.String() != "market data" ||
+		UnsetPriority.String() != "unset" {
+		t.Fatal(unexpected)
+	}
+}
+
+func TestPriorityByEndpoint(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		endpoint string
+		expected Priority
+	}{
+		{"order/new", TradingPriority},
+		{"order/new/multi", AccountPriority},
+		{"buy/btcusd", TradingPriority},
+		{"buy/", TradingPriority},
+		{"balance", AccountPriority},
+		{"", AccountPriority},
+	}
+	for x := range tests {
+		p := PriorityByEndpoint(tests[x].endpoint, "order/new", "buy/")
+		if p != tests[x].expected {
+			t.Errorf("test %d: received '%v' expected '%v'", x, p, tests[x].expected)
+		}
+	}
+}
+
+func waitForQueued(t *testing.T, s *scheduler, amount int) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if s.getQueued() == amount {
+			return
+		}
+		time.Sleep(time.Millisecond * 5)
+	}
+	t.Fatalf("received '%v' queued requests expected '%v'", s.getQueued(), amount)
+}
+
+func TestSchedulerOrdering(t *testing.T) {
+	t.Parallel()
+	var s scheduler
+	ctx := context.Background()
+	if err := s.acquire(ctx, MarketDataPriority, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	served := make(chan Priority, 3)
+	queue := func(p Priority) {
+		if err := s.acquire(ctx, p, 0); err != nil {
+			t.Error(err)
+		}
+		served <- p
+		s.release()
+	}
+
+	go queue(MarketDataPriority)
+	waitForQueued(t, &s, 1)
+	go queue(AccountPriority)
+	waitForQueued(t, &s, 2)
+	go queue(TradingPriority)
+	waitForQueued(t, &s, 3)
+
+	s.release()
+	for _, expected := range []Priority{TradingPriority, AccountPriority, MarketDataPriority} {
+		if p := <-served; p != expected {
+			t.Fatalf("received '%v' expected '%v'", p, expected)
+		}
+	}
+
+	waitForQueued(t, &s, 0)
+	s.m.Lock()
+	active := s.active
+	s.m.Unlock()
+	if active {
+		t.Fatal("scheduler should be idle")
+	}
+}
+
+func TestSchedulerShedding(t *testing.T) {
+	t.Parallel()
+	var s scheduler
+	ctx := context.Background()
+	if err := s.acquire(ctx, TradingPriority, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		if err := s.acquire(ctx, AccountPriority, 1); err != nil {
+			t.Error(err)
+		}
+		s.release()
+		close(done)
+	}()
+	waitForQueued(t, &s, 1)
+
+	err := s.acquire(ctx, MarketDataPriority, 1)
+	if !errors.Is(err, ErrRequestShed) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrRequestShed)
+	}
+
+	s.release()
+	<-done
+}
+
+func TestSchedulerContextCancel(t *testing.T) {
+	t.Parallel()
+	var s scheduler
+	if err := s.acquire(context.Background(), TradingPriority, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		errs <- s.acquire(ctx, MarketDataPriority, 0)
+	}()
+	waitForQueued(t, &s, 1)
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("received '%v' expected '%v'", err, context.Canceled)
+	}
+	if s.getQueued() != 0 {
+		t.Fatal("cancelled request should be removed from the queue")
+	}
+	s.release()
+}
+
+func TestRequesterShedThreshold(t *testing.T) {
+	t.Parallel()
+	r := New("test",
+		new(http.Client),
+		WithLimiter(NewBasicRateLimit(time.Second, 1)),
+		WithShedThreshold(1))
+	if r.shedThreshold != 1 {
+		t.Fatal(unexpected)
+	}
+
+	ctx := context.Background()
+	s := r.getScheduler(Unset)
+	if err := s.acquire(ctx, TradingPriority, 0); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = s.acquire(ctx, TradingPriority, 0)
+		s.release()
+	}()
+	waitForQueued(t, s, 1)
+	if r.GetQueuedRequests() != 1 {
+		t.Fatal(unexpected)
+	}
+
+	err := r.limit(ctx, &Item{Method: http.MethodGet})
+	if !errors.Is(err, ErrRequestShed) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrRequestShed)
+	}
+	s.release()
+}
+
+func TestRequesterEndpointsScheduledSeparately(t *testing.T) {
+	t.Parallel()
+	r := New("test",
+		new(http.Client),
+		WithLimiter(&GlobalLimitTest{
+			Auth:   NewRateLimit(time.Hour, 1),
+			UnAuth: NewRateLimit(time.Second, 100),
+		}))
+
+	// hold the auth endpoint's turn as a request sleeping on a throttled
+	// limiter would
+	auth := r.getScheduler(Auth)
+	if err := auth.acquire(context.Background(), TradingPriority, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer auth.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r.limit(ctx, &Item{Method: http.MethodGet, Endpoint: UnAuth}); err != nil {
+		t.Fatalf("expected unauthenticated request not to wait on the auth endpoint, received '%v'", err)
+	}
+}
+
+// sharedLimitTest maps the auth and unauth endpoint limits to one limiter
+type sharedLimitTest struct {
+	shared *rate.Limiter
+	other  *rate.Limiter
+}
+
+func (s *sharedLimitTest) Limit(e EndpointLimit) error {
+	time.Sleep(s.LimiterFor(e).Reserve().Delay())
+	return nil
+}
+
+func (s *sharedLimitTest) LimiterFor(e EndpointLimit) *rate.Limiter {
+	if e == Auth || e == UnAuth {
+		return s.shared
+	}
+	return s.other
+}
+
+func TestRequesterSharedLimiterScheduledTogether(t *testing.T) {
+	t.Parallel()
+	r := New("test",
+		new(http.Client),
+		WithLimiter(&sharedLimitTest{
+			shared: NewRateLimit(time.Second, 100),
+			other:  NewRateLimit(time.Second, 100),
+		}))
+	if r.getScheduler(Auth) != r.getScheduler(UnAuth) {
+		t.Error("expected endpoint limits sharing a limiter to share a scheduler")
+	}
+	if r.getScheduler(Auth) == r.getScheduler(Unset) {
+		t.Error("expected endpoint limits with separate limiters to be scheduled separately")
+	}
+
+	basic := New("test", new(http.Client), WithLimiter(NewBasicRateLimit(time.Second, 100)))
+	if basic.getScheduler(Auth) != basic.getScheduler(UnAuth) {
+		t.Error("expected a basic limiter to schedule every endpoint limit together")
+	}
+}
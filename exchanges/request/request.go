@@ -62,13 +62,42 @@ func (r *Requester) SendPayload(ctx context.Context, i *Item) error {
 	}
 
 	atomic.AddInt32(&r.jobs, 1)
+	start := time.Now()
 	err = r.doRequest(req, i)
 	atomic.AddInt32(&r.jobs, -1)
 	r.timedLock.UnlockIfLocked()
+	r.recordResult(err, time.Since(start))
 
 	return err
 }
 
+// recordResult tallies the outcome of a sent request. Shed and cancelled
+// requests are not counted as they say nothing about the exchange, and only
+// errors which indicate the exchange is unavailable count as failures.
+// Rejections such as insufficient funds mean the exchange is working.
+func (r *Requester) recordResult(err error, duration time.Duration) {
+	if errors.Is(err, ErrRequestShed) || errors.Is(err, context.Canceled) {
+		return
+	}
+	atomic.AddUint32(&r.requests, 1)
+	requestDuration.Observe(duration.Seconds(), r.Name)
+	if err != nil {
+		requestErrors.Inc(r.Name)
+		var unavailable *unavailableError
+		if errors.As(err, &unavailable) {
+			atomic.AddUint32(&r.failures, 1)
+		}
+	}
+}
+
+// GetRequestStats returns the running totals of requests sent and requests
+// that failed because the exchange could not be reached or responded with a
+// server error or rate limit status. The counters wrap around, so callers
+// should compare the difference between two readings.
+func (r *Requester) GetRequestStats() (requests, failures uint32) {
+	return atomic.LoadUint32(&r.requests), atomic.LoadUint32(&r.failures)
+}
+
 // validateRequest validates the requester item fields
 func (i *Item) validateRequest(ctx context.Context, r *Requester) (*http.Request, error) {
 	if r == nil || r.Name == "" {
@@ -139,14 +168,14 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 
 	for attempt := 1; ; attempt++ {
 		// Initiate a rate limit reservation and sleep on requested endpoint
-		err := r.InitiateRateLimit(p.Endpoint)
+		err := r.limit(req.Context(), p)
 		if err != nil {
 			return err
 		}
 
 		resp, err := r.HTTPClient.Do(req)
 		if retry, checkErr := r.retryPolicy(resp, err); checkErr != nil {
-			return checkErr
+			return &unavailableError{checkErr}
 		} else if retry {
 			if err == nil {
 				// If the body isn't fully read, the connection cannot be re-used
@@ -156,15 +185,15 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 			// Can't currently regenerate nonce and signatures with fresh values for retries, so for now, we must not retry
 			if p.NonceEnabled {
 				if timeoutErr, ok := err.(net.Error); !ok || !timeoutErr.Timeout() {
-					return fmt.Errorf("request.go error - unable to retry request using nonce, err: %v", err)
+					return &unavailableError{fmt.Errorf("request.go error - unable to retry request using nonce, err: %v", err)}
 				}
 			}
 
 			if attempt > r.maxRetries {
 				if err != nil {
-					return fmt.Errorf("request.go error - failed to retry request, err: %v", err)
+					return &unavailableError{fmt.Errorf("request.go error - failed to retry request, err: %v", err)}
 				}
-				return fmt.Errorf("request.go error - failed to retry request, status: %s", resp.Status)
+				return &unavailableError{fmt.Errorf("request.go error - failed to retry request, status: %s", resp.Status)}
 			}
 
 			after := RetryAfter(resp, time.Now())
@@ -176,9 +205,9 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 
 			if d, ok := req.Context().Deadline(); ok && d.After(time.Now()) && time.Now().Add(delay).After(d) {
 				if err != nil {
-					return fmt.Errorf("request.go error - deadline would be exceeded by retry, err: %v", err)
+					return &unavailableError{fmt.Errorf("request.go error - deadline would be exceeded by retry, err: %v", err)}
 				}
-				return fmt.Errorf("request.go error - deadline would be exceeded by retry, status: %s", resp.Status)
+				return &unavailableError{fmt.Errorf("request.go error - deadline would be exceeded by retry, status: %s", resp.Status)}
 			}
 
 			if p.Verbose {
@@ -214,10 +243,15 @@ func (r *Requester) doRequest(req *http.Request, p *Item) error {
 
 		if resp.StatusCode < http.StatusOK ||
 			resp.StatusCode > http.StatusAccepted {
-			return fmt.Errorf("%s unsuccessful HTTP status code: %d raw response: %s",
+			err = fmt.Errorf("%s unsuccessful HTTP status code: %d raw response: %s",
 				r.Name,
 				resp.StatusCode,
 				string(contents))
+			if resp.StatusCode >= http.StatusInternalServerError ||
+				resp.StatusCode == http.StatusTooManyRequests {
+				return &unavailableError{err}
+			}
+			return err
 		}
 
 		if p.HTTPDebugging {
@@ -437,6 +437,48 @@ func TestDoRequest_NotRetryable(t *testing.T) {
 	}
 }
 
+func TestGetRequestStats(t *testing.T) {
+	t.Parallel()
+	r := New("requeststats", new(http.Client))
+	err := r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   testURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   testURL + "/error",
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	// A server error counts as a failure, a client error does not
+	err = r.SendPayload(context.Background(), &Item{
+		Method: http.MethodGet,
+		Path:   testURL + "/timeout",
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	r.recordResult(ErrRequestShed, time.Second)
+	r.recordResult(context.Canceled, time.Second)
+
+	requests, failures := r.GetRequestStats()
+	if requests != 3 || failures != 1 {
+		t.Fatalf("received %d requests %d failures expected 3 requests 1 failure",
+			requests,
+			failures)
+	}
+	if c := requestDuration.Count(r.Name); c != 3 {
+		t.Errorf("received %d observed durations expected 3", c)
+	}
+	if v := requestErrors.Value(r.Name); v != 2 {
+		t.Errorf("received %v errors expected 2", v)
+	}
+}
+
 func TestGetNonce(t *testing.T) {
 	t.Parallel()
 	r := New("test",
@@ -3,26 +3,41 @@ package request
 import (
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common/timedmutex"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/nonce"
+	"github.com/thrasher-corp/gocryptotrader/metrics"
 )
 
 // Const vars for rate limiter
 const (
-	DefaultMaxRequestJobs   int32 = 50
-	DefaultMaxRetryAttempts       = 3
-	DefaultMutexLockTimeout       = 50 * time.Millisecond
-	drainBodyLimit                = 100000
-	proxyTLSTimeout               = 15 * time.Second
-	userAgent                     = "User-Agent"
+	DefaultMaxRequestJobs          int32 = 50
+	DefaultMaxRetryAttempts              = 3
+	DefaultMutexLockTimeout              = 50 * time.Millisecond
+	DefaultMarketDataShedThreshold       = 0
+	drainBodyLimit                       = 100000
+	proxyTLSTimeout                      = 15 * time.Second
+	userAgent                            = "User-Agent"
 )
 
 // Vars for rate limiter
 var (
 	MaxRequestJobs   = DefaultMaxRequestJobs
 	MaxRetryAttempts = DefaultMaxRetryAttempts
+	// MarketDataShedThreshold is the amount of requests waiting on an
+	// exchange's rate limiter at which market data requests are shed, zero
+	// disables shedding
+	MarketDataShedThreshold = DefaultMarketDataShedThreshold
+
+	requestDuration = metrics.Histogram("gct_request_duration_seconds",
+		"Duration of exchange REST requests including retries",
+		metrics.DefaultBuckets,
+		"exchange")
+	requestErrors = metrics.Counter("gct_request_errors_total",
+		"Number of exchange REST requests which returned an error",
+		"exchange")
 )
 
 // Requester struct for the request client
@@ -38,6 +53,11 @@ type Requester struct {
 	backoff            Backoff
 	retryPolicy        RetryPolicy
 	timedLock          *timedmutex.TimedMutex
+	schedulers         map[interface{}]*scheduler
+	schedulersMtx      sync.Mutex
+	shedThreshold      int
+	requests           uint32
+	failures           uint32
 }
 
 // Item is a temp item for requests
@@ -57,6 +77,9 @@ type Item struct {
 	// pagination
 	HeaderResponse *http.Header
 	Endpoint       EndpointLimit
+	// Priority sets the scheduling class on the rate limiter, if unset it is
+	// derived from the request, see GetPriority
+	Priority Priority
 }
 
 // Backoff determines how long to wait between request attempts.
@@ -67,3 +90,19 @@ type RetryPolicy func(resp *http.Response, err error) (bool, error)
 
 // RequesterOption is a function option that can be applied to configure a Requester when creating it.
 type RequesterOption func(*Requester)
+
+// unavailableError wraps an error returned when an exchange could not be
+// reached or responded with a server error or rate limit status code
+type unavailableError struct {
+	err error
+}
+
+// Error implements the error interface
+func (u *unavailableError) Error() string {
+	return u.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (u *unavailableError) Unwrap() error {
+	return u.err
+}
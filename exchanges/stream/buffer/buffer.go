@@ -24,6 +24,22 @@ var (
 	errRESTOverwrite                = errors.New("orderbook has been overwritten by REST protocol")
 )
 
+// SetService sets the orderbook service depth is deployed to
+func (w *Orderbook) SetService(s *orderbook.Service) {
+	w.m.Lock()
+	w.service = s
+	w.m.Unlock()
+}
+
+// getService returns the orderbook service depth is deployed to, falling back
+// to the package level service
+func (w *Orderbook) getService() *orderbook.Service {
+	if w.service != nil {
+		return w.service
+	}
+	return orderbook.GetService()
+}
+
 // Setup sets private variables
 func (w *Orderbook) Setup(obBufferLimit int,
 	bufferEnabled,
@@ -232,7 +248,7 @@ func (w *Orderbook) LoadSnapshot(book *orderbook.Base) error {
 	holder, ok := m2[book.Asset]
 	if !ok {
 		// Associate orderbook pointer with local exchange depth map
-		depth, err := orderbook.DeployDepth(book.Exchange, book.Pair, book.Asset)
+		depth, err := w.getService().DeployDepth(book.Exchange, book.Pair, book.Asset)
 		if err != nil {
 			return err
 		}
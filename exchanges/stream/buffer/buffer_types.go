@@ -25,7 +25,10 @@ type Orderbook struct {
 	exchangeName          string
 	dataHandler           chan interface{}
 	verbose               bool
-	m                     sync.Mutex
+	// service stores the orderbook depth, the package level orderbook
+	// service is used when unset
+	service *orderbook.Service
+	m       sync.Mutex
 }
 
 // orderbookHolder defines a store of pending updates and a pointer to the
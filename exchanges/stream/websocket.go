@@ -12,6 +12,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/metrics"
 )
 
 const (
@@ -23,7 +24,19 @@ const (
 	defaultTrafficPeriod = time.Second
 )
 
-var errClosedConnection = errors.New("use of closed network connection")
+var (
+	errClosedConnection = errors.New("use of closed network connection")
+
+	websocketConnected = metrics.Gauge("gct_websocket_connected",
+		"Whether the exchange websocket is connected (1) or not (0)",
+		"exchange")
+	websocketReconnects = metrics.Counter("gct_websocket_reconnects_total",
+		"Number of successful websocket reconnections after the initial connection",
+		"exchange")
+	websocketMessages = metrics.Counter("gct_websocket_messages_total",
+		"Number of websocket messages received",
+		"exchange")
+)
 
 // New initialises the websocket struct
 func New() *Websocket {
@@ -201,6 +214,10 @@ func (w *Websocket) Connect() error {
 	w.setConnectedStatus(true)
 	w.setConnectingStatus(false)
 	w.setInit(true)
+	if w.hasConnected {
+		websocketReconnects.Inc(w.exchangeName)
+	}
+	w.hasConnected = true
 
 	if !w.IsConnectionMonitorRunning() {
 		w.connectionMonitor()
@@ -499,6 +516,7 @@ func (w *Websocket) trafficMonitor() {
 				w.setConnectedStatus(true)
 				trafficTimer.Reset(w.trafficTimeout)
 			case <-trafficTimer.C: // Falls through when timer runs out
+				w.setLastTrafficTimeout(time.Now())
 				if w.verbose {
 					log.Warnf(log.WebsocketMgr,
 						"%v websocket: has not received a traffic alert in %v. Reconnecting",
@@ -542,6 +560,11 @@ func (w *Websocket) setConnectedStatus(b bool) {
 	w.connectionMutex.Lock()
 	w.connected = b
 	w.connectionMutex.Unlock()
+	if b {
+		websocketConnected.Set(1, w.exchangeName)
+	} else {
+		websocketConnected.Set(0, w.exchangeName)
+	}
 }
 
 // IsConnected returns status of connection
@@ -551,6 +574,20 @@ func (w *Websocket) IsConnected() bool {
 	return w.connected
 }
 
+func (w *Websocket) setLastTrafficTimeout(t time.Time) {
+	w.connectionMutex.Lock()
+	w.lastTrafficTimeout = t
+	w.connectionMutex.Unlock()
+}
+
+// GetLastTrafficTimeout returns when the traffic monitor last timed out
+// waiting for data, zero if it never has
+func (w *Websocket) GetLastTrafficTimeout() time.Time {
+	w.connectionMutex.RLock()
+	defer w.connectionMutex.RUnlock()
+	return w.lastTrafficTimeout
+}
+
 func (w *Websocket) setConnectingStatus(b bool) {
 	w.connectionMutex.Lock()
 	w.connecting = b
@@ -211,6 +211,7 @@ func (w *WebsocketConnection) ReadMessage() Response {
 		return Response{}
 	}
 
+	websocketMessages.Inc(w.ExchangeName)
 	select {
 	case w.Traffic <- struct{}{}:
 	default: // causes contention, just bypass if there is no receiver.
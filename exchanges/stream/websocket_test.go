@@ -182,6 +182,9 @@ func TestTrafficMonitorTimeout(t *testing.T) {
 	if ws.IsTrafficMonitorRunning() {
 		t.Error("should be ded")
 	}
+	if ws.GetLastTrafficTimeout().IsZero() {
+		t.Error("traffic timeout should be recorded")
+	}
 }
 
 func TestIsDisconnectionError(t *testing.T) {
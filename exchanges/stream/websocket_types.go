@@ -29,11 +29,13 @@ type Websocket struct {
 	Init                         bool
 	connected                    bool
 	connecting                   bool
+	hasConnected                 bool
 	verbose                      bool
 	connectionMonitorRunning     bool
 	trafficMonitorRunning        bool
 	dataMonitorRunning           bool
 	trafficTimeout               time.Duration
+	lastTrafficTimeout           time.Time
 	proxyAddr                    string
 	defaultURL                   string
 	defaultURLAuth               string
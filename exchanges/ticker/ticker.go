@@ -13,73 +13,137 @@ import (
 )
 
 func init() {
-	service = new(Service)
-	service.Tickers = make(map[string]map[*currency.Item]map[*currency.Item]map[asset.Item]*Ticker)
-	service.Exchange = make(map[string]uuid.UUID)
-	service.mux = dispatch.GetNewMux()
+	service = NewService()
+}
+
+// NewService returns a new ticker store which is independent of the package
+// level service used by the exchange wrappers
+func NewService() *Service {
+	return &Service{
+		Tickers:  make(map[string]map[*currency.Item]map[*currency.Item]map[asset.Item]*Ticker),
+		Exchange: make(map[string]uuid.UUID),
+		mux:      dispatch.GetNewMux(),
+	}
+}
+
+// GetService returns the package level ticker service which the exchange
+// wrappers process their tickers through
+func GetService() *Service {
+	return service
 }
 
 // SubscribeTicker subcribes to a ticker and returns a communication channel to
 // stream new ticker updates
 func SubscribeTicker(exchange string, p currency.Pair, a asset.Item) (dispatch.Pipe, error) {
+	return service.SubscribeTicker(exchange, p, a)
+}
+
+// SubscribeTicker subcribes to a ticker stored by the service and returns a
+// communication channel to stream new ticker updates
+func (s *Service) SubscribeTicker(exchange string, p currency.Pair, a asset.Item) (dispatch.Pipe, error) {
 	exchange = strings.ToLower(exchange)
-	service.RLock()
-	defer service.RUnlock()
+	s.RLock()
+	defer s.RUnlock()
 
-	tick, ok := service.Tickers[exchange][p.Base.Item][p.Quote.Item][a]
+	tick, ok := s.Tickers[exchange][p.Base.Item][p.Quote.Item][a]
 	if !ok {
 		return dispatch.Pipe{}, fmt.Errorf("ticker item not found for %s %s %s",
 			exchange,
 			p,
 			a)
 	}
-	return service.mux.Subscribe(tick.Main)
+	return s.mux.Subscribe(tick.Main)
 }
 
 // SubscribeToExchangeTickers subcribes to all tickers on an exchange
 func SubscribeToExchangeTickers(exchange string) (dispatch.Pipe, error) {
+	return service.SubscribeToExchangeTickers(exchange)
+}
+
+// SubscribeToExchangeTickers subcribes to all tickers stored by the service
+// for an exchange
+func (s *Service) SubscribeToExchangeTickers(exchange string) (dispatch.Pipe, error) {
 	exchange = strings.ToLower(exchange)
-	service.RLock()
-	defer service.RUnlock()
-	id, ok := service.Exchange[exchange]
+	s.RLock()
+	defer s.RUnlock()
+	id, ok := s.Exchange[exchange]
 	if !ok {
 		return dispatch.Pipe{}, fmt.Errorf("%s exchange tickers not found",
 			exchange)
 	}
 
-	return service.mux.Subscribe(id)
+	return s.mux.Subscribe(id)
 }
 
 // GetTicker checks and returns a requested ticker if it exists
 func GetTicker(exchange string, p currency.Pair, tickerType asset.Item) (*Price, error) {
+	return service.GetTicker(exchange, p, tickerType)
+}
+
+// GetTicker checks and returns a requested ticker stored by the service if it
+// exists
+func (s *Service) GetTicker(exchange string, p currency.Pair, tickerType asset.Item) (*Price, error) {
 	exchange = strings.ToLower(exchange)
-	service.RLock()
-	defer service.RUnlock()
-	if service.Tickers[exchange] == nil {
+	s.RLock()
+	defer s.RUnlock()
+	if s.Tickers[exchange] == nil {
 		return nil, fmt.Errorf("no tickers for %s exchange", exchange)
 	}
 
-	if service.Tickers[exchange][p.Base.Item] == nil {
+	if s.Tickers[exchange][p.Base.Item] == nil {
 		return nil, fmt.Errorf("no tickers associated with base currency %s",
 			p.Base)
 	}
 
-	if service.Tickers[exchange][p.Base.Item][p.Quote.Item] == nil {
+	if s.Tickers[exchange][p.Base.Item][p.Quote.Item] == nil {
 		return nil, fmt.Errorf("no tickers associated with quote currency %s",
 			p.Quote)
 	}
 
-	if service.Tickers[exchange][p.Base.Item][p.Quote.Item][tickerType] == nil {
+	if s.Tickers[exchange][p.Base.Item][p.Quote.Item][tickerType] == nil {
 		return nil, fmt.Errorf("no tickers associated with asset type %s",
 			tickerType)
 	}
 
-	return &service.Tickers[exchange][p.Base.Item][p.Quote.Item][tickerType].Price, nil
+	return &s.Tickers[exchange][p.Base.Item][p.Quote.Item][tickerType].Price, nil
+}
+
+// GetExchangeTickers returns a copy of every stored ticker for an exchange
+// across all currency pairs and asset types
+func GetExchangeTickers(exchange string) ([]Price, error) {
+	return service.GetExchangeTickers(exchange)
+}
+
+// GetExchangeTickers returns a copy of every ticker stored by the service for
+// an exchange across all currency pairs and asset types
+func (s *Service) GetExchangeTickers(exchange string) ([]Price, error) {
+	exchange = strings.ToLower(exchange)
+	s.RLock()
+	defer s.RUnlock()
+	if s.Tickers[exchange] == nil {
+		return nil, fmt.Errorf("no tickers for %s exchange", exchange)
+	}
+
+	var tickers []Price
+	for _, base := range s.Tickers[exchange] {
+		for _, quote := range base {
+			for _, t := range quote {
+				tickers = append(tickers, t.Price)
+			}
+		}
+	}
+	return tickers, nil
 }
 
 // ProcessTicker processes incoming tickers, creating or updating the Tickers
 // list
 func ProcessTicker(tickerNew *Price) error {
+	return service.ProcessTicker(tickerNew)
+}
+
+// ProcessTicker processes incoming tickers, creating or updating the service
+// Tickers list
+func (s *Service) ProcessTicker(tickerNew *Price) error {
 	if tickerNew.ExchangeName == "" {
 		return fmt.Errorf(errExchangeNameUnset)
 	}
@@ -99,7 +163,7 @@ func ProcessTicker(tickerNew *Price) error {
 		tickerNew.LastUpdated = time.Now()
 	}
 
-	return service.Update(tickerNew)
+	return s.Update(tickerNew)
 }
 
 // Update updates ticker price
@@ -198,6 +198,67 @@ func TestGetTicker(t *testing.T) {
 	}
 }
 
+func TestGetExchangeTickers(t *testing.T) {
+	_, err := GetExchangeTickers("tickerless")
+	if err == nil {
+		t.Error("expected error for exchange without tickers")
+	}
+
+	for _, p := range []currency.Pair{
+		currency.NewPair(currency.BTC, currency.USD),
+		currency.NewPair(currency.XRP, currency.BTC),
+	} {
+		err = ProcessTicker(&Price{
+			Pair:         p,
+			Last:         1,
+			ExchangeName: "GetExchangeTickers",
+			AssetType:    asset.Spot,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tickers, err := GetExchangeTickers("getexchangetickers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickers) != 2 {
+		t.Errorf("received '%v' expected '%v'", len(tickers), 2)
+	}
+}
+
+func TestNewService(t *testing.T) {
+	s := NewService()
+	if s == GetService() {
+		t.Fatal("expected a service independent of the package level service")
+	}
+
+	p := currency.NewPair(currency.BTC, currency.USD)
+	err := s.ProcessTicker(&Price{
+		Pair:         p,
+		Last:         1337,
+		ExchangeName: "NewService",
+		AssetType:    asset.Spot,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tick, err := s.GetTicker("newservice", p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tick.Last != 1337 {
+		t.Errorf("received '%v' expected '%v'", tick.Last, 1337)
+	}
+
+	_, err = GetTicker("newservice", p, asset.Spot)
+	if err == nil {
+		t.Error("expected ticker to be absent from the package level service")
+	}
+}
+
 func TestProcessTicker(t *testing.T) { // non-appending function to tickers
 	exchName := "bitstamp"
 	newPair, err := currency.NewPairFromStrings("BTC", "USD")
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	tradesql "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
@@ -27,9 +29,69 @@ func (p *Processor) setup(wg *sync.WaitGroup) {
 	go p.Run(wg)
 }
 
-// AddTradesToBuffer will push trade data onto the buffer
+// SubscribeToExchangeTrades subscribes to all trades processed for an
+// exchange regardless of whether trades are being saved to the database
+func SubscribeToExchangeTrades(exchange string) (dispatch.Pipe, error) {
+	exchange = strings.ToLower(exchange)
+	feed.mu.Lock()
+	defer feed.mu.Unlock()
+	id, ok := feed.ids[exchange]
+	if !ok {
+		var err error
+		id, err = feed.mux.GetID()
+		if err != nil {
+			return dispatch.Pipe{}, err
+		}
+		feed.ids[exchange] = id
+	}
+	return feed.mux.Subscribe(id)
+}
+
+// publish pushes trades to any exchange trade subscribers
+func (f *tradeFeed) publish(exchangeName string, data []Data) error {
+	f.mu.RLock()
+	id, ok := f.ids[strings.ToLower(exchangeName)]
+	f.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	for i := range data {
+		err := f.mux.Publish([]uuid.UUID{id}, &data[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasSubscribers returns whether anything has subscribed to the trades of an
+// exchange
+func HasSubscribers(exchangeName string) bool {
+	feed.mu.RLock()
+	_, ok := feed.ids[strings.ToLower(exchangeName)]
+	feed.mu.RUnlock()
+	return ok
+}
+
+// Publish pushes trades to any exchange trade subscribers without saving them
+func Publish(exchangeName string, data ...Data) {
+	err := feed.publish(exchangeName, data)
+	if err != nil {
+		log.Errorf(log.Trade, "%s could not publish trades: %v", exchangeName, err)
+	}
+}
+
+// AddTradesToBuffer will publish trade data to any subscribers and push it
+// onto the buffer to be saved to database.DB
 func AddTradesToBuffer(exchangeName string, data ...Data) error {
-	if database.DB == nil || database.DB.Config == nil || !database.DB.Config.Enabled {
+	return AddTradesToInstanceBuffer(database.DB, exchangeName, data...)
+}
+
+// AddTradesToInstanceBuffer will publish trade data to any subscribers and
+// push it onto the buffer to be saved to the database instance db
+func AddTradesToInstanceBuffer(db *database.Instance, exchangeName string, data ...Data) error {
+	Publish(exchangeName, data...)
+	if db == nil || db.Config == nil || !db.Config.Enabled {
 		return nil
 	}
 	if len(data) == 0 {
@@ -75,7 +137,10 @@ func AddTradesToBuffer(exchangeName string, data ...Data) error {
 		validDatas = append(validDatas, data[i])
 	}
 	processor.mutex.Lock()
-	processor.buffer = append(processor.buffer, validDatas...)
+	if processor.buffer == nil {
+		processor.buffer = make(map[*database.Instance][]Data)
+	}
+	processor.buffer[db] = append(processor.buffer[db], validDatas...)
 	processor.mutex.Unlock()
 	if len(errs) > 0 {
 		return errs
@@ -99,42 +164,71 @@ func (p *Processor) Run(wg *sync.WaitGroup) {
 	for {
 		<-ticker.C
 		p.mutex.Lock()
-		bufferCopy := append(p.buffer[:0:0], p.buffer...)
+		buffers := p.buffer
 		p.buffer = nil
 		p.mutex.Unlock()
-		if len(bufferCopy) == 0 {
+		var saved int
+		for db, trades := range buffers {
+			if len(trades) == 0 {
+				continue
+			}
+			saved += len(trades)
+			err := SaveTradesToInstance(db, trades...)
+			if err != nil {
+				log.Error(log.Trade, err)
+			}
+		}
+		if saved == 0 {
 			ticker.Stop()
 			return
 		}
-		err := SaveTradesToDatabase(bufferCopy...)
-		if err != nil {
-			log.Error(log.Trade, err)
-		}
 	}
 }
 
 // SaveTradesToDatabase converts trades and saves results to database
 func SaveTradesToDatabase(trades ...Data) error {
+	return SaveTradesToInstance(database.DB, trades...)
+}
+
+// SaveTradesToInstance converts trades and saves results to the database
+// instance db
+func SaveTradesToInstance(db *database.Instance, trades ...Data) error {
 	sqlTrades, err := tradeToSQLData(trades...)
 	if err != nil {
 		return err
 	}
-	return tradesql.Insert(sqlTrades...)
+	return tradesql.New(db).Insert(sqlTrades...)
 }
 
 // GetTradesInRange calls db function to return trades in range
 // to minimise tradesql package usage
 func GetTradesInRange(exchangeName, assetType, base, quote string, startDate, endDate time.Time) ([]Data, error) {
+	return GetTradesInRangeFromInstance(database.DB, exchangeName, assetType, base, quote, startDate, endDate)
+}
+
+// GetTradesInRangeFromInstance returns trades in range stored in the database
+// instance db
+func GetTradesInRangeFromInstance(db *database.Instance, exchangeName, assetType, base, quote string, startDate, endDate time.Time) ([]Data, error) {
 	if exchangeName == "" || assetType == "" || base == "" || quote == "" || startDate.IsZero() || endDate.IsZero() {
 		return nil, errors.New("invalid arguments received")
 	}
-	results, err := tradesql.GetInRange(exchangeName, assetType, base, quote, startDate, endDate)
+	results, err := tradesql.New(db).GetInRange(exchangeName, assetType, base, quote, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 	return SQLDataToTrade(results...)
 }
 
+// GetIntervalsWithTradesFromInstance returns the start of every interval in
+// range which has trades stored in the database instance db without loading
+// the trades
+func GetIntervalsWithTradesFromInstance(db *database.Instance, exchangeName, assetType, base, quote string, startDate, endDate time.Time, interval kline.Interval) ([]time.Time, error) {
+	if exchangeName == "" || assetType == "" || base == "" || quote == "" || startDate.IsZero() || endDate.IsZero() {
+		return nil, errors.New("invalid arguments received")
+	}
+	return tradesql.New(db).GetIntervalsWithTrades(exchangeName, assetType, base, quote, startDate, endDate, interval.Duration())
+}
+
 func tradeToSQLData(trades ...Data) ([]tradesql.Data, error) {
 	sort.Sort(ByDate(trades))
 	var results []tradesql.Data
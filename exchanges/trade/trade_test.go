@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
 	sqltrade "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
@@ -86,10 +87,10 @@ func TestAddTradesToBuffer(t *testing.T) {
 		t.Error(err)
 	}
 	processor.mutex.Lock()
-	if processor.buffer[0].Amount != 1 {
+	if processor.buffer[database.DB][0].Amount != 1 {
 		t.Error("expected positive amount")
 	}
-	if processor.buffer[0].Side != order.Sell {
+	if processor.buffer[database.DB][0].Side != order.Sell {
 		t.Error("expected unknown side")
 	}
 	processor.mutex.Unlock()
@@ -249,3 +250,54 @@ func TestGetTradesInRange(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestSubscribeToExchangeTrades(t *testing.T) {
+	t.Parallel()
+	if !dispatch.IsRunning() {
+		err := dispatch.Start(1, dispatch.DefaultJobsLimit)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	p, err := SubscribeToExchangeTrades("feedTest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp, _ := currency.NewPairFromString("BTC-USD")
+	tr := Data{
+		Timestamp:    time.Now(),
+		Exchange:     "feedTest",
+		CurrencyPair: cp,
+		AssetType:    asset.Spot,
+		Price:        1337,
+		Amount:       1,
+		Side:         order.Buy,
+	}
+	// dispatch drops payloads when the receiver is not immediately ready so
+	// keep publishing until one is received
+	timeout := time.After(time.Second * 5)
+	for {
+		err = AddTradesToBuffer("FEEDTEST", tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case data := <-p.C:
+			received, ok := (*data.(*interface{})).(Data)
+			if !ok {
+				t.Fatal("unexpected payload type")
+			}
+			if received.Price != 1337 || !received.CurrencyPair.Equal(cp) {
+				t.Errorf("unexpected trade received %+v", received)
+			}
+			err = p.Release()
+			if err != nil {
+				t.Error(err)
+			}
+			return
+		case <-timeout:
+			t.Fatal("expected trade to be published")
+		case <-time.After(time.Millisecond * 50):
+		}
+	}
+}
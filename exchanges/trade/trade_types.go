@@ -6,6 +6,8 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
@@ -16,6 +18,10 @@ const DefaultProcessorIntervalTime = time.Second * 15
 
 var (
 	processor Processor
+	feed      = tradeFeed{
+		mux: dispatch.GetNewMux(),
+		ids: make(map[string]uuid.UUID),
+	}
 	// BufferProcessorIntervalTime is the interval to save trade buffer data to the database.
 	// Change this by changing the runtime param `-tradeprocessinginterval=15s`
 	BufferProcessorIntervalTime = DefaultProcessorIntervalTime
@@ -40,7 +46,16 @@ type Processor struct {
 	mutex                   sync.Mutex
 	started                 int32
 	bufferProcessorInterval time.Duration
-	buffer                  []Data
+	// buffer holds the trades waiting to be saved to each database instance
+	buffer map[*database.Instance][]Data
+}
+
+// tradeFeed routes incoming trades to dispatch subscribers on a per exchange
+// basis, IDs are only generated once something has subscribed
+type tradeFeed struct {
+	mu  sync.RWMutex
+	mux *dispatch.Mux
+	ids map[string]uuid.UUID
 }
 
 // ByDate sorts trades by date ascending
@@ -279,6 +279,13 @@ func (y *Yobit) SendHTTPRequest(ep exchange.URL, path string, result interface{}
 	})
 }
 
+// orderEndpoints are the private endpoints which place, amend or cancel
+// orders, requests to them are scheduled ahead of account queries
+var orderEndpoints = []string{
+	privateTrade,
+	privateCancelOrder,
+}
+
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request to Yobit
 func (y *Yobit) SendAuthenticatedHTTPRequest(ep exchange.URL, path string, params url.Values, result interface{}) (err error) {
 	if !y.AllowAuthenticatedRequest() {
@@ -319,6 +326,7 @@ func (y *Yobit) SendAuthenticatedHTTPRequest(ep exchange.URL, path string, param
 		Body:          strings.NewReader(encoded),
 		Result:        result,
 		AuthRequest:   true,
+		Priority:      request.PriorityByEndpoint(path, orderEndpoints...),
 		NonceEnabled:  true,
 		Verbose:       y.Verbose,
 		HTTPDebugging: y.HTTPDebugging,
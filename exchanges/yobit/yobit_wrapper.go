@@ -201,7 +201,7 @@ func (y *Yobit) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 		}
 
 		resultCurr := result[curr]
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = y.GetTickerService().ProcessTicker(&ticker.Price{
 			Pair:         enabledPairs[i],
 			Last:         resultCurr.Last,
 			Ask:          resultCurr.Sell,
@@ -216,12 +216,12 @@ func (y *Yobit) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Pri
 			return nil, err
 		}
 	}
-	return ticker.GetTicker(y.Name, p, assetType)
+	return y.GetTickerService().GetTicker(y.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (y *Yobit) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tick, err := ticker.GetTicker(y.Name, p, assetType)
+	tick, err := y.GetTickerService().GetTicker(y.Name, p, assetType)
 	if err != nil {
 		return y.UpdateTicker(p, assetType)
 	}
@@ -230,7 +230,7 @@ func (y *Yobit) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Pric
 
 // FetchOrderbook returns the orderbook for a currency pair
 func (y *Yobit) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(y.Name, p, assetType)
+	ob, err := y.GetOrderbookService().Retrieve(y.Name, p, assetType)
 	if err != nil {
 		return y.UpdateOrderbook(p, assetType)
 	}
@@ -269,11 +269,11 @@ func (y *Yobit) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderbo
 				Amount: orderbookNew.Asks[i][1],
 			})
 	}
-	err = book.Process()
+	err = y.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(y.Name, p, assetType)
+	return y.GetOrderbookService().Retrieve(y.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -305,7 +305,7 @@ func (y *Yobit) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error
 		Currencies: currencies,
 	})
 
-	err = account.Process(&response)
+	err = y.GetAccountService().Process(&response)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -315,7 +315,7 @@ func (y *Yobit) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (y *Yobit) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(y.Name, assetType)
+	acc, err := y.GetAccountService().GetHoldings(y.Name, assetType)
 	if err != nil {
 		return y.UpdateAccountInfo(assetType)
 	}
@@ -28,15 +28,20 @@ type RateLimit struct {
 
 // Limit limits the outbound requests
 func (r *RateLimit) Limit(f request.EndpointLimit) error {
+	time.Sleep(r.LimiterFor(f).Reserve().Delay())
+	return nil
+}
+
+// LimiterFor returns the rate limiter an endpoint limit draws from
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) *rate.Limiter {
 	switch f {
 	case request.Auth:
-		time.Sleep(r.Auth.Reserve().Delay())
+		return r.Auth
 	case klineFunc:
-		time.Sleep(r.KlineData.Reserve().Delay())
+		return r.KlineData
 	default:
-		time.Sleep(r.UnAuth.Reserve().Delay())
+		return r.UnAuth
 	}
-	return nil
 }
 
 // SetRateLimit returns the rate limit for the exchange
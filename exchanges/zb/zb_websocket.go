@@ -209,7 +209,7 @@ func (z *ZB) wsHandleData(respRaw []byte) error {
 			Status:   order.Cancelled,
 		}
 	case strings.Contains(result.Channel, "trades"):
-		if !z.IsSaveTradeDataEnabled() {
+		if !z.IsTradeProcessingRequired() {
 			return nil
 		}
 		var tradeData WsTrades
@@ -244,7 +244,7 @@ func (z *ZB) wsHandleData(respRaw []byte) error {
 				TID:          strconv.FormatInt(tradeData.Data[i].TID, 10),
 			})
 		}
-		return trade.AddTradesToBuffer(z.Name, trades...)
+		return z.AddTradesToBuffer(trades...)
 	default:
 		z.Websocket.DataHandler <- stream.UnhandledMessageWarning{
 			Message: z.Name +
@@ -262,7 +262,7 @@ func (z *ZB) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Price,
 			continue
 		}
 
-		err = ticker.ProcessTicker(&ticker.Price{
+		err = z.GetTickerService().ProcessTicker(&ticker.Price{
 			Pair:         enabledPairs[x],
 			High:         result[curr].High,
 			Last:         result[curr].Last,
@@ -277,12 +277,12 @@ func (z *ZB) UpdateTicker(p currency.Pair, assetType asset.Item) (*ticker.Price,
 		}
 	}
 
-	return ticker.GetTicker(z.Name, p, assetType)
+	return z.GetTickerService().GetTicker(z.Name, p, assetType)
 }
 
 // FetchTicker returns the ticker for a currency pair
 func (z *ZB) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price, error) {
-	tickerNew, err := ticker.GetTicker(z.Name, p, assetType)
+	tickerNew, err := z.GetTickerService().GetTicker(z.Name, p, assetType)
 	if err != nil {
 		return z.UpdateTicker(p, assetType)
 	}
@@ -291,7 +291,7 @@ func (z *ZB) FetchTicker(p currency.Pair, assetType asset.Item) (*ticker.Price,
 
 // FetchOrderbook returns orderbook base on the currency pair
 func (z *ZB) FetchOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.Base, error) {
-	ob, err := orderbook.Get(z.Name, p, assetType)
+	ob, err := z.GetOrderbookService().Retrieve(z.Name, p, assetType)
 	if err != nil {
 		return z.UpdateOrderbook(p, assetType)
 	}
@@ -329,11 +329,11 @@ func (z *ZB) UpdateOrderbook(p currency.Pair, assetType asset.Item) (*orderbook.
 			Price:  orderbookNew.Asks[x][0],
 		})
 	}
-	err = book.Process()
+	err = z.GetOrderbookService().Process(book)
 	if err != nil {
 		return book, err
 	}
-	return orderbook.Get(z.Name, p, assetType)
+	return z.GetOrderbookService().Retrieve(z.Name, p, assetType)
 }
 
 // UpdateAccountInfo retrieves balances for all enabled currencies for the
@@ -379,7 +379,7 @@ func (z *ZB) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error) {
 		Currencies: balances,
 	})
 
-	err := account.Process(&info)
+	err := z.GetAccountService().Process(&info)
 	if err != nil {
 		return account.Holdings{}, err
 	}
@@ -389,7 +389,7 @@ func (z *ZB) UpdateAccountInfo(assetType asset.Item) (account.Holdings, error) {
 
 // FetchAccountInfo retrieves balances for all enabled currencies
 func (z *ZB) FetchAccountInfo(assetType asset.Item) (account.Holdings, error) {
-	acc, err := account.GetHoldings(z.Name, assetType)
+	acc, err := z.GetAccountService().GetHoldings(z.Name, assetType)
 	if err != nil {
 		return z.UpdateAccountInfo(assetType)
 	}
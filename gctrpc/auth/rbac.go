@@ -0,0 +1,481 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const serviceMethodPrefix = "/gctrpc.GoCryptoTrader/"
+
+// methodScopes maps each GoCryptoTrader RPC to the scope required to call it.
+// Methods which are not listed require the admin scope
+var methodScopes = map[string]string{
+	"GetInfo":                           ScopeRead,
+	"GetSubsystems":                     ScopeRead,
+	"EnableSubsystem":                   ScopeAdmin,
+	"DisableSubsystem":                  ScopeAdmin,
+	"GetRPCEndpoints":                   ScopeRead,
+	"GetCommunicationRelayers":          ScopeRead,
+	"GetExchanges":                      ScopeRead,
+	"DisableExchange":                   ScopeAdmin,
+	"GetExchangeInfo":                   ScopeRead,
+	"GetExchangeOTPCode":                ScopeAdmin,
+	"GetExchangeOTPCodes":               ScopeAdmin,
+	"EnableExchange":                    ScopeAdmin,
+	"GetTicker":                         ScopeRead,
+	"GetTickers":                        ScopeRead,
+	"GetOrderbook":                      ScopeRead,
+	"GetOrderbooks":                     ScopeRead,
+	"GetAccountInfo":                    ScopeRead,
+	"UpdateAccountInfo":                 ScopeRead,
+	"GetAccountInfoStream":              ScopeRead,
+	"GetConfig":                         ScopeAdmin,
+	"GetPortfolio":                      ScopeRead,
+	"GetPortfolioSummary":               ScopeRead,
+	"AddPortfolioAddress":               ScopeAdmin,
+	"RemovePortfolioAddress":            ScopeAdmin,
+	"GetForexProviders":                 ScopeRead,
+	"GetForexRates":                     ScopeRead,
+	"GetOrders":                         ScopeRead,
+	"GetOrder":                          ScopeRead,
+	"SubmitOrder":                       ScopeTrade,
+	"SimulateOrder":                     ScopeRead,
+	"WhaleBomb":                         ScopeRead,
+	"CancelOrder":                       ScopeTrade,
+	"CancelBatchOrders":                 ScopeTrade,
+	"CancelAllOrders":                   ScopeTrade,
+	"GetEvents":                         ScopeRead,
+	"AddEvent":                          ScopeTrade,
+	"RemoveEvent":                       ScopeTrade,
+	"GetCryptocurrencyDepositAddresses": ScopeRead,
+	"GetCryptocurrencyDepositAddress":   ScopeRead,
+	"WithdrawFiatFunds":                 ScopeWithdraw,
+	"WithdrawCryptocurrencyFunds":       ScopeWithdraw,
+	"WithdrawalEventByID":               ScopeRead,
+	"WithdrawalEventsByExchange":        ScopeRead,
+	"WithdrawalEventsByDate":            ScopeRead,
+	"GetLoggerDetails":                  ScopeRead,
+	"SetLoggerDetails":                  ScopeAdmin,
+	"GetExchangePairs":                  ScopeRead,
+	"SetExchangePair":                   ScopeAdmin,
+	"GetOrderbookStream":                ScopeRead,
+	"GetExchangeOrderbookStream":        ScopeRead,
+	"GetTickerStream":                   ScopeRead,
+	"GetExchangeTickerStream":           ScopeRead,
+	"GetAuditEvent":                     ScopeAdmin,
+	"GCTScriptExecute":                  ScopeAdmin,
+	"GCTScriptUpload":                   ScopeAdmin,
+	"GCTScriptReadScript":               ScopeRead,
+	"GCTScriptStatus":                   ScopeRead,
+	"GCTScriptQuery":                    ScopeRead,
+	"GCTScriptStop":                     ScopeAdmin,
+	"GCTScriptStopAll":                  ScopeAdmin,
+	"GCTScriptListAll":                  ScopeRead,
+	"GCTScriptAutoLoadToggle":           ScopeAdmin,
+	"GCTScriptGetState":                 ScopeRead,
+	"GCTScriptClearState":               ScopeAdmin,
+	"GetHistoricCandles":                ScopeRead,
+	"SetExchangeAsset":                  ScopeAdmin,
+	"SetAllExchangePairs":               ScopeAdmin,
+	"UpdateExchangeSupportedPairs":      ScopeAdmin,
+	"GetExchangeAssets":                 ScopeRead,
+	"WebsocketGetInfo":                  ScopeRead,
+	"WebsocketSetEnabled":               ScopeAdmin,
+	"WebsocketGetSubscriptions":         ScopeRead,
+	"WebsocketSetProxy":                 ScopeAdmin,
+	"WebsocketSetURL":                   ScopeAdmin,
+	"GetRecentTrades":                   ScopeRead,
+	"GetHistoricTrades":                 ScopeRead,
+	"GetSavedTrades":                    ScopeRead,
+	"ConvertTradesToCandles":            ScopeAdmin,
+	"FindMissingSavedCandleIntervals":   ScopeRead,
+	"FindMissingSavedTradeIntervals":    ScopeRead,
+	"SetExchangeTradeProcessing":        ScopeAdmin,
+	"GetExchangeHealth":                 ScopeRead,
+	"UpsertDataHistoryJob":              ScopeAdmin,
+	"GetDataHistoryJobDetails":          ScopeRead,
+	"GetActiveDataHistoryJobs":          ScopeRead,
+	"GetDataHistoryJobsBetween":         ScopeRead,
+	"DeleteDataHistoryJob":              ScopeAdmin,
+	"GetEquityCurve":                    ScopeRead,
+	"GetDailyPnL":                       ScopeRead,
+	"RunDatabaseRetention":              ScopeAdmin,
+	"VerifyAuditLog":                    ScopeAdmin,
+	"CreateAPIToken":                    ScopeAdmin,
+	"RevokeAPIToken":                    ScopeAdmin,
+	"ListAPITokens":                     ScopeAdmin,
+	"ReloadConfig":                      ScopeAdmin,
+}
+
+// RequiredScope returns the scope required to call the supplied full gRPC
+// method name e.g. /gctrpc.GoCryptoTrader/GetInfo
+func RequiredScope(fullMethod string) string {
+	if !strings.HasPrefix(fullMethod, serviceMethodPrefix) {
+		return ScopeAdmin
+	}
+	if scope, ok := methodScopes[strings.TrimPrefix(fullMethod, serviceMethodPrefix)]; ok {
+		return scope
+	}
+	return ScopeAdmin
+}
+
+// ValidateScopes ensures at least one scope is supplied and all are known
+func ValidateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return errNoScopes
+	}
+	for i := range scopes {
+		if !isValidScope(scopes[i]) {
+			return fmt.Errorf("%w %q, supported scopes: %s",
+				errInvalidScope,
+				scopes[i],
+				strings.Join(Scopes, ", "))
+		}
+	}
+	return nil
+}
+
+func isValidScope(scope string) bool {
+	for i := range Scopes {
+		if Scopes[i] == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope returns whether the principal has been granted the scope
+func (p *Principal) HasScope(scope string) bool {
+	if p == nil {
+		return false
+	}
+	for i := range p.Scopes {
+		switch {
+		case p.Scopes[i] == ScopeAdmin,
+			p.Scopes[i] == scope,
+			scope == ScopeRead && isValidScope(p.Scopes[i]):
+			return true
+		}
+	}
+	return false
+}
+
+// Authorise returns ErrPermissionDenied if the principal lacks the scope
+func (p *Principal) Authorise(scope string) error {
+	if p.HasScope(scope) {
+		return nil
+	}
+	name := "anonymous"
+	if p != nil {
+		name = p.Type + " " + p.Name
+	}
+	return fmt.Errorf("%w: %s requires %s scope", ErrPermissionDenied, name, scope)
+}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated principal
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// PasswordDigest returns the hex encoded SHA256 of a password, which is what
+// websocket RPC clients send in place of the password itself
+func PasswordDigest(password string) string {
+	h := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(h[:])
+}
+
+// HashPassword returns a bcrypt hash of the password digest, allowing both
+// gRPC basic auth and websocket RPC digests to be verified against it
+func HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", errPasswordEmpty
+	}
+	h, err := bcrypt.GenerateFromPassword([]byte(PasswordDigest(password)), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(h), nil
+}
+
+// CheckUsers validates configured users and hashes any plaintext passwords.
+// It returns whether any user was modified
+func CheckUsers(users []User) (bool, error) {
+	var modified bool
+	seen := make(map[string]bool, len(users))
+	for i := range users {
+		if users[i].Username == "" {
+			return modified, errUsernameEmpty
+		}
+		if seen[users[i].Username] {
+			return modified, fmt.Errorf("%w %s", errDuplicateUsername, users[i].Username)
+		}
+		seen[users[i].Username] = true
+		if err := ValidateScopes(users[i].Scopes); err != nil {
+			return modified, fmt.Errorf("user %s: %w", users[i].Username, err)
+		}
+		if users[i].Password != "" {
+			h, err := HashPassword(users[i].Password)
+			if err != nil {
+				return modified, err
+			}
+			users[i].PasswordHash = h
+			users[i].Password = ""
+			modified = true
+		}
+		if users[i].PasswordHash == "" {
+			return modified, fmt.Errorf("user %s: %w", users[i].Username, errPasswordEmpty)
+		}
+	}
+	return modified, nil
+}
+
+// CheckTokens validates configured API tokens
+func CheckTokens(tokens []Token) error {
+	seen := make(map[string]bool, len(tokens))
+	for i := range tokens {
+		if seen[tokens[i].ID] {
+			return fmt.Errorf("%w %s", errDuplicateTokenID, tokens[i].ID)
+		}
+		seen[tokens[i].ID] = true
+		if tokens[i].Hash == "" {
+			return fmt.Errorf("api token %s: %w", tokens[i].ID, errTokenHashEmpty)
+		}
+		if err := ValidateScopes(tokens[i].Scopes); err != nil {
+			return fmt.Errorf("api token %s: %w", tokens[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// NewToken generates an API token, returning the secret token value which is
+// only available at creation time and the hashed token to be stored
+func NewToken(name, owner string, scopes []string, expiry time.Duration) (string, Token, error) {
+	if err := ValidateScopes(scopes); err != nil {
+		return "", Token{}, err
+	}
+	id := make([]byte, tokenIDLength/2)
+	if _, err := rand.Read(id); err != nil {
+		return "", Token{}, err
+	}
+	secret := make([]byte, tokenSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", Token{}, err
+	}
+	t := Token{
+		ID:      hex.EncodeToString(id),
+		Name:    name,
+		Owner:   owner,
+		Scopes:  append([]string(nil), scopes...),
+		Created: time.Now().UTC(),
+	}
+	if expiry > 0 {
+		t.Expires = t.Created.Add(expiry)
+	}
+	value := tokenPrefix + "_" + t.ID + "_" + hex.EncodeToString(secret)
+	t.Hash = hashToken(value)
+	return value, t, nil
+}
+
+func hashToken(value string) string {
+	h := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(h[:])
+}
+
+// tokenID extracts the ID portion of a token value
+func tokenID(value string) (string, bool) {
+	parts := strings.Split(value, "_")
+	if len(parts) != 3 || parts[0] != tokenPrefix || len(parts[1]) != tokenIDLength {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// Authenticate resolves a Basic or Bearer authorization header value
+func (c *Credentials) Authenticate(authorization string) (*Principal, error) {
+	scheme, value := splitAuthorization(authorization)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, errInvalidAuthHeader
+		}
+		creds := strings.SplitN(string(decoded), ":", 2)
+		if len(creds) != 2 {
+			return nil, errInvalidAuthHeader
+		}
+		return c.AuthenticatePassword(creds[0], creds[1])
+	case "bearer":
+		return c.AuthenticateToken(value)
+	case "":
+		return nil, errInvalidAuthHeader
+	default:
+		return nil, fmt.Errorf("%w %s", errAuthHeaderUnsupported, scheme)
+	}
+}
+
+func splitAuthorization(authorization string) (scheme, value string) {
+	parts := strings.SplitN(strings.TrimSpace(authorization), " ", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return parts[0], strings.TrimSpace(parts[1])
+}
+
+// AuthenticatePassword verifies a username and plaintext password
+func (c *Credentials) AuthenticatePassword(username, password string) (*Principal, error) {
+	if c.matchesLegacy(username, password, c.Password) {
+		return c.legacyPrincipal(), nil
+	}
+	return c.authenticateUser(username, PasswordDigest(password))
+}
+
+// AuthenticateDigest verifies a username and hex encoded SHA256 password as
+// sent by websocket RPC clients
+func (c *Credentials) AuthenticateDigest(username, digest string) (*Principal, error) {
+	if c.matchesLegacy(username, digest, PasswordDigest(c.Password)) {
+		return c.legacyPrincipal(), nil
+	}
+	return c.authenticateUser(username, digest)
+}
+
+func (c *Credentials) matchesLegacy(username, supplied, expected string) bool {
+	if c.Username == "" || c.Password == "" {
+		return false
+	}
+	userMatch := subtle.ConstantTimeCompare([]byte(username), []byte(c.Username)) == 1
+	passMatch := subtle.ConstantTimeCompare([]byte(supplied), []byte(expected)) == 1
+	return userMatch && passMatch
+}
+
+func (c *Credentials) legacyPrincipal() *Principal {
+	return &Principal{Type: PrincipalUser, Name: c.Username, Scopes: []string{ScopeAdmin}}
+}
+
+// authenticateUser verifies a password digest against the configured users.
+// A bcrypt comparison is always made, against a dummy hash for unknown users,
+// so response times do not reveal which usernames exist. Successful
+// verifications are cached briefly to avoid a bcrypt comparison per request
+func (c *Credentials) authenticateUser(username, digest string) (*Principal, error) {
+	for i := range c.Users {
+		if c.Users[i].Username != username || c.Users[i].PasswordHash == "" {
+			continue
+		}
+		key := verificationKey(username, c.Users[i].PasswordHash, digest)
+		if !verified.contains(key) {
+			if bcrypt.CompareHashAndPassword([]byte(c.Users[i].PasswordHash), []byte(digest)) != nil {
+				return nil, ErrUnauthenticated
+			}
+			verified.add(key)
+		}
+		return &Principal{
+			Type:   PrincipalUser,
+			Name:   username,
+			Scopes: append([]string(nil), c.Users[i].Scopes...),
+		}, nil
+	}
+	// the result is discarded, the comparison only keeps the timing the same
+	// as for a configured user
+	_ = bcrypt.CompareHashAndPassword(getDummyHash(), []byte(digest))
+	return nil, ErrUnauthenticated
+}
+
+// getDummyHash returns a bcrypt hash which no digest matches, generated at the
+// same cost as user password hashes
+func getDummyHash() []byte {
+	dummyHashOnce.Do(func() {
+		secret := make([]byte, tokenSecretBytes)
+		if _, err := rand.Read(secret); err != nil {
+			secret = []byte(tokenPrefix)
+		}
+		h, err := bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(secret)), bcrypt.DefaultCost)
+		if err == nil {
+			dummyHash = h
+		}
+	})
+	return dummyHash
+}
+
+// verificationKey returns the digest a successful verification is cached
+// under. The stored hash is included so changing a password invalidates it
+func verificationKey(username, passwordHash, digest string) string {
+	h := sha256.Sum256([]byte(username + "\x00" + passwordHash + "\x00" + digest))
+	return hex.EncodeToString(h[:])
+}
+
+// contains returns whether the key was verified within the cache duration
+func (v *verificationCache) contains(key string) bool {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	expiry, ok := v.entries[key]
+	if !ok {
+		return false
+	}
+	if time.Now().After(expiry) {
+		delete(v.entries, key)
+		return false
+	}
+	return true
+}
+
+// add caches a successful verification, expired entries are removed once the
+// cache is full
+func (v *verificationCache) add(key string) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	now := time.Now()
+	if v.entries == nil {
+		v.entries = make(map[string]time.Time)
+	}
+	if len(v.entries) >= maxCachedVerifications {
+		for k, expiry := range v.entries {
+			if now.After(expiry) {
+				delete(v.entries, k)
+			}
+		}
+		if len(v.entries) >= maxCachedVerifications {
+			v.entries = make(map[string]time.Time)
+		}
+	}
+	v.entries[key] = now.Add(verificationCacheDuration)
+}
+
+// AuthenticateToken verifies an API token value
+func (c *Credentials) AuthenticateToken(value string) (*Principal, error) {
+	id, ok := tokenID(value)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	hash := hashToken(value)
+	for i := range c.Tokens {
+		if c.Tokens[i].ID != id {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(c.Tokens[i].Hash)) != 1 {
+			return nil, ErrUnauthenticated
+		}
+		if !c.Tokens[i].Expires.IsZero() && time.Now().After(c.Tokens[i].Expires) {
+			return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, errTokenExpired)
+		}
+		return &Principal{
+			Type:   PrincipalToken,
+			Name:   id,
+			Scopes: append([]string(nil), c.Tokens[i].Scopes...),
+		}, nil
+	}
+	return nil, ErrUnauthenticated
+}
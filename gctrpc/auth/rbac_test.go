@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestRequiredScope(t *testing.T) {
+	t.Parallel()
+	for method, expected := range map[string]string{
+		"/gctrpc.GoCryptoTrader/GetInfo":                     ScopeRead,
+		"/gctrpc.GoCryptoTrader/SubmitOrder":                 ScopeTrade,
+		"/gctrpc.GoCryptoTrader/WithdrawCryptocurrencyFunds": ScopeWithdraw,
+		"/gctrpc.GoCryptoTrader/GetConfig":                   ScopeAdmin,
+		"/gctrpc.GoCryptoTrader/Meow":                        ScopeAdmin,
+		"/other.Service/GetInfo":                             ScopeAdmin,
+	} {
+		if scope := RequiredScope(method); scope != expected {
+			t.Errorf("%s received: %s but expected: %s", method, scope, expected)
+		}
+	}
+
+	methods := gctrpc.GoCryptoTrader_ServiceDesc.Methods
+	for i := range methods {
+		if _, ok := methodScopes[methods[i].MethodName]; !ok {
+			t.Errorf("%s has no scope mapping", methods[i].MethodName)
+		}
+	}
+	streams := gctrpc.GoCryptoTrader_ServiceDesc.Streams
+	for i := range streams {
+		if _, ok := methodScopes[streams[i].StreamName]; !ok {
+			t.Errorf("%s has no scope mapping", streams[i].StreamName)
+		}
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	t.Parallel()
+	var p *Principal
+	if p.HasScope(ScopeRead) {
+		t.Error("nil principal should not have any scope")
+	}
+	if err := p.Authorise(ScopeRead); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	p = &Principal{Type: PrincipalToken, Name: "1", Scopes: []string{ScopeTrade}}
+	if !p.HasScope(ScopeRead) || !p.HasScope(ScopeTrade) {
+		t.Error("trade scope should grant read and trade")
+	}
+	if p.HasScope(ScopeWithdraw) || p.HasScope(ScopeAdmin) {
+		t.Error("trade scope should not grant withdraw or admin")
+	}
+	if err := p.Authorise(ScopeAdmin); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	p.Scopes = []string{ScopeAdmin}
+	for i := range Scopes {
+		if err := p.Authorise(Scopes[i]); err != nil {
+			t.Error(err)
+		}
+	}
+
+	ctx := WithPrincipal(context.Background(), p)
+	if received, ok := PrincipalFromContext(ctx); !ok || received != p {
+		t.Error("expected principal to be returned from context")
+	}
+	if _, ok := PrincipalFromContext(context.Background()); ok {
+		t.Error("expected no principal")
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	t.Parallel()
+	if err := ValidateScopes(nil); !errors.Is(err, errNoScopes) {
+		t.Errorf("received: %v but expected: %v", err, errNoScopes)
+	}
+	if err := ValidateScopes([]string{ScopeRead, "meow"}); !errors.Is(err, errInvalidScope) {
+		t.Errorf("received: %v but expected: %v", err, errInvalidScope)
+	}
+	if err := ValidateScopes(Scopes); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckUsers(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		users    []User
+		expected error
+	}{
+		{users: []User{{Password: "a", Scopes: []string{ScopeRead}}}, expected: errUsernameEmpty},
+		{users: []User{{Username: "a", Scopes: []string{ScopeRead}}}, expected: errPasswordEmpty},
+		{users: []User{{Username: "a", Password: "a"}}, expected: errNoScopes},
+		{users: []User{
+			{Username: "a", PasswordHash: "a", Scopes: []string{ScopeRead}},
+			{Username: "a", PasswordHash: "a", Scopes: []string{ScopeRead}},
+		}, expected: errDuplicateUsername},
+	} {
+		if _, err := CheckUsers(tt.users); !errors.Is(err, tt.expected) {
+			t.Errorf("received: %v but expected: %v", err, tt.expected)
+		}
+	}
+
+	users := []User{{Username: "trader", Password: "pass", Scopes: []string{ScopeTrade}}}
+	modified, err := CheckUsers(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !modified || users[0].Password != "" || users[0].PasswordHash == "" {
+		t.Errorf("expected password to be hashed, received %+v", users[0])
+	}
+	if modified, err = CheckUsers(users); err != nil || modified {
+		t.Errorf("expected no modification, received %v %v", modified, err)
+	}
+}
+
+func TestCheckTokens(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		tokens   []Token
+		expected error
+	}{
+		{tokens: []Token{{ID: "1", Scopes: []string{ScopeRead}}}, expected: errTokenHashEmpty},
+		{tokens: []Token{{ID: "1", Hash: "a"}}, expected: errNoScopes},
+		{tokens: []Token{
+			{ID: "1", Hash: "a", Scopes: []string{ScopeRead}},
+			{ID: "1", Hash: "a", Scopes: []string{ScopeRead}},
+		}, expected: errDuplicateTokenID},
+		{tokens: []Token{{ID: "1", Hash: "a", Scopes: []string{ScopeRead}}}},
+	} {
+		if err := CheckTokens(tt.tokens); !errors.Is(err, tt.expected) {
+			t.Errorf("received: %v but expected: %v", err, tt.expected)
+		}
+	}
+}
+
+func basicHeader(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+	users := []User{{Username: "reader", Password: "readpass", Scopes: []string{ScopeRead}}}
+	if _, err := CheckUsers(users); err != nil {
+		t.Fatal(err)
+	}
+	value, token, err := NewToken("bot", "admin", []string{ScopeTrade}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(value, tokenPrefix+"_"+token.ID+"_") || token.Hash == value {
+		t.Errorf("unexpected token %s %+v", value, token)
+	}
+	expiredValue, expired, err := NewToken("old", "admin", []string{ScopeRead}, time.Nanosecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	c := &Credentials{
+		Username: "admin",
+		Password: "adminpass",
+		Users:    users,
+		Tokens:   []Token{token, expired},
+	}
+
+	p, err := c.Authenticate(basicHeader("admin", "adminpass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "admin" || !p.HasScope(ScopeAdmin) {
+		t.Errorf("unexpected principal %+v", p)
+	}
+	p, err = c.Authenticate(basicHeader("reader", "readpass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Type != PrincipalUser || p.HasScope(ScopeTrade) || !p.HasScope(ScopeRead) {
+		t.Errorf("unexpected principal %+v", p)
+	}
+	p, err = c.Authenticate("Bearer " + value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Type != PrincipalToken || p.Name != token.ID || !p.HasScope(ScopeTrade) {
+		t.Errorf("unexpected principal %+v", p)
+	}
+	p, err = c.AuthenticateDigest("reader", PasswordDigest("readpass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "reader" {
+		t.Errorf("unexpected principal %+v", p)
+	}
+	if _, err = c.AuthenticateDigest("admin", PasswordDigest("adminpass")); err != nil {
+		t.Error(err)
+	}
+
+	for header, expected := range map[string]error{
+		"":                                errInvalidAuthHeader,
+		"Basic !!!":                       errInvalidAuthHeader,
+		"Digest abc":                      errAuthHeaderUnsupported,
+		basicHeader("reader", "wrong"):    ErrUnauthenticated,
+		basicHeader("admin", "readpass"):  ErrUnauthenticated,
+		basicHeader("nobody", "readpass"): ErrUnauthenticated,
+		"Bearer " + value + "a":           ErrUnauthenticated,
+		"Bearer meow":                     ErrUnauthenticated,
+		"Bearer " + expiredValue:          ErrUnauthenticated,
+		basicHeader("admin", PasswordDigest("adminpass")): ErrUnauthenticated,
+	} {
+		if _, err = c.Authenticate(header); !errors.Is(err, expected) {
+			t.Errorf("%q received: %v but expected: %v", header, err, expected)
+		}
+	}
+}
+
+func TestAuthenticateUserCache(t *testing.T) {
+	t.Parallel()
+	users := []User{{Username: "cached", Password: "cachedpass", Scopes: []string{ScopeRead}}}
+	if _, err := CheckUsers(users); err != nil {
+		t.Fatal(err)
+	}
+	c := &Credentials{Users: users}
+	digest := PasswordDigest("cachedpass")
+	key := verificationKey("cached", users[0].PasswordHash, digest)
+	if verified.contains(key) {
+		t.Fatal("verification should not be cached before authenticating")
+	}
+	if _, err := c.AuthenticateDigest("cached", digest); err != nil {
+		t.Fatal(err)
+	}
+	if !verified.contains(key) {
+		t.Error("successful verification should be cached")
+	}
+	if _, err := c.AuthenticateDigest("cached", PasswordDigest("wrong")); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("received: %v but expected: %v", err, ErrUnauthenticated)
+	}
+
+	// changing the password invalidates the cached verification
+	users[0].Password = "newpass"
+	if _, err := CheckUsers(users); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AuthenticateDigest("cached", digest); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("received: %v but expected: %v", err, ErrUnauthenticated)
+	}
+}
+
+func TestVerificationCacheExpiry(t *testing.T) {
+	t.Parallel()
+	var v verificationCache
+	v.add("a")
+	if !v.contains("a") {
+		t.Error("expected key to be cached")
+	}
+	v.entries["a"] = time.Now().Add(-time.Second)
+	if v.contains("a") {
+		t.Error("expired key should not be cached")
+	}
+	for i := 0; i < maxCachedVerifications+1; i++ {
+		v.add(strconv.Itoa(i))
+	}
+	if len(v.entries) > maxCachedVerifications {
+		t.Errorf("received: %v but expected at most: %v", len(v.entries), maxCachedVerifications)
+	}
+}
+
+func TestDummyHash(t *testing.T) {
+	t.Parallel()
+	cost, err := bcrypt.Cost(getDummyHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost != bcrypt.DefaultCost {
+		t.Errorf("received: %v but expected: %v", cost, bcrypt.DefaultCost)
+	}
+	c := &Credentials{}
+	if _, err = c.AuthenticateDigest("nobody", PasswordDigest("pass")); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("received: %v but expected: %v", err, ErrUnauthenticated)
+	}
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Scopes which can be granted to remote control users and API tokens.
+// Admin implies every other scope and both trade and withdraw imply read.
+const (
+	ScopeRead     = "read"
+	ScopeTrade    = "trade"
+	ScopeWithdraw = "withdraw"
+	ScopeAdmin    = "admin"
+)
+
+// Principal types
+const (
+	PrincipalUser  = "user"
+	PrincipalToken = "token"
+)
+
+const (
+	tokenPrefix      = "gct"
+	tokenIDLength    = 8
+	tokenSecretBytes = 32
+
+	// verificationCacheDuration is how long a successful password
+	// verification is trusted before bcrypt is used again
+	verificationCacheDuration = time.Minute
+	maxCachedVerifications    = 1024
+)
+
+var (
+	// Scopes lists all supported scopes
+	Scopes = []string{ScopeRead, ScopeTrade, ScopeWithdraw, ScopeAdmin}
+
+	// ErrUnauthenticated is returned when supplied credentials do not match a
+	// configured user or token
+	ErrUnauthenticated = errors.New("invalid credentials")
+	// ErrPermissionDenied is returned when an authenticated principal lacks
+	// the scope required for a request
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrTokenNotFound is returned when an API token ID is not configured
+	ErrTokenNotFound = errors.New("api token not found")
+
+	errInvalidScope          = errors.New("invalid scope")
+	errNoScopes              = errors.New("at least one scope is required")
+	errUsernameEmpty         = errors.New("username cannot be empty")
+	errDuplicateUsername     = errors.New("duplicate username")
+	errPasswordEmpty         = errors.New("password cannot be empty")
+	errDuplicateTokenID      = errors.New("duplicate api token ID")
+	errTokenHashEmpty        = errors.New("api token hash cannot be empty")
+	errInvalidAuthHeader     = errors.New("invalid authorization header")
+	errAuthHeaderUnsupported = errors.New("unsupported authorization scheme")
+	errTokenExpired          = errors.New("api token expired")
+
+	verified      verificationCache
+	dummyHash     []byte
+	dummyHashOnce sync.Once
+)
+
+// User is a remote control user. Password is only used to seed PasswordHash
+// and is cleared once the config has been checked
+type User struct {
+	Username     string   `json:"username"`
+	Password     string   `json:"password,omitempty"`
+	PasswordHash string   `json:"passwordHash,omitempty"`
+	Scopes       []string `json:"scopes"`
+}
+
+// Token is a scoped API token. Only the SHA256 hash of the token is stored
+type Token struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Owner   string    `json:"owner,omitempty"`
+	Hash    string    `json:"hash"`
+	Scopes  []string  `json:"scopes"`
+	Created time.Time `json:"created"`
+	Expires time.Time `json:"expires"`
+}
+
+// Credentials holds everything required to authenticate a remote control
+// request. Username and Password are the legacy single admin login
+type Credentials struct {
+	Username string
+	Password string
+	Users    []User
+	Tokens   []Token
+}
+
+// Principal is an authenticated user or API token
+type Principal struct {
+	Type   string
+	Name   string
+	Scopes []string
+}
+
+type principalKey struct{}
+
+// verificationCache holds the expiry of recent successful password
+// verifications keyed by a digest of the credentials
+type verificationCache struct {
+	mtx     sync.Mutex
+	entries map[string]time.Time
+}
@@ -1,6 +1,7 @@
 package gctscript
 
 import (
+	"github.com/thrasher-corp/gocryptotrader/engine"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/gct"
 )
@@ -9,3 +10,14 @@ import (
 func Setup() {
 	modules.SetModuleWrapper(gct.Setup())
 }
+
+// SetupWithEngine configures the wrapper interface to operate on the supplied
+// engine. Scripts run by the engine script manager use its own wrapper, the
+// package wrapper is set as the default for modules loaded elsewhere
+func SetupWithEngine(bot *engine.Engine) {
+	w := gct.SetupWithEngine(bot)
+	modules.SetModuleWrapper(w)
+	if bot.GctScriptManager != nil {
+		bot.GctScriptManager.SetWrapper(w)
+	}
+}
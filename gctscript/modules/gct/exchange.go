@@ -1,7 +1,9 @@
 package gct
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	objects "github.com/d5/tengo/v2"
@@ -10,28 +12,62 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/modules/ta/indicators"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
-var exchangeModule = map[string]objects.Object{
-	"orderbook":      &objects.UserFunction{Name: "orderbook", Value: ExchangeOrderbook},
-	"ticker":         &objects.UserFunction{Name: "ticker", Value: ExchangeTicker},
-	"exchanges":      &objects.UserFunction{Name: "exchanges", Value: ExchangeExchanges},
-	"pairs":          &objects.UserFunction{Name: "pairs", Value: ExchangePairs},
-	"accountinfo":    &objects.UserFunction{Name: "accountinfo", Value: ExchangeAccountInfo},
-	"depositaddress": &objects.UserFunction{Name: "depositaddress", Value: ExchangeDepositAddress},
-	"orderquery":     &objects.UserFunction{Name: "orderquery", Value: ExchangeOrderQuery},
-	"ordercancel":    &objects.UserFunction{Name: "ordercancel", Value: ExchangeOrderCancel},
-	"ordersubmit":    &objects.UserFunction{Name: "ordersubmit", Value: ExchangeOrderSubmit},
-	"withdrawcrypto": &objects.UserFunction{Name: "withdrawcrypto", Value: ExchangeWithdrawCrypto},
-	"withdrawfiat":   &objects.UserFunction{Name: "withdrawfiat", Value: ExchangeWithdrawFiat},
-	"ohlcv":          &objects.UserFunction{Name: "ohlcv", Value: exchangeOHLCV},
+var exchangeModule = exchangeModuleWithContext(context.Background())
+
+// exchangeFuncs maps the exchange module function names to their
+// implementations
+var exchangeFuncs = map[string]func(context.Context, ...objects.Object) (objects.Object, error){
+	"orderbook":      exchangeOrderbook,
+	"ticker":         exchangeTicker,
+	"exchanges":      exchangeExchanges,
+	"pairs":          exchangePairs,
+	"accountinfo":    exchangeAccountInfo,
+	"depositaddress": exchangeDepositAddress,
+	"orderquery":     exchangeOrderQuery,
+	"ordercancel":    exchangeOrderCancel,
+	"ordersubmit":    exchangeOrderSubmit,
+	"withdrawcrypto": exchangeWithdrawCrypto,
+	"withdrawfiat":   exchangeWithdrawFiat,
+	"ohlcv":          exchangeOHLCV,
+	"positions":      exchangePositions,
+	"activeorders":   exchangeActiveOrders,
+	"orderhistory":   exchangeOrderHistory,
+	"recenttrades":   exchangeRecentTrades,
+	"ordermodify":    exchangeOrderModify,
+	"transfer":       exchangeTransfer,
+	"limits":         exchangeLimits,
+	"fee":            exchangeFee,
+	"storedohlcv":    exchangeStoredOHLCV,
+	"storedtrades":   exchangeStoredTrades,
+}
+
+// exchangeModuleWithContext returns the exchange module with functions bound
+// to ctx, so actions can be attributed to the script performing them and
+// are routed to the wrapper held by ctx
+func exchangeModuleWithContext(ctx context.Context) map[string]objects.Object {
+	m := make(map[string]objects.Object, len(exchangeFuncs))
+	for name, fn := range exchangeFuncs {
+		fn := fn
+		m[name] = &objects.UserFunction{Name: name, Value: func(args ...objects.Object) (objects.Object, error) {
+			return fn(ctx, args...)
+		}}
+	}
+	return m
 }
 
 // ExchangeOrderbook returns orderbook for requested exchange & currencypair
 func ExchangeOrderbook(args ...objects.Object) (objects.Object, error) {
+	return exchangeOrderbook(context.Background(), args...)
+}
+
+func exchangeOrderbook(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) != 4 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -63,7 +99,7 @@ func ExchangeOrderbook(args ...objects.Object) (objects.Object, error) {
 		return nil, err
 	}
 
-	ob, err := wrappers.GetWrapper().Orderbook(exchangeName, pair, assetType)
+	ob, err := wrappers.GetWrapperFromContext(ctx).Orderbook(exchangeName, pair, assetType)
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +134,10 @@ func ExchangeOrderbook(args ...objects.Object) (objects.Object, error) {
 
 // ExchangeTicker returns ticker data for requested exchange and currency pair
 func ExchangeTicker(args ...objects.Object) (objects.Object, error) {
+	return exchangeTicker(context.Background(), args...)
+}
+
+func exchangeTicker(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) != 4 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -129,7 +169,7 @@ func ExchangeTicker(args ...objects.Object) (objects.Object, error) {
 		return nil, err
 	}
 
-	tx, err := wrappers.GetWrapper().Ticker(exchangeName, pair, assetType)
+	tx, err := wrappers.GetWrapperFromContext(ctx).Ticker(exchangeName, pair, assetType)
 	if err != nil {
 		return nil, err
 	}
@@ -157,6 +197,10 @@ func ExchangeTicker(args ...objects.Object) (objects.Object, error) {
 
 // ExchangeExchanges returns list of exchanges either enabled or all
 func ExchangeExchanges(args ...objects.Object) (objects.Object, error) {
+	return exchangeExchanges(context.Background(), args...)
+}
+
+func exchangeExchanges(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) != 1 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -165,7 +209,7 @@ func ExchangeExchanges(args ...objects.Object) (objects.Object, error) {
 	if !ok {
 		return nil, fmt.Errorf(ErrParameterConvertFailed, enabledOnly)
 	}
-	rtnValue := wrappers.GetWrapper().Exchanges(enabledOnly)
+	rtnValue := wrappers.GetWrapperFromContext(ctx).Exchanges(enabledOnly)
 
 	r := objects.Array{}
 	for x := range rtnValue {
@@ -177,6 +221,10 @@ func ExchangeExchanges(args ...objects.Object) (objects.Object, error) {
 
 // ExchangePairs returns currency pairs for requested exchange
 func ExchangePairs(args ...objects.Object) (objects.Object, error) {
+	return exchangePairs(context.Background(), args...)
+}
+
+func exchangePairs(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) != 3 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -198,7 +246,7 @@ func ExchangePairs(args ...objects.Object) (objects.Object, error) {
 		return nil, err
 	}
 
-	rtnValue, err := wrappers.GetWrapper().Pairs(exchangeName, enabledOnly, assetType)
+	rtnValue, err := wrappers.GetWrapperFromContext(ctx).Pairs(exchangeName, enabledOnly, assetType)
 	if err != nil {
 		return nil, err
 	}
@@ -213,6 +261,10 @@ func ExchangePairs(args ...objects.Object) (objects.Object, error) {
 
 // ExchangeAccountInfo returns account information for requested exchange
 func ExchangeAccountInfo(args ...objects.Object) (objects.Object, error) {
+	return exchangeAccountInfo(context.Background(), args...)
+}
+
+func exchangeAccountInfo(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) != 2 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -229,7 +281,7 @@ func ExchangeAccountInfo(args ...objects.Object) (objects.Object, error) {
 	if err != nil {
 		return nil, err
 	}
-	rtnValue, err := wrappers.GetWrapper().AccountInformation(exchangeName, assetType)
+	rtnValue, err := wrappers.GetWrapperFromContext(ctx).AccountInformation(exchangeName, assetType)
 	if err != nil {
 		return nil, err
 	}
@@ -256,6 +308,10 @@ func ExchangeAccountInfo(args ...objects.Object) (objects.Object, error) {
 
 // ExchangeOrderQuery query order on exchange
 func ExchangeOrderQuery(args ...objects.Object) (objects.Object, error) {
+	return exchangeOrderQuery(context.Background(), args...)
+}
+
+func exchangeOrderQuery(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) < 2 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -297,11 +353,16 @@ func ExchangeOrderQuery(args ...objects.Object) (objects.Object, error) {
 		return nil, err
 	}
 
-	orderDetails, err := wrappers.GetWrapper().QueryOrder(exchangeName, orderID, pair, assetType)
+	orderDetails, err := wrappers.GetWrapperFromContext(ctx).QueryOrder(exchangeName, orderID, pair, assetType)
 	if err != nil {
 		return nil, err
 	}
 
+	return orderDetailToObject(orderDetails), nil
+}
+
+// orderDetailToObject converts an order into a tengo map
+func orderDetailToObject(orderDetails *order.Detail) *objects.Map {
 	var tradeHistory objects.Array
 	for x := range orderDetails.Trades {
 		temp := make(map[string]objects.Object, 7)
@@ -333,11 +394,15 @@ func ExchangeOrderQuery(args ...objects.Object) (objects.Object, error) {
 
 	return &objects.Map{
 		Value: data,
-	}, nil
+	}
 }
 
 // ExchangeOrderCancel cancels order on requested exchange
 func ExchangeOrderCancel(args ...objects.Object) (objects.Object, error) {
+	return exchangeOrderCancel(context.Background(), args...)
+}
+
+func exchangeOrderCancel(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) < 2 || len(args) > 4 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -384,7 +449,7 @@ func ExchangeOrderCancel(args ...objects.Object) (objects.Object, error) {
 	}
 
 	var isCancelled bool
-	isCancelled, err = wrappers.GetWrapper().CancelOrder(exchangeName, orderID, cp, a)
+	isCancelled, err = wrappers.GetWrapperFromContext(ctx).CancelOrder(ctx, exchangeName, orderID, cp, a)
 	if err != nil {
 		return nil, err
 	}
@@ -397,6 +462,10 @@ func ExchangeOrderCancel(args ...objects.Object) (objects.Object, error) {
 
 // ExchangeOrderSubmit submit order on exchange
 func ExchangeOrderSubmit(args ...objects.Object) (objects.Object, error) {
+	return exchangeOrderSubmit(context.Background(), args...)
+}
+
+func exchangeOrderSubmit(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) != 9 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -457,7 +526,7 @@ func ExchangeOrderSubmit(args ...objects.Object) (objects.Object, error) {
 		Exchange:  exchangeName,
 	}
 
-	rtn, err := wrappers.GetWrapper().SubmitOrder(tempSubmit)
+	rtn, err := wrappers.GetWrapperFromContext(ctx).SubmitOrder(ctx, tempSubmit)
 	if err != nil {
 		return nil, err
 	}
@@ -477,6 +546,10 @@ func ExchangeOrderSubmit(args ...objects.Object) (objects.Object, error) {
 
 // ExchangeDepositAddress returns deposit address (if supported by exchange)
 func ExchangeDepositAddress(args ...objects.Object) (objects.Object, error) {
+	return exchangeDepositAddress(context.Background(), args...)
+}
+
+func exchangeDepositAddress(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) != 2 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -492,7 +565,7 @@ func ExchangeDepositAddress(args ...objects.Object) (objects.Object, error) {
 
 	currCode := currency.NewCode(currencyCode)
 
-	rtn, err := wrappers.GetWrapper().DepositAddress(exchangeName, currCode)
+	rtn, err := wrappers.GetWrapperFromContext(ctx).DepositAddress(exchangeName, currCode)
 	if err != nil {
 		return nil, err
 	}
@@ -502,6 +575,10 @@ func ExchangeDepositAddress(args ...objects.Object) (objects.Object, error) {
 
 // ExchangeWithdrawCrypto submit request to withdraw crypto assets
 func ExchangeWithdrawCrypto(args ...objects.Object) (objects.Object, error) {
+	return exchangeWithdrawCrypto(context.Background(), args...)
+}
+
+func exchangeWithdrawCrypto(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) != 7 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -547,7 +624,7 @@ func ExchangeWithdrawCrypto(args ...objects.Object) (objects.Object, error) {
 		Amount:      amount,
 	}
 
-	rtn, err := wrappers.GetWrapper().WithdrawalCryptoFunds(withdrawRequest)
+	rtn, err := wrappers.GetWrapperFromContext(ctx).WithdrawalCryptoFunds(ctx, withdrawRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -557,6 +634,10 @@ func ExchangeWithdrawCrypto(args ...objects.Object) (objects.Object, error) {
 
 // ExchangeWithdrawFiat submit request to withdraw fiat assets
 func ExchangeWithdrawFiat(args ...objects.Object) (objects.Object, error) {
+	return exchangeWithdrawFiat(context.Background(), args...)
+}
+
+func exchangeWithdrawFiat(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) != 5 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -589,7 +670,7 @@ func ExchangeWithdrawFiat(args ...objects.Object) (objects.Object, error) {
 		Amount:      amount,
 	}
 
-	rtn, err := wrappers.GetWrapper().WithdrawalFiatFunds(bankAccountID, withdrawRequest)
+	rtn, err := wrappers.GetWrapperFromContext(ctx).WithdrawalFiatFunds(ctx, bankAccountID, withdrawRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -607,7 +688,7 @@ func (o *OHLCV) TypeName() string {
 	return indicators.OHLCV
 }
 
-func exchangeOHLCV(args ...objects.Object) (objects.Object, error) {
+func exchangeOHLCV(ctx context.Context, args ...objects.Object) (objects.Object, error) {
 	if len(args) != 7 {
 		return nil, objects.ErrWrongNumArguments
 	}
@@ -656,11 +737,16 @@ func exchangeOHLCV(args ...objects.Object) (objects.Object, error) {
 		return nil, err
 	}
 
-	ret, err := wrappers.GetWrapper().OHLCV(exchangeName, pair, assetType, startTime, endTime, kline.Interval(interval))
+	ret, err := wrappers.GetWrapperFromContext(ctx).OHLCV(exchangeName, pair, assetType, startTime, endTime, kline.Interval(interval))
 	if err != nil {
 		return nil, err
 	}
 
+	return klineToOHLCV(&ret), nil
+}
+
+// klineToOHLCV converts candles into an OHLCV object
+func klineToOHLCV(ret *kline.Item) *OHLCV {
 	var candles objects.Array
 	for x := range ret.Candles {
 		candle := &objects.Array{}
@@ -684,7 +770,492 @@ func exchangeOHLCV(args ...objects.Object) (objects.Object, error) {
 
 	c := new(OHLCV)
 	c.Value = retValue
-	return c, nil
+	return c
+}
+
+// toPairAsset converts pair, delimiter and asset arguments, an empty pair is
+// only permitted when allowEmptyPair is set
+func toPairAsset(pairArg, delimiterArg, assetArg objects.Object, allowEmptyPair bool) (currency.Pair, asset.Item, error) {
+	currencyPair, ok := objects.ToString(pairArg)
+	if !ok {
+		return currency.Pair{}, "", fmt.Errorf(ErrParameterConvertFailed, currencyPair)
+	}
+	delimiter, ok := objects.ToString(delimiterArg)
+	if !ok {
+		return currency.Pair{}, "", fmt.Errorf(ErrParameterConvertFailed, delimiter)
+	}
+	assetTypeParam, ok := objects.ToString(assetArg)
+	if !ok {
+		return currency.Pair{}, "", fmt.Errorf(ErrParameterConvertFailed, assetTypeParam)
+	}
+	assetType, err := asset.New(assetTypeParam)
+	if err != nil {
+		return currency.Pair{}, "", err
+	}
+	if currencyPair == "" {
+		if !allowEmptyPair {
+			return currency.Pair{}, "", fmt.Errorf(ErrEmptyParameter, "currency pair")
+		}
+		return currency.Pair{}, assetType, nil
+	}
+	pair, err := currency.NewPairDelimiter(currencyPair, delimiter)
+	if err != nil {
+		return currency.Pair{}, "", err
+	}
+	return pair, assetType, nil
+}
+
+// ExchangePositions returns open positions for an exchange and asset, an
+// empty pair returns positions for all pairs
+func ExchangePositions(args ...objects.Object) (objects.Object, error) {
+	return exchangePositions(context.Background(), args...)
+}
+
+func exchangePositions(ctx context.Context, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	pair, assetType, err := toPairAsset(args[1], args[2], args[3], true)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := wrappers.GetWrapperFromContext(ctx).Positions(exchangeName, assetType, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	var r objects.Array
+	for x := range positions {
+		temp := make(map[string]objects.Object, 10)
+		temp["symbol"] = &objects.String{Value: positions[x].FutureSymbol}
+		temp["quantity"] = &objects.Float{Value: positions[x].Qty}
+		temp["maxquantity"] = &objects.Float{Value: positions[x].MaxQty}
+		temp["entryprice"] = &objects.Float{Value: positions[x].EntryPrice}
+		temp["markprice"] = &objects.Float{Value: positions[x].MarkPrice}
+		temp["liquidationprice"] = &objects.Float{Value: positions[x].LiquidationPrice}
+		temp["leverage"] = &objects.Float{Value: positions[x].Leverage}
+		temp["side"] = &objects.String{Value: string(positions[x].Side)}
+		temp["unrealisedpnl"] = &objects.Float{Value: positions[x].UnrealisedPnl}
+		temp["realisedpnl"] = &objects.Float{Value: positions[x].RealisedPnl}
+		r.Value = append(r.Value, &objects.Map{Value: temp})
+	}
+	return &r, nil
+}
+
+// ExchangeActiveOrders returns open orders on an exchange, an empty pair
+// returns orders for all pairs
+func ExchangeActiveOrders(args ...objects.Object) (objects.Object, error) {
+	return exchangeActiveOrders(context.Background(), args...)
+}
+
+func exchangeActiveOrders(ctx context.Context, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	exchangeName, request, err := toOrdersRequest(args)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := wrappers.GetWrapperFromContext(ctx).ActiveOrders(exchangeName, request)
+	if err != nil {
+		return nil, err
+	}
+	return ordersToObject(orders), nil
+}
+
+// ExchangeOrderHistory returns past orders on an exchange, optionally between
+// a start and end time
+func ExchangeOrderHistory(args ...objects.Object) (objects.Object, error) {
+	return exchangeOrderHistory(context.Background(), args...)
+}
+
+func exchangeOrderHistory(ctx context.Context, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 && len(args) != 6 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	exchangeName, request, err := toOrdersRequest(args[:4])
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 6 {
+		var ok bool
+		request.StartTime, ok = objects.ToTime(args[4])
+		if !ok {
+			return nil, fmt.Errorf(ErrParameterConvertFailed, request.StartTime)
+		}
+		request.EndTime, ok = objects.ToTime(args[5])
+		if !ok {
+			return nil, fmt.Errorf(ErrParameterConvertFailed, request.EndTime)
+		}
+	}
+
+	orders, err := wrappers.GetWrapperFromContext(ctx).OrderHistory(exchangeName, request)
+	if err != nil {
+		return nil, err
+	}
+	return ordersToObject(orders), nil
+}
+
+// toOrdersRequest converts exchange, pair, delimiter and asset arguments into
+// an orders request
+func toOrdersRequest(args []objects.Object) (string, *order.GetOrdersRequest, error) {
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return "", nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	pair, assetType, err := toPairAsset(args[1], args[2], args[3], true)
+	if err != nil {
+		return "", nil, err
+	}
+	request := &order.GetOrdersRequest{
+		Type:      order.AnyType,
+		Side:      order.AnySide,
+		AssetType: assetType,
+	}
+	if !pair.IsEmpty() {
+		request.Pairs = currency.Pairs{pair}
+	}
+	return exchangeName, request, nil
+}
+
+// ordersToObject converts orders into a tengo array
+func ordersToObject(orders []order.Detail) *objects.Array {
+	var r objects.Array
+	for x := range orders {
+		r.Value = append(r.Value, orderDetailToObject(&orders[x]))
+	}
+	return &r
+}
+
+// ExchangeRecentTrades returns the most recent trades of a pair from an
+// exchange
+func ExchangeRecentTrades(args ...objects.Object) (objects.Object, error) {
+	return exchangeRecentTrades(context.Background(), args...)
+}
+
+func exchangeRecentTrades(ctx context.Context, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	pair, assetType, err := toPairAsset(args[1], args[2], args[3], false)
+	if err != nil {
+		return nil, err
+	}
+
+	trades, err := wrappers.GetWrapperFromContext(ctx).RecentTrades(exchangeName, pair, assetType)
+	if err != nil {
+		return nil, err
+	}
+	return tradesToObject(trades), nil
+}
+
+// tradesToObject converts trades into a tengo array
+func tradesToObject(trades []trade.Data) *objects.Array {
+	var r objects.Array
+	for x := range trades {
+		temp := make(map[string]objects.Object, 8)
+		temp["id"] = &objects.String{Value: trades[x].TID}
+		temp["exchange"] = &objects.String{Value: trades[x].Exchange}
+		temp["pair"] = &objects.String{Value: trades[x].CurrencyPair.String()}
+		temp["asset"] = &objects.String{Value: trades[x].AssetType.String()}
+		temp["side"] = &objects.String{Value: trades[x].Side.String()}
+		temp["price"] = &objects.Float{Value: trades[x].Price}
+		temp["amount"] = &objects.Float{Value: trades[x].Amount}
+		temp["timestamp"] = &objects.Time{Value: trades[x].Timestamp}
+		r.Value = append(r.Value, &objects.Map{Value: temp})
+	}
+	return &r
+}
+
+// ExchangeOrderModify amends the price and amount of an order on an exchange
+func ExchangeOrderModify(args ...objects.Object) (objects.Object, error) {
+	return exchangeOrderModify(context.Background(), args...)
+}
+
+func exchangeOrderModify(ctx context.Context, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 7 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	orderID, ok := objects.ToString(args[1])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, orderID)
+	}
+	if orderID == "" {
+		return nil, fmt.Errorf(ErrEmptyParameter, "orderID")
+	}
+	pair, assetType, err := toPairAsset(args[2], args[3], args[4], false)
+	if err != nil {
+		return nil, err
+	}
+	orderPrice, ok := objects.ToFloat64(args[5])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, orderPrice)
+	}
+	orderAmount, ok := objects.ToFloat64(args[6])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, orderAmount)
+	}
+
+	rtn, err := wrappers.GetWrapperFromContext(ctx).ModifyOrder(ctx, &order.Modify{
+		Exchange:  exchangeName,
+		ID:        orderID,
+		Pair:      pair,
+		AssetType: assetType,
+		Price:     orderPrice,
+		Amount:    orderAmount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &objects.String{Value: rtn}, nil
+}
+
+// ExchangeTransfer moves funds between asset accounts on an exchange
+func ExchangeTransfer(args ...objects.Object) (objects.Object, error) {
+	return exchangeTransfer(context.Background(), args...)
+}
+
+func exchangeTransfer(ctx context.Context, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 5 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	fromParam, ok := objects.ToString(args[1])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, fromParam)
+	}
+	toParam, ok := objects.ToString(args[2])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, toParam)
+	}
+	cur, ok := objects.ToString(args[3])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, cur)
+	}
+	amount, ok := objects.ToFloat64(args[4])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, amount)
+	}
+	from, err := asset.New(fromParam)
+	if err != nil {
+		return nil, err
+	}
+	to, err := asset.New(toParam)
+	if err != nil {
+		return nil, err
+	}
+
+	rtn, err := wrappers.GetWrapperFromContext(ctx).TransferAsset(ctx, exchangeName, from, to, currency.NewCode(cur), amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &objects.String{Value: rtn}, nil
+}
+
+// ExchangeLimits returns the order execution limits of a pair on an exchange
+func ExchangeLimits(args ...objects.Object) (objects.Object, error) {
+	return exchangeLimits(context.Background(), args...)
+}
+
+func exchangeLimits(ctx context.Context, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	pair, assetType, err := toPairAsset(args[1], args[2], args[3], false)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := wrappers.GetWrapperFromContext(ctx).ExecutionLimits(exchangeName, pair, assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]objects.Object, 14)
+	data["pair"] = &objects.String{Value: l.Pair.String()}
+	data["asset"] = &objects.String{Value: l.Asset.String()}
+	data["minprice"] = &objects.Float{Value: l.MinPrice}
+	data["maxprice"] = &objects.Float{Value: l.MaxPrice}
+	data["stepprice"] = &objects.Float{Value: l.StepPrice}
+	data["minamount"] = &objects.Float{Value: l.MinAmount}
+	data["maxamount"] = &objects.Float{Value: l.MaxAmount}
+	data["stepamount"] = &objects.Float{Value: l.StepAmount}
+	data["minnotional"] = &objects.Float{Value: l.MinNotional}
+	data["marketminamount"] = &objects.Float{Value: l.MarketMinQty}
+	data["marketmaxamount"] = &objects.Float{Value: l.MarketMaxQty}
+	data["marketstepamount"] = &objects.Float{Value: l.MarketStepSize}
+	data["maxtotalorders"] = &objects.Int{Value: l.MaxTotalOrders}
+	data["maxalgoorders"] = &objects.Int{Value: l.MaxAlgoOrders}
+
+	return &objects.Map{
+		Value: data,
+	}, nil
+}
+
+// ExchangeFee returns the fee an exchange charges for a trade, deposit or
+// withdrawal
+func ExchangeFee(args ...objects.Object) (objects.Object, error) {
+	return exchangeFee(context.Background(), args...)
+}
+
+func exchangeFee(ctx context.Context, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 7 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	feeType, ok := objects.ToString(args[1])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, feeType)
+	}
+	feeType = strings.ToLower(feeType)
+	if !common.StringDataCompare(modules.FeeTypes, feeType) {
+		return nil, fmt.Errorf("%w %s, supported types: %s", errInvalidFeeType, feeType, strings.Join(modules.FeeTypes, ", "))
+	}
+	currencyPair, ok := objects.ToString(args[2])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, currencyPair)
+	}
+	delimiter, ok := objects.ToString(args[3])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, delimiter)
+	}
+	pair, err := currency.NewPairDelimiter(currencyPair, delimiter)
+	if err != nil {
+		return nil, err
+	}
+	price, ok := objects.ToFloat64(args[4])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, price)
+	}
+	amount, ok := objects.ToFloat64(args[5])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, amount)
+	}
+	isMaker, ok := objects.ToBool(args[6])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, isMaker)
+	}
+
+	fee, err := wrappers.GetWrapperFromContext(ctx).Fee(exchangeName, &modules.FeeRequest{
+		Type:    feeType,
+		Pair:    pair,
+		IsMaker: isMaker,
+		Price:   price,
+		Amount:  amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &objects.Float{Value: fee}, nil
+}
+
+// ExchangeStoredOHLCV returns candles saved to the database
+func ExchangeStoredOHLCV(args ...objects.Object) (objects.Object, error) {
+	return exchangeStoredOHLCV(context.Background(), args...)
+}
+
+func exchangeStoredOHLCV(ctx context.Context, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 7 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	pair, assetType, err := toPairAsset(args[1], args[2], args[3], false)
+	if err != nil {
+		return nil, err
+	}
+	startTime, ok := objects.ToTime(args[4])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, startTime)
+	}
+	endTime, ok := objects.ToTime(args[5])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, endTime)
+	}
+	intervalStr, ok := objects.ToString(args[6])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, intervalStr)
+	}
+	interval, err := parseInterval(intervalStr)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := wrappers.GetWrapperFromContext(ctx).StoredOHLCV(exchangeName, pair, assetType, startTime, endTime, kline.Interval(interval))
+	if err != nil {
+		return nil, err
+	}
+	return klineToOHLCV(&ret), nil
+}
+
+// ExchangeStoredTrades returns trades saved to the database
+func ExchangeStoredTrades(args ...objects.Object) (objects.Object, error) {
+	return exchangeStoredTrades(context.Background(), args...)
+}
+
+func exchangeStoredTrades(ctx context.Context, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 6 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	exchangeName, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, exchangeName)
+	}
+	pair, assetType, err := toPairAsset(args[1], args[2], args[3], false)
+	if err != nil {
+		return nil, err
+	}
+	startTime, ok := objects.ToTime(args[4])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, startTime)
+	}
+	endTime, ok := objects.ToTime(args[5])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, endTime)
+	}
+
+	trades, err := wrappers.GetWrapperFromContext(ctx).StoredTrades(exchangeName, pair, assetType, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return tradesToObject(trades), nil
 }
 
 // parseInterval will parse the interval param of indictors that have them and convert to time.Duration
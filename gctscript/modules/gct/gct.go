@@ -1,5 +1,11 @@
 package gct
 
+import (
+	"context"
+
+	"github.com/d5/tengo/v2"
+)
+
 // AllModuleNames returns a list of all default module names.
 func AllModuleNames() []string {
 	var names []string
@@ -8,3 +14,15 @@ func AllModuleNames() []string {
 	}
 	return names
 }
+
+// ModulesWithContext returns all loadable modules with exchange actions and
+// state bound to ctx
+func ModulesWithContext(ctx context.Context) map[string]map[string]tengo.Object {
+	m := make(map[string]map[string]tengo.Object, len(Modules))
+	for name, mod := range Modules {
+		m[name] = mod
+	}
+	m["exchange"] = exchangeModuleWithContext(ctx)
+	m["state"] = stateModuleWithContext(ctx)
+	return m
+}
@@ -332,3 +332,195 @@ func TestParseInterval(t *testing.T) {
 		}
 	}
 }
+
+func TestExchangePositions(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangePositions()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+
+	futures := &objects.String{Value: asset.Futures.String()}
+	p, err := ExchangePositions(exch, blank, delimiter, futures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	positions, ok := p.(*objects.Array)
+	if !ok || len(positions.Value) != 1 {
+		t.Fatalf("unexpected positions %v", p)
+	}
+
+	_, err = ExchangePositions(exch, currencyPair, delimiter, futures)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExchangeOrders(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeActiveOrders()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+	_, err = ExchangeOrderHistory(exch, currencyPair, delimiter, assetType, blank)
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+
+	_, err = ExchangeActiveOrders(exch, blank, delimiter, assetType)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = ExchangeOrderHistory(exch, currencyPair, delimiter, assetType)
+	if err != nil {
+		t.Error(err)
+	}
+	start := &objects.Time{Value: time.Now().Add(-time.Hour)}
+	end := &objects.Time{Value: time.Now()}
+	o, err := ExchangeOrderHistory(exch, currencyPair, delimiter, assetType, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orders, ok := o.(*objects.Array)
+	if !ok || len(orders.Value) != 1 {
+		t.Fatalf("unexpected orders %v", o)
+	}
+	_, err = ExchangeOrderHistory(exch, currencyPair, delimiter, assetType, blank, end)
+	if err == nil {
+		t.Error("expected error on invalid start time")
+	}
+}
+
+func TestExchangeTrades(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeRecentTrades()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+	_, err = ExchangeRecentTrades(exch, blank, delimiter, assetType)
+	if err == nil {
+		t.Error("expected error on empty pair")
+	}
+	_, err = ExchangeRecentTrades(exch, currencyPair, delimiter, assetType)
+	if err != nil {
+		t.Error(err)
+	}
+
+	start := &objects.Time{Value: time.Now().Add(-time.Hour)}
+	end := &objects.Time{Value: time.Now()}
+	_, err = ExchangeStoredTrades(exch, currencyPair, delimiter, assetType, start)
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+	_, err = ExchangeStoredTrades(exch, currencyPair, delimiter, assetType, start, end)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExchangeOrderModify(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeOrderModify()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+
+	price := &objects.Float{Value: 1}
+	amount := &objects.Float{Value: 2}
+	_, err = ExchangeOrderModify(exch, blank, currencyPair, delimiter, assetType, price, amount)
+	if err == nil {
+		t.Error("expected error on empty order ID")
+	}
+	id, err := ExchangeOrderModify(exch, orderID, currencyPair, delimiter, assetType, price, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := objects.ToString(id); !ok || s != orderID.Value {
+		t.Errorf("expected %v received %v", orderID.Value, id)
+	}
+}
+
+func TestExchangeTransfer(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeTransfer()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+
+	futures := &objects.String{Value: asset.Futures.String()}
+	currCode := &objects.String{Value: "BTC"}
+	amount := &objects.Float{Value: 1}
+	_, err = ExchangeTransfer(exch, assetType, futures, currCode, amount)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = ExchangeTransfer(exch, assetType, blank, currCode, amount)
+	if err == nil {
+		t.Error("expected error on invalid asset")
+	}
+}
+
+func TestExchangeLimits(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeLimits()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+
+	l, err := ExchangeLimits(exch, currencyPair, delimiter, assetType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limits, ok := l.(*objects.Map)
+	if !ok || limits.Value["pair"].String() != `"BTC-AUD"` {
+		t.Errorf("unexpected limits %v", l)
+	}
+}
+
+func TestExchangeFee(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeFee()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+
+	price := &objects.Float{Value: 100}
+	amount := &objects.Float{Value: 2}
+	feeType := &objects.String{Value: modules.FeeTypeTrade}
+	f, err := ExchangeFee(exch, feeType, currencyPair, delimiter, price, amount, tv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee, ok := objects.ToFloat64(f); !ok || fee != 0.2 {
+		t.Errorf("expected fee 0.2 received %v", f)
+	}
+
+	_, err = ExchangeFee(exch, blank, currencyPair, delimiter, price, amount, tv)
+	if !errors.Is(err, errInvalidFeeType) {
+		t.Errorf("received: %v but expected: %v", err, errInvalidFeeType)
+	}
+}
+
+func TestExchangeStoredOHLCV(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeStoredOHLCV()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+
+	start := &objects.Time{Value: time.Now().Add(-time.Hour * 24)}
+	end := &objects.Time{Value: time.Now()}
+	interval := &objects.String{Value: "1h"}
+	c, err := ExchangeStoredOHLCV(exch, currencyPair, delimiter, assetType, start, end, interval)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.(*OHLCV); !ok {
+		t.Errorf("expected OHLCV received %T", c)
+	}
+
+	_, err = ExchangeStoredOHLCV(exch, currencyPair, delimiter, assetType, start, end, &objects.String{Value: "2w"})
+	if !errors.Is(err, errInvalidInterval) {
+		t.Errorf("received: %v but expected: %v", err, errInvalidInterval)
+	}
+}
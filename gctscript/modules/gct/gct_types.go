@@ -13,11 +13,15 @@ const (
 	ErrEmptyParameter = "received empty parameter for %v"
 )
 
-var errInvalidInterval = errors.New("invalid interval")
+var (
+	errInvalidInterval = errors.New("invalid interval")
+	errInvalidFeeType  = errors.New("invalid fee type")
+)
 var supportedDurations = []string{"1m", "3m", "5m", "15m", "30m", "1h", "2h", "4h", "6h", "12h", "24h", "1d", "3d", "1w"}
 
 // Modules map of all loadable modules
 var Modules = map[string]map[string]tengo.Object{
 	"exchange": exchangeModule,
 	"common":   commonModule,
+	"state":    stateModule,
 }
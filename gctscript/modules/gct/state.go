@@ -0,0 +1,175 @@
+package gct
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers"
+)
+
+var (
+	stateModule = stateModuleWithContext(context.Background())
+
+	errScriptNameNotSet = errors.New("state is unavailable, script name not set")
+)
+
+type scriptNameKey struct{}
+
+// WithScriptName returns a copy of ctx scoped to a script which namespaces
+// the state module
+func WithScriptName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, scriptNameKey{}, name)
+}
+
+func scriptNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(scriptNameKey{}).(string)
+	return name
+}
+
+// stateModuleWithContext returns the state module scoped to the script name
+// and wrapper held by ctx
+func stateModuleWithContext(ctx context.Context) map[string]objects.Object {
+	script := scriptNameFromContext(ctx)
+	return map[string]objects.Object{
+		"get": &objects.UserFunction{Name: "get", Value: func(args ...objects.Object) (objects.Object, error) {
+			return stateGet(ctx, script, args...)
+		}},
+		"set": &objects.UserFunction{Name: "set", Value: func(args ...objects.Object) (objects.Object, error) {
+			return stateSet(ctx, script, args...)
+		}},
+		"delete": &objects.UserFunction{Name: "delete", Value: func(args ...objects.Object) (objects.Object, error) {
+			return stateDelete(ctx, script, args...)
+		}},
+		"list": &objects.UserFunction{Name: "list", Value: func(args ...objects.Object) (objects.Object, error) {
+			return stateList(ctx, script, args...)
+		}},
+	}
+}
+
+// stateGet returns the value stored for a key, the optional second argument
+// is returned when the key is not found
+func stateGet(ctx context.Context, script string, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if script == "" {
+		return nil, errScriptNameNotSet
+	}
+	key, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, key)
+	}
+	value, found, err := wrappers.GetWrapperFromContext(ctx).GetState(script, key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if len(args) == 2 {
+			return args[1], nil
+		}
+		return objects.UndefinedValue, nil
+	}
+	return decodeState(value)
+}
+
+// stateSet stores a value for a key, values must be JSON serialisable
+func stateSet(ctx context.Context, script string, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 2 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if script == "" {
+		return nil, errScriptNameNotSet
+	}
+	key, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, key)
+	}
+	value, err := json.Marshal(objects.ToInterface(args[1]))
+	if err != nil {
+		return nil, err
+	}
+	err = wrappers.GetWrapperFromContext(ctx).SetState(script, key, string(value))
+	if err != nil {
+		return nil, err
+	}
+	return objects.TrueValue, nil
+}
+
+// stateDelete removes a key
+func stateDelete(ctx context.Context, script string, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 1 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if script == "" {
+		return nil, errScriptNameNotSet
+	}
+	key, ok := objects.ToString(args[0])
+	if !ok {
+		return nil, fmt.Errorf(ErrParameterConvertFailed, key)
+	}
+	err := wrappers.GetWrapperFromContext(ctx).DeleteState(script, key)
+	if err != nil {
+		return nil, err
+	}
+	return objects.TrueValue, nil
+}
+
+// stateList returns a map of every key and value stored
+func stateList(ctx context.Context, script string, args ...objects.Object) (objects.Object, error) {
+	if len(args) != 0 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if script == "" {
+		return nil, errScriptNameNotSet
+	}
+	list, err := wrappers.GetWrapperFromContext(ctx).ListState(script)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]objects.Object, len(list))
+	for k, v := range list {
+		data[k], err = decodeState(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &objects.Map{Value: data}, nil
+}
+
+// decodeState converts a stored JSON value back to a script object, whole
+// numbers are returned as ints
+func decodeState(value string) (objects.Object, error) {
+	d := json.NewDecoder(bytes.NewBufferString(value))
+	d.UseNumber()
+	var v interface{}
+	err := d.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+	return objects.FromInterface(convertNumbers(v))
+}
+
+func convertNumbers(v interface{}) interface{} {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(val.String(), 10, 64); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case []interface{}:
+		for i := range val {
+			val[i] = convertNumbers(val[i])
+		}
+	case map[string]interface{}:
+		for k := range val {
+			val[k] = convertNumbers(val[k])
+		}
+	}
+	return v
+}
@@ -0,0 +1,104 @@
+package gct
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	objects "github.com/d5/tengo/v2"
+)
+
+func TestStateModule(t *testing.T) {
+	t.Parallel()
+	key := &objects.String{Value: "signal"}
+	unscoped := stateModuleWithContext(context.Background())
+	_, err := unscoped["get"].(*objects.UserFunction).Value(key)
+	if !errors.Is(err, errScriptNameNotSet) {
+		t.Errorf("received: %v but expected: %v", err, errScriptNameNotSet)
+	}
+
+	m := stateModuleWithContext(WithScriptName(context.Background(), "test.gct"))
+	_, err = m["get"].(*objects.UserFunction).Value()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received: %v but expected: %v", err, objects.ErrWrongNumArguments)
+	}
+	v, err := m["get"].(*objects.UserFunction).Value(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != objects.UndefinedValue {
+		t.Errorf("expected undefined for missing key, received %v", v)
+	}
+	v, err = m["get"].(*objects.UserFunction).Value(key, tv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != tv {
+		t.Errorf("expected default for missing key, received %v", v)
+	}
+
+	_, err = m["set"].(*objects.UserFunction).Value(key, &objects.Int{Value: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m["set"].(*objects.UserFunction).Value(key)
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received: %v but expected: %v", err, objects.ErrWrongNumArguments)
+	}
+	_, err = m["delete"].(*objects.UserFunction).Value(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := m["list"].(*objects.UserFunction).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.(*objects.Map).Value) != 0 {
+		t.Errorf("unexpected state %v", list)
+	}
+}
+
+func TestDecodeState(t *testing.T) {
+	t.Parallel()
+	in := &objects.Map{Value: map[string]objects.Object{
+		"count":  &objects.Int{Value: 5},
+		"price":  &objects.Float{Value: 1337.5},
+		"signal": &objects.String{Value: "buy"},
+		"fills":  &objects.Array{Value: []objects.Object{&objects.Int{Value: 1}, &objects.Float{Value: 0.5}}},
+		"active": tv,
+	}}
+	data, err := json.Marshal(objects.ToInterface(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := decodeState(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := out.(*objects.Map).Value
+	if c, ok := m["count"].(*objects.Int); !ok || c.Value != 5 {
+		t.Errorf("expected int count, received %v", m["count"])
+	}
+	if p, ok := m["price"].(*objects.Float); !ok || p.Value != 1337.5 {
+		t.Errorf("expected float price, received %v", m["price"])
+	}
+	if s, ok := m["signal"].(*objects.String); !ok || s.Value != "buy" {
+		t.Errorf("expected string signal, received %v", m["signal"])
+	}
+	fills, ok := m["fills"].(*objects.Array)
+	if !ok || len(fills.Value) != 2 {
+		t.Fatalf("expected fills array, received %v", m["fills"])
+	}
+	if _, ok := fills.Value[1].(*objects.Float); !ok {
+		t.Errorf("expected float fill, received %v", fills.Value[1])
+	}
+	if m["active"] != tv {
+		t.Errorf("expected true, received %v", m["active"])
+	}
+
+	_, err = decodeState("{")
+	if err == nil {
+		t.Error("expected error decoding invalid state")
+	}
+}
@@ -1,28 +1,41 @@
 package loader
 
 import (
+	"context"
+
 	"github.com/d5/tengo/v2"
 	"github.com/d5/tengo/v2/stdlib"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/modules/gct"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/modules/ta"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
 )
 
 // GetModuleMap returns the module map that includes all modules
 // for the given module names.
 func GetModuleMap() *tengo.ModuleMap {
+	return GetModuleMapWithContext(context.Background())
+}
+
+// GetModuleMapWithContext returns the module map that includes all modules,
+// with exchange actions bound to ctx. GCT module functions not permitted by a
+// permission guard carried by ctx are replaced with functions that deny the
+// call
+func GetModuleMapWithContext(ctx context.Context) *tengo.ModuleMap {
 	modules := tengo.NewModuleMap()
+	guard := permission.FromContext(ctx)
 
+	gctModules := gct.ModulesWithContext(ctx)
 	gctModuleList := gct.AllModuleNames()
 	for _, name := range gctModuleList {
-		if mod := gct.Modules[name]; mod != nil {
-			modules.AddBuiltinModule(name, mod)
+		if mod := gctModules[name]; mod != nil {
+			modules.AddBuiltinModule(name, guardModule(guard, name, mod))
 		}
 	}
 
 	taModuleList := ta.AllModuleNames()
 	for _, name := range taModuleList {
 		if mod := ta.Modules[name]; mod != nil {
-			modules.AddBuiltinModule(name, mod)
+			modules.AddBuiltinModule(name, guardModule(guard, name, mod))
 		}
 	}
 
@@ -38,6 +51,27 @@ func GetModuleMap() *tengo.ModuleMap {
 	return modules
 }
 
+// guardModule returns a copy of mod with each function the guard does not
+// permit replaced by one returning a permission error
+func guardModule(guard *permission.Guard, module string, mod map[string]tengo.Object) map[string]tengo.Object {
+	if guard == nil {
+		return mod
+	}
+	guarded := make(map[string]tengo.Object, len(mod))
+	for name, obj := range mod {
+		fn, ok := obj.(*tengo.UserFunction)
+		if !ok || guard.FunctionAllowed(module, name) {
+			guarded[name] = obj
+			continue
+		}
+		function := name
+		guarded[name] = &tengo.UserFunction{Name: fn.Name, Value: func(...tengo.Object) (tengo.Object, error) {
+			return nil, guard.CheckFunction(module, function)
+		}}
+	}
+	return guarded
+}
+
 // SetDefaultScriptOutput sets the output folder
 func SetDefaultScriptOutput(path string) {
 	gct.OutputDir = path
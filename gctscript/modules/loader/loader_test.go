@@ -1,8 +1,13 @@
 package loader
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
 )
 
 func TestGetModuleMap(t *testing.T) {
@@ -16,3 +21,28 @@ func TestGetModuleMap(t *testing.T) {
 		t.Fatal("expected GetModuleMap() to contain module results instead received 0 value")
 	}
 }
+
+func TestGetModuleMapWithContextGuard(t *testing.T) {
+	g, err := permission.NewGuard("test.gct", &permission.Manifest{Modules: []string{"exchange.orderbook"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := GetModuleMapWithContext(permission.WithGuard(context.Background(), g))
+	mod, ok := x.Get("exchange").(*tengo.BuiltinModule)
+	if !ok {
+		t.Fatal("expected exchange builtin module")
+	}
+	// exchange functions are bound to the context per module map, so a
+	// permitted function is checked by calling it rather than by identity
+	_, err = mod.Attrs["orderbook"].(*tengo.UserFunction).Value()
+	if !errors.Is(err, tengo.ErrWrongNumArguments) {
+		t.Errorf("received: %v but expected: %v", err, tengo.ErrWrongNumArguments)
+	}
+	_, err = mod.Attrs["ordersubmit"].(*tengo.UserFunction).Value()
+	if !errors.Is(err, permission.ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, permission.ErrPermissionDenied)
+	}
+	if _, ok = x.Get("fmt").(*tengo.BuiltinModule); !ok {
+		t.Error("expected stdlib modules to be unrestricted")
+	}
+}
@@ -1,15 +1,19 @@
 package modules
 
 import (
+	"context"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/position"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
@@ -20,12 +24,24 @@ const (
 	ErrParameterWithPositionConvertFailed = "%v at position %v failed conversion"
 )
 
+// Fee types which can be looked up by scripts
+const (
+	FeeTypeTrade            = "trade"
+	FeeTypeOfflineTrade     = "offlinetrade"
+	FeeTypeCryptoDeposit    = "cryptodeposit"
+	FeeTypeCryptoWithdrawal = "cryptowithdrawal"
+)
+
+// FeeTypes are the supported fee types
+var FeeTypes = []string{FeeTypeTrade, FeeTypeOfflineTrade, FeeTypeCryptoDeposit, FeeTypeCryptoWithdrawal}
+
 // Wrapper instance of GCT to use for modules
 var Wrapper GCT
 
 // GCT interface requirements
 type GCT interface {
 	Exchange
+	State
 }
 
 // Exchange interface requirements
@@ -36,16 +52,73 @@ type Exchange interface {
 	Ticker(exch string, pair currency.Pair, item asset.Item) (*ticker.Price, error)
 	Pairs(exch string, enabledOnly bool, item asset.Item) (*currency.Pairs, error)
 	QueryOrder(exch, orderid string, pair currency.Pair, assetType asset.Item) (*order.Detail, error)
-	SubmitOrder(submit *order.Submit) (*order.SubmitResponse, error)
-	CancelOrder(exch, orderid string, pair currency.Pair, item asset.Item) (bool, error)
+	SubmitOrder(ctx context.Context, submit *order.Submit) (*order.SubmitResponse, error)
+	CancelOrder(ctx context.Context, exch, orderid string, pair currency.Pair, item asset.Item) (bool, error)
 	AccountInformation(exch string, assetType asset.Item) (account.Holdings, error)
 	DepositAddress(exch string, currencyCode currency.Code) (string, error)
-	WithdrawalFiatFunds(bankAccountID string, request *withdraw.Request) (out string, err error)
-	WithdrawalCryptoFunds(request *withdraw.Request) (out string, err error)
+	WithdrawalFiatFunds(ctx context.Context, bankAccountID string, request *withdraw.Request) (out string, err error)
+	WithdrawalCryptoFunds(ctx context.Context, request *withdraw.Request) (out string, err error)
 	OHLCV(exch string, pair currency.Pair, item asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error)
+	Positions(exch string, item asset.Item, pair currency.Pair) ([]position.Position, error)
+	ActiveOrders(exch string, request *order.GetOrdersRequest) ([]order.Detail, error)
+	OrderHistory(exch string, request *order.GetOrdersRequest) ([]order.Detail, error)
+	RecentTrades(exch string, pair currency.Pair, item asset.Item) ([]trade.Data, error)
+	ModifyOrder(ctx context.Context, modify *order.Modify) (string, error)
+	TransferAsset(ctx context.Context, exch string, from, to asset.Item, currencyCode currency.Code, amount float64) (string, error)
+	ExecutionLimits(exch string, pair currency.Pair, item asset.Item) (order.MinMaxLevel, error)
+	Fee(exch string, request *FeeRequest) (float64, error)
+	StoredOHLCV(exch string, pair currency.Pair, item asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error)
+	StoredTrades(exch string, pair currency.Pair, item asset.Item, start, end time.Time) ([]trade.Data, error)
+}
+
+// FeeRequest defines a fee lookup, deposit and withdrawal fees are for the
+// base currency of the pair
+type FeeRequest struct {
+	Type    string
+	Pair    currency.Pair
+	IsMaker bool
+	Price   float64
+	Amount  float64
+}
+
+// State interface requirements, values are JSON encoded and scoped to the
+// script name
+type State interface {
+	GetState(script, key string) (value string, found bool, err error)
+	SetState(script, key, value string) error
+	DeleteState(script, key string) error
+	ListState(script string) (map[string]string, error)
+}
+
+// Feeds is implemented by wrappers which provide the exchange event feeds
+// scripts subscribe to
+type Feeds interface {
+	TickerFeed(exch string) (dispatch.Pipe, error)
+	OrderbookFeed(exch string) (dispatch.Pipe, error)
+	TradeFeed(exch string) (dispatch.Pipe, error)
+	OrderFeed(exch string) (dispatch.Pipe, error)
 }
 
 // SetModuleWrapper link the wrapper and interface to use for modules
 func SetModuleWrapper(wrapper GCT) {
 	Wrapper = wrapper
 }
+
+type wrapperKey struct{}
+
+// WithWrapper returns a copy of ctx holding the wrapper modules loaded with
+// ctx use in place of the package Wrapper
+func WithWrapper(ctx context.Context, wrapper GCT) context.Context {
+	return context.WithValue(ctx, wrapperKey{}, wrapper)
+}
+
+// WrapperFromContext returns the wrapper held by ctx, falling back to the
+// package Wrapper when ctx holds none
+func WrapperFromContext(ctx context.Context) GCT {
+	if ctx != nil {
+		if wrapper, ok := ctx.Value(wrapperKey{}).(GCT); ok && wrapper != nil {
+			return wrapper
+		}
+	}
+	return Wrapper
+}
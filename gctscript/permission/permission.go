@@ -0,0 +1,295 @@
+package permission
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Validate checks the manifest entries are well formed
+func (m *Manifest) Validate() error {
+	if m == nil {
+		return errNilManifest
+	}
+	for i := range m.Modules {
+		parts := strings.Split(m.Modules[i], ".")
+		if len(parts) > 2 || parts[0] == "" || (len(parts) == 2 && parts[1] == "") {
+			return fmt.Errorf("%w: %q", errInvalidModule, m.Modules[i])
+		}
+	}
+	for i := range m.Exchanges {
+		if strings.TrimSpace(m.Exchanges[i]) == "" {
+			return fmt.Errorf("%w: %q", errInvalidExchange, m.Exchanges[i])
+		}
+	}
+	for i := range m.Pairs {
+		if _, err := currency.NewPairFromString(m.Pairs[i]); err != nil {
+			return fmt.Errorf("%w %q: %v", errUnsupportedPairEntry, m.Pairs[i], err)
+		}
+	}
+	if m.MaxOrderNotional < 0 {
+		return errInvalidNotional
+	}
+	if m.MaxOrdersPerMinute < 0 {
+		return errInvalidOrderRate
+	}
+	return nil
+}
+
+// NewGuard returns a guard enforcing the manifest for script, onViolation is
+// called with every action denied
+func NewGuard(script string, m *Manifest, onViolation func(error)) (*Guard, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	g := &Guard{
+		script:      script,
+		manifest:    *m,
+		onViolation: onViolation,
+	}
+	for i := range m.Pairs {
+		p, err := currency.NewPairFromString(m.Pairs[i])
+		if err != nil {
+			return nil, err
+		}
+		g.pairs = append(g.pairs, p)
+	}
+	return g, nil
+}
+
+// WithGuard returns a copy of ctx carrying the guard for a script
+func WithGuard(ctx context.Context, g *Guard) context.Context {
+	return context.WithValue(ctx, guardKey{}, g)
+}
+
+// FromContext returns the guard carried by ctx, a nil guard permits every
+// action
+func FromContext(ctx context.Context) *Guard {
+	if ctx == nil {
+		return nil
+	}
+	g, _ := ctx.Value(guardKey{}).(*Guard)
+	return g
+}
+
+// Script returns the name of the script the guard enforces
+func (g *Guard) Script() string {
+	if g == nil {
+		return ""
+	}
+	return g.script
+}
+
+// FunctionAllowed returns whether the manifest permits calling a module
+// function, it does not record a violation
+func (g *Guard) FunctionAllowed(module, function string) bool {
+	if g == nil || len(g.manifest.Modules) == 0 {
+		return true
+	}
+	for i := range g.manifest.Modules {
+		if strings.EqualFold(g.manifest.Modules[i], module) ||
+			strings.EqualFold(g.manifest.Modules[i], module+"."+function) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckFunction returns an error when the manifest does not permit calling a
+// module function
+func (g *Guard) CheckFunction(module, function string) error {
+	if g.FunctionAllowed(module, function) {
+		return nil
+	}
+	return g.deny("function %s.%s is not permitted", module, function)
+}
+
+// CheckOrder returns an error when the manifest does not permit submitting
+// the order. lastPrice is used to value market orders when the manifest
+// limits order notional. Permitted orders count towards the order rate limit
+func (g *Guard) CheckOrder(s *order.Submit, lastPrice func() (float64, error)) error {
+	if g == nil {
+		return nil
+	}
+	if s == nil {
+		return errOrderSubmitNil
+	}
+	if err := g.checkExchange(s.Exchange); err != nil {
+		return err
+	}
+	if err := g.checkPair(s.Pair); err != nil {
+		return err
+	}
+	if g.manifest.MaxOrderNotional > 0 {
+		price := s.Price
+		if s.Type == order.Market || price <= 0 {
+			if lastPrice == nil {
+				return errPriceLookupUnset
+			}
+			var err error
+			price, err = lastPrice()
+			if err != nil {
+				return g.deny("%v: %v", errUnableToPriceMarket, err)
+			}
+			if price <= 0 {
+				return g.deny("%v", errUnableToPriceMarket)
+			}
+		}
+		if notional := price * s.Amount; notional > g.manifest.MaxOrderNotional {
+			return g.deny("order notional %v exceeds max %v", notional, g.manifest.MaxOrderNotional)
+		}
+	}
+
+	return g.checkOrderRate()
+}
+
+// CheckCancel returns an error when the manifest does not permit cancelling
+// orders on the exchange and pair, an empty pair only checks the exchange
+func (g *Guard) CheckCancel(exch string, pair currency.Pair) error {
+	if g == nil {
+		return nil
+	}
+	if err := g.checkExchange(exch); err != nil {
+		return err
+	}
+	if pair.IsEmpty() {
+		return nil
+	}
+	return g.checkPair(pair)
+}
+
+// CheckModify returns an error when the manifest does not permit modifying
+// the order. When the manifest limits order notional, a price or amount left
+// unset is taken from the existing order and the last price is used for
+// orders without a price. Modifications which cannot be valued are denied.
+// Permitted modifications count towards the order rate limit
+func (g *Guard) CheckModify(m *order.Modify, existing func() (*order.Detail, error), lastPrice func() (float64, error)) error {
+	if g == nil {
+		return nil
+	}
+	if m == nil {
+		return errOrderModifyNil
+	}
+	if err := g.checkExchange(m.Exchange); err != nil {
+		return err
+	}
+	if err := g.checkPair(m.Pair); err != nil {
+		return err
+	}
+	if g.manifest.MaxOrderNotional > 0 {
+		price, amount := m.Price, m.Amount
+		if price <= 0 || amount <= 0 {
+			if existing == nil {
+				return errOrderLookupUnset
+			}
+			d, err := existing()
+			if err != nil {
+				return g.deny("%v: %v", errUnableToValueModify, err)
+			}
+			if d == nil {
+				return g.deny("%v", errUnableToValueModify)
+			}
+			if price <= 0 {
+				price = d.Price
+			}
+			if amount <= 0 {
+				amount = d.Amount
+			}
+		}
+		if price <= 0 {
+			if lastPrice == nil {
+				return errPriceLookupUnset
+			}
+			var err error
+			price, err = lastPrice()
+			if err != nil {
+				return g.deny("%v: %v", errUnableToValueModify, err)
+			}
+		}
+		if price <= 0 || amount <= 0 {
+			return g.deny("%v", errUnableToValueModify)
+		}
+		if notional := price * amount; notional > g.manifest.MaxOrderNotional {
+			return g.deny("order notional %v exceeds max %v", notional, g.manifest.MaxOrderNotional)
+		}
+	}
+	return g.checkOrderRate()
+}
+
+// CheckTransfer returns an error when the manifest does not permit moving
+// funds between asset accounts on the exchange
+func (g *Guard) CheckTransfer(exch string) error {
+	if g == nil {
+		return nil
+	}
+	return g.checkExchange(exch)
+}
+
+// CheckWithdrawal returns an error when the manifest does not permit
+// withdrawing funds from the exchange
+func (g *Guard) CheckWithdrawal(exch string) error {
+	if g == nil {
+		return nil
+	}
+	if !g.manifest.AllowWithdrawals {
+		return g.deny("withdrawals are not permitted")
+	}
+	return g.checkExchange(exch)
+}
+
+// checkOrderRate returns an error when the order rate limit has been reached,
+// otherwise the order is counted towards the limit
+func (g *Guard) checkOrderRate() error {
+	if g.manifest.MaxOrdersPerMinute <= 0 {
+		return nil
+	}
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	now := time.Now()
+	cutoff := now.Add(-orderRateWindow)
+	recent := g.orders[:0]
+	for i := range g.orders {
+		if g.orders[i].After(cutoff) {
+			recent = append(recent, g.orders[i])
+		}
+	}
+	g.orders = recent
+	if len(g.orders) >= g.manifest.MaxOrdersPerMinute {
+		return g.deny("order rate limit of %d per minute reached", g.manifest.MaxOrdersPerMinute)
+	}
+	g.orders = append(g.orders, now)
+	return nil
+}
+
+func (g *Guard) checkExchange(exch string) error {
+	if len(g.manifest.Exchanges) == 0 {
+		return nil
+	}
+	for i := range g.manifest.Exchanges {
+		if strings.EqualFold(g.manifest.Exchanges[i], exch) {
+			return nil
+		}
+	}
+	return g.deny("exchange %s is not permitted", exch)
+}
+
+func (g *Guard) checkPair(pair currency.Pair) error {
+	if len(g.pairs) == 0 || g.pairs.Contains(pair, true) {
+		return nil
+	}
+	return g.deny("pair %s is not permitted", pair)
+}
+
+// deny returns a permission error for the script and reports it as a
+// violation
+func (g *Guard) deny(format string, a ...interface{}) error {
+	err := fmt.Errorf("%w: script %s %s", ErrPermissionDenied, g.script, fmt.Sprintf(format, a...))
+	if g.onViolation != nil {
+		g.onViolation(err)
+	}
+	return err
+}
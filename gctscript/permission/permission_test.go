@@ -0,0 +1,302 @@
+package permission
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	var m *Manifest
+	if err := m.Validate(); !errors.Is(err, errNilManifest) {
+		t.Errorf("received: %v but expected: %v", err, errNilManifest)
+	}
+
+	testCases := []struct {
+		name     string
+		manifest Manifest
+		expected error
+	}{
+		{name: "empty", manifest: Manifest{}},
+		{name: "valid", manifest: Manifest{Modules: []string{"exchange", "exchange.ordersubmit"}, Exchanges: []string{"binance"}, Pairs: []string{"BTC-USDT"}, MaxOrderNotional: 100, MaxOrdersPerMinute: 1}},
+		{name: "module", manifest: Manifest{Modules: []string{"exchange."}}, expected: errInvalidModule},
+		{name: "nested module", manifest: Manifest{Modules: []string{"a.b.c"}}, expected: errInvalidModule},
+		{name: "exchange", manifest: Manifest{Exchanges: []string{" "}}, expected: errInvalidExchange},
+		{name: "pair", manifest: Manifest{Pairs: []string{"B"}}, expected: errUnsupportedPairEntry},
+		{name: "notional", manifest: Manifest{MaxOrderNotional: -1}, expected: errInvalidNotional},
+		{name: "rate", manifest: Manifest{MaxOrdersPerMinute: -1}, expected: errInvalidOrderRate},
+	}
+	for i := range testCases {
+		tt := testCases[i]
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.manifest.Validate(); !errors.Is(err, tt.expected) {
+				t.Errorf("received: %v but expected: %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	t.Parallel()
+	if g := FromContext(context.Background()); g != nil {
+		t.Error("expected nil guard")
+	}
+	g, err := NewGuard("test.gct", &Manifest{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if FromContext(WithGuard(context.Background(), g)) != g {
+		t.Error("expected guard from context")
+	}
+}
+
+func TestNilGuard(t *testing.T) {
+	t.Parallel()
+	var g *Guard
+	if !g.FunctionAllowed("exchange", "ordersubmit") {
+		t.Error("nil guard should permit functions")
+	}
+	if err := g.CheckOrder(&order.Submit{}, nil); err != nil {
+		t.Error(err)
+	}
+	if err := g.CheckCancel("binance", currency.Pair{}); err != nil {
+		t.Error(err)
+	}
+	if err := g.CheckWithdrawal("binance"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckFunction(t *testing.T) {
+	t.Parallel()
+	var violations []error
+	g, err := NewGuard("test.gct", &Manifest{Modules: []string{"common", "exchange.orderbook"}}, func(err error) {
+		violations = append(violations, err)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fn := range [][2]string{{"common", "writeascsv"}, {"exchange", "orderbook"}, {"EXCHANGE", "Orderbook"}} {
+		if err = g.CheckFunction(fn[0], fn[1]); err != nil {
+			t.Error(err)
+		}
+	}
+	if err = g.CheckFunction("exchange", "ordersubmit"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	if g.FunctionAllowed("exchange", "withdrawcrypto") {
+		t.Error("expected function to be denied")
+	}
+	if len(violations) != 1 {
+		t.Errorf("expected 1 violation, received %d", len(violations))
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	t.Parallel()
+	var violations int
+	g, err := NewGuard("test.gct", &Manifest{
+		Exchanges:          []string{"Binance"},
+		Pairs:              []string{"BTC-USDT"},
+		MaxOrderNotional:   1000,
+		MaxOrdersPerMinute: 2,
+	}, func(error) { violations++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = g.CheckOrder(nil, nil); !errors.Is(err, errOrderSubmitNil) {
+		t.Errorf("received: %v but expected: %v", err, errOrderSubmitNil)
+	}
+
+	btcusdt := currency.NewPair(currency.BTC, currency.USDT)
+	s := &order.Submit{Exchange: "binance", Pair: btcusdt, AssetType: asset.Spot, Type: order.Limit, Price: 100, Amount: 1}
+	if err = g.CheckOrder(&order.Submit{Exchange: "bitstamp", Pair: btcusdt}, nil); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	if err = g.CheckOrder(&order.Submit{Exchange: "binance", Pair: currency.NewPair(currency.ETH, currency.USDT)}, nil); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	if err = g.CheckOrder(&order.Submit{Exchange: "binance", Pair: btcusdt, Type: order.Limit, Price: 100, Amount: 11}, nil); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+
+	market := &order.Submit{Exchange: "binance", Pair: btcusdt, Type: order.Market, Amount: 1}
+	if err = g.CheckOrder(market, nil); !errors.Is(err, errPriceLookupUnset) {
+		t.Errorf("received: %v but expected: %v", err, errPriceLookupUnset)
+	}
+	if err = g.CheckOrder(market, func() (float64, error) { return 0, errors.New("no ticker") }); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	if err = g.CheckOrder(market, func() (float64, error) { return 2000, nil }); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	if err = g.CheckOrder(market, func() (float64, error) { return 500, nil }); err != nil {
+		t.Error(err)
+	}
+	if err = g.CheckOrder(s, nil); err != nil {
+		t.Error(err)
+	}
+	if err = g.CheckOrder(s, nil); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v, order rate should be limited", err, ErrPermissionDenied)
+	}
+	if violations != 6 {
+		t.Errorf("expected 6 violations, received %d", violations)
+	}
+}
+
+func TestCheckCancel(t *testing.T) {
+	t.Parallel()
+	g, err := NewGuard("test.gct", &Manifest{Exchanges: []string{"binance"}, Pairs: []string{"BTC-USDT"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = g.CheckCancel("binance", currency.Pair{}); err != nil {
+		t.Error(err)
+	}
+	if err = g.CheckCancel("binance", currency.NewPair(currency.BTC, currency.USDT)); err != nil {
+		t.Error(err)
+	}
+	if err = g.CheckCancel("binance", currency.NewPair(currency.ETH, currency.USDT)); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	if err = g.CheckCancel("bitstamp", currency.Pair{}); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+}
+
+func TestCheckModify(t *testing.T) {
+	t.Parallel()
+	g, err := NewGuard("test.gct", &Manifest{Exchanges: []string{"binance"}, Pairs: []string{"BTC-USDT"}, MaxOrderNotional: 100}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing := func() (*order.Detail, error) {
+		return &order.Detail{Price: 50, Amount: 1}, nil
+	}
+	lastPrice := func() (float64, error) { return 60, nil }
+	if err = g.CheckModify(nil, existing, lastPrice); !errors.Is(err, errOrderModifyNil) {
+		t.Errorf("received: %v but expected: %v", err, errOrderModifyNil)
+	}
+	m := &order.Modify{
+		Exchange: "binance",
+		Pair:     currency.NewPair(currency.BTC, currency.USDT),
+		Price:    50,
+		Amount:   1,
+	}
+	if err = g.CheckModify(m, nil, nil); err != nil {
+		t.Error(err)
+	}
+	m.Amount = 3
+	if err = g.CheckModify(m, existing, lastPrice); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+
+	// an amount only modification is valued at the existing order price
+	m.Price = 0
+	if err = g.CheckModify(m, nil, lastPrice); !errors.Is(err, errOrderLookupUnset) {
+		t.Errorf("received: %v but expected: %v", err, errOrderLookupUnset)
+	}
+	if err = g.CheckModify(m, existing, lastPrice); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	m.Amount = 1.5
+	if err = g.CheckModify(m, existing, lastPrice); err != nil {
+		t.Error(err)
+	}
+	// and at the last price when the existing order has no price
+	noPrice := func() (*order.Detail, error) {
+		return &order.Detail{Amount: 1}, nil
+	}
+	m.Amount = 1.8
+	if err = g.CheckModify(m, noPrice, lastPrice); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	if err = g.CheckModify(m, noPrice, nil); !errors.Is(err, errPriceLookupUnset) {
+		t.Errorf("received: %v but expected: %v", err, errPriceLookupUnset)
+	}
+
+	// a price only modification is valued at the existing order amount
+	m.Price, m.Amount = 150, 0
+	if err = g.CheckModify(m, existing, lastPrice); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+
+	// modifications which cannot be valued are denied
+	m.Price, m.Amount = 0, 0
+	failed := func() (*order.Detail, error) { return nil, errors.New("order not found") }
+	if err = g.CheckModify(m, failed, lastPrice); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	if err = g.CheckModify(m, noPrice, func() (float64, error) { return 0, nil }); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+
+	m.Pair = currency.NewPair(currency.ETH, currency.USDT)
+	if err = g.CheckModify(m, existing, lastPrice); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	var nilGuard *Guard
+	if err = nilGuard.CheckModify(m, nil, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckModifyOrderRate(t *testing.T) {
+	t.Parallel()
+	g, err := NewGuard("test.gct", &Manifest{MaxOrdersPerMinute: 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &order.Modify{
+		Exchange: "binance",
+		Pair:     currency.NewPair(currency.BTC, currency.USDT),
+		Amount:   1,
+	}
+	if err = g.CheckOrder(&order.Submit{Exchange: "binance", Pair: m.Pair, Price: 1, Amount: 1}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = g.CheckModify(m, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = g.CheckModify(m, nil, nil); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+}
+
+func TestCheckTransfer(t *testing.T) {
+	t.Parallel()
+	g, err := NewGuard("test.gct", &Manifest{Exchanges: []string{"binance"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = g.CheckTransfer("binance"); err != nil {
+		t.Error(err)
+	}
+	if err = g.CheckTransfer("bitstamp"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+}
+
+func TestCheckWithdrawal(t *testing.T) {
+	t.Parallel()
+	g, err := NewGuard("test.gct", &Manifest{Exchanges: []string{"binance"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = g.CheckWithdrawal("binance"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+	g.manifest.AllowWithdrawals = true
+	if err = g.CheckWithdrawal("binance"); err != nil {
+		t.Error(err)
+	}
+	if err = g.CheckWithdrawal("bitstamp"); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, ErrPermissionDenied)
+	}
+}
@@ -0,0 +1,64 @@
+package permission
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// orderRateWindow is the period MaxOrdersPerMinute is measured over
+const orderRateWindow = time.Minute
+
+var (
+	// ErrPermissionDenied is returned when a script attempts an action its
+	// manifest does not permit
+	ErrPermissionDenied = errors.New("permission denied")
+
+	errInvalidModule        = errors.New("invalid module entry, expected module or module.function")
+	errInvalidExchange      = errors.New("invalid exchange entry")
+	errInvalidNotional      = errors.New("max order notional cannot be negative")
+	errInvalidOrderRate     = errors.New("max orders per minute cannot be negative")
+	errNilManifest          = errors.New("manifest is nil")
+	errOrderSubmitNil       = errors.New("order submit is nil")
+	errOrderModifyNil       = errors.New("order modify is nil")
+	errPriceLookupUnset     = errors.New("price lookup required to check order notional")
+	errOrderLookupUnset     = errors.New("order lookup required to check order notional")
+	errUnableToPriceMarket  = errors.New("unable to determine market order price")
+	errUnableToValueModify  = errors.New("unable to determine modified order value")
+	errUnsupportedPairEntry = errors.New("invalid pair entry")
+)
+
+// Manifest defines what a script is permitted to do, empty lists and zero
+// limits are unrestricted
+type Manifest struct {
+	// Modules lists the GCT modules a script may call, either as a whole
+	// module e.g. "exchange" or a single function e.g. "exchange.ordersubmit"
+	Modules []string `json:"modules,omitempty"`
+	// Exchanges lists the exchanges a script may trade and withdraw on
+	Exchanges []string `json:"exchanges,omitempty"`
+	// Pairs lists the currency pairs a script may trade e.g. "BTC-USDT"
+	Pairs []string `json:"pairs,omitempty"`
+	// MaxOrderNotional is the largest order value permitted in the quote
+	// currency of the pair
+	MaxOrderNotional float64 `json:"max_order_notional,omitempty"`
+	// MaxOrdersPerMinute is the number of orders a script may submit or
+	// modify in any minute
+	MaxOrdersPerMinute int `json:"max_orders_per_minute,omitempty"`
+	// AllowWithdrawals permits the script to withdraw funds
+	AllowWithdrawals bool `json:"allow_withdrawals"`
+}
+
+// Guard enforces a manifest for a single running script
+type Guard struct {
+	script      string
+	manifest    Manifest
+	pairs       currency.Pairs
+	onViolation func(error)
+
+	mtx    sync.Mutex
+	orders []time.Time
+}
+
+type guardKey struct{}
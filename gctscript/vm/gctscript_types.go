@@ -3,6 +3,8 @@ package vm
 import (
 	"errors"
 	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
 )
 
 const (
@@ -18,7 +20,11 @@ type Config struct {
 	MaxVirtualMachines uint8         `json:"max_virtual_machines"`
 	AllowImports       bool          `json:"allow_imports"`
 	AutoLoad           []string      `json:"auto_load"`
-	Verbose            bool          `json:"verbose"`
+	EventQueueSize     int           `json:"event_queue_size"`
+	// Permissions restricts what scripts may do keyed by script name,
+	// overriding any permissions uploaded with a script
+	Permissions map[string]*permission.Manifest `json:"permissions,omitempty"`
+	Verbose     bool                            `json:"verbose"`
 }
 
 // Error interface to meet error requirements
@@ -6,7 +6,9 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/engine/subsystem"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -17,6 +19,12 @@ type GctScriptManager struct {
 	config   *Config
 	started  int32
 	shutdown chan struct{}
+	// wrapper is used by the modules of scripts run by this manager, the
+	// package modules.Wrapper is used when unset
+	wrapper modules.GCT
+	// db records script executions, database.DB is used when unset
+	db  *database.Instance
+	mtx sync.RWMutex
 	// Optional values to override stored config ('nil' if not overridden)
 	MaxVirtualMachines *uint8
 }
@@ -31,6 +39,38 @@ func NewManager(config *Config) (*GctScriptManager, error) {
 	}, nil
 }
 
+// SetWrapper sets the wrapper used by the modules of scripts subsequently
+// loaded by this manager
+func (g *GctScriptManager) SetWrapper(wrapper modules.GCT) {
+	g.mtx.Lock()
+	g.wrapper = wrapper
+	g.mtx.Unlock()
+}
+
+// Wrapper returns the wrapper used by the modules of scripts loaded by this
+// manager, falling back to the package modules.Wrapper
+func (g *GctScriptManager) Wrapper() modules.GCT {
+	g.mtx.RLock()
+	defer g.mtx.RUnlock()
+	if g.wrapper != nil {
+		return g.wrapper
+	}
+	return modules.Wrapper
+}
+
+// SetDatabase sets the database instance script executions are recorded in
+func (g *GctScriptManager) SetDatabase(db *database.Instance) {
+	g.mtx.Lock()
+	g.db = db
+	g.mtx.Unlock()
+}
+
+func (g *GctScriptManager) database() *database.Instance {
+	g.mtx.RLock()
+	defer g.mtx.RUnlock()
+	return g.db
+}
+
 // Started returns if gctscript manager subsystem is started
 func (g *GctScriptManager) Started() bool {
 	return atomic.LoadInt32(&g.started) == 1
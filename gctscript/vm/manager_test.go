@@ -4,8 +4,14 @@ import (
 	"reflect"
 	"sync"
 	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
 )
 
+type testWrapper struct {
+	modules.GCT
+}
+
 func TestNewManager(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -121,3 +127,37 @@ func TestGctScriptManagerGetMaxVirtualMachines(t *testing.T) {
 		})
 	}
 }
+
+func TestGctScriptManagerWrapperIsolation(t *testing.T) {
+	t.Parallel()
+	mgrOne, err := NewManager(&Config{AllowImports: true, MaxVirtualMachines: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mgrOne.Wrapper() != modules.Wrapper {
+		t.Error("manager without a wrapper should fall back to modules.Wrapper")
+	}
+	mgrTwo, err := NewManager(&Config{AllowImports: true, MaxVirtualMachines: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapperOne, wrapperTwo := &testWrapper{}, &testWrapper{}
+	mgrOne.SetWrapper(wrapperOne)
+	mgrTwo.SetWrapper(wrapperTwo)
+	mgrOne.started, mgrTwo.started = 1, 1
+
+	vmOne := mgrOne.NewVM()
+	if vmOne == nil {
+		t.Fatal("expected a virtual machine")
+	}
+	vmTwo := mgrTwo.NewVM()
+	if vmTwo == nil {
+		t.Fatal("expected a virtual machine")
+	}
+	if vmOne.wrapper != wrapperOne {
+		t.Error("virtual machine should use its manager's wrapper")
+	}
+	if vmTwo.wrapper != wrapperTwo {
+		t.Error("virtual machine should use its manager's wrapper")
+	}
+}
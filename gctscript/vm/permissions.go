@@ -0,0 +1,93 @@
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var errInvalidScriptName = errors.New("invalid script name")
+
+// SavePermissions stores the permission manifest uploaded for a script, it is
+// applied whenever the script is loaded unless the config defines permissions
+// for the script
+func SavePermissions(script string, m *permission.Manifest) error {
+	err := m.Validate()
+	if err != nil {
+		return err
+	}
+	path, err := permissionsFile(script)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(path, data)
+}
+
+// LoadPermissions returns the permission manifest uploaded for a script, nil
+// is returned when the script has no manifest
+func LoadPermissions(script string) (*permission.Manifest, error) {
+	path, err := permissionsFile(script)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var m permission.Manifest
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func permissionsFile(script string) (string, error) {
+	name := filepath.Base(script)
+	if script == "" || name == "." || name == ".." || name == string(filepath.Separator) {
+		return "", errInvalidScriptName
+	}
+	if filepath.Ext(name) != common.GctExt {
+		name += common.GctExt
+	}
+	return filepath.Join(ScriptPath, "permissions", name+".json"), nil
+}
+
+// manifest returns the permissions for the loaded script, preferring those
+// defined in config over a manifest uploaded with the script
+func (vm *VM) manifest() (*permission.Manifest, error) {
+	if m, ok := vm.config.Permissions[vm.ShortName()]; ok {
+		return m, nil
+	}
+	return LoadPermissions(vm.ShortName())
+}
+
+// newGuard returns the guard enforcing the scripts permissions, scripts
+// without a manifest are unrestricted
+func (vm *VM) newGuard() (*permission.Guard, error) {
+	m, err := vm.manifest()
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return permission.NewGuard(vm.ShortName(), m, vm.violation)
+}
+
+// violation records an action denied by the scripts permissions
+func (vm *VM) violation(err error) {
+	log.Warnln(log.GCTScriptMgr, err)
+	vm.event(StatusDenied, TypePermission)
+}
@@ -0,0 +1,97 @@
+package vm
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
+)
+
+var testScriptPermissions = filepath.Join("..", "..", "testdata", "gctscript", "permissions.gct")
+
+func TestSavePermissions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gct-permissions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	oldPath := ScriptPath
+	ScriptPath = dir
+	defer func() { ScriptPath = oldPath }()
+
+	err = SavePermissions("", &permission.Manifest{})
+	if !errors.Is(err, errInvalidScriptName) {
+		t.Errorf("received: %v but expected: %v", err, errInvalidScriptName)
+	}
+	err = SavePermissions("test", &permission.Manifest{MaxOrdersPerMinute: -1})
+	if err == nil {
+		t.Error("expected invalid manifest error")
+	}
+
+	m, err := LoadPermissions("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m != nil {
+		t.Error("expected no manifest")
+	}
+	err = SavePermissions("test", &permission.Manifest{Exchanges: []string{"binance"}, AllowWithdrawals: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err = LoadPermissions("../test.gct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m == nil || len(m.Exchanges) != 1 || m.Exchanges[0] != "binance" || !m.AllowWithdrawals {
+		t.Errorf("unexpected manifest %+v", m)
+	}
+}
+
+func TestVMPermissions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gct-permissions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	oldPath := ScriptPath
+	ScriptPath = dir
+	defer func() { ScriptPath = oldPath }()
+
+	manager := GctScriptManager{
+		config:  configHelper(true, true, maxTestVirtualMachines),
+		started: 1,
+	}
+	err = SavePermissions("permissions", &permission.Manifest{Modules: []string{"exchange.orderbook"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testVM := manager.New()
+	err = testVM.Load(testScriptPermissions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testVM.guard == nil {
+		t.Fatal("expected uploaded permissions to be enforced")
+	}
+	err = testVM.Compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testVM.Run()
+	if !errors.Is(err, permission.ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, permission.ErrPermissionDenied)
+	}
+
+	manager.config.Permissions = map[string]*permission.Manifest{
+		"permissions.gct": {MaxOrdersPerMinute: -1},
+	}
+	testVM = manager.New()
+	err = testVM.Load(testScriptPermissions)
+	if err == nil {
+		t.Error("expected invalid config permissions to prevent loading")
+	}
+}
@@ -14,8 +14,13 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
 	scriptevent "github.com/thrasher-corp/gocryptotrader/database/repository/script"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules/gct"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/modules/loader"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/gct/state"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/volatiletech/null"
@@ -47,14 +52,18 @@ func (g *GctScriptManager) NewVM() (vm *VM) {
 		ID:         newUUID,
 		Script:     pool.Get().(*tengo.Script),
 		config:     g.config,
+		wrapper:    g.Wrapper(),
+		db:         g.database(),
 		unregister: func() error { return g.RemoveVM(newUUID) },
 	}
 	return
 }
 
-// SetDefaultScriptOutput sets default output file for scripts
+// SetDefaultScriptOutput sets default output file for scripts and the
+// directory state is saved to when the database is disabled
 func SetDefaultScriptOutput() {
 	loader.SetDefaultScriptOutput(filepath.Join(ScriptPath, "output"))
+	state.Path = filepath.Join(ScriptPath, "state")
 }
 
 // Load parses and creates a new instance of tengo script vm
@@ -82,30 +91,77 @@ func (vm *VM) Load(file string) error {
 
 	vm.File = file
 	vm.Path = filepath.Dir(file)
-	vm.Script = tengo.NewScript(code)
-	scriptctx := vm.ShortName() + "-" + vm.ID.String()
-	err = vm.Script.Add("ctx", scriptctx)
+	vm.source = code
+	vm.guard, err = vm.newGuard()
+	if err != nil {
+		return &Error{
+			Action: "Load: permissions",
+			Script: file,
+			Cause:  err,
+		}
+	}
+	vm.Script, err = vm.newScript(code)
 	if err != nil {
 		return err
 	}
-
-	vm.Script.SetImports(loader.GetModuleMap())
 	vm.Hash = vm.getHash()
 
-	if vm.config.AllowImports {
-		if vm.config.Verbose {
-			log.Debugf(log.GCTScriptMgr, "File imports enabled for vm: %v", vm.ID)
-		}
-		vm.Script.EnableFileImport(true)
+	if vm.config.AllowImports && vm.config.Verbose {
+		log.Debugf(log.GCTScriptMgr, "File imports enabled for vm: %v", vm.ID)
 	}
 	vm.event(StatusSuccess, TypeLoad)
 	return nil
 }
 
-// Compile compiles to byte code loaded copy of vm script
+// newScript creates a tengo script with the gct variables and modules set
+func (vm *VM) newScript(code []byte) (*tengo.Script, error) {
+	s := tengo.NewScript(code)
+	scriptctx := vm.ShortName() + "-" + vm.ID.String()
+	err := s.Add("ctx", scriptctx)
+	if err != nil {
+		return nil, err
+	}
+	err = s.Add(eventVariable, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := audit.WithActor(context.Background(), audit.Actor{Type: audit.ActorScript, ID: scriptctx})
+	ctx = gct.WithScriptName(ctx, vm.ShortName())
+	if vm.wrapper != nil {
+		ctx = modules.WithWrapper(ctx, vm.wrapper)
+	}
+	if vm.guard != nil {
+		ctx = permission.WithGuard(ctx, vm.guard)
+	}
+	s.SetImports(loader.GetModuleMapWithContext(ctx))
+	if vm.config.AllowImports {
+		s.EnableFileImport(true)
+	}
+	return s, nil
+}
+
+// Compile compiles to byte code loaded copy of vm script, scripts defining
+// event handlers are recompiled with the code required to route events
 func (vm *VM) Compile() (err error) {
 	vm.Compiled = new(tengo.Compiled)
 	vm.Compiled, err = vm.Script.Compile()
+	if err != nil {
+		return
+	}
+	if vm.source == nil {
+		return
+	}
+	vm.handlers, err = definedHandlers(vm.source)
+	if err != nil || len(vm.handlers) == 0 {
+		return
+	}
+	code := append(append([]byte{}, vm.source...), eventFooter(vm.handlers)...)
+	vm.Script, err = vm.newScript(code)
+	if err != nil {
+		return
+	}
+	vm.Compiled, err = vm.Script.Compile()
 	return
 }
 
@@ -176,6 +232,20 @@ func (vm *VM) CompileAndRun() {
 		}
 		return
 	}
+
+	subscribed, err := vm.startEvents()
+	if err != nil {
+		log.Error(log.GCTScriptMgr, Error{
+			Action: "CompileAndRun: subscriptions",
+			Script: vm.ShortName(),
+			Cause:  err,
+		})
+		err = vm.Shutdown()
+		if err != nil {
+			log.Error(log.GCTScriptMgr, err)
+		}
+		return
+	}
 	if vm.Compiled.Get("timer").String() != "" {
 		vm.T, err = time.ParseDuration(vm.Compiled.Get("timer").String())
 		if err != nil {
@@ -195,7 +265,7 @@ func (vm *VM) CompileAndRun() {
 			return
 		}
 		vm.runner()
-	} else {
+	} else if !subscribed {
 		err = vm.Shutdown()
 		if err != nil {
 			log.Error(log.GCTScriptMgr, err)
@@ -253,7 +323,7 @@ func (vm *VM) event(status, executionType string) {
 		}
 		data.SetValid(scriptData)
 	}
-	scriptevent.Event(vm.getHash(), vm.ShortName(), vm.Path, data, executionType, status, time.Now())
+	scriptevent.New(vm.db).Event(vm.getHash(), vm.ShortName(), vm.Path, data, executionType, status, time.Now())
 }
 
 func (vm *VM) scriptData() ([]byte, error) {
@@ -0,0 +1,466 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/d5/tengo/v2"
+	"github.com/d5/tengo/v2/parser"
+	"github.com/d5/tengo/v2/token"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var (
+	errSubscriptionsInvalid  = errors.New("subscriptions must be an array of maps")
+	errSubscriptionExchange  = errors.New("subscription exchange not set")
+	errSubscriptionEvent     = errors.New("unsupported subscription event")
+	errSubscriptionNoHandler = errors.New("no handler defined for subscription event")
+	errUnexpectedPayload     = errors.New("unexpected event payload")
+	errEventFeedsUnsupported = errors.New("wrapper does not provide exchange event feeds")
+)
+
+// definedHandlers returns the event types which a script declares top level
+// handlers for
+func definedHandlers(code []byte) ([]string, error) {
+	fileSet := parser.NewFileSet()
+	srcFile := fileSet.AddFile("(main)", -1, len(code))
+	file, err := parser.NewParser(srcFile, code, nil).ParseFile()
+	if err != nil {
+		return nil, err
+	}
+	declared := make(map[string]bool)
+	for i := range file.Stmts {
+		assign, ok := file.Stmts[i].(*parser.AssignStmt)
+		if !ok || assign.Token != token.Define {
+			continue
+		}
+		for j := range assign.LHS {
+			if ident, ok := assign.LHS[j].(*parser.Ident); ok {
+				declared[ident.Name] = true
+			}
+		}
+	}
+	var events []string
+	for i := range supportedEvents {
+		if declared[eventHandlers[supportedEvents[i]]] {
+			events = append(events, supportedEvents[i])
+		}
+	}
+	return events, nil
+}
+
+// eventFooter generates the code appended to a script which routes the
+// current event to its handler
+func eventFooter(events []string) string {
+	var b strings.Builder
+	b.WriteString("\nif " + eventVariable + " != undefined {\n")
+	for i := range events {
+		fmt.Fprintf(&b, "\tif %s.type == %q {\n\t\t%s(%s.data)\n\t}\n",
+			eventVariable,
+			events[i],
+			eventHandlers[events[i]],
+			eventVariable)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// parseSubscriptions converts the scripts subscriptions variable into event
+// subscriptions, events default to every handler the script defines
+func parseSubscriptions(v interface{}, handlers []string) ([]subscription, error) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, errSubscriptionsInvalid
+	}
+	subs := make([]subscription, 0, len(list))
+	for i := range list {
+		m, ok := list[i].(map[string]interface{})
+		if !ok {
+			return nil, errSubscriptionsInvalid
+		}
+		var s subscription
+		s.exchange, _ = m["exchange"].(string)
+		if s.exchange == "" {
+			return nil, fmt.Errorf("subscription %d: %w", i, errSubscriptionExchange)
+		}
+		if p, _ := m["pair"].(string); p != "" {
+			var err error
+			s.pair, err = currency.NewPairFromString(p)
+			if err != nil {
+				return nil, fmt.Errorf("subscription %d: %w", i, err)
+			}
+		}
+		if a, _ := m["asset"].(string); a != "" {
+			var err error
+			s.asset, err = asset.New(a)
+			if err != nil {
+				return nil, fmt.Errorf("subscription %d: %w", i, err)
+			}
+		}
+		events, _ := m["events"].([]interface{})
+		if len(events) == 0 {
+			s.events = handlers
+		}
+		for j := range events {
+			e, _ := events[j].(string)
+			e = strings.ToLower(e)
+			if _, ok := eventHandlers[e]; !ok {
+				return nil, fmt.Errorf("subscription %d: %w %v", i, errSubscriptionEvent, events[j])
+			}
+			if !common.StringDataCompare(handlers, e) {
+				return nil, fmt.Errorf("subscription %d: %w %s, %s is not defined",
+					i, errSubscriptionNoHandler, e, eventHandlers[e])
+			}
+			s.events = append(s.events, e)
+		}
+		if len(s.events) == 0 {
+			return nil, fmt.Errorf("subscription %d: %w", i, errSubscriptionNoHandler)
+		}
+		subs = append(subs, s)
+	}
+	return subs, nil
+}
+
+// matches returns whether an event for an exchange, pair and asset falls
+// within the subscription, unset pairs and assets match everything
+func (s *subscription) matches(exch string, p currency.Pair, a asset.Item) bool {
+	return strings.EqualFold(s.exchange, exch) &&
+		(s.pair.IsEmpty() || s.pair.Equal(p)) &&
+		(s.asset == "" || s.asset == a)
+}
+
+// startEvents subscribes to the feeds required by the scripts subscriptions
+// and starts processing events, returning false if the script has none
+func (vm *VM) startEvents() (bool, error) {
+	if !vm.Compiled.IsDefined(subscriptionsVariable) {
+		return false, nil
+	}
+	subs, err := parseSubscriptions(vm.Compiled.Get(subscriptionsVariable).Value(), vm.handlers)
+	if err != nil {
+		return false, err
+	}
+	feeds := make(map[feedKey][]subscription)
+	for i := range subs {
+		for j := range subs[i].events {
+			k := feedKey{
+				exchange: strings.ToLower(subs[i].exchange),
+				event:    subs[i].events[j],
+			}
+			feeds[k] = append(feeds[k], subs[i])
+		}
+	}
+	if len(feeds) == 0 {
+		return false, nil
+	}
+	source, ok := vm.wrapper.(modules.Feeds)
+	if !ok {
+		return false, errEventFeedsUnsupported
+	}
+
+	size := vm.config.EventQueueSize
+	if size <= 0 {
+		size = DefaultEventQueueSize
+	}
+	vm.events = make(chan *scriptEvent, size)
+	vm.S = make(chan struct{}, 1)
+	for k, s := range feeds {
+		go vm.consume(source, k, s)
+	}
+	go vm.processEvents()
+	return true, nil
+}
+
+// subscribeToFeed returns a dispatch pipe for an exchanges event feed provided
+// by the virtual machines wrapper
+func subscribeToFeed(source modules.Feeds, k feedKey) (dispatch.Pipe, error) {
+	switch k.event {
+	case EventTicker:
+		return source.TickerFeed(k.exchange)
+	case EventOrderbook:
+		return source.OrderbookFeed(k.exchange)
+	case EventTrade:
+		return source.TradeFeed(k.exchange)
+	case EventOrderUpdate:
+		return source.OrderFeed(k.exchange)
+	}
+	return dispatch.Pipe{}, fmt.Errorf("%w %s", errSubscriptionEvent, k.event)
+}
+
+// consume drains an exchange feed, passing matching events to the queue.
+// Ticker and orderbook feeds only exist once an exchange has pushed data so
+// subscribing is retried until the virtual machine is shutdown.
+func (vm *VM) consume(source modules.Feeds, k feedKey, subs []subscription) {
+	var pipe dispatch.Pipe
+	var err error
+	for attempt := 0; ; attempt++ {
+		pipe, err = subscribeToFeed(source, k)
+		if err == nil {
+			break
+		}
+		if attempt == 0 {
+			log.Warnf(log.GCTScriptMgr,
+				"Script %s ID: %v unable to subscribe to %s %s events, retrying: %v",
+				vm.ShortName(), vm.ID, k.exchange, k.event, err)
+		}
+		select {
+		case <-vm.S:
+			return
+		case <-time.After(subscribeRetryDelay):
+		}
+	}
+	defer func() {
+		if err := pipe.Release(); err != nil {
+			log.Error(log.GCTScriptMgr, err)
+		}
+	}()
+
+	for {
+		select {
+		case <-vm.S:
+			return
+		case data, ok := <-pipe.C:
+			if !ok {
+				return
+			}
+			evt, err := toScriptEvent(k.event, data, subs)
+			if err != nil {
+				log.Error(log.GCTScriptMgr, err)
+				continue
+			}
+			if evt != nil {
+				vm.enqueue(evt)
+			}
+		}
+	}
+}
+
+// enqueue adds an event to the queue without blocking the feed, dropping the
+// oldest queued event when a script cannot keep up
+func (vm *VM) enqueue(evt *scriptEvent) {
+	for {
+		select {
+		case vm.events <- evt:
+			return
+		default:
+		}
+		select {
+		case <-vm.events:
+			atomic.AddUint64(&vm.droppedEvents, 1)
+			if atomic.CompareAndSwapInt32(&vm.dropping, 0, 1) {
+				log.Warnf(log.GCTScriptMgr,
+					"Script %s ID: %v cannot keep up with events, dropping oldest queued events",
+					vm.ShortName(), vm.ID)
+			}
+		default:
+		}
+	}
+}
+
+// DroppedEvents returns the amount of events dropped due to a full queue
+func (vm *VM) DroppedEvents() uint64 {
+	return atomic.LoadUint64(&vm.droppedEvents)
+}
+
+// processEvents executes the script for each queued event until the virtual
+// machine is shutdown or the script fails
+func (vm *VM) processEvents() {
+	for {
+		select {
+		case <-vm.S:
+			return
+		case evt := <-vm.events:
+			if len(vm.events) == 0 {
+				atomic.StoreInt32(&vm.dropping, 0)
+			}
+			err := vm.runEvent(evt)
+			if err != nil {
+				log.Error(log.GCTScriptMgr, err)
+				err = vm.Shutdown()
+				if err != nil {
+					log.Error(log.GCTScriptMgr, err)
+				}
+				return
+			}
+		}
+	}
+}
+
+// runEvent executes the compiled script with the event set, successful runs
+// are not recorded as script events due to their frequency
+func (vm *VM) runEvent(evt *scriptEvent) error {
+	vm.runMtx.Lock()
+	defer vm.runMtx.Unlock()
+
+	err := vm.Compiled.Set(eventVariable, &tengo.ImmutableMap{
+		Value: map[string]tengo.Object{
+			"type": &tengo.String{Value: evt.event},
+			"data": evt.data,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := vm.Compiled.Set(eventVariable, nil); err != nil {
+			log.Error(log.GCTScriptMgr, err)
+		}
+	}()
+
+	if vm.ctx == nil {
+		vm.ctx = context.Background()
+	}
+	ct, cancel := context.WithTimeout(vm.ctx, vm.config.ScriptTimeout)
+	defer cancel()
+
+	err = vm.Compiled.RunContext(ct)
+	if err != nil {
+		vm.event(StatusFailure, TypeExecute)
+		return Error{
+			Action: "RunEvent: " + evt.event,
+			Script: vm.ShortName(),
+			Cause:  err,
+		}
+	}
+	return nil
+}
+
+// RunEvent runs the scripts handler for an event with the supplied ticker,
+// orderbook, trade or order payload, bypassing subscriptions so event driven
+// scripts can be exercised offline
+func (vm *VM) RunEvent(event string, data interface{}) error {
+	if vm == nil || vm.Compiled == nil {
+		return ErrNoVMLoaded
+	}
+	if !common.StringDataCompare(vm.handlers, event) {
+		return fmt.Errorf("%w %s, %s is not defined", errSubscriptionNoHandler, event, eventHandlers[event])
+	}
+	_, _, _, obj, err := eventObject(event, data)
+	if err != nil {
+		return err
+	}
+	return vm.runEvent(&scriptEvent{event: event, data: obj})
+}
+
+// toScriptEvent converts a dispatch payload into a script event, returning
+// nil if it does not match any subscription
+func toScriptEvent(event string, data interface{}, subs []subscription) (*scriptEvent, error) {
+	payload, ok := data.(*interface{})
+	if !ok || payload == nil {
+		return nil, fmt.Errorf("%w %T for %s", errUnexpectedPayload, data, event)
+	}
+	exch, pair, a, obj, err := eventObject(event, *payload)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		if subs[i].matches(exch, pair, a) {
+			return &scriptEvent{event: event, data: obj}, nil
+		}
+	}
+	return nil, nil
+}
+
+// eventObject converts an event payload to the object passed to its handler
+// along with the exchange, pair and asset it relates to
+func eventObject(event string, payload interface{}) (exch string, pair currency.Pair, a asset.Item, obj tengo.Object, err error) {
+	switch p := payload.(type) {
+	case ticker.Price:
+		return p.ExchangeName, p.Pair, p.AssetType, tickerToObject(&p), nil
+	case orderbook.Base:
+		return p.Exchange, p.Pair, p.Asset, orderbookToObject(&p), nil
+	case trade.Data:
+		return p.Exchange, p.CurrencyPair, p.AssetType, tradeToObject(&p), nil
+	case order.Detail:
+		return p.Exchange, p.Pair, p.AssetType, orderToObject(&p), nil
+	}
+	return "", currency.Pair{}, "", nil, fmt.Errorf("%w %T for %s", errUnexpectedPayload, payload, event)
+}
+
+func tickerToObject(tx *ticker.Price) tengo.Object {
+	data := make(map[string]tengo.Object, 14)
+	data["exchange"] = &tengo.String{Value: tx.ExchangeName}
+	data["last"] = &tengo.Float{Value: tx.Last}
+	data["High"] = &tengo.Float{Value: tx.High}
+	data["Low"] = &tengo.Float{Value: tx.Low}
+	data["bid"] = &tengo.Float{Value: tx.Bid}
+	data["ask"] = &tengo.Float{Value: tx.Ask}
+	data["volume"] = &tengo.Float{Value: tx.Volume}
+	data["quotevolume"] = &tengo.Float{Value: tx.QuoteVolume}
+	data["priceath"] = &tengo.Float{Value: tx.PriceATH}
+	data["open"] = &tengo.Float{Value: tx.Open}
+	data["close"] = &tengo.Float{Value: tx.Close}
+	data["pair"] = &tengo.String{Value: tx.Pair.String()}
+	data["asset"] = &tengo.String{Value: tx.AssetType.String()}
+	data["updated"] = &tengo.Time{Value: tx.LastUpdated}
+	return &tengo.Map{Value: data}
+}
+
+func orderbookToObject(ob *orderbook.Base) tengo.Object {
+	var asks, bids tengo.Array
+	for x := range ob.Asks {
+		temp := make(map[string]tengo.Object, 2)
+		temp["amount"] = &tengo.Float{Value: ob.Asks[x].Amount}
+		temp["price"] = &tengo.Float{Value: ob.Asks[x].Price}
+		asks.Value = append(asks.Value, &tengo.Map{Value: temp})
+	}
+	for x := range ob.Bids {
+		temp := make(map[string]tengo.Object, 2)
+		temp["amount"] = &tengo.Float{Value: ob.Bids[x].Amount}
+		temp["price"] = &tengo.Float{Value: ob.Bids[x].Price}
+		bids.Value = append(bids.Value, &tengo.Map{Value: temp})
+	}
+	data := make(map[string]tengo.Object, 6)
+	data["exchange"] = &tengo.String{Value: ob.Exchange}
+	data["pair"] = &tengo.String{Value: ob.Pair.String()}
+	data["asks"] = &asks
+	data["bids"] = &bids
+	data["asset"] = &tengo.String{Value: ob.Asset.String()}
+	data["updated"] = &tengo.Time{Value: ob.LastUpdated}
+	return &tengo.Map{Value: data}
+}
+
+func tradeToObject(t *trade.Data) tengo.Object {
+	data := make(map[string]tengo.Object, 8)
+	data["exchange"] = &tengo.String{Value: t.Exchange}
+	data["id"] = &tengo.String{Value: t.TID}
+	data["pair"] = &tengo.String{Value: t.CurrencyPair.String()}
+	data["asset"] = &tengo.String{Value: t.AssetType.String()}
+	data["side"] = &tengo.String{Value: t.Side.String()}
+	data["price"] = &tengo.Float{Value: t.Price}
+	data["amount"] = &tengo.Float{Value: t.Amount}
+	data["timestamp"] = &tengo.Time{Value: t.Timestamp}
+	return &tengo.Map{Value: data}
+}
+
+func orderToObject(d *order.Detail) tengo.Object {
+	data := make(map[string]tengo.Object, 15)
+	data["exchange"] = &tengo.String{Value: d.Exchange}
+	data["id"] = &tengo.String{Value: d.ID}
+	data["clientorderid"] = &tengo.String{Value: d.ClientOrderID}
+	data["currencypair"] = &tengo.String{Value: d.Pair.String()}
+	data["asset"] = &tengo.String{Value: d.AssetType.String()}
+	data["price"] = &tengo.Float{Value: d.Price}
+	data["amount"] = &tengo.Float{Value: d.Amount}
+	data["amountexecuted"] = &tengo.Float{Value: d.ExecutedAmount}
+	data["amountremaining"] = &tengo.Float{Value: d.RemainingAmount}
+	data["fee"] = &tengo.Float{Value: d.Fee}
+	data["side"] = &tengo.String{Value: d.Side.String()}
+	data["type"] = &tengo.String{Value: d.Type.String()}
+	data["status"] = &tengo.String{Value: d.Status.String()}
+	data["date"] = &tengo.Time{Value: d.Date}
+	data["updated"] = &tengo.Time{Value: d.LastUpdated}
+	return &tengo.Map{Value: data}
+}
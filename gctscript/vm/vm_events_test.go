@@ -0,0 +1,363 @@
+package vm
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+)
+
+var testScriptEvents = filepath.Join("..", "..", "testdata", "gctscript", "events.gct")
+
+// testFeedWrapper provides the package level exchange event feeds to scripts
+type testFeedWrapper struct {
+	modules.GCT
+}
+
+func (testFeedWrapper) TickerFeed(exch string) (dispatch.Pipe, error) {
+	return ticker.SubscribeToExchangeTickers(exch)
+}
+
+func (testFeedWrapper) OrderbookFeed(exch string) (dispatch.Pipe, error) {
+	return orderbook.SubscribeToExchangeOrderbooks(exch)
+}
+
+func (testFeedWrapper) TradeFeed(exch string) (dispatch.Pipe, error) {
+	return trade.SubscribeToExchangeTrades(exch)
+}
+
+func (testFeedWrapper) OrderFeed(exch string) (dispatch.Pipe, error) {
+	return order.SubscribeToExchangeOrders(exch)
+}
+
+func TestDefinedHandlers(t *testing.T) {
+	t.Parallel()
+	handlers, err := definedHandlers([]byte(`on_ticker := func(t) {}
+on_trade := 1
+x := func() {
+	on_orderbook := func(ob) {}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(handlers) != 2 || handlers[0] != EventTicker || handlers[1] != EventTrade {
+		t.Errorf("expected only top level ticker and trade handlers, received %v", handlers)
+	}
+	_, err = definedHandlers([]byte("on_ticker := "))
+	if err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestEventFooter(t *testing.T) {
+	t.Parallel()
+	footer := eventFooter([]string{EventTicker, EventOrderUpdate})
+	if !strings.Contains(footer, "on_ticker(gct_event.data)") ||
+		!strings.Contains(footer, "on_order_update(gct_event.data)") {
+		t.Errorf("footer missing handler calls: %s", footer)
+	}
+	if strings.Contains(footer, "on_trade") {
+		t.Errorf("footer should not call undefined handlers: %s", footer)
+	}
+}
+
+func TestParseSubscriptions(t *testing.T) {
+	t.Parallel()
+	handlers := []string{EventTrade, EventOrderUpdate}
+	_, err := parseSubscriptions("binance", handlers)
+	if !errors.Is(err, errSubscriptionsInvalid) {
+		t.Errorf("received: %v but expected: %v", err, errSubscriptionsInvalid)
+	}
+
+	_, err = parseSubscriptions([]interface{}{map[string]interface{}{}}, handlers)
+	if !errors.Is(err, errSubscriptionExchange) {
+		t.Errorf("received: %v but expected: %v", err, errSubscriptionExchange)
+	}
+
+	_, err = parseSubscriptions([]interface{}{map[string]interface{}{
+		"exchange": "binance",
+		"events":   []interface{}{"candles"},
+	}}, handlers)
+	if !errors.Is(err, errSubscriptionEvent) {
+		t.Errorf("received: %v but expected: %v", err, errSubscriptionEvent)
+	}
+
+	_, err = parseSubscriptions([]interface{}{map[string]interface{}{
+		"exchange": "binance",
+		"events":   []interface{}{"ticker"},
+	}}, handlers)
+	if !errors.Is(err, errSubscriptionNoHandler) {
+		t.Errorf("received: %v but expected: %v", err, errSubscriptionNoHandler)
+	}
+
+	_, err = parseSubscriptions([]interface{}{map[string]interface{}{
+		"exchange": "binance",
+	}}, nil)
+	if !errors.Is(err, errSubscriptionNoHandler) {
+		t.Errorf("received: %v but expected: %v", err, errSubscriptionNoHandler)
+	}
+
+	_, err = parseSubscriptions([]interface{}{map[string]interface{}{
+		"exchange": "binance",
+		"asset":    "notanasset",
+	}}, handlers)
+	if err == nil {
+		t.Error("expected error for invalid asset")
+	}
+
+	subs, err := parseSubscriptions([]interface{}{map[string]interface{}{
+		"exchange": "binance",
+		"pair":     "BTC-USDT",
+		"asset":    "spot",
+	}}, handlers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 || len(subs[0].events) != 2 {
+		t.Fatalf("expected events to default to defined handlers, received %+v", subs)
+	}
+	if subs[0].pair.String() != "BTC-USDT" || subs[0].asset != asset.Spot {
+		t.Errorf("unexpected subscription %+v", subs[0])
+	}
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPair(currency.BTC, currency.USD)
+	s := subscription{exchange: "Binance"}
+	if !s.matches("binance", p, asset.Futures) {
+		t.Error("expected unset pair and asset to match")
+	}
+	s.pair = p
+	s.asset = asset.Spot
+	if s.matches("binance", p, asset.Futures) {
+		t.Error("expected asset mismatch")
+	}
+	if s.matches("binance", currency.NewPair(currency.ETH, currency.USD), asset.Spot) {
+		t.Error("expected pair mismatch")
+	}
+	if !s.matches("BINANCE", currency.NewPairWithDelimiter("btc", "usd", "_"), asset.Spot) {
+		t.Error("expected match")
+	}
+}
+
+func TestToScriptEvent(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPair(currency.BTC, currency.USD)
+	subs := []subscription{{exchange: "vmtest", pair: p}}
+
+	_, err := toScriptEvent(EventTicker, ticker.Price{}, subs)
+	if !errors.Is(err, errUnexpectedPayload) {
+		t.Errorf("received: %v but expected: %v", err, errUnexpectedPayload)
+	}
+
+	var payload interface{} = ticker.Price{ExchangeName: "vmtest", Pair: p, AssetType: asset.Spot, Last: 1337}
+	evt, err := toScriptEvent(EventTicker, &payload, subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evt == nil || evt.event != EventTicker {
+		t.Fatalf("unexpected event %+v", evt)
+	}
+	m, ok := evt.data.(*tengo.Map)
+	if !ok || m.Value["last"].(*tengo.Float).Value != 1337 {
+		t.Errorf("unexpected event data %v", evt.data)
+	}
+
+	payload = trade.Data{Exchange: "vmtest", CurrencyPair: currency.NewPair(currency.ETH, currency.USD)}
+	evt, err = toScriptEvent(EventTrade, &payload, subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evt != nil {
+		t.Error("expected unsubscribed pair to be filtered")
+	}
+}
+
+func TestEnqueue(t *testing.T) {
+	t.Parallel()
+	testVM := &VM{
+		File:   "enqueue.gct",
+		events: make(chan *scriptEvent, 2),
+	}
+	for i := 0; i < 5; i++ {
+		testVM.enqueue(&scriptEvent{event: EventTrade, data: &tengo.Int{Value: int64(i)}})
+	}
+	if testVM.DroppedEvents() != 3 {
+		t.Errorf("expected 3 dropped events, received %v", testVM.DroppedEvents())
+	}
+	evt := <-testVM.events
+	if evt.data.(*tengo.Int).Value != 3 {
+		t.Errorf("expected oldest events to be dropped, received %v", evt.data)
+	}
+}
+
+func TestRunEvent(t *testing.T) {
+	manager := GctScriptManager{
+		config:  configHelper(true, true, maxTestVirtualMachines),
+		started: 1,
+	}
+	testVM := manager.New()
+	err := testVM.Load(testScriptEvents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testVM.Compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	handlers := testVM.handlers
+	if len(handlers) != 2 {
+		t.Fatalf("expected trade and order update handlers, received %v", handlers)
+	}
+	err = testVM.RunCtx()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := currency.NewPair(currency.BTC, currency.USD)
+	var payload interface{} = trade.Data{
+		Exchange:     "vmtest",
+		CurrencyPair: p,
+		AssetType:    asset.Spot,
+		Price:        1337,
+		Amount:       1,
+		Timestamp:    time.Now(),
+	}
+	subs, err := parseSubscriptions(testVM.Compiled.Get(subscriptionsVariable).Value(), handlers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evt, err := toScriptEvent(EventTrade, &payload, subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testVM.runEvent(evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := testVM.Compiled.Get("last_price").Float(); v != 1337 {
+		t.Errorf("expected on_trade to be called, received %v", v)
+	}
+	if !testVM.Compiled.Get(eventVariable).IsUndefined() {
+		t.Error("expected event to be cleared after run")
+	}
+
+	payload = order.Detail{Exchange: "vmtest", Status: order.Filled}
+	evt, err = toScriptEvent(EventOrderUpdate, &payload, subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testVM.runEvent(evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := testVM.Compiled.Get("last_status").String(); v != order.Filled.String() {
+		t.Errorf("expected on_order_update to be called, received %v", v)
+	}
+
+	err = testVM.RunEvent(EventTrade, trade.Data{Exchange: "offline", CurrencyPair: p, Price: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := testVM.Compiled.Get("last_price").Float(); v != 42 {
+		t.Errorf("expected on_trade to be called regardless of subscriptions, received %v", v)
+	}
+	err = testVM.RunEvent(EventTicker, ticker.Price{})
+	if !errors.Is(err, errSubscriptionNoHandler) {
+		t.Errorf("received: %v but expected: %v", err, errSubscriptionNoHandler)
+	}
+	err = testVM.RunEvent(EventTrade, "meow")
+	if !errors.Is(err, errUnexpectedPayload) {
+		t.Errorf("received: %v but expected: %v", err, errUnexpectedPayload)
+	}
+
+	err = testVM.Shutdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCompileAndRunEvents(t *testing.T) {
+	if !dispatch.IsRunning() {
+		err := dispatch.Start(1, dispatch.DefaultJobsLimit)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager := GctScriptManager{
+		config:  configHelper(true, true, maxTestVirtualMachines),
+		started: 1,
+	}
+	manager.SetWrapper(&testWrapper{})
+	testVM := manager.New()
+	err := testVM.Load(testScriptEvents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testVM.Compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testVM.RunCtx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = testVM.startEvents()
+	if !errors.Is(err, errEventFeedsUnsupported) {
+		t.Errorf("received: %v but expected: %v", err, errEventFeedsUnsupported)
+	}
+	err = testVM.Shutdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager.SetWrapper(&testFeedWrapper{})
+	testVM = manager.New()
+	err = testVM.Load(testScriptEvents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testVM.CompileAndRun()
+	if _, ok := AllVMSync.Load(testVM.ID); !ok {
+		t.Fatal("expected subscribed script to keep running")
+	}
+
+	// dispatch drops payloads when the receiver is not immediately ready so
+	// keep publishing until the handler has run
+	timeout := time.After(time.Second * 5)
+	for testVM.Compiled.Get("last_price").Float() != 1337 {
+		err = trade.AddTradesToBuffer("vmtest", trade.Data{
+			Exchange:     "vmtest",
+			CurrencyPair: currency.NewPair(currency.BTC, currency.USD),
+			AssetType:    asset.Spot,
+			Price:        1337,
+			Amount:       1,
+			Timestamp:    time.Now(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-timeout:
+			t.Fatal("expected on_trade to be called")
+		case <-time.After(time.Millisecond * 50):
+		}
+	}
+
+	err = testVM.Shutdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
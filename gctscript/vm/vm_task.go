@@ -7,7 +7,9 @@ import (
 )
 
 func (vm *VM) runner() {
-	vm.S = make(chan struct{}, 1)
+	if vm.S == nil {
+		vm.S = make(chan struct{}, 1)
+	}
 	waitTime := time.NewTicker(vm.T)
 	vm.NextRun = time.Now().Add(vm.T)
 
@@ -16,7 +18,9 @@ func (vm *VM) runner() {
 			select {
 			case <-waitTime.C:
 				vm.NextRun = time.Now().Add(vm.T)
+				vm.runMtx.Lock()
 				err := vm.RunCtx()
+				vm.runMtx.Unlock()
 				if err != nil {
 					log.Error(log.GCTScriptMgr, err)
 					return
@@ -7,6 +7,11 @@ import (
 
 	"github.com/d5/tengo/v2"
 	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
 )
 
 const (
@@ -25,11 +30,33 @@ const (
 	TypeStop = "stop"
 	// TypeRead text to display in script_event table when a script contents is read
 	TypeRead = "read"
+	// TypePermission text to display in script_event table when a script
+	// attempts an action its permissions do not allow
+	TypePermission = "permission"
+
+	// EventTicker is the subscription event type routed to on_ticker
+	EventTicker = "ticker"
+	// EventOrderbook is the subscription event type routed to on_orderbook
+	EventOrderbook = "orderbook"
+	// EventTrade is the subscription event type routed to on_trade
+	EventTrade = "trade"
+	// EventOrderUpdate is the subscription event type routed to on_order_update
+	EventOrderUpdate = "order_update"
+	// DefaultEventQueueSize default number of events queued per virtual machine
+	// before the oldest are dropped
+	DefaultEventQueueSize = 100
+
+	eventVariable         = "gct_event"
+	subscriptionsVariable = "subscriptions"
+	subscribeRetryDelay   = 5 * time.Second
 
 	// StatusSuccess text to display in script_event table on successful execution
 	StatusSuccess = "success"
 	// StatusFailure text to display in script_event table when script execution fails
 	StatusFailure = "failure"
+	// StatusDenied text to display in script_event table when a script action
+	// is denied
+	StatusDenied = "denied"
 )
 
 type vmscount int32
@@ -44,6 +71,14 @@ var (
 	AllVMSync = &sync.Map{}
 	// VMSCount running total count of Virtual Machines
 	VMSCount vmscount
+
+	supportedEvents = []string{EventTicker, EventOrderbook, EventTrade, EventOrderUpdate}
+	eventHandlers   = map[string]string{
+		EventTicker:      "on_ticker",
+		EventOrderbook:   "on_orderbook",
+		EventTrade:       "on_trade",
+		EventOrderUpdate: "on_order_update",
+	}
 )
 
 // VM contains a pointer to "script" (precompiled source) and "compiled" (compiled byte code) instances
@@ -59,5 +94,35 @@ type VM struct {
 	NextRun    time.Time
 	S          chan struct{}
 	config     *Config
+	guard      *permission.Guard
+	wrapper    modules.GCT
+	db         *database.Instance
 	unregister func() error
+
+	source        []byte
+	handlers      []string
+	runMtx        sync.Mutex
+	events        chan *scriptEvent
+	dropping      int32
+	droppedEvents uint64
+}
+
+// subscription defines a scripts interest in an exchanges event feeds
+type subscription struct {
+	exchange string
+	pair     currency.Pair
+	asset    asset.Item
+	events   []string
+}
+
+// feedKey identifies a single exchange event feed
+type feedKey struct {
+	exchange string
+	event    string
+}
+
+// scriptEvent is a queued event waiting to be handled by a script
+type scriptEvent struct {
+	event string
+	data  tengo.Object
 }
@@ -1,6 +1,7 @@
 package exchange
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -8,6 +9,8 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/engine"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
@@ -15,22 +18,42 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/position"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
 // Exchange implements all required methods for Wrapper
-type Exchange struct{}
+type Exchange struct {
+	bot *engine.Engine
+}
+
+// New returns an Exchange wrapper which operates on the supplied engine
+func New(bot *engine.Engine) *Exchange {
+	return &Exchange{bot: bot}
+}
+
+// getEngine returns the engine the wrapper operates on, falling back to the
+// process wide engine.Bot when none has been supplied
+func (e Exchange) getEngine() *engine.Engine {
+	if e.bot != nil {
+		return e.bot
+	}
+	return engine.Bot
+}
 
 // Exchanges returns slice of all current exchanges
 func (e Exchange) Exchanges(enabledOnly bool) []string {
-	return engine.Bot.GetExchangeNames(enabledOnly)
+	return e.getEngine().GetExchangeNames(enabledOnly)
 }
 
 // GetExchange returns IBotExchange for exchange or error if exchange is not found
 func (e Exchange) GetExchange(exch string) (exchange.IBotExchange, error) {
-	ex := engine.Bot.GetExchangeByName(exch)
+	ex := e.getEngine().GetExchangeByName(exch)
 	if ex == nil {
 		return nil, fmt.Errorf("%v exchange not found", exch)
 	}
@@ -50,7 +73,7 @@ func (e Exchange) IsEnabled(exch string) bool {
 
 // Orderbook returns current orderbook requested exchange, pair and asset
 func (e Exchange) Orderbook(exch string, pair currency.Pair, item asset.Item) (*orderbook.Base, error) {
-	return engine.Bot.GetSpecificOrderbook(pair, exch, item)
+	return e.getEngine().GetSpecificOrderbook(pair, exch, item)
 }
 
 // Ticker returns ticker for provided currency pair & asset type
@@ -65,7 +88,7 @@ func (e Exchange) Ticker(exch string, pair currency.Pair, item asset.Item) (*tic
 
 // Pairs returns either all or enabled currency pairs
 func (e Exchange) Pairs(exch string, enabledOnly bool, item asset.Item) (*currency.Pairs, error) {
-	x, err := engine.Bot.Config.GetExchangeConfig(exch)
+	x, err := e.getEngine().Config.GetExchangeConfig(exch)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +106,7 @@ func (e Exchange) Pairs(exch string, enabledOnly bool, item asset.Item) (*curren
 
 // QueryOrder returns details of a valid exchange order
 func (e Exchange) QueryOrder(exch, orderID string, pair currency.Pair, assetType asset.Item) (*order.Detail, error) {
-	o, err := engine.Bot.OrderManager.GetOrderInfo(exch, orderID, pair, assetType)
+	o, err := e.getEngine().OrderManager.GetOrderInfo(exch, orderID, pair, assetType)
 	if err != nil {
 		return nil, err
 	}
@@ -91,18 +114,35 @@ func (e Exchange) QueryOrder(exch, orderID string, pair currency.Pair, assetType
 	return &o, nil
 }
 
-// SubmitOrder submit new order on exchange
-func (e Exchange) SubmitOrder(submit *order.Submit) (*order.SubmitResponse, error) {
-	r, err := engine.Bot.OrderManager.Submit(submit)
+// SubmitOrder submit new order on exchange, orders are checked against the
+// permissions of the script carried by ctx
+func (e Exchange) SubmitOrder(ctx context.Context, submit *order.Submit) (*order.SubmitResponse, error) {
+	err := permission.FromContext(ctx).CheckOrder(submit, func() (float64, error) {
+		t, errTicker := e.Ticker(submit.Exchange, submit.Pair, submit.AssetType)
+		if errTicker != nil {
+			return 0, errTicker
+		}
+		return t.Last, nil
+	})
 	if err != nil {
+		e.auditAction(ctx, audit.OrderSubmit, submit.Exchange, "order submission denied", submit, err)
 		return nil, err
 	}
-
+	r, err := e.getEngine().OrderManager.Submit(submit)
+	if err != nil {
+		e.auditAction(ctx, audit.OrderSubmit, submit.Exchange, "order submission failed", submit, err)
+		return nil, err
+	}
+	e.auditAction(ctx, audit.OrderSubmit, submit.Exchange, "order "+r.OrderID+" submitted", submit, nil)
 	return &r.SubmitResponse, nil
 }
 
 // CancelOrder wrapper to cancel order on exchange
-func (e Exchange) CancelOrder(exch, orderID string, cp currency.Pair, a asset.Item) (bool, error) {
+func (e Exchange) CancelOrder(ctx context.Context, exch, orderID string, cp currency.Pair, a asset.Item) (bool, error) {
+	err := permission.FromContext(ctx).CheckCancel(exch, cp)
+	if err != nil {
+		return false, err
+	}
 	orderDetails, err := e.QueryOrder(exch, orderID, cp, a)
 	if err != nil {
 		return false, err
@@ -117,10 +157,12 @@ func (e Exchange) CancelOrder(exch, orderID string, cp currency.Pair, a asset.It
 		Exchange:  exch,
 	}
 
-	err = engine.Bot.OrderManager.Cancel(cancel)
+	err = e.getEngine().OrderManager.Cancel(cancel)
 	if err != nil {
+		e.auditAction(ctx, audit.OrderCancel, exch, "order "+orderID+" cancellation failed", cancel, err)
 		return false, err
 	}
+	e.auditAction(ctx, audit.OrderCancel, exch, "order "+orderID+" cancelled", cancel, nil)
 	return true, nil
 }
 
@@ -145,11 +187,15 @@ func (e Exchange) DepositAddress(exch string, currencyCode currency.Code) (out s
 		err = errors.New("currency code is empty")
 		return
 	}
-	return engine.Bot.DepositAddressManager.GetDepositAddressByExchange(exch, currencyCode)
+	return e.getEngine().DepositAddressManager.GetDepositAddressByExchange(exch, currencyCode)
 }
 
 // WithdrawalFiatFunds withdraw funds from exchange to requested fiat source
-func (e Exchange) WithdrawalFiatFunds(bankAccountID string, request *withdraw.Request) (string, error) {
+func (e Exchange) WithdrawalFiatFunds(ctx context.Context, bankAccountID string, request *withdraw.Request) (string, error) {
+	err := permission.FromContext(ctx).CheckWithdrawal(request.Exchange)
+	if err != nil {
+		return "", err
+	}
 	ex, err := e.GetExchange(request.Exchange)
 	if err != nil {
 		return "", err
@@ -163,7 +209,7 @@ func (e Exchange) WithdrawalFiatFunds(bankAccountID string, request *withdraw.Re
 		}
 	}
 
-	otp, err := engine.Bot.GetExchangeoOTPByName(request.Exchange)
+	otp, err := e.getEngine().GetExchangeoOTPByName(request.Exchange)
 	if err == nil {
 		otpValue, errParse := strconv.ParseInt(otp, 10, 64)
 		if errParse != nil {
@@ -182,7 +228,7 @@ func (e Exchange) WithdrawalFiatFunds(bankAccountID string, request *withdraw.Re
 	request.Fiat.Bank.SWIFTCode = v.SWIFTCode
 	request.Fiat.Bank.IBAN = v.IBAN
 
-	resp, err := engine.Bot.SubmitWithdrawal(request)
+	resp, err := e.getEngine().SubmitWithdrawal(ctx, request)
 	if err != nil {
 		return "", err
 	}
@@ -190,13 +236,17 @@ func (e Exchange) WithdrawalFiatFunds(bankAccountID string, request *withdraw.Re
 }
 
 // WithdrawalCryptoFunds withdraw funds from exchange to requested Crypto source
-func (e Exchange) WithdrawalCryptoFunds(request *withdraw.Request) (string, error) {
+func (e Exchange) WithdrawalCryptoFunds(ctx context.Context, request *withdraw.Request) (string, error) {
+	err := permission.FromContext(ctx).CheckWithdrawal(request.Exchange)
+	if err != nil {
+		return "", err
+	}
 	// Checks if exchange is enabled or not so we don't call OTP generation
-	_, err := e.GetExchange(request.Exchange)
+	_, err = e.GetExchange(request.Exchange)
 	if err != nil {
 		return "", err
 	}
-	otp, err := engine.Bot.GetExchangeoOTPByName(request.Exchange)
+	otp, err := e.getEngine().GetExchangeoOTPByName(request.Exchange)
 	if err == nil {
 		v, errParse := strconv.ParseInt(otp, 10, 64)
 		if errParse != nil {
@@ -205,7 +255,7 @@ func (e Exchange) WithdrawalCryptoFunds(request *withdraw.Request) (string, erro
 		request.OneTimePassword = v
 	}
 
-	resp, err := engine.Bot.SubmitWithdrawal(request)
+	resp, err := e.getEngine().SubmitWithdrawal(ctx, request)
 	if err != nil {
 		return "", err
 	}
@@ -231,3 +281,199 @@ func (e Exchange) OHLCV(exch string, pair currency.Pair, item asset.Item, start,
 
 	return ret, nil
 }
+
+// Positions returns open positions for the exchange and asset, an empty pair
+// returns positions for all pairs
+func (e Exchange) Positions(exch string, item asset.Item, pair currency.Pair) ([]position.Position, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return nil, err
+	}
+	var cp *currency.Pair
+	if !pair.IsEmpty() {
+		cp = &pair
+	}
+	return ex.GetPositions(item, cp)
+}
+
+// ActiveOrders returns open orders on the exchange
+func (e Exchange) ActiveOrders(exch string, request *order.GetOrdersRequest) ([]order.Detail, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return nil, err
+	}
+	return ex.GetActiveOrders(request)
+}
+
+// OrderHistory returns past orders on the exchange
+func (e Exchange) OrderHistory(exch string, request *order.GetOrdersRequest) ([]order.Detail, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return nil, err
+	}
+	return ex.GetOrderHistory(request)
+}
+
+// RecentTrades returns the most recent trades for the pair from the exchange
+func (e Exchange) RecentTrades(exch string, pair currency.Pair, item asset.Item) ([]trade.Data, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return nil, err
+	}
+	return ex.GetRecentTrades(pair, item)
+}
+
+// ModifyOrder amends an order on the exchange, orders are checked against the
+// permissions of the script carried by ctx
+func (e Exchange) ModifyOrder(ctx context.Context, modify *order.Modify) (string, error) {
+	if modify == nil {
+		return "", order.ErrModifyOrderIsNil
+	}
+	err := permission.FromContext(ctx).CheckModify(modify, func() (*order.Detail, error) {
+		return e.QueryOrder(modify.Exchange, modify.ID, modify.Pair, modify.AssetType)
+	}, func() (float64, error) {
+		t, errTicker := e.Ticker(modify.Exchange, modify.Pair, modify.AssetType)
+		if errTicker != nil {
+			return 0, errTicker
+		}
+		return t.Last, nil
+	})
+	if err != nil {
+		e.auditAction(ctx, audit.OrderModify, modify.Exchange, "order "+modify.ID+" modification denied", modify, err)
+		return "", err
+	}
+	id, err := e.getEngine().OrderManager.Modify(modify)
+	if err != nil {
+		e.auditAction(ctx, audit.OrderModify, modify.Exchange, "order "+modify.ID+" modification failed", modify, err)
+		return "", err
+	}
+	e.auditAction(ctx, audit.OrderModify, modify.Exchange, "order "+modify.ID+" modified, order ID now "+id, modify, nil)
+	return id, nil
+}
+
+// TransferAsset moves funds between asset accounts on the exchange
+func (e Exchange) TransferAsset(ctx context.Context, exch string, from, to asset.Item, currencyCode currency.Code, amount float64) (string, error) {
+	err := permission.FromContext(ctx).CheckTransfer(exch)
+	if err != nil {
+		return "", err
+	}
+	if currencyCode.IsEmpty() {
+		return "", errors.New("currency code is empty")
+	}
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return "", err
+	}
+	details := map[string]interface{}{
+		"from":     from,
+		"to":       to,
+		"currency": currencyCode.String(),
+		"amount":   amount,
+	}
+	id, err := ex.TransferAsset(from, to, currencyCode.String(), amount)
+	if err != nil {
+		e.auditAction(ctx, audit.AssetTransfer, exch, "asset transfer failed", details, err)
+		return "", err
+	}
+	e.auditAction(ctx, audit.AssetTransfer, exch, "asset transfer "+id+" submitted", details, nil)
+	return id, nil
+}
+
+// TickerFeed subscribes to the tickers the engine stores for an exchange
+func (e Exchange) TickerFeed(exch string) (dispatch.Pipe, error) {
+	return e.getEngine().SubscribeToExchangeTickers(exch)
+}
+
+// OrderbookFeed subscribes to the orderbooks the engine stores for an exchange
+func (e Exchange) OrderbookFeed(exch string) (dispatch.Pipe, error) {
+	return e.getEngine().SubscribeToExchangeOrderbooks(exch)
+}
+
+// TradeFeed subscribes to the trades the engine processes for an exchange
+func (e Exchange) TradeFeed(exch string) (dispatch.Pipe, error) {
+	return e.getEngine().SubscribeToExchangeTrades(exch)
+}
+
+// OrderFeed subscribes to the order updates the engine tracks for an exchange
+func (e Exchange) OrderFeed(exch string) (dispatch.Pipe, error) {
+	return e.getEngine().SubscribeToExchangeOrders(exch)
+}
+
+// ExecutionLimits returns the order execution limits of the pair
+func (e Exchange) ExecutionLimits(exch string, pair currency.Pair, item asset.Item) (order.MinMaxLevel, error) {
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return order.MinMaxLevel{}, err
+	}
+	l, err := ex.GetOrderExecutionLimits(item, pair)
+	if err != nil {
+		return order.MinMaxLevel{}, err
+	}
+	levels := l.Levels()
+	levels.Pair = pair
+	levels.Asset = item
+	return levels, nil
+}
+
+// Fee returns the fee charged by the exchange for the request
+func (e Exchange) Fee(exch string, request *modules.FeeRequest) (float64, error) {
+	if request == nil {
+		return 0, errors.New("fee request is nil")
+	}
+	ex, err := e.GetExchange(exch)
+	if err != nil {
+		return 0, err
+	}
+	feeBuilder := &exchange.FeeBuilder{
+		Pair:          request.Pair,
+		IsMaker:       request.IsMaker,
+		PurchasePrice: request.Price,
+		Amount:        request.Amount,
+	}
+	switch request.Type {
+	case modules.FeeTypeTrade:
+		feeBuilder.FeeType = exchange.CryptocurrencyTradeFee
+	case modules.FeeTypeOfflineTrade:
+		feeBuilder.FeeType = exchange.OfflineTradeFee
+	case modules.FeeTypeCryptoDeposit:
+		feeBuilder.FeeType = exchange.CyptocurrencyDepositFee
+	case modules.FeeTypeCryptoWithdrawal:
+		feeBuilder.FeeType = exchange.CryptocurrencyWithdrawalFee
+	default:
+		return 0, fmt.Errorf("unsupported fee type %s", request.Type)
+	}
+	return ex.GetFeeByType(feeBuilder)
+}
+
+// StoredOHLCV returns open high low close volume candles saved to the
+// database for requested exchange/pair/asset/start & end time
+func (e Exchange) StoredOHLCV(exch string, pair currency.Pair, item asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error) {
+	ret, err := kline.LoadFromInstance(e.getEngine().DatabaseInstance(), exch, pair, item, interval, start, end)
+	if err != nil {
+		return kline.Item{}, err
+	}
+
+	sort.Slice(ret.Candles, func(i, j int) bool {
+		return ret.Candles[i].Time.Before(ret.Candles[j].Time)
+	})
+
+	ret.FormatDates()
+
+	return ret, nil
+}
+
+// StoredTrades returns trades saved to the database for requested
+// exchange/pair/asset/start & end time
+func (e Exchange) StoredTrades(exch string, pair currency.Pair, item asset.Item, start, end time.Time) ([]trade.Data, error) {
+	return trade.GetTradesInRangeFromInstance(e.getEngine().DatabaseInstance(), exch, item.String(), pair.Base.String(), pair.Quote.String(), start, end)
+}
+
+// auditAction records an action taken by a script in the audit log, along with
+// its outcome
+func (e Exchange) auditAction(ctx context.Context, eventType, exch, message string, details interface{}, err error) {
+	data := map[string]interface{}{"request": details}
+	if err != nil {
+		data["error"] = err.Error()
+	}
+	audit.New(e.getEngine().DatabaseInstance()).EventWithContext(ctx, eventType, exch, message, data)
+}
@@ -1,6 +1,8 @@
 package exchange
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,10 +10,14 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/engine"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
 // change these if you wish to test another exchange and/or currency pair
@@ -168,7 +174,7 @@ func TestExchange_SubmitOrder(t *testing.T) {
 		Exchange:     exchName,
 		AssetType:    asset.Spot,
 	}
-	_, err = exchangeTest.SubmitOrder(tempOrder)
+	_, err = exchangeTest.SubmitOrder(context.Background(), tempOrder)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -181,12 +187,176 @@ func TestExchange_CancelOrder(t *testing.T) {
 	t.Parallel()
 	cp := currency.NewPair(currency.BTC, currency.USD)
 	a := asset.Spot
-	_, err := exchangeTest.CancelOrder(exchName, orderID, cp, a)
+	_, err := exchangeTest.CancelOrder(context.Background(), exchName, orderID, cp, a)
 	if err != nil {
 		t.Fatal(err)
 	}
 }
 
+func TestExchange_Permissions(t *testing.T) {
+	t.Parallel()
+	var violations int
+	g, err := permission.NewGuard("test.gct", &permission.Manifest{Exchanges: []string{"Bitstamp"}}, func(error) {
+		violations++
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := permission.WithGuard(context.Background(), g)
+	_, err = exchangeTest.SubmitOrder(ctx, &order.Submit{
+		Pair:      currency.NewPair(currency.BTC, currency.AUD),
+		Type:      orderType,
+		Side:      orderSide,
+		Price:     orderPrice,
+		Amount:    orderAmount,
+		Exchange:  exchName,
+		AssetType: assetType,
+	})
+	if !errors.Is(err, permission.ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, permission.ErrPermissionDenied)
+	}
+	_, err = exchangeTest.CancelOrder(ctx, exchName, orderID, currency.Pair{}, assetType)
+	if !errors.Is(err, permission.ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, permission.ErrPermissionDenied)
+	}
+	_, err = exchangeTest.WithdrawalCryptoFunds(ctx, &withdraw.Request{Exchange: "Bitstamp"})
+	if !errors.Is(err, permission.ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, permission.ErrPermissionDenied)
+	}
+	_, err = exchangeTest.WithdrawalFiatFunds(ctx, "", &withdraw.Request{Exchange: "Bitstamp"})
+	if !errors.Is(err, permission.ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, permission.ErrPermissionDenied)
+	}
+	_, err = exchangeTest.ModifyOrder(ctx, &order.Modify{
+		Exchange:  exchName,
+		ID:        orderID,
+		Pair:      currency.NewPair(currency.BTC, currency.AUD),
+		AssetType: assetType,
+	})
+	if !errors.Is(err, permission.ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, permission.ErrPermissionDenied)
+	}
+	_, err = exchangeTest.TransferAsset(ctx, exchName, asset.Spot, asset.Margin, currency.BTC, 1)
+	if !errors.Is(err, permission.ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, permission.ErrPermissionDenied)
+	}
+	if violations != 6 {
+		t.Errorf("expected 6 violations, received %d", violations)
+	}
+}
+
+func TestExchange_Orders(t *testing.T) {
+	if !configureExchangeKeys() {
+		t.Skip("no exchange configured test skipped")
+	}
+	t.Parallel()
+	req := &order.GetOrdersRequest{
+		Type:      order.AnyType,
+		Side:      order.AnySide,
+		AssetType: assetType,
+	}
+	_, err := exchangeTest.ActiveOrders(exchName, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = exchangeTest.OrderHistory(exchName, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExchange_RecentTrades(t *testing.T) {
+	t.Parallel()
+	c, err := currency.NewPairDelimiter(pairs, delimiter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = exchangeTest.RecentTrades(exchName, c, assetType)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExchange_ExecutionLimits(t *testing.T) {
+	t.Parallel()
+	c, err := currency.NewPairDelimiter(pairs, delimiter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = exchangeTest.ExecutionLimits(exchName, c, assetType)
+	if !errors.Is(err, order.ErrExchangeLimitNotLoaded) {
+		t.Errorf("received: %v but expected: %v", err, order.ErrExchangeLimitNotLoaded)
+	}
+	_, err = exchangeTest.ExecutionLimits("hello world", c, assetType)
+	if err == nil {
+		t.Error("expected error on unknown exchange")
+	}
+}
+
+func TestExchange_Fee(t *testing.T) {
+	t.Parallel()
+	c, err := currency.NewPairDelimiter(pairs, delimiter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = exchangeTest.Fee(exchName, &modules.FeeRequest{
+		Type:   modules.FeeTypeOfflineTrade,
+		Pair:   c,
+		Price:  orderPrice,
+		Amount: orderAmount,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = exchangeTest.Fee(exchName, &modules.FeeRequest{Type: "meow", Pair: c})
+	if err == nil {
+		t.Error("expected error on unsupported fee type")
+	}
+	_, err = exchangeTest.Fee(exchName, nil)
+	if err == nil {
+		t.Error("expected error on nil request")
+	}
+}
+
+func TestExchange_Feeds(t *testing.T) {
+	if !dispatch.IsRunning() {
+		err := dispatch.Start(1, dispatch.DefaultJobsLimit)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := exchangeTest.TickerFeed("unloaded")
+	if err == nil {
+		t.Error("expected an error subscribing to tickers which have not been stored")
+	}
+	pipe, err := exchangeTest.OrderFeed(exchName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = pipe.Release()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExchange_Stored(t *testing.T) {
+	t.Parallel()
+	c, err := currency.NewPairDelimiter(pairs, delimiter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now().Add(-time.Hour * 24).UTC()
+	end := time.Now().UTC()
+	_, err = exchangeTest.StoredOHLCV(exchName, c, assetType, start, end, kline.OneHour)
+	if err == nil {
+		t.Error("expected error without database connection")
+	}
+	_, err = exchangeTest.StoredTrades(exchName, c, assetType, start, end)
+	if err == nil {
+		t.Error("expected error without database connection")
+	}
+}
+
 func TestOHLCV(t *testing.T) {
 	t.Parallel()
 	cp := currency.NewPair(currency.BTC, currency.AUD)
@@ -1,10 +1,23 @@
 package gct
 
-import "github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/gct/exchange"
+import (
+	"github.com/thrasher-corp/gocryptotrader/engine"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/gct/exchange"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/gct/state"
+)
 
-// Setup returns a Wrapper
+// Setup returns a Wrapper which operates on the process wide engine.Bot
 func Setup() *Wrapper {
 	return &Wrapper{
 		&exchange.Exchange{},
+		&state.State{},
+	}
+}
+
+// SetupWithEngine returns a Wrapper which operates on the supplied engine
+func SetupWithEngine(bot *engine.Engine) *Wrapper {
+	return &Wrapper{
+		exchange.New(bot),
+		&state.State{DB: bot.DatabaseInstance()},
 	}
 }
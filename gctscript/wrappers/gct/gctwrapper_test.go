@@ -32,7 +32,7 @@ func TestMain(m *testing.M) {
 	}
 	engine.Bot.LoadExchange(exch.Value, false, nil)
 	engine.Bot.DepositAddressManager = new(engine.DepositAddressManager)
-	go engine.Bot.DepositAddressManager.Sync()
+	go engine.Bot.DepositAddressManager.Sync(engine.Bot)
 	err = engine.Bot.OrderManager.Start(engine.Bot)
 	if err != nil {
 		log.Print(err)
@@ -1,8 +1,12 @@
 package gct
 
-import "github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/gct/exchange"
+import (
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/gct/exchange"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/gct/state"
+)
 
 // Wrapper struct
 type Wrapper struct {
 	*exchange.Exchange
+	*state.State
 }
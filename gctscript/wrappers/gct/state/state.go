@@ -0,0 +1,250 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/scriptstate"
+)
+
+var (
+	// Path is the directory state is saved to when the database is disabled
+	Path string
+
+	errPathUnset         = errors.New("script state path not set")
+	errInvalidScriptName = errors.New("invalid script name")
+	errStateKeyUnset     = errors.New("script state key not set")
+	fileMtx              sync.Mutex
+)
+
+// State implements the script state methods for Wrapper, values are stored
+// in the database when enabled falling back to a JSON file per script
+type State struct {
+	// DB is the database instance values are stored in, database.DB is used
+	// when unset
+	DB *database.Instance
+}
+
+// fileEntry is a single value stored in a scripts state file
+type fileEntry struct {
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetState returns the JSON encoded value stored for a scripts key
+func (s State) GetState(script, key string) (value string, found bool, err error) {
+	d, err := s.Get(script, key)
+	if err != nil {
+		if errors.Is(err, scriptstate.ErrKeyNotFound) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return d.Value, true, nil
+}
+
+// SetState stores a JSON encoded value for a scripts key
+func (s State) SetState(script, key, value string) error {
+	return s.Set(script, key, value)
+}
+
+// DeleteState removes a scripts key
+func (s State) DeleteState(script, key string) error {
+	return s.Delete(script, key)
+}
+
+// ListState returns every JSON encoded value stored for a script by key
+func (s State) ListState(script string) (map[string]string, error) {
+	list, err := s.List(script)
+	if err != nil {
+		return nil, err
+	}
+	resp := make(map[string]string, len(list))
+	for i := range list {
+		resp[list[i].Key] = list[i].Value
+	}
+	return resp, nil
+}
+
+// Get runs State.Get using database.DB
+func Get(script, key string) (*scriptstate.Details, error) {
+	return State{}.Get(script, key)
+}
+
+// Get returns the value stored for a scripts key
+func (s State) Get(script, key string) (*scriptstate.Details, error) {
+	if s.usingDatabase() {
+		return scriptstate.New(s.DB).Get(script, key)
+	}
+	if key == "" {
+		return nil, errStateKeyUnset
+	}
+	fileMtx.Lock()
+	defer fileMtx.Unlock()
+	entries, err := readFile(script)
+	if err != nil {
+		return nil, err
+	}
+	e, ok := entries[key]
+	if !ok {
+		return nil, fmt.Errorf("%s %s %w", script, key, scriptstate.ErrKeyNotFound)
+	}
+	return &scriptstate.Details{Script: script, Key: key, Value: e.Value, UpdatedAt: e.UpdatedAt}, nil
+}
+
+// List runs State.List using database.DB
+func List(script string) ([]scriptstate.Details, error) {
+	return State{}.List(script)
+}
+
+// List returns every value stored for a script ordered by key
+func (s State) List(script string) ([]scriptstate.Details, error) {
+	if s.usingDatabase() {
+		return scriptstate.New(s.DB).List(script)
+	}
+	fileMtx.Lock()
+	defer fileMtx.Unlock()
+	entries, err := readFile(script)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]scriptstate.Details, 0, len(entries))
+	for k, v := range entries {
+		resp = append(resp, scriptstate.Details{Script: script, Key: k, Value: v.Value, UpdatedAt: v.UpdatedAt})
+	}
+	sort.Slice(resp, func(i, j int) bool { return resp[i].Key < resp[j].Key })
+	return resp, nil
+}
+
+// Set runs State.Set using database.DB
+func Set(script, key, value string) error {
+	return State{}.Set(script, key, value)
+}
+
+// Set stores a value for a scripts key, replacing any existing value
+func (s State) Set(script, key, value string) error {
+	if s.usingDatabase() {
+		return scriptstate.New(s.DB).Set(script, key, value)
+	}
+	if key == "" {
+		return errStateKeyUnset
+	}
+	fileMtx.Lock()
+	defer fileMtx.Unlock()
+	entries, err := readFile(script)
+	if err != nil {
+		return err
+	}
+	entries[key] = fileEntry{Value: value, UpdatedAt: time.Now().UTC()}
+	return writeFile(script, entries)
+}
+
+// Delete runs State.Delete using database.DB
+func Delete(script, key string) error {
+	return State{}.Delete(script, key)
+}
+
+// Delete removes a scripts key
+func (s State) Delete(script, key string) error {
+	if s.usingDatabase() {
+		return scriptstate.New(s.DB).Delete(script, key)
+	}
+	if key == "" {
+		return errStateKeyUnset
+	}
+	fileMtx.Lock()
+	defer fileMtx.Unlock()
+	entries, err := readFile(script)
+	if err != nil {
+		return err
+	}
+	if _, ok := entries[key]; !ok {
+		return nil
+	}
+	delete(entries, key)
+	return writeFile(script, entries)
+}
+
+// Clear runs State.Clear using database.DB
+func Clear(script string) (int64, error) {
+	return State{}.Clear(script)
+}
+
+// Clear removes every value stored for a script returning the amount removed
+func (s State) Clear(script string) (int64, error) {
+	if s.usingDatabase() {
+		return scriptstate.New(s.DB).Clear(script)
+	}
+	fileMtx.Lock()
+	defer fileMtx.Unlock()
+	entries, err := readFile(script)
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	p, err := filePath(script)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(entries)), os.Remove(p)
+}
+
+func (s State) usingDatabase() bool {
+	if s.DB != nil {
+		return s.DB.SQL != nil
+	}
+	return database.DB.SQL != nil
+}
+
+func filePath(script string) (string, error) {
+	if Path == "" {
+		return "", errPathUnset
+	}
+	if script == "" || script != filepath.Base(script) || script == "." || script == ".." {
+		return "", fmt.Errorf("%w %q", errInvalidScriptName, script)
+	}
+	return filepath.Join(Path, script+".json"), nil
+}
+
+func readFile(script string) (map[string]fileEntry, error) {
+	p, err := filePath(script)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]fileEntry)
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, err
+	}
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("%s state file %w", script, err)
+	}
+	return entries, nil
+}
+
+func writeFile(script string, entries map[string]fileEntry) error {
+	p, err := filePath(script)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(p, data)
+}
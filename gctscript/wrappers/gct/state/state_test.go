@@ -0,0 +1,116 @@
+package state
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/database/repository/scriptstate"
+)
+
+func TestFileState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gct-state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var s State
+	err = s.SetState("test.gct", "signal", `"buy"`)
+	if !errors.Is(err, errPathUnset) {
+		t.Errorf("received: %v but expected: %v", err, errPathUnset)
+	}
+
+	Path = dir
+	err = s.SetState("../test.gct", "signal", `"buy"`)
+	if !errors.Is(err, errInvalidScriptName) {
+		t.Errorf("received: %v but expected: %v", err, errInvalidScriptName)
+	}
+	err = s.SetState("test.gct", "", `"buy"`)
+	if !errors.Is(err, errStateKeyUnset) {
+		t.Errorf("received: %v but expected: %v", err, errStateKeyUnset)
+	}
+
+	err = s.SetState("test.gct", "signal", `"buy"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.SetState("test.gct", "position", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.SetState("other.gct", "position", "5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.SetState("test.gct", "position", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, found, err := s.GetState("test.gct", "position")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || v != "2" {
+		t.Errorf("received %v %v expected 2", v, found)
+	}
+	_, found, err = s.GetState("test.gct", "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Error("expected missing key to not be found")
+	}
+	_, err = Get("test.gct", "missing")
+	if !errors.Is(err, scriptstate.ErrKeyNotFound) {
+		t.Errorf("received: %v but expected: %v", err, scriptstate.ErrKeyNotFound)
+	}
+
+	list, err := List("test.gct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Key != "position" || list[1].Key != "signal" || list[0].UpdatedAt.IsZero() {
+		t.Errorf("unexpected state %+v", list)
+	}
+
+	err = s.DeleteState("test.gct", "signal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := s.ListState("test.gct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 1 || m["position"] != "2" {
+		t.Errorf("unexpected state %v", m)
+	}
+
+	removed, err := Clear("test.gct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("received %v expected 1", removed)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "test.gct.json")); !os.IsNotExist(err) {
+		t.Error("expected state file to be removed")
+	}
+	removed, err = Clear("test.gct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 0 {
+		t.Errorf("received %v expected 0", removed)
+	}
+	m, err = s.ListState("other.gct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 1 {
+		t.Error("expected other scripts state to remain")
+	}
+}
@@ -0,0 +1,138 @@
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
+	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var (
+	errScenarioNil   = errors.New("scenario is nil")
+	errVMUnavailable = errors.New("unable to create virtual machine")
+)
+
+// runMtx serialises runs as the script path is global
+var runMtx sync.Mutex
+
+// Run executes a script against a scenario offline in place of the engine, no
+// exchanges, database or running instance are required. The script is run the
+// number of times set by the scenario before its events are handled, then the
+// recorded calls are checked against the scenario expectations
+func Run(script string, s *Scenario) (*Result, error) {
+	if s == nil {
+		return nil, errScenarioNil
+	}
+	if filepath.Ext(script) != common.GctExt {
+		script += common.GctExt
+	}
+
+	runMtx.Lock()
+	defer runMtx.Unlock()
+
+	// scripts write their output and look up uploaded permissions relative
+	// to the script path, which must not be the live one
+	dir, err := ioutil.TempDir("", "gctscript-scenario")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	previousPath := gctscript.ScriptPath
+	gctscript.ScriptPath = dir
+	defer func() { gctscript.ScriptPath = previousPath }()
+
+	cfg := &gctscript.Config{
+		Enabled:            true,
+		ScriptTimeout:      s.Timeout,
+		MaxVirtualMachines: 1,
+		AllowImports:       true,
+		EventQueueSize:     gctscript.DefaultEventQueueSize,
+	}
+	if cfg.ScriptTimeout <= 0 {
+		cfg.ScriptTimeout = gctscript.DefaultTimeoutValue
+	}
+	if s.Permissions != nil {
+		cfg.Permissions = map[string]*permission.Manifest{filepath.Base(script): s.Permissions}
+	}
+	manager, err := gctscript.NewManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+	w := NewWrapper(s)
+	manager.SetWrapper(w)
+	var wg sync.WaitGroup
+	err = manager.Start(&wg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if errStop := manager.Stop(); errStop != nil {
+			log.Errorln(log.GCTScriptMgr, errStop)
+		}
+		wg.Wait()
+	}()
+
+	result := &Result{Script: script}
+	runErr := execute(manager, script, s)
+	result.Calls = w.Calls()
+	result.Failures = s.Verify(result.Calls)
+	if runErr != nil {
+		result.Error = runErr.Error()
+	}
+	switch {
+	case s.ExpectError != "" && runErr == nil:
+		result.Failures = append(result.Failures, fmt.Sprintf("expected error containing %q", s.ExpectError))
+	case s.ExpectError != "" && !strings.Contains(runErr.Error(), s.ExpectError):
+		result.Failures = append(result.Failures, fmt.Sprintf("expected error containing %q, received %v", s.ExpectError, runErr))
+	case s.ExpectError == "" && runErr != nil:
+		result.Failures = append(result.Failures, "script failed: "+runErr.Error())
+	}
+	result.Passed = len(result.Failures) == 0
+	return result, nil
+}
+
+// execute loads the script and runs it, followed by the scenario events
+func execute(manager *gctscript.GctScriptManager, script string, s *Scenario) error {
+	v := manager.New()
+	if v == nil {
+		return errVMUnavailable
+	}
+	err := v.Load(script)
+	if err != nil {
+		return err
+	}
+	err = v.Compile()
+	if err != nil {
+		return err
+	}
+	runs := s.Runs
+	if runs <= 0 {
+		runs = 1
+	}
+	for i := 0; i < runs; i++ {
+		err = v.RunCtx()
+		if err != nil {
+			return err
+		}
+	}
+	for i := range s.Events {
+		var payload interface{}
+		payload, err = eventPayload(&s.Events[i])
+		if err != nil {
+			return err
+		}
+		err = v.RunEvent(s.Events[i].Type, payload)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package scenario
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
+)
+
+// Load reads and validates a scenario file
+func Load(path string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	var s Scenario
+	err = d.Decode(&s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	err = s.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Validate checks the scenario is well formed, normalising method names
+func (s *Scenario) Validate() error {
+	for exch, pairs := range s.Exchanges {
+		for i := range pairs {
+			if err := checkFilter(pairs[i], ""); err != nil {
+				return fmt.Errorf("exchanges: %s: %w", exch, err)
+			}
+		}
+	}
+
+	responses := make(map[string][]*Response, len(s.Responses))
+	for method, list := range s.Responses {
+		m := strings.ToLower(method)
+		if !common.StringDataCompare(responseMethods, m) {
+			return fmt.Errorf("responses: %w %s", errUnknownMethod, method)
+		}
+		for i := range list {
+			if list[i] == nil {
+				return fmt.Errorf("responses: %s %d is empty", method, i)
+			}
+			if err := checkFilter(list[i].Pair, list[i].Asset); err != nil {
+				return fmt.Errorf("responses: %s %d: %w", method, i, err)
+			}
+		}
+		responses[m] = list
+	}
+	s.Responses = responses
+
+	for i := range s.Expect {
+		s.Expect[i].Method = strings.ToLower(s.Expect[i].Method)
+		if !common.StringDataCompare(expectMethods, s.Expect[i].Method) {
+			return fmt.Errorf("expect %d: %w %s", i, errUnknownMethod, s.Expect[i].Method)
+		}
+		if err := checkFilter(s.Expect[i].Pair, s.Expect[i].Asset); err != nil {
+			return fmt.Errorf("expect %d: %w", i, err)
+		}
+		if s.Expect[i].Count != nil && *s.Expect[i].Count < 0 {
+			return fmt.Errorf("expect %d: %w", i, errNegativeCount)
+		}
+	}
+
+	for i := range s.Events {
+		s.Events[i].Type = strings.ToLower(s.Events[i].Type)
+		if _, err := eventPayload(&s.Events[i]); err != nil {
+			return fmt.Errorf("event %d: %w", i, err)
+		}
+	}
+
+	if s.Permissions != nil {
+		if err := s.Permissions.Validate(); err != nil {
+			return fmt.Errorf("permissions: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkFilter checks the pair and asset filters of a response or expectation
+func checkFilter(pair, a string) error {
+	if pair != "" {
+		if _, err := currency.NewPairFromString(pair); err != nil {
+			return fmt.Errorf("%w %s: %v", errInvalidPair, pair, err)
+		}
+	}
+	if a != "" {
+		if _, err := asset.New(a); err != nil {
+			return fmt.Errorf("%w %s", errInvalidAsset, a)
+		}
+	}
+	return nil
+}
+
+// filterMatches returns whether a call for an exchange, pair and asset falls
+// under a filter, empty filter fields match everything
+func filterMatches(exchFilter, pairFilter, assetFilter, exch, pair, a string) bool {
+	if exchFilter != "" && !strings.EqualFold(exchFilter, exch) {
+		return false
+	}
+	if assetFilter != "" && !strings.EqualFold(assetFilter, a) {
+		return false
+	}
+	if pairFilter == "" {
+		return true
+	}
+	want, err := currency.NewPairFromString(pairFilter)
+	if err != nil {
+		return false
+	}
+	got, err := currency.NewPairFromString(pair)
+	if err != nil {
+		return false
+	}
+	return want.Equal(got)
+}
+
+// Verify returns a failure message for every expectation the calls do not
+// meet
+func (s *Scenario) Verify(calls []Call) []string {
+	var failures []string
+	for i := range s.Expect {
+		e := &s.Expect[i]
+		var count int
+		for j := range calls {
+			if e.matches(&calls[j]) {
+				count++
+			}
+		}
+		switch {
+		case e.Count != nil && count != *e.Count:
+			failures = append(failures, fmt.Sprintf("expected %s to be called %d times, called %d times", e, *e.Count, count))
+		case e.Count == nil && count == 0:
+			failures = append(failures, fmt.Sprintf("expected %s to be called", e))
+		}
+	}
+	return failures
+}
+
+// matches returns whether a recorded call meets the expectation
+func (e *Expectation) matches(c *Call) bool {
+	if e.Method != c.Method || !filterMatches(e.Exchange, e.Pair, e.Asset, c.Exchange, c.Pair, c.Asset) {
+		return false
+	}
+	for k, want := range e.Args {
+		got, ok := c.Args[k]
+		if !ok || !argEqual(want, got) {
+			return false
+		}
+	}
+	return true
+}
+
+// String describes the calls an expectation matches
+func (e *Expectation) String() string {
+	parts := []string{e.Method}
+	for _, f := range [][2]string{{"exchange", e.Exchange}, {"pair", e.Pair}, {"asset", e.Asset}} {
+		if f[1] != "" {
+			parts = append(parts, f[0]+" "+f[1])
+		}
+	}
+	keys := make([]string, 0, len(e.Args))
+	for k := range e.Args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s %v", k, e.Args[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// argEqual compares an expected argument decoded from JSON with a recorded
+// argument, strings are compared case insensitively
+func argEqual(want, got interface{}) bool {
+	w, wOK := want.(string)
+	g, gOK := got.(string)
+	if wOK && gOK {
+		return strings.EqualFold(w, g)
+	}
+	return fmt.Sprint(want) == fmt.Sprint(got)
+}
+
+// eventPayload decodes an events data into the payload type of its event
+func eventPayload(e *Event) (interface{}, error) {
+	var v interface{}
+	switch e.Type {
+	case gctscript.EventTicker:
+		v = &ticker.Price{}
+	case gctscript.EventOrderbook:
+		v = &orderbook.Base{}
+	case gctscript.EventTrade:
+		v = &trade.Data{}
+	case gctscript.EventOrderUpdate:
+		v = &order.Detail{}
+	default:
+		return nil, fmt.Errorf("%w %s", errUnknownEvent, e.Type)
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(v).Elem().Interface(), nil
+}
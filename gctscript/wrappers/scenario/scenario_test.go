@@ -0,0 +1,271 @@
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var (
+	testScript   = filepath.Join("..", "..", "..", "testdata", "gctscript", "scenario.gct")
+	testScenario = filepath.Join("..", "..", "..", "testdata", "gctscript", "scenario.json")
+)
+
+func TestMain(m *testing.M) {
+	c := log.GenDefaultSettings()
+	c.Enabled = convert.BoolPtr(false)
+	log.RWM.Lock()
+	log.GlobalLogConfig = &c
+	log.RWM.Unlock()
+	os.Exit(m.Run())
+}
+
+func TestLoad(t *testing.T) {
+	s, err := Load(testScenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Responses[MethodTicker]) != 2 || len(s.Expect) != 4 {
+		t.Errorf("unexpected scenario %+v", s)
+	}
+
+	dir, err := ioutil.TempDir("", "gctscript-scenario")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, tt := range []struct {
+		data     string
+		expected error
+	}{
+		{data: `{"responses": {"meow": []}}`, expected: errUnknownMethod},
+		{data: `{"expect": [{"method": "ticker", "pair": "B"}]}`, expected: errInvalidPair},
+		{data: `{"expect": [{"method": "ticker", "asset": "meow"}]}`, expected: errInvalidAsset},
+		{data: `{"expect": [{"method": "ticker", "count": -1}]}`, expected: errNegativeCount},
+		{data: `{"events": [{"type": "meow"}]}`, expected: errUnknownEvent},
+	} {
+		path := filepath.Join(dir, "scenario.json")
+		if err = ioutil.WriteFile(path, []byte(tt.data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = Load(path); !errors.Is(err, tt.expected) {
+			t.Errorf("%s received: %v but expected: %v", tt.data, err, tt.expected)
+		}
+	}
+
+	path := filepath.Join(dir, "scenario.json")
+	if err = ioutil.WriteFile(path, []byte(`{"expected": []}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Load(path); err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Errorf("expected unknown field error, received %v", err)
+	}
+}
+
+func TestWrapperResponses(t *testing.T) {
+	t.Parallel()
+	s := &Scenario{
+		Exchanges: map[string][]string{"binance": {"BTC-USDT"}},
+		Responses: map[string][]*Response{
+			MethodTicker: {
+				{Pair: "ETH-USDT", Result: json.RawMessage(`{"last": 1}`)},
+				{Pair: "BTC-USDT", Result: json.RawMessage(`{"last": 2}`)},
+				{Pair: "BTC-USDT", Result: json.RawMessage(`{"last": 3}`)},
+			},
+		},
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	w := NewWrapper(s)
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	for _, expected := range []float64{2, 3, 3} {
+		tx, err := w.Ticker("binance", p, asset.Spot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.Last != expected || tx.ExchangeName != "binance" || !tx.Pair.Equal(p) {
+			t.Errorf("unexpected ticker %+v expected last %v", tx, expected)
+		}
+	}
+	if _, err := w.Orderbook("binance", p, asset.Spot); !errors.Is(err, errNoResponse) {
+		t.Errorf("received: %v but expected: %v", err, errNoResponse)
+	}
+	if _, err := w.Pairs("bitstamp", true, asset.Spot); !errors.Is(err, errExchangeNotLoaded) {
+		t.Errorf("received: %v but expected: %v", err, errExchangeNotLoaded)
+	}
+	calls := w.Calls()
+	if len(calls) != 5 || calls[3].Method != MethodOrderbook || calls[3].Error == "" {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+}
+
+func TestWrapperTradingResponses(t *testing.T) {
+	t.Parallel()
+	s := &Scenario{
+		Responses: map[string][]*Response{
+			MethodActiveOrders:    {{Pair: "BTC-USDT", Result: json.RawMessage(`[{"id": "1", "price": 10}]`)}},
+			MethodRecentTrades:    {{Result: json.RawMessage(`[{"price": 10, "amount": 1, "side": "BUY"}]`)}},
+			MethodModifyOrder:     {{Result: json.RawMessage(`"2"`)}},
+			MethodExecutionLimits: {{Result: json.RawMessage(`{"minamount": 0.1}`)}},
+			MethodFee:             {{Result: json.RawMessage(`0.5`)}},
+		},
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	w := NewWrapper(s)
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	orders, err := w.ActiveOrders("binance", &order.GetOrdersRequest{Pairs: currency.Pairs{p}, AssetType: asset.Spot})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 || orders[0].ID != "1" {
+		t.Errorf("unexpected orders %+v", orders)
+	}
+	if _, err = w.OrderHistory("binance", &order.GetOrdersRequest{AssetType: asset.Spot}); !errors.Is(err, errNoResponse) {
+		t.Errorf("received: %v but expected: %v", err, errNoResponse)
+	}
+	trades, err := w.RecentTrades("binance", p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 || trades[0].Exchange != "binance" || !trades[0].CurrencyPair.Equal(p) || trades[0].Side != order.Buy {
+		t.Errorf("unexpected trades %+v", trades)
+	}
+	l, err := w.ExecutionLimits("binance", p, asset.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.MinAmount != 0.1 || !l.Pair.Equal(p) {
+		t.Errorf("unexpected limits %+v", l)
+	}
+	fee, err := w.Fee("binance", &modules.FeeRequest{Type: modules.FeeTypeTrade, Pair: p})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee != 0.5 {
+		t.Errorf("expected fee 0.5 received %v", fee)
+	}
+
+	g, err := permission.NewGuard("test.gct", &permission.Manifest{Exchanges: []string{"bitstamp"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := permission.WithGuard(context.Background(), g)
+	m := &order.Modify{Exchange: "binance", ID: "1", Pair: p, AssetType: asset.Spot}
+	if _, err = w.ModifyOrder(ctx, m); !errors.Is(err, permission.ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, permission.ErrPermissionDenied)
+	}
+	if _, err = w.TransferAsset(ctx, "binance", asset.Spot, asset.Margin, currency.BTC, 1); !errors.Is(err, permission.ErrPermissionDenied) {
+		t.Errorf("received: %v but expected: %v", err, permission.ErrPermissionDenied)
+	}
+	id, err := w.ModifyOrder(context.Background(), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "2" {
+		t.Errorf("expected order ID 2 received %v", id)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+	zero, one := 0, 1
+	s := &Scenario{Expect: []Expectation{
+		{Method: MethodSubmitOrder, Exchange: "binance", Args: map[string]interface{}{"side": "buy", "price": float64(10)}, Count: &one},
+		{Method: MethodWithdrawalCryptoFunds, Count: &zero},
+		{Method: MethodTicker, Pair: "BTC-USDT"},
+	}}
+	calls := []Call{
+		{Method: MethodSubmitOrder, Exchange: "Binance", Pair: "BTC-USDT", Args: map[string]interface{}{"side": "BUY", "price": float64(10)}},
+		{Method: MethodSubmitOrder, Exchange: "binance", Pair: "BTC-USDT", Args: map[string]interface{}{"side": "SELL", "price": float64(10)}},
+		{Method: MethodTicker, Pair: "BTC_USDT"},
+	}
+	if failures := s.Verify(calls); len(failures) != 0 {
+		t.Errorf("unexpected failures %v", failures)
+	}
+	calls = append(calls, Call{Method: MethodWithdrawalCryptoFunds}, calls[0])
+	failures := s.Verify(calls[1:])
+	if len(failures) != 1 || !strings.Contains(failures[0], "withdrawalcryptofunds to be called 0 times") {
+		t.Errorf("unexpected failures %v", failures)
+	}
+}
+
+func TestRun(t *testing.T) {
+	if _, err := Run(testScript, nil); !errors.Is(err, errScenarioNil) {
+		t.Errorf("received: %v but expected: %v", err, errScenarioNil)
+	}
+
+	s, err := Load(testScenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := Run(testScript, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Passed {
+		t.Fatalf("expected scenario to pass, received %+v", r)
+	}
+	if r.Error != "" {
+		t.Error(r.Error)
+	}
+
+	// the second order is rejected by the scenario
+	s, err = Load(testScenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Runs = 3
+	s.Expect = nil
+	r, err = Run(testScript, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Passed || !strings.Contains(r.Error, "insufficient funds") {
+		t.Errorf("expected scenario to fail with response error, received %+v", r)
+	}
+	s, err = Load(testScenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Runs = 3
+	s.Expect = nil
+	s.ExpectError = "insufficient funds"
+	r, err = Run(testScript, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Passed {
+		t.Errorf("expected scenario to pass, received %+v", r)
+	}
+
+	s, err = Load(testScenario)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Permissions = &permission.Manifest{MaxOrderNotional: 10}
+	s.Expect = nil
+	s.ExpectError = permission.ErrPermissionDenied.Error()
+	r, err = Run(testScript, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Passed {
+		t.Errorf("expected order to be denied, received %+v", r)
+	}
+}
@@ -0,0 +1,152 @@
+package scenario
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
+)
+
+// Methods of the modules.GCT interface responses and expectations can be
+// defined for
+const (
+	MethodExchanges             = "exchanges"
+	MethodIsEnabled             = "isenabled"
+	MethodOrderbook             = "orderbook"
+	MethodTicker                = "ticker"
+	MethodPairs                 = "pairs"
+	MethodQueryOrder            = "queryorder"
+	MethodSubmitOrder           = "submitorder"
+	MethodCancelOrder           = "cancelorder"
+	MethodAccountInformation    = "accountinformation"
+	MethodDepositAddress        = "depositaddress"
+	MethodWithdrawalFiatFunds   = "withdrawalfiatfunds"
+	MethodWithdrawalCryptoFunds = "withdrawalcryptofunds"
+	MethodOHLCV                 = "ohlcv"
+	MethodPositions             = "positions"
+	MethodActiveOrders          = "activeorders"
+	MethodOrderHistory          = "orderhistory"
+	MethodRecentTrades          = "recenttrades"
+	MethodModifyOrder           = "modifyorder"
+	MethodTransferAsset         = "transferasset"
+	MethodExecutionLimits       = "executionlimits"
+	MethodFee                   = "fee"
+	MethodStoredOHLCV           = "storedohlcv"
+	MethodStoredTrades          = "storedtrades"
+	MethodGetState              = "getstate"
+	MethodSetState              = "setstate"
+	MethodDeleteState           = "deletestate"
+	MethodListState             = "liststate"
+)
+
+var (
+	// responseMethods are the methods which return scenario responses
+	responseMethods = []string{
+		MethodOrderbook, MethodTicker, MethodQueryOrder, MethodSubmitOrder,
+		MethodCancelOrder, MethodAccountInformation, MethodDepositAddress,
+		MethodWithdrawalFiatFunds, MethodWithdrawalCryptoFunds, MethodOHLCV,
+		MethodPositions, MethodActiveOrders, MethodOrderHistory,
+		MethodRecentTrades, MethodModifyOrder, MethodTransferAsset,
+		MethodExecutionLimits, MethodFee, MethodStoredOHLCV, MethodStoredTrades,
+	}
+	// expectMethods are the methods calls are recorded for
+	expectMethods = append([]string{
+		MethodExchanges, MethodIsEnabled, MethodPairs, MethodGetState,
+		MethodSetState, MethodDeleteState, MethodListState,
+	}, responseMethods...)
+
+	errNoResponse        = errors.New("no scenario response")
+	errUnknownMethod     = errors.New("unknown method")
+	errUnknownEvent      = errors.New("unknown event type")
+	errInvalidPair       = errors.New("invalid pair")
+	errInvalidAsset      = errors.New("invalid asset")
+	errNegativeCount     = errors.New("expected count cannot be negative")
+	errResponseError     = errors.New("scenario response error")
+	errExchangeNotLoaded = errors.New("exchange not loaded")
+)
+
+// Scenario defines the responses a script receives offline and the calls it
+// is expected to make
+type Scenario struct {
+	// Exchanges are the enabled exchanges and their enabled pairs
+	Exchanges map[string][]string `json:"exchanges"`
+	// Responses are returned in order per method to calls matching their
+	// exchange, pair and asset, the last matching response is repeated once
+	// the rest are used
+	Responses map[string][]*Response `json:"responses"`
+	// State seeds the scripts persisted state with JSON encoded values
+	State map[string]string `json:"state,omitempty"`
+	// Permissions are enforced as if set in config
+	Permissions *permission.Manifest `json:"permissions,omitempty"`
+	// Runs is the number of times the script is executed, defaults to once
+	Runs int `json:"runs,omitempty"`
+	// Events are handled in order after the script runs
+	Events []Event `json:"events,omitempty"`
+	// Expect lists the calls the script must make
+	Expect []Expectation `json:"expect,omitempty"`
+	// ExpectError requires the script to fail with an error containing it
+	ExpectError string `json:"expect_error,omitempty"`
+	// Timeout limits each run and event, defaults to the gctscript default
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// Response is the result of a single call, Result is decoded into the type
+// returned by the method
+type Response struct {
+	Exchange string          `json:"exchange,omitempty"`
+	Pair     string          `json:"pair,omitempty"`
+	Asset    string          `json:"asset,omitempty"`
+	Result   json.RawMessage `json:"result,omitempty"`
+	Error    string          `json:"error,omitempty"`
+
+	used bool
+}
+
+// Event is delivered to the scripts matching event handler, Data is decoded
+// into the ticker, orderbook, trade or order of the event type
+type Event struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Expectation matches recorded calls, Args values must equal the recorded
+// argument values. Count is the exact number of matching calls required,
+// when unset at least one call is required
+type Expectation struct {
+	Method   string                 `json:"method"`
+	Exchange string                 `json:"exchange,omitempty"`
+	Pair     string                 `json:"pair,omitempty"`
+	Asset    string                 `json:"asset,omitempty"`
+	Args     map[string]interface{} `json:"args,omitempty"`
+	Count    *int                   `json:"count,omitempty"`
+}
+
+// Call is a recorded call to the wrapper
+type Call struct {
+	Method   string                 `json:"method"`
+	Exchange string                 `json:"exchange,omitempty"`
+	Pair     string                 `json:"pair,omitempty"`
+	Asset    string                 `json:"asset,omitempty"`
+	Args     map[string]interface{} `json:"args,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// Result is the outcome of running a script against a scenario
+type Result struct {
+	Script   string   `json:"script"`
+	Passed   bool     `json:"passed"`
+	Error    string   `json:"error,omitempty"`
+	Failures []string `json:"failures,omitempty"`
+	Calls    []Call   `json:"calls"`
+}
+
+// Wrapper implements modules.GCT from a scenario, recording every call
+type Wrapper struct {
+	scenario *Scenario
+
+	mtx   sync.Mutex
+	calls []Call
+	state map[string]string
+}
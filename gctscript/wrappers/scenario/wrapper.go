@@ -0,0 +1,523 @@
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/position"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/permission"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// NewWrapper returns a wrapper serving responses from the scenario, responses
+// are consumed so a scenario should only be used once
+func NewWrapper(s *Scenario) *Wrapper {
+	w := &Wrapper{
+		scenario: s,
+		state:    make(map[string]string, len(s.State)),
+	}
+	for k, v := range s.State {
+		w.state[k] = v
+	}
+	return w
+}
+
+// Calls returns the calls recorded in the order they were made
+func (w *Wrapper) Calls() []Call {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	calls := make([]Call, len(w.calls))
+	copy(calls, w.calls)
+	return calls
+}
+
+// record stores a call along with its outcome
+func (w *Wrapper) record(c *Call, err error) {
+	if err != nil {
+		c.Error = err.Error()
+	}
+	w.mtx.Lock()
+	w.calls = append(w.calls, *c)
+	w.mtx.Unlock()
+}
+
+// respond decodes the next response matching a call into out, the last
+// matching response is reused once all have been consumed
+func (w *Wrapper) respond(method, exch string, pair currency.Pair, a asset.Item, out interface{}, consume bool) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	var match *Response
+	for _, r := range w.scenario.Responses[method] {
+		if !filterMatches(r.Exchange, r.Pair, r.Asset, exch, pair.String(), a.String()) {
+			continue
+		}
+		match = r
+		if !r.used {
+			break
+		}
+	}
+	if match == nil {
+		return fmt.Errorf("%w for %s %s %s %s", errNoResponse, method, exch, pair, a)
+	}
+	if consume {
+		match.used = true
+	}
+	if match.Error != "" {
+		return fmt.Errorf("%w: %s", errResponseError, match.Error)
+	}
+	if len(match.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(match.Result, out)
+}
+
+// newCall returns a call record for a method acting on an exchange, pair and
+// asset
+func newCall(method, exch string, pair currency.Pair, a asset.Item) *Call {
+	return &Call{Method: method, Exchange: exch, Pair: pair.String(), Asset: a.String()}
+}
+
+// Exchanges returns the scenario exchanges
+func (w *Wrapper) Exchanges(enabledOnly bool) []string {
+	w.record(&Call{Method: MethodExchanges, Args: map[string]interface{}{"enabled_only": enabledOnly}}, nil)
+	exchanges := make([]string, 0, len(w.scenario.Exchanges))
+	for exch := range w.scenario.Exchanges {
+		exchanges = append(exchanges, exch)
+	}
+	sort.Strings(exchanges)
+	return exchanges
+}
+
+// IsEnabled returns whether the exchange is part of the scenario
+func (w *Wrapper) IsEnabled(exch string) bool {
+	w.record(&Call{Method: MethodIsEnabled, Exchange: exch}, nil)
+	_, ok := w.exchangePairs(exch)
+	return ok
+}
+
+func (w *Wrapper) exchangePairs(exch string) ([]string, bool) {
+	for k, v := range w.scenario.Exchanges {
+		if strings.EqualFold(k, exch) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Orderbook returns the next scenario orderbook
+func (w *Wrapper) Orderbook(exch string, pair currency.Pair, item asset.Item) (*orderbook.Base, error) {
+	c := newCall(MethodOrderbook, exch, pair, item)
+	ob := &orderbook.Base{Exchange: exch, Pair: pair, Asset: item}
+	err := w.respond(MethodOrderbook, exch, pair, item, ob, true)
+	w.record(c, err)
+	if err != nil {
+		return nil, err
+	}
+	return ob, nil
+}
+
+// Ticker returns the next scenario ticker
+func (w *Wrapper) Ticker(exch string, pair currency.Pair, item asset.Item) (*ticker.Price, error) {
+	c := newCall(MethodTicker, exch, pair, item)
+	tx := &ticker.Price{ExchangeName: exch, Pair: pair, AssetType: item}
+	err := w.respond(MethodTicker, exch, pair, item, tx, true)
+	w.record(c, err)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Pairs returns the scenario pairs of an exchange
+func (w *Wrapper) Pairs(exch string, enabledOnly bool, item asset.Item) (*currency.Pairs, error) {
+	c := newCall(MethodPairs, exch, currency.Pair{}, item)
+	c.Args = map[string]interface{}{"enabled_only": enabledOnly}
+	list, ok := w.exchangePairs(exch)
+	if !ok {
+		err := fmt.Errorf("%w %s", errExchangeNotLoaded, exch)
+		w.record(c, err)
+		return nil, err
+	}
+	pairs, err := currency.NewPairsFromStrings(list)
+	w.record(c, err)
+	if err != nil {
+		return nil, err
+	}
+	return &pairs, nil
+}
+
+// QueryOrder returns the next scenario order detail
+func (w *Wrapper) QueryOrder(exch, orderID string, pair currency.Pair, assetType asset.Item) (*order.Detail, error) {
+	c := newCall(MethodQueryOrder, exch, pair, assetType)
+	c.Args = map[string]interface{}{"order_id": orderID}
+	d := &order.Detail{Exchange: exch, ID: orderID, Pair: pair, AssetType: assetType}
+	err := w.respond(MethodQueryOrder, exch, pair, assetType, d, true)
+	w.record(c, err)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// SubmitOrder returns the next scenario submit response, orders are checked
+// against the script permissions as they are live
+func (w *Wrapper) SubmitOrder(ctx context.Context, submit *order.Submit) (*order.SubmitResponse, error) {
+	c := newCall(MethodSubmitOrder, submit.Exchange, submit.Pair, submit.AssetType)
+	c.Args = map[string]interface{}{
+		"side":      submit.Side.String(),
+		"type":      submit.Type.String(),
+		"price":     submit.Price,
+		"amount":    submit.Amount,
+		"client_id": submit.ClientID,
+	}
+	err := permission.FromContext(ctx).CheckOrder(submit, func() (float64, error) {
+		var tx ticker.Price
+		errTicker := w.respond(MethodTicker, submit.Exchange, submit.Pair, submit.AssetType, &tx, false)
+		return tx.Last, errTicker
+	})
+	if err != nil {
+		w.record(c, err)
+		return nil, err
+	}
+	resp := &order.SubmitResponse{}
+	err = w.respond(MethodSubmitOrder, submit.Exchange, submit.Pair, submit.AssetType, resp, true)
+	w.record(c, err)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CancelOrder returns the next scenario cancel result
+func (w *Wrapper) CancelOrder(ctx context.Context, exch, orderID string, pair currency.Pair, item asset.Item) (bool, error) {
+	c := newCall(MethodCancelOrder, exch, pair, item)
+	c.Args = map[string]interface{}{"order_id": orderID}
+	err := permission.FromContext(ctx).CheckCancel(exch, pair)
+	if err != nil {
+		w.record(c, err)
+		return false, err
+	}
+	cancelled := true
+	err = w.respond(MethodCancelOrder, exch, pair, item, &cancelled, true)
+	w.record(c, err)
+	if err != nil {
+		return false, err
+	}
+	return cancelled, nil
+}
+
+// AccountInformation returns the next scenario account holdings
+func (w *Wrapper) AccountInformation(exch string, assetType asset.Item) (account.Holdings, error) {
+	c := newCall(MethodAccountInformation, exch, currency.Pair{}, assetType)
+	h := account.Holdings{Exchange: exch}
+	err := w.respond(MethodAccountInformation, exch, currency.Pair{}, assetType, &h, true)
+	w.record(c, err)
+	if err != nil {
+		return account.Holdings{}, err
+	}
+	return h, nil
+}
+
+// DepositAddress returns the next scenario deposit address
+func (w *Wrapper) DepositAddress(exch string, currencyCode currency.Code) (string, error) {
+	c := newCall(MethodDepositAddress, exch, currency.Pair{}, "")
+	c.Args = map[string]interface{}{"currency": currencyCode.String()}
+	var address string
+	err := w.respond(MethodDepositAddress, exch, currency.Pair{}, "", &address, true)
+	w.record(c, err)
+	return address, err
+}
+
+// WithdrawalFiatFunds returns the next scenario withdrawal ID
+func (w *Wrapper) WithdrawalFiatFunds(ctx context.Context, bankAccountID string, request *withdraw.Request) (string, error) {
+	c := newCall(MethodWithdrawalFiatFunds, request.Exchange, currency.Pair{}, "")
+	c.Args = map[string]interface{}{
+		"bank_account_id": bankAccountID,
+		"currency":        request.Currency.String(),
+		"amount":          request.Amount,
+		"description":     request.Description,
+	}
+	return w.withdraw(ctx, c, request.Exchange)
+}
+
+// WithdrawalCryptoFunds returns the next scenario withdrawal ID
+func (w *Wrapper) WithdrawalCryptoFunds(ctx context.Context, request *withdraw.Request) (string, error) {
+	c := newCall(MethodWithdrawalCryptoFunds, request.Exchange, currency.Pair{}, "")
+	c.Args = map[string]interface{}{
+		"currency":    request.Currency.String(),
+		"amount":      request.Amount,
+		"description": request.Description,
+	}
+	if request.Crypto.Address != "" {
+		c.Args["address"] = request.Crypto.Address
+		c.Args["address_tag"] = request.Crypto.AddressTag
+		c.Args["fee"] = request.Crypto.FeeAmount
+	}
+	return w.withdraw(ctx, c, request.Exchange)
+}
+
+func (w *Wrapper) withdraw(ctx context.Context, c *Call, exch string) (string, error) {
+	err := permission.FromContext(ctx).CheckWithdrawal(exch)
+	if err != nil {
+		w.record(c, err)
+		return "", err
+	}
+	var id string
+	err = w.respond(c.Method, exch, currency.Pair{}, "", &id, true)
+	w.record(c, err)
+	return id, err
+}
+
+// OHLCV returns the next scenario candles
+func (w *Wrapper) OHLCV(exch string, pair currency.Pair, item asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error) {
+	c := newCall(MethodOHLCV, exch, pair, item)
+	c.Args = map[string]interface{}{
+		"start":    start.UTC().Format(time.RFC3339),
+		"end":      end.UTC().Format(time.RFC3339),
+		"interval": interval.Short(),
+	}
+	k := kline.Item{Exchange: exch, Pair: pair, Asset: item, Interval: interval}
+	err := w.respond(MethodOHLCV, exch, pair, item, &k, true)
+	w.record(c, err)
+	if err != nil {
+		return kline.Item{}, err
+	}
+	return k, nil
+}
+
+// Positions returns the next scenario positions
+func (w *Wrapper) Positions(exch string, item asset.Item, pair currency.Pair) ([]position.Position, error) {
+	c := newCall(MethodPositions, exch, pair, item)
+	var positions []position.Position
+	err := w.respond(MethodPositions, exch, pair, item, &positions, true)
+	w.record(c, err)
+	if err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// ActiveOrders returns the next scenario open orders
+func (w *Wrapper) ActiveOrders(exch string, request *order.GetOrdersRequest) ([]order.Detail, error) {
+	return w.orders(MethodActiveOrders, exch, request)
+}
+
+// OrderHistory returns the next scenario past orders
+func (w *Wrapper) OrderHistory(exch string, request *order.GetOrdersRequest) ([]order.Detail, error) {
+	return w.orders(MethodOrderHistory, exch, request)
+}
+
+// orders responds to an order list request, requests for a single pair match
+// responses for that pair
+func (w *Wrapper) orders(method, exch string, request *order.GetOrdersRequest) ([]order.Detail, error) {
+	var pair currency.Pair
+	if len(request.Pairs) == 1 {
+		pair = request.Pairs[0]
+	}
+	c := newCall(method, exch, pair, request.AssetType)
+	c.Args = map[string]interface{}{
+		"side":  request.Side.String(),
+		"type":  request.Type.String(),
+		"pairs": request.Pairs.Join(),
+	}
+	if !request.StartTime.IsZero() {
+		c.Args["start"] = request.StartTime.UTC().Format(time.RFC3339)
+	}
+	if !request.EndTime.IsZero() {
+		c.Args["end"] = request.EndTime.UTC().Format(time.RFC3339)
+	}
+	var orders []order.Detail
+	err := w.respond(method, exch, pair, request.AssetType, &orders, true)
+	w.record(c, err)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// RecentTrades returns the next scenario trades
+func (w *Wrapper) RecentTrades(exch string, pair currency.Pair, item asset.Item) ([]trade.Data, error) {
+	c := newCall(MethodRecentTrades, exch, pair, item)
+	return w.trades(c, MethodRecentTrades, exch, pair, item)
+}
+
+// trades decodes the next trades response, filling in the trade exchange,
+// pair and asset when unset
+func (w *Wrapper) trades(c *Call, method, exch string, pair currency.Pair, item asset.Item) ([]trade.Data, error) {
+	var trades []trade.Data
+	err := w.respond(method, exch, pair, item, &trades, true)
+	w.record(c, err)
+	if err != nil {
+		return nil, err
+	}
+	for i := range trades {
+		if trades[i].Exchange == "" {
+			trades[i].Exchange = exch
+		}
+		if trades[i].CurrencyPair.IsEmpty() {
+			trades[i].CurrencyPair = pair
+		}
+		if trades[i].AssetType == "" {
+			trades[i].AssetType = item
+		}
+	}
+	return trades, nil
+}
+
+// ModifyOrder returns the next scenario order ID, modifications are checked
+// against the script permissions as they are live
+func (w *Wrapper) ModifyOrder(ctx context.Context, modify *order.Modify) (string, error) {
+	c := newCall(MethodModifyOrder, modify.Exchange, modify.Pair, modify.AssetType)
+	c.Args = map[string]interface{}{
+		"order_id": modify.ID,
+		"price":    modify.Price,
+		"amount":   modify.Amount,
+	}
+	err := permission.FromContext(ctx).CheckModify(modify, func() (*order.Detail, error) {
+		d := &order.Detail{Exchange: modify.Exchange, ID: modify.ID, Pair: modify.Pair, AssetType: modify.AssetType}
+		return d, w.respond(MethodQueryOrder, modify.Exchange, modify.Pair, modify.AssetType, d, false)
+	}, func() (float64, error) {
+		var tx ticker.Price
+		errTicker := w.respond(MethodTicker, modify.Exchange, modify.Pair, modify.AssetType, &tx, false)
+		return tx.Last, errTicker
+	})
+	if err != nil {
+		w.record(c, err)
+		return "", err
+	}
+	id := modify.ID
+	err = w.respond(MethodModifyOrder, modify.Exchange, modify.Pair, modify.AssetType, &id, true)
+	w.record(c, err)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// TransferAsset returns the next scenario transfer ID
+func (w *Wrapper) TransferAsset(ctx context.Context, exch string, from, to asset.Item, currencyCode currency.Code, amount float64) (string, error) {
+	c := newCall(MethodTransferAsset, exch, currency.Pair{}, "")
+	c.Args = map[string]interface{}{
+		"from":     from.String(),
+		"to":       to.String(),
+		"currency": currencyCode.String(),
+		"amount":   amount,
+	}
+	err := permission.FromContext(ctx).CheckTransfer(exch)
+	if err != nil {
+		w.record(c, err)
+		return "", err
+	}
+	var id string
+	err = w.respond(MethodTransferAsset, exch, currency.Pair{}, "", &id, true)
+	w.record(c, err)
+	return id, err
+}
+
+// ExecutionLimits returns the next scenario execution limits
+func (w *Wrapper) ExecutionLimits(exch string, pair currency.Pair, item asset.Item) (order.MinMaxLevel, error) {
+	c := newCall(MethodExecutionLimits, exch, pair, item)
+	var l order.MinMaxLevel
+	err := w.respond(MethodExecutionLimits, exch, pair, item, &l, true)
+	w.record(c, err)
+	if err != nil {
+		return order.MinMaxLevel{}, err
+	}
+	l.Pair = pair
+	l.Asset = item
+	return l, nil
+}
+
+// Fee returns the next scenario fee
+func (w *Wrapper) Fee(exch string, request *modules.FeeRequest) (float64, error) {
+	c := newCall(MethodFee, exch, request.Pair, "")
+	c.Args = map[string]interface{}{
+		"type":     request.Type,
+		"is_maker": request.IsMaker,
+		"price":    request.Price,
+		"amount":   request.Amount,
+	}
+	var fee float64
+	err := w.respond(MethodFee, exch, request.Pair, "", &fee, true)
+	w.record(c, err)
+	return fee, err
+}
+
+// StoredOHLCV returns the next scenario database candles
+func (w *Wrapper) StoredOHLCV(exch string, pair currency.Pair, item asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error) {
+	c := newCall(MethodStoredOHLCV, exch, pair, item)
+	c.Args = map[string]interface{}{
+		"start":    start.UTC().Format(time.RFC3339),
+		"end":      end.UTC().Format(time.RFC3339),
+		"interval": interval.Short(),
+	}
+	k := kline.Item{Exchange: exch, Pair: pair, Asset: item, Interval: interval}
+	err := w.respond(MethodStoredOHLCV, exch, pair, item, &k, true)
+	w.record(c, err)
+	if err != nil {
+		return kline.Item{}, err
+	}
+	return k, nil
+}
+
+// StoredTrades returns the next scenario database trades
+func (w *Wrapper) StoredTrades(exch string, pair currency.Pair, item asset.Item, start, end time.Time) ([]trade.Data, error) {
+	c := newCall(MethodStoredTrades, exch, pair, item)
+	c.Args = map[string]interface{}{
+		"start": start.UTC().Format(time.RFC3339),
+		"end":   end.UTC().Format(time.RFC3339),
+	}
+	return w.trades(c, MethodStoredTrades, exch, pair, item)
+}
+
+// GetState returns a value from the in memory script state
+func (w *Wrapper) GetState(_, key string) (value string, found bool, err error) {
+	w.record(&Call{Method: MethodGetState, Args: map[string]interface{}{"key": key}}, nil)
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	value, found = w.state[key]
+	return value, found, nil
+}
+
+// SetState stores a value in the in memory script state
+func (w *Wrapper) SetState(_, key, value string) error {
+	w.record(&Call{Method: MethodSetState, Args: map[string]interface{}{"key": key, "value": value}}, nil)
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.state[key] = value
+	return nil
+}
+
+// DeleteState removes a value from the in memory script state
+func (w *Wrapper) DeleteState(_, key string) error {
+	w.record(&Call{Method: MethodDeleteState, Args: map[string]interface{}{"key": key}}, nil)
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	delete(w.state, key)
+	return nil
+}
+
+// ListState returns a copy of the in memory script state
+func (w *Wrapper) ListState(string) (map[string]string, error) {
+	w.record(&Call{Method: MethodListState}, nil)
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	state := make(map[string]string, len(w.state))
+	for k, v := range w.state {
+		state[k] = v
+	}
+	return state, nil
+}
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"context"
 	"math/rand"
 	"time"
 
@@ -10,7 +11,10 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/position"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
@@ -146,7 +150,7 @@ func (w Wrapper) QueryOrder(exch, _ string, _ currency.Pair, _ asset.Item) (*ord
 }
 
 // SubmitOrder validator for test execution/scripts
-func (w Wrapper) SubmitOrder(o *order.Submit) (*order.SubmitResponse, error) {
+func (w Wrapper) SubmitOrder(_ context.Context, o *order.Submit) (*order.SubmitResponse, error) {
 	if o == nil {
 		return nil, errTestFailed
 	}
@@ -167,7 +171,7 @@ func (w Wrapper) SubmitOrder(o *order.Submit) (*order.SubmitResponse, error) {
 }
 
 // CancelOrder validator for test execution/scripts
-func (w Wrapper) CancelOrder(exch, orderid string, cp currency.Pair, a asset.Item) (bool, error) {
+func (w Wrapper) CancelOrder(_ context.Context, exch, orderid string, cp currency.Pair, a asset.Item) (bool, error) {
 	if exch == exchError.String() {
 		return false, errTestFailed
 	}
@@ -224,7 +228,7 @@ func (w Wrapper) DepositAddress(exch string, _ currency.Code) (string, error) {
 }
 
 // WithdrawalCryptoFunds validator for test execution/scripts
-func (w Wrapper) WithdrawalCryptoFunds(r *withdraw.Request) (out string, err error) {
+func (w Wrapper) WithdrawalCryptoFunds(_ context.Context, r *withdraw.Request) (out string, err error) {
 	if r.Exchange == exchError.String() {
 		return r.Exchange, errTestFailed
 	}
@@ -233,7 +237,7 @@ func (w Wrapper) WithdrawalCryptoFunds(r *withdraw.Request) (out string, err err
 }
 
 // WithdrawalFiatFunds validator for test execution/scripts
-func (w Wrapper) WithdrawalFiatFunds(_ string, r *withdraw.Request) (out string, err error) {
+func (w Wrapper) WithdrawalFiatFunds(_ context.Context, _ string, r *withdraw.Request) (out string, err error) {
 	if r.Exchange == exchError.String() {
 		return r.Exchange, errTestFailed
 	}
@@ -278,3 +282,154 @@ func (w Wrapper) OHLCV(exch string, p currency.Pair, a asset.Item, start, end ti
 		Candles:  candles,
 	}, nil
 }
+
+// Positions validator for test execution/scripts
+func (w Wrapper) Positions(exch string, _ asset.Item, pair currency.Pair) ([]position.Position, error) {
+	if exch == exchError.String() {
+		return nil, errTestFailed
+	}
+	if pair.IsEmpty() {
+		pair = currency.NewPair(currency.BTC, currency.USD)
+	}
+	return []position.Position{
+		{
+			FutureSymbol:     pair.String(),
+			Qty:              1,
+			EntryPrice:       validatorOpen,
+			MarkPrice:        validatorClose,
+			Leverage:         1,
+			MaxQty:           10,
+			Side:             position.PositionSideLong,
+			LiquidationPrice: validatorLow,
+			UnrealisedPnl:    validatorClose - validatorOpen,
+		},
+	}, nil
+}
+
+// ActiveOrders validator for test execution/scripts
+func (w Wrapper) ActiveOrders(exch string, r *order.GetOrdersRequest) ([]order.Detail, error) {
+	if exch == exchError.String() || r == nil {
+		return nil, errTestFailed
+	}
+	o, err := w.QueryOrder(exch, "", currency.Pair{}, r.AssetType)
+	if err != nil {
+		return nil, err
+	}
+	o.Status = order.Active
+	return []order.Detail{*o}, nil
+}
+
+// OrderHistory validator for test execution/scripts
+func (w Wrapper) OrderHistory(exch string, r *order.GetOrdersRequest) ([]order.Detail, error) {
+	if exch == exchError.String() || r == nil {
+		return nil, errTestFailed
+	}
+	o, err := w.QueryOrder(exch, "", currency.Pair{}, r.AssetType)
+	if err != nil {
+		return nil, err
+	}
+	return []order.Detail{*o}, nil
+}
+
+// RecentTrades validator for test execution/scripts
+func (w Wrapper) RecentTrades(exch string, pair currency.Pair, item asset.Item) ([]trade.Data, error) {
+	if exch == exchError.String() {
+		return nil, errTestFailed
+	}
+	return []trade.Data{
+		{
+			TID:          "1",
+			Exchange:     exch,
+			CurrencyPair: pair,
+			AssetType:    item,
+			Side:         order.Buy,
+			Price:        validatorClose,
+			Amount:       validatorVol,
+			Timestamp:    time.Now(),
+		},
+	}, nil
+}
+
+// ModifyOrder validator for test execution/scripts
+func (w Wrapper) ModifyOrder(_ context.Context, m *order.Modify) (string, error) {
+	if m == nil || m.Exchange == exchError.String() {
+		return "", errTestFailed
+	}
+	return m.ID, nil
+}
+
+// TransferAsset validator for test execution/scripts
+func (w Wrapper) TransferAsset(_ context.Context, exch string, _, _ asset.Item, _ currency.Code, _ float64) (string, error) {
+	if exch == exchError.String() {
+		return "", errTestFailed
+	}
+	return "123", nil
+}
+
+// ExecutionLimits validator for test execution/scripts
+func (w Wrapper) ExecutionLimits(exch string, pair currency.Pair, item asset.Item) (order.MinMaxLevel, error) {
+	if exch == exchError.String() {
+		return order.MinMaxLevel{}, errTestFailed
+	}
+	return order.MinMaxLevel{
+		Pair:        pair,
+		Asset:       item,
+		MinPrice:    1,
+		MaxPrice:    1000000,
+		StepPrice:   0.01,
+		MinAmount:   0.001,
+		MaxAmount:   1000,
+		StepAmount:  0.001,
+		MinNotional: 10,
+	}, nil
+}
+
+// Fee validator for test execution/scripts
+func (w Wrapper) Fee(exch string, r *modules.FeeRequest) (float64, error) {
+	if exch == exchError.String() || r == nil {
+		return 0, errTestFailed
+	}
+	return 0.001 * r.Price * r.Amount, nil
+}
+
+// StoredOHLCV validator for test execution/scripts
+func (w Wrapper) StoredOHLCV(exch string, p currency.Pair, a asset.Item, start, end time.Time, i kline.Interval) (kline.Item, error) {
+	return w.OHLCV(exch, p, a, start, end, i)
+}
+
+// StoredTrades validator for test execution/scripts
+func (w Wrapper) StoredTrades(exch string, pair currency.Pair, item asset.Item, start, _ time.Time) ([]trade.Data, error) {
+	t, err := w.RecentTrades(exch, pair, item)
+	if err != nil {
+		return nil, err
+	}
+	t[0].Timestamp = start
+	return t, nil
+}
+
+// GetState validator wrapper for state, nothing is persisted during
+// validation so keys are never found
+func (w Wrapper) GetState(_, _ string) (value string, found bool, err error) {
+	return "", false, nil
+}
+
+// SetState validator wrapper for state, values are discarded
+func (w Wrapper) SetState(_, key, _ string) error {
+	if key == exchError.String() {
+		return errTestFailed
+	}
+	return nil
+}
+
+// DeleteState validator wrapper for state
+func (w Wrapper) DeleteState(_, key string) error {
+	if key == exchError.String() {
+		return errTestFailed
+	}
+	return nil
+}
+
+// ListState validator wrapper for state, always empty
+func (w Wrapper) ListState(_ string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
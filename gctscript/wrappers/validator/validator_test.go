@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
@@ -77,27 +79,27 @@ func TestWrapper_AccountInformation(t *testing.T) {
 func TestWrapper_CancelOrder(t *testing.T) {
 	t.Parallel()
 	cp := currency.NewPair(currency.BTC, currency.USD)
-	_, err := testWrapper.CancelOrder(exchName, orderID, cp, assetType)
+	_, err := testWrapper.CancelOrder(context.Background(), exchName, orderID, cp, assetType)
 	if err != nil {
 		t.Error(err)
 	}
 
-	_, err = testWrapper.CancelOrder(exchError.String(), orderID, cp, assetType)
+	_, err = testWrapper.CancelOrder(context.Background(), exchError.String(), orderID, cp, assetType)
 	if err == nil {
 		t.Error("expected CancelOrder to return error on invalid name")
 	}
 
-	_, err = testWrapper.CancelOrder(exchName, "", cp, assetType)
+	_, err = testWrapper.CancelOrder(context.Background(), exchName, "", cp, assetType)
 	if err == nil {
 		t.Error("expected CancelOrder to return error on invalid name")
 	}
 
-	_, err = testWrapper.CancelOrder(exchName, orderID, currency.Pair{}, assetType)
+	_, err = testWrapper.CancelOrder(context.Background(), exchName, orderID, currency.Pair{}, assetType)
 	if err != nil {
 		t.Error(err)
 	}
 
-	_, err = testWrapper.CancelOrder(exchName, orderID, cp, "")
+	_, err = testWrapper.CancelOrder(context.Background(), exchName, orderID, cp, "")
 	if err != nil {
 		t.Error(err)
 	}
@@ -181,12 +183,12 @@ func TestWrapper_SubmitOrder(t *testing.T) {
 		Exchange:     "true",
 		AssetType:    asset.Spot,
 	}
-	_, err = testWrapper.SubmitOrder(tempOrder)
+	_, err = testWrapper.SubmitOrder(context.Background(), tempOrder)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	_, err = testWrapper.SubmitOrder(nil)
+	_, err = testWrapper.SubmitOrder(context.Background(), nil)
 	if err == nil {
 		t.Fatal("expected SubmitOrder to return error with invalid name")
 	}
@@ -210,24 +212,24 @@ func TestWrapper_Ticker(t *testing.T) {
 }
 
 func TestWrapper_WithdrawalCryptoFunds(t *testing.T) {
-	_, err := testWrapper.WithdrawalCryptoFunds(&withdraw.Request{Exchange: exchError.String()})
+	_, err := testWrapper.WithdrawalCryptoFunds(context.Background(), &withdraw.Request{Exchange: exchError.String()})
 	if err == nil {
 		t.Fatal("expected WithdrawalCryptoFunds to return error with invalid name")
 	}
 
-	_, err = testWrapper.WithdrawalCryptoFunds(&withdraw.Request{Exchange: exchName})
+	_, err = testWrapper.WithdrawalCryptoFunds(context.Background(), &withdraw.Request{Exchange: exchName})
 	if err != nil {
 		t.Fatal("expected WithdrawalCryptoFunds to return error with invalid name")
 	}
 }
 
 func TestWrapper_WithdrawalFiatFunds(t *testing.T) {
-	_, err := testWrapper.WithdrawalFiatFunds("", &withdraw.Request{Exchange: exchError.String()})
+	_, err := testWrapper.WithdrawalFiatFunds(context.Background(), "", &withdraw.Request{Exchange: exchError.String()})
 	if err == nil {
 		t.Fatal("expected WithdrawalFiatFunds to return error with invalid name")
 	}
 
-	_, err = testWrapper.WithdrawalFiatFunds("", &withdraw.Request{Exchange: exchName})
+	_, err = testWrapper.WithdrawalFiatFunds(context.Background(), "", &withdraw.Request{Exchange: exchName})
 	if err != nil {
 		t.Fatal("expected WithdrawalCryptoFunds to return error with invalid name")
 	}
@@ -247,3 +249,165 @@ func TestWrapper_OHLCV(t *testing.T) {
 		t.Fatal("expected OHLCV to return error with invalid name")
 	}
 }
+
+func TestWrapper_State(t *testing.T) {
+	err := testWrapper.SetState("test.gct", "key", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testWrapper.SetState("test.gct", exchError.String(), "1")
+	if err == nil {
+		t.Fatal("expected SetState to return error with invalid key")
+	}
+	_, found, err := testWrapper.GetState("test.gct", "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		t.Error("expected validator state to not be persisted")
+	}
+	err = testWrapper.DeleteState("test.gct", exchError.String())
+	if err == nil {
+		t.Fatal("expected DeleteState to return error with invalid key")
+	}
+	list, err := testWrapper.ListState("test.gct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 0 {
+		t.Error("expected empty validator state")
+	}
+}
+
+func TestWrapper_Positions(t *testing.T) {
+	t.Parallel()
+	p, err := testWrapper.Positions(exchName, asset.Futures, currencyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != 1 || p[0].FutureSymbol != currencyPair.String() {
+		t.Fatalf("unexpected positions %+v", p)
+	}
+
+	_, err = testWrapper.Positions(exchError.String(), asset.Futures, currency.Pair{})
+	if err == nil {
+		t.Fatal("expected Positions to return error on invalid name")
+	}
+}
+
+func TestWrapper_Orders(t *testing.T) {
+	t.Parallel()
+	req := &order.GetOrdersRequest{AssetType: assetType}
+	o, err := testWrapper.ActiveOrders(exchName, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o) != 1 || o[0].Status != order.Active {
+		t.Fatalf("unexpected active orders %+v", o)
+	}
+	_, err = testWrapper.OrderHistory(exchName, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = testWrapper.ActiveOrders(exchError.String(), req)
+	if err == nil {
+		t.Fatal("expected ActiveOrders to return error on invalid name")
+	}
+	_, err = testWrapper.OrderHistory(exchName, nil)
+	if err == nil {
+		t.Fatal("expected OrderHistory to return error on nil request")
+	}
+}
+
+func TestWrapper_Trades(t *testing.T) {
+	t.Parallel()
+	_, err := testWrapper.RecentTrades(exchName, currencyPair, assetType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now().Add(-time.Hour)
+	tr, err := testWrapper.StoredTrades(exchName, currencyPair, assetType, start, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tr[0].Timestamp.Equal(start) {
+		t.Errorf("expected trade at %v received %v", start, tr[0].Timestamp)
+	}
+
+	_, err = testWrapper.RecentTrades(exchError.String(), currencyPair, assetType)
+	if err == nil {
+		t.Fatal("expected RecentTrades to return error on invalid name")
+	}
+}
+
+func TestWrapper_ModifyOrder(t *testing.T) {
+	t.Parallel()
+	id, err := testWrapper.ModifyOrder(context.Background(), &order.Modify{Exchange: exchName, ID: orderID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != orderID {
+		t.Errorf("expected %v received %v", orderID, id)
+	}
+
+	_, err = testWrapper.ModifyOrder(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected ModifyOrder to return error on nil modify")
+	}
+}
+
+func TestWrapper_TransferAsset(t *testing.T) {
+	t.Parallel()
+	_, err := testWrapper.TransferAsset(context.Background(), exchName, asset.Spot, asset.Futures, currency.BTC, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = testWrapper.TransferAsset(context.Background(), exchError.String(), asset.Spot, asset.Futures, currency.BTC, 1)
+	if err == nil {
+		t.Fatal("expected TransferAsset to return error on invalid name")
+	}
+}
+
+func TestWrapper_ExecutionLimits(t *testing.T) {
+	t.Parallel()
+	l, err := testWrapper.ExecutionLimits(exchName, currencyPair, assetType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.Pair.Equal(currencyPair) || l.Asset != assetType {
+		t.Errorf("unexpected limits %+v", l)
+	}
+
+	_, err = testWrapper.ExecutionLimits(exchError.String(), currencyPair, assetType)
+	if err == nil {
+		t.Fatal("expected ExecutionLimits to return error on invalid name")
+	}
+}
+
+func TestWrapper_Fee(t *testing.T) {
+	t.Parallel()
+	_, err := testWrapper.Fee(exchName, &modules.FeeRequest{Type: modules.FeeTypeTrade, Pair: currencyPair, Price: orderPrice, Amount: orderAmount})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = testWrapper.Fee(exchName, nil)
+	if err == nil {
+		t.Fatal("expected Fee to return error on nil request")
+	}
+}
+
+func TestWrapper_StoredOHLCV(t *testing.T) {
+	t.Parallel()
+	_, err := testWrapper.StoredOHLCV(exchName, currencyPair, assetType, time.Now().Add(-24*time.Hour), time.Now(), kline.OneDay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = testWrapper.StoredOHLCV(exchError.String(), currencyPair, assetType, time.Now().Add(-24*time.Hour), time.Now(), kline.OneDay)
+	if err == nil {
+		t.Fatal("expected StoredOHLCV to return error on invalid name")
+	}
+}
@@ -1,6 +1,8 @@
 package wrappers
 
 import (
+	"context"
+
 	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
 )
@@ -12,3 +14,12 @@ func GetWrapper() modules.GCT {
 	}
 	return modules.Wrapper
 }
+
+// GetWrapperFromContext returns the wrapper held by ctx, falling back to the
+// package wrapper
+func GetWrapperFromContext(ctx context.Context) modules.GCT {
+	if validator.IsTestExecution.Load() == true {
+		return validator.Wrapper{}
+	}
+	return modules.WrapperFromContext(ctx)
+}
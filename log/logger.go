@@ -80,3 +80,14 @@ func SetLevel(s, level string) (*Levels, error) {
 
 	return &logger.Levels, nil
 }
+
+// SetGlobalLevel sets the levels of every sublogger without changing their
+// outputs, sublogger specific levels should be applied afterwards with
+// SetLevel
+func SetGlobalLevel(level string) {
+	RWM.Lock()
+	for x := range subLoggers {
+		subLoggers[x].Levels = splitLevel(level)
+	}
+	RWM.Unlock()
+}
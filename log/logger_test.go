@@ -135,6 +135,18 @@ func TestSetLevel(t *testing.T) {
 	}
 }
 
+func TestSetGlobalLevel(t *testing.T) {
+	SetGlobalLevel("WARN")
+	defer SetGlobalLevel("INFO|WARN|DEBUG|ERROR")
+	levels, err := Level("TICKER")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if levels.Info || levels.Debug || levels.Error || !levels.Warn {
+		t.Errorf("unexpected levels %+v", levels)
+	}
+}
+
 func TestValidSubLogger(t *testing.T) {
 	b, logPtr := validSubLogger("LOG")
 
@@ -20,6 +20,7 @@ var (
 	WebsocketMgr     *subLogger
 	EventMgr         *subLogger
 	DispatchMgr      *subLogger
+	DataHistory      *subLogger
 
 	RequestSys  *subLogger
 	ExchangeSys *subLogger
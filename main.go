@@ -47,9 +47,14 @@ func main() {
 	flag.BoolVar(&settings.EnableCoinmarketcapAnalysis, "coinmarketcap", false, "overrides config and runs currency analysis")
 	flag.BoolVar(&settings.EnableEventManager, "eventmanager", true, "enables the event manager")
 	flag.BoolVar(&settings.EnableOrderManager, "ordermanager", true, "enables the order manager")
+	flag.BoolVar(&settings.EnableExchangeHealthManager, "exchangehealthmanager", true, "enables the exchange health manager which tracks exchange connectivity health")
 	flag.BoolVar(&settings.EnableDepositAddressManager, "depositaddressmanager", true, "enables the deposit address manager")
 	flag.BoolVar(&settings.EnableConnectivityMonitor, "connectivitymonitor", true, "enables the connectivity monitor")
 	flag.BoolVar(&settings.EnableDatabaseManager, "databasemanager", true, "enables database manager")
+	flag.BoolVar(&settings.EnableDataHistoryManager, "datahistorymanager", false, "enables the data history manager which backfills candle and trade data to the database")
+	flag.BoolVar(&settings.EnableBalanceSnapshotManager, "balancesnapshotmanager", false, "enables the balance snapshot manager which periodically stores account balances to the database")
+	flag.BoolVar(&settings.EnableDatabaseRetentionManager, "databaseretentionmanager", false, "enables the database retention manager which downsamples, archives or deletes old trade and candle data")
+	flag.BoolVar(&settings.EnableConfigWatcher, "configwatcher", false, "reloads the config whenever the config file changes, applying pair, logging, communications and order manager changes without a restart")
 	flag.BoolVar(&settings.EnableGCTScriptManager, "gctscriptmanager", true, "enables gctscript manager")
 	flag.DurationVar(&settings.EventManagerDelay, "eventmanagerdelay", time.Duration(0), "sets the event managers sleep delay between event checking")
 	flag.BoolVar(&settings.EnableNTPClient, "ntpclient", true, "enables the NTP client to check system clock drift")
@@ -85,6 +90,7 @@ func main() {
 	flag.BoolVar(&settings.EnableExchangeHTTPRateLimiter, "ratelimiter", true, "enables the rate limiter for HTTP requests")
 	flag.IntVar(&settings.MaxHTTPRequestJobsLimit, "requestjobslimit", int(request.DefaultMaxRequestJobs), "sets the max amount of jobs the HTTP request package stores")
 	flag.IntVar(&settings.RequestMaxRetryAttempts, "httpmaxretryattempts", request.DefaultMaxRetryAttempts, "sets the number of retry attempts after a retryable HTTP failure")
+	flag.IntVar(&settings.RequestShedThreshold, "requestshedthreshold", request.DefaultMarketDataShedThreshold, "sets the amount of HTTP requests queued on an exchange's rate limiter at which market data requests are shed, 0 disables shedding")
 	flag.DurationVar(&settings.HTTPTimeout, "httptimeout", time.Duration(0), "sets the HTTP timeout value for HTTP requests")
 	flag.StringVar(&settings.HTTPUserAgent, "httpuseragent", "", "sets the HTTP user agent")
 	flag.StringVar(&settings.HTTPProxy, "httpproxy", "", "sets the HTTP proxy server")
@@ -130,7 +136,7 @@ func main() {
 	}
 	config.Cfg = *engine.Bot.Config
 
-	gctscript.Setup()
+	gctscript.SetupWithEngine(engine.Bot)
 
 	engine.PrintSettings(&engine.Bot.Settings)
 	if err = engine.Bot.Start(); err != nil {
@@ -0,0 +1,488 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// NewRegistry returns an empty metrics registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]family)}
+}
+
+// GetDefault returns the package level registry used by the exchange,
+// request and stream packages
+func GetDefault() *Registry {
+	return defaultRegistry
+}
+
+// Counter returns a counter from the default registry
+func Counter(name, help string, labels ...string) *CounterVec {
+	return defaultRegistry.Counter(name, help, labels...)
+}
+
+// Gauge returns a gauge from the default registry
+func Gauge(name, help string, labels ...string) *GaugeVec {
+	return defaultRegistry.Gauge(name, help, labels...)
+}
+
+// Histogram returns a histogram from the default registry
+func Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return defaultRegistry.Histogram(name, help, buckets, labels...)
+}
+
+// Counter returns the counter registered under name, creating it if needed.
+// An invalid or clashing definition is logged and an unregistered counter is
+// returned so callers never need to handle an error for a constant name
+func (r *Registry) Counter(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{help: help, labels: labels, series: make(map[string]*series)}
+	existing, err := r.register(name, c)
+	if err != nil {
+		log.Errorf(log.Global, "Metrics unable to register counter %s: %v\n", name, err)
+		return c
+	}
+	return existing.(*CounterVec)
+}
+
+// Gauge returns the gauge registered under name, creating it if needed
+func (r *Registry) Gauge(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{help: help, labels: labels, series: make(map[string]*series)}
+	existing, err := r.register(name, g)
+	if err != nil {
+		log.Errorf(log.Global, "Metrics unable to register gauge %s: %v\n", name, err)
+		return g
+	}
+	return existing.(*GaugeVec)
+}
+
+// Histogram returns the histogram registered under name, creating it with the
+// supplied upper bounds if needed. DefaultBuckets are used when buckets is
+// empty
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+	h := &HistogramVec{help: help, labels: labels, buckets: b, series: make(map[string]*histogramSeries)}
+	existing, err := r.register(name, h)
+	if err != nil {
+		log.Errorf(log.Global, "Metrics unable to register histogram %s: %v\n", name, err)
+		return h
+	}
+	return existing.(*HistogramVec)
+}
+
+// GaugeFunc registers fn to produce the samples of a gauge each time the
+// registry is written, replacing any previous function under the same name
+func (r *Registry) GaugeFunc(name, help string, fn func() []Sample, labels ...string) error {
+	if err := validate(name, labels); err != nil {
+		return err
+	}
+	if fn == nil {
+		return fmt.Errorf("%s gauge function is nil", name)
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if existing, ok := r.families[name]; ok {
+		if _, ok = existing.(*GaugeFunc); !ok {
+			return fmt.Errorf("%s %w", name, errMetricTypeClash)
+		}
+	}
+	r.families[name] = &GaugeFunc{help: help, labels: labels, fn: fn}
+	return nil
+}
+
+// Unregister removes a metric family from the registry
+func (r *Registry) Unregister(name string) {
+	r.mtx.Lock()
+	delete(r.families, name)
+	r.mtx.Unlock()
+}
+
+func (r *Registry) register(name string, f family) (family, error) {
+	_, metricType, labels := f.describe()
+	if err := validate(name, labels); err != nil {
+		return nil, err
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	existing, ok := r.families[name]
+	if !ok {
+		r.families[name] = f
+		return f, nil
+	}
+	_, existingType, existingLabels := existing.describe()
+	if existingType != metricType || strings.Join(existingLabels, ",") != strings.Join(labels, ",") {
+		return nil, fmt.Errorf("%s %w", name, errMetricTypeClash)
+	}
+	if _, isFunc := existing.(*GaugeFunc); isFunc {
+		return nil, fmt.Errorf("%s %w", name, errMetricTypeClash)
+	}
+	return existing, nil
+}
+
+func validate(name string, labels []string) error {
+	if !metricNameRegex.MatchString(name) {
+		return fmt.Errorf("%q %w", name, errInvalidMetricName)
+	}
+	for i := range labels {
+		if !labelNameRegex.MatchString(labels[i]) || labels[i] == "le" {
+			return fmt.Errorf("%s label %q %w", name, labels[i], errInvalidLabelName)
+		}
+	}
+	return nil
+}
+
+// WriteTo writes every metric family in the Prometheus text exposition
+// format, families are sorted by name
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mtx.RLock()
+	names := make([]string, 0, len(r.families))
+	families := make(map[string]family, len(r.families))
+	for name, f := range r.families {
+		names = append(names, name)
+		families[name] = f
+	}
+	r.mtx.RUnlock()
+	sort.Strings(names)
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for i := range names {
+		writeFamily(cw, names[i], families[names[i]])
+	}
+	if cw.err == nil {
+		cw.err = cw.w.(*bufio.Writer).Flush()
+	}
+	return cw.n, cw.err
+}
+
+// Handler returns an HTTP handler which writes the supplied registries, the
+// default registry is used when none are supplied
+func Handler(registries ...*Registry) http.Handler {
+	if len(registries) == 0 {
+		registries = []*Registry{defaultRegistry}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		for i := range registries {
+			if _, err := registries[i].WriteTo(w); err != nil {
+				log.Errorf(log.Global, "Metrics unable to write response: %v\n", err)
+				return
+			}
+		}
+	})
+}
+
+func writeFamily(w *countingWriter, name string, f family) {
+	help, metricType, labels := f.describe()
+	samples := f.collect()
+	if len(samples) == 0 {
+		return
+	}
+	w.printf("# HELP %s %s\n", name, escapeHelp(help))
+	w.printf("# TYPE %s %s\n", name, metricType)
+	for i := range samples {
+		w.printf("%s%s%s %s\n",
+			name,
+			samples[i].suffix,
+			formatLabels(labels, samples[i].labelValues, samples[i].extraLabel, samples[i].extraValue),
+			formatValue(samples[i].value))
+	}
+}
+
+func formatLabels(names, values []string, extraName, extraValue string) string {
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := range names {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(names[i])
+		sb.WriteString(`="`)
+		sb.WriteString(escapeLabelValue(values[i]))
+		sb.WriteByte('"')
+	}
+	if extraName != "" {
+		if len(names) > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(extraName)
+		sb.WriteString(`="`)
+		sb.WriteString(extraValue)
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
+
+// seriesKey joins label values into a map key, it returns false when the
+// number of values does not match the label names
+func seriesKey(labels, values []string) (string, bool) {
+	if len(labels) != len(values) {
+		return "", false
+	}
+	return strings.Join(values, labelSeparator), true
+}
+
+// Inc increments the counter for the label values by one
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increases the counter for the label values, negative values are ignored
+// as counters cannot decrease
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	key, ok := seriesKey(c.labels, labelValues)
+	if !ok {
+		log.Errorf(log.Global, "Metrics counter %v: %v\n", c.labels, errLabelCount)
+		return
+	}
+	c.mtx.Lock()
+	s, ok := c.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		c.series[key] = s
+	}
+	s.value += v
+	c.mtx.Unlock()
+}
+
+// Value returns the current counter value for the label values
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	key, _ := seriesKey(c.labels, labelValues)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if s, ok := c.series[key]; ok {
+		return s.value
+	}
+	return 0
+}
+
+func (c *CounterVec) describe() (help, metricType string, labels []string) {
+	return c.help, counterType, c.labels
+}
+
+func (c *CounterVec) collect() []sample {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return collectSeries(c.series)
+}
+
+// Set sets the gauge for the label values
+func (g *GaugeVec) Set(v float64, labelValues ...string) {
+	g.update(labelValues, func(s *series) { s.value = v })
+}
+
+// Add adds v to the gauge for the label values, v may be negative
+func (g *GaugeVec) Add(v float64, labelValues ...string) {
+	g.update(labelValues, func(s *series) { s.value += v })
+}
+
+// Inc increments the gauge for the label values by one
+func (g *GaugeVec) Inc(labelValues ...string) {
+	g.Add(1, labelValues...)
+}
+
+// Dec decrements the gauge for the label values by one
+func (g *GaugeVec) Dec(labelValues ...string) {
+	g.Add(-1, labelValues...)
+}
+
+// Delete removes the series for the label values
+func (g *GaugeVec) Delete(labelValues ...string) {
+	key, ok := seriesKey(g.labels, labelValues)
+	if !ok {
+		return
+	}
+	g.mtx.Lock()
+	delete(g.series, key)
+	g.mtx.Unlock()
+}
+
+// Value returns the current gauge value for the label values
+func (g *GaugeVec) Value(labelValues ...string) float64 {
+	key, _ := seriesKey(g.labels, labelValues)
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if s, ok := g.series[key]; ok {
+		return s.value
+	}
+	return 0
+}
+
+func (g *GaugeVec) update(labelValues []string, fn func(*series)) {
+	key, ok := seriesKey(g.labels, labelValues)
+	if !ok {
+		log.Errorf(log.Global, "Metrics gauge %v: %v\n", g.labels, errLabelCount)
+		return
+	}
+	g.mtx.Lock()
+	s, ok := g.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		g.series[key] = s
+	}
+	fn(s)
+	g.mtx.Unlock()
+}
+
+func (g *GaugeVec) describe() (help, metricType string, labels []string) {
+	return g.help, gaugeType, g.labels
+}
+
+func (g *GaugeVec) collect() []sample {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return collectSeries(g.series)
+}
+
+// Observe records a value for the label values
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	key, ok := seriesKey(h.labels, labelValues)
+	if !ok {
+		log.Errorf(log.Global, "Metrics histogram %v: %v\n", h.labels, errLabelCount)
+		return
+	}
+	h.mtx.Lock()
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+	for i := range h.buckets {
+		if v <= h.buckets[i] {
+			s.counts[i]++
+		}
+	}
+	s.sum += v
+	s.count++
+	h.mtx.Unlock()
+}
+
+// Count returns the number of observations for the label values
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	key, _ := seriesKey(h.labels, labelValues)
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if s, ok := h.series[key]; ok {
+		return s.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) describe() (help, metricType string, labels []string) {
+	return h.help, histogramType, h.labels
+}
+
+func (h *HistogramVec) collect() []sample {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var resp []sample
+	for _, k := range keys {
+		s := h.series[k]
+		for i := range h.buckets {
+			resp = append(resp, sample{
+				suffix:      "_bucket",
+				labelValues: s.labelValues,
+				extraLabel:  "le",
+				extraValue:  formatValue(h.buckets[i]),
+				value:       float64(s.counts[i]),
+			})
+		}
+		resp = append(resp,
+			sample{suffix: "_bucket", labelValues: s.labelValues, extraLabel: "le", extraValue: "+Inf", value: float64(s.count)},
+			sample{suffix: "_sum", labelValues: s.labelValues, value: s.sum},
+			sample{suffix: "_count", labelValues: s.labelValues, value: float64(s.count)})
+	}
+	return resp
+}
+
+func (g *GaugeFunc) describe() (help, metricType string, labels []string) {
+	return g.help, gaugeType, g.labels
+}
+
+func (g *GaugeFunc) collect() []sample {
+	samples := g.fn()
+	resp := make([]sample, 0, len(samples))
+	for i := range samples {
+		if len(samples[i].LabelValues) != len(g.labels) {
+			continue
+		}
+		resp = append(resp, sample{labelValues: samples[i].LabelValues, value: samples[i].Value})
+	}
+	sort.SliceStable(resp, func(i, j int) bool {
+		return strings.Join(resp[i].labelValues, labelSeparator) < strings.Join(resp[j].labelValues, labelSeparator)
+	})
+	return resp
+}
+
+func collectSeries(m map[string]*series) []sample {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	resp := make([]sample, len(keys))
+	for i := range keys {
+		resp[i] = sample{labelValues: m[keys[i]].labelValues, value: m[keys[i]].value}
+	}
+	return resp
+}
+
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) printf(format string, args ...interface{}) {
+	if c.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(c.w, format, args...)
+	c.n += int64(n)
+	c.err = err
+}
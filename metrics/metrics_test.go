@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	c := r.Counter("test_total", "test counter", "exchange")
+	c.Inc("binance")
+	c.Add(2, "binance")
+	c.Add(-1, "binance")
+	c.Inc("a", "b")
+	if v := c.Value("binance"); v != 3 {
+		t.Errorf("received %v expected 3", v)
+	}
+	if r.Counter("test_total", "test counter", "exchange") != c {
+		t.Error("expected existing counter to be returned")
+	}
+	if r.Gauge("test_total", "clash", "exchange") == nil {
+		t.Error("expected unregistered gauge on clash")
+	}
+	if _, ok := r.families["test_total"].(*CounterVec); !ok {
+		t.Error("expected clash to leave counter registered")
+	}
+}
+
+func TestGauge(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	g := r.Gauge("test_gauge", "test gauge", "exchange")
+	g.Set(5, "bitstamp")
+	g.Inc("bitstamp")
+	g.Dec("bitstamp")
+	g.Dec("bitstamp")
+	if v := g.Value("bitstamp"); v != 4 {
+		t.Errorf("received %v expected 4", v)
+	}
+	g.Delete("bitstamp")
+	if v := g.Value("bitstamp"); v != 0 {
+		t.Errorf("received %v expected 0", v)
+	}
+}
+
+func TestGaugeFunc(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	err := r.GaugeFunc("1bad", "", func() []Sample { return nil })
+	if !errors.Is(err, errInvalidMetricName) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidMetricName)
+	}
+	err = r.GaugeFunc("test_func", "", func() []Sample { return nil }, "le")
+	if !errors.Is(err, errInvalidLabelName) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidLabelName)
+	}
+	r.Counter("test_counter", "")
+	err = r.GaugeFunc("test_counter", "", func() []Sample { return nil })
+	if !errors.Is(err, errMetricTypeClash) {
+		t.Errorf("received '%v' expected '%v'", err, errMetricTypeClash)
+	}
+	err = r.GaugeFunc("test_func", "scraped", func() []Sample {
+		return []Sample{{LabelValues: []string{"b"}, Value: 2}, {LabelValues: []string{"a"}, Value: 1}, {Value: 3}}
+	}, "pair")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err = r.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := "# HELP test_func scraped\n# TYPE test_func gauge\ntest_func{pair=\"a\"} 1\ntest_func{pair=\"b\"} 2\n"
+	if buf.String() != expected {
+		t.Errorf("received %q expected %q", buf.String(), expected)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	h := r.Histogram("test_seconds", "latency", []float64{1, 0.1}, "exchange")
+	h.Observe(0.05, "ex")
+	h.Observe(0.5, "ex")
+	h.Observe(2, "ex")
+	if c := h.Count("ex"); c != 3 {
+		t.Errorf("received %v expected 3", c)
+	}
+	var buf bytes.Buffer
+	if _, err := r.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`test_seconds_bucket{exchange="ex",le="0.1"} 1`,
+		`test_seconds_bucket{exchange="ex",le="1"} 2`,
+		`test_seconds_bucket{exchange="ex",le="+Inf"} 3`,
+		`test_seconds_sum{exchange="ex"} 2.55`,
+		`test_seconds_count{exchange="ex"} 3`,
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("expected output to contain %q, received %q", line, buf.String())
+		}
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	r.Gauge("b_gauge", "line\nbreak").Set(1)
+	r.Counter("a_total", "escaped", "name").Inc("quote\"slash\\")
+	r.Counter("empty_total", "not written")
+	var buf bytes.Buffer
+	n, err := r.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("received %v bytes written expected %v", n, buf.Len())
+	}
+	expected := "# HELP a_total escaped\n# TYPE a_total counter\na_total{name=\"quote\\\"slash\\\\\"} 1\n" +
+		"# HELP b_gauge line\\nbreak\n# TYPE b_gauge gauge\nb_gauge 1\n"
+	if buf.String() != expected {
+		t.Errorf("received %q expected %q", buf.String(), expected)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+	a, b := NewRegistry(), NewRegistry()
+	a.Counter("a_total", "a").Inc()
+	b.Counter("b_total", "b").Inc()
+	rec := httptest.NewRecorder()
+	Handler(a, b).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("received %v expected %v", ct, ContentType)
+	}
+	if !strings.Contains(rec.Body.String(), "a_total 1\n") || !strings.Contains(rec.Body.String(), "b_total 1\n") {
+		t.Errorf("expected both registries to be written, received %q", rec.Body.String())
+	}
+}
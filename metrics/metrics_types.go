@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"errors"
+	"regexp"
+	"sync"
+)
+
+const (
+	// ContentType is the Prometheus text exposition format content type
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+
+	// labelSeparator joins label values into a series key, it cannot appear
+	// in valid UTF-8 label values
+	labelSeparator = "\xff"
+)
+
+var (
+	// DefaultBuckets are the histogram buckets used for request latencies in
+	// seconds
+	DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+	errInvalidMetricName = errors.New("invalid metric name")
+	errInvalidLabelName  = errors.New("invalid label name")
+	errMetricTypeClash   = errors.New("metric already registered with a different type or labels")
+	errLabelCount        = errors.New("label value count does not match label names")
+
+	metricNameRegex = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRegex  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	defaultRegistry = NewRegistry()
+)
+
+// Registry holds a set of metric families which are written out together in
+// the Prometheus text format
+type Registry struct {
+	mtx      sync.RWMutex
+	families map[string]family
+}
+
+// family is a named metric which can write its series
+type family interface {
+	describe() (help, metricType string, labels []string)
+	collect() []sample
+}
+
+type sample struct {
+	suffix      string
+	labelValues []string
+	extraLabel  string
+	extraValue  string
+	value       float64
+}
+
+// Sample is a single labelled value returned by a GaugeFunc at scrape time
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+// CounterVec is a monotonically increasing value partitioned by labels
+type CounterVec struct {
+	help   string
+	labels []string
+	mtx    sync.Mutex
+	series map[string]*series
+}
+
+// GaugeVec is a value which can go up and down partitioned by labels
+type GaugeVec struct {
+	help   string
+	labels []string
+	mtx    sync.Mutex
+	series map[string]*series
+}
+
+// HistogramVec counts observations into cumulative buckets partitioned by
+// labels
+type HistogramVec struct {
+	help    string
+	labels  []string
+	buckets []float64
+	mtx     sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+// GaugeFunc is a gauge whose samples are produced when the registry is
+// written, for values which are cheaper to read than to track
+type GaugeFunc struct {
+	help   string
+	labels []string
+	fn     func() []Sample
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	sum         float64
+	count       uint64
+}